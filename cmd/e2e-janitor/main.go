@@ -0,0 +1,100 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// e2e-janitor deletes the resources leaked by the e2e tests (see
+// e2e/janitor):
+//
+//	$ go run ./cmd/e2e-janitor -project my-project -ttl 6h -dryRun
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/e2e/janitor"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/klog/v2"
+)
+
+var flags = struct {
+	project        string
+	resourcePrefix string
+	ttl            time.Duration
+	regions        string
+	dryRun         bool
+}{
+	resourcePrefix: "k8scp-",
+	ttl:            6 * time.Hour,
+	regions:        "us-central1",
+}
+
+func init() {
+	klog.InitFlags(flag.CommandLine)
+
+	flag.StringVar(&flags.project, "project", flags.project, "GCP project ID")
+	flag.StringVar(&flags.resourcePrefix, "resourcePrefix", flags.resourcePrefix, "Prefix of the resources created by the e2e tests (-resourcePrefix of the tests).")
+	flag.DurationVar(&flags.ttl, "ttl", flags.ttl, "Delete the resources older than this.")
+	flag.StringVar(&flags.regions, "regions", flags.regions, "Comma separated list of regions to sweep.")
+	flag.BoolVar(&flags.dryRun, "dryRun", flags.dryRun, "Only print the resources that would be deleted.")
+}
+
+func main() {
+	flag.Parse()
+
+	if flags.project == "" {
+		fmt.Fprintln(os.Stderr, "-project must be set")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, compute.ComputeScope)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	svc, err := cloud.NewService(ctx, client, &cloud.SingleProjectRouter{ID: flags.project}, &cloud.NopRateLimiter{})
+	if err != nil {
+		klog.Fatal(err)
+	}
+
+	r, err := janitor.Sweep(ctx, &janitor.Config{
+		Cloud:   cloud.NewGCE(svc),
+		Project: flags.project,
+		Prefix:  flags.resourcePrefix,
+		TTL:     flags.ttl,
+		Regions: strings.Split(flags.regions, ","),
+		DryRun:  flags.dryRun,
+	})
+	if r != nil {
+		for _, id := range r.Deleted {
+			fmt.Println(id)
+		}
+		for _, err := range r.Errors {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	if err != nil {
+		klog.Fatal(err)
+	}
+	if len(r.Errors) > 0 {
+		os.Exit(1)
+	}
+}
@@ -120,7 +120,8 @@ func showStep(w http.ResponseWriter, cl cloud.Cloud, idx int, step *testlib.Step
 		step.SetUp(cl)
 	}
 
-	result, err := plan.Do(context.Background(), cl, step.Graph)
+	// Show the recreates in the plan rather than failing.
+	result, err := plan.Do(context.Background(), cl, step.Graph, plan.ImmutableFields(plan.ImmutableFieldsRecreate))
 	klog.Infof("plan.Do() = _, %v", err)
 
 	outln("<pre>")
@@ -29,12 +29,13 @@ func TestAddresses(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
+	tt := fw.NewTest(t)
 
 	const regionName = "us-central1"
 	addr1 := &compute.Address{
 		AddressType: "EXTERNAL",
 		Description: "k8s-cloud-provider-test",
-		Name:        resourceName("addr1"),
+		Name:        tt.Name("addr1"),
 		NetworkTier: "STANDARD",
 		Region:      regionName,
 	}
@@ -42,7 +43,10 @@ func TestAddresses(t *testing.T) {
 
 	t.Logf("addr1 = %s", fmt.Sprint(addr1))
 
-	t.Cleanup(func() { theCloud.Addresses().Delete(context.Background(), addr1Key) })
+	tt.Acquire(map[string]int{"addresses": 1})
+	tt.Register(tt.ID("addresses", addr1Key), func(ctx context.Context) error {
+		return theCloud.Addresses().Delete(ctx, addr1Key)
+	})
 
 	// Insert
 	err := theCloud.Addresses().Insert(ctx, addr1Key, addr1)
@@ -89,18 +93,22 @@ func TestGlobalAddresses(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
+	tt := fw.NewTest(t)
 
 	addr1 := &compute.Address{
 		AddressType: "EXTERNAL",
 		Description: "k8s-cloud-provider-test",
-		Name:        resourceName("addr1"),
+		Name:        tt.Name("addr1"),
 		NetworkTier: "PREMIUM",
 	}
 	addr1Key := meta.GlobalKey(addr1.Name)
 
 	t.Logf("addr1 = %s", fmt.Sprint(addr1))
 
-	t.Cleanup(func() { theCloud.GlobalAddresses().Delete(context.Background(), addr1Key) })
+	tt.Acquire(map[string]int{"addresses": 1})
+	tt.Register(tt.ID("addresses", addr1Key), func(ctx context.Context) error {
+		return theCloud.GlobalAddresses().Delete(ctx, addr1Key)
+	})
 
 	// Insert
 	err := theCloud.GlobalAddresses().Insert(ctx, addr1Key, addr1)
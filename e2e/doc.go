@@ -21,6 +21,34 @@ limitations under the License.
 //	$ gcloud auth application-default login
 //	$ go test ./e2e
 //
+// The tests can also be run hermetically, without credentials, against the
+// in-memory mocks or a fake GCE API server backed by the mocks:
+//
+//	$ go test ./e2e -backend=mock
+//	$ go test ./e2e -backend=fake
+//
+// Interactions can be recorded to a fixture and replayed later (e.g. in CI)
+// without credentials. Fixtures replace the project ID and the run ID used
+// in resource names; review them before checking them in:
+//
+//	$ go test ./e2e -project my-project -run TestAddresses -vcr record -cassette testdata/addresses.json
+//	$ go test ./e2e -run TestAddresses -vcr replay -cassette testdata/addresses.json
+//
+// CI replays testdata/cassette.json (make e2e-replay). It was recorded with
+// -backend=fake; record it again with -vcr record when the tests change. The
+// tests are skipped if the cassette does not exist.
+//
+// Tests create resources with names from fw.NewTest(t).Name() and register
+// them for deletion at the end of the test (see package framework).
+// The tests run in parallel. A test acquires all of the resources it creates
+// with a single tt.Acquire() so that the run stays within the -budget limits:
+//
+//	$ go test ./e2e -project my-project -parallel 8 -budget backendServices=10,forwardingRules=5
+//
+// Resources leaked by interrupted runs can be deleted with the janitor:
+//
+//	$ go run ./cmd/e2e-janitor -project my-project -ttl 6h
+//
 // Run with coverage:
 //
 //	$ go test -coverpkg ./pkg/cloud -coverprofile cov.out ./e2e ./pkg/cloud
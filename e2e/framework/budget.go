@@ -0,0 +1,200 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Budget limits the number of resources of each type that the tests of a run
+// hold at the same time, so that the tests can run with t.Parallel() without
+// exceeding the project quotas. A test acquires the resources from the
+// Budget before creating them and releases them after they are deleted (see
+// Test.Acquire).
+//
+// All of the resource types needed by a test are acquired in a single call.
+// Acquiring them one at a time could deadlock: two tests could each hold
+// one type while waiting for the type held by the other.
+//
+// Waiters are served in FIFO order: a waiter is not passed by a later
+// waiter that needs any of the same resource types, so a large request is
+// not starved by a stream of small ones.
+type Budget struct {
+	lock    sync.Mutex
+	limits  map[string]int
+	used    map[string]int
+	waiters []*waiter
+}
+
+type waiter struct {
+	want  map[string]int
+	ready chan struct{}
+}
+
+// NewBudget returns a Budget with the limits by resource type, e.g.
+// {"backendServices": 10}. Resource types that are not in limits are not
+// limited.
+func NewBudget(limits map[string]int) *Budget {
+	b := &Budget{limits: map[string]int{}, used: map[string]int{}}
+	for k, v := range limits {
+		b.limits[k] = v
+	}
+	return b
+}
+
+// Limit for the resource type. ok is false if the type is not limited.
+func (b *Budget) Limit(resource string) (limit int, ok bool) {
+	limit, ok = b.limits[resource]
+	return limit, ok
+}
+
+// Acquire the resources (number by type, e.g. {"backendServices": 1,
+// "tcpRoutes": 1}) all at once, waiting until all of them are available or
+// ctx is done. Nothing is held if an error is returned. Returns an error if
+// more than the limit of a type is requested.
+func (b *Budget) Acquire(ctx context.Context, resources map[string]int) error {
+	want := map[string]int{}
+	for resource, n := range resources {
+		limit, ok := b.limits[resource]
+		if !ok || n == 0 {
+			continue
+		}
+		if n < 0 || n > limit {
+			return fmt.Errorf("Budget: acquire %d %s: limit is %d", n, resource, limit)
+		}
+		want[resource] = n
+	}
+	if len(want) == 0 {
+		return nil
+	}
+
+	b.lock.Lock()
+	w := &waiter{want: want, ready: make(chan struct{})}
+	b.waiters = append(b.waiters, w)
+	b.notify()
+	b.lock.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	select {
+	case <-w.ready:
+		// Acquired while ctx was done; give it back.
+		b.release(want)
+	default:
+		for i := range b.waiters {
+			if b.waiters[i] == w {
+				b.waiters = append(b.waiters[:i], b.waiters[i+1:]...)
+				break
+			}
+		}
+		// Later waiters may have been blocked behind w.
+		b.notify()
+	}
+	return fmt.Errorf("Budget: acquire %s: %w", FormatBudgetLimits(want), ctx.Err())
+}
+
+// Release the resources acquired with Acquire.
+func (b *Budget) Release(resources map[string]int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.release(resources)
+}
+
+// release must be called with the lock held.
+func (b *Budget) release(resources map[string]int) {
+	for resource, n := range resources {
+		if _, ok := b.limits[resource]; !ok {
+			continue
+		}
+		b.used[resource] -= n
+		if b.used[resource] < 0 {
+			panic(fmt.Sprintf("Budget: released more %s than were acquired", resource))
+		}
+	}
+	b.notify()
+}
+
+// notify the waiters (in order) that fit in the remaining budget. A waiter
+// is skipped if an earlier waiter that needs one of the same types is still
+// waiting. Must be called with the lock held.
+func (b *Budget) notify() {
+	blocked := map[string]bool{}
+	var remaining []*waiter
+	for _, w := range b.waiters {
+		fits := true
+		for resource, n := range w.want {
+			if blocked[resource] || b.used[resource]+n > b.limits[resource] {
+				fits = false
+			}
+		}
+		if !fits {
+			for resource := range w.want {
+				blocked[resource] = true
+			}
+			remaining = append(remaining, w)
+			continue
+		}
+		for resource, n := range w.want {
+			b.used[resource] += n
+		}
+		close(w.ready)
+	}
+	b.waiters = remaining
+}
+
+// ParseBudgetLimits parses limits of the form
+// "backendServices=10,forwardingRules=5".
+func ParseBudgetLimits(s string) (map[string]int, error) {
+	ret := map[string]int{}
+	if s == "" {
+		return ret, nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		resource, value, ok := strings.Cut(item, "=")
+		if !ok || resource == "" {
+			return nil, fmt.Errorf("invalid budget limit %q (want resource=n)", item)
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid budget limit %q: value must be a positive integer", item)
+		}
+		ret[resource] = n
+	}
+	return ret, nil
+}
+
+// FormatBudgetLimits is the inverse of ParseBudgetLimits.
+func FormatBudgetLimits(limits map[string]int) string {
+	var items []string
+	for k, v := range limits {
+		items = append(items, fmt.Sprintf("%s=%d", k, v))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
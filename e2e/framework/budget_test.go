@@ -0,0 +1,167 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBudget(t *testing.T) {
+	ctx := context.Background()
+	b := NewBudget(map[string]int{"addresses": 2})
+	addresses := func(n int) map[string]int { return map[string]int{"addresses": n} }
+
+	if err := b.Acquire(ctx, map[string]int{"backendServices": 100}); err != nil {
+		t.Errorf("Acquire(unlimited) = %v, want nil", err)
+	}
+	if err := b.Acquire(ctx, addresses(3)); err == nil {
+		t.Errorf("Acquire(3) = nil, want error (over the limit)")
+	}
+	if err := b.Acquire(ctx, addresses(2)); err != nil {
+		t.Fatalf("Acquire(2) = %v, want nil", err)
+	}
+
+	// The budget is used up; the next Acquire waits.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := b.Acquire(timeoutCtx, addresses(1)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire() = %v, want DeadlineExceeded", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- b.Acquire(ctx, addresses(2)) }()
+	b.Release(addresses(1))
+	select {
+	case err := <-done:
+		t.Fatalf("Acquire(2) = %v, want blocked with 1 available", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	b.Release(addresses(1))
+	if err := <-done; err != nil {
+		t.Errorf("Acquire(2) = %v, want nil", err)
+	}
+}
+
+func TestBudgetMultipleTypes(t *testing.T) {
+	ctx := context.Background()
+	b := NewBudget(map[string]int{"backendServices": 1, "tcpRoutes": 1, "addresses": 1})
+	both := map[string]int{"backendServices": 1, "tcpRoutes": 1}
+
+	// Tests that need overlapping types do not deadlock: each one gets all
+	// of its resources or none.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+			if err := b.Acquire(ctx, both); err != nil {
+				t.Errorf("Acquire() = %v, want nil", err)
+				return
+			}
+			time.Sleep(time.Millisecond)
+			b.Release(both)
+		}()
+	}
+	wg.Wait()
+
+	// A waiter does not hold the types that are available.
+	if err := b.Acquire(ctx, map[string]int{"backendServices": 1}); err != nil {
+		t.Fatalf("Acquire(backendServices) = %v, want nil", err)
+	}
+	done := make(chan error)
+	go func() { done <- b.Acquire(ctx, both) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Acquire(both) = %v, want blocked", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	// The types that are not waited on can still be acquired.
+	if err := b.Acquire(ctx, map[string]int{"addresses": 1}); err != nil {
+		t.Errorf("Acquire(addresses) = %v, want nil", err)
+	}
+	// tcpRoutes is not acquired by the waiter and later waiters for it are
+	// served after it (FIFO).
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := b.Acquire(timeoutCtx, map[string]int{"tcpRoutes": 1}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire(tcpRoutes) = %v, want DeadlineExceeded (behind the waiter)", err)
+	}
+	b.Release(map[string]int{"backendServices": 1})
+	if err := <-done; err != nil {
+		t.Errorf("Acquire(both) = %v, want nil", err)
+	}
+}
+
+func TestTestAcquire(t *testing.T) {
+	fw := New(nil, "proj", "k8scp-", "abcd")
+	fw.Budget = NewBudget(map[string]int{"addresses": 1})
+	addresses := map[string]int{"addresses": 1}
+
+	done := make(chan error)
+	t.Run("test", func(t *testing.T) {
+		tt := fw.NewTest(t)
+		tt.Acquire(addresses)
+		go func() { done <- fw.Budget.Acquire(context.Background(), addresses) }()
+		select {
+		case err := <-done:
+			t.Fatalf("Acquire() = %v, want blocked until the test ends", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	})
+	// The teardown of the test released the budget.
+	if err := <-done; err != nil {
+		t.Errorf("Acquire() = %v, want nil", err)
+	}
+}
+
+func TestParseBudgetLimits(t *testing.T) {
+	for _, tc := range []struct {
+		s       string
+		want    map[string]int
+		wantErr bool
+	}{
+		{s: "", want: map[string]int{}},
+		{s: "addresses=2", want: map[string]int{"addresses": 2}},
+		{s: "addresses=2,backendServices=10", want: map[string]int{"addresses": 2, "backendServices": 10}},
+		{s: "addresses", wantErr: true},
+		{s: "=2", wantErr: true},
+		{s: "addresses=x", wantErr: true},
+		{s: "addresses=0", wantErr: true},
+	} {
+		got, err := ParseBudgetLimits(tc.s)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("ParseBudgetLimits(%q) = %v; gotErr = %t, want %t", tc.s, err, gotErr, tc.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("ParseBudgetLimits(%q) = %v, want %v", tc.s, got, tc.want)
+		}
+		if s := FormatBudgetLimits(got); s != tc.s {
+			t.Errorf("FormatBudgetLimits(%v) = %q, want %q", got, s, tc.s)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package framework is the harness for the e2e tests. A Framework names the
+// resources created in a test run with a run-scoped prefix and a Test
+// deletes all of the resources registered by a test when it ends:
+//
+//	func TestFoo(t *testing.T) {
+//		tt := fw.NewTest(t)
+//		key := meta.GlobalKey(tt.Name("addr1"))
+//		tt.Register(tt.ID("addresses", key), func(ctx context.Context) error {
+//			return tt.Cloud.GlobalAddresses().Delete(ctx, key)
+//		})
+//		err := tt.Cloud.GlobalAddresses().Insert(ctx, key, addr)
+//		...
+//	}
+//
+// Resources are deleted in the reverse order of registration. Register a
+// resource before the resources that reference it are created so that it is
+// deleted after them.
+//
+// Tests that run with t.Parallel() acquire the resources from the Budget of
+// the Framework with tt.Acquire(map[string]int{"addresses": 1}) before
+// creating them so that the run stays within the project quotas.
+package framework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+)
+
+// DefaultTeardownTimeout is the time allowed for deleting the resources of a
+// Test.
+const DefaultTeardownTimeout = 5 * time.Minute
+
+// DefaultAcquireTimeout is the time a Test waits for the Budget.
+const DefaultAcquireTimeout = 15 * time.Minute
+
+// Framework holds the state shared by the tests in a run.
+type Framework struct {
+	// Cloud to create the resources in.
+	Cloud cloud.Cloud
+	// Project of the resources.
+	Project string
+	// Prefix of all of the resource names in the run, e.g. "k8scp-1a2b-".
+	Prefix string
+	// TeardownTimeout is the time allowed to delete the resources of a
+	// Test. Defaults to DefaultTeardownTimeout.
+	TeardownTimeout time.Duration
+	// Budget limits the resources held by the concurrent tests. nil means
+	// no limit.
+	Budget *Budget
+	// AcquireTimeout is the time a Test waits for the Budget. Defaults to
+	// DefaultAcquireTimeout.
+	AcquireTimeout time.Duration
+}
+
+// NewRunID returns a random ID for a test run.
+func NewRunID() string {
+	return fmt.Sprintf("%0x", rand.Int63()&0xffff)
+}
+
+// New returns a Framework. Resource names are of the form
+// <basePrefix><runID>-<name>. The basePrefix identifies the resources
+// created by the e2e tests (see the janitor) and the runID distinguishes
+// concurrent runs.
+func New(c cloud.Cloud, project, basePrefix, runID string) *Framework {
+	return &Framework{
+		Cloud:           c,
+		Project:         project,
+		Prefix:          basePrefix + runID + "-",
+		TeardownTimeout: DefaultTeardownTimeout,
+		AcquireTimeout:  DefaultAcquireTimeout,
+	}
+}
+
+// Name returns the resource name for name in this run.
+func (f *Framework) Name(name string) string { return f.Prefix + name }
+
+// ParseName returns the run ID of a resource name created by a Framework with
+// basePrefix. ok is false if the name does not follow the naming convention.
+func ParseName(basePrefix, name string) (runID string, ok bool) {
+	if !strings.HasPrefix(name, basePrefix) {
+		return "", false
+	}
+	runID, _, ok = strings.Cut(name[len(basePrefix):], "-")
+	if !ok || runID == "" {
+		return "", false
+	}
+	for _, c := range runID {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return "", false
+		}
+	}
+	return runID, true
+}
+
+// ID returns the ResourceID for a resource in the Project, e.g.
+// ID("backendServices", meta.GlobalKey(f.Name("bs1"))).
+func (f *Framework) ID(resource string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		ProjectID: f.Project,
+		APIGroup:  meta.APIGroupCompute,
+		Resource:  resource,
+		Key:       key,
+	}
+}
+
+// DeleteFunc deletes a resource.
+type DeleteFunc func(ctx context.Context) error
+
+type registered struct {
+	id  *cloud.ResourceID
+	del DeleteFunc
+}
+
+// Test tracks the resources created by a test.
+type Test struct {
+	*Framework
+
+	t         testing.TB
+	lock      sync.Mutex
+	resources []registered
+	// held is the Budget acquired by the test, by resource type. nil if
+	// Acquire has not been called.
+	held map[string]int
+}
+
+// NewTest returns a Test for t. The registered resources are deleted when t
+// finishes, including when it fails or panics.
+func (f *Framework) NewTest(t testing.TB) *Test {
+	ret := &Test{Framework: f, t: t}
+	t.Cleanup(ret.teardown)
+	return ret
+}
+
+// Register a resource for deletion at the end of the test. del is called
+// even if the test has deleted the resource already; NotFound errors are
+// ignored. Register is safe to call concurrently.
+func (t *Test) Register(id *cloud.ResourceID, del DeleteFunc) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.resources = append(t.resources, registered{id: id, del: del})
+}
+
+// Acquire the resources (number by type, e.g. {"backendServices": 1}) from
+// the Budget, waiting if the concurrent tests hold too many. Call this once,
+// with all of the resources of the test, before creating them: see Budget.
+// The Budget is released after the teardown at the end of the test. Fails
+// the test if more than the limit is requested or if the Budget is not
+// available within the AcquireTimeout.
+func (t *Test) Acquire(resources map[string]int) {
+	t.t.Helper()
+
+	if t.Budget == nil {
+		return
+	}
+	t.lock.Lock()
+	acquired := t.held != nil
+	t.lock.Unlock()
+	if acquired {
+		t.t.Fatalf("Acquire(%s): the test has already acquired its resources", FormatBudgetLimits(resources))
+	}
+
+	timeout := t.AcquireTimeout
+	if timeout == 0 {
+		timeout = DefaultAcquireTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := t.Budget.Acquire(ctx, resources); err != nil {
+		t.t.Fatalf("Acquire(%s): %v", FormatBudgetLimits(resources), err)
+	}
+
+	held := map[string]int{}
+	for resource, n := range resources {
+		held[resource] = n
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.held = held
+}
+
+// teardown deletes the resources in the reverse order of registration, then
+// releases the Budget held by the test.
+func (t *Test) teardown() {
+	t.lock.Lock()
+	resources := t.resources
+	held := t.held
+	t.resources = nil
+	t.held = nil
+	t.lock.Unlock()
+
+	defer func() {
+		if held != nil {
+			t.Budget.Release(held)
+		}
+	}()
+
+	timeout := t.TeardownTimeout
+	if timeout == 0 {
+		timeout = DefaultTeardownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for i := len(resources) - 1; i >= 0; i-- {
+		r := resources[i]
+		err := r.del(ctx)
+		switch {
+		case err == nil:
+			t.t.Logf("teardown: deleted %s", r.id)
+		case IsNotFound(err):
+			t.t.Logf("teardown: %s was already deleted", r.id)
+		default:
+			t.t.Errorf("teardown: delete %s: %v (resource may be leaked)", r.id, err)
+		}
+	}
+}
+
+// IsNotFound returns true if err is a HTTP 404 error from the API.
+func IsNotFound(err error) bool {
+	var gerr *googleapi.Error
+	return errors.As(err, &gerr) && gerr.Code == http.StatusNotFound
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+)
+
+func TestTeardown(t *testing.T) {
+	fw := New(nil, "proj", "k8scp-", "abcd")
+	if got, want := fw.Name("bs1"), "k8scp-abcd-bs1"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+
+	var deleted []string
+	t.Run("test", func(t *testing.T) {
+		tt := fw.NewTest(t)
+		for _, name := range []string{"hc", "bs", "um"} {
+			name := name
+			tt.Register(tt.ID("fakes", meta.GlobalKey(tt.Name(name))), func(context.Context) error {
+				deleted = append(deleted, name)
+				if name == "bs" {
+					return &googleapi.Error{Code: http.StatusNotFound}
+				}
+				return nil
+			})
+		}
+	})
+	if got, want := deleted, []string{"um", "bs", "hc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("deleted = %v, want %v", got, want)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want bool
+	}{
+		{err: nil},
+		{err: errors.New("x")},
+		{err: &googleapi.Error{Code: http.StatusForbidden}},
+		{err: &googleapi.Error{Code: http.StatusNotFound}, want: true},
+	} {
+		if got := IsNotFound(tc.err); got != tc.want {
+			t.Errorf("IsNotFound(%v) = %t, want %t", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestParseName(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		wantRunID string
+		wantOK    bool
+	}{
+		{name: "k8scp-1a2b-bs1", wantRunID: "1a2b", wantOK: true},
+		{name: "k8scp-1a2b-bs-1", wantRunID: "1a2b", wantOK: true},
+		{name: "other-1a2b-bs1"},
+		{name: "k8scp-bs1"},
+		{name: "k8scp-xyz-bs1"},
+		{name: "k8scp--bs1"},
+	} {
+		runID, ok := ParseName("k8scp-", tc.name)
+		if runID != tc.wantRunID || ok != tc.wantOK {
+			t.Errorf("ParseName(%q) = %q, %t; want %q, %t", tc.name, runID, ok, tc.wantRunID, tc.wantOK)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package janitor deletes the resources leaked by the e2e tests, e.g. when a
+// CI run is interrupted before the teardown. Resources are matched by the
+// naming convention of package framework and by their creation time.
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/e2e/framework"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/klog/v2"
+)
+
+// Config for Sweep().
+type Config struct {
+	// Cloud to delete the resources from.
+	Cloud cloud.Cloud
+	// Project of the resources.
+	Project string
+	// Prefix is the base prefix of the e2e resource names (the
+	// -resourcePrefix of the e2e tests), e.g. "k8scp-".
+	Prefix string
+	// TTL is the minimum age of the resources to delete. Resources of
+	// running tests must not be deleted.
+	TTL time.Duration
+	// Regions to sweep for regional resources.
+	Regions []string
+	// DryRun only lists the resources that would be deleted.
+	DryRun bool
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Result of Sweep().
+type Result struct {
+	// Deleted resources (or the resources that would be deleted for
+	// DryRun).
+	Deleted []*cloud.ResourceID
+	// Errors from the deletion of resources. Sweep() continues with the
+	// rest of the resources after an error.
+	Errors []error
+}
+
+// sweeper lists and deletes one type of resource.
+type sweeper struct {
+	resource string
+	apiGroup meta.APIGroup
+	// service is the method of cloud.Cloud that returns the service, e.g.
+	// "GlobalForwardingRules".
+	service  string
+	regional bool
+}
+
+// sweepers in the order of deletion: resources are deleted before the
+// resources they reference.
+var sweepers = []sweeper{
+	{resource: "forwardingRules", service: "GlobalForwardingRules"},
+	{resource: "forwardingRules", service: "ForwardingRules", regional: true},
+	{resource: "tcpRoutes", apiGroup: meta.APIGroupNetworkServices, service: "TcpRoutes"},
+	{resource: "targetHttpProxies", service: "TargetHttpProxies"},
+	{resource: "targetHttpProxies", service: "RegionTargetHttpProxies", regional: true},
+	{resource: "targetHttpsProxies", service: "TargetHttpsProxies"},
+	{resource: "targetHttpsProxies", service: "RegionTargetHttpsProxies", regional: true},
+	{resource: "targetTcpProxies", service: "TargetTcpProxies"},
+	{resource: "urlMaps", service: "UrlMaps"},
+	{resource: "urlMaps", service: "RegionUrlMaps", regional: true},
+	{resource: "backendServices", service: "BackendServices"},
+	{resource: "backendServices", service: "RegionBackendServices", regional: true},
+	{resource: "healthChecks", service: "HealthChecks"},
+	{resource: "healthChecks", service: "RegionHealthChecks", regional: true},
+	{resource: "addresses", service: "GlobalAddresses"},
+	{resource: "addresses", service: "Addresses", regional: true},
+	{resource: "firewalls", service: "Firewalls"},
+	{resource: "subnetworks", service: "Subnetworks", regional: true},
+	{resource: "networks", service: "Networks"},
+}
+
+// object is a listed resource.
+type object struct {
+	key     *meta.Key
+	name    string
+	created string
+}
+
+// call the method of the service in c.
+func (s *sweeper) call(c cloud.Cloud, method string, args ...any) []reflect.Value {
+	svc := reflect.ValueOf(c).MethodByName(s.service).Call(nil)[0]
+	var in []reflect.Value
+	for _, a := range args {
+		in = append(in, reflect.ValueOf(a))
+	}
+	return svc.MethodByName(method).Call(in)
+}
+
+func (s *sweeper) list(ctx context.Context, c cloud.Cloud, region string) ([]object, error) {
+	var out []reflect.Value
+	if s.regional {
+		out = s.call(c, "List", ctx, region, filter.None)
+	} else {
+		out = s.call(c, "List", ctx, filter.None)
+	}
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	var ret []object
+	for i := 0; i < out[0].Len(); i++ {
+		v := out[0].Index(i).Elem()
+		// networkservices names are of the form projects/.../<name>.
+		name := v.FieldByName("Name").String()
+		o := object{name: name[strings.LastIndex(name, "/")+1:]}
+		for _, f := range []string{"CreationTimestamp", "CreateTime"} {
+			if fv := v.FieldByName(f); fv.IsValid() {
+				o.created = fv.String()
+			}
+		}
+		if s.regional {
+			o.key = meta.RegionalKey(o.name, region)
+		} else {
+			o.key = meta.GlobalKey(o.name)
+		}
+		ret = append(ret, o)
+	}
+	return ret, nil
+}
+
+func (s *sweeper) del(ctx context.Context, c cloud.Cloud, key *meta.Key) error {
+	err, _ := s.call(c, "Delete", ctx, key)[0].Interface().(error)
+	return err
+}
+
+// Sweep deletes the resources named with the e2e naming convention (see
+// framework.ParseName()) that are older than the TTL.
+func Sweep(ctx context.Context, config *Config) (*Result, error) {
+	if config.Prefix == "" {
+		return nil, fmt.Errorf("Sweep: Prefix must be set")
+	}
+	now := time.Now
+	if config.Now != nil {
+		now = config.Now
+	}
+
+	ret := &Result{}
+	for _, s := range sweepers {
+		regions := []string{""}
+		if s.regional {
+			regions = config.Regions
+		}
+		for _, region := range regions {
+			l, err := s.list(ctx, config.Cloud, region)
+			if err != nil {
+				return ret, fmt.Errorf("Sweep: list %s: %w", s.resource, err)
+			}
+			for _, o := range l {
+				if _, ok := framework.ParseName(config.Prefix, o.name); !ok {
+					continue
+				}
+				created, err := time.Parse(time.RFC3339, o.created)
+				if err != nil {
+					klog.V(2).Infof("Sweep: skipping %s %s: invalid creation time %q", s.resource, o.name, o.created)
+					continue
+				}
+				if now().Sub(created) < config.TTL {
+					continue
+				}
+				apiGroup := s.apiGroup
+				if apiGroup == "" {
+					apiGroup = meta.APIGroupCompute
+				}
+				id := &cloud.ResourceID{ProjectID: config.Project, APIGroup: apiGroup, Resource: s.resource, Key: o.key}
+				if config.DryRun {
+					klog.Infof("Sweep: would delete %s (created %s)", id, o.created)
+				} else {
+					if err := s.del(ctx, config.Cloud, o.key); err != nil && !framework.IsNotFound(err) {
+						ret.Errors = append(ret.Errors, fmt.Errorf("delete %s: %w", id, err))
+						continue
+					}
+					klog.Infof("Sweep: deleted %s (created %s)", id, o.created)
+				}
+				ret.Deleted = append(ret.Deleted, id)
+			}
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package janitor
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-3 * time.Hour).Format(time.RFC3339)
+	recent := now.Add(-time.Minute).Format(time.RFC3339)
+
+	newMock := func() *cloud.MockGCE {
+		mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+		for _, bs := range []*compute.BackendService{
+			{Name: "k8scp-1a2b-bs", CreationTimestamp: old},
+			{Name: "k8scp-3c4d-bs", CreationTimestamp: recent},
+			{Name: "prod-bs", CreationTimestamp: old},
+		} {
+			mock.BackendServices().Insert(ctx, meta.GlobalKey(bs.Name), bs)
+		}
+		fr := &compute.ForwardingRule{Name: "k8scp-1a2b-fr", CreationTimestamp: old}
+		mock.ForwardingRules().Insert(ctx, meta.RegionalKey(fr.Name, "us-central1"), fr)
+		return mock
+	}
+
+	for _, tc := range []struct {
+		name    string
+		dryRun  bool
+		wantBSs []string
+	}{
+		{name: "sweep", wantBSs: []string{"k8scp-3c4d-bs", "prod-bs"}},
+		{name: "dry run", dryRun: true, wantBSs: []string{"k8scp-1a2b-bs", "k8scp-3c4d-bs", "prod-bs"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := newMock()
+			r, err := Sweep(ctx, &Config{
+				Cloud:   mock,
+				Project: "proj",
+				Prefix:  "k8scp-",
+				TTL:     time.Hour,
+				Regions: []string{"us-central1"},
+				DryRun:  tc.dryRun,
+				Now:     func() time.Time { return now },
+			})
+			if err != nil {
+				t.Fatalf("Sweep() = %v, want nil", err)
+			}
+			var deleted []string
+			for _, id := range r.Deleted {
+				deleted = append(deleted, id.String())
+			}
+			// The forwarding rule is deleted before the backend service.
+			wantDeleted := []string{
+				"compute/forwardingRules:proj/us-central1/k8scp-1a2b-fr",
+				"compute/backendServices:proj/k8scp-1a2b-bs",
+			}
+			if !reflect.DeepEqual(deleted, wantDeleted) {
+				t.Errorf("Deleted = %v, want %v", deleted, wantDeleted)
+			}
+			bss, _ := mock.BackendServices().List(ctx, nil)
+			var got []string
+			for _, bs := range bss {
+				got = append(got, bs.Name)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tc.wantBSs) {
+				t.Errorf("BackendServices = %v, want %v", got, tc.wantBSs)
+			}
+		})
+	}
+
+	if _, err := Sweep(ctx, &Config{Cloud: newMock()}); err == nil {
+		t.Error("Sweep() without Prefix = nil, want error")
+	}
+}
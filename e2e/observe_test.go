@@ -45,6 +45,7 @@ func (o *testObserver) End(ctx context.Context, ck *cloud.CallContextKey, err er
 
 func TestObserve(t *testing.T) {
 	t.Parallel()
+	skipForBackend(t, "the mocks do not call the CallObserver", backendMock)
 
 	ctx := context.Background()
 	o := &testObserver{}
@@ -30,17 +30,18 @@ func TestTcpRoute(t *testing.T) {
 	t.Parallel()
 
 	ctx := context.Background()
+	tt := fw.NewTest(t)
 
 	bs := &compute.BackendService{
-		Name:                resourceName("bs1"),
+		Name:                tt.Name("bs1"),
 		Backends:            []*compute.Backend{},
 		LoadBalancingScheme: "INTERNAL_SELF_MANAGED",
 	}
 	bsKey := meta.GlobalKey(bs.Name)
 
-	t.Cleanup(func() {
-		err := theCloud.BackendServices().Delete(ctx, bsKey)
-		t.Logf("bs delete: %v", err)
+	tt.Acquire(map[string]int{"backendServices": 1, "tcpRoutes": 1})
+	tt.Register(tt.ID("backendServices", bsKey), func(ctx context.Context) error {
+		return theCloud.BackendServices().Delete(ctx, bsKey)
 	})
 
 	// TcpRoute needs a BackendService to point to.
@@ -51,9 +52,9 @@ func TestTcpRoute(t *testing.T) {
 	}
 
 	// Current API does not support the new URL scheme.
-	serviceName := fmt.Sprintf("https://compute.googleapis.com/v1/projects/%s/global/backendServices/%s", testFlags.project, bs.Name)
+	serviceName := fmt.Sprintf("https://compute.googleapis.com/v1/projects/%s/global/backendServices/%s", tt.Project, bs.Name)
 	tcpr := &networkservices.TcpRoute{
-		Name: resourceName("route1"),
+		Name: tt.Name("route1"),
 		Rules: []*networkservices.TcpRouteRouteRule{
 			{
 				Action: &networkservices.TcpRouteRouteAction{
@@ -68,9 +69,10 @@ func TestTcpRoute(t *testing.T) {
 	tcprKey := meta.GlobalKey(tcpr.Name)
 
 	// Insert
-	t.Cleanup(func() {
-		err := theCloud.TcpRoutes().Delete(ctx, tcprKey)
-		t.Logf("tcpRoute delete: %v", err)
+	id := tt.ID("tcpRoutes", tcprKey)
+	id.APIGroup = meta.APIGroupNetworkServices
+	tt.Register(id, func(ctx context.Context) error {
+		return theCloud.TcpRoutes().Delete(ctx, tcprKey)
 	})
 
 	err = theCloud.TcpRoutes().Insert(ctx, tcprKey, tcpr)
@@ -18,14 +18,21 @@ package e2e
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"log"
-	"math/rand"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/e2e/framework"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/fakeserver"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/vcr"
 	"golang.org/x/oauth2/google"
 	"k8s.io/klog/v2"
 
@@ -36,13 +43,24 @@ import (
 var (
 	// theCloud is a global to be used in the e2e tests.
 	theCloud cloud.Cloud
+	// fw is the e2e harness. Use fw.NewTest(t) in the tests to name and
+	// clean up the resources.
+	fw *framework.Framework
 	// testFlags passed in from the command line.
 	testFlags = struct {
 		project        string
 		resourcePrefix string
+		backend        string
+		fake           bool
+		vcr            string
+		cassette       string
+		budget         string
 	}{
 		project:        "",
 		resourcePrefix: "k8scp-",
+		backend:        backendGCE,
+		cassette:       "testdata/cassette.json",
+		budget:         "addresses=4,backendServices=4,tcpRoutes=4",
 	}
 	runID string
 )
@@ -52,38 +70,187 @@ func init() {
 
 	flag.StringVar(&testFlags.project, "project", testFlags.project, "GCP project ID")
 	flag.StringVar(&testFlags.resourcePrefix, "resourcePrefix", testFlags.resourcePrefix, "Prefix used to name all resources created in the tests. Any resources with this prefix will be removed during cleanup.")
+	flag.StringVar(&testFlags.backend, "backend", testFlags.backend, `Backend to run the tests against: "gce" (GCP), "mock" (the in-memory mocks) or "fake" (a fake GCE API server backed by the mocks).`)
+	flag.BoolVar(&testFlags.fake, "fake", testFlags.fake, `Same as -backend=fake.`)
+	flag.StringVar(&testFlags.vcr, "vcr", testFlags.vcr, `Record the API interactions to -cassette ("record") or replay them without accessing GCP ("replay").`)
+	flag.StringVar(&testFlags.cassette, "cassette", testFlags.cassette, "Fixture file used by -vcr.")
+	flag.StringVar(&testFlags.budget, "budget", testFlags.budget, `Maximum number of resources of each type held by the parallel tests, e.g. "backendServices=10,forwardingRules=5". Types that are not listed are not limited.`)
 
-	runID = fmt.Sprintf("%0x", rand.Int63()&0xffff)
+	runID = framework.NewRunID()
 }
 
 func parseFlagsOrDie() {
 	flag.Parse()
 
+	if testFlags.fake {
+		testFlags.backend = backendFake
+	}
+	switch testFlags.backend {
+	case backendGCE, backendMock, backendFake:
+	default:
+		fmt.Printf("invalid -backend %q\n", testFlags.backend)
+		os.Exit(1)
+	}
+	if testFlags.vcr != "" {
+		if testFlags.backend == backendMock {
+			fmt.Println("-vcr cannot be used with -backend=mock")
+			os.Exit(1)
+		}
+		if _, err := vcr.ParseMode(testFlags.vcr); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+	if testFlags.backend != backendGCE && testFlags.project == "" {
+		testFlags.project = "fake-project"
+	}
+	if testFlags.vcr == vcr.Replay.String() {
+		if _, err := os.Stat(testFlags.cassette); errors.Is(err, fs.ErrNotExist) {
+			fmt.Printf("skipping the tests: -cassette %q does not exist, record it with -vcr=record\n", testFlags.cassette)
+			os.Exit(0)
+		}
+		// Use the values substituted during recording.
+		testFlags.project = vcrProject
+		runID = vcrRunID
+	}
 	if testFlags.project == "" {
 		fmt.Println("-project must be set")
 		os.Exit(1)
 	}
+	if _, err := framework.ParseBudgetLimits(testFlags.budget); err != nil {
+		fmt.Printf("invalid -budget: %v\n", err)
+		os.Exit(1)
+	}
 }
 
-func resourceName(name string) string {
-	return testFlags.resourcePrefix + runID + "-" + name
+// Values of -backend.
+const (
+	backendGCE  = "gce"
+	backendMock = "mock"
+	backendFake = "fake"
+)
+
+// skipForBackend skips the test when it is run against one of the backends,
+// e.g. if the mocks do not implement the behavior under test.
+func skipForBackend(t *testing.T, reason string, backends ...string) {
+	t.Helper()
+	for _, b := range backends {
+		if testFlags.backend == b {
+			t.Skipf("skipped for -backend=%s: %s", b, reason)
+		}
+	}
 }
 
+const (
+	// vcrProject and vcrRunID replace the project ID and run ID in the
+	// recorded fixtures.
+	vcrProject = "vcr-project"
+	vcrRunID   = "vcr0"
+)
+
 func TestMain(m *testing.M) {
 	parseFlagsOrDie()
 
 	ctx := context.Background()
-	client, err := google.DefaultClient(ctx, compute.ComputeScope)
+	c, cleanup, err := newCloud(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
+	theCloud = c
+	fw = framework.New(theCloud, testFlags.project, testFlags.resourcePrefix, runID)
+	limits, _ := framework.ParseBudgetLimits(testFlags.budget)
+	fw.Budget = framework.NewBudget(limits)
+
+	code := m.Run()
+	if err := cleanup(); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(code)
+}
+
+// newCloud returns the Cloud for -backend (and -vcr). cleanup must be called
+// after the tests have finished.
+func newCloud(ctx context.Context) (_ cloud.Cloud, cleanup func() error, _ error) {
+	if testFlags.backend == backendMock {
+		return newFakeMock(), func() error { return nil }, nil
+	}
+
+	var (
+		client *http.Client
+		srv    *fakeserver.Server
+		rec    *vcr.Recorder
+		err    error
+	)
+	switch {
+	case testFlags.vcr == vcr.Replay.String():
+		rec, err = vcr.New(&vcr.Config{Path: testFlags.cassette, Mode: vcr.Replay})
+		if err != nil {
+			return nil, nil, err
+		}
+		client = rec.Client()
+	case testFlags.backend == backendFake:
+		srv = fakeserver.New(newFakeMock())
+		client = srv.Client()
+	default:
+		client, err = google.DefaultClient(ctx, compute.ComputeScope)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if testFlags.vcr == vcr.Record.String() {
+		rec, err = vcr.New(&vcr.Config{
+			Path:      testFlags.cassette,
+			Mode:      vcr.Record,
+			Transport: client.Transport,
+			Replacer: strings.NewReplacer(
+				testFlags.resourcePrefix+runID+"-", testFlags.resourcePrefix+vcrRunID+"-",
+				testFlags.project, vcrProject,
+			),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		client = rec.Client()
+	}
 	svc, err := cloud.NewService(ctx, client, &cloud.SingleProjectRouter{ID: testFlags.project}, &cloud.NopRateLimiter{})
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, err
+	}
+	cleanup = func() error {
+		if srv != nil {
+			defer srv.Close()
+		}
+		if rec != nil {
+			return rec.Save()
+		}
+		return nil
 	}
-	theCloud = cloud.NewGCE(svc)
+	return cloud.NewGCE(svc), cleanup, nil
+}
 
-	os.Exit(m.Run())
+// newFakeMock returns the mock for -backend=mock and backing the fake server
+// for -backend=fake. The
+// Regions and Zones used by the tests are pre-populated as they are read-only
+// in GCE.
+func newFakeMock() *cloud.MockGCE {
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: testFlags.project})
+	// GCE does not allow resources that are in use to be deleted.
+	mock.EnforceReferentialIntegrity(true)
+	for _, region := range []string{"us-central1"} {
+		key := meta.GlobalKey(region)
+		mock.MockRegions.Objects[*key] = mock.MockRegions.Obj(&compute.Region{
+			Name:     region,
+			SelfLink: cloud.SelfLink(meta.VersionGA, testFlags.project, "regions", key),
+		})
+	}
+	for _, zone := range []string{"us-central1-a", "us-central1-b", "us-central1-c", "us-central1-f"} {
+		key := meta.GlobalKey(zone)
+		mock.MockZones.Objects[*key] = mock.MockZones.Obj(&compute.Zone{
+			Name:     zone,
+			SelfLink: cloud.SelfLink(meta.VersionGA, testFlags.project, "zones", key),
+		})
+	}
+	return mock
 }
 
 func checkErrCode(t *testing.T, err error, wantCode int, fmtStr string, args ...interface{}) {
@@ -26,7 +26,7 @@ import (
 func checkPostAccess(traits *FieldTraits, v reflect.Value) error {
 	acc := newAcceptorFuncs()
 	acc.onStructF = func(p Path, v reflect.Value) (bool, error) {
-		if p.Equal(Path{}.Pointer().Field("ServerResponse")) {
+		if isServerResponse(p) {
 			return false, nil
 		}
 
@@ -218,3 +218,10 @@ func checkStructuralSubsetImpl(p Path, from, to reflect.Type) error {
 	}
 	return fmt.Errorf("%s Unsupported type %v", p.String(), from.Kind())
 }
+
+// isServerResponse is true if p refers to the googleapi.ServerResponse
+// embedded in the API types. This also appears in nested types that are
+// returned by their own methods (e.g. FirewallPolicyRule from GetRule()).
+func isServerResponse(p Path) bool {
+	return len(p) > 0 && p[len(p)-1] == ".ServerResponse"
+}
@@ -43,6 +43,29 @@ func newCopier(opts ...copierOption) *copier {
 	return c
 }
 
+// Convert copies src into dest, where dest is the equivalent type from
+// another API version (e.g. compute.TargetReference and
+// alpha.TargetReference). src and dest must be non-nil pointers. Returns an
+// error if a non-zero field of src does not exist in dest.
+func Convert(dest, src any) error {
+	dv, sv := reflect.ValueOf(dest), reflect.ValueOf(src)
+	if dv.Kind() != reflect.Pointer || sv.Kind() != reflect.Pointer || dv.IsNil() || sv.IsNil() {
+		return fmt.Errorf("Convert: invalid types: dest %T, src %T", dest, src)
+	}
+	c := newCopier()
+	if err := c.do(dv.Elem(), sv.Elem()); err != nil {
+		return fmt.Errorf("Convert: %w", err)
+	}
+	if len(c.missing) > 0 {
+		var paths []string
+		for _, mf := range c.missing {
+			paths = append(paths, mf.Path.String())
+		}
+		return fmt.Errorf("Convert: fields cannot be represented in %T: %v", dest, paths)
+	}
+	return nil
+}
+
 type copier struct {
 	// logSFn is an optional structured log function, matching the
 	// signature from klog/v2.
@@ -554,3 +554,39 @@ func TestCopyMetaFields(t *testing.T) {
 		})
 	}
 }
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	type ga struct {
+		A               string
+		ForceSendFields []string
+	}
+	type alpha struct {
+		A, B            string
+		ForceSendFields []string
+	}
+
+	var a alpha
+	if err := Convert(&a, &ga{ForceSendFields: []string{"A"}}); err != nil {
+		t.Fatalf("Convert() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(a, alpha{ForceSendFields: []string{"A"}}); diff != "" {
+		t.Errorf("Convert(); -got,+want: %s", diff)
+	}
+
+	var g ga
+	if err := Convert(&g, &alpha{A: "a"}); err != nil {
+		t.Fatalf("Convert() = %v, want nil", err)
+	}
+	if g.A != "a" {
+		t.Errorf("g.A = %q, want %q", g.A, "a")
+	}
+	// .B is not in ga.
+	if err := Convert(&g, &alpha{B: "b"}); err == nil {
+		t.Errorf("Convert() = nil, want error")
+	}
+	if err := Convert(g, &alpha{}); err == nil {
+		t.Errorf("Convert(non-pointer) = nil, want error")
+	}
+}
@@ -19,6 +19,9 @@ package api
 import (
 	"fmt"
 	"reflect"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 )
 
 // TODO: how to diff force send fields? null fields? and zero values?
@@ -38,6 +41,9 @@ func diff[T any](a, b *T, trait *FieldTraits) (*DiffResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	for i := range d.result.Items {
+		d.result.Items[i].Immutable = trait.isImmutable(d.result.Items[i].Path)
+	}
 	return d.result, nil
 }
 
@@ -53,6 +59,35 @@ func diffStructs[A any, B any](a *A, b *B) (*DiffResult, error) {
 	return d.result, nil
 }
 
+// DiffComparator returns true if the field values a and b are equal. See
+// FieldTraits.Compare().
+type DiffComparator func(a, b any) bool
+
+// ResourceURLComparator is a DiffComparator for string fields that hold a
+// resource URL. The URLs are compared by the resource they refer to, ignoring
+// the API version and host, e.g. a "compute/beta" selfLink is equal to the
+// "compute/v1" selfLink of the same resource. Values that cannot be parsed are
+// compared as strings.
+func ResourceURLComparator(a, b any) bool {
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if !aok || !bok {
+		return reflect.DeepEqual(a, b)
+	}
+	if as == bs {
+		return true
+	}
+	aID, err := cloud.ParseResourceURL(as)
+	if err != nil {
+		return false
+	}
+	bID, err := cloud.ParseResourceURL(bs)
+	if err != nil {
+		return false
+	}
+	return aID.Equal(bID)
+}
+
 // DiffResult gives a list of elements that differ.
 type DiffResult struct {
 	Items []DiffItem
@@ -64,7 +99,8 @@ func (r *DiffResult) HasDiff() bool { return len(r.Items) > 0 }
 func (r *DiffResult) add(state DiffItemState, p Path, a, b reflect.Value) {
 	di := DiffItem{
 		State: state,
-		Path:  p,
+		// Copy as the Paths of the sibling fields share the backing array.
+		Path: append(Path(nil), p...),
 	}
 	if a.IsValid() {
 		// Interface() will panic if is called on unexported types in this case
@@ -107,6 +143,9 @@ type DiffItem struct {
 	Path  Path
 	A     any
 	B     any
+	// Immutable is true if the field cannot be changed without recreating
+	// the resource. See FieldTraits.Immutable().
+	Immutable bool
 }
 
 type differ[T any] struct {
@@ -131,6 +170,18 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 		return false
 	}
 
+	// The server sets the field when it is not set in B.
+	if sd := d.traits.serverDefault(p); sd != nil && bv.IsValid() && bv.IsZero() && sd.matches(av) {
+		return nil
+	}
+
+	if cmp := d.traits.comparator(p); cmp != nil {
+		if !cmp(valueInterface(av), valueInterface(bv)) {
+			d.result.add(DiffItemDifferent, p, av, bv)
+		}
+		return nil
+	}
+
 	switch {
 	case isBasicV(av):
 		if !av.Equal(bv) {
@@ -174,6 +225,9 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 		if cmpZero() {
 			return nil
 		}
+		if key, ok := d.traits.sliceKey(p); ok {
+			return d.diffByKey(p, key, av, bv)
+		}
 		// If we find the list lengths are difference, don't recurse into a list
 		// to compare item by item. There isn't a use case for a more fine grain
 		// diff within a slice at the moment.
@@ -181,6 +235,16 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 			d.result.add(DiffItemDifferent, p, av, bv)
 			return nil
 		}
+		if d.traits.isUnordered(p) {
+			eq, err := d.unorderedEqual(p, av, bv)
+			if err != nil {
+				return err
+			}
+			if !eq {
+				d.result.add(DiffItemDifferent, p, av, bv)
+			}
+			return nil
+		}
 		for i := 0; i < av.Len(); i++ {
 			asv := av.Index(i)
 			bsv := bv.Index(i)
@@ -195,24 +259,22 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 		if cmpZero() {
 			return nil
 		}
-		if av.Len() != bv.Len() {
-			d.result.add(DiffItemDifferent, p, av, bv)
-			return nil
-		}
-		// For maps of the same size, for the maps to be equal, all keys in A
-		// must be present in B for these to be equal. This means we don't have
-		// to check  in the opposite direction from B to A. However, this makes
-		// the Diff function non-symmetric.
-		for _, amk := range av.MapKeys() {
-			amv := av.MapIndex(amk)
-			bmv := bv.MapIndex(amk)
-			mp := p.MapIndex(amk)
+		// The maps are diff'd per key so that the result only has the
+		// entries that were added, removed or changed.
+		for _, k := range sortedMapKeys(av, bv) {
+			amv := av.MapIndex(k)
+			bmv := bv.MapIndex(k)
+			mp := p.MapIndex(k)
 
-			if !bmv.IsValid() {
-				d.result.add(DiffItemDifferent, mp, amv, bmv)
-			}
-			if err := d.do(mp, amv, bmv); err != nil {
-				return fmt.Errorf("differ map %p: %w", mp, err)
+			switch {
+			case !bmv.IsValid():
+				d.result.add(DiffItemOnlyInA, mp, amv, bmv)
+			case !amv.IsValid():
+				d.result.add(DiffItemOnlyInB, mp, amv, bmv)
+			default:
+				if err := d.do(mp, amv, bmv); err != nil {
+					return fmt.Errorf("differ map %p: %w", mp, err)
+				}
 			}
 		}
 		return nil
@@ -220,3 +282,110 @@ func (d *differ[T]) do(p Path, av, bv reflect.Value) error {
 
 	return fmt.Errorf("differ: invalid type: %s", av.Type())
 }
+
+// sortedMapKeys returns the union of the keys of the maps, sorted so that
+// the order of the diff items is stable.
+func sortedMapKeys(av, bv reflect.Value) []reflect.Value {
+	var ret []reflect.Value
+	seen := map[string]bool{}
+	for _, m := range []reflect.Value{av, bv} {
+		for _, k := range m.MapKeys() {
+			// fmt handles the keys of unexported fields.
+			ks := fmt.Sprint(k)
+			if !seen[ks] {
+				seen[ks] = true
+				ret = append(ret, k)
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return fmt.Sprint(ret[i]) < fmt.Sprint(ret[j]) })
+	return ret
+}
+
+// unorderedEqual returns true if each element in av is equal to a distinct
+// element in bv. The slices must be of the same length.
+func (d *differ[T]) unorderedEqual(p Path, av, bv reflect.Value) (bool, error) {
+	used := make([]bool, bv.Len())
+	for i := 0; i < av.Len(); i++ {
+		found := false
+		for j := 0; j < bv.Len() && !found; j++ {
+			if used[j] {
+				continue
+			}
+			sub := &differ[T]{traits: d.traits, result: &DiffResult{}}
+			if err := sub.do(p.Index(i), av.Index(i), bv.Index(j)); err != nil {
+				return false, fmt.Errorf("differ unordered slice %s: %w", p, err)
+			}
+			if !sub.result.HasDiff() {
+				used[j], found = true, true
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// diffByKey diffs the elements of the slices with the same key field. See
+// FieldTraits.UnorderedByKey().
+func (d *differ[T]) diffByKey(p Path, key string, av, bv reflect.Value) error {
+	// keyOf returns the path and value of the key field of the element.
+	keyOf := func(ep Path, ev reflect.Value) (Path, reflect.Value, bool) {
+		if ev.Kind() == reflect.Pointer {
+			if ev.IsNil() {
+				return nil, reflect.Value{}, false
+			}
+			ep, ev = ep.Pointer(), ev.Elem()
+		}
+		kv := ev.FieldByName(key)
+		return ep.Field(key), kv, kv.IsValid()
+	}
+
+	matched := make([]bool, bv.Len())
+	for i := 0; i < av.Len(); i++ {
+		// Copy as the Paths for the elements of B share the backing array.
+		ep := append(Path(nil), p.Index(i)...)
+		kp, ak, ok := keyOf(ep, av.Index(i))
+		j := -1
+		for k := 0; ok && k < bv.Len() && j < 0; k++ {
+			if matched[k] {
+				continue
+			}
+			_, bk, ok := keyOf(p.Index(k), bv.Index(k))
+			if !ok {
+				continue
+			}
+			sub := &differ[T]{traits: d.traits, result: &DiffResult{}}
+			if err := sub.do(kp, ak, bk); err != nil {
+				return fmt.Errorf("differ keyed slice %s: %w", p, err)
+			}
+			if !sub.result.HasDiff() {
+				j = k
+			}
+		}
+		if j < 0 {
+			d.result.add(DiffItemOnlyInA, ep, av.Index(i), reflect.Value{})
+			continue
+		}
+		matched[j] = true
+		if err := d.do(ep, av.Index(i), bv.Index(j)); err != nil {
+			return fmt.Errorf("differ keyed slice %s: %w", ep, err)
+		}
+	}
+	for j := 0; j < bv.Len(); j++ {
+		if !matched[j] {
+			d.result.add(DiffItemOnlyInB, p.Index(j), reflect.Value{}, bv.Index(j))
+		}
+	}
+	return nil
+}
+
+// valueInterface returns the value as an interface{} or nil if v is not
+// valid.
+func valueInterface(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
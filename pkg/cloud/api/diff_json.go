@@ -0,0 +1,95 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// DiffChange is the kind of change of a DiffItem from A to B, e.g. from the
+// current resource in the cloud to the wanted resource.
+type DiffChange string
+
+var (
+	// DiffChangeModified means the value changed (DiffItemDifferent).
+	DiffChangeModified DiffChange = "Modified"
+	// DiffChangeAdded means the value is only in B (DiffItemOnlyInB).
+	DiffChangeAdded DiffChange = "Added"
+	// DiffChangeRemoved means the value is only in A (DiffItemOnlyInA).
+	DiffChangeRemoved DiffChange = "Removed"
+)
+
+// Change returns the kind of change of the item.
+func (di *DiffItem) Change() DiffChange {
+	switch di.State {
+	case DiffItemOnlyInA:
+		return DiffChangeRemoved
+	case DiffItemOnlyInB:
+		return DiffChangeAdded
+	}
+	return DiffChangeModified
+}
+
+// JSONDiffItem is the serialized form of a DiffItem. Old and New are the
+// values in A and B, null if there is no value.
+type JSONDiffItem struct {
+	// Path is the Path.String() of the field, e.g. "*.Backends!0.Group".
+	Path   string     `json:"path"`
+	Change DiffChange `json:"change"`
+	Old    any        `json:"old"`
+	New    any        `json:"new"`
+}
+
+// JSON returns the items of the diff in a stable order (by path). This can
+// be used by tools and in the status of custom resources to present the
+// diff without parsing the Go values.
+func (r *DiffResult) JSON() []JSONDiffItem {
+	ret := []JSONDiffItem{}
+	for i := range r.Items {
+		item := &r.Items[i]
+		ret = append(ret, JSONDiffItem{
+			Path:   item.Path.String(),
+			Change: item.Change(),
+			Old:    item.A,
+			New:    item.B,
+		})
+	}
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Path < ret[j].Path })
+	return ret
+}
+
+// MarshalJSON implements json.Marshaler with the JSON() of the result.
+func (r *DiffResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.JSON())
+}
+
+// Filter returns the items with a Path under any of the prefixes (see
+// Path.HasPrefix()), e.g. Path{}.Pointer().Field("Backends") for all of
+// the changes to the backends.
+func (r *DiffResult) Filter(prefixes ...Path) *DiffResult {
+	ret := &DiffResult{}
+	for _, item := range r.Items {
+		for _, prefix := range prefixes {
+			if item.Path.HasPrefix(prefix) {
+				ret.Items = append(ret.Items, item)
+				break
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffResultJSON(t *testing.T) {
+	type sti struct {
+		I int
+	}
+	type st struct {
+		S   string
+		I   int
+		P   *sti
+		LSt []sti
+	}
+	a := &st{S: "a", I: 1, P: &sti{I: 1}, LSt: []sti{{I: 1}}}
+	b := &st{S: "b", I: 0, LSt: []sti{{I: 2}}}
+
+	r, err := diff(a, b, &FieldTraits{})
+	if err != nil {
+		t.Fatalf("diff() = %v, want nil", err)
+	}
+	got, err := json.Marshal(r)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want nil", err)
+	}
+	const want = `[` +
+		`{"path":"*.I","change":"Modified","old":1,"new":0},` +
+		`{"path":"*.LSt!0.I","change":"Modified","old":1,"new":2},` +
+		`{"path":"*.P","change":"Removed","old":{"I":1},"new":null},` +
+		`{"path":"*.S","change":"Modified","old":"a","new":"b"}]`
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+	if got, err := json.Marshal(&DiffResult{}); err != nil || string(got) != "[]" {
+		t.Errorf("json.Marshal(empty) = %s, %v, want [], nil", got, err)
+	}
+
+	var paths []string
+	for _, item := range r.Filter(Path{}.Pointer().Field("LSt"), Path{}.Pointer().Field("P")).Items {
+		paths = append(paths, item.Path.String())
+	}
+	if diff := cmp.Diff(paths, []string{"*.P", "*.LSt!0.I"}); diff != "" {
+		t.Errorf("Filter(): -got,+want: %s", diff)
+	}
+	if r.Filter().HasDiff() {
+		t.Errorf("Filter() with no prefixes = %v, want no diff", r.Filter())
+	}
+}
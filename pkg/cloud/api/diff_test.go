@@ -17,8 +17,10 @@ limitations under the License.
 package api
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/kr/pretty"
 )
 
@@ -292,3 +294,295 @@ func TestDiffForStructWithUnexportedFields(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffWithComparators(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		Group string
+		I     int
+	}
+	type st struct {
+		URL  string
+		URLs []string
+		LSt  []*sti
+	}
+
+	const (
+		v1URL   = "https://www.googleapis.com/compute/v1/projects/proj/global/healthChecks/hc"
+		betaURL = "https://www.googleapis.com/compute/beta/projects/proj/global/healthChecks/hc"
+		otherV1 = "https://www.googleapis.com/compute/v1/projects/proj/global/healthChecks/hc2"
+		igURL   = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-b/instanceGroups/ig"
+		igBeta  = "https://www.googleapis.com/compute/beta/projects/proj/zones/us-central1-b/instanceGroups/ig"
+		igOther = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-c/instanceGroups/ig"
+	)
+
+	traits := &FieldTraits{}
+	traits.Compare(Path{}.Pointer().Field("URL"), ResourceURLComparator)
+	traits.Unordered(Path{}.Pointer().Field("URLs"))
+	traits.Compare(Path{}.Pointer().Field("URLs").AnySliceIndex(), ResourceURLComparator)
+	traits.Unordered(Path{}.Pointer().Field("LSt"))
+	traits.Compare(Path{}.Pointer().Field("LSt").AnySliceIndex().Pointer().Field("Group"), ResourceURLComparator)
+
+	if err := traits.CheckSchema(reflect.TypeOf(&st{})); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		a        st
+		b        st
+		wantDiff bool
+	}{
+		{name: "url same", a: st{URL: v1URL}, b: st{URL: v1URL}},
+		{name: "url version", a: st{URL: v1URL}, b: st{URL: betaURL}},
+		{name: "url diff", a: st{URL: v1URL}, b: st{URL: otherV1}, wantDiff: true},
+		{name: "url not parsable", a: st{URL: "abc"}, b: st{URL: "def"}, wantDiff: true},
+		{name: "urls reordered", a: st{URLs: []string{v1URL, otherV1}}, b: st{URLs: []string{otherV1, betaURL}}},
+		{name: "urls diff", a: st{URLs: []string{v1URL, otherV1}}, b: st{URLs: []string{v1URL, v1URL}}, wantDiff: true},
+		{
+			name: "structs reordered",
+			a:    st{LSt: []*sti{{Group: igURL, I: 1}, {Group: igOther, I: 2}}},
+			b:    st{LSt: []*sti{{Group: igOther, I: 2}, {Group: igBeta, I: 1}}},
+		},
+		{
+			name:     "structs diff",
+			a:        st{LSt: []*sti{{Group: igURL, I: 1}, {Group: igOther, I: 2}}},
+			b:        st{LSt: []*sti{{Group: igOther, I: 1}, {Group: igBeta, I: 2}}},
+			wantDiff: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := diff(&tc.a, &tc.b, traits)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if r.HasDiff() != tc.wantDiff {
+				t.Errorf("HasDiff = %t, want %t. diff = %s", r.HasDiff(), tc.wantDiff, pretty.Sprint(r))
+			}
+		})
+	}
+}
+
+func TestDiffUnorderedByKey(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		Group string
+		I     int
+	}
+	type st struct {
+		LSt []*sti
+		L   []sti
+	}
+
+	const (
+		igURL  = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-b/instanceGroups/ig"
+		igBeta = "https://www.googleapis.com/compute/beta/projects/proj/zones/us-central1-b/instanceGroups/ig"
+		ig2    = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-c/instanceGroups/ig"
+	)
+
+	traits := &FieldTraits{}
+	traits.UnorderedByKey(Path{}.Pointer().Field("LSt"), "Group")
+	traits.Compare(Path{}.Pointer().Field("LSt").AnySliceIndex().Pointer().Field("Group"), ResourceURLComparator)
+	traits.UnorderedByKey(Path{}.Pointer().Field("L"), "Group")
+
+	if err := traits.CheckSchema(reflect.TypeOf(&st{})); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		a    st
+		b    st
+		want []DiffItem
+	}{
+		{
+			name: "reordered",
+			a:    st{LSt: []*sti{{Group: igURL, I: 1}, {Group: ig2, I: 2}}},
+			b:    st{LSt: []*sti{{Group: ig2, I: 2}, {Group: igBeta, I: 1}}},
+		},
+		{
+			name: "reordered values",
+			a:    st{L: []sti{{Group: "a", I: 1}, {Group: "b", I: 2}}},
+			b:    st{L: []sti{{Group: "b", I: 2}, {Group: "a", I: 1}}},
+		},
+		{
+			name: "field diff",
+			a:    st{LSt: []*sti{{Group: igURL, I: 1}, {Group: ig2, I: 2}}},
+			b:    st{LSt: []*sti{{Group: ig2, I: 3}, {Group: igURL, I: 1}}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("LSt").Index(1).Pointer().Field("I"), A: 2, B: 3},
+			},
+		},
+		{
+			name: "added and removed",
+			a:    st{L: []sti{{Group: "a", I: 1}, {Group: "b", I: 2}}},
+			b:    st{L: []sti{{Group: "c", I: 3}, {Group: "a", I: 1}, {Group: "d", I: 4}}},
+			want: []DiffItem{
+				{State: DiffItemOnlyInA, Path: Path{}.Pointer().Field("L").Index(1), A: sti{Group: "b", I: 2}},
+				{State: DiffItemOnlyInB, Path: Path{}.Pointer().Field("L").Index(0), B: sti{Group: "c", I: 3}},
+				{State: DiffItemOnlyInB, Path: Path{}.Pointer().Field("L").Index(2), B: sti{Group: "d", I: 4}},
+			},
+		},
+		{
+			name: "nil element",
+			a:    st{LSt: []*sti{nil}},
+			b:    st{LSt: []*sti{{Group: "a"}}},
+			want: []DiffItem{
+				{State: DiffItemOnlyInA, Path: Path{}.Pointer().Field("LSt").Index(0), A: (*sti)(nil)},
+				{State: DiffItemOnlyInB, Path: Path{}.Pointer().Field("LSt").Index(0), B: &sti{Group: "a"}},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := diff(&tc.a, &tc.b, traits)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(r.Items, tc.want); diff != "" {
+				t.Errorf("Items: -got,+want: %s", diff)
+			}
+		})
+	}
+
+	bad := &FieldTraits{}
+	bad.UnorderedByKey(Path{}.Pointer().Field("LSt"), "Missing")
+	if err := bad.CheckSchema(reflect.TypeOf(&st{})); err == nil {
+		t.Errorf("CheckSchema() = nil, want error for a missing key field")
+	}
+}
+
+func TestDiffServerDefaults(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		I int
+	}
+	type st struct {
+		S   string
+		I   int64
+		PI  *int64
+		St  *sti
+		Any string
+	}
+
+	traits := &FieldTraits{}
+	traits.ServerDefault(Path{}.Pointer().Field("S"), "NONE")
+	traits.ServerDefault(Path{}.Pointer().Field("I"), int64(30))
+	traits.ServerDefault(Path{}.Pointer().Field("PI"), int64(10))
+	traits.DefaultedIfUnset(Path{}.Pointer().Field("St"))
+	traits.DefaultedIfUnset(Path{}.Pointer().Field("Any"))
+
+	if err := traits.CheckSchema(reflect.TypeOf(&st{})); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+
+	ten := int64(10)
+	eleven := int64(11)
+
+	for _, tc := range []struct {
+		name     string
+		a        st
+		b        st
+		wantDiff bool
+	}{
+		{name: "defaults unset in b", a: st{S: "NONE", I: 30, PI: &ten, St: &sti{I: 1}, Any: "x"}},
+		{name: "same", a: st{S: "X"}, b: st{S: "X"}},
+		{name: "not default", a: st{S: "X"}, wantDiff: true},
+		{name: "set in b", a: st{S: "NONE"}, b: st{S: "X"}, wantDiff: true},
+		{name: "int not default", a: st{I: 31}, wantDiff: true},
+		{name: "pointer not default", a: st{PI: &eleven}, wantDiff: true},
+		{name: "defaulted if unset set in b", a: st{Any: "x"}, b: st{Any: "y"}, wantDiff: true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := diff(&tc.a, &tc.b, traits)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if r.HasDiff() != tc.wantDiff {
+				t.Errorf("HasDiff = %t, want %t. diff = %s", r.HasDiff(), tc.wantDiff, pretty.Sprint(r))
+			}
+		})
+	}
+
+	bad := &FieldTraits{}
+	bad.ServerDefault(Path{}.Pointer().Field("S"), 1)
+	if err := bad.CheckSchema(reflect.TypeOf(&st{})); err == nil {
+		t.Errorf("CheckSchema() = nil, want error for a default of the wrong type")
+	}
+}
+
+func TestDiffMapPerKey(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		I int
+		S string
+	}
+	type st struct {
+		M  map[string]string
+		MS map[string]sti
+	}
+
+	for _, tc := range []struct {
+		name string
+		a    st
+		b    st
+		want []DiffItem
+	}{
+		{
+			name: "same",
+			a:    st{M: map[string]string{"a": "1", "b": "2"}},
+			b:    st{M: map[string]string{"b": "2", "a": "1"}},
+		},
+		{
+			name: "changed, added and removed",
+			a:    st{M: map[string]string{"a": "1", "b": "2", "c": "3"}},
+			b:    st{M: map[string]string{"a": "1", "b": "20", "d": "4"}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("M").MapIndex("b"), A: "2", B: "20"},
+				{State: DiffItemOnlyInA, Path: Path{}.Pointer().Field("M").MapIndex("c"), A: "3"},
+				{State: DiffItemOnlyInB, Path: Path{}.Pointer().Field("M").MapIndex("d"), B: "4"},
+			},
+		},
+		{
+			name: "struct values",
+			a:    st{MS: map[string]sti{"a": {I: 1, S: "x"}}},
+			b:    st{MS: map[string]sti{"a": {I: 2, S: "x"}}},
+			want: []DiffItem{
+				{State: DiffItemDifferent, Path: Path{}.Pointer().Field("MS").MapIndex("a").Field("I"), A: 1, B: 2},
+			},
+		},
+		{
+			name: "empty map",
+			a:    st{M: map[string]string{}},
+			b:    st{M: map[string]string{"a": "1"}},
+			want: []DiffItem{
+				{State: DiffItemOnlyInB, Path: Path{}.Pointer().Field("M").MapIndex("a"), B: "1"},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := diff(&tc.a, &tc.b, nil)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(r.Items, tc.want); diff != "" {
+				t.Errorf("Items: -got,+want: %s", diff)
+			}
+		})
+	}
+}
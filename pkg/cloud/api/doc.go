@@ -54,8 +54,12 @@ limitations under the License.
 //	// part of version translation.
 //	betaObj, err := addr.ToBeta()
 //	  if err != nil {
-//	    var objErrors *Errors
-//	    if errors.As(err, &objErrors) { /* handle MissingFields, etc. */ }
+//	    var convErr *ConversionError
+//	    if errors.As(err, &convErr) {
+//	      // convErr.MissingFields lists the paths and values that cannot be
+//	      // represented in Beta, convErr.SourceVersions() the versions
+//	      // that have them.
+//	    }
 //	}
 //
 // # Checking type assumptions with unit tests
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FieldMask is the set of fields changed in a diff, in the forms needed to
+// send a patch to the Compute API.
+type FieldMask struct {
+	// Paths of the changed fields with the JSON names separated by ".",
+	// e.g. "cdnPolicy.cacheMode". Slices and maps are not masked per
+	// element so the Path stops at the slice or map field, e.g. "backends".
+	// Sorted. See String() for the updateMask format.
+	Paths []string
+	// ForceSendFields are the Go names of the changed fields that are empty
+	// in B and must be sent so that the patch clears them. Nested fields are
+	// separated by ".", e.g. "CdnPolicy.CacheMode"; the last element goes
+	// into the ForceSendFields of the parent struct. Sorted.
+	ForceSendFields []string
+	// NullFields are the Go names of the changed pointer fields that are
+	// nil in B and must be sent as null, in the same format as
+	// ForceSendFields. Sorted.
+	NullFields []string
+}
+
+// String returns the mask in the updateMask format, e.g.
+// "backends,cdnPolicy.cacheMode".
+func (m *FieldMask) String() string { return strings.Join(m.Paths, ",") }
+
+// FieldMask returns the FieldMask covering exactly the changed fields in
+// the diff from A to B (e.g. from the current to the wanted resource). This
+// is used with the Patch() of the resource. Parent paths subsume the paths
+// of their fields.
+func (r *DiffResult) FieldMask() *FieldMask {
+	paths := map[string]bool{}
+	goPaths := map[string]bool{}
+	forceSend := map[string]bool{}
+	null := map[string]bool{}
+
+	for _, item := range r.Items {
+		var json, goNames []string
+		// truncated is true if the path goes into a slice or map element.
+		truncated := false
+		for _, elem := range item.Path {
+			if elem == string(pathPointer) {
+				continue
+			}
+			if elem == "" || elem[0] != pathField {
+				truncated = true
+				break
+			}
+			goNames = append(goNames, elem[1:])
+			json = append(json, jsonFieldName(elem[1:]))
+		}
+		if len(json) == 0 {
+			continue
+		}
+		paths[strings.Join(json, ".")] = true
+		goPath := strings.Join(goNames, ".")
+		goPaths[goPath] = true
+		if truncated {
+			continue
+		}
+		switch b := reflect.ValueOf(item.B); {
+		case !b.IsValid() || (b.Kind() == reflect.Pointer && b.IsNil()):
+			null[goPath] = true
+		case b.IsZero() || ((b.Kind() == reflect.Slice || b.Kind() == reflect.Map) && b.Len() == 0):
+			forceSend[goPath] = true
+		}
+	}
+
+	return &FieldMask{
+		Paths:           withoutSubPaths(paths),
+		ForceSendFields: inMask(forceSend, goPaths),
+		NullFields:      inMask(null, goPaths),
+	}
+}
+
+// jsonFieldName returns the JSON name of the Go field of a Compute API
+// struct. The Go name is the JSON name with the first letter in upper case,
+// except for the names starting with an initialism (e.g. "IPAddress").
+func jsonFieldName(name string) string {
+	runes := []rune(name)
+	if len(runes) > 1 && unicode.IsUpper(runes[0]) && unicode.IsUpper(runes[1]) {
+		return name
+	}
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// withoutSubPaths returns the sorted paths, dropping the paths that have a
+// parent in the set.
+func withoutSubPaths(paths map[string]bool) []string {
+	var ret []string
+	for p := range paths {
+		if !hasParent(p, paths) {
+			ret = append(ret, p)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// inMask returns the sorted fields that are not under another changed
+// field, i.e. that are sent on their own.
+func inMask(fields, goPaths map[string]bool) []string {
+	var ret []string
+	for f := range fields {
+		if !hasParent(f, goPaths) {
+			ret = append(ret, f)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// hasParent returns true if a parent of the "."-separated path p is in
+// paths.
+func hasParent(p string, paths map[string]bool) bool {
+	for i := strings.LastIndex(p, "."); i > 0; i = strings.LastIndex(p[:i], ".") {
+		if paths[p[:i]] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffResultFieldMask(t *testing.T) {
+	type inner struct {
+		Mode  string
+		Count int
+	}
+	type st struct {
+		IPAddress string
+		Name      string
+		Inner     *inner
+		Other     *inner
+		List      []string
+		Labels    map[string]string
+	}
+
+	for _, tc := range []struct {
+		name string
+		a, b st
+		want FieldMask
+	}{
+		{name: "no diff", a: st{Name: "a"}, b: st{Name: "a"}},
+		{
+			name: "top-level fields",
+			a:    st{IPAddress: "1.2.3.4", Name: "a"},
+			b:    st{IPAddress: "1.2.3.5", Name: "b"},
+			want: FieldMask{Paths: []string{"IPAddress", "name"}},
+		},
+		{
+			name: "nested field",
+			a:    st{Inner: &inner{Mode: "x", Count: 1}},
+			b:    st{Inner: &inner{Mode: "y", Count: 1}},
+			want: FieldMask{Paths: []string{"inner.mode"}},
+		},
+		{
+			name: "nested field cleared",
+			a:    st{Inner: &inner{Mode: "x", Count: 1}},
+			b:    st{Inner: &inner{Count: 1}},
+			want: FieldMask{Paths: []string{"inner.mode"}, ForceSendFields: []string{"Inner.Mode"}},
+		},
+		{
+			name: "pointer removed",
+			a:    st{Other: &inner{Mode: "x"}},
+			want: FieldMask{Paths: []string{"other"}, NullFields: []string{"Other"}},
+		},
+		{
+			name: "list element",
+			a:    st{List: []string{"a", "b"}},
+			b:    st{List: []string{"a", "c"}},
+			want: FieldMask{Paths: []string{"list"}},
+		},
+		{
+			name: "list and map cleared",
+			a:    st{List: []string{"a"}, Labels: map[string]string{"k": "v"}},
+			want: FieldMask{Paths: []string{"labels", "list"}, ForceSendFields: []string{"Labels", "List"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := diff(&tc.a, &tc.b, &FieldTraits{})
+			if err != nil {
+				t.Fatalf("diff() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(*r.FieldMask(), tc.want); diff != "" {
+				t.Errorf("FieldMask(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFieldMaskSubPaths(t *testing.T) {
+	r := &DiffResult{Items: []DiffItem{
+		{State: DiffItemDifferent, Path: Path{}.Pointer().Field("Inner").Pointer().Field("Mode"), A: "a", B: ""},
+		{State: DiffItemOnlyInA, Path: Path{}.Pointer().Field("Inner"), A: "x"},
+	}}
+	got := r.FieldMask()
+	want := &FieldMask{Paths: []string{"inner"}, NullFields: []string{"Inner"}}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("FieldMask(): -got,+want: %s", diff)
+	}
+	if s := (&FieldMask{Paths: []string{"a", "b.c"}}).String(); s != "a,b.c" {
+		t.Errorf("String() = %q, want \"a,b.c\"", s)
+	}
+}
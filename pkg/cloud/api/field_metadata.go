@@ -0,0 +1,53 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+// FieldMetadata lists the output-only and immutable fields of a resource
+// type. It can be generated from the API description (e.g. the "[Output
+// Only]" annotations of the discovery document) and serialized as JSON, the
+// Paths are lists of strings.
+type FieldMetadata struct {
+	OutputOnly []Path `json:"outputOnly,omitempty"`
+	Immutable  []Path `json:"immutable,omitempty"`
+}
+
+// AddMetadata registers the fields in md with OutputOnly() and Immutable().
+// md may be nil. Use CheckSchema() to validate the paths against the type.
+func (dt *FieldTraits) AddMetadata(md *FieldMetadata) {
+	if md == nil {
+		return
+	}
+	for _, p := range md.OutputOnly {
+		dt.OutputOnly(p)
+	}
+	for _, p := range md.Immutable {
+		dt.Immutable(p)
+	}
+}
+
+// Metadata returns the output-only and immutable fields registered in the
+// traits.
+func (dt *FieldTraits) Metadata() *FieldMetadata {
+	ret := &FieldMetadata{}
+	for _, f := range dt.fields {
+		if f.fType == FieldTypeOutputOnly {
+			ret.OutputOnly = append(ret.OutputOnly, f.path)
+		}
+	}
+	ret.Immutable = append(ret.Immutable, dt.immutable...)
+	return ret
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFieldMetadata(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		I int
+	}
+	type st struct {
+		A  int
+		B  string
+		O  string
+		St *sti
+	}
+
+	const mdJSON = `{"outputOnly":[["*",".O"]],"immutable":[["*",".B"],["*",".St"]]}`
+	var md FieldMetadata
+	if err := json.Unmarshal([]byte(mdJSON), &md); err != nil {
+		t.Fatalf("json.Unmarshal() = %v, want nil", err)
+	}
+
+	dt := &FieldTraits{}
+	dt.AddMetadata(&md)
+	dt.AddMetadata(nil)
+	if err := dt.CheckSchema(reflect.TypeOf(&st{})); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+	if diff := cmp.Diff(dt.Metadata(), &md); diff != "" {
+		t.Errorf("Metadata(): -got,+want: %s", diff)
+	}
+
+	a := st{A: 1, B: "b", O: "o", St: &sti{I: 1}}
+	b := st{A: 2, B: "c", St: &sti{I: 2}}
+	r, err := diff(&a, &b, dt)
+	if err != nil {
+		t.Fatalf("diff() = %v, want nil", err)
+	}
+	want := []DiffItem{
+		{State: DiffItemDifferent, Path: Path{}.Pointer().Field("A"), A: 1, B: 2},
+		{State: DiffItemDifferent, Path: Path{}.Pointer().Field("B"), A: "b", B: "c", Immutable: true},
+		{State: DiffItemDifferent, Path: Path{}.Pointer().Field("St").Pointer().Field("I"), A: 1, B: 2, Immutable: true},
+	}
+	if diff := cmp.Diff(r.Items, want); diff != "" {
+		t.Errorf("diff(): -got,+want: %s", diff)
+	}
+}
@@ -160,7 +160,7 @@ func (f *filler) doMap(p Path, v reflect.Value) (bool, error) {
 func fillNullAndForceSend(traits *FieldTraits, v reflect.Value) error {
 	acc := newAcceptorFuncs()
 	acc.onStructF = func(p Path, v reflect.Value) (bool, error) {
-		if p.Equal(Path{}.Pointer().Field("ServerResponse")) {
+		if isServerResponse(p) {
 			return false, nil
 		}
 		acc, err := newMetafieldAccessor(v)
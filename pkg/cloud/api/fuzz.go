@@ -0,0 +1,220 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+)
+
+// Fuzzer fills objects with random values for property tests of the
+// reflection based functions in this package, e.g. that a resource
+// survives a conversion to another version and back. The values are
+// deterministic for a given seed.
+type Fuzzer struct {
+	rand        *rand.Rand
+	nilChance   float64
+	maxElements int
+	traits      *FieldTraits
+	enums       []fuzzEnum
+	required    []Path
+}
+
+type fuzzEnum struct {
+	path   Path
+	values []any
+}
+
+// FuzzOption is an option to NewFuzzer().
+type FuzzOption func(*Fuzzer)
+
+// FuzzNilChance sets the probability (0 to 1) that a pointer, slice or map
+// is left nil. The default is 0.2.
+func FuzzNilChance(p float64) FuzzOption { return func(f *Fuzzer) { f.nilChance = p } }
+
+// FuzzMaxElements sets the maximum number of elements in slices and maps.
+// The default is 2.
+func FuzzMaxElements(n int) FuzzOption { return func(f *Fuzzer) { f.maxElements = n } }
+
+// FuzzTraits skips the OutputOnly and System fields in dt and fills in the
+// NullFields and ForceSendFields for the zero value fields so that the
+// object is valid for Access(). All of the structs must have the metafields.
+func FuzzTraits(dt *FieldTraits) FuzzOption { return func(f *Fuzzer) { f.traits = dt } }
+
+// FuzzEnum sets the field at p (which may contain wildcards) to one of the
+// values, e.g. the enum values of .LoadBalancingScheme.
+func FuzzEnum(p Path, values ...any) FuzzOption {
+	return func(f *Fuzzer) { f.enums = append(f.enums, fuzzEnum{path: p, values: values}) }
+}
+
+// FuzzRequired makes the field at p (which may contain wildcards) always
+// non-zero.
+func FuzzRequired(p Path) FuzzOption {
+	return func(f *Fuzzer) { f.required = append(f.required, p) }
+}
+
+// NewFuzzer returns a Fuzzer with the random seed.
+func NewFuzzer(seed int64, opts ...FuzzOption) *Fuzzer {
+	f := &Fuzzer{
+		rand:        rand.New(rand.NewSource(seed)),
+		nilChance:   0.2,
+		maxElements: 2,
+	}
+	for _, o := range opts {
+		o(f)
+	}
+	return f
+}
+
+// Fuzz fills obj, which must be a pointer to a struct, with random values.
+// The existing values of obj are overwritten.
+func (f *Fuzzer) Fuzz(obj any) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Fuzz: obj must be a pointer to a struct, got %T", obj)
+	}
+	v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	if err := f.fuzzStruct(Path{}.Pointer(), v.Elem()); err != nil {
+		return err
+	}
+	if f.traits != nil {
+		return fillNullAndForceSend(f.traits, v)
+	}
+	return nil
+}
+
+func (f *Fuzzer) isRequired(p Path) bool {
+	for _, r := range f.required {
+		if p.Match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// leaveNil returns true if the container at p should be left nil.
+func (f *Fuzzer) leaveNil(p Path) bool {
+	return !f.isRequired(p) && f.rand.Float64() < f.nilChance
+}
+
+func (f *Fuzzer) numElements(p Path) int {
+	n := f.rand.Intn(f.maxElements + 1)
+	if n == 0 && f.isRequired(p) {
+		n = 1
+	}
+	return n
+}
+
+func (f *Fuzzer) fuzzStruct(p Path, v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		fp := p.Field(v.Type().Field(i).Name)
+		if isNoFillPath(fp) || isServerResponse(fp) || !v.Field(i).CanSet() {
+			continue
+		}
+		if f.traits != nil {
+			switch f.traits.fieldType(fp) {
+			case FieldTypeOutputOnly, FieldTypeSystem:
+				continue
+			}
+		}
+		if err := f.fuzzValue(fp, v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Fuzzer) fuzzValue(p Path, v reflect.Value) error {
+	for _, e := range f.enums {
+		if p.Match(e.path) && len(e.values) > 0 {
+			ev := reflect.ValueOf(e.values[f.rand.Intn(len(e.values))])
+			if ev.Kind() != v.Kind() || !ev.Type().ConvertibleTo(v.Type()) {
+				return fmt.Errorf("Fuzz: enum value for %s is a %s, want %s", p, ev.Type(), v.Type())
+			}
+			v.Set(ev.Convert(v.Type()))
+			return nil
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(f.isRequired(p) || f.rand.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1 + f.rand.Int63n(100))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1 + uint64(f.rand.Int63n(100)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(1+f.rand.Intn(1000)) / 10)
+	case reflect.String:
+		v.SetString(f.randString())
+	case reflect.Pointer:
+		if f.leaveNil(p) {
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		return f.fuzzValue(p.Pointer(), v.Elem())
+	case reflect.Struct:
+		return f.fuzzStruct(p, v)
+	case reflect.Slice:
+		if f.leaveNil(p) {
+			return nil
+		}
+		n := f.numElements(p)
+		if n == 0 {
+			return nil
+		}
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			if err := f.fuzzValue(p.Index(i), v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if f.leaveNil(p) {
+			return nil
+		}
+		n := f.numElements(p)
+		if n == 0 {
+			return nil
+		}
+		v.Set(reflect.MakeMapWithSize(v.Type(), n))
+		for i := 0; i < n; i++ {
+			k := reflect.New(v.Type().Key()).Elem()
+			if err := f.fuzzValue(p.MapIndex("x"), k); err != nil {
+				return err
+			}
+			e := reflect.New(v.Type().Elem()).Elem()
+			if err := f.fuzzValue(p.MapIndex(k), e); err != nil {
+				return err
+			}
+			v.SetMapIndex(k, e)
+		}
+	default:
+		return fmt.Errorf("Fuzz: unsupported type %s at %s", v.Type(), p)
+	}
+	return nil
+}
+
+func (f *Fuzzer) randString() string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, 1+f.rand.Intn(8))
+	for i := range b {
+		b[i] = letters[f.rand.Intn(len(letters))]
+	}
+	return string(b)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type fuzzInner struct {
+	S               string
+	B               bool
+	NullFields      []string
+	ForceSendFields []string
+}
+
+type fuzzGA struct {
+	Name            string
+	I               int64
+	F               float64
+	Mode            string
+	In              *fuzzInner
+	L               []*fuzzInner
+	LS              []string
+	M               map[string]string
+	SelfLink        string
+	NullFields      []string
+	ForceSendFields []string
+}
+
+type fuzzAlpha struct {
+	Name            string
+	I               int64
+	F               float64
+	Mode            string
+	In              *fuzzInner
+	L               []*fuzzInner
+	LS              []string
+	M               map[string]string
+	SelfLink        string
+	AlphaOnly       string
+	NullFields      []string
+	ForceSendFields []string
+}
+
+func TestFuzz(t *testing.T) {
+	t.Parallel()
+
+	mode := Path{}.Pointer().Field("Mode")
+	in := Path{}.Pointer().Field("In")
+	traits := NewFieldTraits()
+	traits.OutputOnly(Path{}.Pointer().Field("SelfLink"))
+
+	fuzz := func(seed int64) *fuzzGA {
+		ret := &fuzzGA{}
+		f := NewFuzzer(seed, FuzzEnum(mode, "A", "B"), FuzzRequired(in), FuzzTraits(traits))
+		if err := f.Fuzz(ret); err != nil {
+			t.Fatalf("Fuzz() = %v, want nil", err)
+		}
+		return ret
+	}
+	for seed := int64(0); seed < 20; seed++ {
+		x := fuzz(seed)
+		if diff := cmp.Diff(x, fuzz(seed)); diff != "" {
+			t.Errorf("seed %d: Fuzz() is not deterministic; -got,+want: %s", seed, diff)
+		}
+		if x.Mode != "A" && x.Mode != "B" {
+			t.Errorf("seed %d: Mode = %q, want A or B", seed, x.Mode)
+		}
+		if x.In == nil {
+			t.Errorf("seed %d: In = nil, want required field set", seed)
+		}
+		if x.SelfLink != "" {
+			t.Errorf("seed %d: SelfLink = %q, want OutputOnly field to be empty", seed, x.SelfLink)
+		}
+	}
+
+	if err := NewFuzzer(0).Fuzz(fuzzGA{}); err == nil {
+		t.Error("Fuzz(non-pointer) = nil, want error")
+	}
+	if err := NewFuzzer(0, FuzzEnum(mode, 1)).Fuzz(&fuzzGA{}); err == nil {
+		t.Error("Fuzz() with invalid enum value = nil, want error")
+	}
+}
+
+// ignoreMetafields ignores the NullFields and ForceSendFields in cmp.
+var ignoreMetafields = cmp.FilterPath(func(p cmp.Path) bool {
+	sf, ok := p.Last().(cmp.StructField)
+	return ok && (sf.Name() == "NullFields" || sf.Name() == "ForceSendFields")
+}, cmp.Ignore())
+
+// TestFuzzResourceProperties checks the conversion, diff and serialization
+// of randomized resources.
+func TestFuzzResourceProperties(t *testing.T) {
+	t.Parallel()
+
+	for seed := int64(0); seed < 100; seed++ {
+		ga := &fuzzGA{}
+		if err := NewFuzzer(seed, FuzzNilChance(0.3), FuzzMaxElements(3)).Fuzz(ga); err != nil {
+			t.Fatalf("seed %d: Fuzz() = %v, want nil", seed, err)
+		}
+
+		res := newTestResource[fuzzGA, fuzzAlpha, fuzzGA](nil)
+		if err := res.Set(ga); err != nil {
+			t.Fatalf("seed %d: Set() = %v, want nil", seed, err)
+		}
+		r, err := res.Freeze()
+		if err != nil {
+			t.Fatalf("seed %d: Freeze() = %v, want nil", seed, err)
+		}
+
+		if got, err := r.ToGA(); err != nil || !cmp.Equal(got, ga) {
+			t.Errorf("seed %d: ToGA() = %+v, %v; want %+v, nil", seed, got, err, ga)
+		}
+
+		// The GA fields are preserved by the conversion to Alpha.
+		alpha, err := r.ToAlpha()
+		if err != nil {
+			t.Fatalf("seed %d: ToAlpha() = %v, want nil", seed, err)
+		}
+		b, err := json.Marshal(alpha)
+		if err != nil {
+			t.Fatalf("seed %d: json.Marshal() = %v, want nil", seed, err)
+		}
+		gotGA := &fuzzGA{}
+		if err := json.Unmarshal(b, gotGA); err != nil {
+			t.Fatalf("seed %d: json.Unmarshal() = %v, want nil", seed, err)
+		}
+		if diff := cmp.Diff(ga, gotGA, ignoreMetafields); diff != "" {
+			t.Errorf("seed %d: GA => Alpha; -want,+got: %s", seed, diff)
+		}
+
+		// JSON round trip, and the result has no diff with the original.
+		b, err = r.(*resource[fuzzGA, fuzzAlpha, fuzzGA]).MarshalJSON()
+		if err != nil {
+			t.Fatalf("seed %d: MarshalJSON() = %v, want nil", seed, err)
+		}
+		r2, err := ResourceFromJSON[fuzzGA, fuzzAlpha, fuzzGA](b, nil)
+		if err != nil {
+			t.Fatalf("seed %d: ResourceFromJSON() = %v, want nil", seed, err)
+		}
+		if got, err := r2.ToGA(); err != nil || !cmp.Equal(got, ga) {
+			t.Errorf("seed %d: JSON round trip = %+v, %v; want %+v, nil", seed, got, err, ga)
+		}
+		dr, err := r.Diff(r2)
+		if err != nil {
+			t.Fatalf("seed %d: Diff() = %v, want nil", seed, err)
+		}
+		if dr.HasDiff() {
+			t.Errorf("seed %d: Diff() = %+v, want no diff", seed, dr)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MergeGA returns a MutableResource with the fields of current overlaid
+// with the fields of the sparse object. This allows a caller that only
+// manages some of the fields to compute the full resource to send:
+//
+//   - Fields that are set in sparse replace the field in current. Structs
+//     (and pointers to structs) are merged recursively, slices and maps are
+//     replaced as a whole.
+//   - Fields in sparse.NullFields are cleared and added to NullFields.
+//   - Zero valued fields in sparse.ForceSendFields are set to the zero
+//     value and added to ForceSendFields.
+//   - Other fields are left as they are in current.
+//
+// current must be representable in GA. current is not modified.
+func MergeGA[GA any, Alpha any, Beta any](current Resource[GA, Alpha, Beta], sparse *GA) (MutableResource[GA, Alpha, Beta], error) {
+	return mergeResource(current, current.ToGA, sparse, func(u *mutableResource[GA, Alpha, Beta], x *GA) error { return u.Set(x) })
+}
+
+// MergeAlpha is MergeGA() for the Alpha version.
+func MergeAlpha[GA any, Alpha any, Beta any](current Resource[GA, Alpha, Beta], sparse *Alpha) (MutableResource[GA, Alpha, Beta], error) {
+	return mergeResource(current, current.ToAlpha, sparse, func(u *mutableResource[GA, Alpha, Beta], x *Alpha) error { return u.SetAlpha(x) })
+}
+
+// MergeBeta is MergeGA() for the Beta version.
+func MergeBeta[GA any, Alpha any, Beta any](current Resource[GA, Alpha, Beta], sparse *Beta) (MutableResource[GA, Alpha, Beta], error) {
+	return mergeResource(current, current.ToBeta, sparse, func(u *mutableResource[GA, Alpha, Beta], x *Beta) error { return u.SetBeta(x) })
+}
+
+func mergeResource[GA any, Alpha any, Beta any, T any](
+	current Resource[GA, Alpha, Beta],
+	get func() (*T, error),
+	sparse *T,
+	set func(*mutableResource[GA, Alpha, Beta], *T) error,
+) (MutableResource[GA, Alpha, Beta], error) {
+	cur, err := get()
+	if err != nil {
+		return nil, fmt.Errorf("Merge: %w", err)
+	}
+	// Copy so that the merge does not modify current.
+	x := new(T)
+	if err := newCopier().do(reflect.ValueOf(x), reflect.ValueOf(cur)); err != nil {
+		return nil, fmt.Errorf("Merge: %w", err)
+	}
+	if err := mergeStruct(Path{}.Pointer(), reflect.ValueOf(x).Elem(), reflect.ValueOf(sparse).Elem()); err != nil {
+		return nil, fmt.Errorf("Merge: %w", err)
+	}
+
+	var tt TypeTrait[GA, Alpha, Beta]
+	if r, ok := current.(*resource[GA, Alpha, Beta]); ok {
+		tt = r.x.typeTrait
+	}
+	u := NewResource(current.ResourceID(), tt)
+	if err := set(u, x); err != nil {
+		return nil, fmt.Errorf("Merge: %w", err)
+	}
+	// The result is the desired state and is validated in Freeze().
+	u.fromServer = false
+	return u, nil
+}
+
+// mergeStruct overlays the fields of src onto dest. See MergeGA().
+func mergeStruct(p Path, dest, src reflect.Value) error {
+	if dest.Kind() != reflect.Struct || dest.Type() != src.Type() {
+		return fmt.Errorf("mergeStruct %s: invalid types (dest %s, src %s)", p, dest.Type(), src.Type())
+	}
+	srcAcc, _ := newMetafieldAccessor(src)
+	destAcc, _ := newMetafieldAccessor(dest)
+
+	for i := 0; i < src.NumField(); i++ {
+		name := src.Type().Field(i).Name
+		fp := p.Field(name)
+		if name == nullFieldsName || name == forceSendFieldsName || isServerResponse(fp) {
+			continue
+		}
+		sf := src.Field(i)
+		df := dest.Field(i)
+
+		switch {
+		case srcAcc != nil && srcAcc.inNull(name):
+			df.Set(reflect.Zero(df.Type()))
+			destAcc.setMeta(name, true)
+		case !sf.IsZero():
+			switch {
+			case sf.Kind() == reflect.Struct:
+				if err := mergeStruct(fp, df, sf); err != nil {
+					return err
+				}
+			case sf.Kind() == reflect.Pointer && sf.Elem().Kind() == reflect.Struct && !df.IsNil():
+				if err := mergeStruct(fp.Pointer(), df.Elem(), sf.Elem()); err != nil {
+					return err
+				}
+			default:
+				df.Set(sf)
+			}
+			destAcc.clearMeta(name)
+		case srcAcc != nil && srcAcc.inForceSend(name):
+			df.Set(reflect.Zero(df.Type()))
+			destAcc.setMeta(name, false)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		I               int
+		S               string
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type st struct {
+		Name            string
+		I               int
+		S               string
+		L               []string
+		M               map[string]string
+		In              *inner
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	newCurrent := func() Resource[st, st, st] {
+		t.Helper()
+		res := newTestResource[st, st, st](nil)
+		if err := res.Set(&st{
+			Name: "obj-1",
+			I:    1,
+			S:    "s",
+			L:    []string{"a", "b"},
+			M:    map[string]string{"k": "v"},
+			In:   &inner{I: 2, S: "t"},
+		}); err != nil {
+			t.Fatalf("Set() = %v, want nil", err)
+		}
+		r, err := res.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v, want nil", err)
+		}
+		return r
+	}
+
+	for _, tc := range []struct {
+		name   string
+		sparse st
+		want   st
+	}{
+		{
+			name: "empty",
+			want: st{Name: "obj-1", I: 1, S: "s", L: []string{"a", "b"}, M: map[string]string{"k": "v"}, In: &inner{I: 2, S: "t"}},
+		},
+		{
+			name:   "overlay",
+			sparse: st{I: 10, L: []string{"c"}, M: map[string]string{"x": "y"}, In: &inner{S: "u"}},
+			want:   st{Name: "obj-1", I: 10, S: "s", L: []string{"c"}, M: map[string]string{"x": "y"}, In: &inner{I: 2, S: "u"}},
+		},
+		{
+			name:   "null fields",
+			sparse: st{NullFields: []string{"L", "In"}},
+			want:   st{Name: "obj-1", I: 1, S: "s", M: map[string]string{"k": "v"}, NullFields: []string{"In", "L"}},
+		},
+		{
+			name:   "force send fields",
+			sparse: st{ForceSendFields: []string{"I"}, In: &inner{ForceSendFields: []string{"S"}}},
+			want: st{
+				Name: "obj-1", S: "s", L: []string{"a", "b"}, M: map[string]string{"k": "v"},
+				In:              &inner{I: 2, ForceSendFields: []string{"S"}},
+				ForceSendFields: []string{"I"},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			current := newCurrent()
+			m, err := MergeGA(current, &tc.sparse)
+			if err != nil {
+				t.Fatalf("MergeGA() = %v, want nil", err)
+			}
+			got, err := m.ToGA()
+			if err != nil {
+				t.Fatalf("ToGA() = %v, want nil", err)
+			}
+			if diff := cmp.Diff(got, &tc.want); diff != "" {
+				t.Errorf("MergeGA(): -got,+want: %s", diff)
+			}
+			// current is not modified.
+			cur, _ := current.ToGA()
+			if cur.I != 1 || cur.In.S != "t" || len(cur.NullFields) != 0 {
+				t.Errorf("current was modified: %+v", cur)
+			}
+		})
+	}
+}
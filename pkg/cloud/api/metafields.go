@@ -19,6 +19,7 @@ package api
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 )
 
 const (
@@ -80,3 +81,99 @@ func (a *metafieldAccessor) inForceSend(f string) bool {
 	}
 	return false
 }
+
+// setMeta adds f to NullFields if null is true, to ForceSendFields
+// otherwise. f is removed from the other list. a may be nil.
+func (a *metafieldAccessor) setMeta(f string, null bool) {
+	if a == nil {
+		return
+	}
+	a.clearMeta(f)
+	l := a.forceSendFields
+	if null {
+		l = a.nullFields
+	}
+	l.Set(reflect.Append(l, reflect.ValueOf(f)))
+}
+
+// clearMeta removes f from NullFields and ForceSendFields. a may be nil.
+func (a *metafieldAccessor) clearMeta(f string) {
+	if a == nil {
+		return
+	}
+	for _, l := range []reflect.Value{a.nullFields, a.forceSendFields} {
+		var ret []string
+		for _, x := range l.Interface().([]string) {
+			if x != f {
+				ret = append(ret, x)
+			}
+		}
+		l.Set(reflect.ValueOf(ret))
+	}
+}
+
+// setMetafield sets the field at p in v (a pointer to the resource struct)
+// to the zero value if clear is true and adds it to NullFields
+// (clear=true) or ForceSendFields (clear=false) of the struct containing
+// the field. Nil pointers on the path are allocated. Returns false if the
+// field does not exist in the type.
+func setMetafield(v reflect.Value, p Path, clear bool) (bool, error) {
+	if _, err := p.ResolveType(v.Type()); err != nil {
+		return false, nil
+	}
+	if len(p) == 0 || p[len(p)-1][0] != pathField {
+		return false, fmt.Errorf("path %s is not a field", p)
+	}
+	for i, x := range p[:len(p)-1] {
+		switch x[0] {
+		case pathPointer:
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		case pathField:
+			v = v.FieldByName(x[1:])
+		case pathSliceIndex:
+			idx, err := strconv.Atoi(x[1:])
+			if err != nil || idx < 0 || idx >= v.Len() {
+				return false, fmt.Errorf("at %s element %d, invalid index %q", p, i, x[1:])
+			}
+			v = v.Index(idx)
+		default:
+			return false, fmt.Errorf("at %s element %d, unsupported path element %q", p, i, x)
+		}
+	}
+	acc, err := newMetafieldAccessor(v)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", p, err)
+	}
+	name := p[len(p)-1][1:]
+	if clear {
+		fv := v.FieldByName(name)
+		fv.Set(reflect.Zero(fv.Type()))
+	}
+	acc.setMeta(name, clear)
+	return true, nil
+}
+
+// pruneNullFields removes the non-zero fields from the NullFields in v.
+// This allows a field cleared with ClearField() to be set again.
+func pruneNullFields(v reflect.Value) error {
+	acc := newAcceptorFuncs()
+	acc.onStructF = func(p Path, v reflect.Value) (bool, error) {
+		if isServerResponse(p) {
+			return false, nil
+		}
+		mfa, err := newMetafieldAccessor(v)
+		if err != nil || !mfa.nullFields.CanSet() {
+			return true, nil
+		}
+		for _, f := range mfa.nullFields.Interface().([]string) {
+			if fv := v.FieldByName(f); fv.IsValid() && !fv.IsZero() {
+				mfa.clearMeta(f)
+			}
+		}
+		return true, nil
+	}
+	return visit(v, acc)
+}
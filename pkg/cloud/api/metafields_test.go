@@ -71,3 +71,77 @@ func TestMetafieldAccessor(t *testing.T) {
 		})
 	}
 }
+
+func TestClearFieldAndEnsureSent(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		S               string
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type ga struct {
+		Name            string
+		I               int
+		S               string
+		In              *inner
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type alph struct {
+		Name            string
+		I               int
+		S               string
+		In              *inner
+		A               string
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	res := newTestResource[ga, alph, ga](nil)
+	for _, p := range []Path{
+		Path{}.Pointer().Field("S"),
+		Path{}.Pointer().Field("In"),
+		Path{}.Pointer().Field("A"),
+	} {
+		if err := res.ClearField(p); err != nil {
+			t.Fatalf("ClearField(%s) = %v, want nil", p, err)
+		}
+	}
+	if err := res.EnsureSent(Path{}.Pointer().Field("I")); err != nil {
+		t.Fatalf("EnsureSent() = %v, want nil", err)
+	}
+	// Set a cleared field again.
+	if err := res.Access(func(x *ga) { x.S = "s" }); err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+
+	gotGA, _ := res.ToGA()
+	wantGA := &ga{Name: "obj-1", S: "s", NullFields: []string{"In"}, ForceSendFields: []string{"I"}}
+	if diff := cmp.Diff(gotGA, wantGA); diff != "" {
+		t.Errorf("ToGA(): -got,+want: %s", diff)
+	}
+	gotAlpha, _ := res.ToAlpha()
+	wantAlpha := &alph{Name: "obj-1", S: "s", NullFields: []string{"A", "In"}, ForceSendFields: []string{"I"}}
+	if diff := cmp.Diff(gotAlpha, wantAlpha); diff != "" {
+		t.Errorf("ToAlpha(): -got,+want: %s", diff)
+	}
+
+	// Nested fields allocate the parent struct.
+	if err := res.EnsureSent(Path{}.Pointer().Field("In").Pointer().Field("S")); err != nil {
+		t.Fatalf("EnsureSent() = %v, want nil", err)
+	}
+	gotGA, _ = res.ToGA()
+	if diff := cmp.Diff(gotGA.In, &inner{ForceSendFields: []string{"S"}}); diff != "" {
+		t.Errorf("ToGA().In: -got,+want: %s", diff)
+	}
+
+	for _, p := range []Path{
+		Path{}.Pointer().Field("X"),
+		Path{}.Pointer(),
+	} {
+		if err := res.ClearField(p); err == nil {
+			t.Errorf("ClearField(%s) = nil, want error", p)
+		}
+	}
+}
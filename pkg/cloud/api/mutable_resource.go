@@ -19,6 +19,8 @@ package api
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
@@ -37,11 +39,46 @@ const (
 	conversionContextCount // Sentinel value used to size arrays.
 )
 
+var conversionContextVersions = [conversionContextCount][2]meta.Version{
+	GAToAlphaConversion:   {meta.VersionGA, meta.VersionAlpha},
+	GAToBetaConversion:    {meta.VersionGA, meta.VersionBeta},
+	AlphaToGAConversion:   {meta.VersionAlpha, meta.VersionGA},
+	AlphaToBetaConversion: {meta.VersionAlpha, meta.VersionBeta},
+	BetaToGAConversion:    {meta.VersionBeta, meta.VersionGA},
+	BetaToAlphaConversion: {meta.VersionBeta, meta.VersionAlpha},
+}
+
+// From is the version of the source of the conversion.
+func (c ConversionContext) From() meta.Version {
+	if c < 0 || c >= conversionContextCount {
+		return ""
+	}
+	return conversionContextVersions[c][0]
+}
+
+// To is the version of the destination of the conversion.
+func (c ConversionContext) To() meta.Version {
+	if c < 0 || c >= conversionContextCount {
+		return ""
+	}
+	return conversionContextVersions[c][1]
+}
+
+// String implements Stringer.
+func (c ConversionContext) String() string {
+	if c < 0 || c >= conversionContextCount {
+		return fmt.Sprintf("ConversionContext(%d)", int(c))
+	}
+	return fmt.Sprintf("%s => %s", c.From(), c.To())
+}
+
 // ConversionError is returned from To*() methods. Inspect this error to get
 // more details on what did not convert.
 type ConversionError struct {
+	// Version requested.
+	Version meta.Version
 	// MissingFields is a list of field values that were set but did not
-	// translate to the version requested.
+	// translate to the version requested, sorted by Path.
 	MissingFields []MissingField
 }
 
@@ -51,7 +88,35 @@ func (e *ConversionError) hasErr() bool {
 
 // Error implements error.
 func (e *ConversionError) Error() string {
-	return fmt.Sprintf("ConversionError: missing fields %v", e.MissingFields)
+	var l []string
+	for _, mf := range e.MissingFields {
+		l = append(l, mf.String())
+	}
+	return fmt.Sprintf("ConversionError: fields cannot be represented in %s: %s", e.Version, strings.Join(l, ", "))
+}
+
+// Paths of the missing fields.
+func (e *ConversionError) Paths() []Path {
+	var ret []Path
+	for _, mf := range e.MissingFields {
+		ret = append(ret, mf.Path)
+	}
+	return ret
+}
+
+// SourceVersions are the versions in which the missing fields are set. The
+// caller can use one of these versions instead (see Resource.AsVersion()) to
+// avoid losing the fields.
+func (e *ConversionError) SourceVersions() []meta.Version {
+	var ret []meta.Version
+	seen := map[meta.Version]bool{}
+	for _, mf := range e.MissingFields {
+		if v := mf.Context.From(); !seen[v] {
+			seen[v] = true
+			ret = append(ret, v)
+		}
+	}
+	return ret
 }
 
 // useOfPlaceholderTypeError is raised when code attempts to convert or operate
@@ -80,6 +145,11 @@ type MissingField struct {
 	Value any
 }
 
+// String implements Stringer.
+func (mf MissingField) String() string {
+	return fmt.Sprintf("%s = %v (%s)", mf.Path, mf.Value, mf.Context)
+}
+
 type conversionErrors struct {
 	missingFields []missingFieldOnCopy
 }
@@ -141,6 +211,17 @@ type MutableResource[GA any, Alpha any, Beta any] interface {
 	// AccessBeta resource.
 	AccessBeta(f func(x *Beta)) error
 
+	// ClearField sets the field at p (e.g. Path{}.Pointer().Field("Description"))
+	// to the zero value and adds it to the NullFields of the struct that
+	// contains it so that the field is cleared on the server. The change is
+	// made in all versions that have the field. A field that is set again
+	// with Access*() is removed from the NullFields.
+	ClearField(p Path) error
+	// EnsureSent adds the field at p to the ForceSendFields of the struct
+	// that contains it so that the field is sent even if it is the zero
+	// value, e.g. a false bool.
+	EnsureSent(p Path) error
+
 	// ToGA returns the GA version of this resource. Use error.As
 	// ConversionError to get the specific details.
 	ToGA() (*GA, error)
@@ -166,7 +247,8 @@ type MutableResource[GA any, Alpha any, Beta any] interface {
 
 	// Freeze the resource to a read-only copy. It is an error if it is ambiguous
 	// which version is the correct one i.e. not all fields can be represented in a
-	// single version of the resource.
+	// single version of the resource. Returns a ValidationError with all of the
+	// violations if the resource is not valid (see RegisterValidation()).
 	Freeze() (Resource[GA, Alpha, Beta], error)
 }
 
@@ -180,6 +262,9 @@ type mutableResource[GA any, Alpha any, Beta any] struct {
 
 	resourceID *cloud.ResourceID
 	errors     [conversionContextCount]conversionErrors
+	// fromServer is true if the resource was last changed by Set*(). The
+	// validations are not run for these.
+	fromServer bool
 }
 
 func (u *mutableResource[GA, Alpha, Beta]) CheckSchema() error {
@@ -293,6 +378,9 @@ func (u *mutableResource[GA, Alpha, Beta]) postAccess(srcVer meta.Version, flags
 	}
 
 	if flags&postAccessSkipValidation == 0 {
+		if err := pruneNullFields(src); err != nil {
+			return err
+		}
 		if err := checkPostAccess(u.typeTrait.FieldTraits(srcVer), src); err != nil {
 			return err
 		}
@@ -305,6 +393,10 @@ func (u *mutableResource[GA, Alpha, Beta]) postAccess(srcVer meta.Version, flags
 		if err := conv.copyHelper(); err != nil {
 			return err
 		}
+		// The copy merges the metafields, drop the fields that were set.
+		if err := pruneNullFields(conv.dest); err != nil {
+			return err
+		}
 		conv.errors.missingFields = c.missing
 	}
 
@@ -313,16 +405,19 @@ func (u *mutableResource[GA, Alpha, Beta]) postAccess(srcVer meta.Version, flags
 
 func (u *mutableResource[GA, Alpha, Beta]) Access(f func(x *GA)) error {
 	f(&u.ga)
+	u.fromServer = false
 	return u.postAccess(meta.VersionGA, 0)
 }
 
 func (u *mutableResource[GA, Alpha, Beta]) AccessAlpha(f func(x *Alpha)) error {
 	f(&u.alpha)
+	u.fromServer = false
 	return u.postAccess(meta.VersionAlpha, 0)
 }
 
 func (u *mutableResource[GA, Alpha, Beta]) AccessBeta(f func(x *Beta)) error {
 	f(&u.beta)
+	u.fromServer = false
 	return u.postAccess(meta.VersionBeta, 0)
 }
 
@@ -384,18 +479,8 @@ func (u *mutableResource[GA, Alpha, Beta]) ImpliedVersion() (meta.Version, error
 }
 
 func (u *mutableResource[GA, Alpha, Beta]) ToGA() (*GA, error) {
-	var errs ConversionError
-	for _, cc := range []ConversionContext{AlphaToGAConversion, BetaToGAConversion} {
-		for _, mf := range u.errors[cc].missingFields {
-			errs.MissingFields = append(errs.MissingFields, MissingField{
-				Context: cc,
-				Path:    mf.Path,
-				Value:   mf.Value,
-			})
-		}
-	}
-	if errs.hasErr() {
-		return &u.ga, &errs
+	if errs := u.conversionError(meta.VersionGA, AlphaToGAConversion, BetaToGAConversion); errs.hasErr() {
+		return &u.ga, errs
 	}
 	return &u.ga, nil
 }
@@ -404,18 +489,8 @@ func (u *mutableResource[GA, Alpha, Beta]) ToAlpha() (*Alpha, error) {
 	if isPlaceholderType(u.alpha) {
 		return nil, useOfPlaceholderTypeError{msg: u.resourceID.String()}
 	}
-	var errs ConversionError
-	for _, cc := range []ConversionContext{GAToAlphaConversion, BetaToAlphaConversion} {
-		for _, mf := range u.errors[cc].missingFields {
-			errs.MissingFields = append(errs.MissingFields, MissingField{
-				Context: cc,
-				Path:    mf.Path,
-				Value:   mf.Value,
-			})
-		}
-	}
-	if errs.hasErr() {
-		return &u.alpha, &errs
+	if errs := u.conversionError(meta.VersionAlpha, GAToAlphaConversion, BetaToAlphaConversion); errs.hasErr() {
+		return &u.alpha, errs
 	}
 	return &u.alpha, nil
 }
@@ -424,8 +499,52 @@ func (u *mutableResource[GA, Alpha, Beta]) ToBeta() (*Beta, error) {
 	if isPlaceholderType(u.beta) {
 		return nil, useOfPlaceholderTypeError{msg: u.resourceID.String()}
 	}
-	var errs ConversionError
-	for _, cc := range []ConversionContext{GAToBetaConversion, AlphaToBetaConversion} {
+	if errs := u.conversionError(meta.VersionBeta, GAToBetaConversion, AlphaToBetaConversion); errs.hasErr() {
+		return &u.beta, errs
+	}
+	return &u.beta, nil
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) ClearField(p Path) error {
+	return u.setMetafield(p, true)
+}
+
+func (u *mutableResource[GA, Alpha, Beta]) EnsureSent(p Path) error {
+	return u.setMetafield(p, false)
+}
+
+// setMetafield updates the field in the first version that has it and
+// propagates the change to the other versions.
+func (u *mutableResource[GA, Alpha, Beta]) setMetafield(p Path, clear bool) error {
+	for _, x := range []struct {
+		ver         meta.Version
+		v           reflect.Value
+		placeholder bool
+	}{
+		{meta.VersionGA, reflect.ValueOf(&u.ga), isPlaceholderType(u.ga)},
+		{meta.VersionBeta, reflect.ValueOf(&u.beta), isPlaceholderType(u.beta)},
+		{meta.VersionAlpha, reflect.ValueOf(&u.alpha), isPlaceholderType(u.alpha)},
+	} {
+		if x.placeholder {
+			continue
+		}
+		ok, err := setMetafield(x.v, p, clear)
+		if err != nil {
+			return err
+		}
+		if ok {
+			u.fromServer = false
+			return u.postAccess(x.ver, postAccessSkipValidation)
+		}
+	}
+	return fmt.Errorf("no field %s in %T", p, u.ga)
+}
+
+// conversionError returns the fields that were lost in the conversions to
+// version.
+func (u *mutableResource[GA, Alpha, Beta]) conversionError(version meta.Version, contexts ...ConversionContext) *ConversionError {
+	errs := &ConversionError{Version: version}
+	for _, cc := range contexts {
 		for _, mf := range u.errors[cc].missingFields {
 			errs.MissingFields = append(errs.MissingFields, MissingField{
 				Context: cc,
@@ -434,10 +553,10 @@ func (u *mutableResource[GA, Alpha, Beta]) ToBeta() (*Beta, error) {
 			})
 		}
 	}
-	if errs.hasErr() {
-		return &u.beta, &errs
-	}
-	return &u.beta, nil
+	sort.SliceStable(errs.MissingFields, func(i, j int) bool {
+		return errs.MissingFields[i].Path.String() < errs.MissingFields[j].Path.String()
+	})
+	return errs
 }
 
 // TODO: Set semantics need to be reworked. The copy over to the other versions
@@ -448,6 +567,7 @@ func (u *mutableResource[GA, Alpha, Beta]) Set(src *GA) error {
 	if err := c.do(reflect.ValueOf(&u.ga), reflect.ValueOf(src)); err != nil {
 		return err
 	}
+	u.fromServer = true
 	return u.postAccess(meta.VersionGA, postAccessSkipValidation)
 }
 
@@ -456,6 +576,7 @@ func (u *mutableResource[GA, Alpha, Beta]) SetAlpha(src *Alpha) error {
 	if err := c.do(reflect.ValueOf(&u.alpha), reflect.ValueOf(src)); err != nil {
 		return err
 	}
+	u.fromServer = true
 	return u.postAccess(meta.VersionAlpha, postAccessSkipValidation)
 }
 
@@ -464,6 +585,7 @@ func (u *mutableResource[GA, Alpha, Beta]) SetBeta(src *Beta) error {
 	if err := c.do(reflect.ValueOf(&u.beta), reflect.ValueOf(src)); err != nil {
 		return err
 	}
+	u.fromServer = true
 	return u.postAccess(meta.VersionBeta, postAccessSkipValidation)
 }
 
@@ -472,6 +594,20 @@ func (u *mutableResource[GA, Alpha, Beta]) Freeze() (Resource[GA, Alpha, Beta],
 	if err != nil {
 		return nil, err
 	}
+	r, err := u.freeze(ver)
+	if err != nil {
+		return nil, err
+	}
+	if !u.fromServer {
+		if err := validate(r, u.typeTrait); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// freeze the resource as version ver.
+func (u *mutableResource[GA, Alpha, Beta]) freeze(ver meta.Version) (Resource[GA, Alpha, Beta], error) {
 	// For the structures in the other versions, fill in
 	// zero-valued fields in the metafields. This ensures that if
 	// the resource can be diff'd and sync'd correctly in all
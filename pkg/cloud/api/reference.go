@@ -0,0 +1,141 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// FieldReference is a string field that holds the URL of another resource.
+// See FieldTraits.Reference().
+type FieldReference struct {
+	// Path to the field. This may contain wildcards.
+	Path Path
+	// Resource is the type of the resource referenced, e.g.
+	// "healthChecks". Empty if the field may reference any type.
+	Resource string
+}
+
+// ReferenceValue is a reference found in a resource by FindReferences().
+type ReferenceValue struct {
+	// Path to the value, without wildcards.
+	Path Path
+	// URL of the referenced resource.
+	URL string
+	// Field that matched.
+	Field FieldReference
+}
+
+// Reference specifies that the string field at path p holds the URL of
+// another resource of the given type (e.g. "healthChecks"; empty for any
+// type). Slices and maps are specified with wildcards, e.g.
+// Path{}.Pointer().Field("Backends").AnySliceIndex().Pointer().Field("Group").
+func (dt *FieldTraits) Reference(p Path, resource string) {
+	dt.references = append(dt.references, FieldReference{Path: p, Resource: resource})
+}
+
+// References returns the fields specified with Reference().
+func (dt *FieldTraits) References() []FieldReference { return dt.references }
+
+// FindReferences returns the non-empty values in obj of the fields specified
+// with Reference(). obj is a pointer to the resource struct. The values are
+// returned in the order the fields were specified.
+func (dt *FieldTraits) FindReferences(obj any) ([]ReferenceValue, error) {
+	var ret []ReferenceValue
+	for _, ref := range dt.references {
+		err := walkPath(Path{}, ref.Path, reflect.ValueOf(obj), func(p Path, v reflect.Value) error {
+			if v.Kind() != reflect.String {
+				return fmt.Errorf("FindReferences: %s is not a string (%s)", p, v.Type())
+			}
+			if v.String() != "" {
+				ret = append(ret, ReferenceValue{Path: p, URL: v.String(), Field: ref})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// walkPath calls f for each value in v that matches the path. cur is the
+// path to v. Nil pointers and missing map keys are skipped. Map keys are
+// visited in sorted order.
+func walkPath(cur, path Path, v reflect.Value, f func(Path, reflect.Value) error) error {
+	if len(path) == 0 {
+		return f(cur, v)
+	}
+	// Each call gets a fresh slice as appending to cur may share the
+	// underlying array.
+	next := func(elem string) Path { return append(append(Path{}, cur...), elem) }
+
+	x := path[0]
+	switch x[0] {
+	case pathPointer:
+		if v.Kind() != reflect.Pointer {
+			return fmt.Errorf("walkPath: at %s, expected pointer, got %s", cur, v.Type())
+		}
+		if v.IsNil() {
+			return nil
+		}
+		return walkPath(next(x), path[1:], v.Elem(), f)
+
+	case pathField:
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("walkPath: at %s, expected struct, got %s", cur, v.Type())
+		}
+		fv := v.FieldByName(x[1:])
+		if !fv.IsValid() {
+			return fmt.Errorf("walkPath: at %s, no field %q in %s", cur, x[1:], v.Type())
+		}
+		return walkPath(next(x), path[1:], fv, f)
+
+	case pathSliceIndex:
+		if v.Kind() != reflect.Slice {
+			return fmt.Errorf("walkPath: at %s, expected slice, got %s", cur, v.Type())
+		}
+		for i := 0; i < v.Len(); i++ {
+			if !isMatch(x, fmt.Sprintf("%c%d", pathSliceIndex, i)) {
+				continue
+			}
+			if err := walkPath(next(fmt.Sprintf("%c%d", pathSliceIndex, i)), path[1:], v.Index(i), f); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case pathMapIndex:
+		if v.Kind() != reflect.Map {
+			return fmt.Errorf("walkPath: at %s, expected map, got %s", cur, v.Type())
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, k := range keys {
+			if !isMatch(x, fmt.Sprintf("%c%v", pathMapIndex, k)) {
+				continue
+			}
+			if err := walkPath(next(fmt.Sprintf("%c%v", pathMapIndex, k)), path[1:], v.MapIndex(k), f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("walkPath: invalid path element %q in %s", x, path)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindReferences(t *testing.T) {
+	t.Parallel()
+
+	type sti struct {
+		Group string
+	}
+	type st struct {
+		URL  string
+		URLs []string
+		LSt  []*sti
+		M    map[string]string
+		P    *sti
+	}
+
+	traits := &FieldTraits{}
+	traits.Reference(Path{}.Pointer().Field("URL"), "healthChecks")
+	traits.Reference(Path{}.Pointer().Field("URLs").AnySliceIndex(), "")
+	traits.Reference(Path{}.Pointer().Field("LSt").AnySliceIndex().Pointer().Field("Group"), "")
+	traits.Reference(Path{}.Pointer().Field("M").AnyMapIndex(), "")
+	traits.Reference(Path{}.Pointer().Field("P").Pointer().Field("Group"), "")
+
+	if err := traits.CheckSchema(reflect.TypeOf(&st{})); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		obj     *st
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", obj: &st{}},
+		{
+			name: "all fields",
+			obj: &st{
+				URL:  "a",
+				URLs: []string{"b", "", "c"},
+				LSt:  []*sti{{Group: "d"}, nil, {}},
+				M:    map[string]string{"y": "f", "x": "e"},
+				P:    &sti{Group: "g"},
+			},
+			want: []string{
+				"*.URL=a",
+				"*.URLs!0=b",
+				"*.URLs!2=c",
+				"*.LSt!0*.Group=d",
+				"*.M:x=e",
+				"*.M:y=f",
+				"*.P*.Group=g",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			refs, err := traits.FindReferences(tc.obj)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("FindReferences() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			var got []string
+			for _, r := range refs {
+				got = append(got, r.Path.String()+"="+r.URL)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("FindReferences() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("not a string", func(t *testing.T) {
+		traits := &FieldTraits{}
+		traits.Reference(Path{}.Pointer().Field("LSt"), "")
+		if _, err := traits.FindReferences(&st{LSt: []*sti{{}}}); err == nil {
+			t.Error("FindReferences() = nil, want error")
+		}
+		if err := traits.CheckSchema(reflect.TypeOf(&st{})); err == nil {
+			t.Error("CheckSchema() = nil, want error")
+		}
+	})
+}
@@ -36,6 +36,12 @@ type Resource[GA any, Alpha any, Beta any] interface {
 	ToAlpha() (*Alpha, error)
 	ToBeta() (*Beta, error)
 
+	// AsVersion returns the resource as the given version. This is used
+	// to send the resource using a specific API version (e.g. to manage a
+	// Beta-only field). Returns a ConversionError if fields that are set
+	// cannot be represented in ver.
+	AsVersion(ver meta.Version) (Resource[GA, Alpha, Beta], error)
+
 	// Diff obtains the difference between this resource and
 	// other, taking into account the versions of the resources
 	// being compared. Cross Alpha and Beta comparisons are not
@@ -58,6 +64,28 @@ func (obj *resource[GA, Alpha, Beta]) ToGA() (*GA, error)            { return ob
 func (obj *resource[GA, Alpha, Beta]) ToAlpha() (*Alpha, error)      { return obj.x.ToAlpha() }
 func (obj *resource[GA, Alpha, Beta]) ToBeta() (*Beta, error)        { return obj.x.ToBeta() }
 
+// AsVersion implements Resource.
+func (obj *resource[GA, Alpha, Beta]) AsVersion(ver meta.Version) (Resource[GA, Alpha, Beta], error) {
+	if ver == obj.ver {
+		return obj, nil
+	}
+	var err error
+	switch ver {
+	case meta.VersionGA:
+		_, err = obj.ToGA()
+	case meta.VersionAlpha:
+		_, err = obj.ToAlpha()
+	case meta.VersionBeta:
+		_, err = obj.ToBeta()
+	default:
+		return nil, fmt.Errorf("Resource.AsVersion: invalid version %q", ver)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Resource.AsVersion(%s): %w", ver, err)
+	}
+	return &resource[GA, Alpha, Beta]{x: obj.x, ver: ver}, nil
+}
+
 // Diff implements Resource.
 func (obj *resource[GA, Alpha, Beta]) Diff(other Resource[GA, Alpha, Beta]) (*DiffResult, error) {
 	switch {
@@ -0,0 +1,257 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"gopkg.in/yaml.v2"
+)
+
+// resourceJSON is the serialized form of a Resource.
+type resourceJSON struct {
+	Version    meta.Version      `json:"version"`
+	ResourceID *cloud.ResourceID `json:"resourceID"`
+	// Object is the resource in Version.
+	Object json.RawMessage `json:"object"`
+	// MetaFields are the NullFields and ForceSendFields of the structs in
+	// Object, keyed by the Path of the struct. These are not part of the
+	// JSON encoding of the API types.
+	MetaFields map[string]jsonMetaFields `json:"metaFields,omitempty"`
+}
+
+type jsonMetaFields struct {
+	NullFields      []string `json:"nullFields,omitempty"`
+	ForceSendFields []string `json:"forceSendFields,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The version, ResourceID and the
+// NullFields and ForceSendFields of the resource are preserved; use
+// ResourceFromJSON() to restore the Resource.
+func (obj *resource[GA, Alpha, Beta]) MarshalJSON() ([]byte, error) {
+	var v reflect.Value
+	switch obj.ver {
+	case meta.VersionGA:
+		v = reflect.ValueOf(&obj.x.ga)
+	case meta.VersionAlpha:
+		v = reflect.ValueOf(&obj.x.alpha)
+	case meta.VersionBeta:
+		v = reflect.ValueOf(&obj.x.beta)
+	default:
+		return nil, fmt.Errorf("Resource.MarshalJSON: invalid version %q", obj.ver)
+	}
+	object, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("Resource.MarshalJSON: %w", err)
+	}
+	mf, err := saveMetaFields(v)
+	if err != nil {
+		return nil, fmt.Errorf("Resource.MarshalJSON: %w", err)
+	}
+	return json.Marshal(&resourceJSON{
+		Version:    obj.ver,
+		ResourceID: obj.ResourceID(),
+		Object:     object,
+		MetaFields: mf,
+	})
+}
+
+// ResourceFromJSON restores a Resource serialized with json.Marshal().
+// typeTrait should be the same as the one used to create the resource.
+func ResourceFromJSON[GA any, Alpha any, Beta any](data []byte, typeTrait TypeTrait[GA, Alpha, Beta]) (Resource[GA, Alpha, Beta], error) {
+	var rj resourceJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return nil, fmt.Errorf("ResourceFromJSON: %w", err)
+	}
+	if rj.ResourceID == nil || rj.ResourceID.Key == nil {
+		return nil, fmt.Errorf("ResourceFromJSON: missing resourceID")
+	}
+	u := NewResource(rj.ResourceID, typeTrait)
+
+	var err error
+	switch rj.Version {
+	case meta.VersionGA:
+		err = unmarshalObject(rj, u.Set)
+	case meta.VersionAlpha:
+		if isPlaceholderType(u.alpha) {
+			return nil, fmt.Errorf("ResourceFromJSON: %w", useOfPlaceholderTypeError{msg: rj.ResourceID.String()})
+		}
+		err = unmarshalObject(rj, u.SetAlpha)
+	case meta.VersionBeta:
+		if isPlaceholderType(u.beta) {
+			return nil, fmt.Errorf("ResourceFromJSON: %w", useOfPlaceholderTypeError{msg: rj.ResourceID.String()})
+		}
+		err = unmarshalObject(rj, u.SetBeta)
+	default:
+		return nil, fmt.Errorf("ResourceFromJSON: invalid version %q", rj.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ResourceFromJSON: %w", err)
+	}
+	return u.freeze(rj.Version)
+}
+
+// unmarshalObject decodes rj.Object and its metafields and calls set with
+// the result.
+func unmarshalObject[T any](rj resourceJSON, set func(*T) error) error {
+	x := new(T)
+	if err := json.Unmarshal(rj.Object, x); err != nil {
+		return err
+	}
+	if err := restoreMetaFields(reflect.ValueOf(x), rj.MetaFields); err != nil {
+		return err
+	}
+	return set(x)
+}
+
+// saveMetaFields returns the non-empty metafields of the structs in v.
+func saveMetaFields(v reflect.Value) (map[string]jsonMetaFields, error) {
+	ret := map[string]jsonMetaFields{}
+	acc := newAcceptorFuncs()
+	acc.onStructF = func(p Path, v reflect.Value) (bool, error) {
+		if isServerResponse(p) {
+			return false, nil
+		}
+		mfa, err := newMetafieldAccessor(v)
+		if err != nil {
+			// Not all structs have metafields.
+			return true, nil
+		}
+		mf := jsonMetaFields{
+			NullFields:      mfa.nullFields.Interface().([]string),
+			ForceSendFields: mfa.forceSendFields.Interface().([]string),
+		}
+		if len(mf.NullFields) > 0 || len(mf.ForceSendFields) > 0 {
+			ret[p.String()] = mf
+		}
+		return true, nil
+	}
+	if err := visit(v, acc); err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, nil
+	}
+	return ret, nil
+}
+
+// restoreMetaFields sets the metafields of the structs in v saved by
+// saveMetaFields().
+func restoreMetaFields(v reflect.Value, mfs map[string]jsonMetaFields) error {
+	acc := newAcceptorFuncs()
+	acc.onStructF = func(p Path, v reflect.Value) (bool, error) {
+		if isServerResponse(p) {
+			return false, nil
+		}
+		mf, ok := mfs[p.String()]
+		if !ok {
+			return true, nil
+		}
+		mfa, err := newMetafieldAccessor(v)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", p, err)
+		}
+		if !mfa.nullFields.CanSet() {
+			// The struct is a value in a map.
+			return false, fmt.Errorf("%s: metafields cannot be set", p)
+		}
+		mfa.nullFields.Set(reflect.ValueOf(mf.NullFields))
+		mfa.forceSendFields.Set(reflect.ValueOf(mf.ForceSendFields))
+		return true, nil
+	}
+	return visit(v, acc)
+}
+
+// ResourceToYAML returns the YAML encoding of the JSON serialization of the
+// resource (see MarshalJSON()).
+func ResourceToYAML[GA any, Alpha any, Beta any](r Resource[GA, Alpha, Beta]) ([]byte, error) {
+	j, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("ResourceToYAML: %w", err)
+	}
+	var x any
+	d := json.NewDecoder(bytes.NewReader(j))
+	// Keep the integers exact.
+	d.UseNumber()
+	if err := d.Decode(&x); err != nil {
+		return nil, fmt.Errorf("ResourceToYAML: %w", err)
+	}
+	ret, err := yaml.Marshal(fromJSONNumbers(x))
+	if err != nil {
+		return nil, fmt.Errorf("ResourceToYAML: %w", err)
+	}
+	return ret, nil
+}
+
+// ResourceFromYAML restores a Resource serialized with ResourceToYAML().
+func ResourceFromYAML[GA any, Alpha any, Beta any](data []byte, typeTrait TypeTrait[GA, Alpha, Beta]) (Resource[GA, Alpha, Beta], error) {
+	var x any
+	if err := yaml.Unmarshal(data, &x); err != nil {
+		return nil, fmt.Errorf("ResourceFromYAML: %w", err)
+	}
+	j, err := json.Marshal(toJSONValue(x))
+	if err != nil {
+		return nil, fmt.Errorf("ResourceFromYAML: %w", err)
+	}
+	return ResourceFromJSON(j, typeTrait)
+}
+
+// fromJSONNumbers replaces the json.Numbers in x with int64 or float64 so
+// that they are encoded as numbers in YAML.
+func fromJSONNumbers(x any) any {
+	switch x := x.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(x), 10, 64); err == nil {
+			return i
+		}
+		f, _ := x.Float64()
+		return f
+	case map[string]any:
+		for k, v := range x {
+			x[k] = fromJSONNumbers(v)
+		}
+	case []any:
+		for i, v := range x {
+			x[i] = fromJSONNumbers(v)
+		}
+	}
+	return x
+}
+
+// toJSONValue converts the map[any]any decoded by yaml.v2 to
+// map[string]any so x can be encoded as JSON.
+func toJSONValue(x any) any {
+	switch x := x.(type) {
+	case map[any]any:
+		ret := map[string]any{}
+		for k, v := range x {
+			ret[fmt.Sprint(k)] = toJSONValue(v)
+		}
+		return ret
+	case []any:
+		for i, v := range x {
+			x[i] = toJSONValue(v)
+		}
+	}
+	return x
+}
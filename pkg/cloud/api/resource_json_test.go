@@ -0,0 +1,152 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResourceJSON(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		S               string
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type ga struct {
+		Name            string
+		I               int64
+		In              *inner
+		L               []*inner
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type alph struct {
+		Name            string
+		I               int64
+		In              *inner
+		L               []*inner
+		B               string
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type beta = ga
+
+	newRes := func(f func(x *alph)) Resource[ga, alph, beta] {
+		t.Helper()
+		res := newTestResource[ga, alph, beta](nil)
+		if err := res.AccessAlpha(f); err != nil {
+			t.Fatalf("AccessAlpha() = %v, want nil", err)
+		}
+		r, err := res.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v, want nil", err)
+		}
+		return r
+	}
+
+	for _, tc := range []struct {
+		name string
+		r    Resource[ga, alph, beta]
+	}{
+		{
+			name: "ga",
+			r: newRes(func(x *alph) {
+				x.I = 1 << 60
+				x.In = &inner{ForceSendFields: []string{"S"}}
+				x.L = []*inner{{S: "a"}, {NullFields: []string{"S"}}}
+				x.NullFields = []string{"B"}
+			}),
+		},
+		{
+			name: "alpha",
+			r: newRes(func(x *alph) {
+				x.B = "b"
+				x.ForceSendFields = []string{"I"}
+				x.NullFields = []string{"In", "L"}
+			}),
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			check := func(name string, got Resource[ga, alph, beta]) {
+				t.Helper()
+				if got.Version() != tc.r.Version() {
+					t.Errorf("%s: Version() = %s, want %s", name, got.Version(), tc.r.Version())
+				}
+				if diff := cmp.Diff(got.ResourceID(), tc.r.ResourceID()); diff != "" {
+					t.Errorf("%s: ResourceID(): -got,+want: %s", name, diff)
+				}
+				gotA, _ := got.ToAlpha()
+				wantA, _ := tc.r.ToAlpha()
+				if diff := cmp.Diff(gotA, wantA); diff != "" {
+					t.Errorf("%s: ToAlpha(): -got,+want: %s", name, diff)
+				}
+				gotGA, _ := got.ToGA()
+				wantGA, _ := tc.r.ToGA()
+				if diff := cmp.Diff(gotGA, wantGA); diff != "" {
+					t.Errorf("%s: ToGA(): -got,+want: %s", name, diff)
+				}
+			}
+
+			j, err := json.Marshal(tc.r)
+			if err != nil {
+				t.Fatalf("json.Marshal() = %v, want nil", err)
+			}
+			t.Logf("json = %s", j)
+			r, err := ResourceFromJSON[ga, alph, beta](j, nil)
+			if err != nil {
+				t.Fatalf("ResourceFromJSON() = %v, want nil", err)
+			}
+			check("JSON", r)
+
+			y, err := ResourceToYAML(tc.r)
+			if err != nil {
+				t.Fatalf("ResourceToYAML() = %v, want nil", err)
+			}
+			t.Logf("yaml = %s", y)
+			r, err = ResourceFromYAML[ga, alph, beta](y, nil)
+			if err != nil {
+				t.Fatalf("ResourceFromYAML() = %v, want nil", err)
+			}
+			check("YAML", r)
+		})
+	}
+
+	const id = `"resourceID":{"ProjectID":"p","Resource":"st","Key":{"Name":"x"}}`
+	for _, tc := range []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "invalid JSON", data: `{"version":"ga"`, wantErr: true},
+		{name: "invalid version", data: `{"version":"v0",` + id + `,"object":{}}`, wantErr: true},
+		{name: "no resourceID", data: `{"version":"ga","object":{}}`, wantErr: true},
+		{name: "unknown metaFields path", data: `{"version":"ga",` + id + `,"object":{},"metaFields":{"*.X":{"nullFields":["A"]}}}`},
+	} {
+		_, err := ResourceFromJSON[ga, alph, beta]([]byte(tc.data), nil)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: ResourceFromJSON() = %v; gotErr = %t, want %t", tc.name, err, gotErr, tc.wantErr)
+		}
+	}
+}
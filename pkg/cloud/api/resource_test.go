@@ -17,6 +17,7 @@ limitations under the License.
 package api
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
@@ -526,8 +527,24 @@ func TestResourceMissingFields(t *testing.T) {
 	if diff := cmp.Diff(gaResult, &ga{A: 15}); diff != "" {
 		t.Errorf("ToGA(); -got,+want: %s", diff)
 	}
-	if err == nil {
-		t.Error("ToGA() = nil, want error")
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("ToGA() = %v, want ConversionError", err)
+	}
+	wantErr := &ConversionError{
+		Version: meta.VersionGA,
+		MissingFields: []MissingField{
+			{Context: AlphaToGAConversion, Path: Path{}.Pointer().Field("B"), Value: 20},
+		},
+	}
+	if diff := cmp.Diff(convErr, wantErr); diff != "" {
+		t.Errorf("ToGA() error; -got,+want: %s", diff)
+	}
+	if got, want := convErr.Error(), "ConversionError: fields cannot be represented in ga: *.B = 20 (alpha => ga)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if diff := cmp.Diff(convErr.SourceVersions(), []meta.Version{meta.VersionAlpha}); diff != "" {
+		t.Errorf("SourceVersions(); -got,+want: %s", diff)
 	}
 	aResult, err := res.ToAlpha()
 	if diff := cmp.Diff(aResult, &alph{A: 15, B: 20}); diff != "" {
@@ -545,6 +562,77 @@ func TestResourceMissingFields(t *testing.T) {
 	}
 }
 
+func TestResourceAsVersion(t *testing.T) {
+	t.Parallel()
+
+	type ga struct {
+		A               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type alph struct {
+		A, B            int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type beta struct {
+		A               int
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	tt := &TypeTraitFuncs[ga, alph, beta]{
+		FieldTraitsF: func(meta.Version) *FieldTraits {
+			dt := &FieldTraits{}
+			dt.AllowZeroValue(Path{}.Pointer().Field("A"))
+			dt.AllowZeroValue(Path{}.Pointer().Field("B"))
+			return dt
+		},
+	}
+	freeze := func(f func(x *alph)) Resource[ga, alph, beta] {
+		t.Helper()
+		res := newTestResource[ga, alph, beta](tt)
+		if err := res.AccessAlpha(f); err != nil {
+			t.Fatalf("AccessAlpha() = %v, want nil", err)
+		}
+		r, err := res.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v, want nil", err)
+		}
+		return r
+	}
+
+	r := freeze(func(x *alph) { x.A = 10 })
+	if r.Version() != meta.VersionGA {
+		t.Fatalf("Version() = %s, want %s", r.Version(), meta.VersionGA)
+	}
+	br, err := r.AsVersion(meta.VersionBeta)
+	if err != nil {
+		t.Fatalf("AsVersion(beta) = %v, want nil", err)
+	}
+	if br.Version() != meta.VersionBeta {
+		t.Errorf("AsVersion(beta).Version() = %s, want %s", br.Version(), meta.VersionBeta)
+	}
+	if b, _ := br.ToBeta(); b.A != 10 {
+		t.Errorf("AsVersion(beta).ToBeta().A = %d, want 10", b.A)
+	}
+	if _, err := r.AsVersion("invalid"); err == nil {
+		t.Error("AsVersion(invalid) = nil, want error")
+	}
+
+	// .B is only in Alpha.
+	r = freeze(func(x *alph) { x.B = 20 })
+	if r.Version() != meta.VersionAlpha {
+		t.Fatalf("Version() = %s, want %s", r.Version(), meta.VersionAlpha)
+	}
+	if ar, err := r.AsVersion(meta.VersionAlpha); err != nil || ar != r {
+		t.Errorf("AsVersion(alpha) = %v, %v; want r, nil", ar, err)
+	}
+	if _, err := r.AsVersion(meta.VersionGA); err == nil {
+		t.Error("AsVersion(ga) = nil, want error")
+	}
+}
+
 func TestResourceMissingMetaFields(t *testing.T) {
 	t.Parallel()
 
@@ -126,7 +126,47 @@ func (f *TypeTraitFuncs[GA, Alpha, Beta]) FieldTraits(v meta.Version) *FieldTrai
 
 // FieldTraits are the features and behavior for fields in the resource.
 type FieldTraits struct {
-	fields []fieldTrait
+	fields      []fieldTrait
+	comparators []fieldComparator
+	unordered   []Path
+	keyed       []keyedSlice
+	defaults    []serverDefault
+	immutable   []Path
+	references  []FieldReference
+}
+
+// serverDefault is the value the server sets for a field that is unset.
+// See ServerDefault().
+type serverDefault struct {
+	path Path
+	// value is the default. Ignored if any is true.
+	value any
+	// any is true if the field is defaulted to an unknown value.
+	any bool
+}
+
+// matches returns true if v is the server default.
+func (sd *serverDefault) matches(v reflect.Value) bool {
+	if sd.any {
+		return true
+	}
+	// The default may be given for the value of a pointer field.
+	for v.Kind() == reflect.Pointer && !v.IsNil() && v.Type() != reflect.TypeOf(sd.value) {
+		v = v.Elem()
+	}
+	return reflect.DeepEqual(valueInterface(v), sd.value)
+}
+
+// keyedSlice is a slice compared as a map keyed by a field of the elements.
+// See UnorderedByKey().
+type keyedSlice struct {
+	path Path
+	key  string
+}
+
+type fieldComparator struct {
+	path Path
+	cmp  DiffComparator
 }
 
 type fieldTrait struct {
@@ -164,6 +204,69 @@ func (dt *FieldTraits) CheckSchema(t reflect.Type) error {
 			return fmt.Errorf("CheckSchema: %w", err)
 		}
 	}
+	for _, c := range dt.comparators {
+		if _, err := c.path.ResolveType(t); err != nil {
+			return fmt.Errorf("CheckSchema: %w", err)
+		}
+	}
+	for _, r := range dt.references {
+		ft, err := r.Path.ResolveType(t)
+		if err != nil {
+			return fmt.Errorf("CheckSchema: %w", err)
+		}
+		if ft.Kind() != reflect.String {
+			return fmt.Errorf("CheckSchema: reference path %s is not a string (%s)", r.Path, ft)
+		}
+	}
+	for _, p := range dt.unordered {
+		ft, err := p.ResolveType(t)
+		if err != nil {
+			return fmt.Errorf("CheckSchema: %w", err)
+		}
+		if ft.Kind() != reflect.Slice {
+			return fmt.Errorf("CheckSchema: unordered path %s is not a slice (%s)", p, ft)
+		}
+	}
+	for _, sd := range dt.defaults {
+		ft, err := sd.path.ResolveType(t)
+		if err != nil {
+			return fmt.Errorf("CheckSchema: %w", err)
+		}
+		if sd.any {
+			continue
+		}
+		vt := reflect.TypeOf(sd.value)
+		for ft.Kind() == reflect.Pointer && ft != vt {
+			ft = ft.Elem()
+		}
+		if vt != ft {
+			return fmt.Errorf("CheckSchema: server default for %s is a %v, want %s", sd.path, vt, ft)
+		}
+	}
+	for _, p := range dt.immutable {
+		if _, err := p.ResolveType(t); err != nil {
+			return fmt.Errorf("CheckSchema: %w", err)
+		}
+	}
+	for _, k := range dt.keyed {
+		ft, err := k.path.ResolveType(t)
+		if err != nil {
+			return fmt.Errorf("CheckSchema: %w", err)
+		}
+		if ft.Kind() != reflect.Slice {
+			return fmt.Errorf("CheckSchema: unordered path %s is not a slice (%s)", k.path, ft)
+		}
+		et := ft.Elem()
+		if et.Kind() == reflect.Pointer {
+			et = et.Elem()
+		}
+		if et.Kind() != reflect.Struct {
+			return fmt.Errorf("CheckSchema: unordered path %s is not a slice of structs (%s)", k.path, ft)
+		}
+		if _, ok := et.FieldByName(k.key); !ok {
+			return fmt.Errorf("CheckSchema: unordered path %s: no key field %q in %s", k.path, k.key, et)
+		}
+	}
 	return nil
 }
 
@@ -180,11 +283,110 @@ func (dt *FieldTraits) System(p Path) { dt.add(p, FieldTypeSystem) }
 // AllowZeroValue specifies the type of the given path.
 func (dt *FieldTraits) AllowZeroValue(p Path) { dt.add(p, FieldTypeAllowZeroValue) }
 
+// Compare the field at path p with cmp in a diff instead of by value. p may
+// contain wildcards, e.g. Path{}.Pointer().Field("Backends").AnySliceIndex().
+func (dt *FieldTraits) Compare(p Path, cmp DiffComparator) {
+	dt.comparators = append(dt.comparators, fieldComparator{path: p, cmp: cmp})
+}
+
+// Unordered specifies that the slice at path p is compared as a set in a
+// diff, i.e. the order of the elements does not matter. Elements are
+// compared with the traits for p.AnySliceIndex().
+func (dt *FieldTraits) Unordered(p Path) { dt.unordered = append(dt.unordered, p) }
+
+// UnorderedByKey specifies that the slice of structs (or pointers to
+// structs) at path p is compared as a map keyed by the field key in a diff,
+// e.g. the .Backends of a BackendService by .Group. This avoids diffs when
+// the server reorders the elements. The elements with the same key are
+// diff'ed field by field, with the Path of the element in A. Elements that
+// are only in A or only in B are reported as such. The keys are compared
+// with the traits for their path, e.g. Compare().
+func (dt *FieldTraits) UnorderedByKey(p Path, key string) {
+	dt.keyed = append(dt.keyed, keyedSlice{path: p, key: key})
+}
+
+// ServerDefault specifies that the server sets the field at path p to value
+// when it is unset. A diff does not report a change if the field is zero in
+// B (the wanted resource) and has the default value in A (the resource from
+// the server). This avoids perpetual updates of fields that are omitted in
+// the wanted resource. value may also be given for the value of a pointer
+// field, e.g. an int64 for an *int64.
+func (dt *FieldTraits) ServerDefault(p Path, value any) {
+	dt.defaults = append(dt.defaults, serverDefault{path: p, value: value})
+}
+
+// DefaultedIfUnset specifies that the server sets the field at path p to a
+// value that is not known in advance (e.g. it depends on other fields) when
+// it is unset. A diff does not report a change if the field is zero in B.
+func (dt *FieldTraits) DefaultedIfUnset(p Path) {
+	dt.defaults = append(dt.defaults, serverDefault{path: p, any: true})
+}
+
+// Immutable specifies that the field at path p (and its sub-fields) cannot
+// be changed once the resource is created. Diff marks the changes to the
+// field as DiffItem.Immutable.
+func (dt *FieldTraits) Immutable(p Path) { dt.immutable = append(dt.immutable, p) }
+
 // Clone create an exact copy of the traits.
 func (dt *FieldTraits) Clone() *FieldTraits {
 	return &FieldTraits{
-		fields: append([]fieldTrait{}, dt.fields...),
+		fields:      append([]fieldTrait{}, dt.fields...),
+		comparators: append([]fieldComparator(nil), dt.comparators...),
+		unordered:   append([]Path(nil), dt.unordered...),
+		keyed:       append([]keyedSlice(nil), dt.keyed...),
+		defaults:    append([]serverDefault(nil), dt.defaults...),
+		immutable:   append([]Path(nil), dt.immutable...),
+		references:  append([]FieldReference(nil), dt.references...),
+	}
+}
+
+func (dt *FieldTraits) comparator(p Path) DiffComparator {
+	for _, c := range dt.comparators {
+		if p.Match(c.path) {
+			return c.cmp
+		}
+	}
+	return nil
+}
+
+// serverDefault returns the server default for the field at p or nil.
+func (dt *FieldTraits) serverDefault(p Path) *serverDefault {
+	for i := range dt.defaults {
+		if p.Match(dt.defaults[i].path) {
+			return &dt.defaults[i]
+		}
+	}
+	return nil
+}
+
+// isImmutable returns true if p is an immutable field or a sub-field of
+// one.
+func (dt *FieldTraits) isImmutable(p Path) bool {
+	for _, i := range dt.immutable {
+		if p.HasPrefix(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// sliceKey returns the key field if the slice at p is compared by key.
+func (dt *FieldTraits) sliceKey(p Path) (string, bool) {
+	for _, k := range dt.keyed {
+		if p.Match(k.path) {
+			return k.key, true
+		}
+	}
+	return "", false
+}
+
+func (dt *FieldTraits) isUnordered(p Path) bool {
+	for _, u := range dt.unordered {
+		if p.Match(u) {
+			return true
+		}
 	}
+	return false
 }
 
 func (dt *FieldTraits) fieldType(p Path) FieldType { return dt.fieldTrait(p).fType }
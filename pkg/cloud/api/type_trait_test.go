@@ -161,6 +161,16 @@ func TestFieldTraitsCheckSchema(t *testing.T) {
 			}(),
 			ty: reflect.TypeOf(&st{}),
 		},
+		{
+			name: "immutable path references fields that don't exist",
+			ft: func() *FieldTraits {
+				var ret FieldTraits
+				ret.Immutable(Path{}.Pointer().Field("X"))
+				return &ret
+			}(),
+			ty:      reflect.TypeOf(&st{}),
+			wantErr: true,
+		},
 		{
 			name: "path is not a field",
 			ft: func() *FieldTraits {
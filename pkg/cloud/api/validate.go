@@ -0,0 +1,86 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// ValidateFunc checks the resource and returns the violations found, e.g.
+// a combination of field values that the API does not accept. All of the
+// violations should be returned, not just the first one.
+type ValidateFunc[GA any, Alpha any, Beta any] func(r Resource[GA, Alpha, Beta]) []error
+
+// Validator is an optional interface for the TypeTrait. Validate is called
+// by Freeze() in addition to the funcs registered with RegisterValidation().
+type Validator[GA any, Alpha any, Beta any] interface {
+	Validate(r Resource[GA, Alpha, Beta]) []error
+}
+
+// ValidationError is returned by Freeze() if the resource is not valid.
+type ValidationError struct {
+	ResourceID *cloud.ResourceID
+	// Violations found by all of the validations.
+	Violations []error
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	var l []string
+	for _, v := range e.Violations {
+		l = append(l, v.Error())
+	}
+	return fmt.Sprintf("ValidationError: %v: %s", e.ResourceID, strings.Join(l, "; "))
+}
+
+// Unwrap returns the violations.
+func (e *ValidationError) Unwrap() []error { return e.Violations }
+
+// validations are the funcs registered by RegisterValidation(), keyed by
+// the GA type.
+var validations = map[reflect.Type][]any{}
+
+// RegisterValidation adds a validation for the resources of the given type
+// (e.g. to enforce an organization policy). The validation is run by
+// Freeze() for all resources of the type that are changed with Access*().
+// Resources from the server (see Set()) are not validated.
+//
+// RegisterValidation is not safe for concurrent use with Freeze() and
+// should be called from an init() function.
+func RegisterValidation[GA any, Alpha any, Beta any](f ValidateFunc[GA, Alpha, Beta]) {
+	t := reflect.TypeOf((*GA)(nil))
+	validations[t] = append(validations[t], f)
+}
+
+// validate the resource with the TypeTrait and the registered validations.
+func validate[GA any, Alpha any, Beta any](r Resource[GA, Alpha, Beta], typeTrait TypeTrait[GA, Alpha, Beta]) error {
+	var errs []error
+	if v, ok := typeTrait.(Validator[GA, Alpha, Beta]); ok {
+		errs = append(errs, v.Validate(r)...)
+	}
+	for _, f := range validations[reflect.TypeOf((*GA)(nil))] {
+		errs = append(errs, f.(ValidateFunc[GA, Alpha, Beta])(r)...)
+	}
+	if len(errs) > 0 {
+		return &ValidationError{ResourceID: r.ResourceID(), Violations: errs}
+	}
+	return nil
+}
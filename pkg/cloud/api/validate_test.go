@@ -0,0 +1,113 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+type validateTestStruct struct {
+	A               int
+	B               string
+	NullFields      []string
+	ForceSendFields []string
+}
+
+type validateTestTrait struct {
+	BaseTypeTrait[validateTestStruct, validateTestStruct, validateTestStruct]
+}
+
+func (*validateTestTrait) FieldTraits(meta.Version) *FieldTraits {
+	dt := &FieldTraits{}
+	dt.AllowZeroValue(Path{}.Pointer().Field("A"))
+	dt.AllowZeroValue(Path{}.Pointer().Field("B"))
+	return dt
+}
+
+func (*validateTestTrait) Validate(r Resource[validateTestStruct, validateTestStruct, validateTestStruct]) []error {
+	x, _ := r.ToGA()
+	if x.A < 0 {
+		return []error{fmt.Errorf(".A is negative")}
+	}
+	return nil
+}
+
+var errValidateB = errors.New(".B requires a positive .A")
+
+func init() {
+	RegisterValidation(func(r Resource[validateTestStruct, validateTestStruct, validateTestStruct]) []error {
+		x, _ := r.ToGA()
+		if x.B != "" && x.A <= 0 {
+			return []error{errValidateB}
+		}
+		return nil
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name           string
+		f              func(x *validateTestStruct)
+		set            bool
+		wantViolations int
+	}{
+		{name: "valid", f: func(x *validateTestStruct) { x.A = 1; x.B = "b" }},
+		{name: "type trait", f: func(x *validateTestStruct) { x.A = -1 }, wantViolations: 1},
+		{name: "registered", f: func(x *validateTestStruct) { x.B = "b" }, wantViolations: 1},
+		{name: "all violations", f: func(x *validateTestStruct) { x.A = -1; x.B = "b" }, wantViolations: 2},
+		{name: "from server", f: func(x *validateTestStruct) { x.B = "b" }, set: true},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res := newTestResource[validateTestStruct, validateTestStruct, validateTestStruct](&validateTestTrait{})
+			if tc.set {
+				x := &validateTestStruct{}
+				tc.f(x)
+				if err := res.Set(x); err != nil {
+					t.Fatalf("Set() = %v, want nil", err)
+				}
+			} else if err := res.Access(tc.f); err != nil {
+				t.Fatalf("Access() = %v, want nil", err)
+			}
+			_, err := res.Freeze()
+			if tc.wantViolations == 0 {
+				if err != nil {
+					t.Errorf("Freeze() = %v, want nil", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Freeze() = %v, want ValidationError", err)
+			}
+			if len(verr.Violations) != tc.wantViolations {
+				t.Errorf("Violations = %v, want %d violations", verr.Violations, tc.wantViolations)
+			}
+			if tc.name == "registered" && !errors.Is(err, errValidateB) {
+				t.Errorf("errors.Is(%v, errValidateB) = false, want true", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// VersionFields lists the fields of a resource type that are not present in
+// all of the API versions. It is generated by CompareVersions() (see
+// rnode/gen/versionfields) and can be serialized as JSON, the Paths are lists
+// of strings.
+//
+// Only the top-most field is listed, e.g. if the struct field .A is Alpha
+// only, .A.B is not listed separately.
+type VersionFields struct {
+	// Alpha fields only exist in the Alpha version.
+	Alpha []Path `json:"alpha,omitempty"`
+	// Beta fields exist in the Beta (and usually Alpha) version but not in
+	// GA.
+	Beta []Path `json:"beta,omitempty"`
+	// Drift are fields that exist in a more stable version but are missing
+	// (or have a different type) in a less stable one, e.g. a GA field that
+	// is not in Alpha. Conversions of resources with these fields set will
+	// fail.
+	Drift []Path `json:"drift,omitempty"`
+}
+
+// CompareVersions compares the struct fields of the GA, Alpha and Beta
+// versions of a resource type. Alpha and Beta may be PlaceholderType.
+func CompareVersions[GA any, Alpha any, Beta any]() *VersionFields {
+	gaT := reflect.TypeOf((*GA)(nil))
+	alphaT := reflect.TypeOf((*Alpha)(nil))
+	betaT := reflect.TypeOf((*Beta)(nil))
+	hasAlpha := !isPlaceholderType(new(Alpha))
+	hasBeta := !isPlaceholderType(new(Beta))
+
+	var (
+		ret   VersionFields
+		alpha []Path
+	)
+	drift := func(p Path) { ret.Drift = append(ret.Drift, p) }
+	if hasAlpha {
+		// alphaOnly are fields that are in Alpha but not GA.
+		var alphaOnly []Path
+		compareTypes(Path{}, gaT, alphaT, drift, func(p Path) { alphaOnly = append(alphaOnly, p) }, drift)
+		if !hasBeta {
+			alpha = alphaOnly
+		}
+	}
+	if hasBeta {
+		compareTypes(Path{}, gaT, betaT, drift, func(p Path) { ret.Beta = append(ret.Beta, p) }, drift)
+	}
+	if hasAlpha && hasBeta {
+		// Beta fields that are missing from Alpha are drift. Alpha fields
+		// that are not in Beta are Alpha only if they are also not in GA
+		// (otherwise they were reported as drift above).
+		compareTypes(Path{}, betaT, alphaT, drift, func(p Path) {
+			if _, err := p.ResolveType(gaT); err != nil {
+				alpha = append(alpha, p)
+			}
+		}, func(Path) {})
+	}
+	ret.Alpha = sortPaths(alpha)
+	ret.Beta = sortPaths(ret.Beta)
+	ret.Drift = sortPaths(ret.Drift)
+
+	return &ret
+}
+
+// compareTypes walks the types a and b and calls onlyInA() and onlyInB() for
+// the top-most fields that exist in only one of them and mismatch() for the
+// fields that have different types.
+func compareTypes(p Path, a, b reflect.Type, onlyInA, onlyInB, mismatch func(Path)) {
+	if a.Kind() != b.Kind() {
+		mismatch(append(Path(nil), p...))
+		return
+	}
+	switch a.Kind() {
+	case reflect.Pointer:
+		compareTypes(p.Pointer(), a.Elem(), b.Elem(), onlyInA, onlyInB, mismatch)
+	case reflect.Slice:
+		compareTypes(p.AnySliceIndex(), a.Elem(), b.Elem(), onlyInA, onlyInB, mismatch)
+	case reflect.Map:
+		if a.Key().Kind() != b.Key().Kind() {
+			mismatch(append(Path(nil), p...))
+			return
+		}
+		compareTypes(p.AnyMapIndex(), a.Elem(), b.Elem(), onlyInA, onlyInB, mismatch)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			sf := a.Field(i)
+			fp := p.Field(sf.Name)
+			if !sf.IsExported() || isNoFillPath(fp) || isServerResponse(fp) {
+				continue
+			}
+			bf, ok := b.FieldByName(sf.Name)
+			if !ok {
+				onlyInA(append(Path(nil), fp...))
+				continue
+			}
+			compareTypes(fp, sf.Type, bf.Type, onlyInA, onlyInB, mismatch)
+		}
+		for i := 0; i < b.NumField(); i++ {
+			sf := b.Field(i)
+			fp := p.Field(sf.Name)
+			if !sf.IsExported() || isNoFillPath(fp) || isServerResponse(fp) {
+				continue
+			}
+			if _, ok := a.FieldByName(sf.Name); !ok {
+				onlyInB(append(Path(nil), fp...))
+			}
+		}
+	}
+}
+
+// sortPaths sorts and removes the duplicates in paths.
+func sortPaths(paths []Path) []Path {
+	sort.Slice(paths, func(i, j int) bool { return paths[i].String() < paths[j].String() })
+	var ret []Path
+	for i, p := range paths {
+		if i > 0 && p.Equal(paths[i-1]) {
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+// MinVersion returns the most stable version that has the field p: GA,
+// Beta or Alpha. This can be used to select the API version for a resource
+// from the fields that are set.
+func (vf *VersionFields) MinVersion(p Path) meta.Version {
+	for _, f := range vf.Alpha {
+		if p.HasPrefix(f) {
+			return meta.VersionAlpha
+		}
+	}
+	for _, f := range vf.Beta {
+		if p.HasPrefix(f) {
+			return meta.VersionBeta
+		}
+	}
+	return meta.VersionGA
+}
+
+// Report returns a human readable listing of the fields.
+func (vf *VersionFields) Report() string {
+	var b strings.Builder
+	for _, x := range []struct {
+		name  string
+		paths []Path
+	}{
+		{"alpha", vf.Alpha},
+		{"beta", vf.Beta},
+		{"drift", vf.Drift},
+	} {
+		fmt.Fprintf(&b, "%s (%d):\n", x.name, len(x.paths))
+		for _, p := range x.paths {
+			fmt.Fprintf(&b, "  %s\n", p)
+		}
+	}
+	return b.String()
+}
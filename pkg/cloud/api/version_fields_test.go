@@ -0,0 +1,122 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	type innerGA struct{ I int }
+	type innerBeta struct {
+		I int
+		B string
+	}
+	type innerAlpha struct {
+		I int
+		A string
+		B string
+	}
+	type ga struct {
+		Name            string
+		In              *innerGA
+		L               []innerGA
+		Changed         int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type beta struct {
+		Name            string
+		In              *innerBeta
+		L               []innerBeta
+		Changed         string
+		BetaField       int
+		NullFields      []string
+		ForceSendFields []string
+	}
+	type alpha struct {
+		Name            string
+		In              *innerAlpha
+		L               []innerAlpha
+		Changed         int
+		BetaField       int
+		AlphaField      *innerAlpha
+		NullFields      []string
+		ForceSendFields []string
+	}
+
+	p := func(f ...string) Path {
+		ret := Path{}.Pointer()
+		for _, x := range f {
+			ret = ret.Field(x)
+		}
+		return ret
+	}
+
+	for _, tc := range []struct {
+		name string
+		got  *VersionFields
+		want *VersionFields
+	}{
+		{
+			name: "all versions",
+			got:  CompareVersions[ga, alpha, beta](),
+			want: &VersionFields{
+				Alpha: []Path{p("AlphaField"), p("In").Pointer().Field("A"), p("L").AnySliceIndex().Field("A")},
+				Beta:  []Path{p("BetaField"), p("In").Pointer().Field("B"), p("L").AnySliceIndex().Field("B")},
+				Drift: []Path{p("Changed")},
+			},
+		},
+		{
+			name: "no beta",
+			got:  CompareVersions[ga, alpha, PlaceholderType](),
+			want: &VersionFields{
+				Alpha: []Path{p("AlphaField"), p("BetaField"), p("In").Pointer().Field("A"), p("In").Pointer().Field("B"), p("L").AnySliceIndex().Field("A"), p("L").AnySliceIndex().Field("B")},
+			},
+		},
+		{
+			name: "same types",
+			got:  CompareVersions[ga, ga, PlaceholderType](),
+			want: &VersionFields{},
+		},
+	} {
+		if diff := cmp.Diff(tc.got, tc.want); diff != "" {
+			t.Errorf("%s: CompareVersions(); -got,+want: %s", tc.name, diff)
+		}
+	}
+
+	vf := CompareVersions[ga, alpha, beta]()
+	for _, tc := range []struct {
+		path Path
+		want meta.Version
+	}{
+		{path: p("Name"), want: meta.VersionGA},
+		{path: p("In").Pointer().Field("I"), want: meta.VersionGA},
+		{path: p("BetaField"), want: meta.VersionBeta},
+		{path: p("AlphaField").Pointer().Field("I"), want: meta.VersionAlpha},
+		{path: p("L").Index(3).Field("A"), want: meta.VersionAlpha},
+	} {
+		if got := vf.MinVersion(tc.path); got != tc.want {
+			t.Errorf("MinVersion(%v) = %v, want %v", tc.path, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,586 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakeserver implements a hermetic fake of the GCE REST API that is
+// backed by a cloud.MockGCE. This allows the real API clients (cloud.GCE) to
+// be tested end-to-end, including URL routing, long running operations and
+// error encoding, without access to a GCP project.
+//
+//	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "my-project"})
+//	srv := fakeserver.New(mock)
+//	defer srv.Close()
+//
+//	svc, err := cloud.NewService(ctx, srv.Client(), pr, &cloud.NopRateLimiter{})
+//	theCloud := cloud.NewGCE(svc)
+//
+// All operations complete immediately. List filters are not evaluated by the
+// server.
+package fakeserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+	"k8s.io/klog/v2"
+)
+
+// Server is a fake GCE API server. Requests are served from the objects in
+// the MockGCE.
+type Server struct {
+	mock     *cloud.MockGCE
+	srv      *httptest.Server
+	services map[serviceKey]*meta.ServiceInfo
+
+	lock   sync.Mutex
+	nextOp int
+	// ops are the operations returned by the server, indexed by relative
+	// resource name (e.g. "projects/p/global/operations/op").
+	ops map[string]interface{}
+}
+
+// serviceKey identifies the service handling a given URL.
+type serviceKey struct {
+	apiGroup meta.APIGroup
+	version  meta.Version
+	resource string
+	keyType  meta.KeyType
+}
+
+// New starts a fake server for mock. Close() must be called when the server is
+// no longer needed.
+func New(mock *cloud.MockGCE) *Server {
+	s := &Server{
+		mock:     mock,
+		services: map[serviceKey]*meta.ServiceInfo{},
+		ops:      map[string]interface{}{},
+	}
+	for _, svc := range meta.AllServices {
+		k := serviceKey{apiGroup: svc.APIGroup, version: svc.Version(), resource: svc.Resource}
+		switch {
+		case svc.KeyIsGlobal():
+			k.keyType = meta.Global
+		case svc.KeyIsRegional():
+			k.keyType = meta.Regional
+		case svc.KeyIsZonal():
+			k.keyType = meta.Zonal
+		}
+		s.services[k] = svc
+	}
+	s.srv = httptest.NewServer(s)
+	return s
+}
+
+// URL of the server.
+func (s *Server) URL() string { return s.srv.URL }
+
+// Close shuts down the server.
+func (s *Server) Close() { s.srv.Close() }
+
+// Client returns an HTTP client that sends all requests to the server,
+// regardless of the API endpoint in the request URL. Use with
+// cloud.NewService().
+func (s *Server) Client() *http.Client {
+	u, _ := url.Parse(s.srv.URL)
+	return &http.Client{Transport: &redirectTransport{target: u, base: s.srv.Client().Transport}}
+}
+
+// redirectTransport sends requests to target, keeping the URL path.
+type redirectTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = ""
+	return t.base.RoundTrip(r)
+}
+
+// request is a parsed API request URL.
+type request struct {
+	apiGroup meta.APIGroup
+	version  meta.Version
+	project  string
+	// scope is one of "global", "regions", "zones", "aggregated".
+	scope    string
+	location string
+	resource string
+	name     string
+	// method is the custom method (e.g. "setTarget") in the URL, if any.
+	method string
+}
+
+func (r *request) key() *meta.Key {
+	switch r.scope {
+	case "regions":
+		return meta.RegionalKey(r.name, r.location)
+	case "zones":
+		return meta.ZonalKey(r.name, r.location)
+	}
+	return meta.GlobalKey(r.name)
+}
+
+func (r *request) keyType() meta.KeyType {
+	switch r.scope {
+	case "regions":
+		return meta.Regional
+	case "zones":
+		return meta.Zonal
+	}
+	return meta.Global
+}
+
+// parseRequest parses the URL path of a request. Compute URLs are of the
+// form /compute/<ver>/projects/<proj>/..., networkservices URLs are of the
+// form /<ver>/projects/<proj>/locations/global/...
+func parseRequest(path string) (*request, error) {
+	errInvalid := fmt.Errorf("invalid URL path %q", path)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	ret := &request{}
+	if parts[0] == "compute" {
+		ret.apiGroup = meta.APIGroupCompute
+		parts = parts[1:]
+		if len(parts) < 3 || parts[1] != "projects" {
+			return nil, errInvalid
+		}
+		switch parts[0] {
+		case "v1":
+			ret.version = meta.VersionGA
+		case "beta":
+			ret.version = meta.VersionBeta
+		case "alpha":
+			ret.version = meta.VersionAlpha
+		default:
+			return nil, errInvalid
+		}
+		ret.project = parts[2]
+		parts = parts[3:]
+
+		if len(parts) == 0 {
+			ret.resource = "projects"
+			return ret, nil
+		}
+		ret.scope = parts[0]
+		switch parts[0] {
+		case "global", "aggregated":
+			parts = parts[1:]
+		case "regions", "zones":
+			// The Regions and Zones resources themselves.
+			if len(parts) <= 2 {
+				ret.scope = "global"
+				ret.resource = parts[0]
+				if len(parts) == 2 {
+					ret.name = parts[1]
+				}
+				return ret, nil
+			}
+			ret.location = parts[1]
+			parts = parts[2:]
+		default:
+			return nil, errInvalid
+		}
+	} else {
+		ret.apiGroup = meta.APIGroupNetworkServices
+		switch parts[0] {
+		case "v1":
+			ret.version = meta.VersionGA
+		case "v1beta1":
+			ret.version = meta.VersionBeta
+		default:
+			return nil, errInvalid
+		}
+		parts = parts[1:]
+		ret.scope = "global"
+		switch {
+		case len(parts) >= 4 && parts[0] == "projects" && parts[2] == "locations" && parts[3] == "global":
+			ret.project = parts[1]
+			parts = parts[4:]
+		case len(parts) == 2:
+			// List() calls use the project as the parent.
+			ret.project = parts[0]
+			parts = parts[1:]
+		default:
+			return nil, errInvalid
+		}
+	}
+
+	switch len(parts) {
+	case 3:
+		ret.method = parts[2]
+		fallthrough
+	case 2:
+		ret.name = parts[1]
+		fallthrough
+	case 1:
+		ret.resource = parts[0]
+	default:
+		return nil, errInvalid
+	}
+	return ret, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	klog.V(5).Infof("fakeserver: %s %s", r.Method, r.URL)
+
+	req, err := parseRequest(r.URL.Path)
+	if err != nil {
+		writeError(w, &googleapi.Error{Code: http.StatusNotFound, Message: err.Error()})
+		return
+	}
+	switch {
+	case req.resource == "operations":
+		s.serveOperation(w, r, req)
+		return
+	case req.resource == "projects":
+		p, err := s.mock.Projects().Get(r.Context(), req.project)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, p)
+		return
+	}
+
+	keyType := req.keyType()
+	if req.scope == "aggregated" {
+		// Aggregated lists are only implemented for non-global resources.
+		keyType = meta.Regional
+		if _, ok := s.services[serviceKey{req.apiGroup, req.version, req.resource, keyType}]; !ok {
+			keyType = meta.Zonal
+		}
+	}
+	svc, ok := s.services[serviceKey{req.apiGroup, req.version, req.resource, keyType}]
+	if !ok {
+		writeError(w, &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("unknown resource %q (%s %s %s)", req.resource, req.apiGroup, req.version, keyType)})
+		return
+	}
+	// Route all calls to the project in the URL.
+	opt := cloud.ForceProjectID(req.project)
+	ctx := r.Context()
+
+	switch {
+	case r.Method == http.MethodGet && req.scope == "aggregated":
+		s.serveAggregatedList(ctx, w, svc, opt)
+	case r.Method == http.MethodGet && req.name == "":
+		s.serveList(ctx, w, svc, req, opt)
+	case r.Method == http.MethodGet && req.method == "":
+		s.writeResult(w, s.call(svc, "Get", ctx, req.key(), opt))
+	case r.Method == http.MethodPost && req.name == "":
+		s.serveInsert(w, r, svc, req, opt)
+	case r.Method == http.MethodDelete:
+		s.writeOp(w, svc, req, "delete", s.call(svc, "Delete", ctx, req.key(), opt))
+	case r.Method == http.MethodPut:
+		s.serveMethod(w, r, svc, req, "Update", opt)
+	case r.Method == http.MethodPatch:
+		s.serveMethod(w, r, svc, req, "Patch", opt)
+	case r.Method == http.MethodPost && req.method != "":
+		s.serveMethod(w, r, svc, req, upperFirst(req.method), opt)
+	default:
+		writeError(w, &googleapi.Error{Code: http.StatusNotImplemented, Message: fmt.Sprintf("%s %s is not supported", r.Method, r.URL.Path)})
+	}
+}
+
+// service returns the interface for svc in the mock (e.g. mock.BackendServices()).
+func (s *Server) service(svc *meta.ServiceInfo) reflect.Value {
+	return reflect.ValueOf(s.mock).MethodByName(svc.WrapType()).Call(nil)[0]
+}
+
+// call invokes the named method on svc with args.
+func (s *Server) call(svc *meta.ServiceInfo, method string, args ...interface{}) []reflect.Value {
+	var in []reflect.Value
+	for _, a := range args {
+		in = append(in, reflect.ValueOf(a))
+	}
+	return s.service(svc).MethodByName(method).Call(in)
+}
+
+func (s *Server) serveList(ctx context.Context, w http.ResponseWriter, svc *meta.ServiceInfo, req *request, opt cloud.Option) {
+	var out []reflect.Value
+	switch {
+	case svc.KeyIsRegional() || svc.KeyIsZonal():
+		out = s.call(svc, "List", ctx, req.location, filter.None, opt)
+	default:
+		out = s.call(svc, "List", ctx, filter.None, opt)
+	}
+	if err := resultErr(out); err != nil {
+		writeError(w, err)
+		return
+	}
+	field := "items"
+	if svc.IsNetworkServices() {
+		field = lowerFirst(svc.ListItemName())
+	}
+	writeJSON(w, map[string]interface{}{field: out[0].Interface()})
+}
+
+func (s *Server) serveAggregatedList(ctx context.Context, w http.ResponseWriter, svc *meta.ServiceInfo, opt cloud.Option) {
+	if !svc.AggregatedList() {
+		writeError(w, &googleapi.Error{Code: http.StatusNotImplemented, Message: fmt.Sprintf("%s does not support AggregatedList", svc.Service)})
+		return
+	}
+	out := s.call(svc, "AggregatedList", ctx, filter.None, opt)
+	if err := resultErr(out); err != nil {
+		writeError(w, err)
+		return
+	}
+	items := map[string]interface{}{}
+	iter := out[0].MapRange()
+	for iter.Next() {
+		items[iter.Key().String()] = map[string]interface{}{lowerFirst(svc.AggregatedListField()): iter.Value().Interface()}
+	}
+	writeJSON(w, map[string]interface{}{"items": items})
+}
+
+func (s *Server) serveInsert(w http.ResponseWriter, r *http.Request, svc *meta.ServiceInfo, req *request, opt cloud.Option) {
+	m := s.service(svc).MethodByName("Insert")
+	obj := reflect.New(m.Type().In(2).Elem())
+	if err := json.NewDecoder(r.Body).Decode(obj.Interface()); err != nil {
+		writeError(w, &googleapi.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid request body: %v", err)})
+		return
+	}
+	req.name = obj.Elem().FieldByName("Name").String()
+	if svc.IsNetworkServices() {
+		// Create calls pass the name in the "<resource>Id" parameter.
+		for k, v := range r.URL.Query() {
+			if strings.HasSuffix(k, "Id") && len(v) > 0 {
+				req.name = v[0]
+			}
+		}
+	}
+	out := m.Call([]reflect.Value{reflect.ValueOf(r.Context()), reflect.ValueOf(req.key()), obj, reflect.ValueOf(opt)})
+	s.writeOp(w, svc, req, "insert", out)
+}
+
+// methodParams are the names of the query parameters of the scalar arguments
+// of the methods, in the order of the arguments of the cloud.Cloud method.
+var methodParams = map[string][]string{
+	"BackendServices.DeleteSignedUrlKey": {"keyName"},
+	"InstanceGroupManagers.Resize":       {"size"},
+	"Instances.DetachDisk":               {"deviceName"},
+	"Instances.UpdateNetworkInterface":   {"networkInterface"},
+}
+
+// serveMethod calls a method other than Get, List, Insert and Delete. Object
+// arguments are decoded from the request body and scalar arguments are taken
+// from the query parameters named in methodParams.
+func (s *Server) serveMethod(w http.ResponseWriter, r *http.Request, svc *meta.ServiceInfo, req *request, method string, opt cloud.Option) {
+	m := s.service(svc).MethodByName(method)
+	if !m.IsValid() {
+		writeError(w, &googleapi.Error{Code: http.StatusNotImplemented, Message: fmt.Sprintf("%s.%s is not supported", svc.Service, method)})
+		return
+	}
+
+	params := methodParams[svc.Service+"."+method]
+	query := r.URL.Query()
+
+	in := []reflect.Value{reflect.ValueOf(r.Context()), reflect.ValueOf(req.key())}
+	mt := m.Type()
+	// Skip ctx, key and the trailing options.
+	for i := 2; i < mt.NumIn()-1; i++ {
+		t := mt.In(i)
+		switch {
+		case t == reflect.TypeOf(filter.None):
+			in = append(in, reflect.ValueOf(filter.None))
+		case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct:
+			arg := reflect.New(t.Elem())
+			if err := json.NewDecoder(r.Body).Decode(arg.Interface()); err != nil {
+				writeError(w, &googleapi.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid request body: %v", err)})
+				return
+			}
+			in = append(in, arg)
+		default:
+			if len(params) == 0 {
+				writeError(w, &googleapi.Error{Code: http.StatusNotImplemented, Message: fmt.Sprintf("parameter %d of %s.%s is unknown", i-2, svc.Service, method)})
+				return
+			}
+			if !query.Has(params[0]) {
+				writeError(w, &googleapi.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("missing parameter %q for %s.%s", params[0], svc.Service, method)})
+				return
+			}
+			arg := reflect.New(t)
+			if err := json.Unmarshal([]byte(jsonScalar(t, query.Get(params[0]))), arg.Interface()); err != nil {
+				writeError(w, &googleapi.Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid parameter %q: %v", params[0], err)})
+				return
+			}
+			params = params[1:]
+			in = append(in, arg.Elem())
+		}
+	}
+	in = append(in, reflect.ValueOf(opt))
+	out := m.Call(in)
+
+	if len(out) == 2 {
+		// Methods returning a value rather than an operation.
+		if out[0].Kind() == reflect.Slice {
+			if err := resultErr(out); err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, map[string]interface{}{"items": out[0].Interface()})
+			return
+		}
+		s.writeResult(w, out)
+		return
+	}
+	s.writeOp(w, svc, req, lowerFirst(method), out)
+}
+
+func jsonScalar(t reflect.Type, v string) string {
+	if t.Kind() == reflect.String {
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+	return v
+}
+
+// serveOperation serves Get and Wait for operations.
+func (s *Server) serveOperation(w http.ResponseWriter, r *http.Request, req *request) {
+	if req.method != "" && req.method != "wait" {
+		writeError(w, &googleapi.Error{Code: http.StatusNotImplemented, Message: fmt.Sprintf("operations.%s is not supported", req.method)})
+		return
+	}
+	s.lock.Lock()
+	op, ok := s.ops[s.opName(req)]
+	s.lock.Unlock()
+
+	if !ok {
+		writeError(w, &googleapi.Error{Code: http.StatusNotFound, Message: fmt.Sprintf("operation %q not found", s.opName(req))})
+		return
+	}
+	writeJSON(w, op)
+}
+
+// opName is the relative resource name of the operation in req.
+func (s *Server) opName(req *request) string {
+	if req.apiGroup == meta.APIGroupNetworkServices {
+		return fmt.Sprintf("projects/%s/locations/global/operations/%s", req.project, req.name)
+	}
+	return cloud.RelativeResourceName(req.project, "operations", req.key())
+}
+
+// writeOp writes the (completed) operation for a call with result out.
+func (s *Server) writeOp(w http.ResponseWriter, svc *meta.ServiceInfo, req *request, opType string, out []reflect.Value) {
+	if err := resultErr(out); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.nextOp++
+	opReq := *req
+	opReq.resource = "operations"
+	opReq.name = fmt.Sprintf("operation-%d", s.nextOp)
+	name := s.opName(&opReq)
+
+	var op map[string]interface{}
+	if svc.IsNetworkServices() {
+		op = map[string]interface{}{
+			"name": name,
+			"done": true,
+		}
+	} else {
+		op = map[string]interface{}{
+			"kind":          "compute#operation",
+			"name":          opReq.name,
+			"operationType": opType,
+			"status":        "DONE",
+			"progress":      100,
+			"targetLink":    cloud.SelfLinkWithGroup(req.apiGroup, req.version, req.project, req.resource, req.key()),
+			"selfLink":      cloud.SelfLinkWithGroup(req.apiGroup, req.version, req.project, "operations", opReq.key()),
+		}
+	}
+	s.ops[name] = op
+	writeJSON(w, op)
+}
+
+// writeResult writes the result of a (obj, error) call.
+func (s *Server) writeResult(w http.ResponseWriter, out []reflect.Value) {
+	if err := resultErr(out); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, out[0].Interface())
+}
+
+// resultErr returns the error from the results of a method call. The error is
+// always the last return value.
+func resultErr(out []reflect.Value) error {
+	last := out[len(out)-1]
+	if last.IsNil() {
+		return nil
+	}
+	return last.Interface().(error)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		klog.Errorf("fakeserver: error encoding response: %v", err)
+	}
+}
+
+// writeError writes err in the format of the Google APIs so it is decoded as
+// a *googleapi.Error by the client. Errors that are not *googleapi.Error are
+// returned as http.StatusBadRequest.
+func writeError(w http.ResponseWriter, err error) {
+	gerr, ok := err.(*googleapi.Error)
+	if !ok {
+		gerr = &googleapi.Error{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+	body := struct {
+		Error struct {
+			Code    int                   `json:"code"`
+			Message string                `json:"message"`
+			Errors  []googleapi.ErrorItem `json:"errors,omitempty"`
+		} `json:"error"`
+	}{}
+	body.Error.Code = gerr.Code
+	body.Error.Message = gerr.Message
+	body.Error.Errors = gerr.Errors
+
+	klog.V(5).Infof("fakeserver: error %v", gerr)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(gerr.Code)
+	json.NewEncoder(w).Encode(body)
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	return string(append([]rune{unicode.ToLower(r[0])}, r[1:]...))
+}
+
+func upperFirst(s string) string {
+	r := []rune(s)
+	return string(append([]rune{unicode.ToUpper(r[0])}, r[1:]...))
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	networkservices "google.golang.org/api/networkservices/v1"
+)
+
+const project = "fake-project"
+
+func newTestCloud(t *testing.T) (*cloud.MockGCE, cloud.Cloud) {
+	t.Helper()
+
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+	mockGCE.EnforceReferentialIntegrity(true)
+	srv := New(mockGCE)
+	t.Cleanup(srv.Close)
+
+	svc, err := cloud.NewService(context.Background(), srv.Client(), &cloud.SingleProjectRouter{ID: project}, &cloud.NopRateLimiter{})
+	if err != nil {
+		t.Fatalf("NewService() = %v", err)
+	}
+	return mockGCE, cloud.NewGCE(svc)
+}
+
+func errCode(err error) int {
+	if gerr, ok := err.(*googleapi.Error); ok {
+		return gerr.Code
+	}
+	return 0
+}
+
+func TestParseRequest(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		path    string
+		want    request
+		wantErr bool
+	}{
+		{
+			path: "/compute/v1/projects/p/global/backendServices",
+			want: request{apiGroup: meta.APIGroupCompute, version: meta.VersionGA, project: "p", scope: "global", resource: "backendServices"},
+		},
+		{
+			path: "/compute/beta/projects/p/regions/r/forwardingRules/fr/setTarget",
+			want: request{apiGroup: meta.APIGroupCompute, version: meta.VersionBeta, project: "p", scope: "regions", location: "r", resource: "forwardingRules", name: "fr", method: "setTarget"},
+		},
+		{
+			path: "/compute/alpha/projects/p/zones/z/operations/op/wait",
+			want: request{apiGroup: meta.APIGroupCompute, version: meta.VersionAlpha, project: "p", scope: "zones", location: "z", resource: "operations", name: "op", method: "wait"},
+		},
+		{
+			path: "/compute/v1/projects/p/aggregated/addresses",
+			want: request{apiGroup: meta.APIGroupCompute, version: meta.VersionGA, project: "p", scope: "aggregated", resource: "addresses"},
+		},
+		{
+			path: "/compute/v1/projects/p/regions/us-central1",
+			want: request{apiGroup: meta.APIGroupCompute, version: meta.VersionGA, project: "p", scope: "global", resource: "regions", name: "us-central1"},
+		},
+		{
+			path: "/compute/v1/projects/p",
+			want: request{apiGroup: meta.APIGroupCompute, version: meta.VersionGA, project: "p", resource: "projects"},
+		},
+		{
+			path: "/v1beta1/projects/p/locations/global/meshes/m",
+			want: request{apiGroup: meta.APIGroupNetworkServices, version: meta.VersionBeta, project: "p", scope: "global", resource: "meshes", name: "m"},
+		},
+		{
+			path: "/v1/p/tcpRoutes",
+			want: request{apiGroup: meta.APIGroupNetworkServices, version: meta.VersionGA, project: "p", scope: "global", resource: "tcpRoutes"},
+		},
+		{path: "/compute/v2/projects/p/global/backendServices", wantErr: true},
+		{path: "/compute/v1/projects/p/moon/backendServices", wantErr: true},
+		{path: "/compute/v1/projects/p/global/a/b/c/d", wantErr: true},
+		{path: "/v1/projects/p/locations/us-central1/meshes", wantErr: true},
+	} {
+		got, err := parseRequest(tc.path)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("parseRequest(%q) = %v; gotErr = %t, want %t", tc.path, err, gotErr, tc.wantErr)
+			continue
+		}
+		if err == nil && *got != tc.want {
+			t.Errorf("parseRequest(%q) = %+v, want %+v", tc.path, *got, tc.want)
+		}
+	}
+}
+
+func TestRegionalCRUD(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, theCloud := newTestCloud(t)
+
+	key := meta.RegionalKey("addr", "us-central1")
+	if err := theCloud.Addresses().Insert(ctx, key, &ga.Address{Name: key.Name, Description: "desc"}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	if err := theCloud.Addresses().Insert(ctx, key, &ga.Address{Name: key.Name}); errCode(err) != http.StatusConflict {
+		t.Errorf("Insert() of existing object = %v, want code %d", err, http.StatusConflict)
+	}
+	// Read back with a different API version.
+	addr, err := theCloud.AlphaAddresses().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if addr.Description != "desc" {
+		t.Errorf("Get() = %+v, want Description = desc", addr)
+	}
+	l, err := theCloud.Addresses().List(ctx, "us-central1", filter.None)
+	if err != nil || len(l) != 1 {
+		t.Errorf("List() = %v, %v; want 1 item", l, err)
+	}
+	if l, err := theCloud.Addresses().List(ctx, "europe-west1", filter.None); err != nil || len(l) != 0 {
+		t.Errorf("List(europe-west1) = %v, %v; want 0 items", l, err)
+	}
+	agg, err := theCloud.Addresses().AggregatedList(ctx, filter.None)
+	if err != nil || len(agg["regions/us-central1"]) != 1 {
+		t.Errorf("AggregatedList() = %v, %v; want 1 item in regions/us-central1", agg, err)
+	}
+	if err := theCloud.Addresses().Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	if _, err := theCloud.Addresses().Get(ctx, key); errCode(err) != http.StatusNotFound {
+		t.Errorf("Get() after Delete() = %v, want code %d", err, http.StatusNotFound)
+	}
+}
+
+func TestInUseError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, theCloud := newTestCloud(t)
+
+	hcKey := meta.GlobalKey("hc")
+	if err := theCloud.HealthChecks().Insert(ctx, hcKey, &ga.HealthCheck{Name: hcKey.Name}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	bsKey := meta.GlobalKey("bs")
+	bs := &ga.BackendService{
+		Name:         bsKey.Name,
+		HealthChecks: []string{cloud.SelfLink(meta.VersionGA, project, "healthChecks", hcKey)},
+	}
+	if err := theCloud.BackendServices().Insert(ctx, bsKey, bs); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+
+	err := theCloud.HealthChecks().Delete(ctx, hcKey)
+	gerr, ok := err.(*googleapi.Error)
+	if !ok || gerr.Code != http.StatusBadRequest || len(gerr.Errors) != 1 || gerr.Errors[0].Reason != cloud.ResourceInUseReason {
+		t.Errorf("Delete() = %v, want %s error", err, cloud.ResourceInUseReason)
+	}
+}
+
+func TestMethods(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockGCE, theCloud := newTestCloud(t)
+	mockGCE.MockAlphaUrlMaps.UpdateHook = mock.UpdateAlphaURLMapHook
+	mockGCE.MockInstances.DetachDiskHook = mock.DetachDiskHook
+
+	// Update (PUT) with an object in the body.
+	umKey := meta.GlobalKey("um")
+	if err := theCloud.AlphaUrlMaps().Insert(ctx, umKey, &alpha.UrlMap{Name: umKey.Name}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	if err := theCloud.AlphaUrlMaps().Update(ctx, umKey, &alpha.UrlMap{Name: umKey.Name, DefaultService: "bs"}); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if um, err := theCloud.UrlMaps().Get(ctx, umKey); err != nil || um.DefaultService != "bs" {
+		t.Errorf("Get() = %+v, %v; want DefaultService = bs", um, err)
+	}
+
+	// POST method with a scalar parameter.
+	vmKey := meta.ZonalKey("vm", "us-central1-b")
+	vm := &ga.Instance{Name: vmKey.Name, Disks: []*ga.AttachedDisk{{DeviceName: "disk"}}}
+	if err := theCloud.Instances().Insert(ctx, vmKey, vm); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	if err := theCloud.Instances().DetachDisk(ctx, vmKey, "disk"); err != nil {
+		t.Fatalf("DetachDisk() = %v", err)
+	}
+	if got, err := theCloud.Instances().Get(ctx, vmKey); err != nil || len(got.Disks) != 0 {
+		t.Errorf("Get() = %+v, %v; want no disks", got, err)
+	}
+	if err := theCloud.Instances().DetachDisk(ctx, vmKey, "disk"); errCode(err) != http.StatusNotFound {
+		t.Errorf("DetachDisk() of missing disk = %v, want code %d", err, http.StatusNotFound)
+	}
+}
+
+func TestMethodParamsByName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+	mockGCE.MockInstances.DetachDiskHook = mock.DetachDiskHook
+	srv := New(mockGCE)
+	t.Cleanup(srv.Close)
+
+	vmKey := meta.ZonalKey("vm", "us-central1-b")
+	vm := &ga.Instance{Name: vmKey.Name, Disks: []*ga.AttachedDisk{{DeviceName: "disk"}}}
+	if err := mockGCE.Instances().Insert(ctx, vmKey, vm); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+
+	// "a" sorts before "deviceName" and must not be bound to the argument.
+	for _, tc := range []struct {
+		query    string
+		wantCode int
+	}{
+		{query: "a=other&deviceName=disk", wantCode: http.StatusOK},
+		{query: "a=disk", wantCode: http.StatusBadRequest},
+	} {
+		url := srv.URL() + "/compute/v1/projects/" + project + "/zones/us-central1-b/instances/vm/detachDisk?" + tc.query
+		resp, err := srv.Client().Post(url, "application/json", nil)
+		if err != nil {
+			t.Fatalf("Post(%q) = %v", url, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.wantCode {
+			t.Errorf("Post(%q): code = %d, want %d", url, resp.StatusCode, tc.wantCode)
+		}
+	}
+	if got, err := mockGCE.Instances().Get(ctx, vmKey); err != nil || len(got.Disks) != 0 {
+		t.Errorf("Get() = %+v, %v; want no disks", got, err)
+	}
+}
+
+func TestNetworkServices(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, theCloud := newTestCloud(t)
+
+	key := meta.GlobalKey("route")
+	route := &networkservices.TcpRoute{
+		Rules: []*networkservices.TcpRouteRouteRule{{
+			Action: &networkservices.TcpRouteRouteAction{OriginalDestination: true},
+		}},
+	}
+	if err := theCloud.TcpRoutes().Insert(ctx, key, route); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	got, err := theCloud.BetaTcpRoutes().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if len(got.Rules) != 1 || !got.Rules[0].Action.OriginalDestination {
+		t.Errorf("Get() = %+v, want the inserted rules", got)
+	}
+	if l, err := theCloud.TcpRoutes().List(ctx, filter.None); err != nil || len(l) != 1 {
+		t.Errorf("List() = %v, %v; want 1 item", l, err)
+	}
+	if err := theCloud.TcpRoutes().Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	if _, err := theCloud.TcpRoutes().Get(ctx, key); errCode(err) != http.StatusNotFound {
+		t.Errorf("Get() after Delete() = %v, want code %d", err, http.StatusNotFound)
+	}
+}
@@ -39,6 +39,7 @@ type MockProjectOpsState struct {
 
 // Get a project by projectID.
 func (m *MockProjects) Get(ctx context.Context, projectID string) (*compute.Project, error) {
+	m.CallLog.record("Projects", meta.VersionGA, "Get", meta.GlobalKey(projectID))
 	m.Lock.Lock()
 	defer m.Lock.Unlock()
 
@@ -70,12 +71,16 @@ func (g *GCEProjects) Get(ctx context.Context, projectID string) (*compute.Proje
 }
 
 // SetCommonInstanceMetadata for a given project.
-func (m *MockProjects) SetCommonInstanceMetadata(ctx context.Context, projectID string, meta *compute.Metadata) error {
+func (m *MockProjects) SetCommonInstanceMetadata(ctx context.Context, projectID string, md *compute.Metadata) error {
+	m.CallLog.record("Projects", meta.VersionGA, "SetCommonInstanceMetadata", meta.GlobalKey(projectID))
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	if m.X == nil {
 		m.X = &MockProjectOpsState{metadata: map[string]*compute.Metadata{}}
 	}
 	state := m.X.(*MockProjectOpsState)
-	state.metadata[projectID] = meta
+	state.metadata[projectID] = md
 	return nil
 }
 
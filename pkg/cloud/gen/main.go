@@ -45,7 +45,6 @@ const (
 	gaComputePackage           = "google.golang.org/api/compute/v1"
 	betaNetworkServicesPackage = "google.golang.org/api/networkservices/v1beta1"
 	gaNetworkServicesPackage   = "google.golang.org/api/networkservices/v1"
-	kLogEnabled                = ".Enabled()"
 
 	filterPackage = packageRoot + "/filter"
 	metaPackage   = packageRoot + "/meta"
@@ -62,7 +61,7 @@ var flags = struct {
 
 func init() {
 	flag.BoolVar(&flags.gofmt, "gofmt", true, "run output through gofmt")
-	flag.StringVar(&flags.mode, "mode", "src", "content to generate: src, test, dummy")
+	flag.StringVar(&flags.mode, "mode", "src", "content to generate: src, test, fingerprint")
 }
 
 // gofmtContent runs "gofmt" on the given contents.
@@ -163,22 +162,6 @@ import (
 	}
 
 	fmt.Fprintf(wr, ")\n\n")
-
-	const kLogAdapter = `
-
-func kLogEnabled(level klog.Level) bool {
-	return klog.V(level){{.KLogEnabled}} == true
-}
-
-`
-
-	kLogAdapterTemplate := template.Must(template.New("klogadapter").Parse(kLogAdapter))
-	kLogTemplateValues := map[string]string{
-		"KLogEnabled": kLogEnabled,
-	}
-	if err := kLogAdapterTemplate.Execute(wr, kLogTemplateValues); err != nil {
-		panic(err)
-	}
 }
 
 // genStubs generates the interface and wrapper stubs.
@@ -228,9 +211,75 @@ func NewMockGCE(projectRouter ProjectRouter) *MockGCE {
 		{{.MockField}}: New{{.MockWrapType}}(projectRouter, mock{{.Service}}Objs),
 	{{- end}}
 	}
+	{{- range .All}}
+	mock.{{.MockField}}.CallLog = &mock.callLog
+	mock.{{.MockField}}.stress = &mock.stress
+	{{- end}}
+	// The mocks for the different versions share the same Objects and must
+	// share the lock and index.
+	{{- range .Groups}}
+	{{- $base := .ServiceInfo.MockField}}
+	{{- if and .Alpha (ne .Alpha.MockField $base)}}
+	mock.{{.Alpha.MockField}}.Lock.share(&mock.{{$base}}.Lock)
+	mock.{{.Alpha.MockField}}.index = mock.{{$base}}.index
+	{{- end}}
+	{{- if and .Beta (ne .Beta.MockField $base)}}
+	mock.{{.Beta.MockField}}.Lock.share(&mock.{{$base}}.Lock)
+	mock.{{.Beta.MockField}}.index = mock.{{$base}}.index
+	{{- end}}
+	{{- end}}
 	return mock
 }
 
+// EnforceReferentialIntegrity configures whether Delete() fails with a
+// "resourceInUseByAnotherResource" error when the object being deleted is
+// still referenced by another object in the mock. This matches the behavior
+// of GCE. It is disabled by default as the check looks at every object in
+// the mock.
+func (mock *MockGCE) EnforceReferentialIntegrity(enforce bool) {
+	var checker *mockInUseChecker
+	if enforce {
+		checker = &mock.inUse
+		checker.mock = mock
+	}
+	{{- range .All}}
+	{{- if .GenerateDelete}}
+	mock.{{.MockField}}.inUse = checker
+	{{- end}}
+	{{- end}}
+}
+
+// seed copies the objects of the services selected by cfg from src into the
+// mock.
+func (mock *MockGCE) seed(ctx context.Context, src Cloud, cfg *SeedConfig) error {
+	{{- range .Groups}}
+	{{- with .ServiceInfo}}
+	{{- if and .GenerateList (not .KeyIsProject)}}
+	if cfg.selected("{{.Service}}") {
+		if err := mock.{{.MockField}}.seed(ctx, src.{{.WrapType}}(), cfg); err != nil {
+			return fmt.Errorf("{{.Service}}: %w", err)
+		}
+	}
+	{{- end}}
+	{{- end}}
+	{{- end}}
+	return nil
+}
+
+// forEachObject calls f for every object stored in the mock until f returns
+// false. The lock of each mock is held while its objects are visited. held is
+// a lock that is already held by the caller (or nil): the mocks that use it
+// are visited without locking.
+func (mock *MockGCE) forEachObject(held *sync.Mutex, f func(obj interface{}) bool) {
+	{{- range .Groups}}
+	{{- with .ServiceInfo}}
+	if !mock.{{.MockField}}.forEachObject(held, f) {
+		return
+	}
+	{{- end}}
+	{{- end}}
+}
+
 // MockGCE implements Cloud.
 var _ Cloud = (*MockGCE)(nil)
 
@@ -239,6 +288,10 @@ type MockGCE struct {
 {{- range .All}}
 	{{.MockField}} *{{.MockWrapType}}
 {{- end}}
+
+	callLog MockCallLog
+	stress  mockStress
+	inUse   mockInUseChecker
 }
 {{range .All}}
 // {{.WrapType}} returns the interface for the {{.Version}} {{.Service}}.
@@ -248,6 +301,60 @@ func (mock *MockGCE) {{.WrapType}}() {{.WrapType}} {
 {{end}}
 
 {{range .Groups}}
+{{- with .ServiceInfo}}
+{{- if and .GenerateList (not .KeyIsProject)}}
+// seed lists the objects in src and adds them to the mock.
+func (m *{{.MockWrapType}}) seed(ctx context.Context, src {{.WrapType}}, cfg *SeedConfig) error {
+	var objs []*{{.FQObjectType}}
+{{- if .KeyIsGlobal}}
+	objs, err := src.List(ctx, filter.None)
+	if err != nil {
+		return err
+	}
+{{- else if .AggregatedList}}
+	all, err := src.AggregatedList(ctx, filter.None)
+	if err != nil {
+		return err
+	}
+	for _, l := range all {
+		objs = append(objs, l...)
+	}
+{{- else if .KeyIsRegional}}
+	for _, region := range cfg.Regions {
+		l, err := src.List(ctx, region, filter.None)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, l...)
+	}
+{{- else}}
+	for _, zone := range cfg.Zones {
+		l, err := src.List(ctx, zone, filter.None)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, l...)
+	}
+{{- end}}
+
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	for _, obj := range objs {
+		key, err := seedKey(obj.Name, obj.SelfLink)
+		if err != nil {
+			return err
+		}
+		m.syncIndex()
+		m.Objects[*key] = &Mock{{.Service}}Obj{obj}
+		m.index.add(*key, obj)
+	}
+	klog.V(5).Infof("{{.MockWrapType}}.seed(%v) = [%v items]", ctx, len(objs))
+	return nil
+}
+{{- end}}
+{{- end}}
+
 // Mock{{.Service}}Obj is used to store the various object versions in the shared
 // map of mocked objects. This allows for multiple API versions to co-exist and
 // share the same "view" of the objects in the backend.
@@ -357,6 +464,7 @@ func New{{.MockWrapType}}(pr ProjectRouter, objs map[meta.Key]*Mock{{.Service}}O
 		ProjectRouter: pr,
 
 		Objects: objs,
+		index:   newMockIndex(),
 		{{- if .GenerateGet}}
 		GetError:    map[meta.Key]error{},
 		{{- end -}}
@@ -372,12 +480,19 @@ func New{{.MockWrapType}}(pr ProjectRouter, objs map[meta.Key]*Mock{{.Service}}O
 
 // {{.MockWrapType}} is the mock for {{.Service}}.
 type {{.MockWrapType}} struct {
-	Lock sync.Mutex
+	// Lock protects Objects. It is shared with the mocks for the other
+	// versions of the service (see MockLock).
+	Lock MockLock
 
 	ProjectRouter ProjectRouter
 
-	// Objects maintained by the mock.
+	// Objects maintained by the mock. Changes made by the hooks are picked
+	// up automatically. Call Reindex() after modifying Objects directly
+	// outside of a hook if the number of objects is unchanged, e.g. when
+	// replacing an object with one that has different labels.
 	Objects map[meta.Key]*Mock{{.Service}}Obj
+	// index of the keys in Objects.
+	index *mockIndex
 
 	// If an entry exists for the given key and operation, then the error
 	// will be returned instead of the operation.
@@ -436,6 +551,19 @@ type {{.MockWrapType}} struct {
 	{{.MockHook}}
 {{- end -}}
 {{- end}}
+{{- if .GenerateDelete}}
+
+	// inUse checks that the object is not referenced by another resource
+	// on Delete(). nil if the check is disabled. See
+	// MockGCE.EnforceReferentialIntegrity().
+	inUse *mockInUseChecker
+{{- end}}
+
+	// CallLog records the calls made to the mock. Calls are not recorded if
+	// it is nil.
+	CallLog *MockCallLog
+	// stress validates the use of Objects. See MockGCE.EnableStressMode().
+	stress *mockStress
 
 	// X is extra state that can be used as part of the mock. Generated code
 	// will not use this field.
@@ -445,6 +573,7 @@ type {{.MockWrapType}} struct {
 {{- if .GenerateGet}}
 // Get returns the object from the mock.
 func (m *{{.MockWrapType}}) Get(ctx context.Context, key *meta.Key, options... Option) (*{{.FQObjectType}}, error) {
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "Get", key)
 	if m.GetHook != nil {
 		if intercept, obj, err := m.GetHook(ctx, key, m, options...);  intercept {
 			klog.V(5).Infof("{{.MockWrapType}}.Get(%v, %s) = %+v, %v", ctx, key, obj ,err)
@@ -457,6 +586,7 @@ func (m *{{.MockWrapType}}) Get(ctx context.Context, key *meta.Key, options... O
 
 	m.Lock.Lock()
 	defer m.Lock.Unlock()
+	m.stress.check("{{.MockWrapType}}.Get", m.snapshot)
 
 	if err, ok := m.GetError[*key]; ok {
 		klog.V(5).Infof("{{.MockWrapType}}.Get(%v, %s) = nil, %v", ctx, key, err)
@@ -490,6 +620,15 @@ func (m *{{.MockWrapType}}) List(ctx context.Context, region string, fl *filter.
 // List all of the objects in the mock in the given zone.
 func (m *{{.MockWrapType}}) List(ctx context.Context, zone string, fl *filter.F, options... Option) ([]*{{.FQObjectType}}, error) {
 {{- end}}
+	{{- if .KeyIsGlobal}}
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "List", nil)
+	{{- end -}}
+	{{- if .KeyIsRegional}}
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "List", &meta.Key{Region: region})
+	{{- end -}}
+	{{- if .KeyIsZonal}}
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "List", &meta.Key{Zone: zone})
+	{{- end}}
 	if m.ListHook != nil {
 		{{if .KeyIsGlobal -}}
 		if intercept, objs, err := m.ListHook(ctx, fl, m, options...);  intercept {
@@ -509,6 +648,7 @@ func (m *{{.MockWrapType}}) List(ctx context.Context, zone string, fl *filter.F,
 
 	m.Lock.Lock()
 	defer m.Lock.Unlock()
+	m.stress.check("{{.MockWrapType}}.List", m.snapshot)
 
 	if m.ListError != nil {
 		err := *m.ListError
@@ -529,22 +669,23 @@ func (m *{{.MockWrapType}}) List(ctx context.Context, zone string, fl *filter.F,
 {{- if .KeyIsGlobal}}
 	for _, obj := range m.Objects {
 {{- else}}
-	for key, obj := range m.Objects {
-{{- end -}}
+	m.syncIndex()
 {{- if .KeyIsRegional}}
-		if key.Region != region {
-			continue
-		}
+	for _, key := range m.index.inScope(region) {
 {{- end -}}
 {{- if .KeyIsZonal}}
-		if key.Zone != zone {
+	for _, key := range m.index.inScope(zone) {
+{{- end}}
+		obj, ok := m.Objects[key]
+		if !ok {
 			continue
 		}
 {{- end}}
-		if !fl.Match(obj.To{{.VersionTitle}}()) {
+		typedObj := obj.To{{.VersionTitle}}()
+		if !fl.Match(typedObj) {
 			continue
 		}
-		objs = append(objs, obj.To{{.VersionTitle}}())
+		objs = append(objs, typedObj)
 	}
 
 	{{if .KeyIsGlobal -}}
@@ -563,8 +704,12 @@ func (m *{{.MockWrapType}}) List(ctx context.Context, zone string, fl *filter.F,
 {{- if .GenerateInsert}}
 // Insert is a mock for inserting/creating a new object.
 func (m *{{.MockWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.FQObjectType}}, options... Option) error {
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "Insert", key)
 	if m.InsertHook != nil {
-		if intercept, err := m.InsertHook(ctx, key, obj, m, options...);  intercept {
+		intercept, err := m.InsertHook(ctx, key, obj, m, options...)
+		// The hook may have modified Objects.
+		m.invalidateIndex()
+		if intercept {
 			klog.V(5).Infof("{{.MockWrapType}}.Insert(%v, %v, %+v) = %v", ctx, key, obj, err)
 			return err
 		}
@@ -576,6 +721,7 @@ func (m *{{.MockWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.F
 
 	m.Lock.Lock()
 	defer m.Lock.Unlock()
+	m.stress.check("{{.MockWrapType}}.Insert", m.snapshot)
 
 	if err, ok := m.InsertError[*key]; ok {
 		klog.V(5).Infof("{{.MockWrapType}}.Insert(%v, %v, %+v) = %v", ctx, key, obj, err)
@@ -594,7 +740,9 @@ func (m *{{.MockWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.F
 	projectID := getProjectID(ctx, m.ProjectRouter, opts, "{{.Version}}", "{{.Resource}}")
 	obj.SelfLink = SelfLinkWithGroup("{{.APIGroup}}", meta.Version{{.VersionTitle}}, projectID, "{{.Resource}}", key)
 
+	m.syncIndex()
 	m.Objects[*key] = &Mock{{.Service}}Obj{obj}
+	m.index.add(*key, obj)
 	klog.V(5).Infof("{{.MockWrapType}}.Insert(%v, %v, %+v) = nil", ctx, key, obj)
 	return nil
 }
@@ -603,8 +751,12 @@ func (m *{{.MockWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.F
 {{- if .GenerateDelete}}
 // Delete is a mock for deleting the object.
 func (m *{{.MockWrapType}}) Delete(ctx context.Context, key *meta.Key, options... Option) error {
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "Delete", key)
 	if m.DeleteHook != nil {
-		if intercept, err := m.DeleteHook(ctx, key, m, options...);  intercept {
+		intercept, err := m.DeleteHook(ctx, key, m, options...)
+		// The hook may have modified Objects.
+		m.invalidateIndex()
+		if intercept {
 			klog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
 			return err
 		}
@@ -613,8 +765,15 @@ func (m *{{.MockWrapType}}) Delete(ctx context.Context, key *meta.Key, options..
 		return fmt.Errorf("invalid GCE key (%+v)", key)
 	}
 
+	if m.inUse != nil {
+		// The check locks the other mocks while this one is locked. It is
+		// serialized to avoid lock cycles between concurrent Deletes.
+		m.inUse.Lock()
+		defer m.inUse.Unlock()
+	}
 	m.Lock.Lock()
 	defer m.Lock.Unlock()
+	m.stress.check("{{.MockWrapType}}.Delete", m.snapshot)
 
 	if err, ok := m.DeleteError[*key]; ok {
 		klog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
@@ -628,8 +787,20 @@ func (m *{{.MockWrapType}}) Delete(ctx context.Context, key *meta.Key, options..
 		klog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
 		return err
 	}
+	if m.inUse != nil {
+		projectID := getProjectID(ctx, m.ProjectRouter, mergeOptions(options), "{{.Version}}", "{{.Resource}}")
+		id := &ResourceID{ProjectID: projectID, APIGroup: "{{.APIGroup}}", Resource: "{{.Resource}}", Key: key}
+		// The lock is held so that no reference can be added between the
+		// check and the delete.
+		if err := m.inUse.check(ctx, id, m.Lock.mutex()); err != nil {
+			klog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
+			return err
+		}
+	}
 
+	m.syncIndex()
 	delete(m.Objects, *key)
+	m.index.remove(*key)
 	klog.V(5).Infof("{{.MockWrapType}}.Delete(%v, %v) = nil", ctx, key)
 	return nil
 }
@@ -638,6 +809,7 @@ func (m *{{.MockWrapType}}) Delete(ctx context.Context, key *meta.Key, options..
 {{- if .AggregatedList}}
 // AggregatedList is a mock for AggregatedList.
 func (m *{{.MockWrapType}}) AggregatedList(ctx context.Context, fl *filter.F, options... Option) (map[string][]*{{.FQObjectType}}, error) {
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "AggregatedList", nil)
 	if m.AggregatedListHook != nil {
 		if intercept, objs, err := m.AggregatedListHook(ctx, fl, m, options...); intercept {
 			klog.V(5).Infof("{{.MockWrapType}}.AggregatedList(%v, %v) = [%v items], %v", ctx, fl, len(objs), err)
@@ -647,6 +819,7 @@ func (m *{{.MockWrapType}}) AggregatedList(ctx context.Context, fl *filter.F, op
 
 	m.Lock.Lock()
 	defer m.Lock.Unlock()
+	m.stress.check("{{.MockWrapType}}.AggregatedList", m.snapshot)
 
 	if m.AggregatedListError != nil {
 		err := *m.AggregatedListError
@@ -675,6 +848,7 @@ func (m *{{.MockWrapType}}) AggregatedList(ctx context.Context, fl *filter.F, op
 {{- if .ListUsable}}
 // List all of the objects in the mock.
 func (m *{{.MockWrapType}}) ListUsable(ctx context.Context, fl *filter.F, options... Option) ([]*{{.FQListUsableObjectType}}, error) {
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "ListUsable", nil)
 	if m.ListUsableHook != nil {
 		if intercept, objs, err := m.ListUsableHook(ctx, fl, m, options...);  intercept {
 			klog.V(5).Infof("{{.MockWrapType}}.ListUsable(%v, %v) = [%v items], %v", ctx, fl, len(objs), err)
@@ -684,6 +858,7 @@ func (m *{{.MockWrapType}}) ListUsable(ctx context.Context, fl *filter.F, option
 
 	m.Lock.Lock()
 	defer m.Lock.Unlock()
+	m.stress.check("{{.MockWrapType}}.ListUsable", m.snapshot)
 
 	if m.ListError != nil {
 		err := *m.ListError
@@ -715,15 +890,148 @@ func (m *{{.MockWrapType}}) Obj(o *{{.FQObjectType}}) *Mock{{.Service}}Obj {
 	return &Mock{{.Service}}Obj{o}
 }
 
+// forEachObject calls f for each object in the mock until f returns false.
+// Returns false if the iteration was stopped by f. The mock is not locked if
+// its Lock is held, the lock that the caller already holds.
+func (m *{{.MockWrapType}}) forEachObject(held *sync.Mutex, f func(obj interface{}) bool) bool {
+	if lock := m.Lock.mutex(); lock != held {
+		lock.Lock()
+		defer lock.Unlock()
+	}
+
+	for _, obj := range m.Objects {
+		if !f(obj.Obj) {
+			return false
+		}
+	}
+	return true
+}
+
+// syncIndex rebuilds the index if Objects was modified directly.
+// m.Lock must be held.
+func (m *{{.MockWrapType}}) syncIndex() {
+	if m.index == nil {
+		m.index = newMockIndex()
+	}
+	if m.index.stale(len(m.Objects)) {
+		m.reindex()
+	}
+}
+
+// snapshot returns the objects in the mock. m.Lock must be held.
+func (m *{{.MockWrapType}}) snapshot() map[meta.Key]interface{} {
+	objs := make(map[meta.Key]interface{}, len(m.Objects))
+	for key, obj := range m.Objects {
+		objs[key] = obj.Obj
+	}
+	return objs
+}
+
+// reindex rebuilds the index. m.Lock must be held.
+func (m *{{.MockWrapType}}) reindex() {
+	m.index.rebuild(m.snapshot())
+}
+
+// invalidateIndex marks the index to be rebuilt on the next lookup. This is
+// called after the hooks that write to Objects, as the index cannot tell
+// which objects they changed. The hooks run without m.Lock, so it is taken
+// here.
+func (m *{{.MockWrapType}}) invalidateIndex() {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	if m.index != nil {
+		m.index.invalidate()
+	}
+}
+
+// Reindex rebuilds the index of the objects in the mock.
+func (m *{{.MockWrapType}}) Reindex() {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	if m.index == nil {
+		m.index = newMockIndex()
+	}
+	m.reindex()
+}
+
+// KeysByName returns the keys of the objects with the given name in all
+// locations.
+func (m *{{.MockWrapType}}) KeysByName(name string) []*meta.Key {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	m.syncIndex()
+	return m.index.withName(name)
+}
+
+// KeysByLabel returns the keys of the objects with the label k=v. Labels are
+// only indexed once this has been called.
+func (m *{{.MockWrapType}}) KeysByLabel(k, v string) []*meta.Key {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	m.syncIndex()
+	if !m.index.labelsEnabled() {
+		m.index.enableLabels()
+		m.reindex()
+	}
+	return m.index.withLabel(k, v)
+}
+
 {{with .Methods -}}
 {{- range .}}
 // {{.Name}} is a mock for the corresponding method.
 func (m *{{.MockWrapType}}) {{.FcnArgs}} {
+	m.CallLog.record("{{.Service}}", meta.Version{{.VersionTitle}}, "{{.Name}}", key)
 {{- if .IsOperation }}
 	if m.{{.MockHookName}} != nil {
+		// The hook may modify Objects.
+		defer m.invalidateIndex()
 		return m.{{.MockHookName}}(ctx, key {{.CallArgs}}, m)
 	}
+{{- if .IsObjectUpdate}}
+	if !key.Valid() {
+		return fmt.Errorf("invalid GCE key (%+v)", key)
+	}
+
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+	m.stress.check("{{.MockWrapType}}.{{.Name}}", m.snapshot)
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		err := &googleapi.Error{
+			Code: http.StatusNotFound,
+			Message: fmt.Sprintf("{{.MockWrapType}} %v not found", key),
+		}
+		klog.V(5).Infof("{{.MockWrapType}}.{{.Name}}(%v, %v, %+v) = %v", ctx, key, arg0, err)
+		return err
+	}
+	// The object is replaced by a copy so that objects returned by earlier
+	// calls and arg0 are not shared with the mock.
+	curObj := cur.To{{.VersionTitle}}()
+	obj := &{{.FQObjectType}}{}
+{{- if eq .Name "Patch"}}
+	if err := copyViaJSON(obj, curObj); err != nil {
+		return err
+	}
+{{- end}}
+	if err := copyViaJSON(obj, arg0); err != nil {
+		return err
+	}
+	obj.Name = curObj.Name
+	obj.SelfLink = curObj.SelfLink
+
+	m.syncIndex()
+	m.Objects[*key] = &Mock{{.Service}}Obj{obj}
+	m.index.add(*key, obj)
+	klog.V(5).Infof("{{.MockWrapType}}.{{.Name}}(%v, %v, %+v) = nil", ctx, key, arg0)
+	return nil
+{{- else}}
 	return nil
+{{- end}}
 {{- else if .IsGet}}
 	if m.{{.MockHookName}} != nil {
 		return m.{{.MockHookName}}(ctx, key {{.CallArgs}}, m)
@@ -747,7 +1055,6 @@ type {{.GCPWrapType}} struct {
 // Get the {{.Object}} named by key.
 func (g *{{.GCPWrapType}}) Get(ctx context.Context, key *meta.Key, options... Option) (*{{.FQObjectType}}, error) {
         opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.Get(%v, %v, %v): called", ctx, key, opts)
 
 	if !key.Valid() {
 		klog.V(2).Infof("{{.GCPWrapType}}.Get(%v, %v): key is invalid (%#v)", ctx, key, key)
@@ -762,14 +1069,14 @@ func (g *{{.GCPWrapType}}) Get(ctx context.Context, key *meta.Key, options... Op
 		Service: "{{.Service}}",
 	}
 
-	klog.V(5).Infof("{{.GCPWrapType}}.Get(%v, %v): projectID = %v, ck = %+v", ctx, key, projectID, ck)
-	callObserverStart(ctx, ck)
+	start := callStart(ctx, ck, key)
 	if err := g.s.RateLimiter.Accept(ctx, ck); err != nil {
+		callEnd(ctx, ck, key, start, err)
 		klog.V(4).Infof("{{.GCPWrapType}}.Get(%v, %v): RateLimiter error: %v", ctx, key, err)
 		return nil, err
 	}
 {{- if .IsNetworkServices}}
-    name := fmt.Sprintf("{{.NetworkServicesFmt}}", projectID, key.Name)
+    name := fmt.Sprintf("{{.NetworkServicesFmt}}", projectID, key.Location(), key.Name)
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.Get(name)
 {{- else}}
 	{{- if .KeyIsGlobal}}
@@ -784,9 +1091,8 @@ func (g *{{.GCPWrapType}}) Get(ctx context.Context, key *meta.Key, options... Op
 {{- end}}
 	call.Context(ctx)
 	v, err := call.Do()
-	klog.V(4).Infof("{{.GCPWrapType}}.Get(%v, %v) = %+v, %v", ctx, key, v, err)
 
-	callObserverEnd(ctx, ck, err)
+	callEnd(ctx, ck, key, start, err)
 	g.s.RateLimiter.Observe(ctx, err, ck)
 
 	return v, err
@@ -798,17 +1104,14 @@ func (g *{{.GCPWrapType}}) Get(ctx context.Context, key *meta.Key, options... Op
 {{- if .KeyIsGlobal}}
 func (g *{{.GCPWrapType}}) List(ctx context.Context, fl *filter.F, options... Option) ([]*{{.FQObjectType}}, error) {
         opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.List(%v, %v, %v) called", ctx, fl, opts)
 {{- end -}}
 {{- if .KeyIsRegional}}
 func (g *{{.GCPWrapType}}) List(ctx context.Context, region string, fl *filter.F, options... Option) ([]*{{.FQObjectType}}, error) {
         opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.List(%v, %v, %v, %v) called", ctx, region, fl, opts)
 {{- end -}}
 {{- if .KeyIsZonal}}
 func (g *{{.GCPWrapType}}) List(ctx context.Context, zone string, fl *filter.F, options... Option) ([]*{{.FQObjectType}}, error) {
         opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.List(%v, %v, %v, %v) called", ctx, zone, fl, opts)
 {{- end}}
         projectID := getProjectID(ctx, g.s.ProjectRouter, opts, "{{.Version}}", "{{.Service}}")
 
@@ -819,21 +1122,19 @@ func (g *{{.GCPWrapType}}) List(ctx context.Context, zone string, fl *filter.F,
 		Service: "{{.Service}}",
 	}
 
-	callObserverStart(ctx, ck)
+	start := callStart(ctx, ck, nil)
 	if err := g.s.RateLimiter.Accept(ctx, ck); err != nil {
+		callEnd(ctx, ck, nil, start, err)
 		return nil, err
 	}
 
 {{- if .KeyIsGlobal}}
-	klog.V(5).Infof("{{.GCPWrapType}}.List(%v, %v): projectID = %v, ck = %+v", ctx, fl, projectID, ck)
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.List(projectID)
 {{- end -}}
 {{- if .KeyIsRegional}}
-	klog.V(5).Infof("{{.GCPWrapType}}.List(%v, %v, %v): projectID = %v, ck = %+v", ctx, region, fl, projectID, ck)
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.List(projectID, region)
 {{- end -}}
 {{- if .KeyIsZonal}}
-	klog.V(5).Infof("{{.GCPWrapType}}.List(%v, %v, %v): projectID = %v, ck = %+v", ctx, zone, fl, projectID, ck)
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.List(projectID, zone)
 {{- end}}
 {{- if not .IsNetworkServices }}
@@ -849,26 +1150,14 @@ func (g *{{.GCPWrapType}}) List(ctx context.Context, zone string, fl *filter.F,
 		return nil
 	}
 	if err := call.Pages(ctx, f); err != nil {
-		callObserverEnd(ctx, ck, err)
+		callEnd(ctx, ck, nil, start, err)
 		g.s.RateLimiter.Observe(ctx, err, ck)
-
-		klog.V(4).Infof("{{.GCPWrapType}}.List(%v, ..., %v) = %v, %v", ctx, fl, nil, err)
 		return nil, err
 	}
 
-        callObserverEnd(ctx, ck, nil)
+        callEnd(ctx, ck, nil, start, nil)
 	g.s.RateLimiter.Observe(ctx, nil, ck)
 
-	if kLogEnabled(4) {
-		klog.V(4).Infof("{{.GCPWrapType}}.List(%v, ..., %v) = [%v items], %v", ctx, fl, len(all), nil)
-	} else if kLogEnabled(5) {
-		var asStr []string
-		for _, o := range all {
-			asStr = append(asStr, fmt.Sprintf("%+v", o))
-		}
-		klog.V(5).Infof("{{.GCPWrapType}}.List(%v, ..., %v) = %v, %v", ctx, fl, asStr, nil)
-	}
-
 	return all, nil
 }
 {{- end}}
@@ -877,7 +1166,6 @@ func (g *{{.GCPWrapType}}) List(ctx context.Context, zone string, fl *filter.F,
 // Insert {{.Object}} with key of value obj.
 func (g *{{.GCPWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.FQObjectType}}, options... Option) error {
     opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.Insert(%v, %v, %+v, %v): called", ctx, key, obj, opts)
 	if !key.Valid() {
 		klog.V(2).Infof("{{.GCPWrapType}}.Insert(%v, %v, ...): key is invalid (%#v)", ctx, key, key)
 		return fmt.Errorf("invalid GCE key (%+v)", key)
@@ -892,23 +1180,20 @@ func (g *{{.GCPWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.FQ
 		Service: "{{.Service}}",
 	}
 	{{- if .IsNetworkServices}}
-	klog.V(5).Infof("{{.GCPWrapType}}.Create(%v, %v, ...): projectID = %v, ck = %+v", ctx, key, projectID, ck)
 	{{- else}}
-	klog.V(5).Infof("{{.GCPWrapType}}.Insert(%v, %v, ...): projectID = %v, ck = %+v", ctx, key, projectID, ck)
 	{{- end}}
-	callObserverStart(ctx, ck)
+	start := callStart(ctx, ck, key)
 	if err := g.s.RateLimiter.Accept(ctx, ck); err != nil {
+		callEnd(ctx, ck, key, start, err)
 		klog.V(4).Infof("{{.GCPWrapType}}.Insert(%v, %v, ...): RateLimiter error: %v", ctx, key, err)
 		return err
 	}
 	obj.Name = key.Name
 
 {{- if .IsNetworkServices}}
-	parent := fmt.Sprintf("projects/%s/locations/global", projectID)
+	parent := fmt.Sprintf("projects/%s/locations/%s", projectID, key.Location())
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.Create(parent, obj)
-	{{- if hasSuffix .Object "Route"}}
-	  call.{{.Object}}Id(obj.Name)
-	{{- end}}
+	call.{{.Object}}Id(obj.Name)
 {{- else}}
 	{{- if .KeyIsGlobal}}
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.Insert(projectID, obj)
@@ -924,17 +1209,14 @@ func (g *{{.GCPWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.FQ
 
 	op, err := call.Do()
 
-	callObserverEnd(ctx, ck, err)
+	callEnd(ctx, ck, key, start, err)
 	g.s.RateLimiter.Observe(ctx, err, ck)
 
 	if err != nil {
-		klog.V(4).Infof("{{.GCPWrapType}}.Insert(%v, %v, ...) = %+v", ctx, key, err)
 		return err
 	}
 
-	err = g.s.WaitForCompletion(ctx, op)
-	klog.V(4).Infof("{{.GCPWrapType}}.Insert(%v, %v, %+v) = %+v", ctx, key, obj, err)
-	return err
+	return g.s.WaitForCompletion(ctx, op)
 }
 {{- end}}
 
@@ -942,7 +1224,6 @@ func (g *{{.GCPWrapType}}) Insert(ctx context.Context, key *meta.Key, obj *{{.FQ
 // Delete the {{.Object}} referenced by key.
 func (g *{{.GCPWrapType}}) Delete(ctx context.Context, key *meta.Key, options... Option) error {
         opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.Delete(%v, %v, %v): called", ctx, key, opts)
 	if !key.Valid() {
 		klog.V(2).Infof("{{.GCPWrapType}}.Delete(%v, %v): key is invalid (%#v)", ctx, key, key)
 		return fmt.Errorf("invalid GCE key (%+v)", key)
@@ -955,14 +1236,14 @@ func (g *{{.GCPWrapType}}) Delete(ctx context.Context, key *meta.Key, options...
 		Version: meta.Version("{{.Version}}"),
 		Service: "{{.Service}}",
 	}
-	klog.V(5).Infof("{{.GCPWrapType}}.Delete(%v, %v): projectID = %v, ck = %+v", ctx, key, projectID, ck)
-	callObserverStart(ctx, ck)
+	start := callStart(ctx, ck, key)
 	if err := g.s.RateLimiter.Accept(ctx, ck); err != nil {
+		callEnd(ctx, ck, key, start, err)
 		klog.V(4).Infof("{{.GCPWrapType}}.Delete(%v, %v): RateLimiter error: %v", ctx, key, err)
 		return err
 	}
 {{- if .IsNetworkServices}}
-	name := fmt.Sprintf("{{.NetworkServicesFmt}}", projectID, key.Name)
+	name := fmt.Sprintf("{{.NetworkServicesFmt}}", projectID, key.Location(), key.Name)
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.Delete(name)
 {{- else}}
 	{{- if .KeyIsGlobal}}
@@ -980,17 +1261,14 @@ func (g *{{.GCPWrapType}}) Delete(ctx context.Context, key *meta.Key, options...
 
 	op, err := call.Do()
 
-	callObserverEnd(ctx, ck, err)
+	callEnd(ctx, ck, key, start, err)
 	g.s.RateLimiter.Observe(ctx, err, ck)
 
 	if err != nil {
-		klog.V(4).Infof("{{.GCPWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
 		return err
 	}
 
-	err = g.s.WaitForCompletion(ctx, op)
-	klog.V(4).Infof("{{.GCPWrapType}}.Delete(%v, %v) = %v", ctx, key, err)
-	return err
+	return g.s.WaitForCompletion(ctx, op)
 }
 {{end -}}
 
@@ -998,7 +1276,6 @@ func (g *{{.GCPWrapType}}) Delete(ctx context.Context, key *meta.Key, options...
 // AggregatedList lists all resources of the given type across all locations.
 func (g *{{.GCPWrapType}}) AggregatedList(ctx context.Context, fl *filter.F, options... Option) (map[string][]*{{.FQObjectType}}, error) {
     opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.AggregatedList(%v, %v) called", ctx, fl)
 
 	projectID := getProjectID(ctx, g.s.ProjectRouter, opts, "{{.Version}}", "{{.Service}}")
 	ck:= &CallContextKey{
@@ -1008,9 +1285,9 @@ func (g *{{.GCPWrapType}}) AggregatedList(ctx context.Context, fl *filter.F, opt
 		Service: "{{.Service}}",
 	}
 
-	klog.V(5).Infof("{{.GCPWrapType}}.AggregatedList(%v, %v): projectID = %v, ck = %+v", ctx, fl, projectID, ck)
-	callObserverStart(ctx, ck)
+	start := callStart(ctx, ck, nil)
 	if err := g.s.RateLimiter.Accept(ctx, ck); err != nil {
+		callEnd(ctx, ck, nil, start, err)
 		klog.V(5).Infof("{{.GCPWrapType}}.AggregatedList(%v, %v): RateLimiter error: %v", ctx, fl, err)
 		return nil, err
 	}
@@ -1030,24 +1307,12 @@ func (g *{{.GCPWrapType}}) AggregatedList(ctx context.Context, fl *filter.F, opt
 		return nil
 	}
 	if err := call.Pages(ctx, f); err != nil {
-		callObserverEnd(ctx, ck, err)
+		callEnd(ctx, ck, nil, start, err)
 		g.s.RateLimiter.Observe(ctx, err, ck)
-
-		klog.V(4).Infof("{{.GCPWrapType}}.AggregatedList(%v, %v) = %v, %v", ctx, fl, nil, err)
 		return nil, err
 	}
-	callObserverEnd(ctx, ck, nil)
+	callEnd(ctx, ck, nil, start, nil)
 	g.s.RateLimiter.Observe(ctx, nil, ck)
-
-	if kLogEnabled(4) {
-		klog.V(4).Infof("{{.GCPWrapType}}.AggregatedList(%v, %v) = [%v items], %v", ctx, fl, len(all), nil)
-	} else if kLogEnabled(5) {
-		var asStr []string
-		for _, o := range all {
-			asStr = append(asStr, fmt.Sprintf("%+v", o))
-		}
-		klog.V(5).Infof("{{.GCPWrapType}}.AggregatedList(%v, %v) = %v, %v", ctx, fl, asStr, nil)
-	}
 	return all, nil
 }
 {{- end}}
@@ -1056,7 +1321,6 @@ func (g *{{.GCPWrapType}}) AggregatedList(ctx context.Context, fl *filter.F, opt
 // List all Usable {{.Object}} objects.
 func (g *{{.GCPWrapType}}) ListUsable(ctx context.Context, fl *filter.F, options... Option) ([]*{{.FQListUsableObjectType}}, error) {
     opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.ListUsable(%v, %v, %v) called", ctx, fl, opts)
 	projectID := getProjectID(ctx, g.s.ProjectRouter, opts, "{{.Version}}", "{{.Service}}")
 	ck:= &CallContextKey{
 		ProjectID: projectID,
@@ -1064,12 +1328,12 @@ func (g *{{.GCPWrapType}}) ListUsable(ctx context.Context, fl *filter.F, options
 		Version: meta.Version("{{.Version}}"),
 		Service: "{{.Service}}",
 	}
-        callObserverStart(ctx, ck)
+        start := callStart(ctx, ck, nil)
 	if err := g.s.RateLimiter.Accept(ctx, ck); err != nil {
+		callEnd(ctx, ck, nil, start, err)
 		return nil, err
 	}
 
-	klog.V(5).Infof("{{.GCPWrapType}}.ListUsable(%v, %v): projectID = %v, ck = %+v", ctx, fl, projectID, ck)
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.ListUsable(projectID)
 	if fl != filter.None {
 		call.Filter(fl.String())
@@ -1081,25 +1345,13 @@ func (g *{{.GCPWrapType}}) ListUsable(ctx context.Context, fl *filter.F, options
 		return nil
 	}
 	if err := call.Pages(ctx, f); err != nil {
-		callObserverEnd(ctx, ck, err)
+		callEnd(ctx, ck, nil, start, err)
 		g.s.RateLimiter.Observe(ctx, err, ck)
-
-		klog.V(4).Infof("{{.GCPWrapType}}.ListUsable(%v, ..., %v) = %v, %v", ctx, fl, nil, err)
 		return nil, err
 	}
-	callObserverEnd(ctx, ck, nil)
+	callEnd(ctx, ck, nil, start, nil)
 	g.s.RateLimiter.Observe(ctx, nil, ck)
 
-	if kLogEnabled(4) {
-		klog.V(4).Infof("{{.GCPWrapType}}.ListUsable(%v, ..., %v) = [%v items], %v", ctx, fl, len(all), nil)
-	} else if kLogEnabled(5) {
-		var asStr []string
-		for _, o := range all {
-			asStr = append(asStr, fmt.Sprintf("%+v", o))
-		}
-		klog.V(5).Infof("{{.GCPWrapType}}.ListUsable(%v, ..., %v) = %v, %v", ctx, fl, asStr, nil)
-	}
-
 	return all, nil
 }
 {{- end}}
@@ -1109,7 +1361,6 @@ func (g *{{.GCPWrapType}}) ListUsable(ctx context.Context, fl *filter.F, options
 // {{.Name}} is a method on {{.GCPWrapType}}.
 func (g *{{.GCPWrapType}}) {{.FcnArgs}} {
     opts := mergeOptions(options)
-	klog.V(5).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, %v, ...): called", ctx, key, opts)
 
 	if !key.Valid() {
 		klog.V(2).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, %v, ...): key is invalid (%#v)", ctx, key, opts, key)
@@ -1128,9 +1379,9 @@ func (g *{{.GCPWrapType}}) {{.FcnArgs}} {
 		Version: meta.Version("{{.Version}}"),
 		Service: "{{.Service}}",
 	}
-	klog.V(5).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, ...): projectID = %v, ck = %+v", ctx, key, projectID, ck)
-	callObserverStart(ctx, ck)
+	start := callStart(ctx, ck, key)
 	if err := g.s.RateLimiter.Accept(ctx, ck); err != nil {
+		callEnd(ctx, ck, key, start, err)
 		klog.V(4).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, ...): RateLimiter error: %v", ctx, key, err)
 	{{- if .IsOperation}}
 		return err
@@ -1140,7 +1391,7 @@ func (g *{{.GCPWrapType}}) {{.FcnArgs}} {
 	}
 
 {{- if .IsNetworkServices}}
-    name := fmt.Sprintf("{{.NetworkServicesFmt}}", projectID, key.Name)
+    name := fmt.Sprintf("{{.NetworkServicesFmt}}", projectID, key.Location(), key.Name)
 	call := g.s.{{.GroupVersionTitle}}.{{.Service}}.{{.Name}}(name {{.CallArgs}})
 {{- else}}
 	{{- if .KeyIsGlobal}}
@@ -1155,30 +1406,26 @@ func (g *{{.GCPWrapType}}) {{.FcnArgs}} {
 {{- end}}
 {{- if .IsOperation}}
 	call.Context(ctx)
-	op, err := call.Do()
+	op, err := call.Do(opts.callOptions...)
 
 	if err != nil {
-		callObserverEnd(ctx, ck, err)
+		callEnd(ctx, ck, key, start, err)
 		g.s.RateLimiter.Observe(ctx, err, ck)
-
-		klog.V(4).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, ...) = %+v", ctx, key, err)
 		return err
 	}
 
 	err = g.s.WaitForCompletion(ctx, op)
-        callObserverEnd(ctx, ck, err)
+        callEnd(ctx, ck, key, start, err)
 	g.s.RateLimiter.Observe(ctx, err, ck) // XXX
 
-	klog.V(4).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, ...) = %+v", ctx, key, err)
 	return err
 {{- else if .IsGet}}
 	call.Context(ctx)
 	v, err := call.Do()
 
-        callObserverEnd(ctx, ck, err)
+        callEnd(ctx, ck, key, start, err)
 	g.s.RateLimiter.Observe(ctx, err, ck)
 
-	klog.V(4).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, ...) = %+v, %v", ctx, key, v, err)
 	return v, err
 {{- else if .IsPaged}}
 	var all []*{{.APIGroup}}{{.Version}}.{{.ItemType}}
@@ -1188,25 +1435,13 @@ func (g *{{.GCPWrapType}}) {{.FcnArgs}} {
 		return nil
 	}
 	if err := call.Pages(ctx, f); err != nil {
-		callObserverEnd(ctx, ck, err)
+		callEnd(ctx, ck, key, start, err)
 		g.s.RateLimiter.Observe(ctx, err, ck)
-
-		klog.V(4).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, ...) = %v, %v", ctx, key, nil, err)
 		return nil, err
 	}
 
-        callObserverEnd(ctx, ck, nil)
+        callEnd(ctx, ck, key, start, nil)
 	g.s.RateLimiter.Observe(ctx, nil, ck)
-
-	if kLogEnabled(4) {
-		klog.V(4).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, ...) = [%v items], %v", ctx, key, len(all), nil)
-	} else if kLogEnabled(5) {
-		var asStr []string
-		for _, o := range all {
-			asStr = append(asStr, fmt.Sprintf("%+v", o))
-		}
-		klog.V(5).Infof("{{.GCPWrapType}}.{{.Name}}(%v, %v, ...) = %v, %v", ctx, key, asStr, nil)
-	}
 	return all, nil
 {{- end}}
 }
@@ -1255,6 +1490,398 @@ func New{{.Service}}ResourceID(project, zone, name string) *ResourceID {
 	}
 }
 
+func genTypedServices(wr io.Writer) {
+	const text = `
+// Typed{{.WrapType}} returns {{.WrapType}} as a TypedService.
+func Typed{{.WrapType}}(c Cloud) *TypedService[{{.FQObjectType}}, {{if .KeyIsRegional}}meta.RegionalScope{{else if .KeyIsZonal}}meta.ZonalScope{{else}}meta.GlobalScope{{end}}] {
+	s := c.{{.WrapType}}()
+	return &TypedService[{{.FQObjectType}}, {{if .KeyIsRegional}}meta.RegionalScope{{else if .KeyIsZonal}}meta.ZonalScope{{else}}meta.GlobalScope{{end}}]{
+		name: "{{.WrapType}}",
+{{- if .GenerateGet}}
+		get: s.Get,
+{{- end}}
+{{- if .GenerateInsert}}
+		insert: s.Insert,
+{{- end}}
+{{- if .GenerateDelete}}
+		delete: s.Delete,
+{{- end}}
+	}
+}
+`
+	tmpl := template.Must(template.New("typedServices").Parse(text))
+	for _, s := range meta.AllServices {
+		if !s.GenerateGet() {
+			continue
+		}
+		if err := tmpl.Execute(wr, s); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// fingerprintSpec describes the mock hooks generated by "-mode fingerprint"
+// for a service that tracks fingerprints.
+type fingerprintSpec struct {
+	// Service is the name of the service, e.g. "UrlMaps".
+	Service string
+	// Name is used in the names of the hooks, e.g. "URLMap".
+	Name string
+	// Field, if set, is the field of the object that carries the
+	// fingerprint, e.g. "Metadata". Otherwise the object itself carries it.
+	Field string
+	// Methods that are hooked in addition to Insert: "Update" and "Patch"
+	// check and update the fingerprint of the object, "SetTarget" and
+	// "SetLabels" update it without a check (SetLabels checks the
+	// LabelFingerprint instead) and "Set<Field>" replaces Field.
+	Methods []string
+}
+
+// fingerprintSpecs are the services with fingerprint hooks.
+var fingerprintSpecs = []*fingerprintSpec{
+	{Service: "UrlMaps", Name: "URLMap", Methods: []string{"Update"}},
+	{Service: "RegionUrlMaps", Name: "RegionURLMap", Methods: []string{"Update"}},
+	{Service: "Subnetworks", Name: "Subnetwork", Methods: []string{"Patch"}},
+	{Service: "Instances", Name: "Instance", Field: "Metadata", Methods: []string{"SetMetadata"}},
+	{Service: "ForwardingRules", Name: "ForwardingRule", Methods: []string{"Patch", "SetTarget", "SetLabels"}},
+}
+
+// fingerprintHook is the data for the hooks of a version of a service.
+type fingerprintHook struct {
+	*meta.ServiceInfo
+	Spec *fingerprintSpec
+}
+
+// HookName returns the name of the hook for method.
+func (h *fingerprintHook) HookName(method string) string {
+	prefix := ""
+	if h.Version() != meta.VersionGA {
+		prefix = h.VersionTitle()
+	}
+	if field := strings.TrimPrefix(method, "Set"); field != method {
+		return "Set" + prefix + h.Spec.Name + field + "WithFingerprintHook"
+	}
+	return method + prefix + h.Spec.Name + "WithFingerprintHook"
+}
+
+// HasMethod returns true if method is hooked.
+func (h *fingerprintHook) HasMethod(method string) bool {
+	for _, m := range h.Spec.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Type is the object type in the mock package.
+func (h *fingerprintHook) Type() string {
+	return fmt.Sprintf("%v.%v", h.Version(), h.Object)
+}
+
+// fingerprintHooks returns the hooks to generate, ordered by spec and version.
+func fingerprintHooks() []*fingerprintHook {
+	var ret []*fingerprintHook
+	for _, spec := range fingerprintSpecs {
+		for _, version := range meta.AllVersions {
+			for _, s := range meta.AllServices {
+				if s.Service == spec.Service && s.Version() == version && s.APIGroup == meta.APIGroupCompute {
+					ret = append(ret, &fingerprintHook{ServiceInfo: s, Spec: spec})
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func genFingerprintHeader(wr io.Writer) {
+	const text = `/*
+Copyright {{.Year}} Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run gen/main.go -mode fingerprint > mock/fingerprint_gen.go".
+// Do not edit directly.
+
+package mock
+
+import (
+	"context"
+
+	cloud "{{.PackageRoot}}"
+	"{{.MetaPackage}}"
+	alpha "{{.AlphaComputePackage}}"
+	beta "{{.BetaComputePackage}}"
+	ga "{{.GaComputePackage}}"
+)
+`
+	tmpl := template.Must(template.New("fingerprint-header").Parse(text))
+	values := map[string]string{
+		"Year":                fmt.Sprintf("%v", time.Now().Year()),
+		"PackageRoot":         packageRoot,
+		"MetaPackage":         metaPackage,
+		"AlphaComputePackage": alphaComputePackage,
+		"BetaComputePackage":  betaComputePackage,
+		"GaComputePackage":    gaComputePackage,
+	}
+	if err := tmpl.Execute(wr, values); err != nil {
+		panic(err)
+	}
+}
+
+// genFingerprintHooks generates the mock hooks that track fingerprints. The
+// hooks never modify the objects passed by the caller.
+func genFingerprintHooks(wr io.Writer) {
+	const enableText = `
+// EnableFingerprints installs the hooks in mockGCE that track fingerprints.
+// Updates to these resources must supply the current fingerprint of the
+// resource and fail with http.StatusPreconditionFailed otherwise, as they do
+// in GCE.
+func EnableFingerprints(mockGCE *cloud.MockGCE) {
+{{- range .}}
+	mockGCE.{{.MockField}}.InsertHook = {{.HookName "Insert"}}
+{{- $h := .}}
+{{- range .Spec.Methods}}
+	mockGCE.{{$h.MockField}}.{{.}}Hook = {{$h.HookName .}}
+{{- end}}
+{{- end}}
+}
+`
+	const text = `
+// {{.HookName "Insert"}} inserts a copy of obj with the initial
+// fingerprint{{if .Spec.Field}} of the {{.Spec.Field}}{{end}} into the {{.MockWrapType}}.
+func {{.HookName "Insert"}}(ctx context.Context, key *meta.Key, obj *{{.Type}}, m *cloud.{{.MockWrapType}}, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &{{.Type}}{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+{{- if .Spec.Field}}
+	if cp.{{.Spec.Field}} == nil {
+		cp.{{.Spec.Field}} = &{{.Version}}.{{.Spec.Field}}{}
+	}
+	fp, err := fingerprint(cp.{{.Spec.Field}})
+	if err != nil {
+		return true, err
+	}
+	cp.{{.Spec.Field}}.Fingerprint = fp
+{{- else}}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+{{- end}}
+{{- if .HasMethod "SetLabels"}}
+	if cp.LabelFingerprint, err = fingerprint(cp.Labels); err != nil {
+		return true, err
+	}
+{{- end}}
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify {{.HookName "Insert"}} implements {{.MockWrapType}}.InsertHook.
+var _ = cloud.{{.MockWrapType}}{
+	InsertHook: {{.HookName "Insert"}},
+}
+{{- $h := .}}
+{{- range .Spec.Methods}}
+{{- if eq . "Update"}}
+
+// {{$h.HookName .}} replaces the stored object with a copy of obj. The
+// update fails if obj does not have the current fingerprint of the object.
+func {{$h.HookName .}}(ctx context.Context, key *meta.Key, obj *{{$h.Type}}, m *cloud.{{$h.MockWrapType}}, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("{{$h.MockWrapType}}", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	stored := &{{$h.Type}}{}
+	if err := copyViaJSON(stored, obj); err != nil {
+		return err
+	}
+	stored.Name = key.Name
+	projectID := m.ProjectRouter.ProjectID(ctx, meta.Version{{$h.VersionTitle}}, "{{$h.Resource}}")
+	stored.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.Version{{$h.VersionTitle}}, projectID, "{{$h.Resource}}", key)
+	fp, err := fingerprint(stored)
+	if err != nil {
+		return err
+	}
+	stored.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.Mock{{$h.Service}}Obj{Obj: stored}
+	return nil
+}
+{{- else if eq . "Patch"}}
+
+// {{$h.HookName .}} applies the non-empty fields of obj to the stored
+// object. The patch fails if obj does not have the current fingerprint of the
+// object.
+func {{$h.HookName .}}(ctx context.Context, key *meta.Key, obj *{{$h.Type}}, m *cloud.{{$h.MockWrapType}}, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("{{$h.MockWrapType}}", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	patched := &{{$h.Type}}{}
+	if err := patchViaJSON(patched, cur.To{{$h.VersionTitle}}(), obj); err != nil {
+		return err
+	}
+	fp, err := fingerprint(patched)
+	if err != nil {
+		return err
+	}
+	patched.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.Mock{{$h.Service}}Obj{Obj: patched}
+	return nil
+}
+{{- else if eq . "SetTarget"}}
+
+// {{$h.HookName .}} sets the target of the stored object, which changes its
+// fingerprint.
+func {{$h.HookName .}}(ctx context.Context, key *meta.Key, arg *{{$h.Version}}.TargetReference, m *cloud.{{$h.MockWrapType}}, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("{{$h.MockWrapType}}", key)
+	}
+	obj := &{{$h.Type}}{}
+	if err := copyViaJSON(obj, cur.To{{$h.VersionTitle}}()); err != nil {
+		return err
+	}
+	obj.Target = arg.Target
+	fp, err := fingerprint(obj)
+	if err != nil {
+		return err
+	}
+	obj.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.Mock{{$h.Service}}Obj{Obj: obj}
+	return nil
+}
+{{- else if eq . "SetLabels"}}
+
+// {{$h.HookName .}} sets the labels of the stored object, which changes its
+// fingerprint. The update fails if arg does not have the current
+// LabelFingerprint of the object.
+func {{$h.HookName .}}(ctx context.Context, key *meta.Key, arg *{{$h.Version}}.{{if $h.KeyIsGlobal}}Global{{else}}Region{{end}}SetLabelsRequest, m *cloud.{{$h.MockWrapType}}, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("{{$h.MockWrapType}}", key)
+	}
+	obj := &{{$h.Type}}{}
+	if err := copyViaJSON(obj, cur.To{{$h.VersionTitle}}()); err != nil {
+		return err
+	}
+	if err := checkFingerprint(obj.LabelFingerprint, arg.LabelFingerprint); err != nil {
+		return err
+	}
+	obj.Labels = map[string]string{}
+	for k, v := range arg.Labels {
+		obj.Labels[k] = v
+	}
+	var err error
+	if obj.LabelFingerprint, err = fingerprint(obj.Labels); err != nil {
+		return err
+	}
+	if obj.Fingerprint, err = fingerprint(obj); err != nil {
+		return err
+	}
+
+	m.Objects[*key] = &cloud.Mock{{$h.Service}}Obj{Obj: obj}
+	return nil
+}
+{{- else}}
+
+// {{$h.HookName .}} replaces the {{$h.Spec.Field}} of the stored object with a
+// copy of arg. The update fails if arg does not have the current fingerprint
+// of the {{$h.Spec.Field}}.
+func {{$h.HookName .}}(ctx context.Context, key *meta.Key, arg *{{$h.Version}}.{{$h.Spec.Field}}, m *cloud.{{$h.MockWrapType}}, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("{{$h.MockWrapType}}", key)
+	}
+	obj := &{{$h.Type}}{}
+	if err := copyViaJSON(obj, cur.To{{$h.VersionTitle}}()); err != nil {
+		return err
+	}
+	var curFingerprint string
+	if obj.{{$h.Spec.Field}} != nil {
+		curFingerprint = obj.{{$h.Spec.Field}}.Fingerprint
+	}
+	if err := checkFingerprint(curFingerprint, arg.Fingerprint); err != nil {
+		return err
+	}
+
+	obj.{{$h.Spec.Field}} = &{{$h.Version}}.{{$h.Spec.Field}}{}
+	if err := copyViaJSON(obj.{{$h.Spec.Field}}, arg); err != nil {
+		return err
+	}
+	fp, err := fingerprint(obj.{{$h.Spec.Field}})
+	if err != nil {
+		return err
+	}
+	obj.{{$h.Spec.Field}}.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.Mock{{$h.Service}}Obj{Obj: obj}
+	return nil
+}
+{{- end}}
+
+// Verify {{$h.HookName .}} implements {{$h.MockWrapType}}.{{.}}Hook.
+var _ = cloud.{{$h.MockWrapType}}{
+	{{.}}Hook: {{$h.HookName .}},
+}
+{{- end}}
+`
+	hooks := fingerprintHooks()
+	if err := template.Must(template.New("fingerprint-enable").Parse(enableText)).Execute(wr, hooks); err != nil {
+		panic(err)
+	}
+	tmpl := template.Must(template.New("fingerprint").Parse(text))
+	for _, h := range hooks {
+		if err := tmpl.Execute(wr, h); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func genUnitTestHeader(wr io.Writer) {
 	const text = `/*
 Copyright {{.Year}} The Kubernetes Authors.
@@ -1604,10 +2231,14 @@ func main() {
 		genStubs(out)
 		genTypes(out)
 		genResourceIDs(out)
+		genTypedServices(out)
 	case "test":
 		genUnitTestHeader(out)
 		genUnitTestServices(out)
 		genUnitTestResourceIDConversion(out)
+	case "fingerprint":
+		genFingerprintHeader(out)
+		genFingerprintHooks(out)
 	default:
 		log.Fatalf("Invalid -mode: %q", flags.mode)
 	}
@@ -640,6 +640,105 @@ func TestForwardingRulesGroup(t *testing.T) {
 	}
 }
 
+func TestGatewaysGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pr := &SingleProjectRouter{"mock-project"}
+	mock := NewMockGCE(pr)
+
+	var key *meta.Key
+	keyBeta := meta.GlobalKey("key-beta")
+	key = keyBeta
+	keyGA := meta.GlobalKey("key-ga")
+	key = keyGA
+	// Ignore unused variables.
+	_, _, _ = ctx, mock, key
+
+	// Get not found.
+	if _, err := mock.BetaGateways().Get(ctx, key); err == nil {
+		t.Errorf("BetaGateways().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.Gateways().Get(ctx, key); err == nil {
+		t.Errorf("Gateways().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+
+	// Insert.
+	{
+		obj := &networkservicesbeta.Gateway{}
+		if err := mock.BetaGateways().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaGateways().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
+	{
+		obj := &networkservicesga.Gateway{}
+		if err := mock.Gateways().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("Gateways().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
+
+	// Get across versions.
+	if obj, err := mock.BetaGateways().Get(ctx, key); err != nil {
+		t.Errorf("BetaGateways().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.Gateways().Get(ctx, key); err != nil {
+		t.Errorf("Gateways().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+
+	// List.
+	mock.MockBetaGateways.Objects[*keyBeta] = mock.MockBetaGateways.Obj(&networkservicesbeta.Gateway{Name: keyBeta.Name})
+	mock.MockGateways.Objects[*keyGA] = mock.MockGateways.Obj(&networkservicesga.Gateway{Name: keyGA.Name})
+	want := map[string]bool{
+		"key-beta": true,
+		"key-ga":   true,
+	}
+	_ = want // ignore unused variables.
+	{
+		objs, err := mock.BetaGateways().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BetaGateways().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaGateways().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.Gateways().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("Gateways().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Gateways().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+
+	// Delete across versions.
+	if err := mock.BetaGateways().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaGateways().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.Gateways().Delete(ctx, keyGA); err != nil {
+		t.Errorf("Gateways().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+
+	// Delete not found.
+	if err := mock.BetaGateways().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaGateways().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.Gateways().Delete(ctx, keyGA); err == nil {
+		t.Errorf("Gateways().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
 func TestGlobalAddressesGroup(t *testing.T) {
 	t.Parallel()
 
@@ -1045,6 +1144,105 @@ func TestGlobalNetworkEndpointGroupsGroup(t *testing.T) {
 	}
 }
 
+func TestGrpcRoutesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pr := &SingleProjectRouter{"mock-project"}
+	mock := NewMockGCE(pr)
+
+	var key *meta.Key
+	keyBeta := meta.GlobalKey("key-beta")
+	key = keyBeta
+	keyGA := meta.GlobalKey("key-ga")
+	key = keyGA
+	// Ignore unused variables.
+	_, _, _ = ctx, mock, key
+
+	// Get not found.
+	if _, err := mock.BetaGrpcRoutes().Get(ctx, key); err == nil {
+		t.Errorf("BetaGrpcRoutes().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.GrpcRoutes().Get(ctx, key); err == nil {
+		t.Errorf("GrpcRoutes().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+
+	// Insert.
+	{
+		obj := &networkservicesbeta.GrpcRoute{}
+		if err := mock.BetaGrpcRoutes().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaGrpcRoutes().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
+	{
+		obj := &networkservicesga.GrpcRoute{}
+		if err := mock.GrpcRoutes().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("GrpcRoutes().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
+
+	// Get across versions.
+	if obj, err := mock.BetaGrpcRoutes().Get(ctx, key); err != nil {
+		t.Errorf("BetaGrpcRoutes().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.GrpcRoutes().Get(ctx, key); err != nil {
+		t.Errorf("GrpcRoutes().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+
+	// List.
+	mock.MockBetaGrpcRoutes.Objects[*keyBeta] = mock.MockBetaGrpcRoutes.Obj(&networkservicesbeta.GrpcRoute{Name: keyBeta.Name})
+	mock.MockGrpcRoutes.Objects[*keyGA] = mock.MockGrpcRoutes.Obj(&networkservicesga.GrpcRoute{Name: keyGA.Name})
+	want := map[string]bool{
+		"key-beta": true,
+		"key-ga":   true,
+	}
+	_ = want // ignore unused variables.
+	{
+		objs, err := mock.BetaGrpcRoutes().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BetaGrpcRoutes().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaGrpcRoutes().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.GrpcRoutes().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("GrpcRoutes().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GrpcRoutes().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+
+	// Delete across versions.
+	if err := mock.BetaGrpcRoutes().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaGrpcRoutes().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.GrpcRoutes().Delete(ctx, keyGA); err != nil {
+		t.Errorf("GrpcRoutes().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+
+	// Delete not found.
+	if err := mock.BetaGrpcRoutes().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaGrpcRoutes().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.GrpcRoutes().Delete(ctx, keyGA); err == nil {
+		t.Errorf("GrpcRoutes().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
 func TestHealthChecksGroup(t *testing.T) {
 	t.Parallel()
 
@@ -1243,6 +1441,105 @@ func TestHttpHealthChecksGroup(t *testing.T) {
 	}
 }
 
+func TestHttpRoutesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pr := &SingleProjectRouter{"mock-project"}
+	mock := NewMockGCE(pr)
+
+	var key *meta.Key
+	keyBeta := meta.GlobalKey("key-beta")
+	key = keyBeta
+	keyGA := meta.GlobalKey("key-ga")
+	key = keyGA
+	// Ignore unused variables.
+	_, _, _ = ctx, mock, key
+
+	// Get not found.
+	if _, err := mock.BetaHttpRoutes().Get(ctx, key); err == nil {
+		t.Errorf("BetaHttpRoutes().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.HttpRoutes().Get(ctx, key); err == nil {
+		t.Errorf("HttpRoutes().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+
+	// Insert.
+	{
+		obj := &networkservicesbeta.HttpRoute{}
+		if err := mock.BetaHttpRoutes().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaHttpRoutes().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
+	{
+		obj := &networkservicesga.HttpRoute{}
+		if err := mock.HttpRoutes().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("HttpRoutes().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
+
+	// Get across versions.
+	if obj, err := mock.BetaHttpRoutes().Get(ctx, key); err != nil {
+		t.Errorf("BetaHttpRoutes().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.HttpRoutes().Get(ctx, key); err != nil {
+		t.Errorf("HttpRoutes().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+
+	// List.
+	mock.MockBetaHttpRoutes.Objects[*keyBeta] = mock.MockBetaHttpRoutes.Obj(&networkservicesbeta.HttpRoute{Name: keyBeta.Name})
+	mock.MockHttpRoutes.Objects[*keyGA] = mock.MockHttpRoutes.Obj(&networkservicesga.HttpRoute{Name: keyGA.Name})
+	want := map[string]bool{
+		"key-beta": true,
+		"key-ga":   true,
+	}
+	_ = want // ignore unused variables.
+	{
+		objs, err := mock.BetaHttpRoutes().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BetaHttpRoutes().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaHttpRoutes().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.HttpRoutes().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("HttpRoutes().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("HttpRoutes().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+
+	// Delete across versions.
+	if err := mock.BetaHttpRoutes().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaHttpRoutes().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.HttpRoutes().Delete(ctx, keyGA); err != nil {
+		t.Errorf("HttpRoutes().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+
+	// Delete not found.
+	if err := mock.BetaHttpRoutes().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaHttpRoutes().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.HttpRoutes().Delete(ctx, keyGA); err == nil {
+		t.Errorf("HttpRoutes().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
 func TestHttpsHealthChecksGroup(t *testing.T) {
 	t.Parallel()
 
@@ -1512,17 +1809,39 @@ func TestInstanceGroupsGroup(t *testing.T) {
 	mock := NewMockGCE(pr)
 
 	var key *meta.Key
+	keyAlpha := meta.ZonalKey("key-alpha", "location")
+	key = keyAlpha
+	keyBeta := meta.ZonalKey("key-beta", "location")
+	key = keyBeta
 	keyGA := meta.ZonalKey("key-ga", "location")
 	key = keyGA
 	// Ignore unused variables.
 	_, _, _ = ctx, mock, key
 
 	// Get not found.
+	if _, err := mock.AlphaInstanceGroups().Get(ctx, key); err == nil {
+		t.Errorf("AlphaInstanceGroups().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.BetaInstanceGroups().Get(ctx, key); err == nil {
+		t.Errorf("BetaInstanceGroups().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
 	if _, err := mock.InstanceGroups().Get(ctx, key); err == nil {
 		t.Errorf("InstanceGroups().Get(%v, %v) = _, nil; want error", ctx, key)
 	}
 
 	// Insert.
+	{
+		obj := &computealpha.InstanceGroup{}
+		if err := mock.AlphaInstanceGroups().Insert(ctx, keyAlpha, obj); err != nil {
+			t.Errorf("AlphaInstanceGroups().Insert(%v, %v, %v) = %v; want nil", ctx, keyAlpha, obj, err)
+		}
+	}
+	{
+		obj := &computebeta.InstanceGroup{}
+		if err := mock.BetaInstanceGroups().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaInstanceGroups().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
 	{
 		obj := &computega.InstanceGroup{}
 		if err := mock.InstanceGroups().Insert(ctx, keyGA, obj); err != nil {
@@ -1531,16 +1850,54 @@ func TestInstanceGroupsGroup(t *testing.T) {
 	}
 
 	// Get across versions.
+	if obj, err := mock.AlphaInstanceGroups().Get(ctx, key); err != nil {
+		t.Errorf("AlphaInstanceGroups().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.BetaInstanceGroups().Get(ctx, key); err != nil {
+		t.Errorf("BetaInstanceGroups().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
 	if obj, err := mock.InstanceGroups().Get(ctx, key); err != nil {
 		t.Errorf("InstanceGroups().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
 	}
 
 	// List.
+	mock.MockAlphaInstanceGroups.Objects[*keyAlpha] = mock.MockAlphaInstanceGroups.Obj(&computealpha.InstanceGroup{Name: keyAlpha.Name})
+	mock.MockBetaInstanceGroups.Objects[*keyBeta] = mock.MockBetaInstanceGroups.Obj(&computebeta.InstanceGroup{Name: keyBeta.Name})
 	mock.MockInstanceGroups.Objects[*keyGA] = mock.MockInstanceGroups.Obj(&computega.InstanceGroup{Name: keyGA.Name})
 	want := map[string]bool{
-		"key-ga": true,
+		"key-alpha": true,
+		"key-beta":  true,
+		"key-ga":    true,
 	}
 	_ = want // ignore unused variables.
+	{
+		objs, err := mock.AlphaInstanceGroups().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaInstanceGroups().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaInstanceGroups().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BetaInstanceGroups().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("BetaInstanceGroups().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaInstanceGroups().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
 	{
 		objs, err := mock.InstanceGroups().List(ctx, location, filter.None)
 		if err != nil {
@@ -1557,11 +1914,23 @@ func TestInstanceGroupsGroup(t *testing.T) {
 	}
 
 	// Delete across versions.
+	if err := mock.AlphaInstanceGroups().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaInstanceGroups().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.BetaInstanceGroups().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaInstanceGroups().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
 	if err := mock.InstanceGroups().Delete(ctx, keyGA); err != nil {
 		t.Errorf("InstanceGroups().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
 	}
 
 	// Delete not found.
+	if err := mock.AlphaInstanceGroups().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaInstanceGroups().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaInstanceGroups().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaInstanceGroups().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
 	if err := mock.InstanceGroups().Delete(ctx, keyGA); err == nil {
 		t.Errorf("InstanceGroups().Delete(%v, %v) = nil; want error", ctx, keyGA)
 	}
@@ -2009,6 +2378,10 @@ func TestNetworkFirewallPoliciesGroup(t *testing.T) {
 	var key *meta.Key
 	keyAlpha := meta.GlobalKey("key-alpha")
 	key = keyAlpha
+	keyBeta := meta.GlobalKey("key-beta")
+	key = keyBeta
+	keyGA := meta.GlobalKey("key-ga")
+	key = keyGA
 	// Ignore unused variables.
 	_, _, _ = ctx, mock, key
 
@@ -2016,6 +2389,12 @@ func TestNetworkFirewallPoliciesGroup(t *testing.T) {
 	if _, err := mock.AlphaNetworkFirewallPolicies().Get(ctx, key); err == nil {
 		t.Errorf("AlphaNetworkFirewallPolicies().Get(%v, %v) = _, nil; want error", ctx, key)
 	}
+	if _, err := mock.BetaNetworkFirewallPolicies().Get(ctx, key); err == nil {
+		t.Errorf("BetaNetworkFirewallPolicies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.NetworkFirewallPolicies().Get(ctx, key); err == nil {
+		t.Errorf("NetworkFirewallPolicies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
 
 	// Insert.
 	{
@@ -2024,16 +2403,38 @@ func TestNetworkFirewallPoliciesGroup(t *testing.T) {
 			t.Errorf("AlphaNetworkFirewallPolicies().Insert(%v, %v, %v) = %v; want nil", ctx, keyAlpha, obj, err)
 		}
 	}
+	{
+		obj := &computebeta.FirewallPolicy{}
+		if err := mock.BetaNetworkFirewallPolicies().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaNetworkFirewallPolicies().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
+	{
+		obj := &computega.FirewallPolicy{}
+		if err := mock.NetworkFirewallPolicies().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("NetworkFirewallPolicies().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
 
 	// Get across versions.
 	if obj, err := mock.AlphaNetworkFirewallPolicies().Get(ctx, key); err != nil {
 		t.Errorf("AlphaNetworkFirewallPolicies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
 	}
+	if obj, err := mock.BetaNetworkFirewallPolicies().Get(ctx, key); err != nil {
+		t.Errorf("BetaNetworkFirewallPolicies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.NetworkFirewallPolicies().Get(ctx, key); err != nil {
+		t.Errorf("NetworkFirewallPolicies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
 
 	// List.
 	mock.MockAlphaNetworkFirewallPolicies.Objects[*keyAlpha] = mock.MockAlphaNetworkFirewallPolicies.Obj(&computealpha.FirewallPolicy{Name: keyAlpha.Name})
+	mock.MockBetaNetworkFirewallPolicies.Objects[*keyBeta] = mock.MockBetaNetworkFirewallPolicies.Obj(&computebeta.FirewallPolicy{Name: keyBeta.Name})
+	mock.MockNetworkFirewallPolicies.Objects[*keyGA] = mock.MockNetworkFirewallPolicies.Obj(&computega.FirewallPolicy{Name: keyGA.Name})
 	want := map[string]bool{
 		"key-alpha": true,
+		"key-beta":  true,
+		"key-ga":    true,
 	}
 	_ = want // ignore unused variables.
 	{
@@ -2050,16 +2451,56 @@ func TestNetworkFirewallPoliciesGroup(t *testing.T) {
 			}
 		}
 	}
+	{
+		objs, err := mock.BetaNetworkFirewallPolicies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BetaNetworkFirewallPolicies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaNetworkFirewallPolicies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.NetworkFirewallPolicies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("NetworkFirewallPolicies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("NetworkFirewallPolicies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
 
 	// Delete across versions.
 	if err := mock.AlphaNetworkFirewallPolicies().Delete(ctx, keyAlpha); err != nil {
 		t.Errorf("AlphaNetworkFirewallPolicies().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
 	}
+	if err := mock.BetaNetworkFirewallPolicies().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaNetworkFirewallPolicies().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.NetworkFirewallPolicies().Delete(ctx, keyGA); err != nil {
+		t.Errorf("NetworkFirewallPolicies().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
 
 	// Delete not found.
 	if err := mock.AlphaNetworkFirewallPolicies().Delete(ctx, keyAlpha); err == nil {
 		t.Errorf("AlphaNetworkFirewallPolicies().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
 	}
+	if err := mock.BetaNetworkFirewallPolicies().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaNetworkFirewallPolicies().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.NetworkFirewallPolicies().Delete(ctx, keyGA); err == nil {
+		t.Errorf("NetworkFirewallPolicies().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
 }
 
 func TestNetworksGroup(t *testing.T) {
@@ -2475,20 +2916,155 @@ func TestRegionHealthChecksGroup(t *testing.T) {
 	}
 
 	// Get across versions.
-	if obj, err := mock.AlphaRegionHealthChecks().Get(ctx, key); err != nil {
-		t.Errorf("AlphaRegionHealthChecks().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	if obj, err := mock.AlphaRegionHealthChecks().Get(ctx, key); err != nil {
+		t.Errorf("AlphaRegionHealthChecks().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.BetaRegionHealthChecks().Get(ctx, key); err != nil {
+		t.Errorf("BetaRegionHealthChecks().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.RegionHealthChecks().Get(ctx, key); err != nil {
+		t.Errorf("RegionHealthChecks().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+
+	// List.
+	mock.MockAlphaRegionHealthChecks.Objects[*keyAlpha] = mock.MockAlphaRegionHealthChecks.Obj(&computealpha.HealthCheck{Name: keyAlpha.Name})
+	mock.MockBetaRegionHealthChecks.Objects[*keyBeta] = mock.MockBetaRegionHealthChecks.Obj(&computebeta.HealthCheck{Name: keyBeta.Name})
+	mock.MockRegionHealthChecks.Objects[*keyGA] = mock.MockRegionHealthChecks.Obj(&computega.HealthCheck{Name: keyGA.Name})
+	want := map[string]bool{
+		"key-alpha": true,
+		"key-beta":  true,
+		"key-ga":    true,
+	}
+	_ = want // ignore unused variables.
+	{
+		objs, err := mock.AlphaRegionHealthChecks().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaRegionHealthChecks().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaRegionHealthChecks().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BetaRegionHealthChecks().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("BetaRegionHealthChecks().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaRegionHealthChecks().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.RegionHealthChecks().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("RegionHealthChecks().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("RegionHealthChecks().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+
+	// Delete across versions.
+	if err := mock.AlphaRegionHealthChecks().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaRegionHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.BetaRegionHealthChecks().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaRegionHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.RegionHealthChecks().Delete(ctx, keyGA); err != nil {
+		t.Errorf("RegionHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+
+	// Delete not found.
+	if err := mock.AlphaRegionHealthChecks().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaRegionHealthChecks().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaRegionHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.RegionHealthChecks().Delete(ctx, keyGA); err == nil {
+		t.Errorf("RegionHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
+func TestRegionNetworkEndpointGroupsGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pr := &SingleProjectRouter{"mock-project"}
+	mock := NewMockGCE(pr)
+
+	var key *meta.Key
+	keyAlpha := meta.RegionalKey("key-alpha", "location")
+	key = keyAlpha
+	keyBeta := meta.RegionalKey("key-beta", "location")
+	key = keyBeta
+	keyGA := meta.RegionalKey("key-ga", "location")
+	key = keyGA
+	// Ignore unused variables.
+	_, _, _ = ctx, mock, key
+
+	// Get not found.
+	if _, err := mock.AlphaRegionNetworkEndpointGroups().Get(ctx, key); err == nil {
+		t.Errorf("AlphaRegionNetworkEndpointGroups().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.BetaRegionNetworkEndpointGroups().Get(ctx, key); err == nil {
+		t.Errorf("BetaRegionNetworkEndpointGroups().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.RegionNetworkEndpointGroups().Get(ctx, key); err == nil {
+		t.Errorf("RegionNetworkEndpointGroups().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+
+	// Insert.
+	{
+		obj := &computealpha.NetworkEndpointGroup{}
+		if err := mock.AlphaRegionNetworkEndpointGroups().Insert(ctx, keyAlpha, obj); err != nil {
+			t.Errorf("AlphaRegionNetworkEndpointGroups().Insert(%v, %v, %v) = %v; want nil", ctx, keyAlpha, obj, err)
+		}
+	}
+	{
+		obj := &computebeta.NetworkEndpointGroup{}
+		if err := mock.BetaRegionNetworkEndpointGroups().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaRegionNetworkEndpointGroups().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
+	{
+		obj := &computega.NetworkEndpointGroup{}
+		if err := mock.RegionNetworkEndpointGroups().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("RegionNetworkEndpointGroups().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaRegionNetworkEndpointGroups().Get(ctx, key); err != nil {
+		t.Errorf("AlphaRegionNetworkEndpointGroups().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
 	}
-	if obj, err := mock.BetaRegionHealthChecks().Get(ctx, key); err != nil {
-		t.Errorf("BetaRegionHealthChecks().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	if obj, err := mock.BetaRegionNetworkEndpointGroups().Get(ctx, key); err != nil {
+		t.Errorf("BetaRegionNetworkEndpointGroups().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
 	}
-	if obj, err := mock.RegionHealthChecks().Get(ctx, key); err != nil {
-		t.Errorf("RegionHealthChecks().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	if obj, err := mock.RegionNetworkEndpointGroups().Get(ctx, key); err != nil {
+		t.Errorf("RegionNetworkEndpointGroups().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
 	}
 
 	// List.
-	mock.MockAlphaRegionHealthChecks.Objects[*keyAlpha] = mock.MockAlphaRegionHealthChecks.Obj(&computealpha.HealthCheck{Name: keyAlpha.Name})
-	mock.MockBetaRegionHealthChecks.Objects[*keyBeta] = mock.MockBetaRegionHealthChecks.Obj(&computebeta.HealthCheck{Name: keyBeta.Name})
-	mock.MockRegionHealthChecks.Objects[*keyGA] = mock.MockRegionHealthChecks.Obj(&computega.HealthCheck{Name: keyGA.Name})
+	mock.MockAlphaRegionNetworkEndpointGroups.Objects[*keyAlpha] = mock.MockAlphaRegionNetworkEndpointGroups.Obj(&computealpha.NetworkEndpointGroup{Name: keyAlpha.Name})
+	mock.MockBetaRegionNetworkEndpointGroups.Objects[*keyBeta] = mock.MockBetaRegionNetworkEndpointGroups.Obj(&computebeta.NetworkEndpointGroup{Name: keyBeta.Name})
+	mock.MockRegionNetworkEndpointGroups.Objects[*keyGA] = mock.MockRegionNetworkEndpointGroups.Obj(&computega.NetworkEndpointGroup{Name: keyGA.Name})
 	want := map[string]bool{
 		"key-alpha": true,
 		"key-beta":  true,
@@ -2496,68 +3072,68 @@ func TestRegionHealthChecksGroup(t *testing.T) {
 	}
 	_ = want // ignore unused variables.
 	{
-		objs, err := mock.AlphaRegionHealthChecks().List(ctx, location, filter.None)
+		objs, err := mock.AlphaRegionNetworkEndpointGroups().List(ctx, location, filter.None)
 		if err != nil {
-			t.Errorf("AlphaRegionHealthChecks().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+			t.Errorf("AlphaRegionNetworkEndpointGroups().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
 		} else {
 			got := map[string]bool{}
 			for _, obj := range objs {
 				got[obj.Name] = true
 			}
 			if !reflect.DeepEqual(got, want) {
-				t.Errorf("AlphaRegionHealthChecks().List(); got %+v, want %+v", got, want)
+				t.Errorf("AlphaRegionNetworkEndpointGroups().List(); got %+v, want %+v", got, want)
 			}
 		}
 	}
 	{
-		objs, err := mock.BetaRegionHealthChecks().List(ctx, location, filter.None)
+		objs, err := mock.BetaRegionNetworkEndpointGroups().List(ctx, location, filter.None)
 		if err != nil {
-			t.Errorf("BetaRegionHealthChecks().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+			t.Errorf("BetaRegionNetworkEndpointGroups().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
 		} else {
 			got := map[string]bool{}
 			for _, obj := range objs {
 				got[obj.Name] = true
 			}
 			if !reflect.DeepEqual(got, want) {
-				t.Errorf("BetaRegionHealthChecks().List(); got %+v, want %+v", got, want)
+				t.Errorf("BetaRegionNetworkEndpointGroups().List(); got %+v, want %+v", got, want)
 			}
 		}
 	}
 	{
-		objs, err := mock.RegionHealthChecks().List(ctx, location, filter.None)
+		objs, err := mock.RegionNetworkEndpointGroups().List(ctx, location, filter.None)
 		if err != nil {
-			t.Errorf("RegionHealthChecks().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+			t.Errorf("RegionNetworkEndpointGroups().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
 		} else {
 			got := map[string]bool{}
 			for _, obj := range objs {
 				got[obj.Name] = true
 			}
 			if !reflect.DeepEqual(got, want) {
-				t.Errorf("RegionHealthChecks().List(); got %+v, want %+v", got, want)
+				t.Errorf("RegionNetworkEndpointGroups().List(); got %+v, want %+v", got, want)
 			}
 		}
 	}
 
 	// Delete across versions.
-	if err := mock.AlphaRegionHealthChecks().Delete(ctx, keyAlpha); err != nil {
-		t.Errorf("AlphaRegionHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	if err := mock.AlphaRegionNetworkEndpointGroups().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaRegionNetworkEndpointGroups().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
 	}
-	if err := mock.BetaRegionHealthChecks().Delete(ctx, keyBeta); err != nil {
-		t.Errorf("BetaRegionHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	if err := mock.BetaRegionNetworkEndpointGroups().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaRegionNetworkEndpointGroups().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
 	}
-	if err := mock.RegionHealthChecks().Delete(ctx, keyGA); err != nil {
-		t.Errorf("RegionHealthChecks().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	if err := mock.RegionNetworkEndpointGroups().Delete(ctx, keyGA); err != nil {
+		t.Errorf("RegionNetworkEndpointGroups().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
 	}
 
 	// Delete not found.
-	if err := mock.AlphaRegionHealthChecks().Delete(ctx, keyAlpha); err == nil {
-		t.Errorf("AlphaRegionHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	if err := mock.AlphaRegionNetworkEndpointGroups().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionNetworkEndpointGroups().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
 	}
-	if err := mock.BetaRegionHealthChecks().Delete(ctx, keyBeta); err == nil {
-		t.Errorf("BetaRegionHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	if err := mock.BetaRegionNetworkEndpointGroups().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaRegionNetworkEndpointGroups().Delete(%v, %v) = nil; want error", ctx, keyBeta)
 	}
-	if err := mock.RegionHealthChecks().Delete(ctx, keyGA); err == nil {
-		t.Errorf("RegionHealthChecks().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	if err := mock.RegionNetworkEndpointGroups().Delete(ctx, keyGA); err == nil {
+		t.Errorf("RegionNetworkEndpointGroups().Delete(%v, %v) = nil; want error", ctx, keyGA)
 	}
 }
 
@@ -3078,6 +3654,141 @@ func TestRegionTargetHttpsProxiesGroup(t *testing.T) {
 	}
 }
 
+func TestRegionTargetTcpProxiesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pr := &SingleProjectRouter{"mock-project"}
+	mock := NewMockGCE(pr)
+
+	var key *meta.Key
+	keyAlpha := meta.RegionalKey("key-alpha", "location")
+	key = keyAlpha
+	keyBeta := meta.RegionalKey("key-beta", "location")
+	key = keyBeta
+	keyGA := meta.RegionalKey("key-ga", "location")
+	key = keyGA
+	// Ignore unused variables.
+	_, _, _ = ctx, mock, key
+
+	// Get not found.
+	if _, err := mock.AlphaRegionTargetTcpProxies().Get(ctx, key); err == nil {
+		t.Errorf("AlphaRegionTargetTcpProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.BetaRegionTargetTcpProxies().Get(ctx, key); err == nil {
+		t.Errorf("BetaRegionTargetTcpProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.RegionTargetTcpProxies().Get(ctx, key); err == nil {
+		t.Errorf("RegionTargetTcpProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+
+	// Insert.
+	{
+		obj := &computealpha.TargetTcpProxy{}
+		if err := mock.AlphaRegionTargetTcpProxies().Insert(ctx, keyAlpha, obj); err != nil {
+			t.Errorf("AlphaRegionTargetTcpProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyAlpha, obj, err)
+		}
+	}
+	{
+		obj := &computebeta.TargetTcpProxy{}
+		if err := mock.BetaRegionTargetTcpProxies().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaRegionTargetTcpProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
+	{
+		obj := &computega.TargetTcpProxy{}
+		if err := mock.RegionTargetTcpProxies().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("RegionTargetTcpProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaRegionTargetTcpProxies().Get(ctx, key); err != nil {
+		t.Errorf("AlphaRegionTargetTcpProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.BetaRegionTargetTcpProxies().Get(ctx, key); err != nil {
+		t.Errorf("BetaRegionTargetTcpProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.RegionTargetTcpProxies().Get(ctx, key); err != nil {
+		t.Errorf("RegionTargetTcpProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+
+	// List.
+	mock.MockAlphaRegionTargetTcpProxies.Objects[*keyAlpha] = mock.MockAlphaRegionTargetTcpProxies.Obj(&computealpha.TargetTcpProxy{Name: keyAlpha.Name})
+	mock.MockBetaRegionTargetTcpProxies.Objects[*keyBeta] = mock.MockBetaRegionTargetTcpProxies.Obj(&computebeta.TargetTcpProxy{Name: keyBeta.Name})
+	mock.MockRegionTargetTcpProxies.Objects[*keyGA] = mock.MockRegionTargetTcpProxies.Obj(&computega.TargetTcpProxy{Name: keyGA.Name})
+	want := map[string]bool{
+		"key-alpha": true,
+		"key-beta":  true,
+		"key-ga":    true,
+	}
+	_ = want // ignore unused variables.
+	{
+		objs, err := mock.AlphaRegionTargetTcpProxies().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("AlphaRegionTargetTcpProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaRegionTargetTcpProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BetaRegionTargetTcpProxies().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("BetaRegionTargetTcpProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaRegionTargetTcpProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.RegionTargetTcpProxies().List(ctx, location, filter.None)
+		if err != nil {
+			t.Errorf("RegionTargetTcpProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("RegionTargetTcpProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+
+	// Delete across versions.
+	if err := mock.AlphaRegionTargetTcpProxies().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaRegionTargetTcpProxies().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.BetaRegionTargetTcpProxies().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaRegionTargetTcpProxies().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.RegionTargetTcpProxies().Delete(ctx, keyGA); err != nil {
+		t.Errorf("RegionTargetTcpProxies().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+
+	// Delete not found.
+	if err := mock.AlphaRegionTargetTcpProxies().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaRegionTargetTcpProxies().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaRegionTargetTcpProxies().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaRegionTargetTcpProxies().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.RegionTargetTcpProxies().Delete(ctx, keyGA); err == nil {
+		t.Errorf("RegionTargetTcpProxies().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
 func TestRegionUrlMapsGroup(t *testing.T) {
 	t.Parallel()
 
@@ -3467,35 +4178,81 @@ func TestSecurityPoliciesGroup(t *testing.T) {
 	mock := NewMockGCE(pr)
 
 	var key *meta.Key
+	keyAlpha := meta.GlobalKey("key-alpha")
+	key = keyAlpha
 	keyBeta := meta.GlobalKey("key-beta")
 	key = keyBeta
+	keyGA := meta.GlobalKey("key-ga")
+	key = keyGA
 	// Ignore unused variables.
 	_, _, _ = ctx, mock, key
 
 	// Get not found.
+	if _, err := mock.AlphaSecurityPolicies().Get(ctx, key); err == nil {
+		t.Errorf("AlphaSecurityPolicies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
 	if _, err := mock.BetaSecurityPolicies().Get(ctx, key); err == nil {
 		t.Errorf("BetaSecurityPolicies().Get(%v, %v) = _, nil; want error", ctx, key)
 	}
+	if _, err := mock.SecurityPolicies().Get(ctx, key); err == nil {
+		t.Errorf("SecurityPolicies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
 
 	// Insert.
+	{
+		obj := &computealpha.SecurityPolicy{}
+		if err := mock.AlphaSecurityPolicies().Insert(ctx, keyAlpha, obj); err != nil {
+			t.Errorf("AlphaSecurityPolicies().Insert(%v, %v, %v) = %v; want nil", ctx, keyAlpha, obj, err)
+		}
+	}
 	{
 		obj := &computebeta.SecurityPolicy{}
 		if err := mock.BetaSecurityPolicies().Insert(ctx, keyBeta, obj); err != nil {
 			t.Errorf("BetaSecurityPolicies().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
 		}
 	}
+	{
+		obj := &computega.SecurityPolicy{}
+		if err := mock.SecurityPolicies().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("SecurityPolicies().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
 
 	// Get across versions.
+	if obj, err := mock.AlphaSecurityPolicies().Get(ctx, key); err != nil {
+		t.Errorf("AlphaSecurityPolicies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
 	if obj, err := mock.BetaSecurityPolicies().Get(ctx, key); err != nil {
 		t.Errorf("BetaSecurityPolicies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
 	}
+	if obj, err := mock.SecurityPolicies().Get(ctx, key); err != nil {
+		t.Errorf("SecurityPolicies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
 
 	// List.
+	mock.MockAlphaSecurityPolicies.Objects[*keyAlpha] = mock.MockAlphaSecurityPolicies.Obj(&computealpha.SecurityPolicy{Name: keyAlpha.Name})
 	mock.MockBetaSecurityPolicies.Objects[*keyBeta] = mock.MockBetaSecurityPolicies.Obj(&computebeta.SecurityPolicy{Name: keyBeta.Name})
+	mock.MockSecurityPolicies.Objects[*keyGA] = mock.MockSecurityPolicies.Obj(&computega.SecurityPolicy{Name: keyGA.Name})
 	want := map[string]bool{
-		"key-beta": true,
+		"key-alpha": true,
+		"key-beta":  true,
+		"key-ga":    true,
 	}
 	_ = want // ignore unused variables.
+	{
+		objs, err := mock.AlphaSecurityPolicies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaSecurityPolicies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaSecurityPolicies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
 	{
 		objs, err := mock.BetaSecurityPolicies().List(ctx, filter.None)
 		if err != nil {
@@ -3510,16 +4267,42 @@ func TestSecurityPoliciesGroup(t *testing.T) {
 			}
 		}
 	}
+	{
+		objs, err := mock.SecurityPolicies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("SecurityPolicies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("SecurityPolicies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
 
 	// Delete across versions.
+	if err := mock.AlphaSecurityPolicies().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaSecurityPolicies().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
 	if err := mock.BetaSecurityPolicies().Delete(ctx, keyBeta); err != nil {
 		t.Errorf("BetaSecurityPolicies().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
 	}
+	if err := mock.SecurityPolicies().Delete(ctx, keyGA); err != nil {
+		t.Errorf("SecurityPolicies().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
 
 	// Delete not found.
+	if err := mock.AlphaSecurityPolicies().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaSecurityPolicies().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
 	if err := mock.BetaSecurityPolicies().Delete(ctx, keyBeta); err == nil {
 		t.Errorf("BetaSecurityPolicies().Delete(%v, %v) = nil; want error", ctx, keyBeta)
 	}
+	if err := mock.SecurityPolicies().Delete(ctx, keyGA); err == nil {
+		t.Errorf("SecurityPolicies().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
 }
 
 func TestServiceAttachmentsGroup(t *testing.T) {
@@ -4309,6 +5092,141 @@ func TestTargetPoolsGroup(t *testing.T) {
 	}
 }
 
+func TestTargetSslProxiesGroup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	pr := &SingleProjectRouter{"mock-project"}
+	mock := NewMockGCE(pr)
+
+	var key *meta.Key
+	keyAlpha := meta.GlobalKey("key-alpha")
+	key = keyAlpha
+	keyBeta := meta.GlobalKey("key-beta")
+	key = keyBeta
+	keyGA := meta.GlobalKey("key-ga")
+	key = keyGA
+	// Ignore unused variables.
+	_, _, _ = ctx, mock, key
+
+	// Get not found.
+	if _, err := mock.AlphaTargetSslProxies().Get(ctx, key); err == nil {
+		t.Errorf("AlphaTargetSslProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.BetaTargetSslProxies().Get(ctx, key); err == nil {
+		t.Errorf("BetaTargetSslProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+	if _, err := mock.TargetSslProxies().Get(ctx, key); err == nil {
+		t.Errorf("TargetSslProxies().Get(%v, %v) = _, nil; want error", ctx, key)
+	}
+
+	// Insert.
+	{
+		obj := &computealpha.TargetSslProxy{}
+		if err := mock.AlphaTargetSslProxies().Insert(ctx, keyAlpha, obj); err != nil {
+			t.Errorf("AlphaTargetSslProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyAlpha, obj, err)
+		}
+	}
+	{
+		obj := &computebeta.TargetSslProxy{}
+		if err := mock.BetaTargetSslProxies().Insert(ctx, keyBeta, obj); err != nil {
+			t.Errorf("BetaTargetSslProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyBeta, obj, err)
+		}
+	}
+	{
+		obj := &computega.TargetSslProxy{}
+		if err := mock.TargetSslProxies().Insert(ctx, keyGA, obj); err != nil {
+			t.Errorf("TargetSslProxies().Insert(%v, %v, %v) = %v; want nil", ctx, keyGA, obj, err)
+		}
+	}
+
+	// Get across versions.
+	if obj, err := mock.AlphaTargetSslProxies().Get(ctx, key); err != nil {
+		t.Errorf("AlphaTargetSslProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.BetaTargetSslProxies().Get(ctx, key); err != nil {
+		t.Errorf("BetaTargetSslProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+	if obj, err := mock.TargetSslProxies().Get(ctx, key); err != nil {
+		t.Errorf("TargetSslProxies().Get(%v, %v) = %v, %v; want nil", ctx, key, obj, err)
+	}
+
+	// List.
+	mock.MockAlphaTargetSslProxies.Objects[*keyAlpha] = mock.MockAlphaTargetSslProxies.Obj(&computealpha.TargetSslProxy{Name: keyAlpha.Name})
+	mock.MockBetaTargetSslProxies.Objects[*keyBeta] = mock.MockBetaTargetSslProxies.Obj(&computebeta.TargetSslProxy{Name: keyBeta.Name})
+	mock.MockTargetSslProxies.Objects[*keyGA] = mock.MockTargetSslProxies.Obj(&computega.TargetSslProxy{Name: keyGA.Name})
+	want := map[string]bool{
+		"key-alpha": true,
+		"key-beta":  true,
+		"key-ga":    true,
+	}
+	_ = want // ignore unused variables.
+	{
+		objs, err := mock.AlphaTargetSslProxies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("AlphaTargetSslProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("AlphaTargetSslProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.BetaTargetSslProxies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("BetaTargetSslProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("BetaTargetSslProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+	{
+		objs, err := mock.TargetSslProxies().List(ctx, filter.None)
+		if err != nil {
+			t.Errorf("TargetSslProxies().List(%v, %v, %v) = %v, %v; want _, nil", ctx, location, filter.None, objs, err)
+		} else {
+			got := map[string]bool{}
+			for _, obj := range objs {
+				got[obj.Name] = true
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("TargetSslProxies().List(); got %+v, want %+v", got, want)
+			}
+		}
+	}
+
+	// Delete across versions.
+	if err := mock.AlphaTargetSslProxies().Delete(ctx, keyAlpha); err != nil {
+		t.Errorf("AlphaTargetSslProxies().Delete(%v, %v) = %v; want nil", ctx, keyAlpha, err)
+	}
+	if err := mock.BetaTargetSslProxies().Delete(ctx, keyBeta); err != nil {
+		t.Errorf("BetaTargetSslProxies().Delete(%v, %v) = %v; want nil", ctx, keyBeta, err)
+	}
+	if err := mock.TargetSslProxies().Delete(ctx, keyGA); err != nil {
+		t.Errorf("TargetSslProxies().Delete(%v, %v) = %v; want nil", ctx, keyGA, err)
+	}
+
+	// Delete not found.
+	if err := mock.AlphaTargetSslProxies().Delete(ctx, keyAlpha); err == nil {
+		t.Errorf("AlphaTargetSslProxies().Delete(%v, %v) = nil; want error", ctx, keyAlpha)
+	}
+	if err := mock.BetaTargetSslProxies().Delete(ctx, keyBeta); err == nil {
+		t.Errorf("BetaTargetSslProxies().Delete(%v, %v) = nil; want error", ctx, keyBeta)
+	}
+	if err := mock.TargetSslProxies().Delete(ctx, keyGA); err == nil {
+		t.Errorf("TargetSslProxies().Delete(%v, %v) = nil; want error", ctx, keyGA)
+	}
+}
+
 func TestTargetTcpProxiesGroup(t *testing.T) {
 	t.Parallel()
 
@@ -4735,11 +5653,14 @@ func TestResourceIDConversion(t *testing.T) {
 		NewDisksResourceID("some-project", "us-east1-b", "my-disks-resource"),
 		NewFirewallsResourceID("some-project", "my-firewalls-resource"),
 		NewForwardingRulesResourceID("some-project", "us-central1", "my-forwardingRules-resource"),
+		NewGatewaysResourceID("some-project", "my-gateways-resource"),
 		NewGlobalAddressesResourceID("some-project", "my-addresses-resource"),
 		NewGlobalForwardingRulesResourceID("some-project", "my-forwardingRules-resource"),
 		NewGlobalNetworkEndpointGroupsResourceID("some-project", "my-networkEndpointGroups-resource"),
+		NewGrpcRoutesResourceID("some-project", "my-grpcRoutes-resource"),
 		NewHealthChecksResourceID("some-project", "my-healthChecks-resource"),
 		NewHttpHealthChecksResourceID("some-project", "my-httpHealthChecks-resource"),
+		NewHttpRoutesResourceID("some-project", "my-httpRoutes-resource"),
 		NewHttpsHealthChecksResourceID("some-project", "my-httpsHealthChecks-resource"),
 		NewImagesResourceID("some-project", "my-Images-resource"),
 		NewInstanceGroupManagersResourceID("some-project", "us-east1-b", "my-instanceGroupManagers-resource"),
@@ -4754,11 +5675,13 @@ func TestResourceIDConversion(t *testing.T) {
 		NewRegionBackendServicesResourceID("some-project", "us-central1", "my-backendServices-resource"),
 		NewRegionDisksResourceID("some-project", "us-central1", "my-disks-resource"),
 		NewRegionHealthChecksResourceID("some-project", "us-central1", "my-healthChecks-resource"),
+		NewRegionNetworkEndpointGroupsResourceID("some-project", "us-central1", "my-networkEndpointGroups-resource"),
 		NewRegionNetworkFirewallPoliciesResourceID("some-project", "us-central1", "my-regionNetworkFirewallPolicies-resource"),
 		NewRegionSslCertificatesResourceID("some-project", "us-central1", "my-sslCertificates-resource"),
 		NewRegionSslPoliciesResourceID("some-project", "us-central1", "my-sslPolicies-resource"),
 		NewRegionTargetHttpProxiesResourceID("some-project", "us-central1", "my-targetHttpProxies-resource"),
 		NewRegionTargetHttpsProxiesResourceID("some-project", "us-central1", "my-targetHttpsProxies-resource"),
+		NewRegionTargetTcpProxiesResourceID("some-project", "us-central1", "my-targetTcpProxies-resource"),
 		NewRegionUrlMapsResourceID("some-project", "us-central1", "my-urlMaps-resource"),
 		NewRegionsResourceID("some-project", "my-regions-resource"),
 		NewRoutersResourceID("some-project", "us-central1", "my-routers-resource"),
@@ -4771,6 +5694,7 @@ func TestResourceIDConversion(t *testing.T) {
 		NewTargetHttpProxiesResourceID("some-project", "my-targetHttpProxies-resource"),
 		NewTargetHttpsProxiesResourceID("some-project", "my-targetHttpsProxies-resource"),
 		NewTargetPoolsResourceID("some-project", "us-central1", "my-targetPools-resource"),
+		NewTargetSslProxiesResourceID("some-project", "my-targetSslProxies-resource"),
 		NewTargetTcpProxiesResourceID("some-project", "my-targetTcpProxies-resource"),
 		NewTcpRoutesResourceID("some-project", "my-tcpRoutes-resource"),
 		NewUrlMapsResourceID("some-project", "my-urlMaps-resource"),
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/klog/v2"
+)
+
+// Verbosity levels of the structured logs.
+const (
+	// LogLevelCallFailed is the level of the calls and operations that
+	// returned an error.
+	LogLevelCallFailed = 2
+	// LogLevelCall is the level of the calls and operations that
+	// completed.
+	LogLevelCall = 4
+	// LogLevelCallDetail is the level of the start of calls and each poll
+	// of an operation.
+	LogLevelCallDetail = 5
+)
+
+var logValuesContextKey = contextKey("logValues")
+
+// WithLogValues returns a context that adds keysAndValues to the logs of
+// the calls made with it. The API calls, operation polling and the rgraph
+// executor are logged to klog with the values of the call, e.g. the service,
+// version, duration and outcome, followed by the values of the context:
+//
+//	ctx = cloud.WithLogValues(ctx, "ingress", name)
+//	g.BackendServices().Insert(ctx, ...)
+//
+// The values are appended to the ones already in ctx.
+func WithLogValues(ctx context.Context, keysAndValues ...any) context.Context {
+	kv := append(append([]any(nil), LogValues(ctx)...), keysAndValues...)
+	return context.WithValue(ctx, logValuesContextKey, kv)
+}
+
+// LogValues returns the values added to ctx with WithLogValues.
+func LogValues(ctx context.Context) []any {
+	kv, _ := ctx.Value(logValuesContextKey).([]any)
+	return kv
+}
+
+// sink is where the structured logs are written. This is replaced in tests.
+var sink logSink = klogSink{}
+
+type logSink interface {
+	Enabled(level klog.Level) bool
+	InfoS(level klog.Level, msg string, keysAndValues ...any)
+}
+
+type klogSink struct{}
+
+func (klogSink) Enabled(level klog.Level) bool { return klog.V(level).Enabled() }
+
+func (klogSink) InfoS(level klog.Level, msg string, keysAndValues ...any) {
+	klog.V(level).InfoS(msg, keysAndValues...)
+}
+
+// logS logs msg at level with kv followed by the values of the context.
+func logS(ctx context.Context, level klog.Level, msg string, kv ...any) {
+	if !sink.Enabled(level) {
+		return
+	}
+	sink.InfoS(level, msg, append(kv, LogValues(ctx)...)...)
+}
+
+// callStart is called by the generated wrappers before a call to the API.
+// key is nil for calls that are not for a single resource (e.g. List). The
+// returned time is passed to callEnd.
+func callStart(ctx context.Context, ck *CallContextKey, key *meta.Key) time.Time {
+	callObserverStart(ctx, ck)
+	logS(ctx, LogLevelCallDetail, "Call started", callValues(ck, key)...)
+	return time.Now()
+}
+
+// callEnd is called by the generated wrappers when a call started at start
+// has finished, including when it was rejected by the RateLimiter.
+func callEnd(ctx context.Context, ck *CallContextKey, key *meta.Key, start time.Time, err error) {
+	callObserverEnd(ctx, ck, err)
+
+	kv := append(callValues(ck, key), "duration", time.Since(start))
+	if err != nil {
+		logS(ctx, LogLevelCallFailed, "Call failed", append(kv, "err", err)...)
+		return
+	}
+	logS(ctx, LogLevelCall, "Call completed", kv...)
+}
+
+func callValues(ck *CallContextKey, key *meta.Key) []any {
+	var kv []any
+	if ck != nil {
+		kv = append(kv,
+			"service", ck.Service,
+			"operation", ck.Operation,
+			"version", ck.Version,
+			"project", ck.ProjectID,
+		)
+	}
+	if key != nil {
+		kv = append(kv, "key", key.String())
+	}
+	return kv
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"k8s.io/klog/v2"
+)
+
+type logEntry struct {
+	level  klog.Level
+	msg    string
+	values map[string]any
+}
+
+// testSink records the entries with level <= maxLevel.
+type testSink struct {
+	maxLevel klog.Level
+
+	lock    sync.Mutex
+	entries []logEntry
+}
+
+func (s *testSink) Enabled(level klog.Level) bool { return level <= s.maxLevel }
+
+func (s *testSink) InfoS(level klog.Level, msg string, kv ...any) {
+	e := logEntry{level: level, msg: msg, values: map[string]any{}}
+	for i := 0; i+1 < len(kv); i += 2 {
+		e.values[kv[i].(string)] = kv[i+1]
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.entries = append(s.entries, e)
+}
+
+func (s *testSink) msgs() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var ret []string
+	for _, e := range s.entries {
+		ret = append(ret, e.msg)
+	}
+	return ret
+}
+
+// withTestSink replaces the sink of the logs for the duration of the test.
+func withTestSink(t *testing.T, maxLevel klog.Level) *testSink {
+	s := &testSink{maxLevel: maxLevel}
+	old := sink
+	sink = s
+	t.Cleanup(func() { sink = old })
+	return s
+}
+
+func TestWithLogValues(t *testing.T) {
+	if got := LogValues(context.Background()); got != nil {
+		t.Errorf("LogValues(no values) = %v, want nil", got)
+	}
+	ctx := WithLogValues(context.Background(), "k1", 1)
+	ctx2 := WithLogValues(ctx, "k2", 2)
+	WithLogValues(ctx, "k3", 3)
+
+	if got, want := LogValues(ctx), []any{"k1", 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LogValues(ctx) = %v, want %v", got, want)
+	}
+	if got, want := LogValues(ctx2), []any{"k1", 1, "k2", 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LogValues(ctx2) = %v, want %v", got, want)
+	}
+}
+
+func TestCallLogging(t *testing.T) {
+	ck := &CallContextKey{ProjectID: "proj", Operation: "Get", Version: meta.VersionGA, Service: "Addresses"}
+	testErr := errors.New("injected")
+
+	for _, tc := range []struct {
+		name     string
+		maxLevel klog.Level
+		err      error
+		want     []string
+	}{
+		{name: "silent", maxLevel: 0},
+		{name: "failed", maxLevel: LogLevelCallFailed, err: testErr, want: []string{"Call failed"}},
+		{name: "completed", maxLevel: LogLevelCall, want: []string{"Call completed"}},
+		{name: "detail", maxLevel: LogLevelCallDetail, want: []string{"Call started", "Call completed"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := withTestSink(t, tc.maxLevel)
+			ctx := WithLogValues(context.Background(), "caller", "test")
+			start := callStart(ctx, ck, meta.GlobalKey("addr"))
+			callEnd(ctx, ck, meta.GlobalKey("addr"), start, tc.err)
+
+			if got := s.msgs(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("logs = %v, want %v", got, tc.want)
+			}
+			for _, e := range s.entries {
+				for k, v := range map[string]any{"caller": "test", "service": "Addresses", "operation": "Get", "version": meta.VersionGA, "project": "proj", "key": "Key{\"addr\"}"} {
+					if e.values[k] != v {
+						t.Errorf("%q: %s = %v, want %v", e.msg, k, e.values[k], v)
+					}
+				}
+				if e.msg == "Call started" {
+					continue
+				}
+				if _, ok := e.values["duration"].(time.Duration); !ok {
+					t.Errorf("%q: duration = %v, want a time.Duration", e.msg, e.values["duration"])
+				}
+				if e.values["err"] != nil && e.values["err"] != tc.err {
+					t.Errorf("%q: err = %v, want %v", e.msg, e.values["err"], tc.err)
+				}
+			}
+		})
+	}
+}
+
+func TestPollOperationLogging(t *testing.T) {
+	ls := withTestSink(t, LogLevelCallDetail)
+	s := Service{RateLimiter: &NopRateLimiter{}}
+	if err := s.pollOperation(context.Background(), &fakeOperation{attemptsRemaining: 2}); err != nil {
+		t.Fatalf("pollOperation() = %v, want nil", err)
+	}
+	want := []string{"Polling operation", "Polling operation", "Operation done"}
+	if got := ls.msgs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("logs = %v, want %v", got, want)
+	}
+	if got := ls.entries[2].values["polls"]; got != 2 {
+		t.Errorf("polls = %v, want 2", got)
+	}
+}
+
+type errRateLimiter struct{ err error }
+
+func (l *errRateLimiter) Accept(context.Context, *RateLimitKey) error   { return l.err }
+func (l *errRateLimiter) Observe(context.Context, error, *RateLimitKey) {}
+
+func TestCallLoggingRateLimiterError(t *testing.T) {
+	ls := withTestSink(t, LogLevelCallDetail)
+	testErr := errors.New("injected")
+	s := &Service{RateLimiter: &errRateLimiter{err: testErr}, ProjectRouter: &SingleProjectRouter{ID: "proj"}}
+
+	if _, err := NewGCE(s).Addresses().Get(context.Background(), meta.RegionalKey("addr", "us-central1")); err != testErr {
+		t.Fatalf("Get() = %v, want %v", err, testErr)
+	}
+	// The call that was not accepted is logged as failed.
+	want := []string{"Call started", "Call failed"}
+	if got := ls.msgs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("logs = %v, want %v", got, want)
+	}
+	if got, want := ls.entries[1].values["key"], `Key{"addr", region: "us-central1"}`; got != want {
+		t.Errorf("key = %v, want %v", got, want)
+	}
+}
@@ -39,6 +39,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&ga.AddressesService{}),
 		options:     AggregatedList,
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -48,6 +51,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&alpha.AddressesService{}),
 		options:     AggregatedList,
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -57,6 +63,9 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Regional,
 		serviceType: reflect.TypeOf(&beta.AddressesService{}),
 		options:     AggregatedList,
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -65,6 +74,9 @@ var ComputeServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&alpha.GlobalAddressesService{}),
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -73,6 +85,9 @@ var ComputeServices = []*ServiceInfo{
 		version:     VersionBeta,
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&beta.GlobalAddressesService{}),
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "Address",
@@ -80,6 +95,9 @@ var ComputeServices = []*ServiceInfo{
 		Resource:    "addresses",
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.GlobalAddressesService{}),
+		additionalMethods: []string{
+			"SetLabels",
+		},
 	},
 	{
 		Object:      "BackendService",
@@ -92,6 +110,7 @@ var ComputeServices = []*ServiceInfo{
 			"Patch",
 			"Update",
 			"SetSecurityPolicy",
+			"SetEdgeSecurityPolicy",
 			"AddSignedUrlKey",
 			"DeleteSignedUrlKey",
 		},
@@ -108,6 +127,7 @@ var ComputeServices = []*ServiceInfo{
 			"Patch",
 			"Update",
 			"SetSecurityPolicy",
+			"SetEdgeSecurityPolicy",
 			"AddSignedUrlKey",
 			"DeleteSignedUrlKey",
 		},
@@ -124,6 +144,7 @@ var ComputeServices = []*ServiceInfo{
 			"Patch",
 			"Update",
 			"SetSecurityPolicy",
+			"SetEdgeSecurityPolicy",
 			"AddSignedUrlKey",
 			"DeleteSignedUrlKey",
 		},
@@ -140,6 +161,7 @@ var ComputeServices = []*ServiceInfo{
 			"GetHealth",
 			"Patch",
 			"Update",
+			"SetSecurityPolicy",
 		},
 	},
 	{
@@ -153,6 +175,7 @@ var ComputeServices = []*ServiceInfo{
 			"GetHealth",
 			"Patch",
 			"Update",
+			"SetSecurityPolicy",
 		},
 	},
 	{
@@ -166,6 +189,7 @@ var ComputeServices = []*ServiceInfo{
 			"GetHealth",
 			"Patch",
 			"Update",
+			"SetSecurityPolicy",
 		},
 	},
 	{
@@ -246,6 +270,49 @@ var ComputeServices = []*ServiceInfo{
 			"TestIamPermissions",
 		},
 	},
+	{
+		Object:      "FirewallPolicy",
+		Service:     "NetworkFirewallPolicies",
+		Resource:    "networkFirewallPolicies",
+		version:     VersionBeta,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&beta.NetworkFirewallPoliciesService{}),
+		additionalMethods: []string{
+			"Patch",
+			"GetRule",
+			"AddRule",
+			"PatchRule",
+			"CloneRules",
+			"RemoveRule",
+			"GetAssociation",
+			"AddAssociation",
+			"RemoveAssociation",
+			"GetIamPolicy",
+			"SetIamPolicy",
+			"TestIamPermissions",
+		},
+	},
+	{
+		Object:      "FirewallPolicy",
+		Service:     "NetworkFirewallPolicies",
+		Resource:    "networkFirewallPolicies",
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&ga.NetworkFirewallPoliciesService{}),
+		additionalMethods: []string{
+			"Patch",
+			"GetRule",
+			"AddRule",
+			"PatchRule",
+			"CloneRules",
+			"RemoveRule",
+			"GetAssociation",
+			"AddAssociation",
+			"RemoveAssociation",
+			"GetIamPolicy",
+			"SetIamPolicy",
+			"TestIamPermissions",
+		},
+	},
 	{
 		Object:      "FirewallPolicy",
 		Service:     "RegionNetworkFirewallPolicies",
@@ -277,6 +344,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"SetTarget",
 			"SetLabels",
+			"Patch",
 		},
 	},
 	{
@@ -289,6 +357,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"SetTarget",
 			"SetLabels",
+			"Patch",
 		},
 	},
 	{
@@ -301,6 +370,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"SetTarget",
 			"SetLabels",
+			"Patch",
 		},
 	},
 	{
@@ -313,6 +383,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"SetTarget",
 			"SetLabels",
+			"Patch",
 		},
 	},
 	{
@@ -325,6 +396,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"SetTarget",
 			"SetLabels",
+			"Patch",
 		},
 	},
 	{
@@ -336,6 +408,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"SetTarget",
 			"SetLabels",
+			"Patch",
 		},
 	},
 	{
@@ -346,6 +419,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&ga.HealthChecksService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -357,6 +431,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&alpha.HealthChecksService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -368,6 +443,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&beta.HealthChecksService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -379,6 +455,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&alpha.RegionHealthChecksService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -390,6 +467,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&beta.RegionHealthChecksService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -401,6 +479,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&ga.RegionHealthChecksService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -411,6 +490,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&ga.HttpHealthChecksService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -421,6 +501,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&ga.HttpsHealthChecksService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -436,6 +517,34 @@ var ComputeServices = []*ServiceInfo{
 			"SetNamedPorts",
 		},
 	},
+	{
+		Object:      "InstanceGroup",
+		Service:     "InstanceGroups",
+		Resource:    "instanceGroups",
+		version:     VersionBeta,
+		keyType:     Zonal,
+		serviceType: reflect.TypeOf(&beta.InstanceGroupsService{}),
+		additionalMethods: []string{
+			"AddInstances",
+			"ListInstances",
+			"RemoveInstances",
+			"SetNamedPorts",
+		},
+	},
+	{
+		Object:      "InstanceGroup",
+		Service:     "InstanceGroups",
+		Resource:    "instanceGroups",
+		version:     VersionAlpha,
+		keyType:     Zonal,
+		serviceType: reflect.TypeOf(&alpha.InstanceGroupsService{}),
+		additionalMethods: []string{
+			"AddInstances",
+			"ListInstances",
+			"RemoveInstances",
+			"SetNamedPorts",
+		},
+	},
 	{
 		Object:      "Instance",
 		Service:     "Instances",
@@ -445,6 +554,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"AttachDisk",
 			"DetachDisk",
+			"SetMetadata",
 		},
 	},
 	{
@@ -457,6 +567,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"AttachDisk",
 			"DetachDisk",
+			"SetMetadata",
 			"UpdateNetworkInterface",
 		},
 	},
@@ -470,6 +581,7 @@ var ComputeServices = []*ServiceInfo{
 		additionalMethods: []string{
 			"AttachDisk",
 			"DetachDisk",
+			"SetMetadata",
 			"UpdateNetworkInterface",
 		},
 	},
@@ -547,6 +659,9 @@ var ComputeServices = []*ServiceInfo{
 		version:     VersionAlpha,
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&alpha.NetworksService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
 	},
 	{
 		Object:      "Network",
@@ -554,7 +669,10 @@ var ComputeServices = []*ServiceInfo{
 		Resource:    "networks",
 		version:     VersionBeta,
 		keyType:     Global,
-		serviceType: reflect.TypeOf(&alpha.NetworksService{}),
+		serviceType: reflect.TypeOf(&beta.NetworksService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
 	},
 	{
 		Object:      "Network",
@@ -562,7 +680,10 @@ var ComputeServices = []*ServiceInfo{
 		Resource:    "networks",
 		version:     VersionGA,
 		keyType:     Global,
-		serviceType: reflect.TypeOf(&alpha.NetworksService{}),
+		serviceType: reflect.TypeOf(&ga.NetworksService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
 	},
 	{
 		Object:      "NetworkEndpointGroup",
@@ -645,6 +766,45 @@ var ComputeServices = []*ServiceInfo{
 			"ListNetworkEndpoints",
 		},
 	},
+	{
+		Object:      "NetworkEndpointGroup",
+		Service:     "RegionNetworkEndpointGroups",
+		Resource:    "networkEndpointGroups",
+		version:     VersionAlpha,
+		keyType:     Regional,
+		serviceType: reflect.TypeOf(&alpha.RegionNetworkEndpointGroupsService{}),
+		additionalMethods: []string{
+			"AttachNetworkEndpoints",
+			"DetachNetworkEndpoints",
+			"ListNetworkEndpoints",
+		},
+	},
+	{
+		Object:      "NetworkEndpointGroup",
+		Service:     "RegionNetworkEndpointGroups",
+		Resource:    "networkEndpointGroups",
+		version:     VersionBeta,
+		keyType:     Regional,
+		serviceType: reflect.TypeOf(&beta.RegionNetworkEndpointGroupsService{}),
+		additionalMethods: []string{
+			"AttachNetworkEndpoints",
+			"DetachNetworkEndpoints",
+			"ListNetworkEndpoints",
+		},
+	},
+	{
+		Object:      "NetworkEndpointGroup",
+		Service:     "RegionNetworkEndpointGroups",
+		Resource:    "networkEndpointGroups",
+		version:     VersionGA,
+		keyType:     Regional,
+		serviceType: reflect.TypeOf(&ga.RegionNetworkEndpointGroupsService{}),
+		additionalMethods: []string{
+			"AttachNetworkEndpoints",
+			"DetachNetworkEndpoints",
+			"ListNetworkEndpoints",
+		},
+	},
 	{
 		Object:   "Project",
 		Service:  "Projects",
@@ -671,6 +831,7 @@ var ComputeServices = []*ServiceInfo{
 		options:     AggregatedList,
 		serviceType: reflect.TypeOf(&alpha.RoutersService{}),
 		additionalMethods: []string{
+			"Update",
 			"Patch",
 			"Preview",
 			"GetRouterStatus",
@@ -686,6 +847,7 @@ var ComputeServices = []*ServiceInfo{
 		options:     AggregatedList,
 		serviceType: reflect.TypeOf(&beta.RoutersService{}),
 		additionalMethods: []string{
+			"Update",
 			"Patch",
 			"Preview",
 			"GetRouterStatus",
@@ -701,6 +863,7 @@ var ComputeServices = []*ServiceInfo{
 		options:     AggregatedList,
 		serviceType: reflect.TypeOf(&ga.RoutersService{}),
 		additionalMethods: []string{
+			"Update",
 			"Patch",
 			"Preview",
 			"GetRouterStatus",
@@ -713,6 +876,21 @@ var ComputeServices = []*ServiceInfo{
 		keyType:     Global,
 		serviceType: reflect.TypeOf(&ga.RoutesService{}),
 	},
+	{
+		Object:      "SecurityPolicy",
+		Service:     "SecurityPolicies",
+		Resource:    "securityPolicies",
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&ga.SecurityPoliciesService{}),
+		additionalMethods: []string{
+			"AddRule",
+			"GetRule",
+			"Patch",
+			"PatchRule",
+			"RemoveRule",
+			"SetLabels",
+		},
+	},
 	{
 		Object:      "SecurityPolicy",
 		Service:     "SecurityPolicies",
@@ -726,6 +904,23 @@ var ComputeServices = []*ServiceInfo{
 			"Patch",
 			"PatchRule",
 			"RemoveRule",
+			"SetLabels",
+		},
+	},
+	{
+		Object:      "SecurityPolicy",
+		Service:     "SecurityPolicies",
+		Resource:    "securityPolicies",
+		version:     VersionAlpha,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&alpha.SecurityPoliciesService{}),
+		additionalMethods: []string{
+			"AddRule",
+			"GetRule",
+			"Patch",
+			"PatchRule",
+			"RemoveRule",
+			"SetLabels",
 		},
 	},
 	{
@@ -834,6 +1029,7 @@ var ComputeServices = []*ServiceInfo{
 		options:     ListUsable,
 		additionalMethods: []string{
 			"Patch",
+			"ExpandIpCidrRange",
 		},
 	},
 	{
@@ -846,6 +1042,7 @@ var ComputeServices = []*ServiceInfo{
 		options:     ListUsable,
 		additionalMethods: []string{
 			"Patch",
+			"ExpandIpCidrRange",
 		},
 	},
 	{
@@ -858,6 +1055,7 @@ var ComputeServices = []*ServiceInfo{
 		options:     ListUsable,
 		additionalMethods: []string{
 			"Patch",
+			"ExpandIpCidrRange",
 		},
 	},
 	{
@@ -1016,6 +1214,51 @@ var ComputeServices = []*ServiceInfo{
 			"RemoveInstance",
 		},
 	},
+	{
+		Object:      "TargetSslProxy",
+		Service:     "TargetSslProxies",
+		Resource:    "targetSslProxies",
+		version:     VersionAlpha,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&alpha.TargetSslProxiesService{}),
+		additionalMethods: []string{
+			"SetBackendService",
+			"SetCertificateMap",
+			"SetProxyHeader",
+			"SetSslCertificates",
+			"SetSslPolicy",
+		},
+	},
+	{
+		Object:      "TargetSslProxy",
+		Service:     "TargetSslProxies",
+		Resource:    "targetSslProxies",
+		version:     VersionBeta,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&beta.TargetSslProxiesService{}),
+		additionalMethods: []string{
+			"SetBackendService",
+			"SetCertificateMap",
+			"SetProxyHeader",
+			"SetSslCertificates",
+			"SetSslPolicy",
+		},
+	},
+	{
+		Object:      "TargetSslProxy",
+		Service:     "TargetSslProxies",
+		Resource:    "targetSslProxies",
+		version:     VersionGA,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&ga.TargetSslProxiesService{}),
+		additionalMethods: []string{
+			"SetBackendService",
+			"SetCertificateMap",
+			"SetProxyHeader",
+			"SetSslCertificates",
+			"SetSslPolicy",
+		},
+	},
 	{
 		Object:      "TargetTcpProxy",
 		Service:     "TargetTcpProxies",
@@ -1025,6 +1268,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&alpha.TargetTcpProxiesService{}),
 		additionalMethods: []string{
 			"SetBackendService",
+			"SetProxyHeader",
 		},
 	},
 	{
@@ -1036,6 +1280,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&beta.TargetTcpProxiesService{}),
 		additionalMethods: []string{
 			"SetBackendService",
+			"SetProxyHeader",
 		},
 	},
 	{
@@ -1047,8 +1292,33 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&ga.TargetTcpProxiesService{}),
 		additionalMethods: []string{
 			"SetBackendService",
+			"SetProxyHeader",
 		},
 	},
+	{
+		Object:      "TargetTcpProxy",
+		Service:     "RegionTargetTcpProxies",
+		Resource:    "targetTcpProxies",
+		version:     VersionAlpha,
+		keyType:     Regional,
+		serviceType: reflect.TypeOf(&alpha.RegionTargetTcpProxiesService{}),
+	},
+	{
+		Object:      "TargetTcpProxy",
+		Service:     "RegionTargetTcpProxies",
+		Resource:    "targetTcpProxies",
+		version:     VersionBeta,
+		keyType:     Regional,
+		serviceType: reflect.TypeOf(&beta.RegionTargetTcpProxiesService{}),
+	},
+	{
+		Object:      "TargetTcpProxy",
+		Service:     "RegionTargetTcpProxies",
+		Resource:    "targetTcpProxies",
+		version:     VersionGA,
+		keyType:     Regional,
+		serviceType: reflect.TypeOf(&ga.RegionTargetTcpProxiesService{}),
+	},
 	{
 		Object:      "UrlMap",
 		Service:     "UrlMaps",
@@ -1058,6 +1328,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&alpha.UrlMapsService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -1069,6 +1340,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&beta.UrlMapsService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -1079,6 +1351,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&ga.UrlMapsService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -1090,6 +1363,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&alpha.RegionUrlMapsService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -1101,6 +1375,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&beta.RegionUrlMapsService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -1112,6 +1387,7 @@ var ComputeServices = []*ServiceInfo{
 		serviceType: reflect.TypeOf(&ga.RegionUrlMapsService{}),
 		additionalMethods: []string{
 			"Update",
+			"Patch",
 		},
 	},
 	{
@@ -38,13 +38,26 @@ const (
 	Regional = "regional"
 	// Global key type.
 	Global = "global"
+
+	// LocationGlobal is the location of global resources in location-scoped
+	// APIs (e.g. "projects/p/locations/global/meshes/m").
+	LocationGlobal = "global"
 )
 
 var (
 	// locationRegexp is the format of regions/zone names in GCE.
 	locationRegexp = regexp.MustCompile("^[a-z](?:[-a-z0-9]+)?$")
+	// nameRegexp is the RFC1035 format of resource names in GCE.
+	nameRegexp = regexp.MustCompile("^[a-z](?:[-a-z0-9]*[a-z0-9])?$")
 )
 
+// MaxNameLength is the maximum length of a resource name.
+const MaxNameLength = 63
+
+// WildcardScope is the Region or Zone of a wildcard key, matching resources
+// in all regions or zones.
+const WildcardScope = "-"
+
 // ZonalKey returns the key for a zonal resource.
 func ZonalKey(name, zone string) *Key {
 	return &Key{name, zone, ""}
@@ -60,6 +73,58 @@ func GlobalKey(name string) *Key {
 	return &Key{name, "", ""}
 }
 
+// AllRegionsKey returns a wildcard key for the regional resources with the
+// given name in any region. Wildcard keys can only be used to list resources
+// (see cloud.ListWildcard); they are not Valid().
+func AllRegionsKey(name string) *Key {
+	return &Key{name, "", WildcardScope}
+}
+
+// AllZonesKey returns a wildcard key for the zonal resources with the given
+// name in any zone.
+func AllZonesKey(name string) *Key {
+	return &Key{name, WildcardScope, ""}
+}
+
+// ZonalKeyChecked returns the key for a zonal resource or an error if the
+// name is not valid.
+func ZonalKeyChecked(name, zone string) (*Key, error) {
+	return checkedKey(ZonalKey(name, zone))
+}
+
+// RegionalKeyChecked returns the key for a regional resource or an error if
+// the name is not valid.
+func RegionalKeyChecked(name, region string) (*Key, error) {
+	return checkedKey(RegionalKey(name, region))
+}
+
+// GlobalKeyChecked returns the key for a global resource or an error if the
+// name is not valid.
+func GlobalKeyChecked(name string) (*Key, error) {
+	return checkedKey(GlobalKey(name))
+}
+
+func checkedKey(k *Key) (*Key, error) {
+	if err := k.ValidateName(); err != nil {
+		return nil, err
+	}
+	if !k.Valid() {
+		return nil, fmt.Errorf("invalid key %v", k)
+	}
+	return k, nil
+}
+
+// LocationKey returns the key for a resource in a location-scoped API such
+// as networkservices ("projects/<proj>/locations/<location>/<res>/<name>").
+// The "global" location maps to a global key, any other location to a
+// regional key.
+func LocationKey(name, location string) *Key {
+	if location == LocationGlobal {
+		return GlobalKey(name)
+	}
+	return RegionalKey(name, location)
+}
+
 // Type returns the type of the key.
 func (k *Key) Type() KeyType {
 	switch {
@@ -72,6 +137,36 @@ func (k *Key) Type() KeyType {
 	}
 }
 
+// IsWildcard is true if the key matches all regions or zones.
+func (k *Key) IsWildcard() bool {
+	return k.Region == WildcardScope || k.Zone == WildcardScope
+}
+
+// Matches is true if other is the same as k, or k is a wildcard key with the
+// same name and type as other.
+func (k *Key) Matches(other *Key) bool {
+	switch {
+	case k.Name != other.Name || k.Type() != other.Type():
+		return false
+	case k.Region == WildcardScope || k.Zone == WildcardScope:
+		return true
+	}
+	return *k == *other
+}
+
+// Location returns the location of the key as used by location-scoped APIs:
+// "global" for global keys, otherwise the region or zone.
+func (k *Key) Location() string {
+	switch k.Type() {
+	case Zonal:
+		return k.Zone
+	case Regional:
+		return k.Region
+	default:
+		return LocationGlobal
+	}
+}
+
 // String returns a string representation of the key.
 func (k Key) String() string {
 	switch k.Type() {
@@ -98,6 +193,21 @@ func (k *Key) Valid() bool {
 	return true
 }
 
+// ValidateName returns an error if the name of the key does not follow the
+// GCE naming rules: 1-63 characters long and matching the RFC1035 regular
+// expression [a-z]([-a-z0-9]*[a-z0-9])?.
+func (k *Key) ValidateName() error {
+	switch {
+	case k.Name == "":
+		return fmt.Errorf("invalid name for %v: name is empty", k)
+	case len(k.Name) > MaxNameLength:
+		return fmt.Errorf("invalid name for %v: longer than %d characters", k, MaxNameLength)
+	case !nameRegexp.MatchString(k.Name):
+		return fmt.Errorf("invalid name for %v: must match %s", k, nameRegexp)
+	}
+	return nil
+}
+
 // KeysToMap creates a map[Key]bool from a list of keys.
 func KeysToMap(keys ...Key) map[Key]bool {
 	ret := map[Key]bool{}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"sort"
+)
+
+// KeySet is a set of keys.
+type KeySet map[Key]struct{}
+
+// NewKeySet returns a KeySet containing keys.
+func NewKeySet(keys ...*Key) KeySet {
+	s := KeySet{}
+	s.Insert(keys...)
+	return s
+}
+
+// Insert adds keys to the set.
+func (s KeySet) Insert(keys ...*Key) {
+	for _, k := range keys {
+		s[*k] = struct{}{}
+	}
+}
+
+// Delete removes keys from the set.
+func (s KeySet) Delete(keys ...*Key) {
+	for _, k := range keys {
+		delete(s, *k)
+	}
+}
+
+// Has is true if key is in the set.
+func (s KeySet) Has(key *Key) bool {
+	_, ok := s[*key]
+	return ok
+}
+
+// Len is the number of keys in the set.
+func (s KeySet) Len() int { return len(s) }
+
+// Sorted returns the keys in the set sorted by SortKeys.
+func (s KeySet) Sorted() []*Key {
+	ret := make([]*Key, 0, len(s))
+	for k := range s {
+		k := k
+		ret = append(ret, &k)
+	}
+	SortKeys(ret)
+	return ret
+}
+
+// Difference returns the keys in s that are not in other.
+func (s KeySet) Difference(other KeySet) KeySet {
+	ret := KeySet{}
+	for k := range s {
+		if _, ok := other[k]; !ok {
+			ret[k] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// Intersection returns the keys in both s and other.
+func (s KeySet) Intersection(other KeySet) KeySet {
+	ret := KeySet{}
+	for k := range s {
+		if _, ok := other[k]; ok {
+			ret[k] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// Equal is true if s and other contain the same keys.
+func (s KeySet) Equal(other KeySet) bool {
+	return len(s) == len(other) && len(s.Intersection(other)) == len(s)
+}
+
+// ByScope groups the keys by ScopeOf().
+func (s KeySet) ByScope() map[string]KeySet {
+	ret := map[string]KeySet{}
+	for k := range s {
+		k := k
+		scope := ScopeOf(&k)
+		if ret[scope] == nil {
+			ret[scope] = KeySet{}
+		}
+		ret[scope][k] = struct{}{}
+	}
+	return ret
+}
+
+// DiffKeySets compares the desired keys with the actual keys, returning the
+// keys that need to be added and removed to reconcile actual with desired.
+func DiffKeySets(desired, actual KeySet) (add, remove KeySet) {
+	return desired.Difference(actual), actual.Difference(desired)
+}
+
+// KeyMap maps keys to values.
+type KeyMap[V any] map[Key]V
+
+// Keys returns the set of keys in the map.
+func (m KeyMap[V]) Keys() KeySet {
+	ret := KeySet{}
+	for k := range m {
+		ret[k] = struct{}{}
+	}
+	return ret
+}
+
+// SortedKeys returns the keys in the map sorted by SortKeys.
+func (m KeyMap[V]) SortedKeys() []*Key {
+	return m.Keys().Sorted()
+}
+
+// ByScope groups the entries by ScopeOf() the key.
+func (m KeyMap[V]) ByScope() map[string]KeyMap[V] {
+	ret := map[string]KeyMap[V]{}
+	for k, v := range m {
+		k := k
+		scope := ScopeOf(&k)
+		if ret[scope] == nil {
+			ret[scope] = KeyMap[V]{}
+		}
+		ret[scope][k] = v
+	}
+	return ret
+}
+
+// ScopeOf returns the scope of the key in the format used by AggregatedList:
+// "global", "regions/<region>" or "zones/<zone>".
+func ScopeOf(k *Key) string {
+	switch k.Type() {
+	case Zonal:
+		return "zones/" + k.Zone
+	case Regional:
+		return "regions/" + k.Region
+	default:
+		return "global"
+	}
+}
+
+// DedupKeys returns keys without duplicates, keeping the first occurrence of
+// each key.
+func DedupKeys(keys []*Key) []*Key {
+	seen := KeySet{}
+	var ret []*Key
+	for _, k := range keys {
+		if seen.Has(k) {
+			continue
+		}
+		seen.Insert(k)
+		ret = append(ret, k)
+	}
+	return ret
+}
+
+// SortKeys sorts keys in place by scope (global, then regional, then zonal),
+// location and name.
+func SortKeys(keys []*Key) {
+	rank := map[KeyType]int{Global: 0, Regional: 1, Zonal: 2}
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if ra, rb := rank[a.Type()], rank[b.Type()]; ra != rb {
+			return ra < rb
+		}
+		if la, lb := a.Location(), b.Location(); la != lb {
+			return la < lb
+		}
+		return a.Name < b.Name
+	})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func keyStrings(keys []*Key) []string {
+	var ret []string
+	for _, k := range keys {
+		ret = append(ret, k.String())
+	}
+	return ret
+}
+
+func TestKeySet(t *testing.T) {
+	t.Parallel()
+
+	g := GlobalKey("g")
+	r1 := RegionalKey("b", "us-central1")
+	r2 := RegionalKey("a", "us-central1")
+	r3 := RegionalKey("a", "europe-west1")
+	z := ZonalKey("a", "us-central1-b")
+
+	s := NewKeySet(z, r1, g, r2, r3, r1)
+	if s.Len() != 5 {
+		t.Errorf("Len() = %d, want 5", s.Len())
+	}
+	if got, want := keyStrings(s.Sorted()), keyStrings([]*Key{g, r3, r2, r1, z}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Sorted() = %v, want %v", got, want)
+	}
+
+	byScope := s.ByScope()
+	for scope, want := range map[string]KeySet{
+		"global":               NewKeySet(g),
+		"regions/us-central1":  NewKeySet(r1, r2),
+		"regions/europe-west1": NewKeySet(r3),
+		"zones/us-central1-b":  NewKeySet(z),
+	} {
+		if !byScope[scope].Equal(want) {
+			t.Errorf("ByScope()[%q] = %v, want %v", scope, byScope[scope], want)
+		}
+	}
+	if len(byScope) != 4 {
+		t.Errorf("len(ByScope()) = %d, want 4", len(byScope))
+	}
+
+	s.Delete(z)
+	if s.Has(z) || !s.Has(g) {
+		t.Errorf("Has(z), Has(g) = %t, %t after Delete(z); want false, true", s.Has(z), s.Has(g))
+	}
+
+	add, remove := DiffKeySets(NewKeySet(g, r1, z), NewKeySet(g, r2))
+	if !add.Equal(NewKeySet(r1, z)) || !remove.Equal(NewKeySet(r2)) {
+		t.Errorf("DiffKeySets() = %v, %v; want %v, %v", add, remove, NewKeySet(r1, z), NewKeySet(r2))
+	}
+}
+
+func TestKeyMap(t *testing.T) {
+	t.Parallel()
+
+	m := KeyMap[int]{
+		*RegionalKey("a", "us-central1"): 1,
+		*RegionalKey("b", "us-central1"): 2,
+		*GlobalKey("c"):                  3,
+	}
+	if got, want := keyStrings(m.SortedKeys()), keyStrings([]*Key{GlobalKey("c"), RegionalKey("a", "us-central1"), RegionalKey("b", "us-central1")}); !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %v, want %v", got, want)
+	}
+	byScope := m.ByScope()
+	if len(byScope["regions/us-central1"]) != 2 || byScope["global"][*GlobalKey("c")] != 3 {
+		t.Errorf("ByScope() = %v", byScope)
+	}
+}
+
+func TestDedupKeys(t *testing.T) {
+	t.Parallel()
+
+	a, b := GlobalKey("a"), RegionalKey("b", "us-central1")
+	got := DedupKeys([]*Key{b, a, GlobalKey("a"), b})
+	if want := keyStrings([]*Key{b, a}); !reflect.DeepEqual(keyStrings(got), want) {
+		t.Errorf("DedupKeys() = %v, want %v", keyStrings(got), want)
+	}
+}
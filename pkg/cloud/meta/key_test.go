@@ -17,6 +17,7 @@ limitations under the License.
 package meta
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -74,3 +75,135 @@ func TestKeyValid(t *testing.T) {
 		}
 	}
 }
+
+func TestLocationKey(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name, location string
+		want           *Key
+	}{
+		{"abc", "global", GlobalKey("abc")},
+		{"abc", "us-central1", RegionalKey("abc", "us-central1")},
+	} {
+		got := LocationKey(tc.name, tc.location)
+		if *got != *tc.want {
+			t.Errorf("LocationKey(%q, %q) = %v, want %v", tc.name, tc.location, got, tc.want)
+		}
+		if got.Location() != tc.location {
+			t.Errorf("LocationKey(%q, %q).Location() = %q, want %q", tc.name, tc.location, got.Location(), tc.location)
+		}
+	}
+	if got := ZonalKey("abc", "us-central1-b").Location(); got != "us-central1-b" {
+		t.Errorf("ZonalKey().Location() = %q, want us-central1-b", got)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		wantErr bool
+	}{
+		{"a", false},
+		{"abc-123", false},
+		{strings.Repeat("a", MaxNameLength), false},
+		{"", true},
+		{strings.Repeat("a", MaxNameLength+1), true},
+		{"1abc", true},
+		{"-abc", true},
+		{"abc-", true},
+		{"aBc", true},
+		{"a_b", true},
+		{"a.b", true},
+	} {
+		err := GlobalKey(tc.name).ValidateName()
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("GlobalKey(%q).ValidateName() = %v; gotErr = %t, want %t", tc.name, err, gotErr, tc.wantErr)
+		}
+	}
+}
+
+func TestKeyChecked(t *testing.T) {
+	t.Parallel()
+
+	if k, err := GlobalKeyChecked("abc"); err != nil || *k != *GlobalKey("abc") {
+		t.Errorf("GlobalKeyChecked(abc) = %v, %v; want %v, nil", k, err, GlobalKey("abc"))
+	}
+	if k, err := RegionalKeyChecked("abc", "us-central1"); err != nil || *k != *RegionalKey("abc", "us-central1") {
+		t.Errorf("RegionalKeyChecked(abc, us-central1) = %v, %v; want %v, nil", k, err, RegionalKey("abc", "us-central1"))
+	}
+	if k, err := ZonalKeyChecked("abc", "us-central1-b"); err != nil || *k != *ZonalKey("abc", "us-central1-b") {
+		t.Errorf("ZonalKeyChecked(abc, us-central1-b) = %v, %v; want %v, nil", k, err, ZonalKey("abc", "us-central1-b"))
+	}
+	if _, err := GlobalKeyChecked("Invalid"); err == nil {
+		t.Errorf("GlobalKeyChecked(Invalid) = _, nil; want error")
+	}
+	if _, err := RegionalKeyChecked("abc", "/invalid/"); err == nil {
+		t.Errorf("RegionalKeyChecked(abc, /invalid/) = _, nil; want error")
+	}
+	if _, err := ZonalKeyChecked("abc-", "us-central1-b"); err == nil {
+		t.Errorf("ZonalKeyChecked(abc-, us-central1-b) = _, nil; want error")
+	}
+}
+
+func TestKeyWildcard(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		key, other *Key
+		want       bool
+	}{
+		{AllRegionsKey("abc"), RegionalKey("abc", "us-central1"), true},
+		{AllRegionsKey("abc"), RegionalKey("def", "us-central1"), false},
+		{AllRegionsKey("abc"), ZonalKey("abc", "us-central1-b"), false},
+		{AllZonesKey("abc"), ZonalKey("abc", "us-central1-b"), true},
+		{RegionalKey("abc", "us-central1"), RegionalKey("abc", "us-central1"), true},
+		{RegionalKey("abc", "us-central1"), RegionalKey("abc", "us-east1"), false},
+	} {
+		if got := tc.key.Matches(tc.other); got != tc.want {
+			t.Errorf("%v.Matches(%v) = %t, want %t", tc.key, tc.other, got, tc.want)
+		}
+	}
+	for _, k := range []*Key{AllRegionsKey("abc"), AllZonesKey("abc")} {
+		if !k.IsWildcard() || k.Valid() {
+			t.Errorf("%v: IsWildcard() = %t, Valid() = %t; want true, false", k, k.IsWildcard(), k.Valid())
+		}
+	}
+}
+
+func TestSubKey(t *testing.T) {
+	t.Parallel()
+
+	neg := ZonalKey("neg", "us-central1-b")
+	for _, tc := range []struct {
+		sub    *SubKey
+		wantID string
+	}{
+		{NetworkEndpointSubKey(neg, "vm-1", "10.0.0.1", 80), "vm-1@10.0.0.1:80"},
+		{NetworkEndpointSubKey(neg, "", "10.0.0.1", 80), "10.0.0.1:80"},
+		{InstanceGroupInstanceSubKey(ZonalKey("ig", "us-central1-b"), "vm-1"), "vm-1"},
+		{FirewallPolicyRuleSubKey(GlobalKey("fp"), 1000), "1000"},
+		{SecurityPolicyRuleSubKey(GlobalKey("sp"), 2147483647), "2147483647"},
+	} {
+		if tc.sub.ID != tc.wantID || !tc.sub.Valid() {
+			t.Errorf("%v: ID = %q, Valid() = %t; want %q, true", tc.sub, tc.sub.ID, tc.sub.Valid(), tc.wantID)
+		}
+		flat := tc.sub.Key()
+		if flat.Type() != tc.sub.Parent.Type() {
+			t.Errorf("%v.Key().Type() = %v, want %v", tc.sub, flat.Type(), tc.sub.Parent.Type())
+		}
+		got, err := ParseSubKey(tc.sub.Kind, flat)
+		if err != nil || *got != *tc.sub {
+			t.Errorf("ParseSubKey(%q, %v) = %v, %v; want %v, nil", tc.sub.Kind, flat, got, err, tc.sub)
+		}
+	}
+
+	if NewSubKey(neg, SubKindRules, "a/b").Valid() {
+		t.Errorf("NewSubKey() with / in ID is Valid(), want invalid")
+	}
+	if _, err := ParseSubKey(SubKindRules, GlobalKey("abc")); err == nil {
+		t.Errorf("ParseSubKey(GlobalKey(abc)) = _, nil; want error")
+	}
+}
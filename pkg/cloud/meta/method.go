@@ -138,6 +138,20 @@ func (m *Method) IsGet() bool {
 	return m.kind == MethodGet
 }
 
+// IsObjectUpdate is true if the method is an Update or Patch that takes the
+// object as its only argument.
+func (m *Method) IsObjectUpdate() bool {
+	if m.kind != MethodOperation || (m.Name() != "Update" && m.Name() != "Patch") {
+		return false
+	}
+	fType := m.m.Func.Type()
+	if fType.NumIn() != m.argsSkip()+1 {
+		return false
+	}
+	t := fType.In(m.argsSkip())
+	return t.Kind() == reflect.Pointer && t.Elem().Name() == m.Object
+}
+
 // argsSkip is the number of arguments to skip when generating the
 // synthesized method.
 func (m *Method) argsSkip() int {
@@ -75,4 +75,70 @@ var NetworkServices = []*ServiceInfo{
 			"Patch",
 		},
 	},
+	{
+		Object:      "HttpRoute",
+		Service:     "HttpRoutes",
+		Resource:    "httpRoutes",
+		version:     VersionGA,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&ga.ProjectsLocationsHttpRoutesService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
+	{
+		Object:      "HttpRoute",
+		Service:     "HttpRoutes",
+		Resource:    "httpRoutes",
+		version:     VersionBeta,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&beta.ProjectsLocationsHttpRoutesService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
+	{
+		Object:      "GrpcRoute",
+		Service:     "GrpcRoutes",
+		Resource:    "grpcRoutes",
+		version:     VersionGA,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&ga.ProjectsLocationsGrpcRoutesService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
+	{
+		Object:      "GrpcRoute",
+		Service:     "GrpcRoutes",
+		Resource:    "grpcRoutes",
+		version:     VersionBeta,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&beta.ProjectsLocationsGrpcRoutesService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
+	{
+		Object:      "Gateway",
+		Service:     "Gateways",
+		Resource:    "gateways",
+		version:     VersionGA,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&ga.ProjectsLocationsGatewaysService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
+	{
+		Object:      "Gateway",
+		Service:     "Gateways",
+		Resource:    "gateways",
+		version:     VersionBeta,
+		keyType:     Global,
+		serviceType: reflect.TypeOf(&beta.ProjectsLocationsGatewaysService{}),
+		additionalMethods: []string{
+			"Patch",
+		},
+	},
 }
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ResourceDescriptor describes a resource that is not part of the services
+// defined in this package.
+type ResourceDescriptor struct {
+	// Object is the Go name of the object type. Example: "ForwardingRule".
+	Object string
+	// Service is the Go name of the service. Example: "GlobalForwardingRules".
+	Service string
+	// Resource is the plural noun of the resource in the API URL. Example:
+	// "forwardingRules".
+	Resource string
+	// APIGroup of the resource.
+	APIGroup APIGroup
+	// KeyType is the scope of the resource.
+	KeyType KeyType
+	// Versions of the API the resource is available in.
+	Versions []Version
+	// ServiceTypes are the types of the services of the API client for each
+	// of the Versions. Example: reflect.TypeOf(&ga.ForwardingRulesService{}).
+	ServiceTypes map[Version]reflect.Type
+	// Options for code generation (e.g. AggregatedList, ReadOnly).
+	Options int
+}
+
+// RegisterResource adds the resource to AllServices, one ServiceInfo per
+// version, so that it is handled by ParseSelfLink(), the mock seeding and
+// code generators built on this package. This allows downstream users to add
+// resources without modifying this package.
+//
+// RegisterResource is safe to call concurrently with ParseSelfLink(). Other
+// readers of AllServices are not synchronized, so it should be called from an
+// init() function.
+func RegisterResource(d *ResourceDescriptor) error {
+	serviceLookupLock.Lock()
+	defer serviceLookupLock.Unlock()
+
+	if err := d.validate(); err != nil {
+		return fmt.Errorf("RegisterResource(%q): %w", d.Service, err)
+	}
+	for _, v := range d.Versions {
+		AllServices = append(AllServices, &ServiceInfo{
+			Object:      d.Object,
+			Service:     d.Service,
+			Resource:    d.Resource,
+			APIGroup:    d.APIGroup,
+			version:     v,
+			keyType:     d.KeyType,
+			serviceType: d.ServiceTypes[v],
+			options:     d.Options,
+		})
+	}
+	regroupServices()
+	// Rebuilt by the next lookupService().
+	serviceLookup = nil
+	return nil
+}
+
+func (d *ResourceDescriptor) validate() error {
+	switch {
+	case d.Object == "" || d.Service == "" || d.Resource == "":
+		return fmt.Errorf("Object, Service and Resource must be set")
+	case len(d.Versions) == 0:
+		return fmt.Errorf("no versions")
+	}
+	switch d.APIGroup {
+	case APIGroupCompute, APIGroupNetworkServices:
+	default:
+		return fmt.Errorf("invalid APIGroup %q", d.APIGroup)
+	}
+	switch d.KeyType {
+	case Global, Regional, Zonal:
+	default:
+		return fmt.Errorf("invalid KeyType %q", d.KeyType)
+	}
+
+	seen := map[Version]bool{}
+	for _, v := range d.Versions {
+		switch v {
+		case VersionGA, VersionAlpha, VersionBeta:
+		default:
+			return fmt.Errorf("invalid version %q", v)
+		}
+		if seen[v] {
+			return fmt.Errorf("duplicate version %q", v)
+		}
+		seen[v] = true
+
+		// The service types follow the naming of the API client, e.g.
+		// "ForwardingRules" is served by *ForwardingRulesService.
+		t := d.ServiceTypes[v]
+		switch {
+		case t == nil:
+			return fmt.Errorf("no service type for version %q", v)
+		case t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct:
+			return fmt.Errorf("service type %v for version %q is not a pointer to a struct", t, v)
+		case !strings.HasSuffix(t.Elem().Name(), d.Service+"Service"):
+			return fmt.Errorf("service type %v for version %q is not a %sService", t, v, d.Service)
+		}
+	}
+	if len(d.ServiceTypes) != len(d.Versions) {
+		return fmt.Errorf("ServiceTypes has versions not in Versions")
+	}
+
+	if _, ok := AllServicesByGroup[d.Service]; ok {
+		return fmt.Errorf("service already exists")
+	}
+	for _, s := range AllServices {
+		if s.APIGroup == d.APIGroup && s.Resource == d.Resource && s.keyType == d.KeyType {
+			return fmt.Errorf("%s resource %q already exists as %q", d.KeyType, d.Resource, s.Service)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+// The API client services of the test resource.
+type (
+	WidgetsService     struct{}
+	BetaWidgetsService struct{}
+	Widgets2Service    struct{}
+)
+
+// TestRegisterResource is not parallel as it modifies AllServices.
+func TestRegisterResource(t *testing.T) {
+	saved := AllServices
+	t.Cleanup(func() {
+		AllServices = saved
+		regroupServices()
+	})
+
+	d := &ResourceDescriptor{
+		Object:   "Widget",
+		Service:  "Widgets",
+		Resource: "widgets",
+		APIGroup: APIGroupCompute,
+		KeyType:  Regional,
+		Versions: []Version{VersionGA, VersionBeta},
+		ServiceTypes: map[Version]reflect.Type{
+			VersionGA:   reflect.TypeOf(&WidgetsService{}),
+			VersionBeta: reflect.TypeOf(&BetaWidgetsService{}),
+		},
+		Options: ReadOnly,
+	}
+	if err := RegisterResource(d); err != nil {
+		t.Fatalf("RegisterResource() = %v", err)
+	}
+
+	sg, ok := AllServicesByGroup["Widgets"]
+	if !ok || !sg.HasGA() || !sg.HasBeta() || sg.HasAlpha() {
+		t.Errorf("AllServicesByGroup[Widgets] = %+v, %t; want GA and Beta", sg, ok)
+	}
+	if ok && !sg.ServiceInfo().KeyIsRegional() {
+		t.Errorf("KeyIsRegional() = false, want true")
+	}
+	for _, si := range AllServices {
+		if si.Service != "Widgets" {
+			continue
+		}
+		if want := d.ServiceTypes[si.Version()]; si.serviceType != want {
+			t.Errorf("%s serviceType = %v, want %v", si.Version(), si.serviceType, want)
+		}
+		// Methods() inspects the service type.
+		if got := si.Methods(); len(got) != 0 {
+			t.Errorf("%s Methods() = %v, want none", si.Version(), got)
+		}
+	}
+	service, _, _, key, err := ParseSelfLink("projects/p/regions/us-central1/widgets/w")
+	if err != nil || service != "Widgets" || *key != *RegionalKey("w", "us-central1") {
+		t.Errorf("ParseSelfLink() = %q, _, _, %v, %v; want Widgets, %v, nil", service, key, err, RegionalKey("w", "us-central1"))
+	}
+
+	for _, tc := range []struct {
+		desc string
+		d    ResourceDescriptor
+	}{
+		{"duplicate", *d},
+		{"existing resource", ResourceDescriptor{Object: "A", Service: "As", Resource: "addresses", APIGroup: APIGroupCompute, KeyType: Regional, Versions: []Version{VersionGA}}},
+		{"missing fields", ResourceDescriptor{Service: "Bs", APIGroup: APIGroupCompute, KeyType: Global, Versions: []Version{VersionGA}}},
+		{"no versions", ResourceDescriptor{Object: "B", Service: "Bs", Resource: "bs", APIGroup: APIGroupCompute, KeyType: Global}},
+		{"invalid version", ResourceDescriptor{Object: "B", Service: "Bs", Resource: "bs", APIGroup: APIGroupCompute, KeyType: Global, Versions: []Version{"v2"}}},
+		{"invalid api group", ResourceDescriptor{Object: "B", Service: "Bs", Resource: "bs", APIGroup: "foo", KeyType: Global, Versions: []Version{VersionGA}}},
+		{"invalid key type", ResourceDescriptor{Object: "B", Service: "Bs", Resource: "bs", APIGroup: APIGroupCompute, KeyType: "moon", Versions: []Version{VersionGA}}},
+		{"no service type", ResourceDescriptor{Object: "Widget", Service: "Widgets2", Resource: "widgets2", APIGroup: APIGroupCompute, KeyType: Global, Versions: []Version{VersionGA}}},
+		{"wrong service type", ResourceDescriptor{Object: "Widget", Service: "Widgets2", Resource: "widgets2", APIGroup: APIGroupCompute, KeyType: Global, Versions: []Version{VersionGA}, ServiceTypes: map[Version]reflect.Type{VersionGA: reflect.TypeOf(&WidgetsService{})}}},
+		{"service type not a pointer", ResourceDescriptor{Object: "Widget", Service: "Widgets2", Resource: "widgets2", APIGroup: APIGroupCompute, KeyType: Global, Versions: []Version{VersionGA}, ServiceTypes: map[Version]reflect.Type{VersionGA: reflect.TypeOf(Widgets2Service{})}}},
+		{"extra service type", ResourceDescriptor{Object: "Widget", Service: "Widgets2", Resource: "widgets2", APIGroup: APIGroupCompute, KeyType: Global, Versions: []Version{VersionGA}, ServiceTypes: map[Version]reflect.Type{VersionGA: reflect.TypeOf(&Widgets2Service{}), VersionBeta: reflect.TypeOf(&Widgets2Service{})}}},
+	} {
+		if err := RegisterResource(&tc.d); err == nil {
+			t.Errorf("%s: RegisterResource() = nil, want error", tc.desc)
+		}
+	}
+}
+
+// TestRegisterResourceConcurrentParse is run with -race to check that
+// RegisterResource() and ParseSelfLink() are synchronized.
+func TestRegisterResourceConcurrentParse(t *testing.T) {
+	saved := AllServices
+	t.Cleanup(func() {
+		AllServices = saved
+		regroupServices()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			ParseSelfLink("projects/p/global/backendServices/b")
+		}
+	}()
+	err := RegisterResource(&ResourceDescriptor{
+		Object:       "Widget",
+		Service:      "Widgets",
+		Resource:     "widgets",
+		APIGroup:     APIGroupCompute,
+		KeyType:      Global,
+		Versions:     []Version{VersionGA},
+		ServiceTypes: map[Version]reflect.Type{VersionGA: reflect.TypeOf(&WidgetsService{})},
+	})
+	<-done
+	if err != nil {
+		t.Fatalf("RegisterResource() = %v", err)
+	}
+	if _, _, _, _, err := ParseSelfLink("projects/p/global/widgets/w"); err != nil {
+		t.Errorf("ParseSelfLink() = %v, want nil", err)
+	}
+}
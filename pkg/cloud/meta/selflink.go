@@ -0,0 +1,163 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// urlVersions maps the version in a resource URL to the API version.
+var urlVersions = map[string]Version{
+	"v1":       VersionGA,
+	"beta":     VersionBeta,
+	"alpha":    VersionAlpha,
+	"v1beta1":  VersionBeta,
+	"v1alpha1": VersionAlpha,
+}
+
+type serviceLookupKey struct {
+	apiGroup APIGroup
+	resource string
+	keyType  KeyType
+}
+
+var (
+	// serviceLookupLock guards serviceLookup and the writes to AllServices
+	// in RegisterResource().
+	serviceLookupLock sync.Mutex
+	// serviceLookup maps the resource and key type to the Service name. It
+	// is rebuilt when AllServices changes size (see RegisterResource).
+	serviceLookup map[serviceLookupKey]string
+	// serviceLookupN is the length of AllServices when serviceLookup was
+	// built.
+	serviceLookupN int
+)
+
+func lookupService(apiGroup APIGroup, resource string, keyType KeyType) (string, bool) {
+	serviceLookupLock.Lock()
+	defer serviceLookupLock.Unlock()
+
+	if serviceLookup == nil || serviceLookupN != len(AllServices) {
+		serviceLookup = map[serviceLookupKey]string{}
+		for _, s := range AllServices {
+			serviceLookup[serviceLookupKey{s.APIGroup, s.Resource, s.keyType}] = s.Service
+		}
+		serviceLookupN = len(AllServices)
+	}
+	if apiGroup != "" {
+		s, ok := serviceLookup[serviceLookupKey{apiGroup, resource, keyType}]
+		return s, ok
+	}
+	for _, g := range []APIGroup{APIGroupCompute, APIGroupNetworkServices} {
+		if s, ok := serviceLookup[serviceLookupKey{g, resource, keyType}]; ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// ParseSelfLink parses a resource URL, returning the Service (as in
+// ServiceInfo.Service, e.g. "RegionBackendServices"), the API version, the
+// project and the key of the resource. The following forms are supported
+// for all versions (v1, beta, alpha, v1beta1, v1alpha1) of the compute and
+// networkservices APIs:
+//
+//	https://compute.googleapis.com/compute/v1/projects/<proj>/global/<res>/<name>
+//	https://www.googleapis.com/compute/beta/projects/<proj>/regions/<region>/<res>/<name>
+//	https://networkservices.googleapis.com/v1/projects/<proj>/locations/<location>/<res>/<name>
+//	projects/<proj>/zones/<zone>/<res>/<name>
+//	projects/<proj>/regions/<region>
+//	projects/<proj>
+//
+// version is empty for partial URLs that do not include the version. Keys
+// for location-scoped resources are built with LocationKey().
+func ParseSelfLink(url string) (service string, version Version, projectID string, key *Key, err error) {
+	errNotValid := fmt.Errorf("ParseSelfLink: %q is not a valid resource URL", url)
+
+	var prefix, path string
+	switch i := strings.Index(url, "/projects/"); {
+	case i >= 0:
+		prefix, path = url[:i], url[i+1:]
+	case strings.HasPrefix(url, "projects/"):
+		path = url
+	default:
+		return "", "", "", nil, errNotValid
+	}
+
+	var apiGroup APIGroup
+	if prefix != "" {
+		// prefix is "<scheme>://<host>[/<apigroup>]/<version>".
+		i := strings.LastIndex(prefix, "/")
+		v, ok := urlVersions[prefix[i+1:]]
+		if !ok {
+			return "", "", "", nil, fmt.Errorf("ParseSelfLink: unknown version in %q", url)
+		}
+		version = v
+		switch {
+		case strings.HasSuffix(prefix[:i], "/compute"), strings.Contains(prefix, "compute.googleapis.com"):
+			apiGroup = APIGroupCompute
+		case strings.HasSuffix(prefix[:i], "/networkservices"), strings.Contains(prefix, "networkservices.googleapis.com"):
+			apiGroup = APIGroupNetworkServices
+		}
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[1] == "" {
+		return "", "", "", nil, errNotValid
+	}
+	projectID = parts[1]
+	parts = parts[2:]
+
+	var resource string
+	switch {
+	case len(parts) == 0:
+		return "Projects", version, projectID, GlobalKey(projectID), nil
+	case len(parts) == 2 && (parts[0] == "regions" || parts[0] == "zones"):
+		// The Region or Zone itself.
+		resource, key = parts[0], GlobalKey(parts[1])
+	case len(parts) == 3 && parts[0] == "global":
+		resource, key = parts[1], GlobalKey(parts[2])
+	case len(parts) == 4 && parts[0] == "locations":
+		resource, key = parts[2], LocationKey(parts[3], parts[1])
+		if apiGroup == "" {
+			apiGroup = APIGroupNetworkServices
+		}
+	case len(parts) == 4 && parts[0] == "regions":
+		resource, key = parts[2], RegionalKey(parts[3], parts[1])
+	case len(parts) == 4 && parts[0] == "zones":
+		resource, key = parts[2], ZonalKey(parts[3], parts[1])
+	default:
+		return "", "", "", nil, errNotValid
+	}
+	if key.Name == "" || !key.Valid() {
+		return "", "", "", nil, errNotValid
+	}
+
+	keyType := key.Type()
+	if parts[0] == "locations" {
+		// Location-scoped services are registered with a Global key type
+		// regardless of the location.
+		keyType = Global
+	}
+	service, ok := lookupService(apiGroup, resource, keyType)
+	if !ok {
+		return "", "", "", nil, fmt.Errorf("ParseSelfLink: unknown %s resource %q in %q", key.Type(), resource, url)
+	}
+	return service, version, projectID, key, nil
+}
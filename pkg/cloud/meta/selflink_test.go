@@ -0,0 +1,100 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"testing"
+)
+
+func TestParseSelfLink(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		url         string
+		wantService string
+		wantVersion Version
+		wantKey     *Key
+		wantErr     bool
+	}{
+		{
+			url:         "https://www.googleapis.com/compute/v1/projects/proj/global/backendServices/bs",
+			wantService: "BackendServices", wantVersion: VersionGA, wantKey: GlobalKey("bs"),
+		},
+		{
+			url:         "https://compute.googleapis.com/compute/beta/projects/proj/regions/us-central1/backendServices/bs",
+			wantService: "RegionBackendServices", wantVersion: VersionBeta, wantKey: RegionalKey("bs", "us-central1"),
+		},
+		{
+			url:         "https://www.googleapis.com/compute/alpha/projects/proj/zones/us-central1-b/instances/vm",
+			wantService: "Instances", wantVersion: VersionAlpha, wantKey: ZonalKey("vm", "us-central1-b"),
+		},
+		{
+			url:         "projects/proj/global/addresses/addr",
+			wantService: "GlobalAddresses", wantKey: GlobalKey("addr"),
+		},
+		{
+			url:         "projects/proj/regions/us-central1/addresses/addr",
+			wantService: "Addresses", wantKey: RegionalKey("addr", "us-central1"),
+		},
+		{
+			url:         "https://www.googleapis.com/compute/v1/projects/proj/regions/us-central1",
+			wantService: "Regions", wantVersion: VersionGA, wantKey: GlobalKey("us-central1"),
+		},
+		{
+			url:         "projects/proj/zones/us-central1-b",
+			wantService: "Zones", wantKey: GlobalKey("us-central1-b"),
+		},
+		{
+			url:         "https://www.googleapis.com/compute/v1/projects/proj",
+			wantService: "Projects", wantVersion: VersionGA, wantKey: GlobalKey("proj"),
+		},
+		{
+			url:         "https://networkservices.googleapis.com/v1beta1/projects/proj/locations/global/meshes/m",
+			wantService: "Meshes", wantVersion: VersionBeta, wantKey: GlobalKey("m"),
+		},
+		{
+			url:         "projects/proj/locations/global/tcpRoutes/r",
+			wantService: "TcpRoutes", wantKey: GlobalKey("r"),
+		},
+		{
+			url:         "projects/proj/locations/us-central1/meshes/m",
+			wantService: "Meshes", wantKey: RegionalKey("m", "us-central1"),
+		},
+		{url: "", wantErr: true},
+		{url: "global/backendServices/bs", wantErr: true},
+		{url: "projects/", wantErr: true},
+		{url: "https://www.googleapis.com/compute/v2/projects/proj/global/backendServices/bs", wantErr: true},
+		{url: "projects/proj/global/unknownResources/x", wantErr: true},
+		{url: "projects/proj/moon/backendServices/bs", wantErr: true},
+		{url: "projects/proj/global/backendServices/", wantErr: true},
+		{url: "projects/proj/global/backendServices/bs/extra", wantErr: true},
+		{url: "projects/proj/regions/us-central1/instances/vm", wantErr: true},
+	} {
+		service, version, projectID, key, err := ParseSelfLink(tc.url)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("ParseSelfLink(%q) = _, _, _, _, %v; gotErr = %t, want %t", tc.url, err, gotErr, tc.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if service != tc.wantService || version != tc.wantVersion || projectID != "proj" || *key != *tc.wantKey {
+			t.Errorf("ParseSelfLink(%q) = %q, %q, %q, %+v, nil; want %q, %q, %q, %+v, nil",
+				tc.url, service, version, projectID, key, tc.wantService, tc.wantVersion, "proj", tc.wantKey)
+		}
+	}
+}
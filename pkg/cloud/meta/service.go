@@ -117,17 +117,14 @@ func (i *ServiceInfo) ListItemName() string {
 	return "Items"
 }
 
+// NetworkServicesFmt is the format string for the resource name of a
+// networkservices resource. The arguments are the project, the location (see
+// meta.Key.Location()) and the name.
 func (i *ServiceInfo) NetworkServicesFmt() string {
-	var scope string
-	switch i.keyType {
-	case Global:
-		scope = "global"
-	}
-
 	runes := []rune(i.Service)
 	serviceLower := append([]rune{unicode.ToLower(runes[0])}, runes[1:]...)
 
-	return `projects/%s/locations/` + scope + `/` + string(serviceLower) + `/%s`
+	return `projects/%s/locations/%s/` + string(serviceLower) + `/%s`
 }
 
 // ObjectAggregatedListType is the compute List type for the object (contains Items field).
@@ -351,8 +348,15 @@ var AllServicesByGroup map[string]*ServiceGroup
 var SortedServicesGroups []*ServiceGroup
 
 func init() {
+	regroupServices()
+}
+
+// regroupServices updates AllServicesByGroup and SortedServicesGroups from
+// AllServices.
+func regroupServices() {
 	AllServicesByGroup = groupServices(AllServices)
 
+	SortedServicesGroups = nil
 	for _, sg := range AllServicesByGroup {
 		SortedServicesGroups = append(SortedServicesGroups, sg)
 	}
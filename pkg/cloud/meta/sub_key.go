@@ -0,0 +1,113 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SubKindNetworkEndpoints are the endpoints of a NetworkEndpointGroup.
+	SubKindNetworkEndpoints = "networkEndpoints"
+	// SubKindInstances are the instances of an InstanceGroup.
+	SubKindInstances = "instances"
+	// SubKindRules are the rules of a FirewallPolicy or SecurityPolicy, by
+	// priority.
+	SubKindRules = "rules"
+)
+
+// subKeySep separates the parent name and the ID in the flattened Key.
+const subKeySep = "/"
+
+// SubKey addresses an entity nested in a resource that does not have its own
+// API resource, e.g. a network endpoint in a NetworkEndpointGroup. This
+// allows attaching and detaching these entities to be modelled in the same
+// way as resources.
+type SubKey struct {
+	// Parent is the key of the resource containing the entity.
+	Parent Key
+	// Kind of the entity (e.g. SubKindNetworkEndpoints).
+	Kind string
+	// ID of the entity within the parent. It must not contain "/".
+	ID string
+}
+
+// NewSubKey returns the key for the entity of the given kind and ID in
+// parent.
+func NewSubKey(parent *Key, kind, id string) *SubKey {
+	return &SubKey{Parent: *parent, Kind: kind, ID: id}
+}
+
+// NetworkEndpointSubKey returns the key for a network endpoint in the NEG.
+// instance is empty for endpoints that are not VMs.
+func NetworkEndpointSubKey(neg *Key, instance, ip string, port int64) *SubKey {
+	id := fmt.Sprintf("%s:%d", ip, port)
+	if instance != "" {
+		id = instance + "@" + id
+	}
+	return NewSubKey(neg, SubKindNetworkEndpoints, id)
+}
+
+// InstanceGroupInstanceSubKey returns the key for an instance in the
+// instance group.
+func InstanceGroupInstanceSubKey(ig *Key, instance string) *SubKey {
+	return NewSubKey(ig, SubKindInstances, instance)
+}
+
+// FirewallPolicyRuleSubKey returns the key for the rule with the given
+// priority in the firewall policy.
+func FirewallPolicyRuleSubKey(policy *Key, priority int64) *SubKey {
+	return NewSubKey(policy, SubKindRules, strconv.FormatInt(priority, 10))
+}
+
+// SecurityPolicyRuleSubKey returns the key for the rule with the given
+// priority in the security policy.
+func SecurityPolicyRuleSubKey(policy *Key, priority int64) *SubKey {
+	return NewSubKey(policy, SubKindRules, strconv.FormatInt(priority, 10))
+}
+
+// Valid is true if the key is valid.
+func (k *SubKey) Valid() bool {
+	return k.Parent.Valid() && k.Kind != "" && k.ID != "" && !strings.Contains(k.ID, subKeySep)
+}
+
+// Key returns the key flattened into a Key with the same scope as the parent
+// and the name "<parent name>/<ID>". The flattened key can be used where a
+// Key is needed to identify the entity (e.g. maps) but not in API calls.
+func (k *SubKey) Key() *Key {
+	ret := k.Parent
+	ret.Name = k.Parent.Name + subKeySep + k.ID
+	return &ret
+}
+
+// String returns a string representation of the key.
+func (k SubKey) String() string {
+	return fmt.Sprintf("SubKey{%v, %s: %q}", k.Parent, k.Kind, k.ID)
+}
+
+// ParseSubKey is the inverse of SubKey.Key().
+func ParseSubKey(kind string, k *Key) (*SubKey, error) {
+	i := strings.Index(k.Name, subKeySep)
+	if i <= 0 || i == len(k.Name)-1 {
+		return nil, fmt.Errorf("ParseSubKey: %v is not a sub-resource key", k)
+	}
+	parent := *k
+	parent.Name = k.Name[:i]
+	return NewSubKey(&parent, kind, k.Name[i+1:]), nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+// GlobalScope marks a TypedKey for a global resource.
+type GlobalScope struct{}
+
+// RegionalScope marks a TypedKey for a regional resource.
+type RegionalScope struct{}
+
+// ZonalScope marks a TypedKey for a zonal resource.
+type ZonalScope struct{}
+
+// Scope is the scope of a TypedKey.
+type Scope interface {
+	GlobalScope | RegionalScope | ZonalScope
+}
+
+// TypedKey is a Key bound to the Go type T of the resource (e.g.
+// compute.BackendService) and its scope S. Using a TypedKey with a service
+// for a different type or scope (e.g. a regional key with the global
+// BackendServices) is a compile time error. See cloud.TypedService.
+type TypedKey[T any, S Scope] struct {
+	key Key
+}
+
+// TypedGlobalKey returns the typed key for a global resource.
+func TypedGlobalKey[T any](name string) TypedKey[T, GlobalScope] {
+	return TypedKey[T, GlobalScope]{*GlobalKey(name)}
+}
+
+// TypedRegionalKey returns the typed key for a regional resource.
+func TypedRegionalKey[T any](name, region string) TypedKey[T, RegionalScope] {
+	return TypedKey[T, RegionalScope]{*RegionalKey(name, region)}
+}
+
+// TypedZonalKey returns the typed key for a zonal resource.
+func TypedZonalKey[T any](name, zone string) TypedKey[T, ZonalScope] {
+	return TypedKey[T, ZonalScope]{*ZonalKey(name, zone)}
+}
+
+// Key returns a copy of the untyped key.
+func (k TypedKey[T, S]) Key() *Key {
+	ret := k.key
+	return &ret
+}
+
+// String returns a string representation of the key.
+func (k TypedKey[T, S]) String() string {
+	return k.key.String()
+}
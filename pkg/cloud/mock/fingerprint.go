@@ -0,0 +1,109 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+)
+
+// The hooks installed by EnableFingerprints are generated into
+// fingerprint_gen.go by "go run gen/main.go -mode fingerprint". This file has
+// the helpers that they share.
+
+// insertingCopyKey marks the context of the Insert of the copy made by the
+// Insert hooks, so that the hooks do not modify the object of the caller.
+type insertingCopyKey struct{}
+
+// fingerprint computes a fingerprint from the contents of obj. The value of
+// the "fingerprint" field of obj itself is ignored.
+func fingerprint(obj interface{}) (string, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("fingerprint(%T): %w", obj, err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return "", fmt.Errorf("fingerprint(%T): %w", obj, err)
+	}
+	delete(fields, "fingerprint")
+	// json.Marshal sorts map keys, so the output is stable.
+	if b, err = json.Marshal(fields); err != nil {
+		return "", fmt.Errorf("fingerprint(%T): %w", obj, err)
+	}
+	sum := sha256.Sum256(b)
+	return base64.StdEncoding.EncodeToString(sum[:8]), nil
+}
+
+// checkFingerprint returns the error returned by GCE when the fingerprint in
+// the update does not match the current fingerprint of the resource.
+func checkFingerprint(current, update string) error {
+	if current == update {
+		return nil
+	}
+	return &googleapi.Error{
+		Code:    http.StatusPreconditionFailed,
+		Message: "Invalid fingerprint.",
+		Errors:  []googleapi.ErrorItem{{Reason: "conditionNotMet", Message: "Invalid fingerprint."}},
+	}
+}
+
+// notFoundError is the error returned when updating a missing object.
+func notFoundError(mockType string, key *meta.Key) error {
+	return &googleapi.Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("%s %v not found", mockType, key),
+	}
+}
+
+// copyViaJSON copies src to dest, which may be of a different API version.
+func copyViaJSON(dest, src interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dest)
+}
+
+// patchViaJSON sets dest to the result of applying the non-empty fields of
+// patch to obj.
+func patchViaJSON(dest, obj, patch interface{}) error {
+	fields := map[string]interface{}{}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	if b, err = json.Marshal(patch); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	if b, err = json.Marshal(fields); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dest)
+}
@@ -0,0 +1,1219 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run gen/main.go -mode fingerprint > mock/fingerprint_gen.go".
+// Do not edit directly.
+
+package mock
+
+import (
+	"context"
+
+	cloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	ga "google.golang.org/api/compute/v1"
+)
+
+// EnableFingerprints installs the hooks in mockGCE that track fingerprints.
+// Updates to these resources must supply the current fingerprint of the
+// resource and fail with http.StatusPreconditionFailed otherwise, as they do
+// in GCE.
+func EnableFingerprints(mockGCE *cloud.MockGCE) {
+	mockGCE.MockUrlMaps.InsertHook = InsertURLMapWithFingerprintHook
+	mockGCE.MockUrlMaps.UpdateHook = UpdateURLMapWithFingerprintHook
+	mockGCE.MockAlphaUrlMaps.InsertHook = InsertAlphaURLMapWithFingerprintHook
+	mockGCE.MockAlphaUrlMaps.UpdateHook = UpdateAlphaURLMapWithFingerprintHook
+	mockGCE.MockBetaUrlMaps.InsertHook = InsertBetaURLMapWithFingerprintHook
+	mockGCE.MockBetaUrlMaps.UpdateHook = UpdateBetaURLMapWithFingerprintHook
+	mockGCE.MockRegionUrlMaps.InsertHook = InsertRegionURLMapWithFingerprintHook
+	mockGCE.MockRegionUrlMaps.UpdateHook = UpdateRegionURLMapWithFingerprintHook
+	mockGCE.MockAlphaRegionUrlMaps.InsertHook = InsertAlphaRegionURLMapWithFingerprintHook
+	mockGCE.MockAlphaRegionUrlMaps.UpdateHook = UpdateAlphaRegionURLMapWithFingerprintHook
+	mockGCE.MockBetaRegionUrlMaps.InsertHook = InsertBetaRegionURLMapWithFingerprintHook
+	mockGCE.MockBetaRegionUrlMaps.UpdateHook = UpdateBetaRegionURLMapWithFingerprintHook
+	mockGCE.MockSubnetworks.InsertHook = InsertSubnetworkWithFingerprintHook
+	mockGCE.MockSubnetworks.PatchHook = PatchSubnetworkWithFingerprintHook
+	mockGCE.MockAlphaSubnetworks.InsertHook = InsertAlphaSubnetworkWithFingerprintHook
+	mockGCE.MockAlphaSubnetworks.PatchHook = PatchAlphaSubnetworkWithFingerprintHook
+	mockGCE.MockBetaSubnetworks.InsertHook = InsertBetaSubnetworkWithFingerprintHook
+	mockGCE.MockBetaSubnetworks.PatchHook = PatchBetaSubnetworkWithFingerprintHook
+	mockGCE.MockInstances.InsertHook = InsertInstanceWithFingerprintHook
+	mockGCE.MockInstances.SetMetadataHook = SetInstanceMetadataWithFingerprintHook
+	mockGCE.MockAlphaInstances.InsertHook = InsertAlphaInstanceWithFingerprintHook
+	mockGCE.MockAlphaInstances.SetMetadataHook = SetAlphaInstanceMetadataWithFingerprintHook
+	mockGCE.MockBetaInstances.InsertHook = InsertBetaInstanceWithFingerprintHook
+	mockGCE.MockBetaInstances.SetMetadataHook = SetBetaInstanceMetadataWithFingerprintHook
+	mockGCE.MockForwardingRules.InsertHook = InsertForwardingRuleWithFingerprintHook
+	mockGCE.MockForwardingRules.PatchHook = PatchForwardingRuleWithFingerprintHook
+	mockGCE.MockForwardingRules.SetTargetHook = SetForwardingRuleTargetWithFingerprintHook
+	mockGCE.MockForwardingRules.SetLabelsHook = SetForwardingRuleLabelsWithFingerprintHook
+	mockGCE.MockAlphaForwardingRules.InsertHook = InsertAlphaForwardingRuleWithFingerprintHook
+	mockGCE.MockAlphaForwardingRules.PatchHook = PatchAlphaForwardingRuleWithFingerprintHook
+	mockGCE.MockAlphaForwardingRules.SetTargetHook = SetAlphaForwardingRuleTargetWithFingerprintHook
+	mockGCE.MockAlphaForwardingRules.SetLabelsHook = SetAlphaForwardingRuleLabelsWithFingerprintHook
+	mockGCE.MockBetaForwardingRules.InsertHook = InsertBetaForwardingRuleWithFingerprintHook
+	mockGCE.MockBetaForwardingRules.PatchHook = PatchBetaForwardingRuleWithFingerprintHook
+	mockGCE.MockBetaForwardingRules.SetTargetHook = SetBetaForwardingRuleTargetWithFingerprintHook
+	mockGCE.MockBetaForwardingRules.SetLabelsHook = SetBetaForwardingRuleLabelsWithFingerprintHook
+}
+
+// InsertURLMapWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockUrlMaps.
+func InsertURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.UrlMap, m *cloud.MockUrlMaps, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &ga.UrlMap{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertURLMapWithFingerprintHook implements MockUrlMaps.InsertHook.
+var _ = cloud.MockUrlMaps{
+	InsertHook: InsertURLMapWithFingerprintHook,
+}
+
+// UpdateURLMapWithFingerprintHook replaces the stored object with a copy of obj. The
+// update fails if obj does not have the current fingerprint of the object.
+func UpdateURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.UrlMap, m *cloud.MockUrlMaps, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockUrlMaps", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	stored := &ga.UrlMap{}
+	if err := copyViaJSON(stored, obj); err != nil {
+		return err
+	}
+	stored.Name = key.Name
+	projectID := m.ProjectRouter.ProjectID(ctx, meta.VersionGA, "urlMaps")
+	stored.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "urlMaps", key)
+	fp, err := fingerprint(stored)
+	if err != nil {
+		return err
+	}
+	stored.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockUrlMapsObj{Obj: stored}
+	return nil
+}
+
+// Verify UpdateURLMapWithFingerprintHook implements MockUrlMaps.UpdateHook.
+var _ = cloud.MockUrlMaps{
+	UpdateHook: UpdateURLMapWithFingerprintHook,
+}
+
+// InsertAlphaURLMapWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockAlphaUrlMaps.
+func InsertAlphaURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.UrlMap, m *cloud.MockAlphaUrlMaps, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &alpha.UrlMap{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertAlphaURLMapWithFingerprintHook implements MockAlphaUrlMaps.InsertHook.
+var _ = cloud.MockAlphaUrlMaps{
+	InsertHook: InsertAlphaURLMapWithFingerprintHook,
+}
+
+// UpdateAlphaURLMapWithFingerprintHook replaces the stored object with a copy of obj. The
+// update fails if obj does not have the current fingerprint of the object.
+func UpdateAlphaURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.UrlMap, m *cloud.MockAlphaUrlMaps, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockAlphaUrlMaps", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	stored := &alpha.UrlMap{}
+	if err := copyViaJSON(stored, obj); err != nil {
+		return err
+	}
+	stored.Name = key.Name
+	projectID := m.ProjectRouter.ProjectID(ctx, meta.VersionAlpha, "urlMaps")
+	stored.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "urlMaps", key)
+	fp, err := fingerprint(stored)
+	if err != nil {
+		return err
+	}
+	stored.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockUrlMapsObj{Obj: stored}
+	return nil
+}
+
+// Verify UpdateAlphaURLMapWithFingerprintHook implements MockAlphaUrlMaps.UpdateHook.
+var _ = cloud.MockAlphaUrlMaps{
+	UpdateHook: UpdateAlphaURLMapWithFingerprintHook,
+}
+
+// InsertBetaURLMapWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockBetaUrlMaps.
+func InsertBetaURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.UrlMap, m *cloud.MockBetaUrlMaps, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &beta.UrlMap{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertBetaURLMapWithFingerprintHook implements MockBetaUrlMaps.InsertHook.
+var _ = cloud.MockBetaUrlMaps{
+	InsertHook: InsertBetaURLMapWithFingerprintHook,
+}
+
+// UpdateBetaURLMapWithFingerprintHook replaces the stored object with a copy of obj. The
+// update fails if obj does not have the current fingerprint of the object.
+func UpdateBetaURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.UrlMap, m *cloud.MockBetaUrlMaps, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockBetaUrlMaps", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	stored := &beta.UrlMap{}
+	if err := copyViaJSON(stored, obj); err != nil {
+		return err
+	}
+	stored.Name = key.Name
+	projectID := m.ProjectRouter.ProjectID(ctx, meta.VersionBeta, "urlMaps")
+	stored.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionBeta, projectID, "urlMaps", key)
+	fp, err := fingerprint(stored)
+	if err != nil {
+		return err
+	}
+	stored.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockUrlMapsObj{Obj: stored}
+	return nil
+}
+
+// Verify UpdateBetaURLMapWithFingerprintHook implements MockBetaUrlMaps.UpdateHook.
+var _ = cloud.MockBetaUrlMaps{
+	UpdateHook: UpdateBetaURLMapWithFingerprintHook,
+}
+
+// InsertRegionURLMapWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockRegionUrlMaps.
+func InsertRegionURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.UrlMap, m *cloud.MockRegionUrlMaps, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &ga.UrlMap{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertRegionURLMapWithFingerprintHook implements MockRegionUrlMaps.InsertHook.
+var _ = cloud.MockRegionUrlMaps{
+	InsertHook: InsertRegionURLMapWithFingerprintHook,
+}
+
+// UpdateRegionURLMapWithFingerprintHook replaces the stored object with a copy of obj. The
+// update fails if obj does not have the current fingerprint of the object.
+func UpdateRegionURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.UrlMap, m *cloud.MockRegionUrlMaps, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockRegionUrlMaps", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	stored := &ga.UrlMap{}
+	if err := copyViaJSON(stored, obj); err != nil {
+		return err
+	}
+	stored.Name = key.Name
+	projectID := m.ProjectRouter.ProjectID(ctx, meta.VersionGA, "urlMaps")
+	stored.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "urlMaps", key)
+	fp, err := fingerprint(stored)
+	if err != nil {
+		return err
+	}
+	stored.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockRegionUrlMapsObj{Obj: stored}
+	return nil
+}
+
+// Verify UpdateRegionURLMapWithFingerprintHook implements MockRegionUrlMaps.UpdateHook.
+var _ = cloud.MockRegionUrlMaps{
+	UpdateHook: UpdateRegionURLMapWithFingerprintHook,
+}
+
+// InsertAlphaRegionURLMapWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockAlphaRegionUrlMaps.
+func InsertAlphaRegionURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.UrlMap, m *cloud.MockAlphaRegionUrlMaps, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &alpha.UrlMap{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertAlphaRegionURLMapWithFingerprintHook implements MockAlphaRegionUrlMaps.InsertHook.
+var _ = cloud.MockAlphaRegionUrlMaps{
+	InsertHook: InsertAlphaRegionURLMapWithFingerprintHook,
+}
+
+// UpdateAlphaRegionURLMapWithFingerprintHook replaces the stored object with a copy of obj. The
+// update fails if obj does not have the current fingerprint of the object.
+func UpdateAlphaRegionURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.UrlMap, m *cloud.MockAlphaRegionUrlMaps, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockAlphaRegionUrlMaps", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	stored := &alpha.UrlMap{}
+	if err := copyViaJSON(stored, obj); err != nil {
+		return err
+	}
+	stored.Name = key.Name
+	projectID := m.ProjectRouter.ProjectID(ctx, meta.VersionAlpha, "urlMaps")
+	stored.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "urlMaps", key)
+	fp, err := fingerprint(stored)
+	if err != nil {
+		return err
+	}
+	stored.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockRegionUrlMapsObj{Obj: stored}
+	return nil
+}
+
+// Verify UpdateAlphaRegionURLMapWithFingerprintHook implements MockAlphaRegionUrlMaps.UpdateHook.
+var _ = cloud.MockAlphaRegionUrlMaps{
+	UpdateHook: UpdateAlphaRegionURLMapWithFingerprintHook,
+}
+
+// InsertBetaRegionURLMapWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockBetaRegionUrlMaps.
+func InsertBetaRegionURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.UrlMap, m *cloud.MockBetaRegionUrlMaps, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &beta.UrlMap{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertBetaRegionURLMapWithFingerprintHook implements MockBetaRegionUrlMaps.InsertHook.
+var _ = cloud.MockBetaRegionUrlMaps{
+	InsertHook: InsertBetaRegionURLMapWithFingerprintHook,
+}
+
+// UpdateBetaRegionURLMapWithFingerprintHook replaces the stored object with a copy of obj. The
+// update fails if obj does not have the current fingerprint of the object.
+func UpdateBetaRegionURLMapWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.UrlMap, m *cloud.MockBetaRegionUrlMaps, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockBetaRegionUrlMaps", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	stored := &beta.UrlMap{}
+	if err := copyViaJSON(stored, obj); err != nil {
+		return err
+	}
+	stored.Name = key.Name
+	projectID := m.ProjectRouter.ProjectID(ctx, meta.VersionBeta, "urlMaps")
+	stored.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionBeta, projectID, "urlMaps", key)
+	fp, err := fingerprint(stored)
+	if err != nil {
+		return err
+	}
+	stored.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockRegionUrlMapsObj{Obj: stored}
+	return nil
+}
+
+// Verify UpdateBetaRegionURLMapWithFingerprintHook implements MockBetaRegionUrlMaps.UpdateHook.
+var _ = cloud.MockBetaRegionUrlMaps{
+	UpdateHook: UpdateBetaRegionURLMapWithFingerprintHook,
+}
+
+// InsertSubnetworkWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockSubnetworks.
+func InsertSubnetworkWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.Subnetwork, m *cloud.MockSubnetworks, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &ga.Subnetwork{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertSubnetworkWithFingerprintHook implements MockSubnetworks.InsertHook.
+var _ = cloud.MockSubnetworks{
+	InsertHook: InsertSubnetworkWithFingerprintHook,
+}
+
+// PatchSubnetworkWithFingerprintHook applies the non-empty fields of obj to the stored
+// object. The patch fails if obj does not have the current fingerprint of the
+// object.
+func PatchSubnetworkWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.Subnetwork, m *cloud.MockSubnetworks, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockSubnetworks", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	patched := &ga.Subnetwork{}
+	if err := patchViaJSON(patched, cur.ToGA(), obj); err != nil {
+		return err
+	}
+	fp, err := fingerprint(patched)
+	if err != nil {
+		return err
+	}
+	patched.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockSubnetworksObj{Obj: patched}
+	return nil
+}
+
+// Verify PatchSubnetworkWithFingerprintHook implements MockSubnetworks.PatchHook.
+var _ = cloud.MockSubnetworks{
+	PatchHook: PatchSubnetworkWithFingerprintHook,
+}
+
+// InsertAlphaSubnetworkWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockAlphaSubnetworks.
+func InsertAlphaSubnetworkWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.Subnetwork, m *cloud.MockAlphaSubnetworks, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &alpha.Subnetwork{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertAlphaSubnetworkWithFingerprintHook implements MockAlphaSubnetworks.InsertHook.
+var _ = cloud.MockAlphaSubnetworks{
+	InsertHook: InsertAlphaSubnetworkWithFingerprintHook,
+}
+
+// PatchAlphaSubnetworkWithFingerprintHook applies the non-empty fields of obj to the stored
+// object. The patch fails if obj does not have the current fingerprint of the
+// object.
+func PatchAlphaSubnetworkWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.Subnetwork, m *cloud.MockAlphaSubnetworks, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockAlphaSubnetworks", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	patched := &alpha.Subnetwork{}
+	if err := patchViaJSON(patched, cur.ToAlpha(), obj); err != nil {
+		return err
+	}
+	fp, err := fingerprint(patched)
+	if err != nil {
+		return err
+	}
+	patched.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockSubnetworksObj{Obj: patched}
+	return nil
+}
+
+// Verify PatchAlphaSubnetworkWithFingerprintHook implements MockAlphaSubnetworks.PatchHook.
+var _ = cloud.MockAlphaSubnetworks{
+	PatchHook: PatchAlphaSubnetworkWithFingerprintHook,
+}
+
+// InsertBetaSubnetworkWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockBetaSubnetworks.
+func InsertBetaSubnetworkWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.Subnetwork, m *cloud.MockBetaSubnetworks, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &beta.Subnetwork{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertBetaSubnetworkWithFingerprintHook implements MockBetaSubnetworks.InsertHook.
+var _ = cloud.MockBetaSubnetworks{
+	InsertHook: InsertBetaSubnetworkWithFingerprintHook,
+}
+
+// PatchBetaSubnetworkWithFingerprintHook applies the non-empty fields of obj to the stored
+// object. The patch fails if obj does not have the current fingerprint of the
+// object.
+func PatchBetaSubnetworkWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.Subnetwork, m *cloud.MockBetaSubnetworks, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockBetaSubnetworks", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	patched := &beta.Subnetwork{}
+	if err := patchViaJSON(patched, cur.ToBeta(), obj); err != nil {
+		return err
+	}
+	fp, err := fingerprint(patched)
+	if err != nil {
+		return err
+	}
+	patched.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockSubnetworksObj{Obj: patched}
+	return nil
+}
+
+// Verify PatchBetaSubnetworkWithFingerprintHook implements MockBetaSubnetworks.PatchHook.
+var _ = cloud.MockBetaSubnetworks{
+	PatchHook: PatchBetaSubnetworkWithFingerprintHook,
+}
+
+// InsertInstanceWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint of the Metadata into the MockInstances.
+func InsertInstanceWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.Instance, m *cloud.MockInstances, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &ga.Instance{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	if cp.Metadata == nil {
+		cp.Metadata = &ga.Metadata{}
+	}
+	fp, err := fingerprint(cp.Metadata)
+	if err != nil {
+		return true, err
+	}
+	cp.Metadata.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertInstanceWithFingerprintHook implements MockInstances.InsertHook.
+var _ = cloud.MockInstances{
+	InsertHook: InsertInstanceWithFingerprintHook,
+}
+
+// SetInstanceMetadataWithFingerprintHook replaces the Metadata of the stored object with a
+// copy of arg. The update fails if arg does not have the current fingerprint
+// of the Metadata.
+func SetInstanceMetadataWithFingerprintHook(ctx context.Context, key *meta.Key, arg *ga.Metadata, m *cloud.MockInstances, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockInstances", key)
+	}
+	obj := &ga.Instance{}
+	if err := copyViaJSON(obj, cur.ToGA()); err != nil {
+		return err
+	}
+	var curFingerprint string
+	if obj.Metadata != nil {
+		curFingerprint = obj.Metadata.Fingerprint
+	}
+	if err := checkFingerprint(curFingerprint, arg.Fingerprint); err != nil {
+		return err
+	}
+
+	obj.Metadata = &ga.Metadata{}
+	if err := copyViaJSON(obj.Metadata, arg); err != nil {
+		return err
+	}
+	fp, err := fingerprint(obj.Metadata)
+	if err != nil {
+		return err
+	}
+	obj.Metadata.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockInstancesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetInstanceMetadataWithFingerprintHook implements MockInstances.SetMetadataHook.
+var _ = cloud.MockInstances{
+	SetMetadataHook: SetInstanceMetadataWithFingerprintHook,
+}
+
+// InsertAlphaInstanceWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint of the Metadata into the MockAlphaInstances.
+func InsertAlphaInstanceWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.Instance, m *cloud.MockAlphaInstances, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &alpha.Instance{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	if cp.Metadata == nil {
+		cp.Metadata = &alpha.Metadata{}
+	}
+	fp, err := fingerprint(cp.Metadata)
+	if err != nil {
+		return true, err
+	}
+	cp.Metadata.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertAlphaInstanceWithFingerprintHook implements MockAlphaInstances.InsertHook.
+var _ = cloud.MockAlphaInstances{
+	InsertHook: InsertAlphaInstanceWithFingerprintHook,
+}
+
+// SetAlphaInstanceMetadataWithFingerprintHook replaces the Metadata of the stored object with a
+// copy of arg. The update fails if arg does not have the current fingerprint
+// of the Metadata.
+func SetAlphaInstanceMetadataWithFingerprintHook(ctx context.Context, key *meta.Key, arg *alpha.Metadata, m *cloud.MockAlphaInstances, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockAlphaInstances", key)
+	}
+	obj := &alpha.Instance{}
+	if err := copyViaJSON(obj, cur.ToAlpha()); err != nil {
+		return err
+	}
+	var curFingerprint string
+	if obj.Metadata != nil {
+		curFingerprint = obj.Metadata.Fingerprint
+	}
+	if err := checkFingerprint(curFingerprint, arg.Fingerprint); err != nil {
+		return err
+	}
+
+	obj.Metadata = &alpha.Metadata{}
+	if err := copyViaJSON(obj.Metadata, arg); err != nil {
+		return err
+	}
+	fp, err := fingerprint(obj.Metadata)
+	if err != nil {
+		return err
+	}
+	obj.Metadata.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockInstancesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetAlphaInstanceMetadataWithFingerprintHook implements MockAlphaInstances.SetMetadataHook.
+var _ = cloud.MockAlphaInstances{
+	SetMetadataHook: SetAlphaInstanceMetadataWithFingerprintHook,
+}
+
+// InsertBetaInstanceWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint of the Metadata into the MockBetaInstances.
+func InsertBetaInstanceWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.Instance, m *cloud.MockBetaInstances, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &beta.Instance{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	if cp.Metadata == nil {
+		cp.Metadata = &beta.Metadata{}
+	}
+	fp, err := fingerprint(cp.Metadata)
+	if err != nil {
+		return true, err
+	}
+	cp.Metadata.Fingerprint = fp
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertBetaInstanceWithFingerprintHook implements MockBetaInstances.InsertHook.
+var _ = cloud.MockBetaInstances{
+	InsertHook: InsertBetaInstanceWithFingerprintHook,
+}
+
+// SetBetaInstanceMetadataWithFingerprintHook replaces the Metadata of the stored object with a
+// copy of arg. The update fails if arg does not have the current fingerprint
+// of the Metadata.
+func SetBetaInstanceMetadataWithFingerprintHook(ctx context.Context, key *meta.Key, arg *beta.Metadata, m *cloud.MockBetaInstances, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockBetaInstances", key)
+	}
+	obj := &beta.Instance{}
+	if err := copyViaJSON(obj, cur.ToBeta()); err != nil {
+		return err
+	}
+	var curFingerprint string
+	if obj.Metadata != nil {
+		curFingerprint = obj.Metadata.Fingerprint
+	}
+	if err := checkFingerprint(curFingerprint, arg.Fingerprint); err != nil {
+		return err
+	}
+
+	obj.Metadata = &beta.Metadata{}
+	if err := copyViaJSON(obj.Metadata, arg); err != nil {
+		return err
+	}
+	fp, err := fingerprint(obj.Metadata)
+	if err != nil {
+		return err
+	}
+	obj.Metadata.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockInstancesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetBetaInstanceMetadataWithFingerprintHook implements MockBetaInstances.SetMetadataHook.
+var _ = cloud.MockBetaInstances{
+	SetMetadataHook: SetBetaInstanceMetadataWithFingerprintHook,
+}
+
+// InsertForwardingRuleWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockForwardingRules.
+func InsertForwardingRuleWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.ForwardingRule, m *cloud.MockForwardingRules, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &ga.ForwardingRule{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	if cp.LabelFingerprint, err = fingerprint(cp.Labels); err != nil {
+		return true, err
+	}
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertForwardingRuleWithFingerprintHook implements MockForwardingRules.InsertHook.
+var _ = cloud.MockForwardingRules{
+	InsertHook: InsertForwardingRuleWithFingerprintHook,
+}
+
+// PatchForwardingRuleWithFingerprintHook applies the non-empty fields of obj to the stored
+// object. The patch fails if obj does not have the current fingerprint of the
+// object.
+func PatchForwardingRuleWithFingerprintHook(ctx context.Context, key *meta.Key, obj *ga.ForwardingRule, m *cloud.MockForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockForwardingRules", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	patched := &ga.ForwardingRule{}
+	if err := patchViaJSON(patched, cur.ToGA(), obj); err != nil {
+		return err
+	}
+	fp, err := fingerprint(patched)
+	if err != nil {
+		return err
+	}
+	patched.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: patched}
+	return nil
+}
+
+// Verify PatchForwardingRuleWithFingerprintHook implements MockForwardingRules.PatchHook.
+var _ = cloud.MockForwardingRules{
+	PatchHook: PatchForwardingRuleWithFingerprintHook,
+}
+
+// SetForwardingRuleTargetWithFingerprintHook sets the target of the stored object, which changes its
+// fingerprint.
+func SetForwardingRuleTargetWithFingerprintHook(ctx context.Context, key *meta.Key, arg *ga.TargetReference, m *cloud.MockForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockForwardingRules", key)
+	}
+	obj := &ga.ForwardingRule{}
+	if err := copyViaJSON(obj, cur.ToGA()); err != nil {
+		return err
+	}
+	obj.Target = arg.Target
+	fp, err := fingerprint(obj)
+	if err != nil {
+		return err
+	}
+	obj.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetForwardingRuleTargetWithFingerprintHook implements MockForwardingRules.SetTargetHook.
+var _ = cloud.MockForwardingRules{
+	SetTargetHook: SetForwardingRuleTargetWithFingerprintHook,
+}
+
+// SetForwardingRuleLabelsWithFingerprintHook sets the labels of the stored object, which changes its
+// fingerprint. The update fails if arg does not have the current
+// LabelFingerprint of the object.
+func SetForwardingRuleLabelsWithFingerprintHook(ctx context.Context, key *meta.Key, arg *ga.RegionSetLabelsRequest, m *cloud.MockForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockForwardingRules", key)
+	}
+	obj := &ga.ForwardingRule{}
+	if err := copyViaJSON(obj, cur.ToGA()); err != nil {
+		return err
+	}
+	if err := checkFingerprint(obj.LabelFingerprint, arg.LabelFingerprint); err != nil {
+		return err
+	}
+	obj.Labels = map[string]string{}
+	for k, v := range arg.Labels {
+		obj.Labels[k] = v
+	}
+	var err error
+	if obj.LabelFingerprint, err = fingerprint(obj.Labels); err != nil {
+		return err
+	}
+	if obj.Fingerprint, err = fingerprint(obj); err != nil {
+		return err
+	}
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetForwardingRuleLabelsWithFingerprintHook implements MockForwardingRules.SetLabelsHook.
+var _ = cloud.MockForwardingRules{
+	SetLabelsHook: SetForwardingRuleLabelsWithFingerprintHook,
+}
+
+// InsertAlphaForwardingRuleWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockAlphaForwardingRules.
+func InsertAlphaForwardingRuleWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.ForwardingRule, m *cloud.MockAlphaForwardingRules, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &alpha.ForwardingRule{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	if cp.LabelFingerprint, err = fingerprint(cp.Labels); err != nil {
+		return true, err
+	}
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertAlphaForwardingRuleWithFingerprintHook implements MockAlphaForwardingRules.InsertHook.
+var _ = cloud.MockAlphaForwardingRules{
+	InsertHook: InsertAlphaForwardingRuleWithFingerprintHook,
+}
+
+// PatchAlphaForwardingRuleWithFingerprintHook applies the non-empty fields of obj to the stored
+// object. The patch fails if obj does not have the current fingerprint of the
+// object.
+func PatchAlphaForwardingRuleWithFingerprintHook(ctx context.Context, key *meta.Key, obj *alpha.ForwardingRule, m *cloud.MockAlphaForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockAlphaForwardingRules", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	patched := &alpha.ForwardingRule{}
+	if err := patchViaJSON(patched, cur.ToAlpha(), obj); err != nil {
+		return err
+	}
+	fp, err := fingerprint(patched)
+	if err != nil {
+		return err
+	}
+	patched.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: patched}
+	return nil
+}
+
+// Verify PatchAlphaForwardingRuleWithFingerprintHook implements MockAlphaForwardingRules.PatchHook.
+var _ = cloud.MockAlphaForwardingRules{
+	PatchHook: PatchAlphaForwardingRuleWithFingerprintHook,
+}
+
+// SetAlphaForwardingRuleTargetWithFingerprintHook sets the target of the stored object, which changes its
+// fingerprint.
+func SetAlphaForwardingRuleTargetWithFingerprintHook(ctx context.Context, key *meta.Key, arg *alpha.TargetReference, m *cloud.MockAlphaForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockAlphaForwardingRules", key)
+	}
+	obj := &alpha.ForwardingRule{}
+	if err := copyViaJSON(obj, cur.ToAlpha()); err != nil {
+		return err
+	}
+	obj.Target = arg.Target
+	fp, err := fingerprint(obj)
+	if err != nil {
+		return err
+	}
+	obj.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetAlphaForwardingRuleTargetWithFingerprintHook implements MockAlphaForwardingRules.SetTargetHook.
+var _ = cloud.MockAlphaForwardingRules{
+	SetTargetHook: SetAlphaForwardingRuleTargetWithFingerprintHook,
+}
+
+// SetAlphaForwardingRuleLabelsWithFingerprintHook sets the labels of the stored object, which changes its
+// fingerprint. The update fails if arg does not have the current
+// LabelFingerprint of the object.
+func SetAlphaForwardingRuleLabelsWithFingerprintHook(ctx context.Context, key *meta.Key, arg *alpha.RegionSetLabelsRequest, m *cloud.MockAlphaForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockAlphaForwardingRules", key)
+	}
+	obj := &alpha.ForwardingRule{}
+	if err := copyViaJSON(obj, cur.ToAlpha()); err != nil {
+		return err
+	}
+	if err := checkFingerprint(obj.LabelFingerprint, arg.LabelFingerprint); err != nil {
+		return err
+	}
+	obj.Labels = map[string]string{}
+	for k, v := range arg.Labels {
+		obj.Labels[k] = v
+	}
+	var err error
+	if obj.LabelFingerprint, err = fingerprint(obj.Labels); err != nil {
+		return err
+	}
+	if obj.Fingerprint, err = fingerprint(obj); err != nil {
+		return err
+	}
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetAlphaForwardingRuleLabelsWithFingerprintHook implements MockAlphaForwardingRules.SetLabelsHook.
+var _ = cloud.MockAlphaForwardingRules{
+	SetLabelsHook: SetAlphaForwardingRuleLabelsWithFingerprintHook,
+}
+
+// InsertBetaForwardingRuleWithFingerprintHook inserts a copy of obj with the initial
+// fingerprint into the MockBetaForwardingRules.
+func InsertBetaForwardingRuleWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.ForwardingRule, m *cloud.MockBetaForwardingRules, options ...cloud.Option) (bool, error) {
+	if ctx.Value(insertingCopyKey{}) != nil {
+		// This is the Insert of the copy below.
+		return false, nil
+	}
+	cp := &beta.ForwardingRule{}
+	if err := copyViaJSON(cp, obj); err != nil {
+		// Fail the Insert.
+		return true, err
+	}
+	fp, err := fingerprint(cp)
+	if err != nil {
+		return true, err
+	}
+	cp.Fingerprint = fp
+	if cp.LabelFingerprint, err = fingerprint(cp.Labels); err != nil {
+		return true, err
+	}
+	return true, m.Insert(context.WithValue(ctx, insertingCopyKey{}, true), key, cp, options...)
+}
+
+// Verify InsertBetaForwardingRuleWithFingerprintHook implements MockBetaForwardingRules.InsertHook.
+var _ = cloud.MockBetaForwardingRules{
+	InsertHook: InsertBetaForwardingRuleWithFingerprintHook,
+}
+
+// PatchBetaForwardingRuleWithFingerprintHook applies the non-empty fields of obj to the stored
+// object. The patch fails if obj does not have the current fingerprint of the
+// object.
+func PatchBetaForwardingRuleWithFingerprintHook(ctx context.Context, key *meta.Key, obj *beta.ForwardingRule, m *cloud.MockBetaForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockBetaForwardingRules", key)
+	}
+	if err := checkFingerprint(cur.ToGA().Fingerprint, obj.Fingerprint); err != nil {
+		return err
+	}
+
+	patched := &beta.ForwardingRule{}
+	if err := patchViaJSON(patched, cur.ToBeta(), obj); err != nil {
+		return err
+	}
+	fp, err := fingerprint(patched)
+	if err != nil {
+		return err
+	}
+	patched.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: patched}
+	return nil
+}
+
+// Verify PatchBetaForwardingRuleWithFingerprintHook implements MockBetaForwardingRules.PatchHook.
+var _ = cloud.MockBetaForwardingRules{
+	PatchHook: PatchBetaForwardingRuleWithFingerprintHook,
+}
+
+// SetBetaForwardingRuleTargetWithFingerprintHook sets the target of the stored object, which changes its
+// fingerprint.
+func SetBetaForwardingRuleTargetWithFingerprintHook(ctx context.Context, key *meta.Key, arg *beta.TargetReference, m *cloud.MockBetaForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockBetaForwardingRules", key)
+	}
+	obj := &beta.ForwardingRule{}
+	if err := copyViaJSON(obj, cur.ToBeta()); err != nil {
+		return err
+	}
+	obj.Target = arg.Target
+	fp, err := fingerprint(obj)
+	if err != nil {
+		return err
+	}
+	obj.Fingerprint = fp
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetBetaForwardingRuleTargetWithFingerprintHook implements MockBetaForwardingRules.SetTargetHook.
+var _ = cloud.MockBetaForwardingRules{
+	SetTargetHook: SetBetaForwardingRuleTargetWithFingerprintHook,
+}
+
+// SetBetaForwardingRuleLabelsWithFingerprintHook sets the labels of the stored object, which changes its
+// fingerprint. The update fails if arg does not have the current
+// LabelFingerprint of the object.
+func SetBetaForwardingRuleLabelsWithFingerprintHook(ctx context.Context, key *meta.Key, arg *beta.RegionSetLabelsRequest, m *cloud.MockBetaForwardingRules, options ...cloud.Option) error {
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
+	cur, ok := m.Objects[*key]
+	if !ok {
+		return notFoundError("MockBetaForwardingRules", key)
+	}
+	obj := &beta.ForwardingRule{}
+	if err := copyViaJSON(obj, cur.ToBeta()); err != nil {
+		return err
+	}
+	if err := checkFingerprint(obj.LabelFingerprint, arg.LabelFingerprint); err != nil {
+		return err
+	}
+	obj.Labels = map[string]string{}
+	for k, v := range arg.Labels {
+		obj.Labels[k] = v
+	}
+	var err error
+	if obj.LabelFingerprint, err = fingerprint(obj.Labels); err != nil {
+		return err
+	}
+	if obj.Fingerprint, err = fingerprint(obj); err != nil {
+		return err
+	}
+
+	m.Objects[*key] = &cloud.MockForwardingRulesObj{Obj: obj}
+	return nil
+}
+
+// Verify SetBetaForwardingRuleLabelsWithFingerprintHook implements MockBetaForwardingRules.SetLabelsHook.
+var _ = cloud.MockBetaForwardingRules{
+	SetLabelsHook: SetBetaForwardingRuleLabelsWithFingerprintHook,
+}
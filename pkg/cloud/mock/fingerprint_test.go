@@ -0,0 +1,217 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+
+	cloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func isPreconditionFailed(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	return ok && gerr.Code == http.StatusPreconditionFailed
+}
+
+func TestURLMapFingerprint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	EnableFingerprints(mockGCE)
+
+	key := meta.GlobalKey("um")
+	if err := mockGCE.UrlMaps().Insert(ctx, key, &ga.UrlMap{DefaultService: "bs1"}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	um, err := mockGCE.UrlMaps().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if um.Fingerprint == "" {
+		t.Fatalf("Fingerprint is empty after Insert()")
+	}
+
+	if err := mockGCE.UrlMaps().Update(ctx, key, &ga.UrlMap{DefaultService: "bs2"}); !isPreconditionFailed(err) {
+		t.Errorf("Update() without fingerprint = %v, want %d", err, http.StatusPreconditionFailed)
+	}
+	// Updates through a different API version see the same fingerprint.
+	if err := mockGCE.AlphaUrlMaps().Update(ctx, key, &alpha.UrlMap{DefaultService: "bs2", Fingerprint: um.Fingerprint}); err != nil {
+		t.Fatalf("Update() with current fingerprint = %v, want nil", err)
+	}
+	got, err := mockGCE.UrlMaps().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if got.DefaultService != "bs2" || got.Fingerprint == um.Fingerprint {
+		t.Errorf("after Update(): got %+v, want DefaultService = bs2 and a new fingerprint", got)
+	}
+	if err := mockGCE.UrlMaps().Update(ctx, key, &ga.UrlMap{DefaultService: "bs3", Fingerprint: um.Fingerprint}); !isPreconditionFailed(err) {
+		t.Errorf("Update() with stale fingerprint = %v, want %d", err, http.StatusPreconditionFailed)
+	}
+	if err := mockGCE.UrlMaps().Update(ctx, meta.GlobalKey("missing"), &ga.UrlMap{}); err == nil {
+		t.Errorf("Update() of missing UrlMap = nil, want error")
+	}
+}
+
+func TestSubnetworkFingerprint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	EnableFingerprints(mockGCE)
+
+	key := meta.RegionalKey("subnet", "us-central1")
+	if err := mockGCE.Subnetworks().Insert(ctx, key, &ga.Subnetwork{IpCidrRange: "10.0.0.0/24", Description: "d"}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	sn, err := mockGCE.Subnetworks().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+
+	if err := mockGCE.Subnetworks().Patch(ctx, key, &ga.Subnetwork{IpCidrRange: "10.0.0.0/16", Fingerprint: "stale"}); !isPreconditionFailed(err) {
+		t.Errorf("Patch() with stale fingerprint = %v, want %d", err, http.StatusPreconditionFailed)
+	}
+	if err := mockGCE.Subnetworks().Patch(ctx, key, &ga.Subnetwork{IpCidrRange: "10.0.0.0/16", Fingerprint: sn.Fingerprint}); err != nil {
+		t.Fatalf("Patch() with current fingerprint = %v, want nil", err)
+	}
+	got, err := mockGCE.Subnetworks().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if got.IpCidrRange != "10.0.0.0/16" || got.Description != "d" || got.Fingerprint == sn.Fingerprint {
+		t.Errorf("after Patch(): got %+v, want patched IpCidrRange, unchanged Description and a new fingerprint", got)
+	}
+}
+
+func TestInstanceMetadataFingerprint(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	EnableFingerprints(mockGCE)
+
+	key := meta.ZonalKey("vm", "us-central1-b")
+	if err := mockGCE.Instances().Insert(ctx, key, &ga.Instance{}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	vm, err := mockGCE.Instances().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if vm.Metadata == nil || vm.Metadata.Fingerprint == "" {
+		t.Fatalf("Metadata fingerprint is empty after Insert(): %+v", vm.Metadata)
+	}
+	fp := vm.Metadata.Fingerprint
+
+	v := "v"
+	md := &ga.Metadata{Items: []*ga.MetadataItems{{Key: "k", Value: &v}}}
+	if err := mockGCE.Instances().SetMetadata(ctx, key, md); !isPreconditionFailed(err) {
+		t.Errorf("SetMetadata() without fingerprint = %v, want %d", err, http.StatusPreconditionFailed)
+	}
+	md.Fingerprint = fp
+	if err := mockGCE.Instances().SetMetadata(ctx, key, md); err != nil {
+		t.Fatalf("SetMetadata() with current fingerprint = %v, want nil", err)
+	}
+	got, err := mockGCE.Instances().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if len(got.Metadata.Items) != 1 || got.Metadata.Fingerprint == fp {
+		t.Errorf("after SetMetadata(): got %+v, want 1 item and a new fingerprint", got.Metadata)
+	}
+}
+
+func TestFingerprintHooksCopy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	EnableFingerprints(mockGCE)
+
+	key := meta.GlobalKey("um")
+	um := &ga.UrlMap{DefaultService: "bs1"}
+	if err := mockGCE.UrlMaps().Insert(ctx, key, um); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	if um.Fingerprint != "" || um.Name != "" {
+		t.Errorf("Insert() modified the object of the caller: %+v", um)
+	}
+	got, err := mockGCE.UrlMaps().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+
+	update := &ga.UrlMap{DefaultService: "bs2", Fingerprint: got.Fingerprint}
+	if err := mockGCE.UrlMaps().Update(ctx, key, update); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if update.Fingerprint != got.Fingerprint || update.Name != "" || update.SelfLink != "" {
+		t.Errorf("Update() modified the object of the caller: %+v", update)
+	}
+	// Changes to the object of the caller are not seen by the mock.
+	update.DefaultService = "bs3"
+	if got, err = mockGCE.UrlMaps().Get(ctx, key); err != nil || got.DefaultService != "bs2" {
+		t.Errorf("Get() = %+v, %v; want DefaultService = bs2", got, err)
+	}
+
+	vmKey := meta.ZonalKey("vm", "us-central1-b")
+	if err := mockGCE.Instances().Insert(ctx, vmKey, &ga.Instance{}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	vm, err := mockGCE.Instances().Get(ctx, vmKey)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	md := &ga.Metadata{Fingerprint: vm.Metadata.Fingerprint}
+	if err := mockGCE.Instances().SetMetadata(ctx, vmKey, md); err != nil {
+		t.Fatalf("SetMetadata() = %v", err)
+	}
+	if md.Fingerprint != vm.Metadata.Fingerprint {
+		t.Errorf("SetMetadata() modified the metadata of the caller: %+v", md)
+	}
+}
+
+func TestFingerprintError(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mockGCE := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	EnableFingerprints(mockGCE)
+
+	// NaN cannot be marshaled to JSON.
+	key := meta.GlobalKey("um")
+	um := &ga.UrlMap{
+		DefaultRouteAction: &ga.HttpRouteAction{
+			FaultInjectionPolicy: &ga.HttpFaultInjection{Abort: &ga.HttpFaultAbort{Percentage: math.NaN()}},
+		},
+	}
+	if err := mockGCE.UrlMaps().Insert(ctx, key, um); err == nil {
+		t.Fatalf("Insert() = nil, want error")
+	}
+	if _, err := mockGCE.UrlMaps().Get(ctx, key); err == nil {
+		t.Errorf("Get() = _, nil; want error after the failed Insert()")
+	}
+}
@@ -60,6 +60,10 @@ func AttachDiskHook(ctx context.Context, key *meta.Key, req *ga.AttachedDisk, m
 	if err != nil {
 		return err
 	}
+
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	instance.Disks = append(instance.Disks, req)
 	return nil
 }
@@ -75,6 +79,10 @@ func DetachDiskHook(ctx context.Context, key *meta.Key, diskName string, m *clou
 	if err != nil {
 		return err
 	}
+
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	for i, disk := range instance.Disks {
 		if disk.DeviceName == diskName {
 			instance.Disks = append(instance.Disks[:i], instance.Disks[i+1:]...)
@@ -102,6 +110,9 @@ func AddInstanceHook(ctx context.Context, key *meta.Key, req *ga.TargetPoolsAddI
 		}
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	for _, instance := range req.Instances {
 		pool.Instances = append(pool.Instances, instance.Instance)
 	}
@@ -124,6 +135,9 @@ func RemoveInstanceHook(ctx context.Context, key *meta.Key, req *ga.TargetPoolsR
 		}
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	for _, instanceToRemove := range req.Instances {
 		for i, instance := range pool.Instances {
 			if instanceToRemove.Instance == instance {
@@ -420,6 +434,9 @@ func AddInstancesHook(ctx context.Context, key *meta.Key, req *ga.InstanceGroups
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	var attrs InstanceGroupAttributes
 	attrs = m.X.(InstanceGroupAttributes)
 	attrs.AddInstances(key, req.Instances)
@@ -439,6 +456,9 @@ func ListInstancesHook(ctx context.Context, key *meta.Key, req *ga.InstanceGroup
 		return nil, err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	var attrs InstanceGroupAttributes
 	attrs = m.X.(InstanceGroupAttributes)
 	instances := attrs.List(key)
@@ -458,6 +478,9 @@ func RemoveInstancesHook(ctx context.Context, key *meta.Key, req *ga.InstanceGro
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	var attrs InstanceGroupAttributes
 	attrs = m.X.(InstanceGroupAttributes)
 	attrs.RemoveInstances(key, req.Instances)
@@ -478,6 +501,9 @@ func UpdateFirewallHook(ctx context.Context, key *meta.Key, obj *ga.Firewall, m
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "ga", "firewalls")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "firewalls", key)
@@ -499,6 +525,9 @@ func UpdateAlphaFirewallHook(ctx context.Context, key *meta.Key, obj *alpha.Fire
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "alpha", "firewalls")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "firewalls", key)
@@ -520,6 +549,9 @@ func UpdateBetaFirewallHook(ctx context.Context, key *meta.Key, obj *beta.Firewa
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "beta", "firewalls")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionBeta, projectID, "firewalls", key)
@@ -541,6 +573,9 @@ func UpdateHealthCheckHook(ctx context.Context, key *meta.Key, obj *ga.HealthChe
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "ga", "healthChecks")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "healthChecks", key)
@@ -562,6 +597,9 @@ func UpdateAlphaHealthCheckHook(ctx context.Context, key *meta.Key, obj *alpha.H
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "alpha", "healthChecks")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "healthChecks", key)
@@ -582,6 +620,9 @@ func UpdateAlphaRegionHealthCheckHook(ctx context.Context, key *meta.Key, obj *a
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "alpha", "healthChecks")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "healthChecks", key)
@@ -602,6 +643,9 @@ func UpdateBetaHealthCheckHook(ctx context.Context, key *meta.Key, obj *beta.Hea
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "beta", "healthChecks")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionBeta, projectID, "healthChecks", key)
@@ -622,6 +666,9 @@ func UpdateBetaRegionHealthCheckHook(ctx context.Context, key *meta.Key, obj *be
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "beta", "healthChecks")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionBeta, projectID, "healthChecks", key)
@@ -642,6 +689,9 @@ func UpdateRegionHealthCheckHook(ctx context.Context, key *meta.Key, obj *ga.Hea
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "ga", "healthChecks")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "healthChecks", key)
@@ -664,6 +714,9 @@ func UpdateRegionBackendServiceHook(ctx context.Context, key *meta.Key, obj *ga.
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "ga", "backendServices")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "backendServices", key)
@@ -686,6 +739,9 @@ func UpdateAlphaRegionBackendServiceHook(ctx context.Context, key *meta.Key, obj
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "alpha", "backendServices")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "backendServices", key)
@@ -708,6 +764,9 @@ func UpdateBetaRegionBackendServiceHook(ctx context.Context, key *meta.Key, obj
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "beta", "backendServices")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "backendServices", key)
@@ -729,6 +788,9 @@ func UpdateBackendServiceHook(ctx context.Context, key *meta.Key, obj *ga.Backen
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "ga", "backendServices")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "backendServices", key)
@@ -750,6 +812,9 @@ func UpdateAlphaBackendServiceHook(ctx context.Context, key *meta.Key, obj *alph
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "alpha", "backendServices")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "backendServices", key)
@@ -771,6 +836,9 @@ func UpdateBetaBackendServiceHook(ctx context.Context, key *meta.Key, obj *beta.
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "beta", "backendServices")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionBeta, projectID, "backendServices", key)
@@ -792,6 +860,9 @@ func UpdateURLMapHook(ctx context.Context, key *meta.Key, obj *ga.UrlMap, m *clo
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "ga", "urlMaps")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "urlMaps", key)
@@ -813,6 +884,9 @@ func UpdateAlphaURLMapHook(ctx context.Context, key *meta.Key, obj *alpha.UrlMap
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "alpha", "urlMaps")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "urlMaps", key)
@@ -834,6 +908,9 @@ func UpdateBetaURLMapHook(ctx context.Context, key *meta.Key, obj *beta.UrlMap,
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "beta", "urlMaps")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionBeta, projectID, "urlMaps", key)
@@ -855,6 +932,9 @@ func UpdateAlphaRegionURLMapHook(ctx context.Context, key *meta.Key, obj *alpha.
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "alpha", "urlMaps")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionAlpha, projectID, "urlMaps", key)
@@ -876,6 +956,9 @@ func UpdateBetaRegionURLMapHook(ctx context.Context, key *meta.Key, obj *beta.Ur
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "beta", "urlMaps")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionBeta, projectID, "urlMaps", key)
@@ -897,6 +980,9 @@ func UpdateRegionURLMapHook(ctx context.Context, key *meta.Key, obj *ga.UrlMap,
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	obj.Name = key.Name
 	projectID := m.ProjectRouter.ProjectID(ctx, "ga", "urlMaps")
 	obj.SelfLink = cloud.SelfLinkWithGroup(meta.APIGroupCompute, meta.VersionGA, projectID, "urlMaps", key)
@@ -917,6 +1003,9 @@ func SetTargetGlobalForwardingRuleHook(ctx context.Context, key *meta.Key, obj *
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	fw.Target = obj.Target
 	return nil
 }
@@ -933,6 +1022,9 @@ func SetTargetForwardingRuleHook(ctx context.Context, key *meta.Key, obj *ga.Tar
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	fw.Target = obj.Target
 	return nil
 }
@@ -949,6 +1041,9 @@ func SetTargetAlphaForwardingRuleHook(ctx context.Context, key *meta.Key, obj *a
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	fw.Target = obj.Target
 	return nil
 }
@@ -965,6 +1060,9 @@ func SetTargetBetaForwardingRuleHook(ctx context.Context, key *meta.Key, obj *be
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	fw.Target = obj.Target
 	return nil
 }
@@ -981,6 +1079,9 @@ func SetTargetAlphaGlobalForwardingRuleHook(ctx context.Context, key *meta.Key,
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	fw.Target = ref.Target
 	return nil
 }
@@ -997,6 +1098,9 @@ func SetTargetBetaGlobalForwardingRuleHook(ctx context.Context, key *meta.Key, o
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	fw.Target = obj.Target
 	return nil
 }
@@ -1013,6 +1117,9 @@ func SetURLMapTargetHTTPProxyHook(ctx context.Context, key *meta.Key, ref *ga.Ur
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1029,6 +1136,9 @@ func SetURLMapTargetHTTPSProxyHook(ctx context.Context, key *meta.Key, ref *ga.U
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1045,6 +1155,9 @@ func SetURLMapAlphaRegionTargetHTTPSProxyHook(ctx context.Context, key *meta.Key
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1061,6 +1174,9 @@ func SetURLMapBetaRegionTargetHTTPSProxyHook(ctx context.Context, key *meta.Key,
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1077,6 +1193,9 @@ func SetURLMapRegionTargetHTTPSProxyHook(ctx context.Context, key *meta.Key, ref
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1093,6 +1212,9 @@ func SetURLMapAlphaTargetHTTPProxyHook(ctx context.Context, key *meta.Key, ref *
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1109,6 +1231,9 @@ func SetURLMapBetaTargetHTTPProxyHook(ctx context.Context, key *meta.Key, ref *b
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1125,6 +1250,9 @@ func SetURLMapBetaTargetHTTPSProxyHook(ctx context.Context, key *meta.Key, ref *
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1141,6 +1269,9 @@ func SetURLMapAlphaRegionTargetHTTPProxyHook(ctx context.Context, key *meta.Key,
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1157,6 +1288,9 @@ func SetURLMapBetaRegionTargetHTTPProxyHook(ctx context.Context, key *meta.Key,
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1173,6 +1307,9 @@ func SetURLMapRegionTargetHTTPProxyHook(ctx context.Context, key *meta.Key, ref
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.UrlMap = ref.UrlMap
 	return nil
 }
@@ -1189,6 +1326,9 @@ func SetBackendServiceAlphaTargetTCPProxyHook(ctx context.Context, key *meta.Key
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.Service = ref.Service
 	return nil
 }
@@ -1205,6 +1345,9 @@ func SetBackendServiceBetaTargetTCPProxyHook(ctx context.Context, key *meta.Key,
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.Service = ref.Service
 	return nil
 }
@@ -1221,6 +1364,9 @@ func SetSslCertificateTargetHTTPSProxyHook(ctx context.Context, key *meta.Key, r
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslCertificates = req.SslCertificates
 	return nil
 }
@@ -1236,6 +1382,10 @@ func SetSslCertificateAlphaTargetHTTPSProxyHook(ctx context.Context, key *meta.K
 	if err != nil {
 		return err
 	}
+
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslCertificates = req.SslCertificates
 	return nil
 }
@@ -1251,6 +1401,10 @@ func SetSslCertificateBetaTargetHTTPSProxyHook(ctx context.Context, key *meta.Ke
 	if err != nil {
 		return err
 	}
+
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslCertificates = req.SslCertificates
 	return nil
 }
@@ -1267,6 +1421,9 @@ func SetSslCertificateAlphaRegionTargetHTTPSProxyHook(ctx context.Context, key *
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslCertificates = req.SslCertificates
 	return nil
 }
@@ -1283,6 +1440,9 @@ func SetSslCertificateBetaRegionTargetHTTPSProxyHook(ctx context.Context, key *m
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslCertificates = req.SslCertificates
 	return nil
 }
@@ -1299,6 +1459,9 @@ func SetSslCertificateRegionTargetHTTPSProxyHook(ctx context.Context, key *meta.
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslCertificates = req.SslCertificates
 	return nil
 }
@@ -1315,6 +1478,9 @@ func SetSslPolicyTargetHTTPSProxyHook(ctx context.Context, key *meta.Key, ref *g
 		return err
 	}
 
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslPolicy = ref.SslPolicy
 	return nil
 }
@@ -1330,6 +1496,10 @@ func SetSslPolicyAlphaTargetHTTPSProxyHook(ctx context.Context, key *meta.Key, r
 	if err != nil {
 		return err
 	}
+
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslPolicy = ref.SslPolicy
 	return nil
 }
@@ -1345,6 +1515,10 @@ func SetSslPolicyBetaTargetHTTPSProxyHook(ctx context.Context, key *meta.Key, re
 	if err != nil {
 		return err
 	}
+
+	m.Lock.Lock()
+	defer m.Lock.Unlock()
+
 	tp.SslPolicy = ref.SslPolicy
 	return nil
 }
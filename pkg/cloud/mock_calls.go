@@ -0,0 +1,140 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// MockCall is a call that was made to a mock.
+type MockCall struct {
+	// Service called, e.g. "BackendServices".
+	Service string
+	// Version of the API called.
+	Version meta.Version
+	// Operation is the name of the method, e.g. "Get", "Insert", "Update".
+	Operation string
+	// Key of the call. For List, this will only contain the region or zone
+	// and will be nil for global and aggregated lists.
+	Key *meta.Key
+}
+
+// String implements Stringer.
+func (c MockCall) String() string {
+	if c.Key == nil {
+		return fmt.Sprintf("%s.%s(%s)", c.Service, c.Operation, c.Version)
+	}
+	return fmt.Sprintf("%s.%s(%s, %v)", c.Service, c.Operation, c.Version, c.Key)
+}
+
+// matches returns true if c matches pattern. Fields that are not set in the
+// pattern match any value.
+func (c MockCall) matches(pattern MockCall) bool {
+	switch {
+	case pattern.Service != "" && pattern.Service != c.Service:
+		return false
+	case pattern.Version != "" && pattern.Version != c.Version:
+		return false
+	case pattern.Operation != "" && pattern.Operation != c.Operation:
+		return false
+	case pattern.Key != nil && (c.Key == nil || *pattern.Key != *c.Key):
+		return false
+	}
+	return true
+}
+
+// MockCalls is a list of calls made to the mocks, in the order they were made.
+type MockCalls []MockCall
+
+// Matching returns the calls that match any of the patterns. Fields that
+// are not set in a pattern match any value. Example:
+//
+//	// Exactly one Update was issued for the BackendService "bs".
+//	calls := mock.Calls().Matching(MockCall{
+//		Service:   "BackendServices",
+//		Operation: "Update",
+//		Key:       meta.GlobalKey("bs"),
+//	})
+//	if len(calls) != 1 { ... }
+func (l MockCalls) Matching(patterns ...MockCall) MockCalls {
+	var ret MockCalls
+	for _, c := range l {
+		for _, p := range patterns {
+			if c.matches(p) {
+				ret = append(ret, c)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// MockCallLog records the calls made to the mocks. It is safe for
+// concurrent use. A nil *MockCallLog does not record anything.
+type MockCallLog struct {
+	lock  sync.Mutex
+	calls MockCalls
+}
+
+func (l *MockCallLog) record(service string, version meta.Version, op string, key *meta.Key) {
+	if l == nil {
+		return
+	}
+	c := MockCall{Service: service, Version: version, Operation: op}
+	if key != nil {
+		k := *key
+		c.Key = &k
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.calls = append(l.calls, c)
+}
+
+// Calls returns a copy of the calls recorded so far.
+func (l *MockCallLog) Calls() MockCalls {
+	if l == nil {
+		return nil
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append(MockCalls(nil), l.calls...)
+}
+
+// Reset clears the recorded calls.
+func (l *MockCallLog) Reset() {
+	if l == nil {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.calls = nil
+}
+
+// Calls returns the calls made to all of the mocks in the MockGCE, in the
+// order they were made.
+func (mock *MockGCE) Calls() MockCalls {
+	return mock.callLog.Calls()
+}
+
+// ResetCalls clears the calls recorded by the MockGCE.
+func (mock *MockGCE) ResetCalls() {
+	mock.callLog.Reset()
+}
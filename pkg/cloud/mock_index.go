@@ -0,0 +1,229 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// mockIndex indexes the keys of the objects in a mock by scope (region or
+// zone) and name, and optionally by label. This keeps List() and lookups
+// fast for mocks holding a large number of objects.
+//
+// The index is shared by the mocks of all versions of a service, as they
+// share the Objects map. It is maintained by the mock methods and is
+// invalidated after the hooks that can write to Objects. Changes made to
+// Objects directly are detected when the number of objects differs from the
+// index; otherwise Reindex() must be called on the mock.
+type mockIndex struct {
+	lock sync.Mutex
+	// invalid is set when Objects may have changed without the index
+	// being updated. The index is rebuilt on the next lookup.
+	invalid bool
+
+	keys    meta.KeySet
+	byScope map[string]meta.KeySet
+	byName  map[string]meta.KeySet
+	// byLabel is keyed by "key=value". It is nil until labels are first
+	// looked up.
+	byLabel map[string]meta.KeySet
+	labels  map[meta.Key]map[string]string
+}
+
+func newMockIndex() *mockIndex {
+	ix := &mockIndex{}
+	ix.reset()
+	return ix
+}
+
+// mockIndexScope returns the scope of the key: the region, zone or "" for
+// global keys.
+func mockIndexScope(key meta.Key) string {
+	switch {
+	case key.Region != "":
+		return key.Region
+	case key.Zone != "":
+		return key.Zone
+	}
+	return ""
+}
+
+// mockObjLabels returns the Labels field of obj, if any.
+func mockObjLabels(obj interface{}) map[string]string {
+	v := reflect.ValueOf(obj)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.FieldByName("Labels")
+	if !f.IsValid() {
+		return nil
+	}
+	labels, _ := f.Interface().(map[string]string)
+	return labels
+}
+
+func mockLabelKey(k, v string) string { return k + "=" + v }
+
+func addToSet(m map[string]meta.KeySet, s string, key meta.Key) {
+	if m[s] == nil {
+		m[s] = meta.KeySet{}
+	}
+	m[s].Insert(&key)
+}
+
+func removeFromSet(m map[string]meta.KeySet, s string, key meta.Key) {
+	m[s].Delete(&key)
+	if m[s].Len() == 0 {
+		delete(m, s)
+	}
+}
+
+// reset clears the index. Label indexing stays enabled if it was enabled.
+func (ix *mockIndex) reset() {
+	ix.invalid = false
+	ix.keys = meta.KeySet{}
+	ix.byScope = map[string]meta.KeySet{}
+	ix.byName = map[string]meta.KeySet{}
+	if ix.byLabel != nil {
+		ix.byLabel = map[string]meta.KeySet{}
+	}
+	ix.labels = map[meta.Key]map[string]string{}
+}
+
+// stale returns true if the index does not match objects with n entries or
+// has been invalidated.
+func (ix *mockIndex) stale(n int) bool {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+	return ix.invalid || len(ix.keys) != n
+}
+
+// invalidate the index. It is rebuilt on the next syncIndex().
+func (ix *mockIndex) invalidate() {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+	ix.invalid = true
+}
+
+// rebuild replaces the contents of the index with objs.
+func (ix *mockIndex) rebuild(objs map[meta.Key]interface{}) {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+
+	ix.reset()
+	for key, obj := range objs {
+		ix.addLocked(key, obj)
+	}
+}
+
+// add indexes obj stored under key, replacing any previous entry.
+func (ix *mockIndex) add(key meta.Key, obj interface{}) {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+
+	ix.removeLocked(key)
+	ix.addLocked(key, obj)
+}
+
+func (ix *mockIndex) addLocked(key meta.Key, obj interface{}) {
+	ix.keys.Insert(&key)
+	addToSet(ix.byScope, mockIndexScope(key), key)
+	addToSet(ix.byName, key.Name, key)
+	if ix.byLabel == nil {
+		return
+	}
+	labels := mockObjLabels(obj)
+	for k, v := range labels {
+		addToSet(ix.byLabel, mockLabelKey(k, v), key)
+	}
+	if len(labels) > 0 {
+		// Copy as the object may be modified in place.
+		ix.labels[key] = make(map[string]string, len(labels))
+		for k, v := range labels {
+			ix.labels[key][k] = v
+		}
+	}
+}
+
+// remove removes key from the index.
+func (ix *mockIndex) remove(key meta.Key) {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+	ix.removeLocked(key)
+}
+
+func (ix *mockIndex) removeLocked(key meta.Key) {
+	if !ix.keys.Has(&key) {
+		return
+	}
+	ix.keys.Delete(&key)
+	removeFromSet(ix.byScope, mockIndexScope(key), key)
+	removeFromSet(ix.byName, key.Name, key)
+	for k, v := range ix.labels[key] {
+		removeFromSet(ix.byLabel, mockLabelKey(k, v), key)
+	}
+	delete(ix.labels, key)
+}
+
+// inScope returns the keys in the given region or zone ("" for global).
+func (ix *mockIndex) inScope(scope string) []meta.Key {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+
+	var ret []meta.Key
+	for k := range ix.byScope[scope] {
+		ret = append(ret, k)
+	}
+	return ret
+}
+
+// withName returns the keys with the given name, sorted.
+func (ix *mockIndex) withName(name string) []*meta.Key {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+	return ix.byName[name].Sorted()
+}
+
+// labelsEnabled returns true if labels are being indexed.
+func (ix *mockIndex) labelsEnabled() bool {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+	return ix.byLabel != nil
+}
+
+// enableLabels starts indexing labels. The index must be rebuilt afterwards.
+func (ix *mockIndex) enableLabels() {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+	ix.byLabel = map[string]meta.KeySet{}
+}
+
+// withLabel returns the keys with the label k=v, sorted.
+func (ix *mockIndex) withLabel(k, v string) []*meta.Key {
+	ix.lock.Lock()
+	defer ix.lock.Unlock()
+	return ix.byLabel[mockLabelKey(k, v)].Sorted()
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import "sync"
+
+// MockLock is the Lock of a mock, which protects its Objects. The mocks for
+// the versions of a service (e.g. MockBackendServices and
+// MockAlphaBackendServices) share the Objects and NewMockGCE makes their
+// Locks use the same mutex, so a hook can lock m.Lock of any version. The
+// zero value is an unlocked mutex that is not shared, e.g. for a mock that is
+// built as a literal.
+//
+// Migration: Lock used to be a sync.Mutex for each version, so hooks on the
+// Alpha and Beta mocks were not mutually exclusive with the GA one. Hooks
+// that call m.Lock.Lock() and m.Lock.Unlock() are unchanged. Code that uses
+// &m.Lock as a *sync.Mutex or copies m.Lock no longer compiles; use &m.Lock
+// as a sync.Locker instead.
+type MockLock struct {
+	mu sync.Mutex
+	// shared, if not nil, is the mutex of the mock that owns the Objects.
+	// It is used instead of mu.
+	shared *sync.Mutex
+}
+
+// Lock the Objects of the mock.
+func (l *MockLock) Lock() { l.mutex().Lock() }
+
+// Unlock the Objects of the mock.
+func (l *MockLock) Unlock() { l.mutex().Unlock() }
+
+// mutex returns the mutex in effect.
+func (l *MockLock) mutex() *sync.Mutex {
+	if l.shared != nil {
+		return l.shared
+	}
+	return &l.mu
+}
+
+// share makes l use the mutex of base. This must be called before the mock
+// is used.
+func (l *MockLock) share(base *MockLock) { l.shared = base.mutex() }
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"google.golang.org/api/googleapi"
+	"k8s.io/klog/v2"
+)
+
+// ResourceInUseReason is the reason returned by GCE in the error when trying
+// to delete a resource that is still referenced by another resource.
+const ResourceInUseReason = "resourceInUseByAnotherResource"
+
+// mockBackRefFields are fields that contain references from other resources
+// to the object (e.g. Address.Users). These are maintained by GCE and do not
+// prevent deletion of the referenced resource.
+var mockBackRefFields = map[string]bool{
+	"selfLink":       true,
+	"selfLinkWithId": true,
+	"users":          true,
+}
+
+// mockInUseChecker checks that an object is not referenced by the other
+// objects in the mock before it is deleted. See
+// MockGCE.EnforceReferentialIntegrity().
+//
+// The mock deleting the object holds its lock during the check so that no
+// reference is added between the check and the delete. The Deletes that
+// check are serialized with the embedded Mutex, which must be locked before
+// the lock of the mock.
+type mockInUseChecker struct {
+	sync.Mutex
+	mock *MockGCE
+}
+
+// check returns a resourceInUseByAnotherResource error if any object in the
+// mock references id. held is the lock of the mock deleting the object.
+func (c *mockInUseChecker) check(ctx context.Context, id *ResourceID, held *sync.Mutex) error {
+	var user string
+	c.mock.forEachObject(held, func(obj interface{}) bool {
+		user = mockObjectReferrer(obj, id)
+		return user == ""
+	})
+	if user == "" {
+		return nil
+	}
+	msg := fmt.Sprintf("The resource '%s' is already being used by '%s'", RelativeResourceName(id.ProjectID, id.Resource, id.Key), user)
+	return &googleapi.Error{
+		Code:    http.StatusBadRequest,
+		Message: msg,
+		Errors:  []googleapi.ErrorItem{{Reason: ResourceInUseReason, Message: msg}},
+	}
+}
+
+// mockObjectReferrer returns the self link of obj if obj contains a reference
+// to id. Returns "" if there is no reference.
+func mockObjectReferrer(obj interface{}, id *ResourceID) string {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		klog.Errorf("Could not convert %T to JSON: %v", obj, err)
+		return ""
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		klog.Errorf("Could not convert %T from JSON: %v", obj, err)
+		return ""
+	}
+	selfLink, _ := fields["selfLink"].(string)
+	if selfLink != "" && mockURLMatches(selfLink, id) {
+		// Objects do not block their own deletion.
+		return ""
+	}
+	if !mockValueReferences(fields, id) {
+		return ""
+	}
+	if selfLink == "" {
+		return fmt.Sprintf("%T", obj)
+	}
+	return selfLink
+}
+
+// mockValueReferences returns true if v (decoded from JSON) contains a
+// reference to id.
+func mockValueReferences(v interface{}, id *ResourceID) bool {
+	switch v := v.(type) {
+	case string:
+		return mockURLMatches(v, id)
+	case []interface{}:
+		for _, item := range v {
+			if mockValueReferences(item, id) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for field, item := range v {
+			if mockBackRefFields[field] {
+				continue
+			}
+			if mockValueReferences(item, id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mockURLMatches returns true if the url refers to the resource id. The
+// API version of the url is ignored, as is the project and API group if they
+// are not present in the url.
+func mockURLMatches(url string, id *ResourceID) bool {
+	ref, err := ParseResourceURL(url)
+	if err != nil || ref.Key == nil {
+		return false
+	}
+	switch {
+	case ref.Resource != id.Resource || *ref.Key != *id.Key:
+		return false
+	case ref.ProjectID != "" && ref.ProjectID != id.ProjectID:
+		return false
+	case ref.APIGroup != "" && id.APIGroup != "" && ref.APIGroup != id.APIGroup:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// SeedConfig selects the resources copied by MockGCE.Seed().
+type SeedConfig struct {
+	// Services to copy, using the names in meta.ServiceInfo.Service (e.g.
+	// "BackendServices", "GlobalForwardingRules"). If empty, all services
+	// are copied.
+	Services []string
+	// Regions to list for regional resources that do not support
+	// AggregatedList.
+	Regions []string
+	// Zones to list for zonal resources that do not support AggregatedList.
+	Zones []string
+}
+
+func (c *SeedConfig) selected(service string) bool {
+	if len(c.Services) == 0 {
+		return true
+	}
+	for _, s := range c.Services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// Seed lists the resources selected by cfg in src and stores them in the
+// mock. Objects are stored as returned by src, keeping their SelfLinks. src
+// is typically a GCE object for a live project, which allows a production
+// topology to be reproduced in the mock for debugging. cfg may be nil to
+// copy all global and aggregated resources.
+//
+// Objects already in the mock with the same key are overwritten.
+func (mock *MockGCE) Seed(ctx context.Context, src Cloud, cfg *SeedConfig) error {
+	if cfg == nil {
+		cfg = &SeedConfig{}
+	}
+	for _, s := range cfg.Services {
+		if _, ok := meta.AllServicesByGroup[s]; !ok {
+			return fmt.Errorf("Seed: unknown service %q", s)
+		}
+	}
+	if err := mock.seed(ctx, src, cfg); err != nil {
+		return fmt.Errorf("Seed: %w", err)
+	}
+	return nil
+}
+
+// seedKey returns the key for an object with the given name and self link.
+// Resources that are not addressed by self links (e.g. networkservices) carry
+// the resource URL in the name, e.g. "projects/p/locations/global/meshes/m".
+func seedKey(name, selfLink string) (*meta.Key, error) {
+	for _, url := range []string{selfLink, name} {
+		if _, _, _, key, err := meta.ParseSelfLink(url); err == nil {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot determine key for object (name=%q, selfLink=%q)", name, selfLink)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// mockStressYields is the number of times the goroutine yields inside of the
+// critical section of a mock call in stress mode.
+const mockStressYields = 3
+
+// mockStress implements the stress mode of the mocks. In stress mode, each
+// mock call widens its critical section by yielding to other goroutines and
+// verifies that the objects in the mock were not modified while the lock was
+// held. A modification means that another goroutine changed Objects, or an
+// object stored in it, without holding the lock: e.g. a hook that modifies
+// the object returned by Get() without locking or a test that changes Objects
+// directly while the mocks are in use.
+type mockStress struct {
+	enabled atomic.Bool
+
+	lock sync.Mutex
+	errs []error
+}
+
+// check verifies that the objects returned by snapshot do not change while
+// the calling goroutine yields. The lock of the mock must be held.
+func (s *mockStress) check(op string, snapshot func() map[meta.Key]interface{}) {
+	if s == nil || !s.enabled.Load() {
+		return
+	}
+	before := mockStressState(snapshot())
+	for i := 0; i < mockStressYields; i++ {
+		runtime.Gosched()
+	}
+	after := mockStressState(snapshot())
+
+	for key, b := range before {
+		a, ok := after[key]
+		switch {
+		case !ok:
+			s.addError(fmt.Errorf("%s: object %v was deleted without holding the lock", op, key))
+		case a != b:
+			s.addError(fmt.Errorf("%s: object %v was modified without holding the lock", op, key))
+		}
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			s.addError(fmt.Errorf("%s: object %v was added without holding the lock", op, key))
+		}
+	}
+}
+
+func (s *mockStress) addError(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.errs = append(s.errs, err)
+}
+
+// mockStressState returns the serialized form of each object.
+func mockStressState(objs map[meta.Key]interface{}) map[meta.Key]string {
+	ret := make(map[meta.Key]string, len(objs))
+	for key, obj := range objs {
+		b, err := json.Marshal(obj)
+		if err != nil {
+			b = []byte(fmt.Sprintf("%p: %v", obj, err))
+		}
+		ret[key] = string(b)
+	}
+	return ret
+}
+
+// EnableStressMode configures the stress mode of the mocks. Stress mode
+// detects data races between concurrent users of the mocks, such as objects
+// modified without holding the mock lock (torn reads) and Objects modified
+// while another goroutine iterates over it. Violations are returned by
+// StressErrors().
+//
+// Stress mode makes each call significantly slower and should be used in
+// tests that exercise the mocks from many goroutines, ideally together with
+// the race detector (go test -race).
+func (mock *MockGCE) EnableStressMode(enable bool) {
+	mock.stress.enabled.Store(enable)
+}
+
+// StressErrors returns the violations detected in stress mode.
+func (mock *MockGCE) StressErrors() []error {
+	mock.stress.lock.Lock()
+	defer mock.stress.lock.Unlock()
+	return append([]error(nil), mock.stress.errs...)
+}
@@ -18,9 +18,16 @@ package cloud
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"reflect"
+	"sort"
+	"sync"
 	"testing"
 
+	"google.golang.org/api/googleapi"
+
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
 	ga "google.golang.org/api/compute/v1"
@@ -149,3 +156,522 @@ func TestMocks(t *testing.T) {
 		t.Errorf("Addresses().Delete(%v, %v) = nil; want error", ctx, key)
 	}
 }
+
+func TestMockReferentialIntegrity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+	mock.EnforceReferentialIntegrity(true)
+
+	hcKey := meta.GlobalKey("hc")
+	bsKey := meta.GlobalKey("bs")
+	if err := mock.HealthChecks().Insert(ctx, hcKey, &ga.HealthCheck{}); err != nil {
+		t.Fatalf("HealthChecks().Insert(%v) = %v; want nil", hcKey, err)
+	}
+	hc, err := mock.HealthChecks().Get(ctx, hcKey)
+	if err != nil {
+		t.Fatalf("HealthChecks().Get(%v) = _, %v; want nil", hcKey, err)
+	}
+	// Reference the health check using a different API version.
+	bs := &beta.BackendService{HealthChecks: []string{SelfLink(meta.VersionBeta, "mock-project", "healthChecks", hcKey)}}
+	if err := mock.BetaBackendServices().Insert(ctx, bsKey, bs); err != nil {
+		t.Fatalf("BetaBackendServices().Insert(%v) = %v; want nil", bsKey, err)
+	}
+
+	err = mock.HealthChecks().Delete(ctx, hcKey)
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) || len(gerr.Errors) != 1 || gerr.Errors[0].Reason != ResourceInUseReason {
+		t.Fatalf("HealthChecks().Delete(%v) = %v; want %s error", hcKey, err, ResourceInUseReason)
+	}
+	if _, err := mock.AlphaHealthChecks().Get(ctx, hcKey); err != nil {
+		t.Errorf("AlphaHealthChecks().Get(%v) = _, %v; want nil", hcKey, err)
+	}
+	// Objects do not block their own deletion.
+	if err := mock.BackendServices().Delete(ctx, bsKey); err != nil {
+		t.Errorf("BackendServices().Delete(%v) = %v; want nil", bsKey, err)
+	}
+	if err := mock.HealthChecks().Delete(ctx, hcKey); err != nil {
+		t.Errorf("HealthChecks().Delete(%v) = %v; want nil", hcKey, err)
+	}
+
+	// Disable enforcement.
+	mock.EnforceReferentialIntegrity(false)
+	if err := mock.HealthChecks().Insert(ctx, hcKey, &ga.HealthCheck{}); err != nil {
+		t.Fatalf("HealthChecks().Insert(%v) = %v; want nil", hcKey, err)
+	}
+	if err := mock.BackendServices().Insert(ctx, bsKey, &ga.BackendService{HealthChecks: []string{hc.SelfLink}}); err != nil {
+		t.Fatalf("BackendServices().Insert(%v) = %v; want nil", bsKey, err)
+	}
+	if err := mock.HealthChecks().Delete(ctx, hcKey); err != nil {
+		t.Errorf("HealthChecks().Delete(%v) = %v; want nil", hcKey, err)
+	}
+}
+
+func TestMockReferentialIntegrityConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+	mock.EnforceReferentialIntegrity(true)
+
+	// Concurrent Deletes of objects of different services that reference
+	// each other must not deadlock.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				hcKey := meta.GlobalKey(fmt.Sprintf("hc-%d-%d", i, j))
+				bsKey := meta.GlobalKey(fmt.Sprintf("bs-%d-%d", i, j))
+				mock.HealthChecks().Insert(ctx, hcKey, &ga.HealthCheck{})
+				mock.BetaBackendServices().Insert(ctx, bsKey, &beta.BackendService{
+					HealthChecks: []string{SelfLink(meta.VersionGA, "mock-project", "healthChecks", hcKey)},
+				})
+				if err := mock.AlphaHealthChecks().Delete(ctx, hcKey); err == nil {
+					t.Errorf("AlphaHealthChecks().Delete(%v) = nil, want in use error", hcKey)
+				}
+				if err := mock.BackendServices().Delete(ctx, bsKey); err != nil {
+					t.Errorf("BackendServices().Delete(%v) = %v", bsKey, err)
+				}
+				if err := mock.HealthChecks().Delete(ctx, hcKey); err != nil {
+					t.Errorf("HealthChecks().Delete(%v) = %v", hcKey, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMockSeed(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	src := NewMockGCE(&SingleProjectRouter{"src-project"})
+
+	bsKey := meta.GlobalKey("bs")
+	addrKey := meta.RegionalKey("addr", "us-central1")
+	rbsKey := meta.RegionalKey("rbs", "us-central1")
+	rbsOtherKey := meta.RegionalKey("rbs", "europe-west1")
+	for _, insert := range []func() error{
+		func() error { return src.BackendServices().Insert(ctx, bsKey, &ga.BackendService{Description: "bs"}) },
+		func() error { return src.Addresses().Insert(ctx, addrKey, &ga.Address{}) },
+		func() error { return src.RegionBackendServices().Insert(ctx, rbsKey, &ga.BackendService{}) },
+		func() error { return src.RegionBackendServices().Insert(ctx, rbsOtherKey, &ga.BackendService{}) },
+	} {
+		if err := insert(); err != nil {
+			t.Fatalf("Insert() = %v", err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		cfg     *SeedConfig
+		want    map[string]bool
+		wantErr bool
+	}{
+		{
+			name: "all",
+			cfg:  &SeedConfig{Regions: []string{"us-central1"}},
+			want: map[string]bool{"bs": true, "addr": true, "rbs/us-central1": true},
+		},
+		{
+			name: "nil config",
+			want: map[string]bool{"bs": true, "addr": true},
+		},
+		{
+			name: "selected services",
+			cfg:  &SeedConfig{Services: []string{"Addresses"}},
+			want: map[string]bool{"addr": true},
+		},
+		{
+			name:    "unknown service",
+			cfg:     &SeedConfig{Services: []string{"Foo"}},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dst := NewMockGCE(&SingleProjectRouter{"dst-project"})
+			err := dst.Seed(ctx, src, tc.cfg)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Seed() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := map[string]bool{}
+			if bs, err := dst.BackendServices().Get(ctx, bsKey); err == nil {
+				if bs.Description != "bs" || bs.SelfLink != SelfLink(meta.VersionGA, "src-project", "backendServices", bsKey) {
+					t.Errorf("BackendServices().Get() = %+v; want the object from src", bs)
+				}
+				got["bs"] = true
+			}
+			if _, err := dst.Addresses().Get(ctx, addrKey); err == nil {
+				got["addr"] = true
+			}
+			if _, err := dst.RegionBackendServices().Get(ctx, rbsKey); err == nil {
+				got["rbs/us-central1"] = true
+			}
+			if _, err := dst.RegionBackendServices().Get(ctx, rbsOtherKey); err == nil {
+				got["rbs/europe-west1"] = true
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("objects after Seed() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMockCalls(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+
+	bsKey := meta.GlobalKey("bs")
+	addrKey := meta.RegionalKey("addr", "us-central1")
+	mock.BackendServices().Insert(ctx, bsKey, &ga.BackendService{})
+	mock.BetaBackendServices().Get(ctx, bsKey)
+	mock.BackendServices().Update(ctx, bsKey, &ga.BackendService{})
+	mock.Addresses().List(ctx, "us-central1", filter.None)
+	mock.Addresses().Get(ctx, addrKey)
+	mock.BackendServices().Delete(ctx, bsKey)
+
+	for _, tc := range []struct {
+		name     string
+		patterns []MockCall
+		want     []string
+	}{
+		{
+			name: "all",
+			want: []string{
+				"BackendServices.Insert(ga, Key{\"bs\"})",
+				"BackendServices.Get(beta, Key{\"bs\"})",
+				"BackendServices.Update(ga, Key{\"bs\"})",
+				"Addresses.List(ga, Key{\"\", region: \"us-central1\"})",
+				"Addresses.Get(ga, Key{\"addr\", region: \"us-central1\"})",
+				"BackendServices.Delete(ga, Key{\"bs\"})",
+			},
+		},
+		{
+			name:     "by operation",
+			patterns: []MockCall{{Service: "BackendServices", Operation: "Update"}},
+			want:     []string{"BackendServices.Update(ga, Key{\"bs\"})"},
+		},
+		{
+			name:     "by version",
+			patterns: []MockCall{{Version: meta.VersionBeta}},
+			want:     []string{"BackendServices.Get(beta, Key{\"bs\"})"},
+		},
+		{
+			name:     "by key",
+			patterns: []MockCall{{Key: addrKey}},
+			want:     []string{"Addresses.Get(ga, Key{\"addr\", region: \"us-central1\"})"},
+		},
+		{
+			name:     "multiple patterns",
+			patterns: []MockCall{{Operation: "Insert"}, {Operation: "Delete"}},
+			want:     []string{"BackendServices.Insert(ga, Key{\"bs\"})", "BackendServices.Delete(ga, Key{\"bs\"})"},
+		},
+		{
+			name:     "no match",
+			patterns: []MockCall{{Service: "UrlMaps"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := mock.Calls()
+			if tc.patterns != nil {
+				calls = calls.Matching(tc.patterns...)
+			}
+			var got []string
+			for _, c := range calls {
+				got = append(got, c.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("calls = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	mock.ResetCalls()
+	if got := mock.Calls(); len(got) != 0 {
+		t.Errorf("Calls() after ResetCalls() = %v, want none", got)
+	}
+}
+
+func TestMockIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+
+	keys := []*meta.Key{
+		meta.RegionalKey("fr1", "us-central1"),
+		meta.RegionalKey("fr2", "us-central1"),
+		meta.RegionalKey("fr1", "europe-west1"),
+	}
+	for _, key := range keys {
+		// Objects inserted with any version are indexed for all versions.
+		if err := mock.AlphaForwardingRules().Insert(ctx, key, &alpha.ForwardingRule{}); err != nil {
+			t.Fatalf("Insert(%v) = %v", key, err)
+		}
+	}
+	// Objects added directly are picked up.
+	direct := meta.RegionalKey("fr3", "europe-west1")
+	mock.MockForwardingRules.Objects[*direct] = mock.MockForwardingRules.Obj(&ga.ForwardingRule{Name: direct.Name})
+
+	listNames := func(region string) []string {
+		t.Helper()
+		l, err := mock.ForwardingRules().List(ctx, region, filter.None)
+		if err != nil {
+			t.Fatalf("List(%q) = _, %v", region, err)
+		}
+		var names []string
+		for _, fr := range l {
+			names = append(names, fr.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	if got, want := listNames("us-central1"), []string{"fr1", "fr2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List(us-central1) = %v, want %v", got, want)
+	}
+	if got, want := listNames("europe-west1"), []string{"fr1", "fr3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List(europe-west1) = %v, want %v", got, want)
+	}
+	if got := mock.ForwardingRules().(*MockForwardingRules).KeysByName("fr1"); len(got) != 2 {
+		t.Errorf("KeysByName(fr1) = %v, want 2 keys", got)
+	}
+
+	if err := mock.ForwardingRules().Delete(ctx, keys[1]); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	if got, want := listNames("us-central1"), []string{"fr1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List(us-central1) after Delete() = %v, want %v", got, want)
+	}
+
+	// Labels are reindexed after the mock methods modify the object.
+	mock.MockForwardingRules.SetLabelsHook = func(_ context.Context, key *meta.Key, req *ga.RegionSetLabelsRequest, m *MockForwardingRules, _ ...Option) error {
+		m.Lock.Lock()
+		defer m.Lock.Unlock()
+		m.Objects[*key] = m.Obj(&ga.ForwardingRule{Name: key.Name, Labels: req.Labels})
+		return nil
+	}
+	if got := mock.MockForwardingRules.KeysByLabel("app", "web"); len(got) != 0 {
+		t.Errorf("KeysByLabel(app, web) = %v, want none", got)
+	}
+	if err := mock.ForwardingRules().SetLabels(ctx, keys[0], &ga.RegionSetLabelsRequest{Labels: map[string]string{"app": "web"}}); err != nil {
+		t.Fatalf("SetLabels() = %v", err)
+	}
+	if got := mock.MockForwardingRules.KeysByLabel("app", "web"); len(got) != 1 || *got[0] != *keys[0] {
+		t.Errorf("KeysByLabel(app, web) = %v, want [%v]", got, keys[0])
+	}
+	// Objects modified in place require Reindex().
+	mock.MockForwardingRules.Objects[*direct].ToGA().Labels = map[string]string{"app": "web"}
+	mock.MockForwardingRules.Reindex()
+	if got := mock.MockForwardingRules.KeysByLabel("app", "web"); len(got) != 2 {
+		t.Errorf("KeysByLabel(app, web) after Reindex() = %v, want 2 keys", got)
+	}
+}
+
+func TestMockIndexReplace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+	m := mock.MockForwardingRules
+
+	keyA := meta.RegionalKey("fr-a", "us-central1")
+	keyB := meta.RegionalKey("fr-b", "us-central1")
+	if err := mock.ForwardingRules().Insert(ctx, keyA, &ga.ForwardingRule{Labels: map[string]string{"app": "a"}}); err != nil {
+		t.Fatalf("Insert(%v) = %v", keyA, err)
+	}
+	if got := m.KeysByLabel("app", "a"); len(got) != 1 {
+		t.Fatalf("KeysByLabel(app, a) = %v, want 1 key", got)
+	}
+
+	// The hook replaces fr-a with fr-b: the number of objects is unchanged.
+	m.InsertHook = func(_ context.Context, key *meta.Key, obj *ga.ForwardingRule, m *MockForwardingRules, _ ...Option) (bool, error) {
+		m.Lock.Lock()
+		defer m.Lock.Unlock()
+		delete(m.Objects, *keyA)
+		obj.Name = key.Name
+		m.Objects[*key] = m.Obj(obj)
+		return true, nil
+	}
+	if err := mock.ForwardingRules().Insert(ctx, keyB, &ga.ForwardingRule{Labels: map[string]string{"app": "b"}}); err != nil {
+		t.Fatalf("Insert(%v) = %v", keyB, err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		got  []*meta.Key
+		want []*meta.Key
+	}{
+		{name: "KeysByName(fr-a)", got: m.KeysByName("fr-a")},
+		{name: "KeysByName(fr-b)", got: m.KeysByName("fr-b"), want: []*meta.Key{keyB}},
+		{name: "KeysByLabel(app, a)", got: m.KeysByLabel("app", "a")},
+		{name: "KeysByLabel(app, b)", got: m.KeysByLabel("app", "b"), want: []*meta.Key{keyB}},
+	} {
+		if len(tc.got) != len(tc.want) || (len(tc.want) > 0 && *tc.got[0] != *tc.want[0]) {
+			t.Errorf("%s = %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+	l, err := mock.ForwardingRules().List(ctx, "us-central1", filter.None)
+	if err != nil || len(l) != 1 || l[0].Name != "fr-b" {
+		t.Errorf("List() = %v, %v; want [fr-b]", l, err)
+	}
+}
+
+func TestMockStress(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+	mock.EnableStressMode(true)
+
+	// The mocks for all versions of a service share the same objects, so
+	// concurrent calls through different versions must be safe.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				key := meta.GlobalKey(fmt.Sprintf("bs-%d-%d", i, j))
+				switch i % 3 {
+				case 0:
+					mock.BackendServices().Insert(ctx, key, &ga.BackendService{})
+				case 1:
+					mock.AlphaBackendServices().Insert(ctx, key, &alpha.BackendService{})
+				case 2:
+					mock.BetaBackendServices().List(ctx, filter.None)
+				}
+				mock.BackendServices().Get(ctx, key)
+				mock.AlphaBackendServices().Delete(ctx, key)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if errs := mock.StressErrors(); len(errs) != 0 {
+		t.Errorf("StressErrors() = %v, want none", errs)
+	}
+}
+
+func TestMockLiteral(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	// Mocks built as literals have a Lock that is not shared.
+	mock := &MockBackendServices{
+		ProjectRouter: &SingleProjectRouter{"mock-project"},
+		Objects:       map[meta.Key]*MockBackendServicesObj{},
+	}
+	key := meta.GlobalKey("bs")
+	if err := mock.Insert(ctx, key, &ga.BackendService{}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	if _, err := mock.Get(ctx, key); err != nil {
+		t.Errorf("Get() = %v", err)
+	}
+	if err := mock.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() = %v", err)
+	}
+	if mock.Lock.mutex() != &mock.Lock.mu {
+		t.Errorf("Lock.mutex() = %p, want the mutex of the mock (%p)", mock.Lock.mutex(), &mock.Lock.mu)
+	}
+
+	// A hook that locks the Alpha mock excludes the GA one.
+	gce := NewMockGCE(&SingleProjectRouter{"mock-project"})
+	gce.MockAlphaBackendServices.Lock.Lock()
+	if gce.MockBackendServices.Lock.mutex().TryLock() {
+		t.Errorf("the GA mock can be locked while the Alpha mock is locked")
+	}
+	gce.MockAlphaBackendServices.Lock.Unlock()
+}
+
+func TestMockStressCheck(t *testing.T) {
+	t.Parallel()
+
+	key := meta.GlobalKey("bs")
+	var calls int
+	// snapshot simulates another goroutine modifying the object while the
+	// check is running.
+	snapshot := func() map[meta.Key]interface{} {
+		calls++
+		return map[meta.Key]interface{}{*key: &ga.BackendService{Description: fmt.Sprint(calls)}}
+	}
+
+	s := &mockStress{}
+	s.check("op", snapshot)
+	if len(s.errs) != 0 || calls != 0 {
+		t.Fatalf("check() with stress mode disabled: errs = %v, calls = %d; want none", s.errs, calls)
+	}
+	s.enabled.Store(true)
+	s.check("op", snapshot)
+	if len(s.errs) != 1 {
+		t.Errorf("check() = %v, want 1 error", s.errs)
+	}
+}
+
+func isNotFound(err error) bool {
+	var gerr *googleapi.Error
+	return errors.As(err, &gerr) && gerr.Code == http.StatusNotFound
+}
+
+func TestMockDefaultUpdatePatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"mock-project"})
+
+	key := meta.GlobalKey("um")
+	if err := mock.UrlMaps().Update(ctx, key, &ga.UrlMap{}); !isNotFound(err) {
+		t.Errorf("Update() of missing object = %v, want 404", err)
+	}
+	if err := mock.UrlMaps().Patch(ctx, key, &ga.UrlMap{}); !isNotFound(err) {
+		t.Errorf("Patch() of missing object = %v, want 404", err)
+	}
+
+	if err := mock.UrlMaps().Insert(ctx, key, &ga.UrlMap{DefaultService: "bs1", Description: "d"}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	before, err := mock.UrlMaps().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+
+	update := &ga.UrlMap{DefaultService: "bs2"}
+	if err := mock.UrlMaps().Update(ctx, key, update); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	// Neither the object returned earlier nor the argument are shared with
+	// the mock.
+	update.DefaultService = "modified"
+	if before.DefaultService != "bs1" {
+		t.Errorf("object returned before Update() was modified: %+v", before)
+	}
+	got, err := mock.AlphaUrlMaps().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if got.DefaultService != "bs2" || got.Description != "" || got.Name != "um" || got.SelfLink != before.SelfLink {
+		t.Errorf("after Update(): got %+v, want the object replaced with the same Name and SelfLink", got)
+	}
+
+	if err := mock.AlphaUrlMaps().Patch(ctx, key, &alpha.UrlMap{Description: "patched"}); err != nil {
+		t.Fatalf("Patch() = %v", err)
+	}
+	gotGA, err := mock.UrlMaps().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if gotGA.DefaultService != "bs2" || gotGA.Description != "patched" {
+		t.Errorf("after Patch(): got %+v, want DefaultService = bs2, Description = patched", gotGA)
+	}
+}
@@ -1,5 +1,7 @@
 package cloud
 
+import "google.golang.org/api/googleapi"
+
 // Option are optional parameters to the generated methods.
 type Option interface {
 	mergeInto(all *allOptions)
@@ -7,7 +9,8 @@ type Option interface {
 
 // allOptions that can be configured for the generated methods.
 type allOptions struct {
-	projectID string
+	projectID   string
+	callOptions []googleapi.CallOption
 }
 
 // ForceProjectID forces the projectID to be used in the call to be the one
@@ -18,6 +21,23 @@ type projectIDOption string
 
 func (opt projectIDOption) mergeInto(all *allOptions) { all.projectID = string(opt) }
 
+// QueryParameter sets the query parameter in the call to the API. This is
+// used for optional parameters of additional methods that are not part of the
+// generated signature, e.g. the priority of the rule for
+// NetworkFirewallPolicies.RemoveRule().
+func QueryParameter(key string, values ...string) Option {
+	return queryParameterOption{key: key, values: values}
+}
+
+type queryParameterOption struct {
+	key    string
+	values []string
+}
+
+func (opt queryParameterOption) mergeInto(all *allOptions) {
+	all.callOptions = append(all.callOptions, googleapi.QueryParameter(opt.key, opt.values...))
+}
+
 func mergeOptions(options []Option) allOptions {
 	var ret allOptions
 	for _, opt := range options {
@@ -24,7 +24,8 @@ import (
 )
 
 // Do accumulates all of the Actions for executing a plan to transform
-// got to want.
+// got to want. The Actions are ordered by the ID of their node, so the same
+// plan always results in the same list.
 func Do(got, want *rgraph.Graph) ([]exec.Action, error) {
 	var actions []exec.Action
 	for _, n := range want.All() {
@@ -68,6 +68,13 @@ func (p *planner) preconditions() error {
 }
 
 func (p *planner) planWantGraph(gotNode, wantNode rnode.Node) error {
+	if wantNode.Ownership() == rnode.OwnershipExternal &&
+		wantNode.State() == rnode.NodeExists &&
+		gotNode.State() == rnode.NodeDoesNotExist {
+		// External resources (e.g. the VPC Network) are referenced but not
+		// created by us, so they must already exist.
+		return fmt.Errorf("localPlanner: external node %s does not exist", wantNode.ID())
+	}
 	if wantNode.Ownership() != rnode.OwnershipManaged {
 		wantNode.Plan().Set(rnode.PlanDetails{
 			Operation: rnode.OpNothing,
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/algo/graphviz"
@@ -108,6 +109,21 @@ func TestLocalPlan(t *testing.T) {
 				makeID(0).String(): rnode.OpNothing,
 			},
 		},
+		{
+			name: "error: external node does not exist",
+			setupBuilder: func(gotb, wantb *rgraph.Builder) {
+				node := newNode(0)
+				node.SetOwnership(rnode.OwnershipExternal)
+				node.SetState(rnode.NodeDoesNotExist)
+				gotb.Add(node)
+
+				node = newNode(0)
+				node.SetOwnership(rnode.OwnershipExternal)
+				node.SetState(rnode.NodeExists)
+				wantb.Add(node)
+			},
+			wantErr: true,
+		},
 		{
 			name: "delete resource (1 -> 0 node)",
 			setupBuilder: func(gotb, wantb *rgraph.Builder) {
@@ -159,6 +175,24 @@ func TestLocalPlan(t *testing.T) {
 				makeID(0).String(): rnode.OpUpdate,
 			},
 		},
+		{
+			name: "update node ignored field (nop)",
+			setupBuilder: func(gotb, wantb *rgraph.Builder) {
+				node := newNodeWithValue(0, "abc")
+				node.SetOwnership(rnode.OwnershipManaged)
+				node.SetState(rnode.NodeExists)
+				gotb.Add(node)
+
+				node = newNodeWithValue(0, "def")
+				node.SetOwnership(rnode.OwnershipManaged)
+				node.SetState(rnode.NodeExists)
+				node.IgnoreDiff(api.Path{}.Pointer().Field("Value"))
+				wantb.Add(node)
+			},
+			wantPlan: map[string]rnode.Operation{
+				makeID(0).String(): rnode.OpNothing,
+			},
+		},
 		{
 			name: "multiple nodes",
 			setupBuilder: func(gotb, wantb *rgraph.Builder) {
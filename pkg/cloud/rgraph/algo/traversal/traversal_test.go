@@ -207,26 +207,26 @@ func TestConnectedSubgraph(t *testing.T) {
 			want:  []string{"a", "b", "c", "d", "e"},
 		},
 		{
-			name:  "cycle one",
-			graph: "a->a",
+			name:  "duplicate ref",
+			graph: "a->b; a->b",
 			start: "a",
-			want:  []string{"a"},
+			want:  []string{"a", "b"},
 		},
 		{
-			name:  "cycle two",
-			graph: "a->b;b->a",
-			start: "a",
-			want:  []string{"a", "b"},
+			name:  "undirected cycle two",
+			graph: "a->b; a->c->b",
+			start: "b",
+			want:  []string{"a", "b", "c"},
 		},
 		{
-			name:  "cycle many",
-			graph: "a->b;b->c;c->d;d->a",
+			name:  "undirected cycle many",
+			graph: "a->b;b->c;c->d;a->d",
 			start: "a",
 			want:  []string{"a", "b", "c", "d"},
 		},
 		{
 			name:  "complex",
-			graph: "a->b->c; b->c; c->a; c->d->e",
+			graph: "a->b->c; b->c; a->c; c->d->e",
 			start: "a",
 			want:  []string{"a", "b", "c", "d", "e"},
 		},
@@ -312,21 +312,21 @@ func TestTransitiveRefs(t *testing.T) {
 			want:  []string{"a", "b", "c", "e", "f"},
 		},
 		{
-			name:  "cycle 1",
-			graph: "a->a",
-			start: "a",
-			want:  []string{"a"},
+			name:  "duplicate ref",
+			graph: "a->b; a->b",
+			start: "b",
+			want:  []string{"a", "b"},
 		},
 		{
-			name:  "cycle 3",
-			graph: "a->b; b->c; c->a",
-			start: "b",
-			want:  []string{"a", "b", "c"},
+			name:  "diamond",
+			graph: "a->b->d; a->c->d",
+			start: "d",
+			want:  []string{"a", "b", "c", "d"},
 		},
 		{
-			name:  "cycle 3",
-			graph: "a->b; b->c; c->a",
-			start: "b",
+			name:  "diamond, middle",
+			graph: "a->b->d; a->c->d; b->c",
+			start: "c",
 			want:  []string{"a", "b", "c"},
 		},
 		{
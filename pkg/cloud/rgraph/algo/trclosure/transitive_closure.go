@@ -24,6 +24,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/algo"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
 	"github.com/kr/pretty"
@@ -40,9 +41,16 @@ func OnGetFunc(f func(n rnode.Builder) error) Option {
 	return func(c *Config) { c.onGet = f }
 }
 
+// CloudRouter fetches the resources with the Cloud for their project. The
+// Cloud passed to Do() is used for the projects that are not routed.
+func CloudRouter(r exec.CloudRouter) Option {
+	return func(c *Config) { c.router = r }
+}
+
 // Config for the algorithm.
 type Config struct {
-	onGet func(n rnode.Builder) error
+	onGet  func(n rnode.Builder) error
+	router exec.CloudRouter
 }
 
 func makeConfig(opts ...Option) Config {
@@ -107,6 +115,10 @@ func doInternal(
 	var graphLock sync.Mutex
 
 	fn := func(ctx context.Context, w work) error {
+		// Stop fetching as soon as the context is canceled.
+		if err := ctx.Err(); err != nil {
+			return makeErr("%w", err)
+		}
 		outRefs, err := syncNode(ctx, cl, config, w.b)
 		if err != nil {
 			return err
@@ -146,7 +158,7 @@ func doInternal(
 // respect to the Node it is syncing.
 func syncNode(ctx context.Context, cl cloud.Cloud, config Config, b rnode.Builder) ([]rnode.ResourceRef, error) {
 	// TODO: SyncFromCloud needs to be threadsafe.
-	err := b.SyncFromCloud(ctx, cl)
+	err := b.SyncFromCloud(ctx, config.router.For(b.ID(), cl))
 	klog.V(2).Infof("node.SyncFromCloud(%s) = %v (%s)", b.ID(), err, pretty.Sprint(b))
 
 	if err != nil {
@@ -18,6 +18,8 @@ package rgraph
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
@@ -37,13 +39,26 @@ func NewBuilder() *Builder {
 // Builder builds resource Graphs.
 type Builder struct {
 	nodes map[cloud.ResourceMapKey]rnode.Builder
+	// newExternal is set by AddMissingRefsAsExternal().
+	newExternal func(*cloud.ResourceID) (rnode.Builder, error)
 }
 
+// AddMissingRefsAsExternal configures Build() to add the resources that are
+// referenced by nodes but are not in the graph as OwnershipExternal nodes
+// instead of returning a DanglingRefError. newBuilder returns the node
+// Builder for the resource (e.g. all.NewBuilderByID). The state of the added
+// nodes is fetched from the Cloud during planning.
+func (g *Builder) AddMissingRefsAsExternal(newBuilder func(*cloud.ResourceID) (rnode.Builder, error)) {
+	g.newExternal = newBuilder
+}
+
+// All of the node builders, sorted by ID.
 func (g *Builder) All() []rnode.Builder {
 	var ret []rnode.Builder
 	for _, nb := range g.nodes {
 		ret = append(ret, nb)
 	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID().String() < ret[j].ID().String() })
 	return ret
 }
 
@@ -59,6 +74,9 @@ func (g *Builder) Build() (*Graph, error) {
 	if err := g.computeInRefs(); err != nil {
 		return nil, err
 	}
+	if err := g.checkCycles(); err != nil {
+		return nil, err
+	}
 	if err := g.validate(); err != nil {
 		return nil, err
 	}
@@ -85,9 +103,23 @@ func (g *Builder) MustBuild() *Graph {
 	return ret
 }
 
+// DanglingRefError is returned by Build() if a node references a resource
+// that is not in the graph. See AddMissingRefsAsExternal().
+type DanglingRefError struct {
+	// Ref is the reference to the missing resource.
+	Ref rnode.ResourceRef
+}
+
+func (e *DanglingRefError) Error() string {
+	return fmt.Sprintf("%s: missing outRef: %s (field %s) points to %s which isn't in the graph", builderErrPrefix, e.Ref.From, e.Ref.Path, e.Ref.To)
+}
+
 // computeInRefs calculates the inbound references to a resource from all of the
 // nodes in the graph.
 func (g *Builder) computeInRefs() error {
+	if err := g.addMissingRefs(); err != nil {
+		return err
+	}
 	for _, fromNode := range g.nodes {
 		refs, err := fromNode.OutRefs()
 		if err != nil {
@@ -96,7 +128,7 @@ func (g *Builder) computeInRefs() error {
 		for _, ref := range refs {
 			toNode, ok := g.nodes[ref.To.MapKey()]
 			if !ok {
-				return fmt.Errorf("%s: missing outRef: %s points to %s which isn't in the graph", builderErrPrefix, fromNode.ID(), ref.To)
+				return &DanglingRefError{Ref: ref}
 			}
 			toNode.AddInRef(ref)
 		}
@@ -104,6 +136,139 @@ func (g *Builder) computeInRefs() error {
 	return nil
 }
 
+// addMissingRefs adds the missing references as OwnershipExternal nodes if
+// configured.
+func (g *Builder) addMissingRefs() error {
+	if g.newExternal == nil {
+		return nil
+	}
+	missing := map[cloud.ResourceMapKey]*cloud.ResourceID{}
+	for _, fromNode := range g.nodes {
+		refs, err := fromNode.OutRefs()
+		if err != nil {
+			return fmt.Errorf("computeInRefs: %w", err)
+		}
+		for _, ref := range refs {
+			if _, ok := g.nodes[ref.To.MapKey()]; !ok {
+				missing[ref.To.MapKey()] = ref.To
+			}
+		}
+	}
+	for _, id := range missing {
+		nb, err := g.newExternal(id)
+		if err != nil {
+			return fmt.Errorf("%s: add missing outRef %s: %w", builderErrPrefix, id, err)
+		}
+		nb.SetOwnership(rnode.OwnershipExternal)
+		g.Add(nb)
+	}
+	return nil
+}
+
+// CycleError is returned by Builder.Build() and Graph.AddExternal() if the
+// references between the nodes form a cycle. Resources in a cycle cannot be
+// created or deleted as each resource must exist before it is referenced.
+type CycleError struct {
+	// Path of references in the cycle. Path[len(Path)-1].To ==
+	// Path[0].From.
+	Path []rnode.ResourceRef
+}
+
+func (e *CycleError) Error() string {
+	if len(e.Path) == 0 {
+		return "reference cycle"
+	}
+	var parts []string
+	for _, ref := range e.Path {
+		parts = append(parts, fmt.Sprintf("%s (%s)", ref.From, ref.Path))
+	}
+	parts = append(parts, e.Path[0].From.String())
+	return fmt.Sprintf("reference cycle: %s", strings.Join(parts, " -> "))
+}
+
+// checkCycles returns a CycleError if there is a cycle in the references.
+func (g *Builder) checkCycles() error {
+	outRefs := map[cloud.ResourceMapKey][]rnode.ResourceRef{}
+	for k, nb := range g.nodes {
+		refs, err := nb.OutRefs()
+		if err != nil {
+			return fmt.Errorf("%s: checkCycles: %w", builderErrPrefix, err)
+		}
+		outRefs[k] = refs
+	}
+	if err := findCycle(outRefs); err != nil {
+		return fmt.Errorf("%s: %w", builderErrPrefix, err)
+	}
+	return nil
+}
+
+// findCycle returns a CycleError if there is a cycle in outRefs, the
+// references of each node. References to nodes that are not in outRefs are
+// ignored. Nodes are visited in a stable order so that the same cycle is
+// reported every time.
+func findCycle(outRefs map[cloud.ResourceMapKey][]rnode.ResourceRef) *CycleError {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[cloud.ResourceMapKey]int{}
+	// stack of references from the root to the current node.
+	var stack []rnode.ResourceRef
+
+	sortedRefs := func(k cloud.ResourceMapKey) []rnode.ResourceRef {
+		refs := append([]rnode.ResourceRef(nil), outRefs[k]...)
+		sort.Slice(refs, func(i, j int) bool { return refs[i].To.String() < refs[j].To.String() })
+		return refs
+	}
+
+	var visit func(k cloud.ResourceMapKey) *CycleError
+	visit = func(k cloud.ResourceMapKey) *CycleError {
+		state[k] = visiting
+		for _, ref := range sortedRefs(k) {
+			to := ref.To.MapKey()
+			if _, ok := outRefs[to]; !ok {
+				continue
+			}
+			switch state[to] {
+			case visiting:
+				// Trim the stack to the start of the cycle.
+				start := len(stack)
+				for i := range stack {
+					if stack[i].From.Equal(ref.To) {
+						start = i
+						break
+					}
+				}
+				path := append(append([]rnode.ResourceRef(nil), stack[start:]...), ref)
+				return &CycleError{Path: path}
+			case unvisited:
+				stack = append(stack, ref)
+				if err := visit(to); err != nil {
+					return err
+				}
+				stack = stack[:len(stack)-1]
+			}
+		}
+		state[k] = visited
+		return nil
+	}
+
+	var all []cloud.ResourceMapKey
+	for k := range outRefs {
+		all = append(all, k)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ToID().String() < all[j].ToID().String() })
+	for _, k := range all {
+		if state[k] == unvisited {
+			if err := visit(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // validate the graph.
 func (g *Builder) validate() error {
 	for _, n := range g.nodes {
@@ -111,6 +276,21 @@ func (g *Builder) validate() error {
 		if n.Ownership() == rnode.OwnershipUnknown {
 			return fmt.Errorf("%s: node %s has ownership %s", builderErrPrefix, n.ID(), n.Ownership())
 		}
+		// Resources that will be created by us have valid names.
+		if n.Ownership() == rnode.OwnershipManaged && n.ID().Key != nil {
+			key := n.ID().Key
+			if n.ID().IsSubResource() {
+				// Only the parent is a named GCE resource.
+				_, sub, err := n.ID().SubResource()
+				if err != nil {
+					return fmt.Errorf("%s: node %s: %w", builderErrPrefix, n.ID(), err)
+				}
+				key = &sub.Parent
+			}
+			if err := key.ValidateName(); err != nil {
+				return fmt.Errorf("%s: node %s: %w", builderErrPrefix, n.ID(), err)
+			}
+		}
 		// ResourceID is not mismatched
 		resource := n.Resource()
 		if resource != nil && !resource.ResourceID().Equal(n.ID()) {
@@ -128,7 +308,7 @@ func (g *Builder) validate() error {
 		}
 		for _, d := range deps {
 			if _, ok := g.nodes[d.To.MapKey()]; !ok {
-				return fmt.Errorf("%s: missing outRef: %v points to %v which isn't in the graph", builderErrPrefix, n.ID(), d.To)
+				return &DanglingRefError{Ref: d}
 			}
 		}
 	}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rgraph
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// ExportDOT returns a .dot (http://graphviz.org) representation of the
+// resource graph for visualization. Nodes are annotated with their state,
+// ownership and plan and colored by the planned operation. Edges are the
+// references between the resources, labelled with the referencing field.
+//
+// The output is sorted so that the same graph always gives the same output.
+func ExportDOT(g *Graph) string {
+	nodes := g.All()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID().String() < nodes[j].ID().String() })
+
+	var buf bytes.Buffer
+	buf.WriteString("digraph G {\n")
+	buf.WriteString("  rankdir=TB\n") // layout top to bottom.
+
+	for _, node := range nodes {
+		dn := &dotNode{
+			name:      node.ID().String(),
+			shape:     "box",
+			style:     "filled",
+			fillcolor: opColor(node.Plan().Op()),
+			kv: map[string]any{
+				"localPlan": node.Plan().GraphvizString(),
+				"state":     node.State(),
+				"ownership": node.Ownership(),
+			},
+		}
+		buf.WriteString(dn.String())
+
+		refs := node.OutRefs()
+		sort.Slice(refs, func(i, j int) bool {
+			if refs[i].To.String() != refs[j].To.String() {
+				return refs[i].To.String() < refs[j].To.String()
+			}
+			return refs[i].Path.String() < refs[j].Path.String()
+		})
+		for _, ref := range refs {
+			buf.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [label=<%s>]\n", node.ID(), ref.To, ref.Path))
+		}
+	}
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+func opColor(op rnode.Operation) string {
+	switch op {
+	case rnode.OpCreate:
+		return "palegreen"
+	case rnode.OpDelete:
+		return "pink"
+	case rnode.OpRecreate:
+		return "yellow"
+	case rnode.OpUpdate:
+		return "khaki1"
+	case rnode.OpNothing:
+		return "gray90"
+	case rnode.OpUnknown:
+		return "gray90"
+	}
+	return "mediumpurple1"
+}
+
+type dotNode struct {
+	name string
+
+	fillcolor string
+	shape     string
+	style     string
+
+	kv map[string]any
+}
+
+func (n *dotNode) String() string {
+	var buf bytes.Buffer
+	outf := func(indent int, s string, args ...any) {
+		for i := 0; i < indent; i++ {
+			buf.WriteString("  ")
+		}
+		buf.WriteString(fmt.Sprintf(s, args...))
+		buf.WriteString("\n")
+	}
+
+	outf(1, "\"%s\" [label=<", n.name)
+	outf(2, "<table border=\"0\">")
+	outf(3, "<tr><td colspan=\"2\"><font point-size=\"16\">\\N</font></td></tr>")
+	outf(3, "<tr><td colspan=\"2\">---</td></tr>")
+
+	var keys []string
+	for k := range n.kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		outf(3, "<tr><td>%s</td><td align=\"left\">%v</td></tr>", k, n.kv[k])
+	}
+	outf(2, "</table>")
+
+	var attribs string
+	for _, at := range []struct {
+		key string
+		val string
+	}{
+		{"fillcolor", n.fillcolor},
+		{"shape", n.shape},
+		{"style", n.style},
+	} {
+		if at.val != "" {
+			attribs += fmt.Sprintf(",%s=%s", at.key, at.val)
+		}
+	}
+	outf(1, ">%s]", attribs)
+
+	return buf.String()
+}
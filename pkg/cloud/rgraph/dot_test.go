@@ -0,0 +1,73 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rgraph
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/fake"
+)
+
+func TestExportDOT(t *testing.T) {
+	ids := make([]*cloud.ResourceID, 3)
+	for i := 0; i < len(ids); i++ {
+		ids[i] = &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey(fmt.Sprintf("r%d", i))}
+	}
+
+	b := NewBuilder()
+	b0 := fake.NewBuilder(ids[0])
+	b0.FakeOutRefs = append(b0.FakeOutRefs,
+		rnode.ResourceRef{From: ids[0], To: ids[2]},
+		rnode.ResourceRef{From: ids[0], To: ids[1]},
+	)
+	b.Add(b0)
+	b.Add(fake.NewBuilder(ids[1]))
+	b.Add(fake.NewBuilder(ids[2]))
+	for _, id := range ids {
+		b.Get(id).SetOwnership(rnode.OwnershipManaged)
+	}
+	g := b.MustBuild()
+
+	out := ExportDOT(g)
+	t.Logf("ExportDOT() = \n%s", out)
+
+	// Output must be stable.
+	for i := 0; i < 5; i++ {
+		if again := ExportDOT(g); again != out {
+			t.Fatalf("ExportDOT() is not stable:\n%s\n%s", out, again)
+		}
+	}
+	for _, s := range []string{
+		fmt.Sprintf("%q -> %q", ids[0], ids[1]),
+		fmt.Sprintf("%q -> %q", ids[0], ids[2]),
+		fmt.Sprintf("%q [label=<", ids[1]),
+		"fillcolor=gray90",
+		"<tr><td>ownership</td>",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("ExportDOT() does not contain %q", s)
+		}
+	}
+	if r1, r2 := strings.Index(out, fmt.Sprintf("%q -> %q", ids[0], ids[1])), strings.Index(out, fmt.Sprintf("%q -> %q", ids[0], ids[2])); r1 > r2 {
+		t.Errorf("edges are not sorted")
+	}
+}
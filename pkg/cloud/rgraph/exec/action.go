@@ -47,6 +47,55 @@ type Action interface {
 	Metadata() *ActionMetadata
 }
 
+// ResourceAction is implemented by Actions that operate on a single
+// resource. This is used to limit the concurrency by scope (see
+// ScopeConcurrencyOption).
+type ResourceAction interface {
+	Action
+	// ResourceID the Action operates on.
+	ResourceID() *cloud.ResourceID
+}
+
+// scopeKey returns the (service, scope) of the Action. Returns false if the
+// Action does not implement ResourceAction.
+func scopeKey(a Action) (string, bool) {
+	ra, ok := a.(ResourceAction)
+	if !ok || ra.ResourceID() == nil || ra.ResourceID().Key == nil {
+		return "", false
+	}
+	id := ra.ResourceID()
+	scope := "global"
+	switch {
+	case id.Key.Zone != "":
+		scope = "zones/" + id.Key.Zone
+	case id.Key.Region != "":
+		scope = "regions/" + id.Key.Region
+	}
+	return fmt.Sprintf("%s/%s:%s/%s", id.APIGroup, id.Resource, id.ProjectID, scope), true
+}
+
+// GroupedAction is an Action that belongs to a concurrency group. The
+// parallel executor runs at most one Action of each group at a time, e.g.
+// all of the Actions mutating the same UrlMap, to avoid GCE operation
+// conflicts (409 resourceNotReady) by construction. Actions embedding
+// ActionBase implement this with ActionBase.Group.
+type GroupedAction interface {
+	Action
+	// ConcurrencyGroup of the Action. Empty if the Action is not in a
+	// group.
+	ConcurrencyGroup() string
+}
+
+// concurrencyGroup of the Action. Returns false if the Action is not in a
+// group.
+func concurrencyGroup(a Action) (string, bool) {
+	ga, ok := a.(GroupedAction)
+	if !ok || ga.ConcurrencyGroup() == "" {
+		return "", false
+	}
+	return ga.ConcurrencyGroup(), true
+}
+
 type ActionType string
 
 var (
@@ -65,6 +114,9 @@ type ActionMetadata struct {
 	Type ActionType
 	// Summary is a human readable description of this action.
 	Summary string
+	// Annotations are arbitrary metadata attached to the Action after
+	// planning, e.g. the estimated downtime or risk level. See Annotate().
+	Annotations map[string]string
 }
 
 // ActionBase is a helper that implements some standard behaviors of common
@@ -74,10 +126,107 @@ type ActionBase struct {
 	Want EventList
 	// Done tracks the events that have happened. This is for debugging.
 	Done EventList
+	// Annotations set with Annotate(). These are added to the Metadata()
+	// returned by the Metadata() function.
+	Annotations map[string]string
+	// Group is the concurrency group of the Action (see GroupedAction).
+	// Empty if the Action is not in a group.
+	Group string
+	// Post are the events signaled by the Executor in addition to the
+	// events returned by the Action when it completes without error. See
+	// SignalAfter().
+	Post EventList
 }
 
 func (b *ActionBase) CanRun() bool             { return len(b.Want) == 0 }
 func (b *ActionBase) PendingEvents() EventList { return b.Want }
+func (b *ActionBase) ConcurrencyGroup() string { return b.Group }
+
+func (b *ActionBase) annotate(key, value string) {
+	if b.Annotations == nil {
+		b.Annotations = map[string]string{}
+	}
+	b.Annotations[key] = value
+}
+
+func (b *ActionBase) annotations() map[string]string { return b.Annotations }
+
+// Annotate sets the annotation key on the Action. Returns an error if the
+// Action cannot be annotated, i.e. it does not embed ActionBase.
+func Annotate(a Action, key, value string) error {
+	an, ok := a.(interface{ annotate(string, string) })
+	if !ok {
+		return fmt.Errorf("Annotate: %v (%T) cannot be annotated", a, a)
+	}
+	an.annotate(key, value)
+	return nil
+}
+
+// Metadata returns a.Metadata() with the annotations set by Annotate().
+func Metadata(a Action) *ActionMetadata {
+	md := a.Metadata()
+	an, ok := a.(interface{ annotations() map[string]string })
+	if !ok || len(an.annotations()) == 0 {
+		return md
+	}
+	ret := *md
+	ret.Annotations = map[string]string{}
+	for k, v := range md.Annotations {
+		ret.Annotations[k] = v
+	}
+	for k, v := range an.annotations() {
+		ret.Annotations[k] = v
+	}
+	return &ret
+}
+
+// AddWant adds events to wait for before the action CanRun.
+func (b *ActionBase) AddWant(evs ...Event) { b.Want = append(b.Want, evs...) }
+
+// AddPost adds events to signal after the action completes.
+func (b *ActionBase) AddPost(evs ...Event) { b.Post = append(b.Post, evs...) }
+
+func (b *ActionBase) postEvents() EventList { return b.Post }
+
+// SignalAfter makes a signal evs when it completes without error, in
+// addition to its own Events. This is used to make other Actions wait for
+// an Action that does not signal anything, e.g. an update. Returns an error
+// if a does not embed ActionBase.
+func SignalAfter(a Action, evs ...Event) error {
+	p, ok := a.(interface{ AddPost(...Event) })
+	if !ok {
+		return fmt.Errorf("SignalAfter: %v (%T) cannot signal events", a, a)
+	}
+	p.AddPost(evs...)
+	return nil
+}
+
+// dryRunEvents returns the Events signaled by the Action in dry run mode,
+// including the events added with SignalAfter().
+func dryRunEvents(a Action) EventList {
+	// Copy as the Action may return its own EventList.
+	return append(append(EventList(nil), a.DryRun()...), postEvents(a)...)
+}
+
+// postEvents of the Action. See SignalAfter().
+func postEvents(a Action) EventList {
+	if p, ok := a.(interface{ postEvents() EventList }); ok {
+		return p.postEvents()
+	}
+	return nil
+}
+
+// withPostEvents appends the postEvents() of the Action after it completes
+// without error.
+func withPostEvents(run func(context.Context, cloud.Cloud, Action) (EventList, error)) func(context.Context, cloud.Cloud, Action) (EventList, error) {
+	return func(ctx context.Context, c cloud.Cloud, a Action) (EventList, error) {
+		events, err := run(ctx, c, a)
+		if err != nil || len(postEvents(a)) == 0 {
+			return events, err
+		}
+		return append(append(EventList(nil), events...), postEvents(a)...), nil
+	}
+}
 
 func (b *ActionBase) Signal(ev Event) bool {
 	for i, wantEv := range b.Want {
@@ -162,3 +162,58 @@ func TestEventAction(t *testing.T) {
 		t.Errorf("diff: -got/+want: %s", diff)
 	}
 }
+
+func TestAnnotate(t *testing.T) {
+	a := &testAction{name: "a"}
+	if md := Metadata(a); md.Annotations != nil {
+		t.Errorf("Metadata(a).Annotations = %v, want nil", md.Annotations)
+	}
+	for _, kv := range [][2]string{{"risk", "low"}, {"downtime", "0s"}, {"risk", "high"}} {
+		if err := Annotate(a, kv[0], kv[1]); err != nil {
+			t.Fatalf("Annotate(a, %q, %q) = %v, want nil", kv[0], kv[1], err)
+		}
+	}
+	want := map[string]string{"risk": "high", "downtime": "0s"}
+	if diff := cmp.Diff(Metadata(a).Annotations, want); diff != "" {
+		t.Errorf("Metadata(a).Annotations diff: -got/+want: %s", diff)
+	}
+	// a.Metadata() is unchanged.
+	if got := a.Metadata().Annotations; got != nil {
+		t.Errorf("a.Metadata().Annotations = %v, want nil", got)
+	}
+
+	if err := Annotate(&struct{ testAction }{}, "k", "v"); err != nil {
+		t.Errorf("Annotate(embedded) = %v, want nil", err)
+	}
+}
+
+func TestSignalAfter(t *testing.T) {
+	// B waits for an event that is only signaled with SignalAfter().
+	a := &testAction{name: "A"}
+	b := &testAction{name: "B"}
+	b.Want = EventList{StringEvent("a-done")}
+	if err := SignalAfter(a, StringEvent("a-done")); err != nil {
+		t.Fatalf("SignalAfter() = %v, want nil", err)
+	}
+	if err := SignalAfter(NewExistsAction(nil), StringEvent("x")); err == nil {
+		t.Errorf("SignalAfter(eventAction) = nil, want error")
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		b.Want = EventList{StringEvent("a-done")}
+		ex, err := NewSerialExecutor([]Action{b, a}, DryRunOption(dryRun))
+		if err != nil {
+			t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+		}
+		result, err := ex.Run(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Run() = %v, want nil (dryRun=%t)", err, dryRun)
+		}
+		if len(result.Completed) != 2 {
+			t.Errorf("Completed = %v, want [A, B] (dryRun=%t)", result.Completed, dryRun)
+		}
+	}
+	if got := dryRunEvents(a); len(got) != 1 || !got[0].Equal(StringEvent("a-done")) {
+		t.Errorf("dryRunEvents(a) = %v, want [a-done]", got)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+// CoalescingAction is implemented by Actions that can be merged with other
+// Actions on the same resource, e.g. two updates that can be done with a
+// single API call.
+type CoalescingAction interface {
+	ResourceAction
+	// Coalesce returns a single Action that does the work of the receiver
+	// followed by other. The returned Action must wait for the
+	// PendingEvents() of both and signal the Events of both. Returns false
+	// if the Actions cannot be merged.
+	Coalesce(other Action) (Action, bool)
+}
+
+// Coalesce returns the Actions with the duplicate event Actions (e.g.
+// NewExistsAction() for the same resource) removed and the Actions on the
+// same resource merged where possible (see CoalescingAction). Actions are not
+// merged if one of them (transitively) waits for the other as the merged
+// Action would never run.
+func Coalesce(actions []Action) []Action {
+	var ret []Action
+	seenEvents := map[string]bool{}
+	// last is the index in ret of the last Action for a resource.
+	last := map[string]int{}
+
+	for _, a := range actions {
+		if ea, ok := a.(*eventAction); ok {
+			name := ea.Metadata().Name
+			if seenEvents[name] {
+				continue
+			}
+			seenEvents[name] = true
+			ret = append(ret, a)
+			continue
+		}
+		ra, ok := a.(ResourceAction)
+		if !ok || ra.ResourceID() == nil {
+			ret = append(ret, a)
+			continue
+		}
+		key := ra.ResourceID().String()
+		if i, ok := last[key]; ok {
+			if ca, ok := ret[i].(CoalescingAction); ok && !waitsFor(actions, a, ret[i]) && !waitsFor(actions, ret[i], a) {
+				if merged, ok := ca.Coalesce(a); ok {
+					ret[i] = merged
+					continue
+				}
+			}
+		}
+		last[key] = len(ret)
+		ret = append(ret, a)
+	}
+	return ret
+}
+
+// waitsFor returns true if a waits (transitively through the other Actions)
+// for an Event signaled by b.
+func waitsFor(actions []Action, a, b Action) bool {
+	signaled := map[string]bool{}
+	for _, ev := range dryRunEvents(b) {
+		signaled[ev.String()] = true
+	}
+	waits := func(x Action) bool {
+		for _, ev := range x.PendingEvents() {
+			if signaled[ev.String()] {
+				return true
+			}
+		}
+		return false
+	}
+	// Propagate the signaled Events until there are no changes.
+	done := map[Action]bool{a: true, b: true}
+	for changed := true; changed; {
+		changed = false
+		for _, x := range actions {
+			if done[x] || !waits(x) {
+				continue
+			}
+			done[x] = true
+			changed = true
+			for _, ev := range dryRunEvents(x) {
+				signaled[ev.String()] = true
+			}
+		}
+	}
+	return waits(a)
+}
+
+// MergeEvents returns the union of the EventLists, keeping the order.
+func MergeEvents(lists ...EventList) EventList {
+	var ret EventList
+	seen := map[string]bool{}
+	for _, l := range lists {
+		for _, ev := range l {
+			if !seen[ev.String()] {
+				seen[ev.String()] = true
+				ret = append(ret, ev)
+			}
+		}
+	}
+	return ret
+}
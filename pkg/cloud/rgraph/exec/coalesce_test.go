@@ -0,0 +1,145 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+// mergeableAction merges with other mergeableActions; the name of the merged
+// Action is the concatenation of the names.
+type mergeableAction struct {
+	testAction
+	id *cloud.ResourceID
+}
+
+func (a *mergeableAction) ResourceID() *cloud.ResourceID { return a.id }
+
+func (a *mergeableAction) Coalesce(other Action) (Action, bool) {
+	o, ok := other.(*mergeableAction)
+	if !ok {
+		return nil, false
+	}
+	ret := &mergeableAction{
+		testAction: testAction{
+			name:   a.name + "+" + o.name,
+			events: MergeEvents(a.events, o.events),
+		},
+		id: a.id,
+	}
+	ret.Want = MergeEvents(a.PendingEvents(), o.PendingEvents())
+	return ret, true
+}
+
+func TestCoalesce(t *testing.T) {
+	id1 := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("r1")}
+	id2 := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("r2")}
+	newAction := func(name string, id *cloud.ResourceID, want ...string) *mergeableAction {
+		a := &mergeableAction{
+			testAction: testAction{name: name, events: EventList{StringEvent(name)}},
+			id:         id,
+		}
+		for _, w := range want {
+			a.Want = append(a.Want, StringEvent(w))
+		}
+		return a
+	}
+	name := func(a Action) string {
+		switch a := a.(type) {
+		case *mergeableAction:
+			return a.name
+		case *testAction:
+			return a.name
+		}
+		return a.String()
+	}
+
+	for _, tc := range []struct {
+		name    string
+		actions []Action
+		want    []string
+	}{
+		{
+			name: "duplicate event actions",
+			actions: []Action{
+				NewExistsAction(id1),
+				NewExistsAction(id1),
+				NewExistsAction(id2),
+			},
+			want: []string{
+				NewExistsAction(id1).String(),
+				NewExistsAction(id2).String(),
+			},
+		},
+		{
+			name: "same resource",
+			actions: []Action{
+				newAction("A", id1, "X"),
+				newAction("B", id2),
+				newAction("C", id1, "Y"),
+			},
+			want: []string{"A+C", "B"},
+		},
+		{
+			name: "different resources",
+			actions: []Action{
+				newAction("A", id1),
+				newAction("B", id2),
+			},
+			want: []string{"A", "B"},
+		},
+		{
+			name: "dependency",
+			actions: []Action{
+				newAction("A", id1),
+				newAction("C", id1, "A"),
+			},
+			want: []string{"A", "C"},
+		},
+		{
+			name: "transitive dependency",
+			actions: []Action{
+				newAction("A", id1),
+				newAction("B", id2, "A"),
+				newAction("C", id1, "B"),
+			},
+			want: []string{"A", "B", "C"},
+		},
+		{
+			name: "not coalescing",
+			actions: []Action{
+				&testAction{name: "A"},
+				&testAction{name: "B"},
+			},
+			want: []string{"A", "B"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, a := range Coalesce(tc.actions) {
+				got = append(got, name(a))
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Coalesce(): -got,+want: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// ErrAborted is returned (wrapped) by the Executor when the execution was
+// stopped with Handle.Abort().
+var ErrAborted = errors.New("execution aborted")
+
+// Handle controls an Executor running in the background. See RunAsync().
+type Handle struct {
+	ctl  *control
+	done chan struct{}
+
+	// result and err are set before done is closed.
+	result *Result
+	err    error
+}
+
+// RunAsync runs the Executor in a goroutine. The Handle can be used to
+// pause, resume or abort the execution, e.g. for an emergency stop during an
+// incident. Returns an error if the Executor does not support runtime
+// control.
+func RunAsync(ctx context.Context, ex Executor, c cloud.Cloud) (*Handle, error) {
+	cex, ok := ex.(interface{ setControl(*control) })
+	if !ok {
+		return nil, fmt.Errorf("RunAsync: %T does not support runtime control", ex)
+	}
+	h := &Handle{
+		ctl:  newControl(),
+		done: make(chan struct{}),
+	}
+	cex.setControl(h.ctl)
+	go func() {
+		h.result, h.err = ex.Run(ctx, c)
+		close(h.done)
+	}()
+	return h, nil
+}
+
+// Pause stops the Executor from starting new Actions. The Actions that are
+// running are not interrupted. The execution does not finish while it is
+// paused.
+func (h *Handle) Pause() { h.ctl.set(func() { h.ctl.paused = true }) }
+
+// Resume starting Actions after Pause().
+func (h *Handle) Resume() { h.ctl.set(func() { h.ctl.paused = false }) }
+
+// Abort the execution gracefully. No new Actions are started and the
+// Actions that are running are allowed to finish. The Actions that were not
+// started are returned in Result.Canceled and Run() returns ErrAborted. This
+// overrides Pause(). The completed Actions are not rolled back unless
+// RollbackOnAbortOption is set.
+func (h *Handle) Abort() { h.ctl.set(func() { h.ctl.aborted = true }) }
+
+// Paused returns true if the execution is paused.
+func (h *Handle) Paused() bool {
+	paused, _, _ := h.ctl.state()
+	return paused
+}
+
+// Done is closed when the execution has finished.
+func (h *Handle) Done() <-chan struct{} { return h.done }
+
+// Wait for the execution to finish and return the result of Run().
+func (h *Handle) Wait() (*Result, error) {
+	<-h.done
+	return h.result, h.err
+}
+
+// control is the runtime state shared between a Handle and the Executor. A
+// nil *control is never paused nor aborted.
+type control struct {
+	lock    sync.Mutex
+	paused  bool
+	aborted bool
+	// changed is closed (and replaced) when the state changes.
+	changed chan struct{}
+}
+
+func newControl() *control {
+	return &control{changed: make(chan struct{})}
+}
+
+// set the state with f and wake up the waiters.
+func (c *control) set(f func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	f()
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// state returns the current state and a channel that is closed on the next
+// change.
+func (c *control) state() (paused, aborted bool, changed <-chan struct{}) {
+	if c == nil {
+		return false, false, nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.paused, c.aborted, c.changed
+}
+
+// wait while the execution is paused. Returns true if the execution was
+// aborted. Returns false if the context is done.
+func (c *control) wait(ctx context.Context) bool {
+	for {
+		paused, aborted, changed := c.state()
+		if aborted {
+			return true
+		}
+		if !paused {
+			return false
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
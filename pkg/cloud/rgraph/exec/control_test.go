@@ -0,0 +1,244 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/google/go-cmp/cmp"
+)
+
+// blockingAction signals started when it is run and blocks until release
+// is closed.
+type blockingAction struct {
+	testAction
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *blockingAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	close(a.started)
+	<-a.release
+	return a.testAction.Run(ctx, c)
+}
+
+func TestRunAsync(t *testing.T) {
+	names := func(l []Action) []string {
+		var ret []string
+		for _, a := range l {
+			ret = append(ret, a.String())
+		}
+		sort.Strings(ret)
+		return ret
+	}
+
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		for _, tc := range []struct {
+			name string
+			// control is called while A is running.
+			control       func(h *Handle)
+			wantPaused    bool
+			wantErr       error
+			wantCompleted []string
+			wantCanceled  []string
+		}{
+			{
+				name:          "no control",
+				control:       func(h *Handle) {},
+				wantCompleted: []string{"A([A])", "B([B])", "C([C])"},
+			},
+			{
+				name:          "pause and resume",
+				control:       func(h *Handle) { h.Pause() },
+				wantPaused:    true,
+				wantCompleted: []string{"A([A])", "B([B])", "C([C])"},
+			},
+			{
+				name:          "abort",
+				control:       func(h *Handle) { h.Abort() },
+				wantErr:       ErrAborted,
+				wantCompleted: []string{"A([A])"},
+				wantCanceled:  []string{"B([B])", "C([C])"},
+			},
+			{
+				name:          "abort while paused",
+				control:       func(h *Handle) { h.Pause(); h.Abort() },
+				wantErr:       ErrAborted,
+				wantCompleted: []string{"A([A])"},
+				wantCanceled:  []string{"B([B])", "C([C])"},
+			},
+		} {
+			t.Run(newExecutor.name+"/"+tc.name, func(t *testing.T) {
+				// A -> B -> C, A blocks until released.
+				a := &blockingAction{
+					testAction: testAction{name: "A", events: EventList{StringEvent("A")}},
+					started:    make(chan struct{}),
+					release:    make(chan struct{}),
+				}
+				b := &testAction{name: "B", events: EventList{StringEvent("B")}}
+				b.Want = EventList{StringEvent("A")}
+				c := &testAction{name: "C", events: EventList{StringEvent("C")}}
+				c.Want = EventList{StringEvent("B")}
+
+				ex, err := newExecutor.f([]Action{a, b, c})
+				if err != nil {
+					t.Fatalf("newExecutor() = %v, want nil", err)
+				}
+				h, err := RunAsync(context.Background(), ex, nil)
+				if err != nil {
+					t.Fatalf("RunAsync() = %v, want nil", err)
+				}
+				<-a.started
+				tc.control(h)
+				close(a.release)
+
+				if tc.wantPaused {
+					// The execution must not finish while paused.
+					select {
+					case <-h.Done():
+						t.Fatalf("execution finished while paused")
+					case <-time.After(50 * time.Millisecond):
+					}
+					if !h.Paused() {
+						t.Errorf("h.Paused() = false, want true")
+					}
+					h.Resume()
+				}
+
+				result, err := h.Wait()
+				if tc.wantErr == nil && err != nil {
+					t.Fatalf("Wait() = _, %v, want nil", err)
+				}
+				if tc.wantErr != nil && !errors.Is(err, tc.wantErr) {
+					t.Errorf("Wait() = _, %v, want %v", err, tc.wantErr)
+				}
+				if diff := cmp.Diff(names(result.Completed), tc.wantCompleted); diff != "" {
+					t.Errorf("Completed: -got,+want: %s", diff)
+				}
+				if diff := cmp.Diff(names(result.Canceled), tc.wantCanceled); diff != "" {
+					t.Errorf("Canceled: -got,+want: %s", diff)
+				}
+				if len(result.Pending) > 0 || len(result.Errors) > 0 {
+					t.Errorf("Pending = %v, Errors = %v, want none", result.Pending, result.Errors)
+				}
+			})
+		}
+	}
+}
+
+func TestRunAsyncCancelWhilePaused(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := &blockingAction{
+		testAction: testAction{name: "A", events: EventList{StringEvent("A")}},
+		started:    make(chan struct{}),
+		release:    make(chan struct{}),
+	}
+	b := &testAction{name: "B", events: EventList{StringEvent("B")}}
+	b.Want = EventList{StringEvent("A")}
+	ex, err := NewParallelExecutor([]Action{a, b})
+	if err != nil {
+		t.Fatalf("NewParallelExecutor() = %v, want nil", err)
+	}
+	h, err := RunAsync(ctx, ex, nil)
+	if err != nil {
+		t.Fatalf("RunAsync() = %v, want nil", err)
+	}
+	<-a.started
+	h.Pause()
+	close(a.release)
+	cancel()
+
+	result, err := h.Wait()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() = _, %v, want %v", err, context.Canceled)
+	}
+	if len(result.Canceled) != 1 || result.Canceled[0] != b {
+		t.Errorf("Canceled = %v, want [B]", result.Canceled)
+	}
+}
+
+// reversibleBlockingAction is a blockingAction that is reverted with
+// "undo-<name>".
+type reversibleBlockingAction struct {
+	blockingAction
+}
+
+func (a *reversibleBlockingAction) Inverse() (Action, error) {
+	return &testAction{name: "undo-" + a.name}, nil
+}
+
+func TestRunAsyncAbortRollback(t *testing.T) {
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		for _, tc := range []struct {
+			name            string
+			rollbackOnAbort bool
+			wantRolledBack  int
+		}{
+			{name: "abort is not rolled back"},
+			{name: "RollbackOnAbort", rollbackOnAbort: true, wantRolledBack: 1},
+		} {
+			t.Run(newExecutor.name+"/"+tc.name, func(t *testing.T) {
+				a := &reversibleBlockingAction{blockingAction{
+					testAction: testAction{name: "A", events: EventList{StringEvent("A")}},
+					started:    make(chan struct{}),
+					release:    make(chan struct{}),
+				}}
+				b := &testAction{name: "B", events: EventList{StringEvent("B")}}
+				b.Want = EventList{StringEvent("A")}
+
+				ex, err := newExecutor.f([]Action{a, b}, RollbackOption(true), RollbackOnAbortOption(tc.rollbackOnAbort))
+				if err != nil {
+					t.Fatalf("newExecutor() = %v, want nil", err)
+				}
+				h, err := RunAsync(context.Background(), ex, nil)
+				if err != nil {
+					t.Fatalf("RunAsync() = %v, want nil", err)
+				}
+				<-a.started
+				h.Abort()
+				close(a.release)
+
+				result, err := h.Wait()
+				if !errors.Is(err, ErrAborted) {
+					t.Errorf("Wait() = _, %v, want %v", err, ErrAborted)
+				}
+				if len(result.RolledBack) != tc.wantRolledBack {
+					t.Errorf("RolledBack = %v, want %d Actions", result.RolledBack, tc.wantRolledBack)
+				}
+			})
+		}
+	}
+}
@@ -26,4 +26,15 @@ limitations under the License.
 // - CTP does not have dependencies.
 //
 // Then the execution trace will be CTP -> TPE -> CFR.
+//
+// NewSerialExecutor runs one Action at a time. NewParallelExecutor runs
+// the Actions that do not depend on each other concurrently. RunAsync runs
+// an Executor in the background and returns a Handle to pause, resume or
+// abort the execution.
+//
+// NewWaitAction polls for a condition (e.g. the endpoints of a NEG being
+// healthy) and Gate makes other Actions wait for it, e.g. to not switch
+// traffic until the backends are healthy. Similarly, NewDrainAction waits
+// for the connections to drain after a reference is dropped so that the
+// deletion of the old backend can be gated on it.
 package exec
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// ExportDOT returns a .dot (http://graphviz.org) representation of the
+// Actions and the Events between them before they are executed. Actions are
+// colored by type. Events are drawn as edges from the Action that signals the
+// Event to the Event, and from the Event to the Actions waiting for it.
+// Events that are not signaled by any Action are highlighted.
+//
+// ExportDOT calls DryRun() on the Actions to find the Events they signal
+// (see also SignalAfter()).
+func ExportDOT(actions []Action) string {
+	type actionInfo struct {
+		md      *ActionMetadata
+		signals []string
+		waits   []string
+	}
+	var infos []actionInfo
+	signaled := map[string]bool{}
+	events := map[string]bool{}
+
+	for _, a := range actions {
+		info := actionInfo{md: a.Metadata()}
+		for _, ev := range dryRunEvents(a) {
+			info.signals = append(info.signals, ev.String())
+			signaled[ev.String()] = true
+			events[ev.String()] = true
+		}
+		for _, ev := range a.PendingEvents() {
+			info.waits = append(info.waits, ev.String())
+			events[ev.String()] = true
+		}
+		sort.Strings(info.signals)
+		sort.Strings(info.waits)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].md.Name < infos[j].md.Name })
+
+	var buf bytes.Buffer
+	outf := func(s string, args ...any) { buf.WriteString(fmt.Sprintf(s+"\n", args...)) }
+
+	outf("digraph {")
+	for _, info := range infos {
+		outf("  \"%s\" [style=filled,fillcolor=%s,shape=box,label=<", info.md.Name, actionTypeToColor(info.md.Type))
+		outf("    <table border=\"0\">")
+		outf("      <tr><td>\\N</td></tr>")
+		outf("      <tr><td>%s</td></tr>", info.md.Summary)
+		outf("    </table>")
+		outf("  >]")
+	}
+
+	var evList []string
+	for ev := range events {
+		evList = append(evList, ev)
+	}
+	sort.Strings(evList)
+	for _, ev := range evList {
+		if signaled[ev] {
+			outf("  \"%s\" [shape=ellipse]", ev)
+		} else {
+			// Nothing will signal this event; the Actions waiting for it
+			// will never run.
+			outf("  \"%s\" [shape=ellipse,style=filled,color=pink]", ev)
+		}
+	}
+
+	for _, info := range infos {
+		for _, ev := range info.signals {
+			outf("  \"%s\" -> \"%s\"", info.md.Name, ev)
+		}
+		for _, ev := range info.waits {
+			outf("  \"%s\" -> \"%s\"", ev, info.md.Name)
+		}
+	}
+	outf("}")
+
+	return buf.String()
+}
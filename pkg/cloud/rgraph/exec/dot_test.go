@@ -0,0 +1,54 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExportDOT(t *testing.T) {
+	a := &testAction{name: "A", events: EventList{StringEvent("a")}}
+	b := &testAction{name: "B"}
+	b.Want = EventList{StringEvent("a"), StringEvent("x")}
+
+	got := ExportDOT([]Action{b, a})
+	const want = `digraph {
+  "A([a])" [style=filled,fillcolor=khaki,shape=box,label=<
+    <table border="0">
+      <tr><td>\N</td></tr>
+      <tr><td>Action used for testing</td></tr>
+    </table>
+  >]
+  "B([])" [style=filled,fillcolor=khaki,shape=box,label=<
+    <table border="0">
+      <tr><td>\N</td></tr>
+      <tr><td>Action used for testing</td></tr>
+    </table>
+  >]
+  "a" [shape=ellipse]
+  "x" [shape=ellipse,style=filled,color=pink]
+  "A([a])" -> "a"
+  "a" -> "B([])"
+  "x" -> "B([])"
+}
+`
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("ExportDOT() diff -got,+want: %s", diff)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// NewDrainedEvent returns an event that signals that the connections going
+// from -> to have been drained after the reference was dropped. This is
+// signaled by the Action returned by NewDrainAction.
+func NewDrainedEvent(from, to *cloud.ResourceID) Event {
+	return &drainedEvent{from: from, to: to}
+}
+
+type drainedEvent struct {
+	from, to *cloud.ResourceID
+}
+
+func (e *drainedEvent) Equal(other Event) bool {
+	switch other := other.(type) {
+	case *drainedEvent:
+		return e.from.Equal(other.from) && e.to.Equal(other.to)
+	}
+	return false
+}
+
+func (e *drainedEvent) String() string {
+	return fmt.Sprintf("Drained(%v => %v)", e.from, e.to)
+}
+
+// NewDrainAction returns an Action that waits for the reference from -> to
+// to be dropped (NewDropRefEvent), waits d for the in-flight connections to
+// drain and then signals NewDrainedEvent(from, to).
+//
+// Use Gate() to make the deletion of "to" wait for the drain, e.g. to not
+// delete a NetworkEndpointGroup right after it was removed from the
+// BackendService.
+func NewDrainAction(from, to *cloud.ResourceID, d time.Duration) Action {
+	return &drainAction{
+		ActionBase: ActionBase{Want: EventList{NewDropRefEvent(from, to)}},
+		from:       from,
+		to:         to,
+		d:          d,
+	}
+}
+
+type drainAction struct {
+	ActionBase
+
+	from, to *cloud.ResourceID
+	d        time.Duration
+}
+
+// drainAction is intentionally not a ResourceAction: it does not mutate any
+// resource and should not take a slot limited by ScopeConcurrencyOption.
+var _ Action = (*drainAction)(nil)
+
+func (a *drainAction) Run(ctx context.Context, _ cloud.Cloud) (EventList, error) {
+	timer := time.NewTimer(a.d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%v: %w", a, ctx.Err())
+	case <-timer.C:
+	}
+	return a.DryRun(), nil
+}
+
+func (a *drainAction) DryRun() EventList {
+	return EventList{NewDrainedEvent(a.from, a.to)}
+}
+
+func (a *drainAction) String() string {
+	return fmt.Sprintf("DrainAction(%v => %v, %v)", a.from, a.to, a.d)
+}
+
+func (a *drainAction) Metadata() *ActionMetadata {
+	return &ActionMetadata{
+		Name:    a.String(),
+		Type:    ActionTypeCustom,
+		Summary: fmt.Sprintf("Wait %v for the connections from %v to %v to drain", a.d, a.from, a.to),
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// ActionError is the error of an Action that failed. This is the Err in
+// Result.Errors, Result.TimedOut and Result.RollbackErrors. Callers can use
+// ResourceID to attach the failure to the object that owns the resource
+// (see Result.ErrorTree()).
+type ActionError struct {
+	// Name of the Action (Metadata().Name).
+	Name string
+	// ResourceID the Action operates on. nil if the Action is not a
+	// ResourceAction.
+	ResourceID *cloud.ResourceID
+	// Attempts is the number of times the Action was run. This is more
+	// than 1 if the Action was retried (see RetryOption).
+	Attempts int
+	// TimedOut is true if the Action ran out of time.
+	TimedOut bool
+	// OperationURL is the URL of the GCE operation that failed, if the
+	// Action reported it with an OperationError.
+	OperationURL string
+	// Err is the cause.
+	Err error
+}
+
+// Error includes the attempts and timeout. The OperationURL is already in
+// the message of the OperationError.
+func (e *ActionError) Error() string {
+	var extra []string
+	if e.Attempts > 1 {
+		extra = append(extra, fmt.Sprintf("attempts: %d", e.Attempts))
+	}
+	if e.TimedOut {
+		extra = append(extra, "timed out")
+	}
+	if len(extra) == 0 {
+		return fmt.Sprintf("%s: %v", e.Name, e.Err)
+	}
+	return fmt.Sprintf("%s: %v (%s)", e.Name, e.Err, strings.Join(extra, ", "))
+}
+
+func (e *ActionError) Unwrap() error { return e.Err }
+
+// newActionError returns the ActionError for the error returned by running
+// the Action.
+func newActionError(a Action, err error, timedOut bool) *ActionError {
+	ret := &ActionError{
+		Name:     a.Metadata().Name,
+		Attempts: 1,
+		TimedOut: timedOut,
+		Err:      err,
+	}
+	if ra, ok := a.(ResourceAction); ok {
+		ret.ResourceID = ra.ResourceID()
+	}
+	var re *retriedError
+	if errors.As(err, &re) {
+		ret.Attempts = re.attempts
+		ret.Err = re.err
+	}
+	var oe *OperationError
+	if errors.As(err, &oe) {
+		ret.OperationURL = oe.URL
+	}
+	return ret
+}
+
+// OperationError is returned by Actions to report the GCE operation that
+// failed.
+type OperationError struct {
+	// URL (SelfLink) of the operation.
+	URL string
+	Err error
+}
+
+func (e *OperationError) Error() string { return fmt.Sprintf("operation %s: %v", e.URL, e.Err) }
+func (e *OperationError) Unwrap() error { return e.Err }
+
+// retriedError is the error of an Action after all of the attempts failed.
+// It is replaced by ActionError.Attempts.
+type retriedError struct {
+	attempts int
+	err      error
+}
+
+func (e *retriedError) Error() string { return e.err.Error() }
+func (e *retriedError) Unwrap() error { return e.err }
+
+// ErrorTree groups the errors of an execution by the resource the Actions
+// operate on.
+type ErrorTree struct {
+	// Resources are the errors by resource, sorted by ID.
+	Resources []*ResourceErrors
+	// Other are the errors of Actions that do not operate on a single
+	// resource.
+	Other []*ActionError
+}
+
+// ResourceErrors are the errors of the Actions on a resource.
+type ResourceErrors struct {
+	ID     *cloud.ResourceID
+	Errors []*ActionError
+}
+
+// ErrorTree returns the errors of the execution (including timeouts and
+// errors in rollback) grouped by resource. Returns nil if there were no
+// errors.
+func (r *Result) ErrorTree() *ErrorTree {
+	var all []ActionWithErr
+	all = append(all, r.Errors...)
+	all = append(all, r.TimedOut...)
+	all = append(all, r.RollbackErrors...)
+	if len(all) == 0 {
+		return nil
+	}
+
+	ret := &ErrorTree{}
+	byID := map[cloud.ResourceMapKey]*ResourceErrors{}
+	for _, awe := range all {
+		var ae *ActionError
+		if !errors.As(awe.Err, &ae) {
+			ae = newActionError(awe.Action, awe.Err, false)
+		}
+		if ae.ResourceID == nil {
+			ret.Other = append(ret.Other, ae)
+			continue
+		}
+		re, ok := byID[ae.ResourceID.MapKey()]
+		if !ok {
+			re = &ResourceErrors{ID: ae.ResourceID}
+			byID[ae.ResourceID.MapKey()] = re
+			ret.Resources = append(ret.Resources, re)
+		}
+		re.Errors = append(re.Errors, ae)
+	}
+	sort.Slice(ret.Resources, func(i, j int) bool {
+		return ret.Resources[i].ID.String() < ret.Resources[j].ID.String()
+	})
+	return ret
+}
+
+// For returns the errors of the Actions on the resource id.
+func (t *ErrorTree) For(id *cloud.ResourceID) []*ActionError {
+	if t == nil {
+		return nil
+	}
+	for _, re := range t.Resources {
+		if re.ID.Equal(id) {
+			return re.Errors
+		}
+	}
+	return nil
+}
+
+// Summary returns a human readable summary with one line per resource, e.g.
+// for a Kubernetes condition message.
+func (t *ErrorTree) Summary() string {
+	if t == nil {
+		return ""
+	}
+	var lines []string
+	for _, re := range t.Resources {
+		var l []string
+		for _, ae := range re.Errors {
+			l = append(l, ae.Error())
+		}
+		lines = append(lines, fmt.Sprintf("%v: %s", re.ID, strings.Join(l, "; ")))
+	}
+	for _, ae := range t.Other {
+		lines = append(lines, ae.Error())
+	}
+	return strings.Join(lines, "\n")
+}
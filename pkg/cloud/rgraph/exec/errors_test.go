@@ -0,0 +1,105 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestErrorTree(t *testing.T) {
+	id1 := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("r1")}
+	id2 := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("r2")}
+	errInjected := errors.New("injected")
+
+	// A fails in its operation, A and B are retried and C does not operate
+	// on a resource.
+	a := &mergeableAction{
+		testAction: testAction{name: "A", err: &OperationError{URL: "ops/op-1", Err: errInjected}},
+		id:         id1,
+	}
+	b := &mergeableAction{
+		testAction: testAction{name: "B", err: errInjected},
+		id:         id2,
+	}
+	c := &testAction{name: "C", err: errInjected}
+	ok := &mergeableAction{testAction: testAction{name: "D"}, id: id2}
+
+	registry := NewRetryRegistry(nil)
+	registry.RegisterResource("fake", noWait{3})
+	ex, err := NewSerialExecutor([]Action{a, b, c, ok}, ErrorStrategyOption(ContinueOnError), RetryOption(registry))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	result, err := ex.Run(context.Background(), nil)
+	if err == nil {
+		t.Fatalf("Run() = nil, want error")
+	}
+
+	for _, awe := range result.Errors {
+		if !errors.Is(awe.Err, errInjected) {
+			t.Errorf("errors.Is(%v, errInjected) = false, want true", awe.Err)
+		}
+	}
+
+	tree := result.ErrorTree()
+	if len(tree.Resources) != 2 {
+		t.Fatalf("ErrorTree().Resources = %v, want 2 resources", tree.Resources)
+	}
+	type summary struct {
+		Name         string
+		Attempts     int
+		OperationURL string
+	}
+	summarize := func(l []*ActionError) []summary {
+		var ret []summary
+		for _, ae := range l {
+			ret = append(ret, summary{ae.Name, ae.Attempts, ae.OperationURL})
+		}
+		return ret
+	}
+	for _, tc := range []struct {
+		id   *cloud.ResourceID
+		want []summary
+	}{
+		{id: id1, want: []summary{{Name: a.Metadata().Name, Attempts: 3, OperationURL: "ops/op-1"}}},
+		{id: id2, want: []summary{{Name: b.Metadata().Name, Attempts: 3}}},
+	} {
+		if diff := cmp.Diff(summarize(tree.For(tc.id)), tc.want); diff != "" {
+			t.Errorf("For(%v): -got,+want: %s", tc.id, diff)
+		}
+	}
+	if diff := cmp.Diff(summarize(tree.Other), []summary{{Name: c.Metadata().Name, Attempts: 1}}); diff != "" {
+		t.Errorf("Other: -got,+want: %s", diff)
+	}
+
+	wantSummary := "fake:/r1: A([]): operation ops/op-1: injected (attempts: 3)\n" +
+		"fake:/r2: B([]): injected (attempts: 3)\n" +
+		"C([]): injected"
+	if got := tree.Summary(); got != wantSummary {
+		t.Errorf("Summary() = %q, want %q", got, wantSummary)
+	}
+
+	if tree := (&Result{}).ErrorTree(); tree != nil {
+		t.Errorf("ErrorTree() = %v, want nil for no errors", tree)
+	}
+}
@@ -18,9 +18,14 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/metrics"
+	"k8s.io/klog/v2"
 )
 
 type Result struct {
@@ -31,11 +36,26 @@ type Result struct {
 	// Pending are Actions that could not be executed due to missing
 	// preconditions.
 	Pending []Action
+	// Skipped are Actions that were filtered out by the ApprovalFunc.
+	Skipped []Action
+	// RolledBack are the inverse Actions that were run to revert the
+	// Completed Actions (see RollbackOption).
+	RolledBack []Action
+	// RollbackErrors are the errors from reverting the Completed Actions.
+	RollbackErrors []ActionWithErr
+	// TimedOut are Actions that failed because they ran out of time (see
+	// ActionTimeoutOption and TimeoutOption). These are not in Errors.
+	TimedOut []ActionWithErr
+	// Canceled are Actions that were not started because the context was
+	// canceled or the execution budget was exhausted. These are not in
+	// Pending.
+	Canceled []Action
 }
 
 type ActionWithErr struct {
 	Action Action
-	Err    error
+	// Err is an *ActionError wrapping the error returned by the Action.
+	Err error
 }
 
 // Executor performs the operations given by a list of Actions.
@@ -76,10 +96,63 @@ func ErrorStrategyOption(s ErrorStrategy) Option {
 	return func(c *ExecutorConfig) { c.ErrorStrategy = s }
 }
 
+// MaxConcurrencyOption limits the number of Actions that are run at the same
+// time by the parallel executor. 0 means no limit.
+func MaxConcurrencyOption(n int) Option {
+	return func(c *ExecutorConfig) { c.MaxConcurrency = n }
+}
+
+// ScopeConcurrencyOption limits the number of Actions that are run at the
+// same time by the parallel executor for the same (service, scope), e.g.
+// BackendServices in region us-central1 of a project. This avoids operation
+// conflicts and mutation rate limits in GCE. 0 means no limit. Only Actions
+// implementing ResourceAction are limited.
+func ScopeConcurrencyOption(n int) Option {
+	return func(c *ExecutorConfig) { c.ScopeConcurrency = n }
+}
+
+// ApprovalFunc is called with all of the Actions before any of them are
+// executed, e.g. to ask a human or a policy engine to approve the plan. The
+// Metadata() of the Actions describes what they will do. Use the Metadata()
+// function to include the annotations (see Annotate()), e.g. to reject
+// Actions annotated with a high risk.
+//
+// Returns the Actions to execute, which must be a subset of actions. The
+// Actions that are filtered out are not run and the Actions that depend on
+// them will remain pending. Returning an error rejects the execution and no
+// Actions will be run.
+type ApprovalFunc func(ctx context.Context, actions []Action) ([]Action, error)
+
+// ApprovalOption sets the ApprovalFunc to call before execution.
+func ApprovalOption(f ApprovalFunc) Option {
+	return func(c *ExecutorConfig) { c.Approval = f }
+}
+
+// RetryOption retries the Actions that fail with the RetryProvider from the
+// registry, e.g. DefaultRetryRegistry(). Retries are not done in dry run
+// mode.
+func RetryOption(r *RetryRegistry) Option {
+	return func(c *ExecutorConfig) { c.Retry = r }
+}
+
+// ActionTimeoutOption sets the deadline for running each Action. 0 means no
+// deadline.
+func ActionTimeoutOption(d time.Duration) Option {
+	return func(c *ExecutorConfig) { c.ActionTimeout = d }
+}
+
+// TimeoutOption sets the wall-clock budget for the whole execution. Actions
+// are not started after the budget is exhausted and are returned in
+// Result.Canceled. 0 means no limit.
+func TimeoutOption(d time.Duration) Option {
+	return func(c *ExecutorConfig) { c.Timeout = d }
+}
+
 func defaultExecutorConfig() *ExecutorConfig {
 	return &ExecutorConfig{
-		DryRun:        false,
-		ErrorStrategy: StopOnError,
+		DryRun:          false,
+		ErrorStrategy:   StopOnError,
+		RollbackTimeout: DefaultRollbackTimeout,
 	}
 }
 
@@ -88,6 +161,38 @@ type ExecutorConfig struct {
 	Tracer        Tracer
 	DryRun        bool
 	ErrorStrategy ErrorStrategy
+	// MaxConcurrency is the maximum number of concurrent Actions. 0 means
+	// no limit.
+	MaxConcurrency int
+	// ScopeConcurrency is the maximum number of concurrent Actions for the
+	// same (service, scope). 0 means no limit.
+	ScopeConcurrency int
+	// Approval is called before execution. Optional.
+	Approval ApprovalFunc
+	// Rollback the completed Actions if execution fails.
+	Rollback bool
+	// RollbackOnAbort also rolls back when the execution is aborted.
+	RollbackOnAbort bool
+	// RollbackTimeout is the budget for the rollback. 0 means no limit.
+	RollbackTimeout time.Duration
+	// Retry is the registry of RetryProviders for the Actions. Optional.
+	Retry *RetryRegistry
+	// ActionTimeout is the deadline for each Action. 0 means no deadline.
+	ActionTimeout time.Duration
+	// Timeout is the budget for the whole execution. 0 means no limit.
+	Timeout time.Duration
+	// Observers are notified of the progress of the execution.
+	Observers []Observer
+	// CloudRouter selects the Cloud for the project of each Action.
+	// Optional.
+	CloudRouter CloudRouter
+	// Metrics is the Recorder for the metrics of the Actions. Optional.
+	Metrics metrics.Recorder
+	// Progress is updated as the Actions start and finish. Optional.
+	Progress ProgressReporter
+	// DurationHistory is used to estimate the ETA in the Progress.
+	// Optional.
+	DurationHistory *DurationHistory
 }
 
 func (c *ExecutorConfig) validate() error {
@@ -96,5 +201,100 @@ func (c *ExecutorConfig) validate() error {
 	default:
 		return fmt.Errorf("invalid ErrorStrategy: %q", c.ErrorStrategy)
 	}
+	if c.MaxConcurrency < 0 {
+		return fmt.Errorf("invalid MaxConcurrency: %d", c.MaxConcurrency)
+	}
+	if c.ScopeConcurrency < 0 {
+		return fmt.Errorf("invalid ScopeConcurrency: %d", c.ScopeConcurrency)
+	}
+	if c.ActionTimeout < 0 {
+		return fmt.Errorf("invalid ActionTimeout: %v", c.ActionTimeout)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("invalid Timeout: %v", c.Timeout)
+	}
+	if c.RollbackTimeout < 0 {
+		return fmt.Errorf("invalid RollbackTimeout: %v", c.RollbackTimeout)
+	}
 	return nil
 }
+
+// budgetContext returns the context for the whole execution.
+func (c *ExecutorConfig) budgetContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.Timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.Timeout)
+}
+
+// runWithTimeout runs the Action with the ActionTimeout. Returns true if the
+// Action failed because the deadline (of the Action or of the execution) was
+// exceeded. The calls made by the Action log with the name of the Action (see
+// cloud.WithLogValues).
+func (c *ExecutorConfig) runWithTimeout(
+	ctx context.Context,
+	cl cloud.Cloud,
+	a Action,
+	run func(context.Context, cloud.Cloud, Action) (EventList, error),
+) (EventList, bool, error) {
+	if c.ActionTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.ActionTimeout)
+		defer cancel()
+	}
+	ctx = cloud.WithLogValues(ctx, "action", a.String())
+	klog.V(2).InfoS("Running Action", cloud.LogValues(ctx)...)
+
+	start := time.Now()
+	events, err := run(ctx, cl, a)
+	timedOut := err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded)
+
+	if err != nil {
+		klog.ErrorS(err, "Action failed", append([]any{"duration", time.Since(start), "timedOut", timedOut}, cloud.LogValues(ctx)...)...)
+	} else {
+		klog.V(cloud.LogLevelCall).InfoS("Action completed", append([]any{"duration", time.Since(start), "events", len(events)}, cloud.LogValues(ctx)...)...)
+	}
+	return events, timedOut, err
+}
+
+// approve calls the ApprovalFunc (if any) with the pending Actions. Returns
+// the indices of the Actions in pending that were skipped.
+func (c *ExecutorConfig) approve(ctx context.Context, pending []Action) (map[int]bool, error) {
+	if c.Approval == nil {
+		return nil, nil
+	}
+	approved, err := c.Approval(ctx, pending)
+	if err != nil {
+		return nil, fmt.Errorf("actions were not approved: %w", err)
+	}
+	// Actions are matched by identity, as custom Actions may have the same
+	// Metadata().Name.
+	skipped := map[int]bool{}
+	for i := range pending {
+		skipped[i] = true
+	}
+	for _, a := range approved {
+		found := false
+		for i, p := range pending {
+			if sameAction(a, p) {
+				delete(skipped, i)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("approved Action %s is not in the list of Actions", a)
+		}
+	}
+	return skipped, nil
+}
+
+// sameAction is true if a and b are the same Action. Actions with types that
+// cannot be compared (e.g. structs with slices, instead of pointers) are
+// never the same.
+func sameAction(a, b Action) bool {
+	ta := reflect.TypeOf(a)
+	if ta != reflect.TypeOf(b) || !ta.Comparable() {
+		return false
+	}
+	return a == b
+}
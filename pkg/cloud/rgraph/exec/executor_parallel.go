@@ -0,0 +1,386 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// NewParallelExecutor returns a new Executor that runs the Actions that are
+// ready (i.e. CanRun()) concurrently. Actions are started as soon as the
+// Events they are waiting for are signaled. Actions in the same concurrency
+// group (see GroupedAction) are run one at a time.
+//
+// The Actions in the Result are in the same order as in pending, regardless
+// of the order of execution.
+func NewParallelExecutor(pending []Action, opts ...Option) (*parallelExecutor, error) {
+	ret := &parallelExecutor{
+		config:       defaultExecutorConfig(),
+		scopeRunning: map[string]int{},
+		groupRunning: map[string]bool{},
+	}
+	for i, a := range pending {
+		ret.pending = append(ret.pending, indexedAction{i: i, a: a})
+	}
+	for _, opt := range opts {
+		opt(ret.config)
+	}
+
+	if err := ret.config.validate(); err != nil {
+		return nil, err
+	}
+	if ret.config.Metrics != nil {
+		ret.config.Observers = append(ret.config.Observers, newMetricsObserver(ret.config.Metrics))
+	}
+	if ret.config.Progress != nil {
+		ret.config.Observers = append(ret.config.Observers, newProgressObserver(ret.config.Progress, ret.config.DurationHistory, pending))
+	}
+
+	if ret.config.DryRun {
+		ret.runFunc = func(ctx context.Context, c cloud.Cloud, a Action) (EventList, error) {
+			return a.DryRun(), nil
+		}
+	} else {
+		ret.runFunc = func(ctx context.Context, c cloud.Cloud, a Action) (EventList, error) {
+			return a.Run(ctx, c)
+		}
+	}
+	if ret.config.Retry != nil && !ret.config.DryRun {
+		ret.runFunc = withRetry(ret.config.Retry, ret.config.Metrics, ret.runFunc)
+	}
+	if ret.config.CloudRouter != nil {
+		ret.runFunc = withCloudRouter(ret.config.CloudRouter, ret.runFunc)
+	}
+	ret.runFunc = withPostEvents(ret.runFunc)
+
+	return ret, nil
+}
+
+type parallelExecutor struct {
+	config *ExecutorConfig
+
+	runFunc func(context.Context, cloud.Cloud, Action) (EventList, error)
+
+	// The fields below are only accessed from the Run() goroutine. The
+	// Actions themselves (Signal(), CanRun()) are also only accessed from
+	// the Run() goroutine while they are not running.
+	pending   []indexedAction
+	completed []indexedAction
+	errors    []indexedAction
+	skipped   []indexedAction
+	timedOut  []indexedAction
+	canceled  []indexedAction
+
+	// running is the number of Actions that are running.
+	running int
+	// scopeRunning is the number of running Actions by scopeKey().
+	scopeRunning map[string]int
+	// groupRunning is the set of concurrency groups with a running Action.
+	groupRunning map[string]bool
+
+	// control is set by RunAsync(). Optional.
+	control *control
+}
+
+// indexedAction is an Action with its index in the original list.
+type indexedAction struct {
+	i   int
+	a   Action
+	err error
+}
+
+var _ Executor = (*parallelExecutor)(nil)
+
+func (ex *parallelExecutor) setControl(c *control) { ex.control = c }
+
+// parallelResult is the result of an Action run in a goroutine.
+type parallelResult struct {
+	ia       indexedAction
+	te       *TraceEntry
+	events   EventList
+	timedOut bool
+	err      error
+}
+
+func (ex *parallelExecutor) Run(ctx context.Context, c cloud.Cloud) (*Result, error) {
+	result, err := ex.run(ctx, c)
+	if ex.config.shouldRollback(err) {
+		// ex.completed is in the order of completion.
+		var completed []Action
+		for _, ia := range ex.completed {
+			completed = append(completed, ia.a)
+		}
+		result.RolledBack, result.RollbackErrors = rollback(ctx, ex.config.RollbackTimeout, c, ex.runFunc, completed)
+		if len(result.RollbackErrors) > 0 {
+			err = fmt.Errorf("%w; parallelExecutor: errors in rollback %v", err, result.RollbackErrors)
+		}
+	}
+	return result, err
+}
+
+func (ex *parallelExecutor) run(ctx context.Context, c cloud.Cloud) (*Result, error) {
+	ctx, cancel := ex.config.budgetContext(ctx)
+	defer cancel()
+
+	if err := ex.approve(ctx); err != nil {
+		return ex.result(), err
+	}
+
+	done := make(chan parallelResult)
+	var (
+		stopErr error
+		aborted bool
+	)
+
+	for {
+		paused, abort, changed := ex.control.state()
+		aborted = aborted || abort
+		stopping := stopErr != nil || aborted || ctx.Err() != nil
+		// Start all of the Actions that are ready, unless we are stopping
+		// or paused.
+		if !stopping && !paused {
+			for _, ia := range ex.ready() {
+				ex.start(ia)
+				ex.config.observe(func(o Observer) { o.ActionStarted(ia.a) })
+				go ex.runAction(ctx, c, ia, done)
+			}
+		}
+		if ex.running == 0 && (stopping || !paused) {
+			break
+		}
+		// The context only needs to be watched while paused with nothing
+		// running; the running Actions return when it is done.
+		var ctxDone <-chan struct{}
+		if ex.running == 0 {
+			ctxDone = ctx.Done()
+		}
+		select {
+		case r := <-done:
+			ex.finish(r.ia)
+			if err := ex.record(r); err != nil && stopErr == nil {
+				stopErr = err
+			}
+		case <-changed:
+		case <-ctxDone:
+		}
+	}
+
+	// Actions are not started once the context is done or the execution
+	// was aborted.
+	if ctx.Err() != nil || aborted {
+		ex.canceled = append(ex.canceled, ex.pending...)
+		ex.pending = nil
+	}
+
+	result := ex.result()
+	if stopErr != nil {
+		return result, stopErr
+	}
+	if ex.config.Tracer != nil {
+		ex.config.Tracer.Finish(append(result.Pending, result.Canceled...))
+	}
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("parallelExecutor: %w", err)
+	}
+	if aborted {
+		return result, fmt.Errorf("parallelExecutor: %w", ErrAborted)
+	}
+	if len(result.Errors) > 0 || len(result.TimedOut) > 0 {
+		return result, fmt.Errorf("parallelExecutor: errors in execution %v (timed out: %v)", result.Errors, result.TimedOut)
+	}
+
+	return result, nil
+}
+
+// approve moves the Actions that were not approved from pending to skipped.
+func (ex *parallelExecutor) approve(ctx context.Context) error {
+	var actions []Action
+	for _, ia := range ex.pending {
+		actions = append(actions, ia.a)
+	}
+	skipped, err := ex.config.approve(ctx, actions)
+	if err != nil {
+		return fmt.Errorf("parallelExecutor: %w", err)
+	}
+	if len(skipped) == 0 {
+		return nil
+	}
+	var pending []indexedAction
+	for i, ia := range ex.pending {
+		if skipped[i] {
+			ex.skipped = append(ex.skipped, ia)
+			ex.config.observe(func(o Observer) { o.ActionSkipped(ia.a) })
+		} else {
+			pending = append(pending, ia)
+		}
+	}
+	ex.pending = pending
+	return nil
+}
+
+// ready removes the Actions that can run from pending, within the
+// concurrency limits.
+func (ex *parallelExecutor) ready() []indexedAction {
+	var ret, pending []indexedAction
+	running := ex.running
+	scopeRunning := map[string]int{}
+	groupRunning := map[string]bool{}
+	for _, ia := range ex.pending {
+		if !ia.a.CanRun() || !ex.underLimits(ia.a, running, scopeRunning, groupRunning) {
+			pending = append(pending, ia)
+			continue
+		}
+		ret = append(ret, ia)
+		running++
+		if key, ok := scopeKey(ia.a); ok {
+			scopeRunning[key]++
+		}
+		if group, ok := concurrencyGroup(ia.a); ok {
+			groupRunning[group] = true
+		}
+	}
+	ex.pending = pending
+	return ret
+}
+
+// underLimits returns true if a can be started with the given number of
+// Actions running in addition to the ones in ex.scopeRunning and
+// ex.groupRunning.
+func (ex *parallelExecutor) underLimits(a Action, running int, scopeRunning map[string]int, groupRunning map[string]bool) bool {
+	if ex.config.MaxConcurrency > 0 && running >= ex.config.MaxConcurrency {
+		return false
+	}
+	if group, ok := concurrencyGroup(a); ok && (ex.groupRunning[group] || groupRunning[group]) {
+		return false
+	}
+	if ex.config.ScopeConcurrency == 0 {
+		return true
+	}
+	key, ok := scopeKey(a)
+	return !ok || ex.scopeRunning[key]+scopeRunning[key] < ex.config.ScopeConcurrency
+}
+
+func (ex *parallelExecutor) start(ia indexedAction) {
+	ex.running++
+	if key, ok := scopeKey(ia.a); ok {
+		ex.scopeRunning[key]++
+	}
+	if group, ok := concurrencyGroup(ia.a); ok {
+		ex.groupRunning[group] = true
+	}
+}
+
+func (ex *parallelExecutor) finish(ia indexedAction) {
+	ex.running--
+	if key, ok := scopeKey(ia.a); ok {
+		ex.scopeRunning[key]--
+	}
+	if group, ok := concurrencyGroup(ia.a); ok {
+		delete(ex.groupRunning, group)
+	}
+}
+
+func (ex *parallelExecutor) runAction(ctx context.Context, c cloud.Cloud, ia indexedAction, done chan<- parallelResult) {
+	te := &TraceEntry{
+		Action: ia.a,
+		Start:  time.Now(),
+	}
+	events, timedOut, err := ex.config.runWithTimeout(ctx, c, ia.a, ex.runFunc)
+	te.End = time.Now()
+	te.Events = events
+	te.Err = err
+
+	done <- parallelResult{ia: ia, te: te, events: events, timedOut: timedOut, err: err}
+}
+
+// record the result of an Action and signal the events to the pending
+// Actions. Returns an error if execution should stop.
+func (ex *parallelExecutor) record(r parallelResult) error {
+	a := r.ia.a
+
+	var ret error
+	if r.err == nil {
+		ex.completed = append(ex.completed, r.ia)
+		ex.config.observe(func(o Observer) { o.ActionFinished(a) })
+	} else {
+		ex.config.observe(func(o Observer) { o.ActionErrored(a, r.err, r.timedOut) })
+		r.ia.err = newActionError(a, r.err, r.timedOut)
+		if r.timedOut {
+			ex.timedOut = append(ex.timedOut, r.ia)
+		} else {
+			ex.errors = append(ex.errors, r.ia)
+		}
+		switch ex.config.ErrorStrategy {
+		case ContinueOnError:
+		case StopOnError:
+			ret = fmt.Errorf("parallelExecutor: stopping execution for Action %s (got %v)", a, r.err)
+		default:
+			ret = fmt.Errorf("parallelExecutor: invalid ErrorStrategy %q", ex.config.ErrorStrategy)
+		}
+	}
+	// Events are signaled even if the execution is stopping so that the
+	// trace reflects what has happened.
+	for _, ev := range r.events {
+		var signaled []Action
+		for _, p := range ex.pending {
+			if p.a.Signal(ev) {
+				r.te.Signaled = append(r.te.Signaled, TraceSignal{Event: ev, SignaledAction: p.a})
+				signaled = append(signaled, p.a)
+			}
+		}
+		ex.config.observe(func(o Observer) { o.EventSignaled(a, ev, signaled) })
+	}
+	if ex.config.Tracer != nil {
+		ex.config.Tracer.Record(r.te, r.err)
+	}
+
+	return ret
+}
+
+// result returns the Result with the Actions in the original order.
+func (ex *parallelExecutor) result() *Result {
+	sorted := func(l []indexedAction) []indexedAction {
+		l = append([]indexedAction(nil), l...)
+		sort.Slice(l, func(i, j int) bool { return l[i].i < l[j].i })
+		return l
+	}
+	ret := &Result{}
+	for _, ia := range sorted(ex.completed) {
+		ret.Completed = append(ret.Completed, ia.a)
+	}
+	for _, ia := range sorted(ex.errors) {
+		ret.Errors = append(ret.Errors, ActionWithErr{Action: ia.a, Err: ia.err})
+	}
+	for _, ia := range sorted(ex.pending) {
+		ret.Pending = append(ret.Pending, ia.a)
+	}
+	for _, ia := range sorted(ex.skipped) {
+		ret.Skipped = append(ret.Skipped, ia.a)
+	}
+	for _, ia := range sorted(ex.timedOut) {
+		ret.TimedOut = append(ret.TimedOut, ActionWithErr{Action: ia.a, Err: ia.err})
+	}
+	for _, ia := range sorted(ex.canceled) {
+		ret.Canceled = append(ret.Canceled, ia.a)
+	}
+	return ret
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParallelExecutor(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		for _, tc := range []struct {
+			name     string
+			graph    string
+			strategy ErrorStrategy
+			// pending should be sorted alphabetically for comparison.
+			pending []string
+			errs    []string
+			wantErr bool
+		}{
+			{name: "empty graph"},
+			{name: "one action", graph: "A"},
+			{name: "chain of 3 actions", graph: "A -> B -> C"},
+			{name: "two chains with common root", graph: "A -> B -> C; A -> C"},
+			{name: "complex fan in", graph: "A -> Z; B -> Z; C -> D -> B"},
+			{
+				name:    "cycle in larger graph",
+				graph:   "A -> B -> C -> D -> C; X -> Y",
+				pending: []string{"C", "D"},
+			},
+			{
+				name:     "stop on error",
+				graph:    "A -> !B -> C -> D -> E",
+				strategy: StopOnError,
+				pending:  []string{"C", "D", "E"},
+				errs:     []string{"B"},
+				wantErr:  true,
+			},
+			{
+				name:     "continue on error",
+				graph:    "A -> !B -> C -> D -> E; X -> Y",
+				strategy: ContinueOnError,
+				errs:     []string{"B"},
+				wantErr:  true,
+			},
+		} {
+			if dryRun && tc.wantErr {
+				// Dry run assumes no errors happen.
+				continue
+			}
+			t.Run(tc.name, func(t *testing.T) {
+				actions := actionsFromGraphStr(tc.graph)
+				strategy := tc.strategy
+				if strategy == "" {
+					strategy = StopOnError
+				}
+				tr := NewGraphvizTracer()
+				ex, err := NewParallelExecutor(actions,
+					ErrorStrategyOption(strategy),
+					TracerOption(tr),
+					DryRunOption(dryRun))
+				if err != nil {
+					t.Fatalf("NewParallelExecutor() = %v, want nil", err)
+				}
+				result, err := ex.Run(context.Background(), nil)
+				if gotErr := err != nil; gotErr != tc.wantErr {
+					t.Fatalf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+				}
+				got := sortedStrings(result.Pending, func(a Action) string { return a.(*testAction).name })
+				if diff := cmp.Diff(got, tc.pending); diff != "" {
+					t.Errorf("pending: diff -got,+want: %s", diff)
+				}
+				got = sortedStrings(result.Errors, func(a ActionWithErr) string { return a.Action.(*testAction).name })
+				if diff := cmp.Diff(got, tc.errs); diff != "" {
+					t.Errorf("errors: diff -got,+want: %s", diff)
+				}
+				t.Log(tr.String())
+			})
+		}
+	}
+}
+
+// barrierAction blocks in Run() until all of the barrierActions sharing wg
+// have started.
+type barrierAction struct {
+	testAction
+	wg *sync.WaitGroup
+}
+
+func (a *barrierAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	a.wg.Done()
+	a.wg.Wait()
+	return a.testAction.Run(ctx, c)
+}
+
+func TestParallelExecutorConcurrency(t *testing.T) {
+	// A, B and C can only finish if they run concurrently. D waits for all
+	// of them.
+	var wg sync.WaitGroup
+	wg.Add(3)
+	var actions []Action
+	for _, name := range []string{"A", "B", "C"} {
+		actions = append(actions, &barrierAction{
+			testAction: testAction{name: name, events: EventList{StringEvent(name)}},
+			wg:         &wg,
+		})
+	}
+	d := &testAction{name: "D", events: EventList{StringEvent("D")}}
+	d.Want = EventList{StringEvent("A"), StringEvent("B"), StringEvent("C")}
+	// Put D first to check that the Result is in the original order.
+	actions = append([]Action{d}, actions...)
+
+	ex, err := NewParallelExecutor(actions)
+	if err != nil {
+		t.Fatalf("NewParallelExecutor() = %v, want nil", err)
+	}
+	type runResult struct {
+		result *Result
+		err    error
+	}
+	ch := make(chan runResult)
+	go func() {
+		result, err := ex.Run(context.Background(), nil)
+		ch <- runResult{result, err}
+	}()
+
+	var r runResult
+	select {
+	case r = <-ch:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Run() did not finish, actions were not run concurrently")
+	}
+	if r.err != nil {
+		t.Fatalf("Run() = %v, want nil", r.err)
+	}
+	var got []string
+	for _, a := range r.result.Completed {
+		got = append(got, a.Metadata().Name)
+	}
+	want := []string{"D([D])", "A([A])", "B([B])", "C([C])"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Completed: diff -got,+want: %s", diff)
+	}
+}
+
+// countingAction records the maximum number of concurrently running
+// countingActions sharing the same counter.
+type countingAction struct {
+	testAction
+	id *cloud.ResourceID
+	c  *concurrencyCounter
+}
+
+type concurrencyCounter struct {
+	lock    sync.Mutex
+	running map[string]int
+	max     map[string]int
+}
+
+func (c *concurrencyCounter) add(key string, d int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, k := range []string{"", key} {
+		c.running[k] += d
+		if c.running[k] > c.max[k] {
+			c.max[k] = c.running[k]
+		}
+	}
+}
+
+func (a *countingAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	key, ok := concurrencyGroup(a)
+	if !ok {
+		key, _ = scopeKey(a)
+	}
+	a.c.add(key, 1)
+	// Give other Actions a chance to run concurrently.
+	time.Sleep(10 * time.Millisecond)
+	a.c.add(key, -1)
+	return a.testAction.Run(ctx, c)
+}
+
+func (a *countingAction) ResourceID() *cloud.ResourceID { return a.id }
+
+func TestParallelExecutorConcurrencyLimits(t *testing.T) {
+	bsID := func(key *meta.Key) *cloud.ResourceID {
+		return &cloud.ResourceID{ProjectID: "proj", Resource: "backendServices", Key: key}
+	}
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		ids  []*cloud.ResourceID
+		// groups are the concurrency groups of the Actions by name.
+		groups  map[string]string
+		wantMax map[string]int
+		wantErr bool
+	}{
+		{
+			name: "max concurrency",
+			opts: []Option{MaxConcurrencyOption(2)},
+			ids: []*cloud.ResourceID{
+				bsID(meta.GlobalKey("a")),
+				bsID(meta.GlobalKey("b")),
+				bsID(meta.RegionalKey("c", "us-central1")),
+				bsID(meta.RegionalKey("d", "us-central1")),
+				bsID(meta.RegionalKey("e", "us-east1")),
+			},
+			wantMax: map[string]int{"": 2},
+		},
+		{
+			name: "scope concurrency",
+			opts: []Option{ScopeConcurrencyOption(1)},
+			ids: []*cloud.ResourceID{
+				bsID(meta.GlobalKey("a")),
+				bsID(meta.GlobalKey("b")),
+				bsID(meta.RegionalKey("c", "us-central1")),
+				bsID(meta.RegionalKey("d", "us-central1")),
+				bsID(meta.RegionalKey("e", "us-east1")),
+			},
+			wantMax: map[string]int{
+				"":                             3,
+				"/backendServices:proj/global": 1,
+				"/backendServices:proj/regions/us-central1": 1,
+				"/backendServices:proj/regions/us-east1":    1,
+			},
+		},
+		{
+			name: "concurrency groups",
+			ids: []*cloud.ResourceID{
+				bsID(meta.GlobalKey("a")),
+				bsID(meta.GlobalKey("b")),
+				bsID(meta.GlobalKey("c")),
+				bsID(meta.GlobalKey("d")),
+				bsID(meta.GlobalKey("e")),
+			},
+			groups: map[string]string{"a": "um1", "b": "um1", "c": "um2", "d": "um2"},
+			wantMax: map[string]int{
+				"":    3,
+				"um1": 1,
+				"um2": 1,
+			},
+		},
+		{
+			name:    "invalid max concurrency",
+			opts:    []Option{MaxConcurrencyOption(-1)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid scope concurrency",
+			opts:    []Option{ScopeConcurrencyOption(-1)},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &concurrencyCounter{running: map[string]int{}, max: map[string]int{}}
+			var actions []Action
+			for _, id := range tc.ids {
+				a := &countingAction{
+					testAction: testAction{name: id.Key.Name},
+					id:         id,
+					c:          c,
+				}
+				a.Group = tc.groups[id.Key.Name]
+				actions = append(actions, a)
+			}
+			ex, err := NewParallelExecutor(actions, tc.opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("NewParallelExecutor() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			result, err := ex.Run(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if len(result.Completed) != len(tc.ids) {
+				t.Errorf("len(result.Completed) = %d, want %d", len(result.Completed), len(tc.ids))
+			}
+			for key, want := range tc.wantMax {
+				if got := c.max[key]; got != want {
+					t.Errorf("max concurrency for %q = %d, want %d", key, got, want)
+				}
+			}
+		})
+	}
+}
@@ -22,10 +22,11 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
-	"k8s.io/klog/v2"
 )
 
 // NewSerialExecutor returns a new Executor that runs tasks single-threaded.
+// The order of execution is deterministic: the next Action to run is always the
+// first Action in pending that can run.
 func NewSerialExecutor(pending []Action, opts ...Option) (*serialExecutor, error) {
 	ret := &serialExecutor{
 		config: defaultExecutorConfig(),
@@ -38,6 +39,12 @@ func NewSerialExecutor(pending []Action, opts ...Option) (*serialExecutor, error
 	if err := ret.config.validate(); err != nil {
 		return nil, err
 	}
+	if ret.config.Metrics != nil {
+		ret.config.Observers = append(ret.config.Observers, newMetricsObserver(ret.config.Metrics))
+	}
+	if ret.config.Progress != nil {
+		ret.config.Observers = append(ret.config.Observers, newProgressObserver(ret.config.Progress, ret.config.DurationHistory, pending))
+	}
 
 	if ret.config.DryRun {
 		ret.runFunc = func(ctx context.Context, c cloud.Cloud, a Action) (EventList, error) {
@@ -48,6 +55,13 @@ func NewSerialExecutor(pending []Action, opts ...Option) (*serialExecutor, error
 			return a.Run(ctx, c)
 		}
 	}
+	if ret.config.Retry != nil && !ret.config.DryRun {
+		ret.runFunc = withRetry(ret.config.Retry, ret.config.Metrics, ret.runFunc)
+	}
+	if ret.config.CloudRouter != nil {
+		ret.runFunc = withCloudRouter(ret.config.CloudRouter, ret.runFunc)
+	}
+	ret.runFunc = withPostEvents(ret.runFunc)
 
 	return ret, nil
 }
@@ -57,41 +71,101 @@ type serialExecutor struct {
 
 	runFunc func(context.Context, cloud.Cloud, Action) (EventList, error)
 	result  *Result
+	// control is set by RunAsync(). Optional.
+	control *control
 }
 
 var _ Executor = (*serialExecutor)(nil)
 
+func (ex *serialExecutor) setControl(c *control) { ex.control = c }
+
 func (ex *serialExecutor) Run(ctx context.Context, c cloud.Cloud) (*Result, error) {
-	for a := ex.next(); a != nil; a = ex.next() {
-		err := ex.runAction(ctx, c, a)
-		if err != nil {
-			return ex.result, err
+	err := ex.run(ctx, c)
+	if ex.config.shouldRollback(err) {
+		ex.result.RolledBack, ex.result.RollbackErrors = rollback(ctx, ex.config.RollbackTimeout, c, ex.runFunc, ex.result.Completed)
+		if len(ex.result.RollbackErrors) > 0 {
+			err = fmt.Errorf("%w; serialExecutor: errors in rollback %v", err, ex.result.RollbackErrors)
 		}
 	}
+	return ex.result, err
+}
+
+func (ex *serialExecutor) run(ctx context.Context, c cloud.Cloud) error {
+	ctx, cancel := ex.config.budgetContext(ctx)
+	defer cancel()
+
+	skipped, err := ex.config.approve(ctx, ex.result.Pending)
+	if err != nil {
+		return fmt.Errorf("serialExecutor: %w", err)
+	}
+	if len(skipped) > 0 {
+		var pending []Action
+		for i, a := range ex.result.Pending {
+			if skipped[i] {
+				ex.result.Skipped = append(ex.result.Skipped, a)
+				ex.config.observe(func(o Observer) { o.ActionSkipped(a) })
+			} else {
+				pending = append(pending, a)
+			}
+		}
+		ex.result.Pending = pending
+	}
+
+	// The context and the control are checked between Actions so that
+	// execution stops cleanly when it is canceled or aborted.
+	var aborted bool
+	for ctx.Err() == nil {
+		if aborted = ex.control.wait(ctx); aborted || ctx.Err() != nil {
+			break
+		}
+		a := ex.next()
+		if a == nil {
+			break
+		}
+		if err := ex.runAction(ctx, c, a); err != nil {
+			ex.cancelPending(ctx, false)
+			return err
+		}
+	}
+	ex.cancelPending(ctx, aborted)
 	if ex.config.Tracer != nil {
-		ex.config.Tracer.Finish(ex.result.Pending)
+		ex.config.Tracer.Finish(append(ex.result.Pending, ex.result.Canceled...))
 	}
-	if len(ex.result.Errors) > 0 {
-		return ex.result, fmt.Errorf("serialExecutor: errors in execution %v", ex.result.Errors)
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("serialExecutor: %w", err)
+	}
+	if aborted {
+		return fmt.Errorf("serialExecutor: %w", ErrAborted)
+	}
+	if len(ex.result.Errors) > 0 || len(ex.result.TimedOut) > 0 {
+		return fmt.Errorf("serialExecutor: errors in execution %v (timed out: %v)", ex.result.Errors, ex.result.TimedOut)
 	}
 
-	return ex.result, nil
+	return nil
 }
 
 func (ex *serialExecutor) runAction(ctx context.Context, c cloud.Cloud, a Action) error {
-	klog.Infof("runAction %s", a)
-
 	te := &TraceEntry{
 		Action: a,
 		Start:  time.Now(),
 	}
-	events, runErr := ex.runFunc(ctx, c, a)
+	ex.config.observe(func(o Observer) { o.ActionStarted(a) })
+	events, timedOut, runErr := ex.config.runWithTimeout(ctx, c, a, ex.runFunc)
 	te.End = time.Now()
+	te.Events = events
+	te.Err = runErr
 
 	if runErr == nil {
 		ex.result.Completed = append(ex.result.Completed, a)
+		ex.config.observe(func(o Observer) { o.ActionFinished(a) })
 	} else {
-		ex.result.Errors = append(ex.result.Errors, ActionWithErr{Action: a, Err: runErr})
+		ex.config.observe(func(o Observer) { o.ActionErrored(a, runErr, timedOut) })
+		awe := ActionWithErr{Action: a, Err: newActionError(a, runErr, timedOut)}
+		if timedOut {
+			ex.result.TimedOut = append(ex.result.TimedOut, awe)
+		} else {
+			ex.result.Errors = append(ex.result.Errors, awe)
+		}
 		switch ex.config.ErrorStrategy {
 		case ContinueOnError:
 		case StopOnError:
@@ -103,6 +177,13 @@ func (ex *serialExecutor) runAction(ctx context.Context, c cloud.Cloud, a Action
 	for _, ev := range events {
 		signaled := ex.signal(ev)
 		te.Signaled = append(te.Signaled, signaled...)
+		if len(ex.config.Observers) > 0 {
+			var actions []Action
+			for _, s := range signaled {
+				actions = append(actions, s.SignaledAction)
+			}
+			ex.config.observe(func(o Observer) { o.EventSignaled(a, ev, actions) })
+		}
 	}
 	if ex.config.Tracer != nil {
 		ex.config.Tracer.Record(te, runErr)
@@ -111,6 +192,16 @@ func (ex *serialExecutor) runAction(ctx context.Context, c cloud.Cloud, a Action
 	return nil
 }
 
+// cancelPending moves the Actions that were not run to Canceled if the
+// context is done or the execution was aborted.
+func (ex *serialExecutor) cancelPending(ctx context.Context, aborted bool) {
+	if ctx.Err() == nil && !aborted {
+		return
+	}
+	ex.result.Canceled = append(ex.result.Canceled, ex.result.Pending...)
+	ex.result.Pending = nil
+}
+
 func (ex *serialExecutor) next() Action {
 	for i, a := range ex.result.Pending {
 		if a.CanRun() {
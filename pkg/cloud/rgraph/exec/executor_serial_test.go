@@ -183,3 +183,31 @@ func TestSerialExecutorErrorStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestSerialExecutorOrder(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		// C waits for A. B and D are independent, so they are run in the
+		// order of the list.
+		a := &testAction{name: "A", events: EventList{StringEvent("A")}}
+		b := &testAction{name: "B", events: EventList{StringEvent("B")}}
+		c := &testAction{name: "C", events: EventList{StringEvent("C")}}
+		c.Want = EventList{StringEvent("A")}
+		d := &testAction{name: "D", events: EventList{StringEvent("D")}}
+
+		ex, err := NewSerialExecutor([]Action{d, c, b, a})
+		if err != nil {
+			t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+		}
+		result, err := ex.Run(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Run() = %v, want nil", err)
+		}
+		var got []string
+		for _, a := range result.Completed {
+			got = append(got, a.(*testAction).name)
+		}
+		if diff := cmp.Diff(got, []string{"D", "B", "A", "C"}); diff != "" {
+			t.Fatalf("Completed: -got,+want: %s", diff)
+		}
+	}
+}
@@ -17,8 +17,15 @@ limitations under the License.
 package exec
 
 import (
+	"context"
 	"errors"
+	"sort"
 	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/google/go-cmp/cmp"
 )
 
 // actionsFromGraphStr parses a graph in the form of "A -> B -> C; B -> D" to a
@@ -67,3 +74,532 @@ func actionsFromGraphStr(graphStr string) []Action {
 
 	return actions
 }
+
+func TestApproval(t *testing.T) {
+	names := func(l []Action) []string {
+		var ret []string
+		for _, a := range l {
+			ret = append(ret, a.(*testAction).name)
+		}
+		sort.Strings(ret)
+		return ret
+	}
+
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		for _, tc := range []struct {
+			name string
+			// skip are the Actions to filter out.
+			skip          []string
+			reject        bool
+			wantCompleted []string
+			wantPending   []string
+			wantSkipped   []string
+			wantErr       bool
+		}{
+			{
+				name:          "approve all",
+				wantCompleted: []string{"A", "B", "C", "D"},
+			},
+			{
+				name:          "filter",
+				skip:          []string{"B"},
+				wantCompleted: []string{"A", "D"},
+				wantPending:   []string{"C"},
+				wantSkipped:   []string{"B"},
+			},
+			{
+				name:        "reject",
+				reject:      true,
+				wantPending: []string{"A", "B", "C", "D"},
+				wantErr:     true,
+			},
+		} {
+			t.Run(newExecutor.name+"/"+tc.name, func(t *testing.T) {
+				actions := actionsFromGraphStr("A -> B -> C; A -> D")
+				var gotApproval []string
+				approval := func(ctx context.Context, actions []Action) ([]Action, error) {
+					gotApproval = names(actions)
+					if tc.reject {
+						return nil, errors.New("rejected")
+					}
+					var ret []Action
+					for _, a := range actions {
+						skip := false
+						for _, s := range tc.skip {
+							skip = skip || a.(*testAction).name == s
+						}
+						if !skip {
+							ret = append(ret, a)
+						}
+					}
+					return ret, nil
+				}
+				ex, err := newExecutor.f(actions, ApprovalOption(approval))
+				if err != nil {
+					t.Fatalf("NewExecutor() = %v, want nil", err)
+				}
+				result, err := ex.Run(context.Background(), nil)
+				if gotErr := err != nil; gotErr != tc.wantErr {
+					t.Fatalf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+				}
+				if diff := cmp.Diff(gotApproval, []string{"A", "B", "C", "D"}); diff != "" {
+					t.Errorf("ApprovalFunc actions: diff -got,+want: %s", diff)
+				}
+				for _, x := range []struct {
+					name string
+					got  []Action
+					want []string
+				}{
+					{"Completed", result.Completed, tc.wantCompleted},
+					{"Pending", result.Pending, tc.wantPending},
+					{"Skipped", result.Skipped, tc.wantSkipped},
+				} {
+					if diff := cmp.Diff(names(x.got), x.want); diff != "" {
+						t.Errorf("%s: diff -got,+want: %s", x.name, diff)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestApprovalSameName(t *testing.T) {
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		t.Run(newExecutor.name, func(t *testing.T) {
+			// The Actions have the same Metadata().Name.
+			a1 := &testAction{name: "A"}
+			a2 := &testAction{name: "A"}
+			approval := func(ctx context.Context, actions []Action) ([]Action, error) {
+				return []Action{a1}, nil
+			}
+			ex, err := newExecutor.f([]Action{a1, a2}, ApprovalOption(approval))
+			if err != nil {
+				t.Fatalf("NewExecutor() = %v, want nil", err)
+			}
+			result, err := ex.Run(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			if len(result.Completed) != 1 || result.Completed[0] != a1 {
+				t.Errorf("Completed = %v, want [%p]", result.Completed, a1)
+			}
+			if len(result.Skipped) != 1 || result.Skipped[0] != a2 {
+				t.Errorf("Skipped = %v, want [%p]", result.Skipped, a2)
+			}
+		})
+	}
+}
+
+// reversibleAction is a testAction that can be undone.
+type reversibleAction struct {
+	*testAction
+	inverseErr error
+}
+
+func (a *reversibleAction) Inverse() (Action, error) {
+	return &testAction{name: "undo-" + a.name}, a.inverseErr
+}
+
+func TestRollback(t *testing.T) {
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		for _, tc := range []struct {
+			name     string
+			graph    string
+			rollback bool
+			// irreversible Actions do not implement ReversibleAction.
+			irreversible   []string
+			inverseErr     string
+			wantRolledBack []string
+			wantRbErrs     int
+		}{
+			{
+				name:     "no errors",
+				graph:    "A -> B",
+				rollback: true,
+			},
+			{
+				name:  "rollback disabled",
+				graph: "A -> B -> !C",
+			},
+			{
+				name:           "rollback in reverse order",
+				graph:          "A -> B -> !C",
+				rollback:       true,
+				wantRolledBack: []string{"undo-B", "undo-A"},
+			},
+			{
+				name:           "irreversible Action",
+				graph:          "A -> B -> !C",
+				rollback:       true,
+				irreversible:   []string{"B"},
+				wantRolledBack: []string{"undo-A"},
+			},
+			{
+				name:           "inverse error",
+				graph:          "A -> B -> !C",
+				rollback:       true,
+				inverseErr:     "B",
+				wantRolledBack: []string{"undo-A"},
+				wantRbErrs:     1,
+			},
+		} {
+			t.Run(newExecutor.name+"/"+tc.name, func(t *testing.T) {
+				var actions []Action
+				for _, a := range actionsFromGraphStr(tc.graph) {
+					ta := a.(*testAction)
+					irreversible := false
+					for _, name := range tc.irreversible {
+						irreversible = irreversible || ta.name == name
+					}
+					if irreversible {
+						actions = append(actions, ta)
+						continue
+					}
+					ra := &reversibleAction{testAction: ta}
+					if ta.name == tc.inverseErr {
+						ra.inverseErr = errors.New("injected")
+					}
+					actions = append(actions, ra)
+				}
+				ex, err := newExecutor.f(actions, RollbackOption(tc.rollback))
+				if err != nil {
+					t.Fatalf("NewExecutor() = %v, want nil", err)
+				}
+				result, _ := ex.Run(context.Background(), nil)
+
+				var got []string
+				for _, a := range result.RolledBack {
+					got = append(got, a.(*testAction).name)
+				}
+				if diff := cmp.Diff(got, tc.wantRolledBack); diff != "" {
+					t.Errorf("RolledBack: diff -got,+want: %s", diff)
+				}
+				if len(result.RollbackErrors) != tc.wantRbErrs {
+					t.Errorf("RollbackErrors = %v, want %d errors", result.RollbackErrors, tc.wantRbErrs)
+				}
+			})
+		}
+	}
+}
+
+// cancelAction cancels the execution when it is run.
+type cancelAction struct {
+	testAction
+	cancel context.CancelFunc
+}
+
+func (a *cancelAction) Run(context.Context, cloud.Cloud) (EventList, error) {
+	a.cancel()
+	return nil, context.Canceled
+}
+
+// slowReversibleAction is reverted with a slowAction that takes undo.
+type slowReversibleAction struct {
+	testAction
+	undo time.Duration
+}
+
+func (a *slowReversibleAction) Inverse() (Action, error) {
+	return &slowAction{testAction: testAction{name: "undo-" + a.name}, d: a.undo}, nil
+}
+
+func TestRollbackContext(t *testing.T) {
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		for _, tc := range []struct {
+			name           string
+			timeout        time.Duration
+			undo           time.Duration
+			wantRolledBack int
+			wantRbErrs     int
+		}{
+			{
+				name:           "rollback after cancel",
+				undo:           10 * time.Millisecond,
+				wantRolledBack: 1,
+			},
+			{
+				name:       "rollback timeout",
+				timeout:    10 * time.Millisecond,
+				undo:       time.Minute,
+				wantRbErrs: 1,
+			},
+		} {
+			t.Run(newExecutor.name+"/"+tc.name, func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				a := &slowReversibleAction{testAction: testAction{name: "A", events: EventList{StringEvent("A")}}, undo: tc.undo}
+				b := &cancelAction{testAction: testAction{name: "B"}, cancel: cancel}
+				b.Want = EventList{StringEvent("A")}
+
+				opts := []Option{RollbackOption(true)}
+				if tc.timeout != 0 {
+					opts = append(opts, RollbackTimeoutOption(tc.timeout))
+				}
+				ex, err := newExecutor.f([]Action{a, b}, opts...)
+				if err != nil {
+					t.Fatalf("NewExecutor() = %v, want nil", err)
+				}
+				result, err := ex.Run(ctx, nil)
+				if err == nil {
+					t.Fatalf("Run() = nil, want error")
+				}
+				if len(result.RolledBack) != tc.wantRolledBack {
+					t.Errorf("RolledBack = %v, want %d Actions", result.RolledBack, tc.wantRolledBack)
+				}
+				if len(result.RollbackErrors) != tc.wantRbErrs {
+					t.Errorf("RollbackErrors = %v, want %d errors", result.RollbackErrors, tc.wantRbErrs)
+				}
+			})
+		}
+	}
+}
+
+// slowAction takes d to run unless the context is done first.
+type slowAction struct {
+	testAction
+	d time.Duration
+}
+
+func (a *slowAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(a.d):
+		return a.events, nil
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	names := func(l []Action) []string {
+		var ret []string
+		for _, a := range l {
+			ret = append(ret, a.(*slowAction).name)
+		}
+		return ret
+	}
+	errNames := func(l []ActionWithErr) []string {
+		var ret []string
+		for _, a := range l {
+			ret = append(ret, a.Action.(*slowAction).name)
+		}
+		return ret
+	}
+
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		for _, tc := range []struct {
+			name          string
+			opts          []Option
+			wantCompleted []string
+			wantTimedOut  []string
+			wantPending   []string
+			wantCanceled  []string
+			wantErr       bool
+		}{
+			{
+				name:          "no timeout",
+				wantCompleted: []string{"A", "B", "C"},
+			},
+			{
+				name:          "action timeout",
+				opts:          []Option{ActionTimeoutOption(50 * time.Millisecond), ErrorStrategyOption(ContinueOnError)},
+				wantCompleted: []string{"A"},
+				wantTimedOut:  []string{"B"},
+				wantPending:   []string{"C"},
+				wantErr:       true,
+			},
+			{
+				name:          "execution budget",
+				opts:          []Option{TimeoutOption(50 * time.Millisecond)},
+				wantCompleted: []string{"A"},
+				wantTimedOut:  []string{"B"},
+				wantCanceled:  []string{"C"},
+				wantErr:       true,
+			},
+		} {
+			t.Run(newExecutor.name+"/"+tc.name, func(t *testing.T) {
+				// A -> B -> C, B is slow.
+				a := &slowAction{testAction: testAction{name: "A", events: EventList{StringEvent("A")}}}
+				b := &slowAction{testAction: testAction{name: "B", events: EventList{StringEvent("B")}}, d: 200 * time.Millisecond}
+				b.Want = EventList{StringEvent("A")}
+				c := &slowAction{testAction: testAction{name: "C", events: EventList{StringEvent("C")}}}
+				c.Want = EventList{StringEvent("B")}
+
+				ex, err := newExecutor.f([]Action{a, b, c}, tc.opts...)
+				if err != nil {
+					t.Fatalf("newExecutor() = %v, want nil", err)
+				}
+				result, err := ex.Run(context.Background(), nil)
+				if gotErr := err != nil; gotErr != tc.wantErr {
+					t.Errorf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+				}
+				if diff := cmp.Diff(names(result.Completed), tc.wantCompleted); diff != "" {
+					t.Errorf("Completed: -got,+want: %s", diff)
+				}
+				if diff := cmp.Diff(errNames(result.TimedOut), tc.wantTimedOut); diff != "" {
+					t.Errorf("TimedOut: -got,+want: %s", diff)
+				}
+				if diff := cmp.Diff(names(result.Pending), tc.wantPending); diff != "" {
+					t.Errorf("Pending: -got,+want: %s", diff)
+				}
+				if diff := cmp.Diff(names(result.Canceled), tc.wantCanceled); diff != "" {
+					t.Errorf("Canceled: -got,+want: %s", diff)
+				}
+				if len(result.Errors) > 0 {
+					t.Errorf("Errors = %v, want none", result.Errors)
+				}
+			})
+		}
+	}
+}
+
+// cancelingAction cancels the execution when it is run.
+type cancelingAction struct {
+	testAction
+	cancel context.CancelFunc
+}
+
+func (a *cancelingAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	a.cancel()
+	return a.events, nil
+}
+
+func TestCancel(t *testing.T) {
+	names := func(l []Action) []string {
+		var ret []string
+		for _, a := range l {
+			ret = append(ret, a.String())
+		}
+		sort.Strings(ret)
+		return ret
+	}
+
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		for _, tc := range []struct {
+			name          string
+			cancelFirst   bool
+			wantCompleted []string
+			wantCanceled  []string
+		}{
+			{
+				name:          "canceled during execution",
+				wantCompleted: []string{"A([A])"},
+				wantCanceled:  []string{"B([B])", "C([C])"},
+			},
+			{
+				name:         "canceled before execution",
+				cancelFirst:  true,
+				wantCanceled: []string{"A([A])", "B([B])", "C([C])"},
+			},
+		} {
+			t.Run(newExecutor.name+"/"+tc.name, func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				if tc.cancelFirst {
+					cancel()
+				}
+				// A -> B -> C, A cancels the context.
+				a := &cancelingAction{testAction: testAction{name: "A", events: EventList{StringEvent("A")}}, cancel: cancel}
+				b := &testAction{name: "B", events: EventList{StringEvent("B")}}
+				b.Want = EventList{StringEvent("A")}
+				c := &testAction{name: "C", events: EventList{StringEvent("C")}}
+				c.Want = EventList{StringEvent("B")}
+
+				ex, err := newExecutor.f([]Action{a, b, c})
+				if err != nil {
+					t.Fatalf("newExecutor() = %v, want nil", err)
+				}
+				result, err := ex.Run(ctx, nil)
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("Run() = %v, want %v", err, context.Canceled)
+				}
+				if diff := cmp.Diff(names(result.Completed), tc.wantCompleted); diff != "" {
+					t.Errorf("Completed: -got,+want: %s", diff)
+				}
+				if diff := cmp.Diff(names(result.Canceled), tc.wantCanceled); diff != "" {
+					t.Errorf("Canceled: -got,+want: %s", diff)
+				}
+				if len(result.Pending) > 0 || len(result.Errors) > 0 {
+					t.Errorf("Pending = %v, Errors = %v, want none", result.Pending, result.Errors)
+				}
+			})
+		}
+	}
+}
+
+// logValuesAction saves the log values of the context it is run with.
+type logValuesAction struct {
+	testAction
+	values []any
+}
+
+func (a *logValuesAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	a.values = cloud.LogValues(ctx)
+	return a.testAction.Run(ctx, c)
+}
+
+func TestActionLogValues(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+	}{
+		{name: "ok"},
+		{name: "error", err: errors.New("injected")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			newExecutors := []func([]Action, ...Option) (Executor, error){
+				func(a []Action, opts ...Option) (Executor, error) { return NewSerialExecutor(a, opts...) },
+				func(a []Action, opts ...Option) (Executor, error) { return NewParallelExecutor(a, opts...) },
+			}
+			for _, newEx := range newExecutors {
+				ctx := cloud.WithLogValues(context.Background(), "caller", "test")
+				a := &logValuesAction{testAction: testAction{name: "A", err: tc.err}}
+				ex, err := newEx([]Action{a}, ErrorStrategyOption(ContinueOnError))
+				if err != nil {
+					t.Fatalf("newEx() = %v, want nil", err)
+				}
+				ex.Run(ctx, nil)
+
+				if want := []any{"caller", "test", "action", a.String()}; !cmp.Equal(a.values, want) {
+					t.Errorf("log values = %v, want %v", a.values, want)
+				}
+			}
+		})
+	}
+}
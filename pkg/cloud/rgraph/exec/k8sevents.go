@@ -0,0 +1,117 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// Kubernetes Event types.
+const (
+	EventTypeNormal  = "Normal"
+	EventTypeWarning = "Warning"
+)
+
+// Reasons of the Kubernetes Events emitted by the EventObserver.
+const (
+	ReasonCloudResourceCreated = "CloudResourceCreated"
+	ReasonCloudResourceUpdated = "CloudResourceUpdated"
+	ReasonCloudResourceDeleted = "CloudResourceDeleted"
+	ReasonCloudActionFailed    = "CloudActionFailed"
+)
+
+// EventRecorder emits Kubernetes Events for an object. This is
+// record.EventRecorder from k8s.io/client-go with the object (e.g. the
+// Service or Gateway that owns the resources) already bound:
+//
+//	type recorder struct {
+//		r   record.EventRecorder
+//		obj runtime.Object
+//	}
+//
+//	func (r *recorder) Eventf(eventType, reason, messageFmt string, args ...any) {
+//		r.r.Eventf(r.obj, eventType, reason, messageFmt, args...)
+//	}
+type EventRecorder interface {
+	Eventf(eventType, reason, messageFmt string, args ...any)
+}
+
+// EventObserver emits Kubernetes Events when cloud resources are created,
+// updated or deleted and when Actions fail, so that cluster operators see
+// the cloud mutations in `kubectl describe`. The messages contain the
+// SelfLink of the resource. Add it to the Executor with ObserverOption().
+type EventObserver struct {
+	NopObserver
+	r EventRecorder
+}
+
+// NewEventObserver returns an EventObserver emitting to r.
+func NewEventObserver(r EventRecorder) *EventObserver {
+	return &EventObserver{r: r}
+}
+
+var _ Observer = (*EventObserver)(nil)
+
+func (o *EventObserver) ActionFinished(a Action) {
+	id := actionResourceID(a)
+	if id == nil {
+		return
+	}
+	var reason, verb string
+	switch a.Metadata().Type {
+	case ActionTypeCreate:
+		reason, verb = ReasonCloudResourceCreated, "Created"
+	case ActionTypeUpdate:
+		reason, verb = ReasonCloudResourceUpdated, "Updated"
+	case ActionTypeDelete:
+		reason, verb = ReasonCloudResourceDeleted, "Deleted"
+	default:
+		return
+	}
+	o.r.Eventf(EventTypeNormal, reason, "%s %s %s", verb, id.Resource, resourceLink(id))
+}
+
+func (o *EventObserver) ActionErrored(a Action, err error, timedOut bool) {
+	msg := fmt.Sprintf("%s failed", a.Metadata().Name)
+	if timedOut {
+		msg += " (timed out)"
+	}
+	if id := actionResourceID(a); id != nil {
+		msg += fmt.Sprintf(" for %s %s", id.Resource, resourceLink(id))
+	}
+	o.r.Eventf(EventTypeWarning, ReasonCloudActionFailed, "%s: %v", msg, err)
+}
+
+// actionResourceID returns the ResourceID of a ResourceAction or nil.
+func actionResourceID(a Action) *cloud.ResourceID {
+	if ra, ok := a.(ResourceAction); ok {
+		return ra.ResourceID()
+	}
+	return nil
+}
+
+// resourceLink returns the GA SelfLink of the resource or its String() if
+// it does not have a Key.
+func resourceLink(id *cloud.ResourceID) string {
+	if id.Key == nil {
+		return id.String()
+	}
+	return id.SelfLink(meta.VersionGA)
+}
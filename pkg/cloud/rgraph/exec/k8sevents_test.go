@@ -0,0 +1,102 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeEventRecorder struct {
+	events []string
+}
+
+func (r *fakeEventRecorder) Eventf(eventType, reason, messageFmt string, args ...any) {
+	r.events = append(r.events, fmt.Sprintf("%s %s "+messageFmt, append([]any{eventType, reason}, args...)...))
+}
+
+// typedResourceAction is a ResourceAction with the given ActionType.
+type typedResourceAction struct {
+	testAction
+	id  *cloud.ResourceID
+	typ ActionType
+}
+
+func (a *typedResourceAction) ResourceID() *cloud.ResourceID { return a.id }
+
+func (a *typedResourceAction) Metadata() *ActionMetadata {
+	return &ActionMetadata{Name: a.name, Type: a.typ}
+}
+
+func TestEventObserver(t *testing.T) {
+	id := &cloud.ResourceID{ProjectID: "proj", Resource: "backendServices", Key: meta.GlobalKey("bs")}
+	link := "https://www.googleapis.com/compute/v1/projects/proj/global/backendServices/bs"
+
+	for _, tc := range []struct {
+		name string
+		a    Action
+		want []string
+	}{
+		{
+			name: "create",
+			a:    &typedResourceAction{testAction: testAction{name: "c"}, id: id, typ: ActionTypeCreate},
+			want: []string{"Normal CloudResourceCreated Created backendServices " + link},
+		},
+		{
+			name: "update",
+			a:    &typedResourceAction{testAction: testAction{name: "u"}, id: id, typ: ActionTypeUpdate},
+			want: []string{"Normal CloudResourceUpdated Updated backendServices " + link},
+		},
+		{
+			name: "delete",
+			a:    &typedResourceAction{testAction: testAction{name: "d"}, id: id, typ: ActionTypeDelete},
+			want: []string{"Normal CloudResourceDeleted Deleted backendServices " + link},
+		},
+		{
+			name: "meta Action has no event",
+			a:    &typedResourceAction{testAction: testAction{name: "m"}, id: id, typ: ActionTypeMeta},
+		},
+		{
+			name: "failed",
+			a:    &typedResourceAction{testAction: testAction{name: "c", err: errors.New("quota")}, id: id, typ: ActionTypeCreate},
+			want: []string{"Warning CloudActionFailed c failed for backendServices " + link + ": quota"},
+		},
+		{
+			name: "failed custom Action",
+			a:    &testAction{name: "x", err: errors.New("boom")},
+			want: []string{"Warning CloudActionFailed x([]) failed: boom"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &fakeEventRecorder{}
+			ex, err := NewSerialExecutor([]Action{tc.a}, ObserverOption(NewEventObserver(r)))
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			ex.Run(context.Background(), nil)
+			if diff := cmp.Diff(r.events, tc.want); diff != "" {
+				t.Errorf("events: -got,+want: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/metrics"
+)
+
+// MetricsOption reports the duration, errors and retries of the Actions to
+// the Recorder.
+func MetricsOption(r metrics.Recorder) Option {
+	return func(c *ExecutorConfig) { c.Metrics = r }
+}
+
+// actionLabels returns the type and resource kind of the Action for the
+// metrics.
+func actionLabels(a Action) (string, string) {
+	var resource string
+	if ra, ok := a.(ResourceAction); ok && ra.ResourceID() != nil {
+		resource = ra.ResourceID().Resource
+	}
+	return string(a.Metadata().Type), resource
+}
+
+// metricsObserver times the Actions for the metrics. Observers are called
+// from a single goroutine so no locking is needed.
+type metricsObserver struct {
+	NopObserver
+
+	r     metrics.Recorder
+	start map[string]time.Time
+}
+
+func newMetricsObserver(r metrics.Recorder) *metricsObserver {
+	return &metricsObserver{r: r, start: map[string]time.Time{}}
+}
+
+func (o *metricsObserver) ActionStarted(a Action) {
+	o.start[a.Metadata().Name] = time.Now()
+}
+
+func (o *metricsObserver) ActionFinished(a Action) { o.record(a, nil, false) }
+
+func (o *metricsObserver) ActionErrored(a Action, err error, timedOut bool) {
+	o.record(a, err, timedOut)
+}
+
+func (o *metricsObserver) record(a Action, err error, timedOut bool) {
+	name := a.Metadata().Name
+	m := metrics.Action{Err: err, TimedOut: timedOut}
+	m.Type, m.Resource = actionLabels(a)
+	if start, ok := o.start[name]; ok {
+		m.Duration = time.Since(start)
+		delete(o.start, name)
+	}
+	o.r.RecordAction(m)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/metrics"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMetricsOption(t *testing.T) {
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		t.Run(newExecutor.name, func(t *testing.T) {
+			injected := errors.New("injected")
+			bsID := &cloud.ResourceID{Resource: "backendServices", Key: meta.GlobalKey("bs")}
+			actions := []Action{
+				&mergeableAction{testAction: testAction{name: "A"}, id: bsID},
+				// B succeeds after one retry.
+				&flakyAction{testAction: testAction{name: "B", err: injected}, failures: 1},
+				// C fails after 2 retries.
+				&flakyAction{testAction: testAction{name: "C", err: injected}, failures: 5},
+			}
+			r := &metrics.MemoryRecorder{}
+			ex, err := newExecutor.f(actions,
+				MetricsOption(r),
+				RetryOption(NewRetryRegistry(noWait{3})),
+				ErrorStrategyOption(ContinueOnError))
+			if err != nil {
+				t.Fatalf("newExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background(), nil); err == nil {
+				t.Fatalf("Run() = nil, want error")
+			}
+
+			type row struct {
+				Type, Resource string
+				Err            bool
+			}
+			var got []row
+			for _, m := range r.Actions() {
+				got = append(got, row{m.Type, m.Resource, m.Err != nil})
+				if m.Duration < 0 {
+					t.Errorf("Duration = %v, want >= 0", m.Duration)
+				}
+			}
+			sort.Slice(got, func(i, j int) bool {
+				if got[i].Resource != got[j].Resource {
+					return got[i].Resource < got[j].Resource
+				}
+				return !got[i].Err && got[j].Err
+			})
+			want := []row{
+				{Type: "Custom"},
+				{Type: "Custom", Err: true},
+				{Type: "Custom", Resource: "backendServices"},
+			}
+			if diff := cmp.Diff(got, want); diff != "" {
+				t.Errorf("Actions(): -got,+want: %s", diff)
+			}
+			if n := r.Retries("Custom", ""); n != 3 {
+				t.Errorf("Retries() = %d, want 3", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+// Observer is notified by the Executor as the execution progresses. This can
+// be used for logging, metrics, Kubernetes Events or progress UIs.
+//
+// The methods are called from a single goroutine so they do not need to be
+// thread-safe but they must not block as this will delay the execution.
+// Embed NopObserver to implement only some of the methods.
+type Observer interface {
+	// ActionStarted is called before the Action is run.
+	ActionStarted(a Action)
+	// ActionFinished is called when the Action completed without error.
+	ActionFinished(a Action)
+	// ActionErrored is called when the Action returned an error. timedOut
+	// is true if the Action ran out of time (see ActionTimeoutOption).
+	ActionErrored(a Action, err error, timedOut bool)
+	// ActionSkipped is called for the Actions that were filtered out by the
+	// ApprovalFunc.
+	ActionSkipped(a Action)
+	// EventSignaled is called for each Event returned by the Action with
+	// the pending Actions that were waiting for it (may be empty).
+	EventSignaled(from Action, ev Event, signaled []Action)
+}
+
+// ObserverOption adds Observers to the execution.
+func ObserverOption(o ...Observer) Option {
+	return func(c *ExecutorConfig) { c.Observers = append(c.Observers, o...) }
+}
+
+// NopObserver implements Observer with methods that do nothing.
+type NopObserver struct{}
+
+var _ Observer = NopObserver{}
+
+func (NopObserver) ActionStarted(Action)                  {}
+func (NopObserver) ActionFinished(Action)                 {}
+func (NopObserver) ActionErrored(Action, error, bool)     {}
+func (NopObserver) ActionSkipped(Action)                  {}
+func (NopObserver) EventSignaled(Action, Event, []Action) {}
+
+// observe calls f for each of the Observers.
+func (c *ExecutorConfig) observe(f func(o Observer)) {
+	for _, o := range c.Observers {
+		f(o)
+	}
+}
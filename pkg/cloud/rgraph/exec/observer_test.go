@@ -0,0 +1,108 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type recordingObserver struct {
+	NopObserver
+	log []string
+}
+
+func (o *recordingObserver) ActionStarted(a Action) {
+	o.log = append(o.log, "start "+a.(*testAction).name)
+}
+
+func (o *recordingObserver) ActionFinished(a Action) {
+	o.log = append(o.log, "finish "+a.(*testAction).name)
+}
+
+func (o *recordingObserver) ActionErrored(a Action, err error, timedOut bool) {
+	o.log = append(o.log, fmt.Sprintf("error %s (timedOut=%t)", a.(*testAction).name, timedOut))
+}
+
+func (o *recordingObserver) ActionSkipped(a Action) {
+	o.log = append(o.log, "skip "+a.(*testAction).name)
+}
+
+func (o *recordingObserver) EventSignaled(from Action, ev Event, signaled []Action) {
+	var names []string
+	for _, a := range signaled {
+		names = append(names, a.(*testAction).name)
+	}
+	o.log = append(o.log, fmt.Sprintf("signal %s %v", from.(*testAction).name, names))
+}
+
+func TestObserver(t *testing.T) {
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		t.Run(newExecutor.name, func(t *testing.T) {
+			actions := actionsFromGraphStr("A -> B; !C; D")
+			// Skip D.
+			approval := func(ctx context.Context, actions []Action) ([]Action, error) {
+				var ret []Action
+				for _, a := range actions {
+					if a.(*testAction).name != "D" {
+						ret = append(ret, a)
+					}
+				}
+				return ret, nil
+			}
+			o := &recordingObserver{}
+			ex, err := newExecutor.f(actions,
+				ObserverOption(o),
+				ApprovalOption(approval),
+				ErrorStrategyOption(ContinueOnError))
+			if err != nil {
+				t.Fatalf("newExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background(), nil); err == nil {
+				t.Fatalf("Run() = nil, want error")
+			}
+
+			// The order of independent Actions is not deterministic.
+			sort.Strings(o.log)
+			want := []string{
+				"error C (timedOut=false)",
+				"finish A",
+				"finish B",
+				"signal A [B]",
+				"signal B []",
+				"signal C []",
+				"skip D",
+				"start A",
+				"start B",
+				"start C",
+			}
+			if diff := cmp.Diff(o.log, want); diff != "" {
+				t.Errorf("log: -got,+want: %s", diff)
+			}
+		})
+	}
+}
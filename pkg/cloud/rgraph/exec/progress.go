@@ -0,0 +1,209 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Progress of an execution.
+type Progress struct {
+	// Total number of Actions.
+	Total int
+	// Completed is the number of Actions that finished without error.
+	Completed int
+	// Failed is the number of Actions that returned an error or timed out.
+	Failed int
+	// Skipped is the number of Actions filtered out by the ApprovalFunc.
+	Skipped int
+	// Running are the names of the Actions that are running, sorted.
+	Running []string
+	// ETA is the estimated time to run the remaining Actions based on the
+	// DurationHistory. The Actions are assumed to run one after the other
+	// so this is an upper bound for the parallel executor. 0 if there is no
+	// history.
+	ETA time.Duration
+}
+
+// ProgressReporter is updated by the Executor when an Action starts or
+// finishes, e.g. to show the progress of long executions to a user or in
+// the status of a custom resource. ReportProgress must not block as this
+// will delay the execution.
+type ProgressReporter interface {
+	ReportProgress(p Progress)
+}
+
+// ProgressFunc adapts a function to a ProgressReporter.
+type ProgressFunc func(p Progress)
+
+func (f ProgressFunc) ReportProgress(p Progress) { f(p) }
+
+// ProgressOption reports the Progress of the execution to r. The ETA is
+// estimated from h, which is updated with the durations of the Actions in
+// this execution. h can be nil if no ETA is needed.
+func ProgressOption(r ProgressReporter, h *DurationHistory) Option {
+	return func(c *ExecutorConfig) {
+		c.Progress = r
+		c.DurationHistory = h
+	}
+}
+
+// DurationHistory is the average duration of the Actions by type and
+// resource kind (e.g. "Create" and "backendServices"). Share the same
+// DurationHistory between executions to improve the estimates. It is safe
+// for concurrent use.
+type DurationHistory struct {
+	lock sync.Mutex
+	// entries by actionLabels().
+	entries map[[2]string]durationEntry
+	all     durationEntry
+}
+
+type durationEntry struct {
+	total time.Duration
+	n     int
+}
+
+func (e durationEntry) mean() time.Duration { return e.total / time.Duration(e.n) }
+
+// NewDurationHistory returns an empty DurationHistory.
+func NewDurationHistory() *DurationHistory {
+	return &DurationHistory{entries: map[[2]string]durationEntry{}}
+}
+
+// Record the duration of an Action.
+func (h *DurationHistory) Record(a Action, d time.Duration) {
+	typ, resource := actionLabels(a)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	e := h.entries[[2]string{typ, resource}]
+	e.total += d
+	e.n++
+	h.entries[[2]string{typ, resource}] = e
+	h.all.total += d
+	h.all.n++
+}
+
+// Estimate the duration of the Action. This is the average of the Actions
+// with the same type and resource kind or of all of the Actions if there
+// is none. Returns false if the history is empty.
+func (h *DurationHistory) Estimate(a Action) (time.Duration, bool) {
+	typ, resource := actionLabels(a)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if e, ok := h.entries[[2]string{typ, resource}]; ok {
+		return e.mean(), true
+	}
+	if h.all.n == 0 {
+		return 0, false
+	}
+	return h.all.mean(), true
+}
+
+// progressObserver tracks the Progress for the ProgressReporter. Observers
+// are called from a single goroutine so no locking is needed.
+type progressObserver struct {
+	NopObserver
+
+	r ProgressReporter
+	h *DurationHistory
+	p Progress
+	// remaining are the Actions that have not finished.
+	remaining map[string]Action
+	// start time of the running Actions.
+	start map[string]time.Time
+}
+
+func newProgressObserver(r ProgressReporter, h *DurationHistory, pending []Action) *progressObserver {
+	ret := &progressObserver{
+		r:         r,
+		h:         h,
+		p:         Progress{Total: len(pending)},
+		remaining: map[string]Action{},
+		start:     map[string]time.Time{},
+	}
+	for _, a := range pending {
+		ret.remaining[a.Metadata().Name] = a
+	}
+	return ret
+}
+
+func (o *progressObserver) ActionStarted(a Action) {
+	o.start[a.Metadata().Name] = time.Now()
+	o.report()
+}
+
+func (o *progressObserver) ActionFinished(a Action) {
+	name := a.Metadata().Name
+	if start, ok := o.start[name]; ok && o.h != nil {
+		o.h.Record(a, time.Since(start))
+	}
+	o.p.Completed++
+	o.done(name)
+}
+
+func (o *progressObserver) ActionErrored(a Action, _ error, _ bool) {
+	o.p.Failed++
+	o.done(a.Metadata().Name)
+}
+
+func (o *progressObserver) ActionSkipped(a Action) {
+	o.p.Skipped++
+	o.done(a.Metadata().Name)
+}
+
+func (o *progressObserver) done(name string) {
+	delete(o.start, name)
+	delete(o.remaining, name)
+	o.report()
+}
+
+func (o *progressObserver) report() {
+	p := o.p
+	for name := range o.start {
+		p.Running = append(p.Running, name)
+	}
+	sort.Strings(p.Running)
+	p.ETA = o.eta()
+	o.r.ReportProgress(p)
+}
+
+// eta is the sum of the estimated durations of the remaining Actions,
+// minus the time the running Actions have already taken.
+func (o *progressObserver) eta() time.Duration {
+	if o.h == nil {
+		return 0
+	}
+	var ret time.Duration
+	for name, a := range o.remaining {
+		d, ok := o.h.Estimate(a)
+		if !ok {
+			return 0
+		}
+		if start, ok := o.start[name]; ok {
+			d -= time.Since(start)
+		}
+		if d > 0 {
+			ret += d
+		}
+	}
+	return ret
+}
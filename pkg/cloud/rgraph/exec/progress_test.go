@@ -0,0 +1,121 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProgressOption(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		graph   string
+		history time.Duration
+		// want is the Progress reported, ignoring the ETA.
+		want []Progress
+		// wantETA is the minimum ETA of the first report.
+		wantETA time.Duration
+	}{
+		{
+			name:  "chain without history",
+			graph: "A -> B",
+			want: []Progress{
+				{Total: 2, Running: []string{"A([A])"}},
+				{Total: 2, Completed: 1},
+				{Total: 2, Completed: 1, Running: []string{"B([B])"}},
+				{Total: 2, Completed: 2},
+			},
+		},
+		{
+			name:    "chain with history",
+			graph:   "A -> B -> C",
+			history: time.Hour,
+			want: []Progress{
+				{Total: 3, Running: []string{"A([A])"}},
+				{Total: 3, Completed: 1},
+				{Total: 3, Completed: 1, Running: []string{"B([B])"}},
+				{Total: 3, Completed: 2},
+				{Total: 3, Completed: 2, Running: []string{"C([C])"}},
+				{Total: 3, Completed: 3},
+			},
+			wantETA: 2 * time.Hour,
+		},
+		{
+			name:  "error",
+			graph: "!A -> B",
+			want: []Progress{
+				{Total: 2, Running: []string{"A([A])"}},
+				{Total: 2, Failed: 1},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var h *DurationHistory
+			if tc.history > 0 {
+				h = NewDurationHistory()
+				h.Record(&testAction{}, tc.history)
+			}
+			var got []Progress
+			ex, err := NewSerialExecutor(actionsFromGraphStr(tc.graph),
+				ProgressOption(ProgressFunc(func(p Progress) { got = append(got, p) }), h))
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			ex.Run(context.Background(), nil)
+
+			if len(got) == 0 {
+				t.Fatalf("no Progress reported")
+			}
+			if eta := got[0].ETA; eta < tc.wantETA || (h == nil && eta != 0) {
+				t.Errorf("got[0].ETA = %v, want >= %v", eta, tc.wantETA)
+			}
+			if eta := got[len(got)-1].ETA; eta != 0 {
+				t.Errorf("last ETA = %v, want 0", eta)
+			}
+			for i := range got {
+				got[i].ETA = 0
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("Progress: -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDurationHistory(t *testing.T) {
+	h := NewDurationHistory()
+	a := &testAction{name: "a"}
+	if _, ok := h.Estimate(a); ok {
+		t.Errorf("h.Estimate() = _, true, want false for an empty history")
+	}
+	h.Record(a, time.Second)
+	h.Record(a, 3*time.Second)
+	if d, ok := h.Estimate(a); !ok || d != 2*time.Second {
+		t.Errorf("h.Estimate() = %v, %t, want 2s, true", d, ok)
+	}
+	// Other kinds of Actions use the average of all Actions.
+	ea := NewExistsAction(&cloud.ResourceID{ProjectID: "proj", Resource: "res", Key: meta.GlobalKey("x")})
+	if d, ok := h.Estimate(ea); !ok || d != 2*time.Second {
+		t.Errorf("h.Estimate(%v) = %v, %t, want 2s, true", ea, d, ok)
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/metrics"
+	"google.golang.org/api/googleapi"
+	"k8s.io/klog/v2"
+)
+
+// RetryProvider decides if a failed Action is retried.
+type RetryProvider interface {
+	// Retry is called after attempt (starting at 1) of the Action failed
+	// with err. Returns the time to wait before the next attempt and false
+	// if the Action should not be retried.
+	Retry(a Action, attempt int, err error) (time.Duration, bool)
+}
+
+// ExponentialBackoff retries errors with an exponentially increasing delay.
+type ExponentialBackoff struct {
+	// IsRetriable returns true if the error is transient.
+	IsRetriable func(error) bool
+	// Initial delay before the first retry.
+	Initial time.Duration
+	// Max is the maximum delay between attempts.
+	Max time.Duration
+	// Multiplier for the delay after each attempt.
+	Multiplier float64
+	// Jitter is the random fraction of the delay added to each wait,
+	// e.g. 0.1 for up to 10%.
+	Jitter float64
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+}
+
+var _ RetryProvider = (*ExponentialBackoff)(nil)
+
+func (b *ExponentialBackoff) Retry(_ Action, attempt int, err error) (time.Duration, bool) {
+	if attempt >= b.MaxAttempts || b.IsRetriable == nil || !b.IsRetriable(err) {
+		return 0, false
+	}
+	d := float64(b.Initial)
+	for i := 1; i < attempt; i++ {
+		d *= b.Multiplier
+		if d >= float64(b.Max) {
+			d = float64(b.Max)
+			break
+		}
+	}
+	d += d * b.Jitter * rand.Float64()
+	return time.Duration(d), true
+}
+
+// DefaultRetryProvider returns the RetryProvider for GCE API errors: errors
+// from concurrent operations (409), rate limits (429) and server errors (5xx)
+// are retried with exponential backoff and jitter.
+func DefaultRetryProvider() *ExponentialBackoff {
+	return &ExponentialBackoff{
+		IsRetriable: IsRetriableGCEError,
+		Initial:     time.Second,
+		Max:         30 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.2,
+		MaxAttempts: 5,
+	}
+}
+
+// IsRetriableGCEError returns true if the err is a transient error from the
+// GCE API. 409 errors are retried except when the resource already exists.
+func IsRetriableGCEError(err error) bool {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+	switch {
+	case gerr.Code == http.StatusConflict:
+		for _, item := range gerr.Errors {
+			if item.Reason == "alreadyExists" {
+				return false
+			}
+		}
+		return true
+	case gerr.Code == http.StatusTooManyRequests:
+		return true
+	case gerr.Code >= 500 && gerr.Code < 600:
+		return true
+	}
+	return false
+}
+
+// NewRetryRegistry returns a registry with def as the RetryProvider for
+// Actions that don't have a specific one. def may be nil to not retry by
+// default.
+func NewRetryRegistry(def RetryProvider) *RetryRegistry {
+	return &RetryRegistry{
+		def:          def,
+		byActionType: map[ActionType]RetryProvider{},
+		byResource:   map[string]RetryProvider{},
+	}
+}
+
+// DefaultRetryRegistry returns a registry that uses DefaultRetryProvider()
+// for all Actions.
+func DefaultRetryRegistry() *RetryRegistry {
+	return NewRetryRegistry(DefaultRetryProvider())
+}
+
+// RetryRegistry maps Actions to their RetryProvider. The provider is looked
+// up by the resource of the Action (see ResourceAction), then by the
+// ActionType, then the default is used. This object is thread-safe.
+type RetryRegistry struct {
+	lock         sync.RWMutex
+	def          RetryProvider
+	byActionType map[ActionType]RetryProvider
+	byResource   map[string]RetryProvider
+}
+
+// RegisterActionType sets the provider for Actions of type t. A nil provider
+// disables retries for the type.
+func (r *RetryRegistry) RegisterActionType(t ActionType, p RetryProvider) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.byActionType[t] = p
+}
+
+// RegisterResource sets the provider for Actions on the resource type, e.g.
+// "backendServices" (see cloud.ResourceID.Resource). A nil provider disables
+// retries for the resource.
+func (r *RetryRegistry) RegisterResource(resource string, p RetryProvider) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.byResource[resource] = p
+}
+
+// For returns the RetryProvider for the Action. Returns nil if the Action
+// should not be retried.
+func (r *RetryRegistry) For(a Action) RetryProvider {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if ra, ok := a.(ResourceAction); ok && ra.ResourceID() != nil {
+		if p, ok := r.byResource[ra.ResourceID().Resource]; ok {
+			return p
+		}
+	}
+	if p, ok := r.byActionType[a.Metadata().Type]; ok {
+		return p
+	}
+	return r.def
+}
+
+// withRetry wraps run to retry the Actions that fail with the provider from
+// the registry. The whole Action is run again so Actions with multiple
+// steps should be safe to retry. Retries are reported to m if non-nil.
+func withRetry(r *RetryRegistry, m metrics.Recorder, run func(context.Context, cloud.Cloud, Action) (EventList, error)) func(context.Context, cloud.Cloud, Action) (EventList, error) {
+	return func(ctx context.Context, c cloud.Cloud, a Action) (EventList, error) {
+		p := r.For(a)
+		for attempt := 1; ; attempt++ {
+			events, err := run(ctx, c, a)
+			if err == nil || p == nil {
+				return events, err
+			}
+			d, ok := p.Retry(a, attempt, err)
+			if !ok {
+				if attempt > 1 {
+					err = &retriedError{attempts: attempt, err: err}
+				}
+				return events, err
+			}
+			klog.V(2).Infof("Action %s failed (attempt %d), retrying in %v: %v", a, attempt, d, err)
+			if m != nil {
+				m.RecordRetry(actionLabels(a))
+			}
+			select {
+			case <-ctx.Done():
+				return events, &retriedError{attempts: attempt, err: fmt.Errorf("%w (retry cancelled: %v)", err, ctx.Err())}
+			case <-time.After(d):
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsRetriableGCEError(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil"},
+		{name: "not googleapi", err: errors.New("x")},
+		{name: "404", err: &googleapi.Error{Code: http.StatusNotFound}},
+		{name: "409", err: &googleapi.Error{Code: http.StatusConflict}, want: true},
+		{
+			name: "409 alreadyExists",
+			err:  &googleapi.Error{Code: http.StatusConflict, Errors: []googleapi.ErrorItem{{Reason: "alreadyExists"}}},
+		},
+		{name: "429", err: &googleapi.Error{Code: http.StatusTooManyRequests}, want: true},
+		{name: "503", err: &googleapi.Error{Code: http.StatusServiceUnavailable}, want: true},
+		{name: "wrapped 500", err: fmt.Errorf("x: %w", &googleapi.Error{Code: http.StatusInternalServerError}), want: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := IsRetriableGCEError(tc.err); got != tc.want {
+				t.Errorf("IsRetriableGCEError(%v) = %t, want %t", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := &ExponentialBackoff{
+		IsRetriable: func(error) bool { return true },
+		Initial:     time.Second,
+		Max:         5 * time.Second,
+		Multiplier:  2,
+		MaxAttempts: 5,
+	}
+	var got []time.Duration
+	for attempt := 1; ; attempt++ {
+		d, ok := b.Retry(nil, attempt, errors.New("x"))
+		if !ok {
+			break
+		}
+		got = append(got, d)
+	}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("delays = %v, want %v", got, want)
+	}
+
+	b.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		if d, _ := b.Retry(nil, 1, errors.New("x")); d < time.Second || d > 1500*time.Millisecond {
+			t.Errorf("delay with jitter = %v, want in [1s, 1.5s]", d)
+		}
+	}
+}
+
+// noWait retries up to attempts times without waiting.
+type noWait struct{ attempts int }
+
+func (p noWait) Retry(_ Action, attempt int, _ error) (time.Duration, bool) {
+	return 0, attempt < p.attempts
+}
+
+func TestRetryRegistry(t *testing.T) {
+	def, forBS := noWait{1}, noWait{3}
+	r := NewRetryRegistry(def)
+	r.RegisterResource("backendServices", forBS)
+	r.RegisterActionType(ActionTypeMeta, nil)
+
+	bsID := &cloud.ResourceID{Resource: "backendServices", Key: meta.GlobalKey("bs")}
+	for _, tc := range []struct {
+		name string
+		a    Action
+		want RetryProvider
+	}{
+		{name: "default", a: &testAction{name: "A"}, want: def},
+		{name: "resource", a: &countingAction{id: bsID}, want: forBS},
+		{name: "action type", a: NewExistsAction(bsID), want: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := r.For(tc.a); got != tc.want {
+				t.Errorf("For() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// flakyAction fails with err the first failures times it is run.
+type flakyAction struct {
+	testAction
+	failures int
+	runs     int
+}
+
+func (a *flakyAction) Run(ctx context.Context, c cloud.Cloud) (EventList, error) {
+	a.runs++
+	if a.runs <= a.failures {
+		return nil, a.err
+	}
+	return a.events, nil
+}
+
+func TestRetryOption(t *testing.T) {
+	unavailable := &googleapi.Error{Code: http.StatusServiceUnavailable}
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		for _, tc := range []struct {
+			name     string
+			err      error
+			failures int
+			wantRuns int
+			wantErr  bool
+		}{
+			{name: "no error", wantRuns: 1},
+			{name: "transient error", err: unavailable, failures: 2, wantRuns: 3},
+			{name: "too many errors", err: unavailable, failures: 5, wantRuns: 3, wantErr: true},
+			{name: "not retriable", err: &googleapi.Error{Code: http.StatusBadRequest}, failures: 1, wantRuns: 1, wantErr: true},
+		} {
+			t.Run(newExecutor.name+"/"+tc.name, func(t *testing.T) {
+				a := &flakyAction{
+					testAction: testAction{name: "A", events: EventList{StringEvent("A")}, err: tc.err},
+					failures:   tc.failures,
+				}
+				r := NewRetryRegistry(&ExponentialBackoff{
+					IsRetriable: IsRetriableGCEError,
+					Multiplier:  2,
+					MaxAttempts: 3,
+				})
+				ex, err := newExecutor.f([]Action{a}, RetryOption(r))
+				if err != nil {
+					t.Fatalf("newExecutor() = %v, want nil", err)
+				}
+				_, err = ex.Run(context.Background(), nil)
+				if gotErr := err != nil; gotErr != tc.wantErr {
+					t.Errorf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+				}
+				if a.runs != tc.wantRuns {
+					t.Errorf("runs = %d, want %d", a.runs, tc.wantRuns)
+				}
+			})
+		}
+	}
+}
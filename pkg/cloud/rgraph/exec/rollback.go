@@ -0,0 +1,121 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"k8s.io/klog/v2"
+)
+
+// ReversibleAction is implemented by Actions that can be undone after they
+// have completed, e.g. a create is undone by deleting the resource.
+type ReversibleAction interface {
+	Action
+	// Inverse returns an Action that reverts the changes made by this
+	// Action. The returned Action must not depend on any Events.
+	Inverse() (Action, error)
+}
+
+// RollbackOption will revert the completed Actions if the execution fails.
+// The inverse Actions are run one at a time, in the reverse order of
+// completion. Only ReversibleActions are reverted; the other Actions (e.g.
+// deletions) are left as-is.
+func RollbackOption(rollback bool) Option {
+	return func(c *ExecutorConfig) { c.Rollback = rollback }
+}
+
+// RollbackOnAbortOption also reverts the completed Actions when the execution
+// is stopped with Handle.Abort(). By default, an aborted execution is not
+// rolled back: an emergency stop should not start deleting resources.
+func RollbackOnAbortOption(rollback bool) Option {
+	return func(c *ExecutorConfig) { c.RollbackOnAbort = rollback }
+}
+
+// shouldRollback returns true if the completed Actions must be reverted after
+// the execution returned err.
+func (c *ExecutorConfig) shouldRollback(err error) bool {
+	if err == nil || !c.Rollback {
+		return false
+	}
+	return c.RollbackOnAbort || !errors.Is(err, ErrAborted)
+}
+
+// DefaultRollbackTimeout is the default budget for the rollback. See
+// RollbackTimeoutOption.
+const DefaultRollbackTimeout = 10 * time.Minute
+
+// RollbackTimeoutOption sets the budget for reverting the completed Actions.
+// The rollback is not canceled with the context given to Run() (the
+// execution may have failed because it was canceled) and is only bounded by
+// this timeout. 0 means no limit.
+func RollbackTimeoutOption(d time.Duration) Option {
+	return func(c *ExecutorConfig) { c.RollbackTimeout = d }
+}
+
+// detachedContext has the values of the parent context but not its deadline
+// or cancellation.
+type detachedContext struct{ parent context.Context }
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
+
+// rollback runs the inverse of the completed Actions in reverse order.
+// Returns the inverse Actions that were run and the errors.
+func rollback(
+	ctx context.Context,
+	timeout time.Duration,
+	c cloud.Cloud,
+	runFunc func(context.Context, cloud.Cloud, Action) (EventList, error),
+	completed []Action,
+) ([]Action, []ActionWithErr) {
+	var (
+		done []Action
+		errs []ActionWithErr
+	)
+	ctx = detachedContext{parent: ctx}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	for i := len(completed) - 1; i >= 0; i-- {
+		ra, ok := completed[i].(ReversibleAction)
+		if !ok {
+			klog.V(2).Infof("rollback: %s is not reversible, skipping", completed[i])
+			continue
+		}
+		inv, err := ra.Inverse()
+		if err != nil {
+			errs = append(errs, ActionWithErr{Action: ra, Err: newActionError(ra, fmt.Errorf("Inverse(): %w", err), false)})
+			continue
+		}
+		klog.Infof("rollback: runAction %s (reverting %s)", inv, ra)
+		if _, err := runFunc(ctx, c, inv); err != nil {
+			errs = append(errs, ActionWithErr{Action: inv, Err: newActionError(inv, err, false)})
+			continue
+		}
+		done = append(done, inv)
+	}
+	return done, errs
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// CloudRouter returns the Cloud to use for the resources in projectID or nil
+// to use the default Cloud. This allows a graph to contain resources from
+// multiple projects (e.g. a shared VPC or a cross-project load balancer) that
+// are accessed with different credentials.
+//
+// Calls for a resource are always made to the project in its ResourceID
+// (see cloud.ForceProjectID), so a CloudRouter is only needed if the
+// projects are not reachable through the same Cloud.
+type CloudRouter func(projectID string) cloud.Cloud
+
+// ProjectClouds returns a CloudRouter that uses the Cloud in clouds for the
+// project. Projects that are not in clouds use the default Cloud.
+func ProjectClouds(clouds map[string]cloud.Cloud) CloudRouter {
+	return func(projectID string) cloud.Cloud { return clouds[projectID] }
+}
+
+// For returns the Cloud for the resource id, falling back to def. r may be
+// nil.
+func (r CloudRouter) For(id *cloud.ResourceID, def cloud.Cloud) cloud.Cloud {
+	if r == nil || id == nil {
+		return def
+	}
+	if c := r(id.ProjectID); c != nil {
+		return c
+	}
+	return def
+}
+
+// CloudRouterOption routes each ResourceAction to the Cloud for the project
+// of its resource. Other Actions are run with the Cloud given to Run().
+func CloudRouterOption(r CloudRouter) Option {
+	return func(c *ExecutorConfig) { c.CloudRouter = r }
+}
+
+// routedAction is implemented by Actions that are routed by the resource they
+// operate on without being a ResourceAction (e.g. waitAction).
+type routedAction interface {
+	routeID() *cloud.ResourceID
+}
+
+// withCloudRouter wraps run to call the Action with the Cloud for its
+// resource.
+func withCloudRouter(r CloudRouter, run func(context.Context, cloud.Cloud, Action) (EventList, error)) func(context.Context, cloud.Cloud, Action) (EventList, error) {
+	return func(ctx context.Context, c cloud.Cloud, a Action) (EventList, error) {
+		switch a := a.(type) {
+		case ResourceAction:
+			c = r.For(a.ResourceID(), c)
+		case routedAction:
+			c = r.For(a.routeID(), c)
+		}
+		return run(ctx, c, a)
+	}
+}
@@ -26,8 +26,11 @@ type Tracer interface {
 
 // TraceEntry represents the execution of an Action.
 type TraceEntry struct {
-	Action   Action
-	Err      error
+	Action Action
+	Err    error
+	// Events returned by the Action (from DryRun() in dry run mode),
+	// including the ones that no pending Action was waiting for.
+	Events   EventList
 	Signaled []TraceSignal
 
 	Start time.Time
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import "sync"
+
+// NewStreamTracer returns a Tracer that calls onRecord as each Action is
+// run, in the order of execution. seq is the 0-based position of the Action
+// in the trace. onFinish (optional) is called with the Actions that were not
+// run at the end of the execution.
+//
+// Combined with DryRunOption(true), this streams a simulated execution of the
+// Actions and the Events they would signal, e.g. to display the progress of
+// the execution:
+//
+//	tr := NewStreamTracer(func(seq int, te *TraceEntry) {
+//		fmt.Printf("%d: %s -> %v\n", seq, te.Action, te.Events)
+//	}, nil)
+//	ex, err := NewSerialExecutor(actions, DryRunOption(true), TracerOption(tr))
+func NewStreamTracer(onRecord func(seq int, te *TraceEntry), onFinish func(pending []Action)) *StreamTracer {
+	return &StreamTracer{
+		onRecord: onRecord,
+		onFinish: onFinish,
+	}
+}
+
+// StreamTracer calls funcs for each Action as it is run. The calls are
+// serialized so the funcs do not need to be thread-safe.
+type StreamTracer struct {
+	lock     sync.Mutex
+	seq      int
+	onRecord func(seq int, te *TraceEntry)
+	onFinish func(pending []Action)
+}
+
+var _ Tracer = (*StreamTracer)(nil)
+
+func (tr *StreamTracer) Record(entry *TraceEntry, err error) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	if entry.Err == nil {
+		entry.Err = err
+	}
+	tr.onRecord(tr.seq, entry)
+	tr.seq++
+}
+
+func (tr *StreamTracer) Finish(pending []Action) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	if tr.onFinish != nil {
+		tr.onFinish(pending)
+	}
+}
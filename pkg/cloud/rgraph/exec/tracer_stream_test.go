@@ -0,0 +1,90 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStreamTracerDryRun(t *testing.T) {
+	for _, newExecutor := range []struct {
+		name string
+		f    func([]Action, ...Option) (Executor, error)
+	}{
+		{"serial", func(a []Action, o ...Option) (Executor, error) { return NewSerialExecutor(a, o...) }},
+		{"parallel", func(a []Action, o ...Option) (Executor, error) { return NewParallelExecutor(a, o...) }},
+	} {
+		t.Run(newExecutor.name, func(t *testing.T) {
+			actions := actionsFromGraphStr("A -> B -> C; A -> D")
+			// E waits for an Event that is never signaled.
+			e := &testAction{name: "E", events: EventList{StringEvent("E")}}
+			e.Want = EventList{StringEvent("X")}
+			actions = append(actions, e)
+
+			var (
+				seqs       []int
+				order      = map[string]int{}
+				events     = map[string][]string{}
+				gotPending []string
+			)
+			tr := NewStreamTracer(func(seq int, te *TraceEntry) {
+				name := te.Action.(*testAction).name
+				seqs = append(seqs, seq)
+				order[name] = seq
+				for _, ev := range te.Events {
+					events[name] = append(events[name], ev.String())
+				}
+			}, func(pending []Action) {
+				for _, a := range pending {
+					gotPending = append(gotPending, a.(*testAction).name)
+				}
+			})
+
+			ex, err := newExecutor.f(actions, DryRunOption(true), TracerOption(tr))
+			if err != nil {
+				t.Fatalf("newExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background(), nil); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+
+			if diff := cmp.Diff(seqs, []int{0, 1, 2, 3}); diff != "" {
+				t.Errorf("seqs: -got,+want: %s", diff)
+			}
+			for _, dep := range [][2]string{{"A", "B"}, {"B", "C"}, {"A", "D"}} {
+				if order[dep[0]] >= order[dep[1]] {
+					t.Errorf("%s (seq %d) traced after %s (seq %d)", dep[0], order[dep[0]], dep[1], order[dep[1]])
+				}
+			}
+			wantEvents := map[string][]string{
+				"A": {"A"},
+				"B": {"B"},
+				"C": {"C"},
+				"D": {"D"},
+			}
+			if diff := cmp.Diff(events, wantEvents); diff != "" {
+				t.Errorf("events: -got,+want: %s", diff)
+			}
+			if diff := cmp.Diff(gotPending, []string{"E"}); diff != "" {
+				t.Errorf("pending: -got,+want: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// NewReadyEvent returns an event that signals that the resource ID has
+// reached the named condition (e.g. "Healthy"). This is signaled by the
+// Action returned by NewWaitAction.
+func NewReadyEvent(id *cloud.ResourceID, condition string) Event {
+	return &readyEvent{id: id, condition: condition}
+}
+
+type readyEvent struct {
+	id        *cloud.ResourceID
+	condition string
+}
+
+func (e *readyEvent) Equal(other Event) bool {
+	switch other := other.(type) {
+	case *readyEvent:
+		return e.id.Equal(other.id) && e.condition == other.condition
+	}
+	return false
+}
+
+func (e *readyEvent) String() string {
+	return fmt.Sprintf("Ready(%v, %s)", e.id, e.condition)
+}
+
+// WaitCondition returns true when the condition being waited for holds.
+// Errors stop the wait.
+type WaitCondition func(ctx context.Context, cl cloud.Cloud) (bool, error)
+
+// WaitOptions configure the polling of a WaitCondition.
+type WaitOptions struct {
+	// Interval between polls. Defaults to DefaultWaitInterval.
+	Interval time.Duration
+	// Timeout for the condition to hold. 0 means the wait is only bounded
+	// by the context (see ActionTimeoutOption).
+	Timeout time.Duration
+}
+
+// DefaultWaitInterval is the polling interval if WaitOptions.Interval is
+// not set.
+const DefaultWaitInterval = 5 * time.Second
+
+// NewWaitAction returns an Action that polls the condition on the resource
+// until it holds and then signals NewReadyEvent(id, condition). The Action
+// waits for the resource to exist before polling.
+//
+// Use Gate() to make other Actions wait for the condition, e.g. to not
+// switch traffic to a backend until its endpoints are healthy.
+func NewWaitAction(id *cloud.ResourceID, condition string, f WaitCondition, opts WaitOptions) Action {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWaitInterval
+	}
+	return &waitAction{
+		ActionBase: ActionBase{Want: EventList{NewExistsEvent(id)}},
+		id:         id,
+		condition:  condition,
+		f:          f,
+		opts:       opts,
+	}
+}
+
+type waitAction struct {
+	ActionBase
+
+	id        *cloud.ResourceID
+	condition string
+	f         WaitCondition
+	opts      WaitOptions
+}
+
+// waitAction is intentionally not a ResourceAction: polling should not take
+// a slot from the mutations limited by ScopeConcurrencyOption.
+var _ Action = (*waitAction)(nil)
+
+func (a *waitAction) Run(ctx context.Context, cl cloud.Cloud) (EventList, error) {
+	if a.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.opts.Timeout)
+		defer cancel()
+	}
+	ticker := time.NewTicker(a.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		ok, err := a.f(ctx, cl)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", a, err)
+		}
+		if ok {
+			return a.DryRun(), nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%v: condition not met: %w", a, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *waitAction) routeID() *cloud.ResourceID { return a.id }
+
+func (a *waitAction) DryRun() EventList {
+	return EventList{NewReadyEvent(a.id, a.condition)}
+}
+
+func (a *waitAction) String() string {
+	return fmt.Sprintf("WaitAction(%v, %s)", a.id, a.condition)
+}
+
+func (a *waitAction) Metadata() *ActionMetadata {
+	return &ActionMetadata{
+		Name:    a.String(),
+		Type:    ActionTypeCustom,
+		Summary: fmt.Sprintf("Wait for %v to be %s", a.id, a.condition),
+	}
+}
+
+// Gate makes the Actions operating on any of the ids wait for ev in addition
+// to their existing dependencies. Actions that are not a ResourceAction are
+// not changed. Returns an error if a matching Action cannot be gated, i.e.
+// it does not embed ActionBase.
+func Gate(actions []Action, ev Event, ids ...*cloud.ResourceID) error {
+	type wanter interface{ AddWant(...Event) }
+
+	for _, a := range actions {
+		ra, ok := a.(ResourceAction)
+		if !ok || ra.ResourceID() == nil {
+			continue
+		}
+		for _, id := range ids {
+			if !ra.ResourceID().Equal(id) {
+				continue
+			}
+			w, ok := a.(wanter)
+			if !ok {
+				return fmt.Errorf("Gate: %v (%T) cannot wait for events", a, a)
+			}
+			w.AddWant(ev)
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWaitAction(t *testing.T) {
+	id := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("r1")}
+
+	for _, tc := range []struct {
+		name      string
+		readyAt   int
+		err       error
+		opts      WaitOptions
+		wantPolls int
+		wantErr   bool
+	}{
+		{
+			name:      "ready immediately",
+			readyAt:   1,
+			wantPolls: 1,
+		},
+		{
+			name:      "ready after polling",
+			readyAt:   3,
+			wantPolls: 3,
+		},
+		{
+			name:      "error",
+			readyAt:   3,
+			err:       errors.New("injected"),
+			wantPolls: 1,
+			wantErr:   true,
+		},
+		{
+			name:    "timeout",
+			readyAt: 1000,
+			opts:    WaitOptions{Timeout: 20 * time.Millisecond},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var polls int
+			f := func(context.Context, cloud.Cloud) (bool, error) {
+				polls++
+				return polls >= tc.readyAt, tc.err
+			}
+			if tc.opts.Interval == 0 {
+				tc.opts.Interval = time.Millisecond
+			}
+			a := NewWaitAction(id, "Healthy", f, tc.opts)
+			if a.CanRun() {
+				t.Fatalf("CanRun() = true before Exists(%v) was signaled", id)
+			}
+			a.Signal(NewExistsEvent(id))
+			if !a.CanRun() {
+				t.Fatalf("CanRun() = false, want true")
+			}
+
+			events, err := a.Run(context.Background(), nil)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if tc.wantPolls != 0 && polls != tc.wantPolls {
+				t.Errorf("polls = %d, want %d", polls, tc.wantPolls)
+			}
+			if err != nil {
+				return
+			}
+			if want := (EventList{NewReadyEvent(id, "Healthy")}); !events.Equal(want) {
+				t.Errorf("Run() = %v, want %v", events, want)
+			}
+		})
+	}
+}
+
+func TestGate(t *testing.T) {
+	backend := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("backend")}
+	frontend := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("frontend")}
+	other := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("other")}
+
+	var polls int
+	wait := NewWaitAction(backend, "Healthy", func(context.Context, cloud.Cloud) (bool, error) {
+		polls++
+		return polls >= 2, nil
+	}, WaitOptions{Interval: time.Millisecond})
+
+	createBackend := &mergeableAction{
+		testAction: testAction{name: "createBackend", events: EventList{NewExistsEvent(backend)}},
+		id:         backend,
+	}
+	updateFrontend := &mergeableAction{
+		testAction: testAction{name: "updateFrontend", events: EventList{StringEvent("updateFrontend")}},
+		id:         frontend,
+	}
+	updateOther := &mergeableAction{
+		testAction: testAction{name: "updateOther", events: EventList{StringEvent("updateOther")}},
+		id:         other,
+	}
+	actions := []Action{updateFrontend, updateOther, wait, createBackend}
+
+	if err := Gate(actions, NewReadyEvent(backend, "Healthy"), frontend); err != nil {
+		t.Fatalf("Gate() = %v, want nil", err)
+	}
+	if updateFrontend.CanRun() {
+		t.Errorf("updateFrontend.CanRun() = true after Gate()")
+	}
+	if !updateOther.CanRun() {
+		t.Errorf("updateOther.CanRun() = false, want true (not gated)")
+	}
+	if err := Gate([]Action{NewExistsAction(frontend), &testAction{name: "x"}}, StringEvent("x"), frontend); err != nil {
+		t.Errorf("Gate() = %v, want nil for Actions that are not ResourceActions", err)
+	}
+
+	ex, err := NewSerialExecutor(actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	result, err := ex.Run(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	var order []string
+	for _, a := range result.Completed {
+		order = append(order, a.String())
+	}
+	// updateOther has no dependencies and runs first; updateFrontend must
+	// come after the wait.
+	want := []string{updateOther.String(), createBackend.String(), wait.String(), updateFrontend.String()}
+	if diff := cmp.Diff(order, want); diff != "" {
+		t.Errorf("Completed: -got,+want: %s", diff)
+	}
+}
@@ -19,6 +19,7 @@ package rgraph
 import (
 	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
@@ -36,15 +37,36 @@ type Graph struct {
 	nodes map[cloud.ResourceMapKey]rnode.Node
 }
 
-// All of the nodes in the Graph.
+// All of the nodes in the Graph, sorted by ID. The order is stable so that
+// the output of the algorithms (e.g. the Actions of a plan) is reproducible.
 func (g *Graph) All() []rnode.Node {
 	var ret []rnode.Node
 	for _, n := range g.nodes {
 		ret = append(ret, n)
 	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID().String() < ret[j].ID().String() })
 	return ret
 }
 
+// Clone returns a copy of the Graph with the same resources and settings
+// (see rnode.CopyBuilderSettings). The plans of the nodes are not copied. This
+// can be used to plan the same Graph more than once as planning modifies the
+// "want" Graph.
+func (g *Graph) Clone() (*Graph, error) {
+	b := NewBuilder()
+	for _, n := range g.All() {
+		nb := n.Builder()
+		if r := n.Resource(); r != nil {
+			if err := nb.SetResource(r); err != nil {
+				return nil, fmt.Errorf("Clone: %w", err)
+			}
+		}
+		rnode.CopyBuilderSettings(n, nb)
+		b.Add(nb)
+	}
+	return b.Build()
+}
+
 // Get returns the Node named by id. Returns nil if the resource does not exist
 // in the Graph.
 func (g *Graph) Get(id *cloud.ResourceID) rnode.Node {
@@ -57,7 +79,11 @@ func (g *Graph) Get(id *cloud.ResourceID) rnode.Node {
 func (g *Graph) NewBuilderWithEmptyNodes() *Builder {
 	builder := NewBuilder()
 	for _, n := range g.nodes {
-		builder.Add(n.Builder())
+		nb := n.Builder()
+		// The resource must be fetched with the same API version as
+		// the node.
+		nb.SetVersion(n.Version())
+		builder.Add(nb)
 	}
 	return builder
 }
@@ -71,6 +97,26 @@ func (g *Graph) AddTombstone(n rnode.Node) error {
 	return nil
 }
 
+// AddExternal adds a node for a resource that is not managed by the graph,
+// e.g. a resource found in the Cloud that is owned by another component.
+// Returns a CycleError if the node forms a reference cycle with the nodes
+// in the Graph; the node is not added.
+func (g *Graph) AddExternal(n rnode.Node) error {
+	if n.Ownership() != rnode.OwnershipExternal {
+		return fmt.Errorf("graph: invalid external node (want ownership %s, but got %s)", rnode.OwnershipExternal, n.Ownership())
+	}
+	outRefs := map[cloud.ResourceMapKey][]rnode.ResourceRef{}
+	for k, gn := range g.nodes {
+		outRefs[k] = gn.OutRefs()
+	}
+	outRefs[n.ID().MapKey()] = n.OutRefs()
+	if err := findCycle(outRefs); err != nil {
+		return fmt.Errorf("graph: AddExternal %s: %w", n.ID(), err)
+	}
+	g.nodes[n.ID().MapKey()] = n
+	return nil
+}
+
 // add a note to the graph. This is package internal on purpose and
 // should not be used outside of internal implementation of the graph
 // package.
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/fake"
@@ -129,11 +130,11 @@ func TestGraph(t *testing.T) {
 				b.Add(b0)
 
 				b1 := fake.NewBuilder(ids[1])
-				b1.FakeOutRefs = append(b0.FakeOutRefs, rnode.ResourceRef{From: ids[1], To: ids[3]})
+				b1.FakeOutRefs = append(b1.FakeOutRefs, rnode.ResourceRef{From: ids[1], To: ids[3]})
 				b.Add(b1)
 
 				b2 := fake.NewBuilder(ids[2])
-				b2.FakeOutRefs = append(b0.FakeOutRefs, rnode.ResourceRef{From: ids[2], To: ids[3]})
+				b2.FakeOutRefs = append(b2.FakeOutRefs, rnode.ResourceRef{From: ids[2], To: ids[3]})
 				b.Add(b2)
 
 				b.Add(fake.NewBuilder(ids[3]))
@@ -273,7 +274,9 @@ func TestGraphNewBuilder(t *testing.T) {
 	b0 := fake.NewBuilder(ids[0])
 	b0.FakeOutRefs = append(b0.FakeOutRefs, rnode.ResourceRef{From: ids[0], To: ids[1]})
 	b.Add(b0)
-	b.Add(fake.NewBuilder(ids[1]))
+	b1 := fake.NewBuilder(ids[1])
+	b1.SetVersion(meta.VersionBeta)
+	b.Add(b1)
 
 	b.Get(ids[0]).SetOwnership(rnode.OwnershipManaged)
 	b.Get(ids[1]).SetOwnership(rnode.OwnershipManaged)
@@ -291,6 +294,70 @@ func TestGraphNewBuilder(t *testing.T) {
 	}); diff != "" {
 		t.Errorf("Diff() -got,+want: %s", diff)
 	}
+	// The API version of the node is kept for fetching the resource.
+	if v := b.Get(ids[1]).Version(); v != meta.VersionBeta {
+		t.Errorf("b.Get(%s).Version() = %s, want %s", ids[1], v, meta.VersionBeta)
+	}
+}
+
+func TestGraphAllSorted(t *testing.T) {
+	b := NewBuilder()
+	for _, name := range []string{"r3", "r1", "r4", "r0", "r2"} {
+		nb := fake.NewBuilder(&cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey(name)})
+		nb.SetOwnership(rnode.OwnershipManaged)
+		b.Add(nb)
+	}
+	want := []string{"r0", "r1", "r2", "r3", "r4"}
+
+	var got []string
+	for _, nb := range b.All() {
+		got = append(got, nb.ID().Key.Name)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Builder.All(): -got,+want: %s", diff)
+	}
+
+	got = nil
+	for _, n := range b.MustBuild().All() {
+		got = append(got, n.ID().Key.Name)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Graph.All(): -got,+want: %s", diff)
+	}
+}
+
+func TestGraphClone(t *testing.T) {
+	id := &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey("r0")}
+	path := api.Path{}.Pointer().Field("Value")
+
+	b := NewBuilder()
+	nb := fake.NewBuilder(id)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	nb.IgnoreDiff(path)
+	b.Add(nb)
+	g := b.MustBuild()
+	g.Get(id).Plan().Set(rnode.PlanDetails{Operation: rnode.OpUpdate})
+
+	c, err := g.Clone()
+	if err != nil {
+		t.Fatalf("Clone() = %v, want nil", err)
+	}
+	n := c.Get(id)
+	if n == nil {
+		t.Fatalf("Clone() is missing node %s", id)
+	}
+	if n.Ownership() != rnode.OwnershipManaged || n.State() != rnode.NodeExists {
+		t.Errorf("node = (%s, %s), want (%s, %s)", n.Ownership(), n.State(), rnode.OwnershipManaged, rnode.NodeExists)
+	}
+	if op := n.Plan().Op(); op != rnode.OpUnknown {
+		t.Errorf("Plan().Op() = %s, want %s", op, rnode.OpUnknown)
+	}
+	settings := fake.NewBuilder(id)
+	rnode.CopyBuilderSettings(n, settings)
+	if diff := cmp.Diff(settings.IgnoredDiffs(), []api.Path{path}); diff != "" {
+		t.Errorf("IgnoredDiffs(): -got,+want: %s", diff)
+	}
 }
 
 func TestGraphAddTombstone(t *testing.T) {
@@ -338,3 +405,199 @@ func TestGraphAddTombstone(t *testing.T) {
 		t.Fatalf("g.AddTombstone() = nil, want error")
 	}
 }
+
+func TestGraphAddExternalCycle(t *testing.T) {
+	ids := make([]*cloud.ResourceID, 3)
+	for i := 0; i < len(ids); i++ {
+		ids[i] = &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey(fmt.Sprintf("r%d", i))}
+	}
+	external := func(from int, refs ...*cloud.ResourceID) rnode.Node {
+		nb := fake.NewBuilder(ids[from])
+		for _, to := range refs {
+			nb.FakeOutRefs = append(nb.FakeOutRefs, rnode.ResourceRef{From: ids[from], To: to})
+		}
+		nb.SetOwnership(rnode.OwnershipExternal)
+		n, err := nb.Build()
+		if err != nil {
+			t.Fatalf("nb.Build() = %v", err)
+		}
+		return n
+	}
+
+	// r0 -> r1 -> r2
+	g := NewBuilder().MustBuild()
+	for i := 0; i < 2; i++ {
+		if err := g.AddExternal(external(i, ids[i+1])); err != nil {
+			t.Fatalf("g.AddExternal(r%d) = %v, want nil", i, err)
+		}
+	}
+
+	// r2 -> r0 closes the cycle.
+	err := g.AddExternal(external(2, ids[0]))
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("g.AddExternal(r2 -> r0) = %v, want CycleError", err)
+	}
+	if n := g.Get(ids[2]); n != nil {
+		t.Errorf("g.Get(r2) = %v, want nil", n)
+	}
+	// r2 without references is fine.
+	if err := g.AddExternal(external(2)); err != nil {
+		t.Fatalf("g.AddExternal(r2) = %v, want nil", err)
+	}
+	if n := g.Get(ids[2]); n == nil {
+		t.Errorf("g.Get(r2) = nil, want node")
+	}
+}
+
+func TestBuilderInvalidName(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		ownership rnode.OwnershipStatus
+		wantErr   bool
+	}{
+		{"valid-name", rnode.OwnershipManaged, false},
+		{"Invalid_Name", rnode.OwnershipManaged, true},
+		{strings.Repeat("a", meta.MaxNameLength+1), rnode.OwnershipManaged, true},
+		{"Invalid_Name", rnode.OwnershipExternal, false},
+	} {
+		b := NewBuilder()
+		nb := fake.NewBuilder(&cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey(tc.name)})
+		nb.SetOwnership(tc.ownership)
+		b.Add(nb)
+
+		_, err := b.Build()
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("Build() with name %q, ownership %s = %v; gotErr = %t, want %t", tc.name, tc.ownership, err, gotErr, tc.wantErr)
+		}
+	}
+
+	// Only the parent name of sub-resources is validated.
+	parent := &cloud.ResourceID{Resource: "fake", Key: meta.ZonalKey("neg", "us-central1-b")}
+	b := NewBuilder()
+	nb := fake.NewBuilder(cloud.NewSubResourceID(parent, meta.NetworkEndpointSubKey(parent.Key, "vm", "10.0.0.1", 80)))
+	nb.SetOwnership(rnode.OwnershipManaged)
+	b.Add(nb)
+	if _, err := b.Build(); err != nil {
+		t.Errorf("Build() with sub-resource = %v, want nil", err)
+	}
+}
+
+func TestBuilderCycle(t *testing.T) {
+	id := func(name string) *cloud.ResourceID {
+		return &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey(name)}
+	}
+	for _, tc := range []struct {
+		name  string
+		graph string
+		// wantCycle is the sequence of nodes in the cycle. Empty if there
+		// is no cycle.
+		wantCycle []string
+	}{
+		{name: "no cycle", graph: "a -> b -> c; a -> c"},
+		{name: "self reference", graph: "a -> a", wantCycle: []string{"a", "a"}},
+		{name: "cycle two", graph: "a -> b -> a", wantCycle: []string{"a", "b", "a"}},
+		{name: "cycle", graph: "a -> b -> c -> a", wantCycle: []string{"a", "b", "c", "a"}},
+		{name: "cycle many", graph: "a -> b -> c -> d -> a", wantCycle: []string{"a", "b", "c", "d", "a"}},
+		{name: "complex cycle", graph: "a -> b -> c; b -> c; c -> a; c -> d -> e", wantCycle: []string{"a", "b", "c", "a"}},
+		{name: "cycle not from root", graph: "a -> b -> c -> d -> b", wantCycle: []string{"b", "c", "d", "b"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBuilder()
+			topo := parseTopology(tc.graph)
+			for n := range topo.nodes {
+				nb := fake.NewBuilder(id(n))
+				for to := range topo.edges[n] {
+					nb.FakeOutRefs = append(nb.FakeOutRefs, rnode.ResourceRef{
+						From: id(n),
+						Path: api.Path{}.Pointer().Field("Ref"),
+						To:   id(to),
+					})
+				}
+				nb.SetOwnership(rnode.OwnershipManaged)
+				b.Add(nb)
+			}
+			_, err := b.Build()
+			if len(tc.wantCycle) == 0 {
+				if err != nil {
+					t.Fatalf("Build() = %v, want nil", err)
+				}
+				return
+			}
+			var cycleErr *CycleError
+			if !errors.As(err, &cycleErr) {
+				t.Fatalf("Build() = %v, want CycleError", err)
+			}
+			var got []string
+			for _, ref := range cycleErr.Path {
+				got = append(got, ref.From.Key.Name)
+			}
+			got = append(got, cycleErr.Path[len(cycleErr.Path)-1].To.Key.Name)
+			if diff := cmp.Diff(got, tc.wantCycle); diff != "" {
+				t.Errorf("cycle: diff -got,+want: %s", diff)
+			}
+			t.Logf("Build() = %v", err)
+		})
+	}
+}
+
+func TestCycleErrorEmptyPath(t *testing.T) {
+	if got, want := (&CycleError{}).Error(), "reference cycle"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestBuilderDanglingRef(t *testing.T) {
+	ids := make([]*cloud.ResourceID, 2)
+	for i := 0; i < len(ids); i++ {
+		ids[i] = &cloud.ResourceID{Resource: "fake", Key: meta.GlobalKey(fmt.Sprintf("r%d", i))}
+	}
+	ref := rnode.ResourceRef{From: ids[0], Path: api.Path{}.Pointer().Field("Ref"), To: ids[1]}
+
+	for _, tc := range []struct {
+		name        string
+		addExternal bool
+		wantErr     bool
+	}{
+		{name: "dangling reference", wantErr: true},
+		{name: "add as external", addExternal: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBuilder()
+			b0 := fake.NewBuilder(ids[0])
+			b0.FakeOutRefs = append(b0.FakeOutRefs, ref)
+			b0.SetOwnership(rnode.OwnershipManaged)
+			b.Add(b0)
+			if tc.addExternal {
+				b.AddMissingRefsAsExternal(func(id *cloud.ResourceID) (rnode.Builder, error) {
+					return fake.NewBuilder(id), nil
+				})
+			}
+
+			g, err := b.Build()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Build() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				var danglingErr *DanglingRefError
+				if !errors.As(err, &danglingErr) {
+					t.Fatalf("Build() = %v, want DanglingRefError", err)
+				}
+				if diff := cmp.Diff(danglingErr.Ref, ref); diff != "" {
+					t.Errorf("DanglingRefError.Ref: diff -got,+want: %s", diff)
+				}
+				return
+			}
+			n := g.Get(ids[1])
+			if n == nil {
+				t.Fatalf("g.Get(%s) = nil, want node", ids[1])
+			}
+			if n.Ownership() != rnode.OwnershipExternal {
+				t.Errorf("n.Ownership() = %s, want %s", n.Ownership(), rnode.OwnershipExternal)
+			}
+			if len(n.InRefs()) != 1 {
+				t.Errorf("n.InRefs() = %v, want 1 ref", n.InRefs())
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the Recorder for the metrics of planning and
+// executing Graphs. This package does not depend on a metrics library;
+// implement Recorder to export the metrics to e.g. Prometheus or OpenCensus.
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Recorder of the metrics. The methods may be called concurrently and must
+// not block.
+type Recorder interface {
+	// RecordPlan is called at the end of planning.
+	RecordPlan(p Plan)
+	// RecordAction is called when an Action has finished running, with or
+	// without an error.
+	RecordAction(a Action)
+	// RecordRetry is called each time an Action is retried.
+	RecordRetry(actionType, resource string)
+}
+
+// Plan metrics.
+type Plan struct {
+	Duration time.Duration
+	// Nodes is the number of nodes in the Graph by planned operation (e.g.
+	// "Create"). This is nil if planning failed.
+	Nodes map[string]int
+	Err   error
+}
+
+// Action metrics.
+type Action struct {
+	// Type of the Action, e.g. "Create".
+	Type string
+	// Resource is the kind of resource (e.g. "backendServices") the Action
+	// operates on. Empty if the Action does not operate on a resource.
+	Resource string
+	Duration time.Duration
+	// Err is non-nil if the Action failed.
+	Err error
+	// TimedOut is true if the Action failed because it ran out of time.
+	TimedOut bool
+}
+
+// NopRecorder does nothing.
+type NopRecorder struct{}
+
+var _ Recorder = NopRecorder{}
+
+func (NopRecorder) RecordPlan(Plan)            {}
+func (NopRecorder) RecordAction(Action)        {}
+func (NopRecorder) RecordRetry(string, string) {}
+
+// MemoryRecorder keeps all of the metrics in memory. This is intended for
+// testing and debugging.
+type MemoryRecorder struct {
+	lock    sync.Mutex
+	plans   []Plan
+	actions []Action
+	retries map[[2]string]int
+}
+
+var _ Recorder = (*MemoryRecorder)(nil)
+
+func (r *MemoryRecorder) RecordPlan(p Plan) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.plans = append(r.plans, p)
+}
+
+func (r *MemoryRecorder) RecordAction(a Action) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.actions = append(r.actions, a)
+}
+
+func (r *MemoryRecorder) RecordRetry(actionType, resource string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.retries == nil {
+		r.retries = map[[2]string]int{}
+	}
+	r.retries[[2]string{actionType, resource}]++
+}
+
+// Plans recorded, in order.
+func (r *MemoryRecorder) Plans() []Plan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]Plan{}, r.plans...)
+}
+
+// Actions recorded, in order.
+func (r *MemoryRecorder) Actions() []Action {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]Action{}, r.actions...)
+}
+
+// Retries recorded for the (actionType, resource).
+func (r *MemoryRecorder) Retries(actionType, resource string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.retries[[2]string{actionType, resource}]
+}
@@ -45,6 +45,10 @@ func CreateActions[GA any, Alpha any, Beta any](
 	if err != nil {
 		return nil, err
 	}
+	resource, err = WantResource(node, resource)
+	if err != nil {
+		return nil, fmt.Errorf("CreateActions %s: %w", node.ID(), err)
+	}
 	return []exec.Action{
 		newGenericCreateAction(events, ops, node.ID(), resource),
 	}, nil
@@ -90,10 +94,17 @@ func (a *genericCreateAction[GA, Alpha, Beta]) DryRun() exec.EventList {
 	return exec.EventList{exec.NewExistsEvent(a.id)}
 }
 
+// Inverse implements exec.ReversibleAction.
+func (a *genericCreateAction[GA, Alpha, Beta]) Inverse() (exec.Action, error) {
+	return DeleteResourceAction(a.ops, a.id), nil
+}
+
 func (a *genericCreateAction[GA, Alpha, Beta]) String() string {
 	return fmt.Sprintf("GenericCreateAction(%v)", a.id)
 }
 
+func (a *genericCreateAction[GA, Alpha, Beta]) ResourceID() *cloud.ResourceID { return a.id }
+
 func (a *genericCreateAction[GA, Alpha, Beta]) Metadata() *exec.ActionMetadata {
 	return &exec.ActionMetadata{
 		Name:    fmt.Sprintf("GenericCreateAction(%s)", a.id),
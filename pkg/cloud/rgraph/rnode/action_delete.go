@@ -38,6 +38,19 @@ func NewGenericDeleteAction[GA any, Alpha any, Beta any](
 	}
 }
 
+// DeleteResourceAction returns an Action that deletes the resource id. The
+// Action does not wait for any Events. This is used to undo the creation of
+// the resource (see exec.ReversibleAction).
+func DeleteResourceAction[GA any, Alpha any, Beta any](
+	ops GenericOps[GA, Alpha, Beta],
+	id *cloud.ResourceID,
+) exec.Action {
+	return &genericDeleteAction[GA, Alpha, Beta]{
+		ops: ops,
+		id:  id,
+	}
+}
+
 func DeletePreconditions(got, want Node) exec.EventList {
 	var ret exec.EventList
 	// Condition: no inRefs to the resource still exist.
@@ -94,6 +107,8 @@ func (a *genericDeleteAction[GA, Alpha, Beta]) String() string {
 	return fmt.Sprintf("GenericDeleteAction(%v)", a.id)
 }
 
+func (a *genericDeleteAction[GA, Alpha, Beta]) ResourceID() *cloud.ResourceID { return a.id }
+
 func (a *genericDeleteAction[GA, Alpha, Beta]) Metadata() *exec.ActionMetadata {
 	return &exec.ActionMetadata{
 		Name:    fmt.Sprintf("GenericDeleteAction(%s)", a.id),
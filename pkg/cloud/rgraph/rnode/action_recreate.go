@@ -17,16 +17,32 @@ limitations under the License.
 package rnode
 
 import (
+	"fmt"
+
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 )
 
+// RecreateActions deletes the got resource and creates the want resource in
+// its place. Both Actions are reversible when the got resource is known: a
+// rollback deletes the new resource and then inserts the got resource again.
 func RecreateActions[GA any, Alpha any, Beta any](
 	ops GenericOps[GA, Alpha, Beta],
 	got, want Node,
 	resource api.Resource[GA, Alpha, Beta],
 ) ([]exec.Action, error) {
-	deleteAction := NewGenericDeleteAction(DeletePreconditions(got, want), ops, got)
+	resource, err := WantResource(want, resource)
+	if err != nil {
+		return nil, fmt.Errorf("RecreateActions %s: %w", want.ID(), err)
+	}
+	var restore exec.Action
+	if gotRes, ok := got.Resource().(api.Resource[GA, Alpha, Beta]); ok {
+		// Keep the current resource to be able to restore it.
+		if prev, err := gotRes.AsVersion(resource.Version()); err == nil {
+			restore = newGenericCreateAction(nil, ops, got.ID(), prev)
+		}
+	}
+	deleteAction := NewRecreateDeleteAction(ops, got, want, restore)
 
 	createEvents, err := CreatePreconditions(want)
 	if err != nil {
@@ -38,3 +54,33 @@ func RecreateActions[GA any, Alpha any, Beta any](
 
 	return []exec.Action{deleteAction, createAction}, nil
 }
+
+// NewRecreateDeleteAction returns the delete half of a recreate. Unlike a
+// plain delete, the Action is reversible: its inverse is restore, which must
+// insert the deleted resource again. restore may be nil if the previous state
+// is unknown.
+func NewRecreateDeleteAction[GA any, Alpha any, Beta any](
+	ops GenericOps[GA, Alpha, Beta],
+	got, want Node,
+	restore exec.Action,
+) exec.Action {
+	return &recreateDeleteAction[GA, Alpha, Beta]{
+		genericDeleteAction: NewGenericDeleteAction(DeletePreconditions(got, want), ops, got),
+		restore:             restore,
+	}
+}
+
+type recreateDeleteAction[GA any, Alpha any, Beta any] struct {
+	*genericDeleteAction[GA, Alpha, Beta]
+	restore exec.Action
+}
+
+// Inverse implements exec.ReversibleAction. The create half of the recreate
+// is reverted first (rollback runs in reverse order), which deletes the new
+// resource.
+func (a *recreateDeleteAction[GA, Alpha, Beta]) Inverse() (exec.Action, error) {
+	if a.restore == nil {
+		return nil, fmt.Errorf("GenericDeleteAction(%s): previous state is unknown", a.id)
+	}
+	return a.restore, nil
+}
@@ -27,19 +27,65 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 )
 
+// UpdateOption configures UpdateActions().
+type UpdateOption func(c *updateConfig)
+
+type updateConfig struct {
+	patch bool
+}
+
+// UpdateWithPatch changes the resource with patch() instead of update(). Only
+// the fields in the planned Diff are sent (see FieldMask()), leaving fields
+// managed by other systems untouched. ops must implement PatchOps.
+func UpdateWithPatch() UpdateOption {
+	return func(c *updateConfig) { c.patch = true }
+}
+
 func UpdateActions[GA any, Alpha any, Beta any](
 	ops GenericOps[GA, Alpha, Beta],
 	got, want Node,
 	resource api.Resource[GA, Alpha, Beta],
+	opts ...UpdateOption,
 ) ([]exec.Action, error) {
+	var config updateConfig
+	for _, o := range opts {
+		o(&config)
+	}
 	preEvents, err := updatePreconditions(got, want)
 	if err != nil {
 		return nil, err
 	}
 	postEvents := postUpdateActionEvents(got, want)
-	return []exec.Action{
-		newGenericUpdateAction(preEvents, ops, want.ID(), resource, postEvents),
-	}, nil
+	resource, err = WantResource(want, resource)
+	if err != nil {
+		return nil, fmt.Errorf("UpdateActions %s: %w", want.ID(), err)
+	}
+	// The update must carry the fingerprint of the current resource.
+	var fingerprint string
+	if gotRes, ok := got.Resource().(api.Resource[GA, Alpha, Beta]); ok {
+		fingerprint = resourceFingerprint(gotRes)
+	}
+	act := newGenericUpdateAction(preEvents, ops, want.ID(), resource, postEvents)
+	act.fingerprint = fingerprint
+	if gotRes, ok := got.Resource().(api.Resource[GA, Alpha, Beta]); ok {
+		// Keep the current resource to be able to revert the update.
+		if prev, err := gotRes.AsVersion(resource.Version()); err == nil {
+			act.prev = prev
+		}
+	}
+	if config.patch {
+		patchOps, ok := ops.(PatchOps[GA, Alpha, Beta])
+		if !ok {
+			return nil, fmt.Errorf("UpdateActions %s: ops %T does not support patch", want.ID(), ops)
+		}
+		details := want.Plan().Details()
+		if details == nil {
+			return nil, fmt.Errorf("UpdateActions %s: node has not been planned", want.ID())
+		}
+		act.patchOps = patchOps
+		act.fieldMask = FieldMask(details.Diff)
+	}
+	return []exec.Action{act}, nil
 }
 
 func newGenericUpdateAction[GA any, Alpha any, Beta any](
@@ -64,6 +110,17 @@ type genericUpdateAction[GA any, Alpha any, Beta any] struct {
 	id         *cloud.ResourceID
 	resource   api.Resource[GA, Alpha, Beta]
 	postEvents exec.EventList
+	// fingerprint of the got resource.
+	fingerprint string
+	// patchOps is set if the resource is changed with patch(), sending only
+	// the fields in fieldMask.
+	patchOps  PatchOps[GA, Alpha, Beta]
+	fieldMask []string
+	// prev is the resource before the update, used by Inverse().
+	prev api.Resource[GA, Alpha, Beta]
+	// refreshFingerprint gets the current fingerprint before the update
+	// instead of using .fingerprint.
+	refreshFingerprint bool
 
 	start, end time.Time
 }
@@ -73,7 +130,21 @@ func (a *genericUpdateAction[GA, Alpha, Beta]) Run(
 	c cloud.Cloud,
 ) (exec.EventList, error) {
 	a.start = time.Now()
-	err := a.ops.UpdateFuncs(c).Do(ctx, "", a.id, a.resource)
+	f := a.ops.UpdateFuncs(c)
+	if a.patchOps != nil {
+		f = a.patchOps.PatchFuncs(c)
+		f.FieldMask = a.fieldMask
+	}
+	fingerprint := a.fingerprint
+	if a.refreshFingerprint && f.Options&UpdateFuncsNoFingerprint == 0 {
+		cur, err := a.ops.GetFuncs(c).fingerprint(ctx, a.resource.Version(), a.id)
+		if err != nil {
+			a.end = time.Now()
+			return nil, fmt.Errorf("GenericUpdateAction(%s): get fingerprint: %w", a.id, err)
+		}
+		fingerprint = cur
+	}
+	err := f.DoWithRetry(ctx, fingerprint, a.id, a.resource, a.ops.GetFuncs(c))
 	a.end = time.Now()
 
 	// Emit DropReference events for removed references.
@@ -85,11 +156,33 @@ func (a *genericUpdateAction[GA, Alpha, Beta]) DryRun() exec.EventList {
 	return a.postEvents
 }
 
+// Inverse implements exec.ReversibleAction. The inverse restores the
+// resource to the state before the update.
+func (a *genericUpdateAction[GA, Alpha, Beta]) Inverse() (exec.Action, error) {
+	if a.prev == nil {
+		return nil, fmt.Errorf("GenericUpdateAction(%s): previous state is unknown", a.id)
+	}
+	inv := newGenericUpdateAction(nil, a.ops, a.id, a.prev, nil)
+	inv.patchOps = a.patchOps
+	inv.fieldMask = a.fieldMask
+	inv.refreshFingerprint = true
+	return inv, nil
+}
+
 func (a *genericUpdateAction[GA, Alpha, Beta]) String() string {
 	return fmt.Sprintf("GenericUpdateAction(%v)", a.id)
 }
 
+func (a *genericUpdateAction[GA, Alpha, Beta]) ResourceID() *cloud.ResourceID { return a.id }
+
 func (a *genericUpdateAction[GA, Alpha, Beta]) Metadata() *exec.ActionMetadata {
+	if a.patchOps != nil {
+		return &exec.ActionMetadata{
+			Name:    fmt.Sprintf("GenericUpdateAction(%s)", a.id),
+			Type:    exec.ActionTypeUpdate,
+			Summary: fmt.Sprintf("Patch %s (fields: %v)", a.id, a.fieldMask),
+		}
+	}
 	return &exec.ActionMetadata{
 		Name:    fmt.Sprintf("GenericUpdateAction(%s)", a.id),
 		Type:    exec.ActionTypeUpdate,
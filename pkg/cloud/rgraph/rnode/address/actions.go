@@ -0,0 +1,74 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package address
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+// setLabelsAction updates the .Labels of the Address.
+type setLabelsAction struct {
+	exec.ActionBase
+
+	id               *cloud.ResourceID
+	labelFingerprint string
+	labels           map[string]string
+}
+
+func (act *setLabelsAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	var err error
+	switch act.id.Key.Type() {
+	case meta.Global:
+		err = cl.GlobalAddresses().SetLabels(ctx, act.id.Key, &compute.GlobalSetLabelsRequest{
+			LabelFingerprint: act.labelFingerprint,
+			Labels:           act.labels,
+		}, cloud.ForceProjectID(act.id.ProjectID))
+	case meta.Regional:
+		err = cl.Addresses().SetLabels(ctx, act.id.Key, &compute.RegionSetLabelsRequest{
+			LabelFingerprint: act.labelFingerprint,
+			Labels:           act.labels,
+		}, cloud.ForceProjectID(act.id.ProjectID))
+	default:
+		err = fmt.Errorf("invalid key type")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("AddressSetLabelsAction Run(%s): %w", act.id, err)
+	}
+	return nil, nil
+}
+
+func (act *setLabelsAction) DryRun() exec.EventList { return nil }
+
+func (act *setLabelsAction) String() string {
+	return fmt.Sprintf("AddressSetLabelsAction(%s)", act.id)
+}
+
+func (act *setLabelsAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *setLabelsAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("AddressSetLabelsAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: fmt.Sprintf("Set labels on %s to %v", act.id, act.labels),
+	}
+}
@@ -17,9 +17,14 @@ limitations under the License.
 package address
 
 import (
+	"context"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -107,3 +112,167 @@ func TestAddressFieldTraits(t *testing.T) {
 		})
 	}
 }
+
+func TestDiff(t *testing.T) {
+	makeNode := func(f func(x *compute.Address)) rnode.Node {
+		t.Helper()
+		m := NewMutableAddress("proj-1", meta.RegionalKey("addr", "us-central1"))
+		m.Access(func(x *compute.Address) {
+			x.Name = "addr"
+			x.Address = "1.2.3.4"
+			x.NetworkTier = "PREMIUM"
+			x.Labels = map[string]string{"a": "b"}
+			if f != nil {
+				f(x)
+			}
+		})
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		f      func(x *compute.Address)
+		wantOp rnode.Operation
+	}{
+		{name: "no diff", wantOp: rnode.OpNothing},
+		{name: "labels", f: func(x *compute.Address) { x.Labels = map[string]string{"a": "c"} }, wantOp: rnode.OpUpdate},
+		{name: "clear labels", f: func(x *compute.Address) { x.Labels = nil }, wantOp: rnode.OpUpdate},
+		{name: "address", f: func(x *compute.Address) { x.Address = "1.2.3.5" }, wantOp: rnode.OpRecreate},
+		{name: "tier", f: func(x *compute.Address) { x.NetworkTier = "STANDARD" }, wantOp: rnode.OpRecreate},
+		{name: "purpose", f: func(x *compute.Address) { x.Purpose = "GCE_ENDPOINT" }, wantOp: rnode.OpRecreate},
+		{name: "description", f: func(x *compute.Address) { x.Description = "new" }, wantOp: rnode.OpRecreate},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode(nil)
+			want := makeNode(tc.f)
+			pd, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = _, %v", err)
+			}
+			if pd.Operation != tc.wantOp {
+				t.Fatalf("Diff().Operation = %s, want %s (why: %s)", pd.Operation, tc.wantOp, pd.Why)
+			}
+			if pd.Operation != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*pd)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = _, %v", err)
+			}
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj-1"})
+			for _, a := range actions {
+				if _, err := a.Run(context.Background(), mock); err != nil {
+					t.Fatalf("%v.Run() = %v", a, err)
+				}
+			}
+			key := want.ID().Key
+			if calls := mock.Calls().Matching(cloud.MockCall{Service: "Addresses", Operation: "SetLabels", Key: key}); len(calls) != 1 {
+				t.Errorf("calls to Addresses.SetLabels = %v, want 1", calls)
+			}
+		})
+	}
+}
+
+func TestDiffPolicyOverride(t *testing.T) {
+	makeNode := func(addr string, p *rnode.DiffPolicy) rnode.Node {
+		t.Helper()
+		m := NewMutableAddress("proj-1", meta.RegionalKey("addr", "us-central1"))
+		m.Access(func(x *compute.Address) {
+			x.Name = "addr"
+			x.Address = addr
+			x.Labels = map[string]string{"a": addr}
+		})
+		return nodetest.Build(t, m, NewBuilderWithResource, func(b rnode.Builder) error {
+			b.SetDiffPolicy(p)
+			return nil
+		})
+	}
+	addressPath := api.Path{}.Pointer().Field("Address")
+
+	for _, tc := range []struct {
+		name    string
+		policy  *rnode.DiffPolicy
+		wantOp  rnode.Operation
+		wantErr bool
+	}{
+		{name: "default", wantOp: rnode.OpRecreate},
+		{
+			name:   "ignore address",
+			policy: rnode.NewDiffPolicy("").Set(addressPath, rnode.FieldIgnore),
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "ignore address and labels",
+			policy: rnode.NewDiffPolicy("").SetFields(rnode.FieldIgnore, "Address", "Labels"),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:    "forbid address",
+			policy:  rnode.NewDiffPolicy("").Set(addressPath, rnode.FieldForbidden),
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode("1.2.3.4", nil)
+			want := makeNode("1.2.3.5", tc.policy)
+			pd, err := want.Diff(got)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Diff() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if pd.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s (why: %s)", pd.Operation, tc.wantOp, pd.Why)
+			}
+		})
+	}
+}
+
+func TestVersion(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ver  meta.Version
+		want meta.Version
+	}{
+		{name: "default is the resource version", want: meta.VersionGA},
+		{name: "beta", ver: meta.VersionBeta, want: meta.VersionBeta},
+		{name: "alpha", ver: meta.VersionAlpha, want: meta.VersionAlpha},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key := meta.RegionalKey("addr", "us-central1")
+			m := NewMutableAddress("proj-1", key)
+			m.Access(func(x *compute.Address) {
+				x.Name = "addr"
+				x.Address = "1.2.3.4"
+			})
+			n := nodetest.Build(t, m, NewBuilderWithResource, func(b rnode.Builder) error {
+				if tc.ver != "" {
+					b.SetVersion(tc.ver)
+				}
+				return nil
+			})
+			if n.Version() != tc.want {
+				t.Fatalf("Version() = %s, want %s", n.Version(), tc.want)
+			}
+
+			n.Plan().Set(rnode.PlanDetails{Operation: rnode.OpCreate})
+			actions, err := n.Actions(nil)
+			if err != nil {
+				t.Fatalf("Actions() = _, %v", err)
+			}
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj-1"})
+			for _, a := range actions {
+				if _, err := a.Run(context.Background(), mock); err != nil {
+					t.Fatalf("%v.Run() = %v", a, err)
+				}
+			}
+			calls := mock.Calls().Matching(cloud.MockCall{Operation: "Insert", Key: key})
+			if len(calls) != 1 || calls[0].Version != tc.want {
+				t.Errorf("calls to Insert = %v, want 1 call with version %s", calls, tc.want)
+			}
+		})
+	}
+}
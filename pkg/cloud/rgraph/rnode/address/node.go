@@ -19,6 +19,7 @@ package address
 import (
 	"fmt"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -31,10 +32,38 @@ type addressNode struct {
 	resource Address
 }
 
-var _ rnode.Node = (*addressNode)(nil)
+var _ rnode.UsedByNode = (*addressNode)(nil)
 
 func (n *addressNode) Resource() rnode.UntypedResource { return n.resource }
 
+// UsedBy returns the resources in .Users (e.g. ForwardingRules).
+func (n *addressNode) UsedBy() ([]*cloud.ResourceID, error) {
+	if n.resource == nil {
+		return nil, nil
+	}
+	// .Users is available in all versions.
+	obj, err := n.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("AddressNode: UsedBy: %w", err)
+	}
+	var ret []*cloud.ResourceID
+	for _, u := range obj.Users {
+		id, err := cloud.ParseResourceURL(u)
+		if err != nil {
+			return nil, fmt.Errorf("AddressNode: UsedBy: %w", err)
+		}
+		ret = append(ret, id)
+	}
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for Address. .Address, .NetworkTier
+// and .Purpose cannot be changed once the IP is reserved. .Labels is changed
+// with setLabels(). The Addresses API has no update or patch method, so the
+// other fields (e.g. .Description, .Subnetwork) also require a recreate.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate).
+	SetFields(rnode.FieldUpdate, "Labels")
+
 func (n *addressNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
 	gotRes, ok := gotNode.Resource().(Address)
 	if !ok {
@@ -46,19 +75,7 @@ func (n *addressNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
 		return nil, fmt.Errorf("AddressNode: Diff %w", err)
 	}
 
-	if diff.HasDiff() {
-		// TODO: setLabels() when the field goes GA.
-		return &rnode.PlanDetails{
-			Operation: rnode.OpRecreate,
-			Why:       "Address needs to be recreated (no update method exists)",
-			Diff:      diff,
-		}, nil
-	}
-
-	return &rnode.PlanDetails{
-		Operation: rnode.OpNothing,
-		Why:       "No diff between got and want",
-	}, nil
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("Address", diff)
 }
 
 func (n *addressNode) Actions(got rnode.Node) ([]exec.Action, error) {
@@ -66,8 +83,6 @@ func (n *addressNode) Actions(got rnode.Node) ([]exec.Action, error) {
 
 	switch op {
 	case rnode.OpCreate:
-		// TODO: .Labels can only be updated via the setLabels method. This is
-		// currently in Beta and we don't support it.
 		return rnode.CreateActions[compute.Address, alpha.Address, beta.Address](&ops{}, n, n.resource)
 
 	case rnode.OpDelete:
@@ -80,14 +95,47 @@ func (n *addressNode) Actions(got rnode.Node) ([]exec.Action, error) {
 		return rnode.RecreateActions[compute.Address, alpha.Address, beta.Address](&ops{}, got, n, n.resource)
 
 	case rnode.OpUpdate:
-		return nil, fmt.Errorf("%s is not supported for Address", op)
+		return n.updateActions(got)
 	}
 
 	return nil, fmt.Errorf("AddressNode: invalid plan op %s", op)
 }
 
 func (n *addressNode) Builder() rnode.Builder {
-	b := &builder{}
+	b := &builder{resource: n.resource}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
 	return b
 }
+
+func (n *addressNode) updateActions(got rnode.Node) ([]exec.Action, error) {
+	gotRes, ok := got.Resource().(Address)
+	if !ok {
+		return nil, fmt.Errorf("AddressNode: updateActions: invalid type %T", got.Resource())
+	}
+	want, err := rnode.WantResource(n, n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("AddressNode: updateActions: %w", err)
+	}
+	gotGA, err := gotRes.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("AddressNode: updateActions: %w", err)
+	}
+	wantGA, err := want.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("AddressNode: updateActions: %w", err)
+	}
+
+	labels := wantGA.Labels
+	if labels == nil {
+		// Clear the labels.
+		labels = map[string]string{}
+	}
+	return []exec.Action{
+		exec.NewExistsAction(n.ID()),
+		&setLabelsAction{
+			id:               n.ID(),
+			labelFingerprint: gotGA.LabelFingerprint,
+			labels:           labels,
+		},
+	}, nil
+}
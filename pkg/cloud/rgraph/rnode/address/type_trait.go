@@ -33,6 +33,7 @@ func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 	dt := api.NewFieldTraits()
 	// Built-ins
 	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("LabelFingerprint"))
 	// [Output Only]
 	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
@@ -18,40 +18,127 @@ package all
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/fake"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/firewall"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/gateway"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/grpcroute"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/httproute"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instancegroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/mesh"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkfirewallpolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/securitypolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/serviceattachment"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/sslcertificate"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/sslpolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpsproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targetsslproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targettcpproxy"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/tcproute"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
 )
 
+var (
+	registeredLock sync.RWMutex
+	// registered are the Builder constructors added with RegisterBuilder,
+	// by Resource.
+	registered = map[string]func(*cloud.ResourceID) rnode.Builder{}
+)
+
+// RegisterBuilder adds the Builder constructor for a resource that is not
+// handled by this package, e.g. one added with meta.RegisterResource().
+func RegisterBuilder(resource string, newBuilder func(*cloud.ResourceID) rnode.Builder) error {
+	if b := builtinBuilder(&cloud.ResourceID{Resource: resource}); b != nil {
+		return fmt.Errorf("RegisterBuilder: resource %q is already handled", resource)
+	}
+
+	registeredLock.Lock()
+	defer registeredLock.Unlock()
+
+	if _, ok := registered[resource]; ok {
+		return fmt.Errorf("RegisterBuilder: resource %q is already registered", resource)
+	}
+	registered[resource] = newBuilder
+	return nil
+}
+
 func NewBuilderByID(id *cloud.ResourceID) (rnode.Builder, error) {
+	if b := builtinBuilder(id); b != nil {
+		return b, nil
+	}
+
+	registeredLock.RLock()
+	defer registeredLock.RUnlock()
+
+	if newBuilder, ok := registered[id.Resource]; ok {
+		return newBuilder(id), nil
+	}
+	return nil, fmt.Errorf("NewBuilderByID: invalid Resource %q", id.Resource)
+}
+
+func builtinBuilder(id *cloud.ResourceID) rnode.Builder {
 	switch id.Resource {
 	case "addresses":
-		return address.NewBuilder(id), nil
+		return address.NewBuilder(id)
 	case "backendServices":
-		return backendservice.NewBuilder(id), nil
+		return backendservice.NewBuilder(id)
 	case "fakes":
-		return fake.NewBuilder(id), nil
+		return fake.NewBuilder(id)
+	case "firewalls":
+		return firewall.NewBuilder(id)
 	case "forwardingRules":
-		return forwardingrule.NewBuilder(id), nil
+		return forwardingrule.NewBuilder(id)
+	case "gateways":
+		return gateway.NewBuilder(id)
+	case "grpcRoutes":
+		return grpcroute.NewBuilder(id)
 	case "healthChecks":
-		return healthcheck.NewBuilder(id), nil
+		return healthcheck.NewBuilder(id)
+	case "httpRoutes":
+		return httproute.NewBuilder(id)
+	case "instanceGroups":
+		return instancegroup.NewBuilder(id)
+	case "meshes":
+		return mesh.NewBuilder(id)
+	case "networks":
+		return network.NewBuilder(id)
 	case "networkEndpointGroups":
-		return networkendpointgroup.NewBuilder(id), nil
+		return networkendpointgroup.NewBuilder(id)
+	case "networkFirewallPolicies":
+		return networkfirewallpolicy.NewBuilder(id)
+	case "securityPolicies":
+		return securitypolicy.NewBuilder(id)
+	case "serviceAttachments":
+		return serviceattachment.NewBuilder(id)
+	case "sslCertificates":
+		return sslcertificate.NewBuilder(id)
+	case "sslPolicies":
+		return sslpolicy.NewBuilder(id)
+	case "subnetworks":
+		return subnetwork.NewBuilder(id)
 	case "targetHttpProxies":
-		return targethttpproxy.NewBuilder(id), nil
+		return targethttpproxy.NewBuilder(id)
+	case "targetHttpsProxies":
+		return targethttpsproxy.NewBuilder(id)
+	case "targetSslProxies":
+		return targetsslproxy.NewBuilder(id)
+	case "targetTcpProxies":
+		return targettcpproxy.NewBuilder(id)
 	case "urlMaps":
-		return urlmap.NewBuilder(id), nil
+		return urlmap.NewBuilder(id)
 	case "tcpRoute":
-		return tcproute.NewBuilder(id), nil
+		return tcproute.NewBuilder(id)
 	}
-	return nil, fmt.Errorf("NewBuilderByID: invalid Resource %q", id.Resource)
+	return nil
 }
@@ -24,10 +24,24 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/firewall"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/gateway"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/grpcroute"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/httproute"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instancegroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/mesh"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkfirewallpolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/securitypolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/serviceattachment"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpsproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targetsslproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targettcpproxy"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/tcproute"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
 	"google.golang.org/api/compute/v1"
@@ -83,14 +97,40 @@ func (b *ResourceBuilder) DefaultZone() *ResourceBuilder   { return b.Z("us-cent
 
 func (b *ResourceBuilder) Address() *AddressBuilder               { return &AddressBuilder{*b} }
 func (b *ResourceBuilder) BackendService() *BackendServiceBuilder { return &BackendServiceBuilder{*b} }
+func (b *ResourceBuilder) Firewall() *FirewallBuilder             { return &FirewallBuilder{*b} }
 func (b *ResourceBuilder) ForwardingRule() *ForwardingRuleBuilder { return &ForwardingRuleBuilder{*b} }
+func (b *ResourceBuilder) Gateway() *GatewayBuilder               { return &GatewayBuilder{*b} }
+func (b *ResourceBuilder) GrpcRoute() *GrpcRouteBuilder           { return &GrpcRouteBuilder{*b} }
 func (b *ResourceBuilder) HealthCheck() *HealthCheckBuilder       { return &HealthCheckBuilder{*b} }
+func (b *ResourceBuilder) HttpRoute() *HttpRouteBuilder           { return &HttpRouteBuilder{*b} }
+func (b *ResourceBuilder) InstanceGroup() *InstanceGroupBuilder   { return &InstanceGroupBuilder{*b} }
+func (b *ResourceBuilder) Mesh() *MeshBuilder                     { return &MeshBuilder{*b} }
+func (b *ResourceBuilder) Network() *NetworkBuilder               { return &NetworkBuilder{*b} }
 func (b *ResourceBuilder) NetworkEndpointGroup() *NetworkEndpointGroupBuilder {
 	return &NetworkEndpointGroupBuilder{*b}
 }
+func (b *ResourceBuilder) NetworkFirewallPolicy() *NetworkFirewallPolicyBuilder {
+	return &NetworkFirewallPolicyBuilder{*b}
+}
+func (b *ResourceBuilder) SecurityPolicy() *SecurityPolicyBuilder {
+	return &SecurityPolicyBuilder{*b}
+}
+func (b *ResourceBuilder) ServiceAttachment() *ServiceAttachmentBuilder {
+	return &ServiceAttachmentBuilder{*b}
+}
+func (b *ResourceBuilder) Subnetwork() *SubnetworkBuilder { return &SubnetworkBuilder{*b} }
 func (b *ResourceBuilder) TargetHttpProxy() *TargetHttpProxyBuilder {
 	return &TargetHttpProxyBuilder{*b}
 }
+func (b *ResourceBuilder) TargetHttpsProxy() *TargetHttpsProxyBuilder {
+	return &TargetHttpsProxyBuilder{*b}
+}
+func (b *ResourceBuilder) TargetSslProxy() *TargetSslProxyBuilder {
+	return &TargetSslProxyBuilder{*b}
+}
+func (b *ResourceBuilder) TargetTcpProxy() *TargetTcpProxyBuilder {
+	return &TargetTcpProxyBuilder{*b}
+}
 func (b *ResourceBuilder) UrlMap() *UrlMapBuilder { return &UrlMapBuilder{*b} }
 
 type AddressBuilder struct{ ResourceBuilder }
@@ -221,6 +261,28 @@ func (b *TargetHttpProxyBuilder) Build(f func(*compute.TargetHttpProxy)) rnode.B
 	return nb
 }
 
+type TargetHttpsProxyBuilder struct{ ResourceBuilder }
+
+func (b *TargetHttpsProxyBuilder) ID() *cloud.ResourceID {
+	return targethttpsproxy.ID(b.Project, b.Key())
+}
+func (b *TargetHttpsProxyBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *TargetHttpsProxyBuilder) Resource() targethttpsproxy.MutableTargetHttpsProxy {
+	return targethttpsproxy.NewMutableTargetHttpsProxy(b.Project, b.Key())
+}
+
+func (b *TargetHttpsProxyBuilder) Build(f func(*compute.TargetHttpsProxy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := targethttpsproxy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
 type UrlMapBuilder struct{ ResourceBuilder }
 
 func (b *UrlMapBuilder) ID() *cloud.ResourceID { return urlmap.ID(b.Project, b.Key()) }
@@ -260,3 +322,282 @@ func (b *TcpRouteBuilder) Build(f func(*networkservices.TcpRoute)) rnode.Builder
 	nb.SetState(rnode.NodeExists)
 	return nb
 }
+
+type HttpRouteBuilder struct{ ResourceBuilder }
+
+func (b *HttpRouteBuilder) ID() *cloud.ResourceID { return httproute.ID(b.Project, b.Key()) }
+func (b *HttpRouteBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *HttpRouteBuilder) Resource() httproute.MutableHttpRoute {
+	return httproute.NewMutableHttpRoute(b.Project, b.Key())
+}
+
+func (b *HttpRouteBuilder) Build(f func(*networkservices.HttpRoute)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := httproute.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type GrpcRouteBuilder struct{ ResourceBuilder }
+
+func (b *GrpcRouteBuilder) ID() *cloud.ResourceID { return grpcroute.ID(b.Project, b.Key()) }
+func (b *GrpcRouteBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *GrpcRouteBuilder) Resource() grpcroute.MutableGrpcRoute {
+	return grpcroute.NewMutableGrpcRoute(b.Project, b.Key())
+}
+
+func (b *GrpcRouteBuilder) Build(f func(*networkservices.GrpcRoute)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := grpcroute.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type MeshBuilder struct{ ResourceBuilder }
+
+func (b *MeshBuilder) ID() *cloud.ResourceID { return mesh.ID(b.Project, b.Key()) }
+func (b *MeshBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *MeshBuilder) Resource() mesh.MutableMesh {
+	return mesh.NewMutableMesh(b.Project, b.Key())
+}
+
+func (b *MeshBuilder) Build(f func(*networkservices.Mesh)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := mesh.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type GatewayBuilder struct{ ResourceBuilder }
+
+func (b *GatewayBuilder) ID() *cloud.ResourceID { return gateway.ID(b.Project, b.Key()) }
+func (b *GatewayBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *GatewayBuilder) Resource() gateway.MutableGateway {
+	return gateway.NewMutableGateway(b.Project, b.Key())
+}
+
+func (b *GatewayBuilder) Build(f func(*networkservices.Gateway)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := gateway.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type FirewallBuilder struct{ ResourceBuilder }
+
+func (b *FirewallBuilder) ID() *cloud.ResourceID { return firewall.ID(b.Project, b.Key()) }
+func (b *FirewallBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *FirewallBuilder) Resource() firewall.MutableFirewall {
+	return firewall.NewMutableFirewall(b.Project, b.Key())
+}
+
+func (b *FirewallBuilder) Build(f func(*compute.Firewall)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := firewall.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type InstanceGroupBuilder struct{ ResourceBuilder }
+
+func (b *InstanceGroupBuilder) ID() *cloud.ResourceID { return instancegroup.ID(b.Project, b.Key()) }
+func (b *InstanceGroupBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *InstanceGroupBuilder) Resource() instancegroup.MutableInstanceGroup {
+	return instancegroup.NewMutableInstanceGroup(b.Project, b.Key())
+}
+
+func (b *InstanceGroupBuilder) Build(f func(*compute.InstanceGroup)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := instancegroup.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type NetworkFirewallPolicyBuilder struct{ ResourceBuilder }
+
+func (b *NetworkFirewallPolicyBuilder) ID() *cloud.ResourceID {
+	return networkfirewallpolicy.ID(b.Project, b.Key())
+}
+func (b *NetworkFirewallPolicyBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *NetworkFirewallPolicyBuilder) Resource() networkfirewallpolicy.MutableNetworkFirewallPolicy {
+	return networkfirewallpolicy.NewMutableNetworkFirewallPolicy(b.Project, b.Key())
+}
+
+func (b *NetworkFirewallPolicyBuilder) Build(f func(*compute.FirewallPolicy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := networkfirewallpolicy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type SecurityPolicyBuilder struct{ ResourceBuilder }
+
+func (b *SecurityPolicyBuilder) ID() *cloud.ResourceID { return securitypolicy.ID(b.Project, b.Key()) }
+func (b *SecurityPolicyBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *SecurityPolicyBuilder) Resource() securitypolicy.MutableSecurityPolicy {
+	return securitypolicy.NewMutableSecurityPolicy(b.Project, b.Key())
+}
+
+func (b *SecurityPolicyBuilder) Build(f func(*compute.SecurityPolicy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := securitypolicy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type ServiceAttachmentBuilder struct{ ResourceBuilder }
+
+func (b *ServiceAttachmentBuilder) ID() *cloud.ResourceID {
+	return serviceattachment.ID(b.Project, b.Key())
+}
+func (b *ServiceAttachmentBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *ServiceAttachmentBuilder) Resource() serviceattachment.MutableServiceAttachment {
+	return serviceattachment.NewMutableServiceAttachment(b.Project, b.Key())
+}
+
+func (b *ServiceAttachmentBuilder) Build(f func(*compute.ServiceAttachment)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := serviceattachment.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type SubnetworkBuilder struct{ ResourceBuilder }
+
+func (b *SubnetworkBuilder) ID() *cloud.ResourceID { return subnetwork.ID(b.Project, b.Key()) }
+func (b *SubnetworkBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *SubnetworkBuilder) Resource() subnetwork.MutableSubnetwork {
+	return subnetwork.NewMutableSubnetwork(b.Project, b.Key())
+}
+
+func (b *SubnetworkBuilder) Build(f func(*compute.Subnetwork)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := subnetwork.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type NetworkBuilder struct{ ResourceBuilder }
+
+func (b *NetworkBuilder) ID() *cloud.ResourceID { return network.ID(b.Project, b.Key()) }
+func (b *NetworkBuilder) SelfLink() string      { return b.ID().SelfLink(meta.VersionGA) }
+func (b *NetworkBuilder) Resource() network.MutableNetwork {
+	return network.NewMutableNetwork(b.Project, b.Key())
+}
+
+// Build a managed Network. This should only be used in test environments,
+// Networks are usually referenced with External().
+func (b *NetworkBuilder) Build(f func(*compute.Network)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := network.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+// External returns a builder for a Network that is not managed by the graph.
+// The resource is filled in by SyncFromCloud().
+func (b *NetworkBuilder) External() rnode.Builder {
+	nb := network.NewBuilder(b.ID())
+	nb.SetOwnership(rnode.OwnershipExternal)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type TargetSslProxyBuilder struct{ ResourceBuilder }
+
+func (b *TargetSslProxyBuilder) ID() *cloud.ResourceID {
+	return targetsslproxy.ID(b.Project, b.Key())
+}
+func (b *TargetSslProxyBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *TargetSslProxyBuilder) Resource() targetsslproxy.MutableTargetSslProxy {
+	return targetsslproxy.NewMutableTargetSslProxy(b.Project, b.Key())
+}
+
+func (b *TargetSslProxyBuilder) Build(f func(*compute.TargetSslProxy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := targetsslproxy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
+
+type TargetTcpProxyBuilder struct{ ResourceBuilder }
+
+func (b *TargetTcpProxyBuilder) ID() *cloud.ResourceID {
+	return targettcpproxy.ID(b.Project, b.Key())
+}
+func (b *TargetTcpProxyBuilder) SelfLink() string { return b.ID().SelfLink(meta.VersionGA) }
+func (b *TargetTcpProxyBuilder) Resource() targettcpproxy.MutableTargetTcpProxy {
+	return targettcpproxy.NewMutableTargetTcpProxy(b.Project, b.Key())
+}
+
+func (b *TargetTcpProxyBuilder) Build(f func(*compute.TargetTcpProxy)) rnode.Builder {
+	m := b.Resource()
+	if f != nil {
+		m.Access(f)
+	}
+	r, _ := m.Freeze()
+	nb := targettcpproxy.NewBuilderWithResource(r)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	return nb
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package all
+
+import "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+
+//go:generate go run ../gen/versionfields -out version_fields_gen.go
+
+// VersionFields returns the fields of the resource type (e.g.
+// "backendServices") that are not in all of the API versions. Returns nil if
+// the resource is not known.
+func VersionFields(resource string) *api.VersionFields {
+	return versionFields[resource]
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen/versionfields".
+// Do not edit directly.
+
+package all
+
+import "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+
+// versionFields by resource.
+var versionFields = map[string]*api.VersionFields{
+	"addresses": {
+		Alpha: []api.Path{
+			{"*", ".SelfLinkWithId"},
+		},
+	},
+	"backendServices": {
+		Alpha: []api.Path{
+			{"*", ".Iap", "*", ".Oauth2ClientInfo"},
+			{"*", ".LogConfig", "*", ".Optional"},
+			{"*", ".SecuritySettings", "*", ".AuthenticationPolicy"},
+			{"*", ".SecuritySettings", "*", ".AuthorizationConfig"},
+			{"*", ".SecuritySettings", "*", ".ClientTlsSettings"},
+			{"*", ".SelfLinkWithId"},
+			{"*", ".VpcNetworkScope"},
+		},
+		Beta: []api.Path{
+			{"*", ".Backends", "!#", "*", ".Preference"},
+			{"*", ".CircuitBreakers", "*", ".ConnectTimeout"},
+			{"*", ".IpAddressSelectionPolicy"},
+			{"*", ".SecuritySettings", "*", ".Authentication"},
+			{"*", ".ServiceLbPolicy"},
+			{"*", ".Subsetting", "*", ".SubsetSize"},
+		},
+	},
+	"firewalls": {
+		Alpha: []api.Path{
+			{"*", ".SelfLinkWithId"},
+		},
+		Beta: []api.Path{
+			{"*", ".EnableLogging"},
+		},
+	},
+	"forwardingRules": {
+		Alpha: []api.Path{
+			{"*", ".IpCollection"},
+			{"*", ".SelfLinkWithId"},
+		},
+		Beta: []api.Path{
+			{"*", ".AllowPscPacketInjection"},
+		},
+	},
+	"gateways":   {},
+	"grpcRoutes": {},
+	"healthChecks": {
+		Alpha: []api.Path{
+			{"*", ".Http2HealthCheck", "*", ".WeightReportMode"},
+			{"*", ".HttpHealthCheck", "*", ".WeightReportMode"},
+			{"*", ".HttpsHealthCheck", "*", ".WeightReportMode"},
+			{"*", ".SelfLinkWithId"},
+			{"*", ".SourceRegions"},
+			{"*", ".UdpHealthCheck"},
+		},
+	},
+	"httpRoutes": {},
+	"instanceGroups": {
+		Alpha: []api.Path{
+			{"*", ".SelfLinkWithId"},
+		},
+	},
+	"meshes": {},
+	"networks": {
+		Alpha: []api.Path{
+			{"*", ".Peerings", "!#", "*", ".AdvertisePeerSubnetsViaRouters"},
+			{"*", ".Region"},
+			{"*", ".RoutingConfig", "*", ".BgpAlwaysCompareMed"},
+			{"*", ".RoutingConfig", "*", ".BgpBestPathSelectionMode"},
+			{"*", ".RoutingConfig", "*", ".BgpInterRegionCost"},
+		},
+	},
+	"networkEndpointGroups": {
+		Alpha: []api.Path{
+			{"*", ".ClientPortMappingMode"},
+			{"*", ".SelfLinkWithId"},
+			{"*", ".Type"},
+		},
+		Beta: []api.Path{
+			{"*", ".LoadBalancer"},
+			{"*", ".ServerlessDeployment"},
+		},
+	},
+	"networkFirewallPolicies": {
+		Alpha: []api.Path{
+			{"*", ".Associations", "!#", "*", ".Priority"},
+			{"*", ".VpcNetworkScope"},
+		},
+		Beta: []api.Path{
+			{"*", ".Rules", "!#", "*", ".SecurityProfileGroup"},
+			{"*", ".Rules", "!#", "*", ".TlsInspect"},
+		},
+	},
+	"securityPolicies": {
+		Alpha: []api.Path{
+			{"*", ".CloudArmorConfig"},
+			{"*", ".Rules", "!#", "*", ".Match", "*", ".Config", "*", ".DestPorts"},
+			{"*", ".Rules", "!#", "*", ".RateLimitOptions", "*", ".ExceedActionRpcStatus"},
+			{"*", ".Rules", "!#", "*", ".RedirectTarget"},
+			{"*", ".Rules", "!#", "*", ".RuleManagedProtectionTier"},
+		},
+		Beta: []api.Path{
+			{"*", ".AdaptiveProtectionConfig", "*", ".AutoDeployConfig"},
+			{"*", ".Associations"},
+			{"*", ".DisplayName"},
+			{"*", ".Parent"},
+			{"*", ".RuleTupleCount"},
+			{"*", ".Rules", "!#", "*", ".Direction"},
+			{"*", ".Rules", "!#", "*", ".EnableLogging"},
+			{"*", ".Rules", "!#", "*", ".Match", "*", ".Config", "*", ".DestIpRanges"},
+			{"*", ".Rules", "!#", "*", ".Match", "*", ".Config", "*", ".Layer4Configs"},
+			{"*", ".Rules", "!#", "*", ".Match", "*", ".ExprOptions"},
+			{"*", ".Rules", "!#", "*", ".RuleNumber"},
+			{"*", ".Rules", "!#", "*", ".RuleTupleCount"},
+			{"*", ".Rules", "!#", "*", ".TargetResources"},
+			{"*", ".Rules", "!#", "*", ".TargetServiceAccounts"},
+			{"*", ".SelfLinkWithId"},
+		},
+	},
+	"serviceAttachments": {
+		Alpha: []api.Path{
+			{"*", ".PropagatedConnectionLimit"},
+		},
+		Beta: []api.Path{
+			{"*", ".TunnelingConfig"},
+		},
+	},
+	"subnetworks": {
+		Alpha: []api.Path{
+			{"*", ".AggregationInterval"},
+			{"*", ".EnableL2"},
+			{"*", ".EnablePrivateV6Access"},
+			{"*", ".FlowSampling"},
+			{"*", ".Metadata"},
+			{"*", ".SelfLinkWithId"},
+			{"*", ".Vlans"},
+		},
+		Beta: []api.Path{
+			{"*", ".AllowSubnetCidrRoutesOverlap"},
+			{"*", ".ReservedInternalRange"},
+			{"*", ".SecondaryIpRanges", "!#", "*", ".ReservedInternalRange"},
+		},
+	},
+	"targetHttpProxies": {
+		Alpha: []api.Path{
+			{"*", ".SelfLinkWithId"},
+		},
+		Beta: []api.Path{
+			{"*", ".HttpFilters"},
+		},
+	},
+	"targetHttpsProxies": {
+		Alpha: []api.Path{
+			{"*", ".SelfLinkWithId"},
+		},
+		Beta: []api.Path{
+			{"*", ".Authentication"},
+			{"*", ".Authorization"},
+			{"*", ".HttpFilters"},
+		},
+	},
+	"targetSslProxies": {},
+	"targetTcpProxies": {},
+	"tcpRoute":         {},
+	"urlMaps": {
+		Alpha: []api.Path{
+			{"*", ".Tests", "!#", "*", ".BackendServiceWeight"},
+			{"*", ".Tests", "!#", "*", ".ExpectedUrlRedirect"},
+		},
+		Beta: []api.Path{
+			{"*", ".DefaultCustomErrorResponsePolicy"},
+			{"*", ".PathMatchers", "!#", "*", ".DefaultCustomErrorResponsePolicy"},
+			{"*", ".PathMatchers", "!#", "*", ".PathRules", "!#", "*", ".CustomErrorResponsePolicy"},
+			{"*", ".PathMatchers", "!#", "*", ".RouteRules", "!#", "*", ".CustomErrorResponsePolicy"},
+			{"*", ".PathMatchers", "!#", "*", ".RouteRules", "!#", "*", ".HttpFilterConfigs"},
+			{"*", ".PathMatchers", "!#", "*", ".RouteRules", "!#", "*", ".HttpFilterMetadata"},
+		},
+	},
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/klog/v2"
+)
+
+// setSecurityPolicyAction attaches the security policies to the
+// BackendService. The policies cannot be set by insert() or patch().
+type setSecurityPolicyAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+
+	// securityPolicy if non-nil will call setSecurityPolicy(). An empty
+	// string detaches the policy.
+	securityPolicy *string
+	// edgeSecurityPolicy if non-nil will call setEdgeSecurityPolicy(). An
+	// empty string detaches the policy.
+	edgeSecurityPolicy *string
+	// oldPolicies are the policies that are no longer referenced after the
+	// update.
+	oldPolicies []*cloud.ResourceID
+}
+
+// newSetSecurityPolicyAction returns the action to go from the policies in got
+// to the ones in want. got is nil if the BackendService is being created.
+// Returns nil if the policies are the same.
+func newSetSecurityPolicyAction(id *cloud.ResourceID, got, want *compute.BackendService) (*setSecurityPolicyAction, error) {
+	if got == nil {
+		got = &compute.BackendService{}
+	}
+	act := &setSecurityPolicyAction{id: id}
+	// Condition: the BackendService must exist.
+	act.Want = exec.EventList{exec.NewExistsEvent(id)}
+
+	set := func(field string, dest **string, gotURL, wantURL string) error {
+		if gotURL == wantURL {
+			return nil
+		}
+		*dest = &wantURL
+		if wantURL != "" {
+			policyID, err := cloud.ParseResourceURL(wantURL)
+			if err != nil {
+				return fmt.Errorf("BackendServiceNode %s: %w", field, err)
+			}
+			// Condition: the policy must exist before it is attached.
+			act.Want = append(act.Want, exec.NewExistsEvent(policyID))
+		}
+		if gotURL != "" {
+			policyID, err := cloud.ParseResourceURL(gotURL)
+			if err != nil {
+				return fmt.Errorf("BackendServiceNode %s: %w", field, err)
+			}
+			act.oldPolicies = append(act.oldPolicies, policyID)
+		}
+		return nil
+	}
+	if err := set("SecurityPolicy", &act.securityPolicy, got.SecurityPolicy, want.SecurityPolicy); err != nil {
+		return nil, err
+	}
+	if err := set("EdgeSecurityPolicy", &act.edgeSecurityPolicy, got.EdgeSecurityPolicy, want.EdgeSecurityPolicy); err != nil {
+		return nil, err
+	}
+	if act.securityPolicy == nil && act.edgeSecurityPolicy == nil {
+		return nil, nil
+	}
+	return act, nil
+}
+
+func (act *setSecurityPolicyAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	key := act.id.Key
+	projectOpt := cloud.ForceProjectID(act.id.ProjectID)
+	errf := func(verb string, err error) error {
+		return fmt.Errorf("BackendServiceSetSecurityPolicyAction Run(%s): %s: %w", act.id, verb, err)
+	}
+
+	if act.securityPolicy != nil {
+		ref := &compute.SecurityPolicyReference{
+			SecurityPolicy:  *act.securityPolicy,
+			ForceSendFields: []string{"SecurityPolicy"},
+		}
+		var err error
+		switch key.Type() {
+		case meta.Global:
+			err = cl.BackendServices().SetSecurityPolicy(ctx, key, ref, projectOpt)
+		case meta.Regional:
+			err = cl.RegionBackendServices().SetSecurityPolicy(ctx, key, ref, projectOpt)
+		default:
+			err = fmt.Errorf("invalid key type")
+		}
+		if err != nil {
+			return nil, errf("SetSecurityPolicy", err)
+		}
+	}
+
+	if act.edgeSecurityPolicy != nil {
+		if key.Type() != meta.Global {
+			return nil, errf("SetEdgeSecurityPolicy", fmt.Errorf("EdgeSecurityPolicy is only supported for global BackendServices"))
+		}
+		ref := &compute.SecurityPolicyReference{
+			SecurityPolicy:  *act.edgeSecurityPolicy,
+			ForceSendFields: []string{"SecurityPolicy"},
+		}
+		if err := cl.BackendServices().SetEdgeSecurityPolicy(ctx, key, ref, projectOpt); err != nil {
+			return nil, errf("SetEdgeSecurityPolicy", err)
+		}
+	}
+
+	return act.DryRun(), nil
+}
+
+func (act *setSecurityPolicyAction) DryRun() exec.EventList {
+	var events exec.EventList
+	for _, policy := range act.oldPolicies {
+		events = append(events, exec.NewDropRefEvent(act.id, policy))
+	}
+	return events
+}
+
+func (act *setSecurityPolicyAction) String() string {
+	return fmt.Sprintf("BackendServiceSetSecurityPolicyAction(%s)", act.id)
+}
+
+func (act *setSecurityPolicyAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *setSecurityPolicyAction) Metadata() *exec.ActionMetadata {
+	str := func(s *string) string {
+		if s == nil {
+			return "<unchanged>"
+		}
+		return fmt.Sprintf("%q", *s)
+	}
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("BackendServiceSetSecurityPolicyAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: fmt.Sprintf("Set security policies for %s (securityPolicy: %s, edgeSecurityPolicy: %s)", act.id, str(act.securityPolicy), str(act.edgeSecurityPolicy)),
+	}
+}
+
+// updateAction changes the fields (e.g. .Backends) that are updated in place
+// with update(). The other fields in resource are sent as-is. If patch is
+// set, patch() is used instead and only the fields in fieldMask are sent.
+type updateAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// resource to send to update().
+	resource BackendService
+	// fingerprint of the current resource.
+	fingerprint string
+	// oldGroups are the backends that are no longer referenced after the
+	// update.
+	oldGroups []*cloud.ResourceID
+
+	patch     bool
+	fieldMask []string
+
+	// merge is set in merge mode (see SetBackendsOwnership). The backends
+	// in resource were merged with the ones at plan time so they are merged
+	// again with the current backends if the resource has changed since.
+	merge *updateMerge
+}
+
+// updateMerge are the inputs to withMergedBackends() for updateAction.
+type updateMerge struct {
+	// want is the resource of the node, without the backends that are not
+	// owned.
+	want      BackendService
+	ownership *BackendsOwnership
+}
+
+func newUpdateAction(id *cloud.ResourceID, got, want *compute.BackendService, resource BackendService) *updateAction {
+	act := &updateAction{
+		id:          id,
+		resource:    resource,
+		fingerprint: got.Fingerprint,
+	}
+	gotGroups := map[string]bool{}
+	for _, b := range got.Backends {
+		gotGroups[b.Group] = true
+	}
+	wantGroups := map[string]bool{}
+	// Condition: the BackendService and new backends must exist.
+	act.Want = exec.EventList{exec.NewExistsEvent(id)}
+	for _, b := range want.Backends {
+		wantGroups[b.Group] = true
+		if gotGroups[b.Group] {
+			continue
+		}
+		if groupID, err := cloud.ParseResourceURL(b.Group); err == nil {
+			act.Want = append(act.Want, exec.NewExistsEvent(groupID))
+		}
+	}
+	for _, b := range got.Backends {
+		if wantGroups[b.Group] {
+			continue
+		}
+		if groupID, err := cloud.ParseResourceURL(b.Group); err == nil {
+			act.oldGroups = append(act.oldGroups, groupID)
+		}
+	}
+	return act
+}
+
+func (act *updateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	f := (&ops{}).UpdateFuncs(cl)
+	if act.patch {
+		f = (&ops{}).PatchFuncs(cl)
+		f.FieldMask = act.fieldMask
+	}
+	var err error
+	if act.merge == nil {
+		err = f.DoWithRetry(ctx, act.fingerprint, act.id, act.resource, (&ops{}).GetFuncs(cl))
+	} else {
+		err = act.doMerged(ctx, cl, f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("BackendServiceUpdateAction(%s): %w", act.id, err)
+	}
+	return act.DryRun(), nil
+}
+
+// doMerged sends the resource in merge mode. A stale fingerprint means that
+// the resource was changed after it was read, e.g. a backend was added by
+// another system. Retrying with only the new fingerprint (DoWithRetry) would
+// remove that backend, so the current resource is read and the backends are
+// merged again before the update is retried once.
+func (act *updateAction) doMerged(ctx context.Context, cl cloud.Cloud, f *rnode.UpdateFuncs[compute.BackendService, alpha.BackendService, beta.BackendService]) error {
+	err := f.Do(ctx, act.fingerprint, act.id, act.resource)
+	if !rnode.IsErrorFingerprintMismatch(err) {
+		return err
+	}
+	klog.V(2).Infof("update %s: fingerprint mismatch, merging the backends again: %v", act.id, err)
+	cur, getErr := (&ops{}).GetFuncs(cl).Do(ctx, act.resource.Version(), act.id, &typeTrait{})
+	if getErr != nil {
+		return fmt.Errorf("%w (get current resource: %v)", err, getErr)
+	}
+	resource, mergeErr := withMergedBackends(cur, act.merge.want, act.merge.ownership)
+	if mergeErr != nil {
+		return fmt.Errorf("%w (merge backends: %v)", err, mergeErr)
+	}
+	curGA, convErr := cur.ToGA()
+	if convErr != nil {
+		return fmt.Errorf("%w (get current fingerprint: %v)", err, convErr)
+	}
+	return f.Do(ctx, curGA.Fingerprint, act.id, resource)
+}
+
+func (act *updateAction) DryRun() exec.EventList {
+	var events exec.EventList
+	for _, group := range act.oldGroups {
+		events = append(events, exec.NewDropRefEvent(act.id, group))
+	}
+	return events
+}
+
+func (act *updateAction) String() string {
+	return fmt.Sprintf("BackendServiceUpdateAction(%s)", act.id)
+}
+
+func (act *updateAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *updateAction) Metadata() *exec.ActionMetadata {
+	summary := fmt.Sprintf("Update %s", act.id)
+	if act.patch {
+		summary = fmt.Sprintf("Patch %s (fields: %v)", act.id, act.fieldMask)
+	}
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("BackendServiceUpdateAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: summary,
+	}
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendservice
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/securitypolicy"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestSetSecurityPolicyAction(t *testing.T) {
+	t.Parallel()
+
+	policy := "https://www.googleapis.com/compute/v1/projects/proj/global/securityPolicies/sp"
+	oldPolicy := securitypolicy.ID("proj", meta.GlobalKey("sp-old"))
+
+	for _, tc := range []struct {
+		name      string
+		key       *meta.Key
+		service   string
+		edge      bool
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:      "global",
+			key:       meta.GlobalKey("bs"),
+			service:   "BackendServices",
+			edge:      true,
+			wantCalls: []string{"SetSecurityPolicy", "SetEdgeSecurityPolicy"},
+		},
+		{
+			name:      "regional",
+			key:       meta.RegionalKey("bs", "us-central1"),
+			service:   "RegionBackendServices",
+			wantCalls: []string{"SetSecurityPolicy"},
+		},
+		{
+			name:    "regional edge policy",
+			key:     meta.RegionalKey("bs", "us-central1"),
+			edge:    true,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := ID("proj", tc.key)
+			act := &setSecurityPolicyAction{
+				id:             id,
+				securityPolicy: &policy,
+				oldPolicies:    []*cloud.ResourceID{oldPolicy},
+			}
+			if tc.edge {
+				act.edgeSecurityPolicy = &policy
+			}
+			wantEvents := exec.EventList{exec.NewDropRefEvent(id, oldPolicy)}
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+			events, err := act.Run(context.Background(), mock)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !events.Equal(wantEvents) {
+				t.Errorf("Run() = %v, want %v", events, wantEvents)
+			}
+			for _, op := range tc.wantCalls {
+				calls := mock.Calls().Matching(cloud.MockCall{Service: tc.service, Operation: op, Key: tc.key})
+				if len(calls) != 1 {
+					t.Errorf("calls to %s.%s = %v, want 1", tc.service, op, calls)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateAction(t *testing.T) {
+	const (
+		group    = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-a/networkEndpointGroups/neg"
+		oldGroup = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-a/networkEndpointGroups/neg-old"
+	)
+	key := meta.GlobalKey("bs")
+	id := ID("proj", key)
+	got := &compute.BackendService{Name: "bs", Fingerprint: "fp", Backends: []*compute.Backend{{Group: oldGroup}}}
+	want := &compute.BackendService{Name: "bs", Backends: []*compute.Backend{{Group: group}}}
+	m := NewMutableBackendService("proj", key)
+	if err := m.Set(want); err != nil {
+		t.Fatalf("Set() = %v, want nil", err)
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+
+	act := newUpdateAction(id, got, want, r)
+	negID, _ := cloud.ParseResourceURL(group)
+	oldNegID, _ := cloud.ParseResourceURL(oldGroup)
+	if wantEvents := (exec.EventList{exec.NewExistsEvent(id), exec.NewExistsEvent(negID)}); !act.Want.Equal(wantEvents) {
+		t.Errorf("Want = %v, want %v", act.Want, wantEvents)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	if err := mock.BackendServices().Insert(context.Background(), key, got); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	events, err := act.Run(context.Background(), mock)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	if wantEvents := (exec.EventList{exec.NewDropRefEvent(id, oldNegID)}); !events.Equal(wantEvents) {
+		t.Errorf("Run() = %v, want %v", events, wantEvents)
+	}
+	bs, err := mock.BackendServices().Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get() = %v, want nil", err)
+	}
+	if len(bs.Backends) != 1 || bs.Backends[0].Group != group {
+		t.Errorf("Backends = %v, want [%q]", bs.Backends, group)
+	}
+}
+
+func TestUpdateActionPatch(t *testing.T) {
+	const group = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-a/networkEndpointGroups/neg"
+	key := meta.GlobalKey("bs")
+	id := ID("proj", key)
+	// .Description is managed by another system and is not in want.
+	got := &compute.BackendService{Name: "bs", Fingerprint: "fp", Description: "external", TimeoutSec: 10}
+	want := &compute.BackendService{Name: "bs", Backends: []*compute.Backend{{Group: group}}}
+	m := NewMutableBackendService("proj", key)
+	if err := m.Set(want); err != nil {
+		t.Fatalf("Set() = %v, want nil", err)
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+
+	act := newUpdateAction(id, got, want, r)
+	act.patch = true
+	act.fieldMask = []string{"Backends", "TimeoutSec"}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	if err := mock.BackendServices().Insert(context.Background(), key, got); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	if _, err := act.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	for op, want := range map[string]int{"Patch": 1, "Update": 0} {
+		if calls := mock.Calls().Matching(cloud.MockCall{Service: "BackendServices", Operation: op, Key: key}); len(calls) != want {
+			t.Errorf("calls to BackendServices.%s = %v, want %d", op, calls, want)
+		}
+	}
+	bs, err := mock.BackendServices().Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get() = %v, want nil", err)
+	}
+	if len(bs.Backends) != 1 || bs.Backends[0].Group != group {
+		t.Errorf("Backends = %v, want [%q]", bs.Backends, group)
+	}
+	// Fields in the mask are cleared, the others are not changed.
+	if bs.TimeoutSec != 0 {
+		t.Errorf("TimeoutSec = %d, want 0", bs.TimeoutSec)
+	}
+	if bs.Description != "external" {
+		t.Errorf("Description = %q, want %q", bs.Description, "external")
+	}
+}
+
+func TestUpdateActionMergeStaleFingerprint(t *testing.T) {
+	const (
+		prefix = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-a/networkEndpointGroups/k8s-"
+		owned1 = prefix + "neg1"
+		owned2 = prefix + "neg2"
+		ext    = "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-a/instanceGroups/ig"
+	)
+	ctx := context.Background()
+	key := meta.GlobalKey("bs")
+	makeBuilder := func(groups ...string) rnode.Builder {
+		t.Helper()
+		m := NewMutableBackendService("proj", key)
+		m.Access(func(x *compute.BackendService) {
+			x.Name = "bs"
+			x.Fingerprint = "fp"
+			for _, g := range groups {
+				x.Backends = append(x.Backends, &compute.Backend{Group: g})
+			}
+		})
+		r, _ := m.Freeze()
+		b := NewBuilderWithResource(r)
+		b.SetOwnership(rnode.OwnershipManaged)
+		b.SetState(rnode.NodeExists)
+		return b
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	// Update() checks and changes the .Fingerprint like the API.
+	mock.MockBackendServices.UpdateHook = func(_ context.Context, key *meta.Key, obj *compute.BackendService, m *cloud.MockBackendServices, _ ...cloud.Option) error {
+		m.Lock.Lock()
+		defer m.Lock.Unlock()
+		cur := m.Objects[*key].ToGA()
+		if obj.Fingerprint != cur.Fingerprint {
+			return &googleapi.Error{Code: http.StatusPreconditionFailed, Message: "Invalid fingerprint."}
+		}
+		c := *obj
+		c.Fingerprint = cur.Fingerprint + "+"
+		m.Objects[*key] = m.Obj(&c)
+		return nil
+	}
+	if err := mock.BackendServices().Insert(ctx, key, &compute.BackendService{Name: "bs", Fingerprint: "fp", Backends: []*compute.Backend{{Group: owned1}}}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+
+	got, err := makeBuilder(owned1).Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	wantb := makeBuilder(owned1, owned2)
+	if err := SetBackendsOwnership(wantb, &BackendsOwnership{GroupPrefixes: []string{prefix}}); err != nil {
+		t.Fatalf("SetBackendsOwnership() = %v, want nil", err)
+	}
+	want, err := wantb.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	p, err := want.Diff(got)
+	if err != nil {
+		t.Fatalf("Diff() = %v, want nil", err)
+	}
+	want.Plan().Set(*p)
+	actions, err := want.Actions(got)
+	if err != nil {
+		t.Fatalf("Actions() = %v, want nil", err)
+	}
+
+	// Another system adds a backend after the plan.
+	if err := mock.BackendServices().Update(ctx, key, &compute.BackendService{Name: "bs", Fingerprint: "fp", Backends: []*compute.Backend{{Group: ext}, {Group: owned1}}}); err != nil {
+		t.Fatalf("Update() = %v, want nil", err)
+	}
+	for _, act := range actions {
+		if _, err := act.Run(ctx, mock); err != nil {
+			t.Fatalf("%s.Run() = %v, want nil", act, err)
+		}
+	}
+
+	bs, err := mock.BackendServices().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = %v, want nil", err)
+	}
+	var groups []string
+	for _, b := range bs.Backends {
+		groups = append(groups, b.Group)
+	}
+	if diff := cmp.Diff(groups, []string{ext, owned1, owned2}); diff != "" {
+		t.Errorf("Backends: diff -got,+want: %s", diff)
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendservice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// BackendsOwnership puts the BackendService node into merge mode for
+// .Backends: the graph owns only the backends that match and preserves the
+// other (externally added) backends in the cloud resource on update.
+//
+// A backend is owned if its Group starts with one of the GroupPrefixes or
+// its Description is equal to Description. GroupPrefixes should use the URL
+// form returned by the API, e.g.
+// "https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-a/networkEndpointGroups/k8s1-".
+type BackendsOwnership struct {
+	GroupPrefixes []string
+	// Description marks backends created by the graph. Ignored if empty.
+	Description string
+}
+
+func (o *BackendsOwnership) owns(group, description string) bool {
+	for _, prefix := range o.GroupPrefixes {
+		if strings.HasPrefix(group, prefix) {
+			return true
+		}
+	}
+	return o.Description != "" && description == o.Description
+}
+
+func (o *BackendsOwnership) copy() *BackendsOwnership {
+	if o == nil {
+		return nil
+	}
+	return &BackendsOwnership{
+		GroupPrefixes: append([]string(nil), o.GroupPrefixes...),
+		Description:   o.Description,
+	}
+}
+
+// SetBackendsOwnership enables merge mode for .Backends on the BackendService
+// builder. A nil o means the node owns the entire list (the default). All of
+// the backends in the builder resource must be owned.
+func SetBackendsOwnership(b rnode.Builder, o *BackendsOwnership) error {
+	bsb, ok := b.(*builder)
+	if !ok {
+		return fmt.Errorf("SetBackendsOwnership: invalid builder type %T", b)
+	}
+	if o != nil && len(o.GroupPrefixes) == 0 && o.Description == "" {
+		return fmt.Errorf("SetBackendsOwnership: %s: no GroupPrefixes or Description", b.ID())
+	}
+	bsb.backendsOwnership = o.copy()
+	return nil
+}
+
+// validateBackendsOwnership checks that the graph only specifies backends
+// that it owns.
+func validateBackendsOwnership(r BackendService, o *BackendsOwnership) error {
+	if r == nil || o == nil {
+		return nil
+	}
+	obj, err := r.ToGA()
+	if err != nil {
+		return err
+	}
+	for _, backend := range obj.Backends {
+		if !o.owns(backend.Group, backend.Description) {
+			return fmt.Errorf("backend %q is not owned by the graph", backend.Group)
+		}
+	}
+	return nil
+}
+
+// mergeBackends returns the Backends from want and the backends in got that
+// are not owned. The order of the backends in got is preserved to avoid
+// spurious diffs.
+func mergeBackends[B any](o *BackendsOwnership, got, want []*B, fields func(*B) (group, description string)) []*B {
+	wantByGroup := map[string]*B{}
+	for _, b := range want {
+		group, _ := fields(b)
+		wantByGroup[group] = b
+	}
+
+	var ret []*B
+	done := map[string]bool{}
+	for _, b := range got {
+		group, description := fields(b)
+		if w, ok := wantByGroup[group]; ok {
+			ret = append(ret, w)
+			done[group] = true
+		} else if !o.owns(group, description) {
+			ret = append(ret, b)
+		}
+	}
+	for _, b := range want {
+		if group, _ := fields(b); !done[group] {
+			ret = append(ret, b)
+		}
+	}
+	return ret
+}
+
+// withMergedBackends returns a copy of want with .Backends merged with the
+// externally owned backends from got.
+func withMergedBackends(got, want BackendService, o *BackendsOwnership) (BackendService, error) {
+	m := NewMutableBackendService(want.ResourceID().ProjectID, want.ResourceID().Key)
+	switch want.Version() {
+	case meta.VersionAlpha:
+		g, err := got.ToAlpha()
+		if err != nil {
+			return nil, err
+		}
+		x, err := want.ToAlpha()
+		if err != nil {
+			return nil, err
+		}
+		c := *x
+		c.Backends = mergeBackends(o, g.Backends, x.Backends, func(b *alpha.Backend) (string, string) { return b.Group, b.Description })
+		if err := m.SetAlpha(&c); err != nil {
+			return nil, err
+		}
+	case meta.VersionBeta:
+		g, err := got.ToBeta()
+		if err != nil {
+			return nil, err
+		}
+		x, err := want.ToBeta()
+		if err != nil {
+			return nil, err
+		}
+		c := *x
+		c.Backends = mergeBackends(o, g.Backends, x.Backends, func(b *beta.Backend) (string, string) { return b.Group, b.Description })
+		if err := m.SetBeta(&c); err != nil {
+			return nil, err
+		}
+	default:
+		g, err := got.ToGA()
+		if err != nil {
+			return nil, err
+		}
+		x, err := want.ToGA()
+		if err != nil {
+			return nil, err
+		}
+		c := *x
+		c.Backends = mergeBackends(o, g.Backends, x.Backends, func(b *compute.Backend) (string, string) { return b.Group, b.Description })
+		if err := m.Set(&c); err != nil {
+			return nil, err
+		}
+	}
+	return m.Freeze()
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package backendservice
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
 )
 
 func TestBackendServiceSchema(t *testing.T) {
@@ -30,3 +33,28 @@ func TestBackendServiceSchema(t *testing.T) {
 		t.Fatalf("CheckSchema() = %v, want nil", err)
 	}
 }
+
+func TestBackendServiceValidate(t *testing.T) {
+	const proj = "proj-1"
+	key := meta.GlobalKey("key-1")
+
+	x := NewMutableBackendService(proj, key)
+	x.Access(func(x *compute.BackendService) {
+		x.Backends = []*compute.Backend{{Group: "ig"}, {}, {}}
+	})
+	_, err := x.Freeze()
+	var verr *api.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Freeze() = %v, want ValidationError", err)
+	}
+	if len(verr.Violations) != 2 {
+		t.Errorf("len(Violations) = %d, want 2 (%v)", len(verr.Violations), verr)
+	}
+
+	// Resources from the server are not validated.
+	x = NewMutableBackendService(proj, key)
+	x.Set(&compute.BackendService{Name: "key-1", Backends: []*compute.Backend{{}}})
+	if _, err := x.Freeze(); err != nil {
+		t.Errorf("Freeze() = %v, want nil", err)
+	}
+}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
-	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
@@ -43,17 +42,34 @@ func NewBuilderWithResource(r BackendService) rnode.Builder {
 type builder struct {
 	rnode.BuilderBase
 	resource BackendService
+	// backendsOwnership enables merge mode for .Backends. See
+	// SetBackendsOwnership().
+	backendsOwnership *BackendsOwnership
+	// usePatch is set by SetUsePatch().
+	usePatch bool
 }
 
 // builder implements node.Builder.
 var _ rnode.Builder = (*builder)(nil)
 
+// SetUsePatch changes the BackendService with patch() instead of update().
+// Only the changed fields are sent, leaving the fields managed by other
+// systems as-is.
+func SetUsePatch(b rnode.Builder, usePatch bool) error {
+	bsb, ok := b.(*builder)
+	if !ok {
+		return fmt.Errorf("SetUsePatch: invalid builder type %T", b)
+	}
+	bsb.usePatch = usePatch
+	return nil
+}
+
 func (b *builder) Resource() rnode.UntypedResource { return b.resource }
 
 func (b *builder) SetResource(u rnode.UntypedResource) error {
 	r, ok := u.(BackendService)
 	if !ok {
-		return fmt.Errorf("XXX")
+		return fmt.Errorf("cannot set BackendService from untyped resource, %T", u)
 	}
 	b.resource = r
 	return nil
@@ -65,67 +81,7 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 }
 
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
-	if b.resource == nil {
-		return nil, nil
-	}
-
-	obj, _ := b.resource.ToGA()
-
-	var ret []rnode.ResourceRef
-
-	// Backends[].Group
-	for idx, backend := range obj.Backends {
-		id, err := cloud.ParseResourceURL(backend.Group)
-		if err != nil {
-			return nil, fmt.Errorf("BackendServiceNode Group: %w", err)
-		}
-		ret = append(ret, rnode.ResourceRef{
-			From: b.ID(),
-			Path: api.Path{}.Field("Backends").Index(idx).Field("Group"),
-			To:   id,
-		})
-	}
-
-	// Healthchecks[]
-	for idx, hc := range obj.HealthChecks {
-		id, err := cloud.ParseResourceURL(hc)
-		if err != nil {
-			return nil, fmt.Errorf("BackendServiceNode HealthChecks: %w", err)
-		}
-		ret = append(ret, rnode.ResourceRef{
-			From: b.ID(),
-			Path: api.Path{}.Field("HealthChecks").Index(idx),
-			To:   id,
-		})
-	}
-
-	// SecurityPolicy
-	if obj.SecurityPolicy != "" {
-		id, err := cloud.ParseResourceURL(obj.SecurityPolicy)
-		if err != nil {
-			return nil, fmt.Errorf("BackendServiceNode SecurityPolicy: %w", err)
-		}
-		ret = append(ret, rnode.ResourceRef{
-			From: b.ID(),
-			Path: api.Path{}.Field("SecurityPolicy"),
-			To:   id,
-		})
-	}
-
-	// EdgeSecurityPolicy
-	if obj.EdgeSecurityPolicy != "" {
-		id, err := cloud.ParseResourceURL(obj.EdgeSecurityPolicy)
-		if err != nil {
-			return nil, fmt.Errorf("BackendServiceNode SecurityPolicy: %w", err)
-		}
-		ret = append(ret, rnode.ResourceRef{
-			From: b.ID(),
-			Path: api.Path{}.Field("SecurityPolicy"),
-			To:   id,
-		})
-	}
-
-	return ret, nil
+	return rnode.OutRefsFromTraits[compute.BackendService, alpha.BackendService, beta.BackendService](b.resource, &typeTrait{})
 }
 
 func (b *builder) Build() (rnode.Node, error) {
@@ -133,7 +89,15 @@ func (b *builder) Build() (rnode.Node, error) {
 		return nil, fmt.Errorf("BackendService %s resource is nil with state %s", b.ID(), b.State())
 	}
 
-	ret := &backendServiceNode{resource: b.resource}
+	if err := validateBackendsOwnership(b.resource, b.backendsOwnership); err != nil {
+		return nil, fmt.Errorf("BackendService %s: %w", b.ID(), err)
+	}
+
+	ret := &backendServiceNode{
+		resource:          b.resource,
+		backendsOwnership: b.backendsOwnership.copy(),
+		usePatch:          b.usePatch,
+	}
 	if err := ret.InitFromBuilder(b); err != nil {
 		return nil, err
 	}
@@ -18,9 +18,10 @@ package backendservice
 
 import (
 	"fmt"
-	"strings"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -31,60 +32,71 @@ import (
 type backendServiceNode struct {
 	rnode.NodeBase
 	resource BackendService
+	// backendsOwnership is non-nil if the node only owns a subset of
+	// .Backends. See SetBackendsOwnership().
+	backendsOwnership *BackendsOwnership
+	// usePatch is true if the fields are changed with patch(). See
+	// SetUsePatch().
+	usePatch bool
 }
 
-var _ rnode.Node = (*backendServiceNode)(nil)
+var _ rnode.UsedByNode = (*backendServiceNode)(nil)
 
 func (n *backendServiceNode) Resource() rnode.UntypedResource { return n.resource }
 
+// diffPolicy is the default DiffPolicy for BackendService. The policies are
+// attached with setSecurityPolicy() and setEdgeSecurityPolicy() and the
+// .Backends are changed in place with update(). All other fields (e.g.
+// LoadBalancingScheme) require the BackendService to be recreated.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate).
+	SetFields(rnode.FieldUpdate, "Backends", "EdgeSecurityPolicy", "SecurityPolicy")
+
+// securityPolicyPaths are changed by setSecurityPolicyAction instead of
+// update().
+var securityPolicyPaths = []api.Path{
+	api.Path{}.Pointer().Field("EdgeSecurityPolicy"),
+	api.Path{}.Pointer().Field("SecurityPolicy"),
+}
+
+// UsedBy returns the resources in .UsedBy (e.g. UrlMaps).
+func (n *backendServiceNode) UsedBy() ([]*cloud.ResourceID, error) {
+	if n.resource == nil {
+		return nil, nil
+	}
+	// .UsedBy is available in all versions.
+	obj, err := n.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("BackendServiceNode: UsedBy: %w", err)
+	}
+	var ret []*cloud.ResourceID
+	for _, u := range obj.UsedBy {
+		if u == nil || u.Reference == "" {
+			continue
+		}
+		id, err := cloud.ParseResourceURL(u.Reference)
+		if err != nil {
+			return nil, fmt.Errorf("BackendServiceNode: UsedBy: %w", err)
+		}
+		ret = append(ret, id)
+	}
+	return ret, nil
+}
+
 func (n *backendServiceNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
 	got, ok := gotNode.(*backendServiceNode)
 	if !ok {
 		return nil, fmt.Errorf("BackendServiceNode: invalid type to Diff: %T", gotNode)
 	}
-	diff, err := got.resource.Diff(n.resource)
+	want, err := n.wantResource(got)
 	if err != nil {
 		return nil, fmt.Errorf("BackendServiceNode: Diff %w", err)
 	}
-
-	if !diff.HasDiff() {
-		return &rnode.PlanDetails{
-			Operation: rnode.OpNothing,
-			Why:       "No diff between got and want",
-		}, nil
-	}
-
-	var (
-		needsRecreate bool
-		details       []string
-	)
-
-	planRecreate := func(s string, args ...any) {
-		details = append(details, fmt.Sprintf(s, args...))
-		needsRecreate = true
-	}
-
-	for _, delta := range diff.Items {
-		switch {
-		case delta.Path.Equal(api.Path{}.Pointer().Field("LoadBalancingScheme")):
-			planRecreate("LoadBalancingScheme change: '%v' -> '%v'", delta.A, delta.B)
-		default:
-			planRecreate("%s change: '%v' -> '%v'", delta.Path, delta.A, delta.B)
-		}
+	diff, err := got.resource.Diff(want)
+	if err != nil {
+		return nil, fmt.Errorf("BackendServiceNode: Diff %w", err)
 	}
 
-	if needsRecreate {
-		return &rnode.PlanDetails{
-			Operation: rnode.OpRecreate,
-			Why:       "BackendService needs to be recreated: " + strings.Join(details, ", "),
-			Diff:      diff,
-		}, nil
-	}
-	return &rnode.PlanDetails{
-		Operation: rnode.OpRecreate,
-		Why:       "BackendService needs to be updated: " + strings.Join(details, ", "),
-		Diff:      diff,
-	}, nil
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("BackendService", diff)
 }
 
 func (n *backendServiceNode) Actions(got rnode.Node) ([]exec.Action, error) {
@@ -92,7 +104,9 @@ func (n *backendServiceNode) Actions(got rnode.Node) ([]exec.Action, error) {
 
 	switch op {
 	case rnode.OpCreate:
-		return rnode.CreateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, n, n.resource)
+		return n.createActions(n.resource, func(r BackendService) ([]exec.Action, error) {
+			return rnode.CreateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, n, r)
+		})
 
 	case rnode.OpDelete:
 		return rnode.DeleteActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, got, n)
@@ -101,21 +115,180 @@ func (n *backendServiceNode) Actions(got rnode.Node) ([]exec.Action, error) {
 		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
 
 	case rnode.OpRecreate:
-		return rnode.RecreateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, got, n, n.resource)
+		gotNode, ok := got.(*backendServiceNode)
+		if !ok {
+			return nil, fmt.Errorf("BackendServiceNode: invalid type %T", got)
+		}
+		// Externally owned backends are preserved across the recreate.
+		want, err := n.wantResource(gotNode)
+		if err != nil {
+			return nil, fmt.Errorf("BackendServiceNode: %w", err)
+		}
+		return n.createActions(want, func(r BackendService) ([]exec.Action, error) {
+			return rnode.RecreateActions[compute.BackendService, alpha.BackendService, beta.BackendService](&ops{}, got, n, r)
+		})
 
 	case rnode.OpUpdate:
-		// TODO
+		return n.updateActions(got)
 	}
 
 	return nil, fmt.Errorf("BackendServiceNode: invalid plan op %s", op)
 }
 
 func (n *backendServiceNode) Builder() rnode.Builder {
-	b := &builder{}
+	b := &builder{resource: n.resource, backendsOwnership: n.backendsOwnership.copy(), usePatch: n.usePatch}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
 	return b
 }
 
+func isSecurityPolicyPath(p api.Path) bool {
+	for _, sp := range securityPolicyPaths {
+		if p.HasPrefix(sp) {
+			return true
+		}
+	}
+	return false
+}
+
+// wantResource returns the resource to sync to the cloud. In merge mode (see
+// SetBackendsOwnership), this adds the backends in got that are not owned by
+// the graph. The resource is converted to the API version of the node.
+func (n *backendServiceNode) wantResource(got *backendServiceNode) (BackendService, error) {
+	if n.resource == nil {
+		return nil, nil
+	}
+	want := n.resource
+	if n.backendsOwnership != nil && got.resource != nil {
+		var err error
+		if want, err = withMergedBackends(got.resource, n.resource, n.backendsOwnership); err != nil {
+			return nil, err
+		}
+	}
+	return want.AsVersion(n.Version())
+}
+
+// createActions inserts want without the security policies using
+// insertActions and then attaches the policies.
+func (n *backendServiceNode) createActions(want BackendService, insertActions func(BackendService) ([]exec.Action, error)) ([]exec.Action, error) {
+	wantGA, err := want.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("BackendServiceNode: createActions: %w", err)
+	}
+	if wantGA.SecurityPolicy == "" && wantGA.EdgeSecurityPolicy == "" {
+		return insertActions(want)
+	}
+
+	r, err := withoutSecurityPolicies(want)
+	if err != nil {
+		return nil, fmt.Errorf("BackendServiceNode: createActions: %w", err)
+	}
+	actions, err := insertActions(r)
+	if err != nil {
+		return nil, err
+	}
+	act, err := newSetSecurityPolicyAction(n.ID(), nil, wantGA)
+	if err != nil {
+		return nil, err
+	}
+	return append(actions, act), nil
+}
+
+func (n *backendServiceNode) updateActions(gotNode rnode.Node) ([]exec.Action, error) {
+	got, ok := gotNode.(*backendServiceNode)
+	if !ok {
+		return nil, fmt.Errorf("BackendServiceNode: updateActions: invalid type %T", gotNode)
+	}
+	want, err := n.wantResource(got)
+	if err != nil {
+		return nil, fmt.Errorf("BackendServiceNode: updateActions: %w", err)
+	}
+	// update() replaces the whole resource so the OwnerMarker in
+	// .Description must be sent again.
+	if want, err = rnode.WantResource(n, want); err != nil {
+		return nil, fmt.Errorf("BackendServiceNode: updateActions: %w", err)
+	}
+
+	actions := []exec.Action{exec.NewExistsAction(n.ID())}
+	gotGA, err := got.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("BackendServiceNode: updateActions: %w", err)
+	}
+	wantGA, err := want.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("BackendServiceNode: updateActions: %w", err)
+	}
+	// Fields other than the security policies are changed with update() (or
+	// patch()).
+	var others api.DiffResult
+	for _, delta := range n.Plan().Details().Diff.Items {
+		if !isSecurityPolicyPath(delta.Path) {
+			others.Items = append(others.Items, delta)
+		}
+	}
+	if others.HasDiff() {
+		act := newUpdateAction(n.ID(), gotGA, wantGA, want)
+		if n.usePatch {
+			act.patch = true
+			act.fieldMask = rnode.FieldMask(&others)
+		}
+		if n.backendsOwnership != nil {
+			unmerged, err := rnode.WantResource(n, n.resource)
+			if err != nil {
+				return nil, fmt.Errorf("BackendServiceNode: updateActions: %w", err)
+			}
+			act.merge = &updateMerge{want: unmerged, ownership: n.backendsOwnership}
+		}
+		actions = append(actions, act)
+	}
+	act, err := newSetSecurityPolicyAction(n.ID(), gotGA, wantGA)
+	if err != nil {
+		return nil, err
+	}
+	if act != nil {
+		actions = append(actions, act)
+	}
+	return actions, nil
+}
+
+// withoutSecurityPolicies returns a copy of the resource with the security
+// policies cleared as they cannot be set by insert().
+func withoutSecurityPolicies(r BackendService) (BackendService, error) {
+	m := NewMutableBackendService(r.ResourceID().ProjectID, r.ResourceID().Key)
+	switch r.Version() {
+	case meta.VersionAlpha:
+		x, err := r.ToAlpha()
+		if err != nil {
+			return nil, err
+		}
+		c := *x
+		c.SecurityPolicy, c.EdgeSecurityPolicy = "", ""
+		if err := m.SetAlpha(&c); err != nil {
+			return nil, err
+		}
+	case meta.VersionBeta:
+		x, err := r.ToBeta()
+		if err != nil {
+			return nil, err
+		}
+		c := *x
+		c.SecurityPolicy, c.EdgeSecurityPolicy = "", ""
+		if err := m.SetBeta(&c); err != nil {
+			return nil, err
+		}
+	default:
+		x, err := r.ToGA()
+		if err != nil {
+			return nil, err
+		}
+		c := *x
+		c.SecurityPolicy, c.EdgeSecurityPolicy = "", ""
+		if err := m.Set(&c); err != nil {
+			return nil, err
+		}
+	}
+	return m.Freeze()
+}
+
 /*
 name
 string
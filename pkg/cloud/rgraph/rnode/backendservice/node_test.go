@@ -0,0 +1,520 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendservice
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/securitypolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestSecurityPolicyDiff(t *testing.T) {
+	const (
+		proj       = "proj-1"
+		policy     = "https://www.googleapis.com/compute/v1/projects/proj-1/global/securityPolicies/sp"
+		policy2    = "https://www.googleapis.com/compute/v1/projects/proj-1/global/securityPolicies/sp2"
+		betaPolicy = "https://www.googleapis.com/compute/beta/projects/proj-1/global/securityPolicies/sp"
+		edgePolicy = "https://www.googleapis.com/compute/v1/projects/proj-1/global/securityPolicies/edge"
+	)
+	key := meta.GlobalKey("bs")
+	makeNode := func(scheme, sp, edge string) rnode.Node {
+		t.Helper()
+		m := NewMutableBackendService(proj, key)
+		// The field traits are incomplete so Access() returns an error
+		// for the zero-valued fields.
+		m.Access(func(x *compute.BackendService) {
+			x.Name = "bs"
+			x.LoadBalancingScheme = scheme
+			x.SecurityPolicy = sp
+			x.EdgeSecurityPolicy = edge
+		})
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	got := makeNode("EXTERNAL_MANAGED", policy, "")
+
+	for _, tc := range []struct {
+		name        string
+		want        rnode.Node
+		wantOp      rnode.Operation
+		wantSP      *string
+		wantEdge    *string
+		wantDropRef int
+	}{
+		{
+			name:   "no diff",
+			want:   makeNode("EXTERNAL_MANAGED", policy, ""),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "policy URL with a different version",
+			want:   makeNode("EXTERNAL_MANAGED", betaPolicy, ""),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:        "change policy",
+			want:        makeNode("EXTERNAL_MANAGED", policy2, ""),
+			wantOp:      rnode.OpUpdate,
+			wantSP:      &[]string{policy2}[0],
+			wantDropRef: 1,
+		},
+		{
+			name:        "detach policy",
+			want:        makeNode("EXTERNAL_MANAGED", "", ""),
+			wantOp:      rnode.OpUpdate,
+			wantSP:      &[]string{""}[0],
+			wantDropRef: 1,
+		},
+		{
+			name:     "attach edge policy",
+			want:     makeNode("EXTERNAL_MANAGED", policy, edgePolicy),
+			wantOp:   rnode.OpUpdate,
+			wantEdge: &[]string{edgePolicy}[0],
+		},
+		{
+			name:   "other fields are recreated",
+			want:   makeNode("EXTERNAL", policy2, ""),
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := tc.want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			tc.want.Plan().Set(*p)
+			actions, err := tc.want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*setSecurityPolicyAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *setSecurityPolicyAction", actions[1])
+			}
+			eq := func(a, b *string) bool { return (a == nil && b == nil) || (a != nil && b != nil && *a == *b) }
+			if !eq(act.securityPolicy, tc.wantSP) {
+				t.Errorf("securityPolicy = %v, want %v", act.securityPolicy, tc.wantSP)
+			}
+			if !eq(act.edgeSecurityPolicy, tc.wantEdge) {
+				t.Errorf("edgeSecurityPolicy = %v, want %v", act.edgeSecurityPolicy, tc.wantEdge)
+			}
+			if n := len(act.DryRun()); n != tc.wantDropRef {
+				t.Errorf("len(DryRun()) = %d, want %d", n, tc.wantDropRef)
+			}
+		})
+	}
+}
+
+func TestCreateWithSecurityPolicy(t *testing.T) {
+	const policy = "https://www.googleapis.com/compute/v1/projects/proj-1/global/securityPolicies/sp"
+	m := NewMutableBackendService("proj-1", meta.GlobalKey("bs"))
+	m.Access(func(x *compute.BackendService) {
+		x.Name = "bs"
+		x.SecurityPolicy = policy
+	})
+	r, _ := m.Freeze()
+	b := NewBuilderWithResource(r)
+	b.SetOwnership(rnode.OwnershipManaged)
+	b.SetState(rnode.NodeExists)
+	want, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	want.Plan().Set(rnode.PlanDetails{Operation: rnode.OpCreate})
+
+	actions, err := want.Actions(nil)
+	if err != nil {
+		t.Fatalf("Actions() = %v, want nil", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+	}
+	act, ok := actions[1].(*setSecurityPolicyAction)
+	if !ok {
+		t.Fatalf("Actions()[1] is %T, want *setSecurityPolicyAction", actions[1])
+	}
+	if act.securityPolicy == nil || *act.securityPolicy != policy {
+		t.Errorf("securityPolicy = %v, want %q", act.securityPolicy, policy)
+	}
+	// The policy is attached after the BackendService is created.
+	wantEvents := exec.EventList{
+		exec.NewExistsEvent(want.ID()),
+		exec.NewExistsEvent(securitypolicy.ID("proj-1", meta.GlobalKey("sp"))),
+	}
+	if !act.Want.Equal(wantEvents) {
+		t.Errorf("Want = %v, want %v", act.Want, wantEvents)
+	}
+	// The resource that is inserted does not have the policy.
+	stripped, err := withoutSecurityPolicies(r)
+	if err != nil {
+		t.Fatalf("withoutSecurityPolicies() = %v, want nil", err)
+	}
+	if ga, _ := stripped.ToGA(); ga.SecurityPolicy != "" {
+		t.Errorf("withoutSecurityPolicies().SecurityPolicy = %q, want empty", ga.SecurityPolicy)
+	}
+	if ga, _ := r.ToGA(); ga.SecurityPolicy != policy {
+		t.Errorf("SecurityPolicy = %q after withoutSecurityPolicies(), want %q", ga.SecurityPolicy, policy)
+	}
+}
+
+func TestUpdateKeepsOwnerMarker(t *testing.T) {
+	const (
+		proj = "proj-1"
+		neg  = "https://www.googleapis.com/compute/v1/projects/proj-1/zones/us-central1-a/networkEndpointGroups/neg"
+	)
+	key := meta.GlobalKey("bs")
+	marker := &rnode.OwnerMarker{Description: "managed-by: test"}
+	makeNode := func(desc string, groups ...string) rnode.Node {
+		t.Helper()
+		m := NewMutableBackendService(proj, key)
+		m.Access(func(x *compute.BackendService) {
+			x.Name = "bs"
+			x.Description = desc
+			x.Fingerprint = "fp"
+			for _, g := range groups {
+				x.Backends = append(x.Backends, &compute.Backend{Group: g})
+			}
+		})
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	got := makeNode(marker.Description)
+	want := makeNode("", neg)
+	want.Plan().SetOwnerMarker(marker)
+	p, err := want.Diff(got)
+	if err != nil {
+		t.Fatalf("Diff() = %v, want nil", err)
+	}
+	if p.Operation != rnode.OpUpdate {
+		t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, rnode.OpUpdate, p.Why)
+	}
+	want.Plan().Set(*p)
+	actions, err := want.Actions(got)
+	if err != nil {
+		t.Fatalf("Actions() = %v, want nil", err)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	if err := mock.BackendServices().Insert(context.Background(), key, &compute.BackendService{Name: "bs", Description: marker.Description, Fingerprint: "fp"}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	for _, act := range actions {
+		if _, err := act.Run(context.Background(), mock); err != nil {
+			t.Fatalf("%s.Run() = %v, want nil", act, err)
+		}
+	}
+	bs, err := mock.BackendServices().Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get() = %v, want nil", err)
+	}
+	if len(bs.Backends) != 1 || bs.Backends[0].Group != neg {
+		t.Errorf("Backends = %v, want [%q]", bs.Backends, neg)
+	}
+	// The update must not drop the marker, otherwise the resource is no
+	// longer owned by the graph.
+	if bs.Description != marker.Description {
+		t.Errorf("Description = %q, want %q", bs.Description, marker.Description)
+	}
+}
+
+func TestBackendsOwnership(t *testing.T) {
+	const (
+		proj    = "proj-1"
+		prefix  = "https://www.googleapis.com/compute/v1/projects/proj-1/zones/us-central1-a/networkEndpointGroups/k8s-"
+		owned1  = prefix + "neg1"
+		owned2  = prefix + "neg2"
+		ext     = "https://www.googleapis.com/compute/v1/projects/proj-1/zones/us-central1-a/instanceGroups/ig"
+		extDesc = "https://www.googleapis.com/compute/v1/projects/proj-1/zones/us-central1-a/instanceGroups/ig2"
+	)
+	key := meta.GlobalKey("bs")
+	ownership := &BackendsOwnership{GroupPrefixes: []string{prefix}, Description: "graph"}
+	makeBuilder := func(groups ...string) rnode.Builder {
+		t.Helper()
+		m := NewMutableBackendService(proj, key)
+		m.Access(func(x *compute.BackendService) {
+			x.Name = "bs"
+			x.Fingerprint = "fp"
+			for _, g := range groups {
+				x.Backends = append(x.Backends, &compute.Backend{Group: g})
+			}
+		})
+		r, err := m.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v, want nil", err)
+		}
+		b := NewBuilderWithResource(r)
+		b.SetOwnership(rnode.OwnershipManaged)
+		b.SetState(rnode.NodeExists)
+		return b
+	}
+	build := func(b rnode.Builder) rnode.Node {
+		t.Helper()
+		n, err := b.Build()
+		if err != nil {
+			t.Fatalf("Build() = %v, want nil", err)
+		}
+		return n
+	}
+
+	for _, tc := range []struct {
+		name       string
+		got        []string
+		want       []string
+		noMerge    bool
+		wantOp     rnode.Operation
+		wantGroups []string
+	}{
+		{
+			name:   "external backends are preserved",
+			got:    []string{ext, owned1},
+			want:   []string{owned1},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:       "add owned backend",
+			got:        []string{ext, owned1},
+			want:       []string{owned1, owned2},
+			wantOp:     rnode.OpUpdate,
+			wantGroups: []string{ext, owned1, owned2},
+		},
+		{
+			name:       "remove owned backend",
+			got:        []string{owned1, ext, owned2},
+			want:       []string{owned2},
+			wantOp:     rnode.OpUpdate,
+			wantGroups: []string{ext, owned2},
+		},
+		{
+			name:       "without merge the list is replaced",
+			got:        []string{ext, owned1},
+			want:       []string{owned1},
+			noMerge:    true,
+			wantOp:     rnode.OpUpdate,
+			wantGroups: []string{owned1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := build(makeBuilder(tc.got...))
+			wantb := makeBuilder(tc.want...)
+			if !tc.noMerge {
+				if err := SetBackendsOwnership(wantb, ownership); err != nil {
+					t.Fatalf("SetBackendsOwnership() = %v, want nil", err)
+				}
+			}
+			want := build(wantb)
+
+			p, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*p)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*updateAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *updateAction", actions[1])
+			}
+			if act.fingerprint != "fp" {
+				t.Errorf("fingerprint = %q, want %q", act.fingerprint, "fp")
+			}
+			ga, _ := act.resource.ToGA()
+			var groups []string
+			for _, b := range ga.Backends {
+				groups = append(groups, b.Group)
+			}
+			if diff := cmp.Diff(groups, tc.wantGroups); diff != "" {
+				t.Errorf("Backends: diff -got,+want: %s", diff)
+			}
+		})
+	}
+
+	// Backends in the graph must be owned.
+	b := makeBuilder(owned1, ext)
+	if err := SetBackendsOwnership(b, ownership); err != nil {
+		t.Fatalf("SetBackendsOwnership() = %v, want nil", err)
+	}
+	if _, err := b.Build(); err == nil {
+		t.Errorf("Build() = nil, want error for backend %q that is not owned", ext)
+	}
+	// Description marks owned backends.
+	if !ownership.owns(extDesc, "graph") || ownership.owns(extDesc, "") {
+		t.Errorf("owns(%q) by Description = wrong result", extDesc)
+	}
+}
+
+func TestUnorderedDiff(t *testing.T) {
+	const (
+		proj = "proj-1"
+		hc1  = "https://www.googleapis.com/compute/v1/projects/proj-1/global/healthChecks/hc1"
+		hc2  = "https://www.googleapis.com/compute/v1/projects/proj-1/global/healthChecks/hc2"
+		neg1 = "https://www.googleapis.com/compute/v1/projects/proj-1/zones/us-central1-a/networkEndpointGroups/neg"
+		neg2 = "https://www.googleapis.com/compute/v1/projects/proj-1/zones/us-central1-b/networkEndpointGroups/neg"
+	)
+	beta := func(s string) string { return strings.Replace(s, "/v1/", "/beta/", 1) }
+	key := meta.GlobalKey("bs")
+	makeNode := func(hcs []string, groups ...string) rnode.Node {
+		t.Helper()
+		m := NewMutableBackendService(proj, key)
+		m.Access(func(x *compute.BackendService) {
+			x.Name = "bs"
+			x.HealthChecks = hcs
+			for _, g := range groups {
+				x.Backends = append(x.Backends, &compute.Backend{Group: g, BalancingMode: "RATE"})
+			}
+		})
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	got := makeNode([]string{hc1, hc2}, neg1, neg2)
+
+	for _, tc := range []struct {
+		name   string
+		want   rnode.Node
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "reordered",
+			want:   makeNode([]string{hc2, hc1}, neg2, neg1),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "beta URLs",
+			want:   makeNode([]string{beta(hc1), beta(hc2)}, beta(neg1), beta(neg2)),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "different health check",
+			want:   makeNode([]string{hc1}, neg1, neg2),
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "different backend",
+			want:   makeNode([]string{hc1, hc2}, neg1),
+			wantOp: rnode.OpUpdate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := tc.want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+		})
+	}
+}
+
+func TestOutRefs(t *testing.T) {
+	const (
+		proj = "proj-1"
+		hc   = "https://www.googleapis.com/compute/v1/projects/proj-1/global/healthChecks/hc"
+		neg  = "https://www.googleapis.com/compute/v1/projects/proj-1/zones/us-central1-a/networkEndpointGroups/neg"
+		sp   = "https://www.googleapis.com/compute/v1/projects/proj-1/global/securityPolicies/sp"
+		edge = "https://www.googleapis.com/compute/v1/projects/proj-1/global/securityPolicies/edge"
+	)
+	for _, tc := range []struct {
+		name    string
+		f       func(x *compute.BackendService)
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "no refs",
+			f:    func(x *compute.BackendService) {},
+		},
+		{
+			name: "all refs",
+			f: func(x *compute.BackendService) {
+				x.Backends = []*compute.Backend{{Group: neg}}
+				x.HealthChecks = []string{hc}
+				x.SecurityPolicy = sp
+				x.EdgeSecurityPolicy = edge
+			},
+			want: []string{
+				"*.Backends!0*.Group=compute/networkEndpointGroups:proj-1/us-central1-a/neg",
+				"*.HealthChecks!0=compute/healthChecks:proj-1/hc",
+				"*.SecurityPolicy=compute/securityPolicies:proj-1/sp",
+				"*.EdgeSecurityPolicy=compute/securityPolicies:proj-1/edge",
+			},
+		},
+		{
+			name:    "invalid URL",
+			f:       func(x *compute.BackendService) { x.HealthChecks = []string{"invalid"} },
+			wantErr: true,
+		},
+		{
+			name:    "wrong resource type",
+			f:       func(x *compute.BackendService) { x.SecurityPolicy = hc },
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMutableBackendService(proj, meta.GlobalKey("bs"))
+			m.Access(func(x *compute.BackendService) {
+				x.Name = "bs"
+				tc.f(x)
+			})
+			// The field traits are incomplete so Freeze() may return an
+			// error; the resource is still usable.
+			r, _ := m.Freeze()
+			refs, err := NewBuilderWithResource(r).OutRefs()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("OutRefs() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			var got []string
+			for _, ref := range refs {
+				got = append(got, ref.Path.String()+"="+ref.To.String())
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("OutRefs() diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
@@ -78,6 +78,23 @@ func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.BackendServi
 	}
 }
 
+func (*ops) PatchFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
+	return &rnode.UpdateFuncs[compute.BackendService, alpha.BackendService, beta.BackendService]{
+		GA: rnode.UpdateFuncsByScope[compute.BackendService]{
+			Global:   gcp.BackendServices().Patch,
+			Regional: gcp.RegionBackendServices().Patch,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.BackendService]{
+			Global:   gcp.AlphaBackendServices().Patch,
+			Regional: gcp.AlphaRegionBackendServices().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.BackendService]{
+			Global:   gcp.BetaBackendServices().Patch,
+			Regional: gcp.BetaRegionBackendServices().Patch,
+		},
+	}
+}
+
 func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.BackendService, alpha.BackendService, beta.BackendService] {
 	return &rnode.DeleteFuncs[compute.BackendService, alpha.BackendService, beta.BackendService]{
 		GA: rnode.DeleteFuncsByScope[compute.BackendService]{
@@ -17,6 +17,8 @@ limitations under the License.
 package backendservice
 
 import (
+	"fmt"
+
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -36,18 +38,91 @@ func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 
 	// [Output Only]
 	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("EdgeSecurityPolicy"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("SecurityPolicy"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
 
 	dt.OutputOnly(api.Path{}.Pointer().Field("Iap").Field("Oauth2ClientSecretSha256"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("CdnPolicy").Field("SignedUrlKeyNames"))
 
+	// The security policies are [Output Only] for insert() and are attached
+	// with setSecurityPolicy() and setEdgeSecurityPolicy().
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("EdgeSecurityPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("SecurityPolicy"))
+
+	// References are compared by the resource, ignoring the API version of
+	// the URL. The order of .HealthChecks and .Backends is not significant;
+	// .Backends are matched by .Group so that only the changed fields of a
+	// backend are in the diff.
+	dt.Unordered(api.Path{}.Pointer().Field("HealthChecks"))
+	dt.Compare(api.Path{}.Pointer().Field("HealthChecks").AnySliceIndex(), api.ResourceURLComparator)
+	dt.UnorderedByKey(api.Path{}.Pointer().Field("Backends"), "Group")
+	dt.Compare(api.Path{}.Pointer().Field("Backends").AnySliceIndex().Pointer().Field("Group"), api.ResourceURLComparator)
+	dt.Compare(api.Path{}.Pointer().Field("EdgeSecurityPolicy"), api.ResourceURLComparator)
+	dt.Compare(api.Path{}.Pointer().Field("SecurityPolicy"), api.ResourceURLComparator)
+
+	// Fields set by the server when they are omitted in the wanted resource.
+	dt.ServerDefault(api.Path{}.Pointer().Field("SessionAffinity"), "NONE")
+	dt.ServerDefault(api.Path{}.Pointer().Field("TimeoutSec"), int64(30))
+	dt.DefaultedIfUnset(api.Path{}.Pointer().Field("ConnectionDraining"))
+
 	// TODO: finish me
 	// TODO: handle alpha/beta
 
+	// References to other resources.
+	dt.Reference(api.Path{}.Pointer().Field("Backends").AnySliceIndex().Pointer().Field("Group"), "")
+	dt.Reference(api.Path{}.Pointer().Field("HealthChecks").AnySliceIndex(), "healthChecks")
+	dt.Reference(api.Path{}.Pointer().Field("SecurityPolicy"), "securityPolicies")
+	dt.Reference(api.Path{}.Pointer().Field("EdgeSecurityPolicy"), "securityPolicies")
+
 	return dt
 }
+
+// Validate implements api.Validator.
+func (*typeTrait) Validate(r BackendService) []error {
+	// Check the version of the resource as a conversion to another version
+	// may fail for unrelated fields.
+	var groups []string
+	switch r.Version() {
+	case meta.VersionAlpha:
+		x, err := r.ToAlpha()
+		if err != nil {
+			return []error{err}
+		}
+		for _, b := range x.Backends {
+			groups = append(groups, backendGroup(b, func(b *alpha.Backend) string { return b.Group }))
+		}
+	case meta.VersionBeta:
+		x, err := r.ToBeta()
+		if err != nil {
+			return []error{err}
+		}
+		for _, b := range x.Backends {
+			groups = append(groups, backendGroup(b, func(b *beta.Backend) string { return b.Group }))
+		}
+	default:
+		x, err := r.ToGA()
+		if err != nil {
+			return []error{err}
+		}
+		for _, b := range x.Backends {
+			groups = append(groups, backendGroup(b, func(b *compute.Backend) string { return b.Group }))
+		}
+	}
+	var errs []error
+	for i, group := range groups {
+		if group == "" {
+			errs = append(errs, fmt.Errorf(".Backends[%d].Group is not set", i))
+		}
+	}
+	return errs
+}
+
+// backendGroup returns the .Group of b or "" if b is nil.
+func backendGroup[B any](b *B, group func(*B) string) string {
+	if b == nil {
+		return ""
+	}
+	return group(b)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 )
 
@@ -43,9 +44,32 @@ type Builder interface {
 	// SetResource to a new value.
 	SetResource(UntypedResource) error
 
-	// Version of the resource. This is used when fetching the
-	// resource from the Cloud.
+	// DiffPolicy overrides set for this node. nil means the default
+	// policy for the node type is used.
+	DiffPolicy() *DiffPolicy
+	// SetDiffPolicy overrides the default DiffPolicy of the node type
+	// for this node. Rules in p take precedence over the defaults.
+	SetDiffPolicy(p *DiffPolicy)
+	// IgnoreDiff adds field paths (and their sub-fields) to ignore when
+	// the node is diffed, e.g. fields populated by the server or managed
+	// by another controller. These take precedence over the DiffPolicy.
+	IgnoreDiff(paths ...api.Path)
+	// IgnoredDiffs are the paths added with IgnoreDiff().
+	IgnoredDiffs() []api.Path
+
+	// Version of the API used for the resource. This is used when
+	// fetching the resource from the Cloud, for the Diff and for the
+	// actions.
 	Version() meta.Version
+	// SetVersion of the API to use for the resource, e.g. to manage a
+	// field that is only available in Beta. If this is not set, the
+	// version of the Resource is used.
+	SetVersion(ver meta.Version)
+
+	// AddPrecondition to check before the planned operation is executed.
+	AddPrecondition(p Precondition)
+	// Preconditions added with AddPrecondition().
+	Preconditions() []Precondition
 
 	// OutRefs parses the outgoing references of the Resource.
 	OutRefs() ([]ResourceRef, error)
@@ -64,16 +88,24 @@ type Builder interface {
 	// been computed from a complete set of nodes in the graph
 	// Builder.
 	inRefs() []ResourceRef
+	// versionSet is true if SetVersion() was called.
+	versionSet() bool
 }
 
 // BuilderBase implements the non-type specific fields.
 type BuilderBase struct {
-	id        *cloud.ResourceID
-	state     NodeState
-	ownership OwnershipStatus
-	version   meta.Version
+	id         *cloud.ResourceID
+	state      NodeState
+	ownership  OwnershipStatus
+	version    meta.Version
+	diffPolicy *DiffPolicy
+	ignored    []api.Path
+
+	preconditions []Precondition
 
 	curInRefs []ResourceRef
+	// explicitVersion is true if the version was given by SetVersion().
+	explicitVersion bool
 }
 
 func (b *BuilderBase) ID() *cloud.ResourceID           { return b.id }
@@ -82,9 +114,24 @@ func (b *BuilderBase) SetState(state NodeState)        { b.state = state }
 func (b *BuilderBase) Ownership() OwnershipStatus      { return b.ownership }
 func (b *BuilderBase) SetOwnership(os OwnershipStatus) { b.ownership = os }
 func (b *BuilderBase) Version() meta.Version           { return b.version }
+func (b *BuilderBase) DiffPolicy() *DiffPolicy         { return b.diffPolicy }
+func (b *BuilderBase) SetDiffPolicy(p *DiffPolicy)     { b.diffPolicy = p }
+func (b *BuilderBase) IgnoreDiff(paths ...api.Path)    { b.ignored = append(b.ignored, paths...) }
+func (b *BuilderBase) IgnoredDiffs() []api.Path        { return b.ignored }
+
+func (b *BuilderBase) AddPrecondition(p Precondition) {
+	b.preconditions = append(b.preconditions, p)
+}
+func (b *BuilderBase) Preconditions() []Precondition { return b.preconditions }
+
+func (b *BuilderBase) SetVersion(ver meta.Version) {
+	b.version = ver
+	b.explicitVersion = true
+}
 
 func (b *BuilderBase) AddInRef(ref ResourceRef) { b.curInRefs = append(b.curInRefs, ref) }
 func (b *BuilderBase) inRefs() []ResourceRef    { return b.curInRefs }
+func (b *BuilderBase) versionSet() bool         { return b.explicitVersion }
 
 // Defaults sets the default values for a empty Builder node.
 func (b *BuilderBase) Defaults(id *cloud.ResourceID) {
@@ -0,0 +1,279 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+)
+
+// FieldAction is what happens when a field differs between the got and want
+// resource.
+type FieldAction string
+
+var (
+	// FieldRecreate means the resource must be deleted and created again.
+	FieldRecreate FieldAction = "Recreate"
+	// FieldUpdate means the field is changed in place by the update Actions
+	// of the node.
+	FieldUpdate FieldAction = "Update"
+	// FieldForbidden means the field must not be changed. Diff() returns an
+	// error.
+	FieldForbidden FieldAction = "Forbidden"
+	// FieldIgnore means changes to the field are ignored.
+	FieldIgnore FieldAction = "Ignore"
+)
+
+type diffPolicyRule struct {
+	path   api.Path
+	action FieldAction
+}
+
+// DiffPolicy is a declarative table of field path to FieldAction that
+// determines whether a diff is planned as an update or a recreate of the
+// resource. A rule applies to the field and all of its sub-fields; if more
+// than one rule matches, the longest path wins. Paths may contain wildcards
+// (see api.Path.HasPrefix).
+//
+// Each node type has a default DiffPolicy that callers can override for a
+// specific node with Builder.SetDiffPolicy().
+type DiffPolicy struct {
+	def   FieldAction
+	rules []diffPolicyRule
+	// base is consulted for paths that are not matched by the rules.
+	base *DiffPolicy
+	// ignoreItems are predicates for diff items that are ignored regardless
+	// of the rules.
+	ignoreItems []func(api.DiffItem) bool
+}
+
+// NewDiffPolicy returns a policy where fields that are not in the table
+// have the def action. def may be empty for a policy that is only used to
+// Override() another.
+func NewDiffPolicy(def FieldAction) *DiffPolicy {
+	return &DiffPolicy{def: def}
+}
+
+// Set the action for the field at path. Returns the policy for chaining.
+func (p *DiffPolicy) Set(path api.Path, action FieldAction) *DiffPolicy {
+	for i := range p.rules {
+		if p.rules[i].path.Equal(path) {
+			p.rules[i].action = action
+			return p
+		}
+	}
+	p.rules = append(p.rules, diffPolicyRule{path: path, action: action})
+	return p
+}
+
+// SetFields is a convenience for Set() on the top-level fields of the
+// resource, e.g. SetFields(FieldUpdate, "Description", "Labels").
+func (p *DiffPolicy) SetFields(action FieldAction, fields ...string) *DiffPolicy {
+	for _, f := range fields {
+		p.Set(api.Path{}.Pointer().Field(f), action)
+	}
+	return p
+}
+
+// IgnoreItems ignores the diff items for which f returns true, e.g. a
+// change that is only due to a value stamped by the server. Returns the
+// policy for chaining.
+func (p *DiffPolicy) IgnoreItems(f func(api.DiffItem) bool) *DiffPolicy {
+	p.ignoreItems = append(p.ignoreItems, f)
+	return p
+}
+
+// Override returns a policy where the rules (and default, if set) in o take
+// precedence over the ones in p. p and o are not modified. o may be nil.
+func (p *DiffPolicy) Override(o *DiffPolicy) *DiffPolicy {
+	if o == nil {
+		return p
+	}
+	ret := &DiffPolicy{
+		def:         o.def,
+		rules:       append([]diffPolicyRule(nil), o.rules...),
+		base:        p,
+		ignoreItems: append([]func(api.DiffItem) bool(nil), o.ignoreItems...),
+	}
+	if o.base != nil {
+		// Flatten o on top of p.
+		ret.base = p.Override(o.base)
+	}
+	return ret
+}
+
+func (p *DiffPolicy) match(path api.Path) (FieldAction, bool) {
+	var (
+		ret     FieldAction
+		bestLen = -1
+	)
+	for _, r := range p.rules {
+		if len(r.path) > bestLen && path.HasPrefix(r.path) {
+			ret, bestLen = r.action, len(r.path)
+		}
+	}
+	if bestLen >= 0 {
+		return ret, true
+	}
+	if p.base != nil {
+		return p.base.match(path)
+	}
+	return "", false
+}
+
+func (p *DiffPolicy) defaultAction() FieldAction {
+	for x := p; x != nil; x = x.base {
+		if x.def != "" {
+			return x.def
+		}
+	}
+	return FieldRecreate
+}
+
+func (p *DiffPolicy) ignoreItem(item api.DiffItem) bool {
+	for x := p; x != nil; x = x.base {
+		for _, f := range x.ignoreItems {
+			if f(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Action for a change to the field at path.
+func (p *DiffPolicy) Action(path api.Path) FieldAction {
+	if a, ok := p.match(path); ok {
+		return a
+	}
+	return p.defaultAction()
+}
+
+// DiffPolicyResult is the diff classified by a DiffPolicy.
+type DiffPolicyResult struct {
+	// Diff without the ignored items.
+	Diff *api.DiffResult
+	// Update are the items that are changed in place.
+	Update []api.DiffItem
+	// Recreate are the items that require the resource to be recreated.
+	Recreate []api.DiffItem
+	// Ignored items.
+	Ignored []api.DiffItem
+}
+
+// Apply the policy to the diff. Returns an error if a FieldForbidden field
+// has changed. Changes to immutable fields (see api.FieldTraits.Immutable())
+// are recreated even if the policy says FieldUpdate.
+func (p *DiffPolicy) Apply(diff *api.DiffResult) (*DiffPolicyResult, error) {
+	ret := &DiffPolicyResult{Diff: &api.DiffResult{}}
+	var forbidden []string
+	for _, item := range diff.Items {
+		if p.ignoreItem(item) {
+			ret.Ignored = append(ret.Ignored, item)
+			continue
+		}
+		action := p.Action(item.Path)
+		if item.Immutable && action == FieldUpdate {
+			// The API does not allow the field to be changed.
+			action = FieldRecreate
+		}
+		switch action {
+		case FieldIgnore:
+			ret.Ignored = append(ret.Ignored, item)
+			continue
+		case FieldForbidden:
+			forbidden = append(forbidden, diffItemString(item))
+		case FieldUpdate:
+			ret.Update = append(ret.Update, item)
+		default:
+			ret.Recreate = append(ret.Recreate, item)
+		}
+		ret.Diff.Items = append(ret.Diff.Items, item)
+	}
+	if len(forbidden) > 0 {
+		return nil, fmt.Errorf("changes to fields are forbidden: %s", strings.Join(forbidden, ", "))
+	}
+	return ret, nil
+}
+
+// PlanDetails returns the plan for the result: OpNothing if nothing changed
+// (other than ignored fields), OpRecreate if any of the items require a
+// recreate and OpUpdate otherwise. name is the resource type used in the Why
+// message, e.g. "BackendService".
+func (r *DiffPolicyResult) PlanDetails(name string) *PlanDetails {
+	switch {
+	case !r.Diff.HasDiff() && len(r.Ignored) > 0:
+		return &PlanDetails{
+			Operation: OpNothing,
+			Why:       "No diff between got and want (ignored: " + diffItemsString(r.Ignored) + ")",
+		}
+	case !r.Diff.HasDiff():
+		return &PlanDetails{
+			Operation: OpNothing,
+			Why:       "No diff between got and want",
+		}
+	case len(r.Recreate) > 0:
+		return &PlanDetails{
+			Operation: OpRecreate,
+			Why:       fmt.Sprintf("%s needs to be recreated: %s", name, diffItemsString(r.Recreate)),
+			Diff:      r.Diff,
+		}
+	}
+	return &PlanDetails{
+		Operation: OpUpdate,
+		Why:       fmt.Sprintf("%s needs to be updated: %s", name, diffItemsString(r.Update)),
+		Diff:      r.Diff,
+	}
+}
+
+// PlanDiff applies the policy to the diff and returns the plan. See
+// DiffPolicyResult.PlanDetails().
+func (p *DiffPolicy) PlanDiff(name string, diff *api.DiffResult) (*PlanDetails, error) {
+	r, err := p.Apply(diff)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return r.PlanDetails(name), nil
+}
+
+func diffItemString(item api.DiffItem) string {
+	return fmt.Sprintf("%s (%s -> %s)", item.Path, diffValueString(item.A), diffValueString(item.B))
+}
+
+// diffValueString formats composite values as JSON so that the contents are
+// printed instead of pointer addresses.
+func diffValueString(v any) string {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Struct:
+		if b, err := json.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func diffItemsString(items []api.DiffItem) string {
+	var s []string
+	for _, item := range items {
+		s = append(s, diffItemString(item))
+	}
+	return strings.Join(s, ", ")
+}
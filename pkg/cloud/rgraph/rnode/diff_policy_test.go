@@ -0,0 +1,161 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+)
+
+func TestDiffPolicyAction(t *testing.T) {
+	field := func(f ...string) api.Path {
+		p := api.Path{}.Pointer()
+		for _, x := range f {
+			p = p.Field(x)
+		}
+		return p
+	}
+
+	p := NewDiffPolicy(FieldRecreate).
+		SetFields(FieldUpdate, "A", "B").
+		Set(field("B", "C"), FieldForbidden).
+		SetFields(FieldIgnore, "D")
+	o := NewDiffPolicy("").
+		SetFields(FieldRecreate, "A").
+		Set(field("B", "C", "E"), FieldUpdate)
+
+	for _, tc := range []struct {
+		name   string
+		policy *DiffPolicy
+		path   api.Path
+		want   FieldAction
+	}{
+		{name: "default", policy: p, path: field("X"), want: FieldRecreate},
+		{name: "field", policy: p, path: field("A"), want: FieldUpdate},
+		{name: "sub-field", policy: p, path: field("A", "X"), want: FieldUpdate},
+		{name: "longest match", policy: p, path: field("B", "C", "X"), want: FieldForbidden},
+		{name: "shorter match", policy: p, path: field("B", "X"), want: FieldUpdate},
+		{name: "ignore", policy: p, path: field("D"), want: FieldIgnore},
+		{name: "override", policy: p.Override(o), path: field("A"), want: FieldRecreate},
+		{name: "override longest match", policy: p.Override(o), path: field("B", "C", "E"), want: FieldUpdate},
+		{name: "override falls back", policy: p.Override(o), path: field("B", "C"), want: FieldForbidden},
+		{name: "override default", policy: p.Override(o), path: field("X"), want: FieldRecreate},
+		{name: "override new default", policy: p.Override(NewDiffPolicy(FieldIgnore)), path: field("X"), want: FieldIgnore},
+		{name: "override nil", policy: p.Override(nil), path: field("A"), want: FieldUpdate},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.policy.Action(tc.path); got != tc.want {
+				t.Errorf("Action(%v) = %q, want %q", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiffPolicyPlanDiff(t *testing.T) {
+	item := func(f string) api.DiffItem {
+		return api.DiffItem{State: api.DiffItemDifferent, Path: api.Path{}.Pointer().Field(f), A: "a", B: "b"}
+	}
+	immutable := func(f string) api.DiffItem {
+		ret := item(f)
+		ret.Immutable = true
+		return ret
+	}
+	p := NewDiffPolicy(FieldRecreate).
+		SetFields(FieldUpdate, "Update").
+		SetFields(FieldForbidden, "Forbidden").
+		SetFields(FieldIgnore, "Ignore")
+
+	for _, tc := range []struct {
+		name    string
+		items   []api.DiffItem
+		wantOp  Operation
+		wantErr bool
+	}{
+		{name: "no diff", wantOp: OpNothing},
+		{name: "ignored", items: []api.DiffItem{item("Ignore")}, wantOp: OpNothing},
+		{name: "update", items: []api.DiffItem{item("Update"), item("Ignore")}, wantOp: OpUpdate},
+		{name: "recreate", items: []api.DiffItem{item("Update"), item("Other")}, wantOp: OpRecreate},
+		{name: "forbidden", items: []api.DiffItem{item("Update"), item("Forbidden")}, wantErr: true},
+		{name: "immutable", items: []api.DiffItem{immutable("Update")}, wantOp: OpRecreate},
+		{name: "immutable ignored", items: []api.DiffItem{immutable("Ignore")}, wantOp: OpNothing},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pd, err := p.PlanDiff("Fake", &api.DiffResult{Items: tc.items})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("PlanDiff() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if pd.Operation != tc.wantOp {
+				t.Errorf("PlanDiff() = %v, want %v (%s)", pd.Operation, tc.wantOp, pd.Why)
+			}
+			if pd.Diff != nil {
+				for _, item := range pd.Diff.Items {
+					if item.Path.Equal(api.Path{}.Pointer().Field("Ignore")) {
+						t.Errorf("PlanDiff().Diff contains ignored item %v", item)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEffectiveDiffPolicy(t *testing.T) {
+	updatePath := api.Path{}.Pointer().Field("Update")
+	ignorePath := api.Path{}.Pointer().Field("Update").Field("Ignore")
+	defaults := NewDiffPolicy(FieldRecreate).Set(updatePath, FieldUpdate)
+
+	b := &BuilderBase{}
+	b.SetDiffPolicy(NewDiffPolicy("").Set(ignorePath, FieldForbidden))
+	b.IgnoreDiff(ignorePath)
+
+	n := &NodeBase{diffPolicy: b.DiffPolicy(), ignored: b.IgnoredDiffs()}
+	p := n.EffectiveDiffPolicy(defaults)
+
+	for _, tc := range []struct {
+		path api.Path
+		want FieldAction
+	}{
+		{path: api.Path{}.Pointer().Field("Other"), want: FieldRecreate},
+		{path: updatePath, want: FieldUpdate},
+		{path: ignorePath, want: FieldIgnore},
+		{path: ignorePath.Field("Sub"), want: FieldIgnore},
+	} {
+		if got := p.Action(tc.path); got != tc.want {
+			t.Errorf("Action(%v) = %q, want %q", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestDiffPolicyIgnoreItems(t *testing.T) {
+	path := api.Path{}.Pointer().Field("Description")
+	p := NewDiffPolicy(FieldRecreate).Override(NewDiffPolicy("").IgnoreItems(func(item api.DiffItem) bool {
+		return item.A == "stamp"
+	}))
+	r, err := p.Apply(&api.DiffResult{Items: []api.DiffItem{
+		{Path: path, A: "stamp", B: ""},
+		{Path: path, A: "a", B: "b"},
+	}})
+	if err != nil {
+		t.Fatalf("Apply() = %v, want nil", err)
+	}
+	if len(r.Ignored) != 1 || len(r.Recreate) != 1 {
+		t.Errorf("Apply() = %+v, want 1 ignored and 1 recreate item", r)
+	}
+}
@@ -23,6 +23,9 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 )
 
+// diffPolicy for Fake. All fields can be updated.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldUpdate)
+
 type fakeNode struct {
 	rnode.NodeBase
 	resource Fake
@@ -42,18 +45,7 @@ func (n *fakeNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
 	if err != nil {
 		return nil, fmt.Errorf("fakeNode %s: Diff %w", n.ID(), err)
 	}
-	if diff.HasDiff() {
-		return &rnode.PlanDetails{
-			Operation: rnode.OpUpdate,
-			Why:       "Fake has diff",
-			Diff:      diff,
-		}, nil
-	}
-
-	return &rnode.PlanDetails{
-		Operation: rnode.OpNothing,
-		Why:       "No diff between got and want",
-	}, nil
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("Fake", diff)
 }
 
 func (n *fakeNode) Actions(got rnode.Node) ([]exec.Action, error) {
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+)
+
+// FieldMask returns the sorted list of top-level fields (e.g. "Backends")
+// that are changed in the diff. The mask is used with patch() to limit the
+// update to the changed fields.
+func FieldMask(diff *api.DiffResult) []string {
+	if diff == nil {
+		return nil
+	}
+	fields := map[string]bool{}
+	for _, item := range diff.Items {
+		// Paths of the resource fields are of the form "*.Field...".
+		p := item.Path
+		if len(p) < 2 || p[0] != "*" || !strings.HasPrefix(p[1], ".") {
+			continue
+		}
+		fields[p[1][1:]] = true
+	}
+	var ret []string
+	for f := range fields {
+		ret = append(ret, f)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// maskFields returns a copy of x with only the fields in mask set. .Name and
+// .Fingerprint are always copied. The masked fields are sent even if they
+// are empty so that patch() clears them; nil pointers are sent as null.
+func maskFields[T any](x *T, mask []string) (*T, error) {
+	src := reflect.ValueOf(x).Elem()
+	if src.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("maskFields: invalid type %T", x)
+	}
+	ret := new(T)
+	dest := reflect.ValueOf(ret).Elem()
+
+	for _, name := range []string{"Name", "Fingerprint"} {
+		if fv := src.FieldByName(name); fv.IsValid() {
+			dest.FieldByName(name).Set(fv)
+		}
+	}
+	var forceSend, null []string
+	for _, name := range mask {
+		fv := src.FieldByName(name)
+		if !fv.IsValid() {
+			return nil, fmt.Errorf("maskFields: %T has no field %q", x, name)
+		}
+		dest.FieldByName(name).Set(fv)
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			null = append(null, name)
+		} else {
+			forceSend = append(forceSend, name)
+		}
+	}
+	for name, fields := range map[string][]string{"ForceSendFields": forceSend, "NullFields": null} {
+		if len(fields) == 0 {
+			continue
+		}
+		fv := dest.FieldByName(name)
+		if !fv.IsValid() || fv.Type() != reflect.TypeOf([]string{}) {
+			return nil, fmt.Errorf("maskFields: %T has no %s", x, name)
+		}
+		fv.Set(reflect.ValueOf(fields))
+	}
+	return ret, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestFieldMask(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		diff *api.DiffResult
+		want []string
+	}{
+		{name: "nil"},
+		{name: "empty", diff: &api.DiffResult{}},
+		{
+			name: "fields",
+			diff: &api.DiffResult{Items: []api.DiffItem{
+				{Path: api.Path{}.Pointer().Field("TimeoutSec")},
+				{Path: api.Path{}.Pointer().Field("Backends").Index(0).Pointer().Field("Group")},
+				{Path: api.Path{}.Pointer().Field("Backends").Index(1)},
+				// Invalid path.
+				{Path: api.Path{}.Field("X")},
+			}},
+			want: []string{"Backends", "TimeoutSec"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FieldMask(tc.diff)
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("FieldMask() = %v; -got,+want: %s", got, diff)
+			}
+		})
+	}
+}
+
+func TestMaskFields(t *testing.T) {
+	x := &compute.BackendService{
+		Name:        "bs",
+		Fingerprint: "fp",
+		Description: "abc",
+		Backends:    []*compute.Backend{{Group: "g"}},
+		TimeoutSec:  10,
+	}
+	for _, tc := range []struct {
+		name    string
+		mask    []string
+		want    *compute.BackendService
+		wantErr bool
+	}{
+		{
+			name: "fields",
+			mask: []string{"Backends", "Port"},
+			want: &compute.BackendService{
+				Name:            "bs",
+				Fingerprint:     "fp",
+				Backends:        []*compute.Backend{{Group: "g"}},
+				ForceSendFields: []string{"Backends", "Port"},
+			},
+		},
+		{
+			name: "nil pointer",
+			mask: []string{"ConsistentHash", "TimeoutSec"},
+			want: &compute.BackendService{
+				Name:            "bs",
+				Fingerprint:     "fp",
+				TimeoutSec:      10,
+				ForceSendFields: []string{"TimeoutSec"},
+				NullFields:      []string{"ConsistentHash"},
+			},
+		},
+		{
+			name:    "invalid field",
+			mask:    []string{"NoSuchField"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := maskFields(x, tc.mask)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("maskFields() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("maskFields() = %+v; -got,+want: %s", got, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// FieldSetter changes a field in place for resources that do not have a
+// generic Update() or Patch(), e.g. ForwardingRules.SetTarget().
+//
+// A is the update Action that is filled in by the setter and R is what the
+// setter reads the got and want values from (e.g. the GA resource).
+type FieldSetter[A any, R any] struct {
+	// Path of the field. Changes to sub-fields of Path (e.g. elements of a
+	// list) are handled by the same setter.
+	Path api.Path
+	// Scope restricts the setter to resources with the given key type.
+	// Empty means all scopes.
+	Scope meta.KeyType
+	// Set adds the change of the field to act.
+	Set func(act A, got, want R) error
+}
+
+// FieldSetters is the table of the fields that can be changed in place.
+// Changes to fields that are not in the table require the resource to be
+// recreated.
+type FieldSetters[A any, R any] []FieldSetter[A, R]
+
+func (s FieldSetters[A, R]) find(keyType meta.KeyType, path api.Path) int {
+	for i, fs := range s {
+		if fs.Scope != "" && fs.Scope != keyType {
+			continue
+		}
+		if path.HasPrefix(fs.Path) {
+			return i
+		}
+	}
+	return -1
+}
+
+// PlanDetails is DiffPolicyResult.PlanDetails() with the additional check
+// that all of the FieldUpdate items have a setter. The DiffPolicy of a node
+// can be overridden to update a field that has no setter; the resource is
+// recreated in this case.
+func (s FieldSetters[A, R]) PlanDetails(name string, keyType meta.KeyType, r *DiffPolicyResult) *PlanDetails {
+	if len(r.Recreate) > 0 || !r.Diff.HasDiff() {
+		return r.PlanDetails(name)
+	}
+	var noSetter []api.DiffItem
+	for _, item := range r.Update {
+		if s.find(keyType, item.Path) < 0 {
+			noSetter = append(noSetter, item)
+		}
+	}
+	if len(noSetter) > 0 {
+		return &PlanDetails{
+			Operation: OpRecreate,
+			Why:       fmt.Sprintf("%s needs to be recreated: %s", name, diffItemsString(noSetter)),
+			Diff:      r.Diff,
+		}
+	}
+	return r.PlanDetails(name)
+}
+
+// Apply calls the setters for the items in the diff. Each setter is called
+// once, even if there are multiple changes to the field. Returns the
+// descriptions of the changes or an error if a field cannot be changed in
+// place.
+func (s FieldSetters[A, R]) Apply(keyType meta.KeyType, diff *api.DiffResult, act A, got, want R) ([]string, error) {
+	var (
+		changes []string
+		called  = map[int]bool{}
+	)
+	for _, item := range diff.Items {
+		i := s.find(keyType, item.Path)
+		if i < 0 {
+			return nil, fmt.Errorf("field %s cannot be updated in place", item.Path)
+		}
+		changes = append(changes, diffItemString(item))
+		if called[i] {
+			continue
+		}
+		called[i] = true
+		if err := s[i].Set(act, got, want); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+func TestFieldSetters(t *testing.T) {
+	type action struct{ set map[string]int }
+	setter := func(name string) func(act *action, _, _ string) error {
+		return func(act *action, _, _ string) error {
+			act.set[name]++
+			return nil
+		}
+	}
+	setters := FieldSetters[*action, string]{
+		{Path: api.Path{}.Pointer().Field("A"), Set: setter("A")},
+		{Path: api.Path{}.Pointer().Field("B"), Scope: meta.Global, Set: setter("B")},
+	}
+	item := func(p api.Path) api.DiffItem {
+		return api.DiffItem{State: api.DiffItemDifferent, Path: p, A: "x", B: "y"}
+	}
+	policy := NewDiffPolicy(FieldUpdate)
+
+	for _, tc := range []struct {
+		name    string
+		keyType meta.KeyType
+		items   []api.DiffItem
+		wantOp  Operation
+		wantSet map[string]int
+	}{
+		{
+			name:    "no diff",
+			keyType: meta.Global,
+			wantOp:  OpNothing,
+		},
+		{
+			name:    "setters",
+			keyType: meta.Global,
+			items: []api.DiffItem{
+				item(api.Path{}.Pointer().Field("A").Index(0)),
+				item(api.Path{}.Pointer().Field("A").Index(1)),
+				item(api.Path{}.Pointer().Field("B")),
+			},
+			wantOp:  OpUpdate,
+			wantSet: map[string]int{"A": 1, "B": 1},
+		},
+		{
+			name:    "no setter for field",
+			keyType: meta.Global,
+			items:   []api.DiffItem{item(api.Path{}.Pointer().Field("A")), item(api.Path{}.Pointer().Field("C"))},
+			wantOp:  OpRecreate,
+		},
+		{
+			name:    "no setter in scope",
+			keyType: meta.Regional,
+			items:   []api.DiffItem{item(api.Path{}.Pointer().Field("B"))},
+			wantOp:  OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := &api.DiffResult{Items: tc.items}
+			r, err := policy.Apply(diff)
+			if err != nil {
+				t.Fatalf("Apply() = %v", err)
+			}
+			pd := setters.PlanDetails("Res", tc.keyType, r)
+			if pd.Operation != tc.wantOp {
+				t.Errorf("PlanDetails().Operation = %s, want %s (why: %s)", pd.Operation, tc.wantOp, pd.Why)
+			}
+
+			act := &action{set: map[string]int{}}
+			changes, err := setters.Apply(tc.keyType, diff, act, "got", "want")
+			if gotErr, wantErr := err != nil, tc.wantOp == OpRecreate; gotErr != wantErr {
+				t.Fatalf("Apply() = %v; gotErr = %t, want %t", err, gotErr, wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(changes) != len(tc.items) {
+				t.Errorf("Apply() = %v, want %d changes", changes, len(tc.items))
+			}
+			for k, v := range tc.wantSet {
+				if act.set[k] != v {
+					t.Errorf("setter %s called %d times, want %d", k, act.set[k], v)
+				}
+			}
+		})
+	}
+}
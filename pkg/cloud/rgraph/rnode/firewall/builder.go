@@ -1,5 +1,5 @@
 /*
-Copyright 2023 Google LLC
+Copyright 2024 Google LLC
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package healthcheck
+package firewall
 
 import (
 	"context"
@@ -33,7 +33,7 @@ func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	return b
 }
 
-func NewBuilderWithResource(r HealthCheck) rnode.Builder {
+func NewBuilderWithResource(r Firewall) rnode.Builder {
 	b := &builder{resource: r}
 	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
 	return b
@@ -41,7 +41,7 @@ func NewBuilderWithResource(r HealthCheck) rnode.Builder {
 
 type builder struct {
 	rnode.BuilderBase
-	resource HealthCheck
+	resource Firewall
 }
 
 // builder implements node.Builder.
@@ -50,30 +50,28 @@ var _ rnode.Builder = (*builder)(nil)
 func (b *builder) Resource() rnode.UntypedResource { return b.resource }
 
 func (b *builder) SetResource(u rnode.UntypedResource) error {
-	r, ok := u.(HealthCheck)
+	r, ok := u.(Firewall)
 	if !ok {
-		return fmt.Errorf("XXX")
+		return fmt.Errorf("cannot set Firewall from untyped resource, %T", u)
 	}
 	b.resource = r
 	return nil
 }
 
 func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
-	return rnode.GenericGet[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck](
-		ctx, gcp, "HealthCheck", &healthCheckOps{}, &typeTrait{}, b)
+	return rnode.GenericGet[compute.Firewall, alpha.Firewall, beta.Firewall](ctx, gcp, "Firewall", &ops{}, &typeTrait{}, b)
 }
 
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
-	// No references.
-	return nil, nil
+	return rnode.OutRefsFromTraits[compute.Firewall, alpha.Firewall, beta.Firewall](b.resource, &typeTrait{})
 }
 
 func (b *builder) Build() (rnode.Node, error) {
 	if b.State() == rnode.NodeExists && b.resource == nil {
-		return nil, fmt.Errorf("HealthCheck %s resource is nil with state %s", b.ID(), b.State())
+		return nil, fmt.Errorf("Firewall %s resource is nil with state %s", b.ID(), b.State())
 	}
 
-	ret := &healthCheckNode{resource: b.resource}
+	ret := &firewallNode{resource: b.resource}
 	if err := ret.InitFromBuilder(b); err != nil {
 		return nil, err
 	}
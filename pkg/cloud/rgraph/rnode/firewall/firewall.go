@@ -1,5 +1,5 @@
 /*
-Copyright 2023 Google LLC
+Copyright 2024 Google LLC
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,13 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package healthcheck
+package firewall
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
-
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
@@ -28,22 +27,22 @@ import (
 
 func ID(project string, key *meta.Key) *cloud.ResourceID {
 	return &cloud.ResourceID{
-		Resource:  "healthChecks",
+		Resource:  "firewalls",
 		APIGroup:  meta.APIGroupCompute,
 		ProjectID: project,
 		Key:       key,
 	}
 }
 
-type MutableHealthCheck = api.MutableResource[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]
+type MutableFirewall = api.MutableResource[compute.Firewall, alpha.Firewall, beta.Firewall]
 
-func NewMutableHealthCheck(project string, key *meta.Key) MutableHealthCheck {
+func NewMutableFirewall(project string, key *meta.Key) MutableFirewall {
 	id := ID(project, key)
 	return api.NewResource[
-		compute.HealthCheck,
-		alpha.HealthCheck,
-		beta.HealthCheck,
+		compute.Firewall,
+		alpha.Firewall,
+		beta.Firewall,
 	](id, &typeTrait{})
 }
 
-type HealthCheck = api.Resource[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]
+type Firewall = api.Resource[compute.Firewall, alpha.Firewall, beta.Firewall]
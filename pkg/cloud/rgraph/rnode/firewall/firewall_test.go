@@ -0,0 +1,122 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestFirewallSchema(t *testing.T) {
+	x := NewMutableFirewall("proj-1", meta.GlobalKey("key-1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.GlobalKey("fw"))
+	base := func(x *compute.Firewall) {
+		x.Name = "fw"
+		x.Network = "projects/proj-1/global/networks/default"
+		x.Direction = "INGRESS"
+		x.Priority = 1000
+		x.SourceRanges = []string{"130.211.0.0/22", "35.191.0.0/16"}
+		x.Allowed = []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"80"}}}
+	}
+	makeNode := func(f func(*compute.Firewall)) rnode.Node {
+		t.Helper()
+		m := NewMutableFirewall(id.ProjectID, id.Key)
+		if err := m.Access(func(x *compute.Firewall) {
+			base(x)
+			f(x)
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		f      func(*compute.Firewall)
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "no diff",
+			f:      func(*compute.Firewall) {},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "ports",
+			f:      func(x *compute.Firewall) { x.Allowed[0].Ports = []string{"80", "443"} },
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "source ranges",
+			f:      func(x *compute.Firewall) { x.SourceRanges = []string{"0.0.0.0/0"} },
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "network",
+			f:      func(x *compute.Firewall) { x.Network = "projects/proj-1/global/networks/other" },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "direction",
+			f:      func(x *compute.Firewall) { x.Direction = "EGRESS" },
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := makeNode(tc.f).Diff(makeNode(func(*compute.Firewall) {}))
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Errorf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+		})
+	}
+}
+
+func TestOutRefs(t *testing.T) {
+	id := ID("proj", meta.GlobalKey("fw"))
+	netID := network.ID("proj", meta.GlobalKey("vpc"))
+
+	mr := NewMutableFirewall(id.ProjectID, id.Key)
+	mr.Access(func(x *compute.Firewall) {
+		x.Network = netID.SelfLink(meta.VersionGA)
+	})
+	r, _ := mr.Freeze()
+	got, err := NewBuilderWithResource(r).OutRefs()
+	if err != nil {
+		t.Fatalf("OutRefs() = %v, want nil", err)
+	}
+	want := []rnode.ResourceRef{
+		{From: id, To: netID, Path: api.Path{}.Pointer().Field("Network")},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("OutRefs diff = -got,+want: %s", diff)
+	}
+}
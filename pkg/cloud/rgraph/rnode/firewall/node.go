@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type firewallNode struct {
+	rnode.NodeBase
+	resource Firewall
+}
+
+var _ rnode.Node = (*firewallNode)(nil)
+
+func (n *firewallNode) Resource() rnode.UntypedResource { return n.resource }
+
+// diffPolicy is the default DiffPolicy for Firewall. .Direction and .Network
+// cannot be changed with patch(), these are Immutable() in the typeTrait.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldUpdate)
+
+func (n *firewallNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*firewallNode)
+	if !ok {
+		return nil, fmt.Errorf("FirewallNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("FirewallNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("Firewall", diff)
+}
+
+func (n *firewallNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.Firewall, alpha.Firewall, beta.Firewall](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.Firewall, alpha.Firewall, beta.Firewall](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.Firewall, alpha.Firewall, beta.Firewall](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[compute.Firewall, alpha.Firewall, beta.Firewall](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("FirewallNode: invalid plan op %s", op)
+}
+
+func (n *firewallNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
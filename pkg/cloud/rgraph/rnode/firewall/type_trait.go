@@ -0,0 +1,70 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewall
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/firewalls
+type typeTrait struct {
+	api.BaseTypeTrait[compute.Firewall, alpha.Firewall, beta.Firewall]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	// [Output Only]
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Disabled"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("LogConfig"))
+	// Only one of Allowed and Denied is set. The ranges, tags and service
+	// accounts are optional selectors.
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Allowed"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Allowed").AnySliceIndex().Pointer().Field("Ports"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Denied"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Denied").AnySliceIndex().Pointer().Field("Ports"))
+	for _, f := range []string{
+		"DestinationRanges",
+		"SourceRanges",
+		"SourceServiceAccounts",
+		"SourceTags",
+		"TargetServiceAccounts",
+		"TargetTags",
+	} {
+		dt.AllowZeroValue(api.Path{}.Pointer().Field(f))
+	}
+	// Priority 0 is the highest priority.
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Priority"))
+
+	// .Direction and .Network cannot be changed with patch().
+	dt.Immutable(api.Path{}.Pointer().Field("Direction"))
+	dt.Immutable(api.Path{}.Pointer().Field("Network"))
+
+	// References to other resources.
+	dt.Reference(api.Path{}.Pointer().Field("Network"), "networks")
+
+	return dt
+}
@@ -19,33 +19,86 @@ package forwardingrule
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
 )
 
 func forwardingRuleSetLabels(
 	ctx context.Context,
 	cl cloud.Cloud,
-	key *meta.Key,
+	ver meta.Version,
+	id *cloud.ResourceID,
 	labelFingerprint string,
 	labels map[string]string,
 ) error {
-	switch key.Type() {
+	switch id.Key.Type() {
 	case meta.Global:
-		return cl.GlobalForwardingRules().SetLabels(ctx, key, &compute.GlobalSetLabelsRequest{
+		return globalSetLabelsFuncs(cl).Do(ctx, ver, id, &compute.GlobalSetLabelsRequest{
 			LabelFingerprint: labelFingerprint,
 			Labels:           labels,
 		})
 	case meta.Regional:
-		return cl.ForwardingRules().SetLabels(ctx, key, &compute.RegionSetLabelsRequest{
+		return regionSetLabelsFuncs(cl).Do(ctx, ver, id, &compute.RegionSetLabelsRequest{
 			LabelFingerprint: labelFingerprint,
 			Labels:           labels,
 		})
 	}
-	return fmt.Errorf("forwardingRuleMethodsByScope: invalid scope %v", key.Type())
+	return fmt.Errorf("forwardingRuleMethodsByScope: invalid scope %v", id.Key.Type())
+}
+
+// resourceLabels returns the .Labels and .LabelFingerprint of res in the
+// version of res.
+func resourceLabels(res ForwardingRule) (map[string]string, string, error) {
+	switch res.Version() {
+	case meta.VersionAlpha:
+		x, err := res.ToAlpha()
+		if err != nil {
+			return nil, "", err
+		}
+		return x.Labels, x.LabelFingerprint, nil
+	case meta.VersionBeta:
+		x, err := res.ToBeta()
+		if err != nil {
+			return nil, "", err
+		}
+		return x.Labels, x.LabelFingerprint, nil
+	}
+	x, err := res.ToGA()
+	if err != nil {
+		return nil, "", err
+	}
+	return x.Labels, x.LabelFingerprint, nil
+}
+
+// resourceGlobalAccess returns the .AllowGlobalAccess and .Fingerprint of res
+// in the version of res.
+func resourceGlobalAccess(res ForwardingRule) (bool, string, error) {
+	switch res.Version() {
+	case meta.VersionAlpha:
+		x, err := res.ToAlpha()
+		if err != nil {
+			return false, "", err
+		}
+		return x.AllowGlobalAccess, x.Fingerprint, nil
+	case meta.VersionBeta:
+		x, err := res.ToBeta()
+		if err != nil {
+			return false, "", err
+		}
+		return x.AllowGlobalAccess, x.Fingerprint, nil
+	}
+	x, err := res.ToGA()
+	if err != nil {
+		return false, "", err
+	}
+	return x.AllowGlobalAccess, x.Fingerprint, nil
 }
 
 func newForwardingRuleCreateAction(id *cloud.ResourceID, res ForwardingRule, want exec.EventList) exec.Action {
@@ -63,23 +116,26 @@ type forwardingRuleCreateAction struct {
 }
 
 func (act *forwardingRuleCreateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
-	// XXX: project routing
 	ops := &ops{}
 	err := ops.CreateFuncs(cl).Do(ctx, act.id, act.res)
 	if err != nil {
 		return nil, err
 	}
 
-	ga, _ := act.res.ToGA()
-	labels := ga.Labels
-	if labels != nil && len(labels) > 0 {
-		res, err := ops.GetFuncs(cl).Do(ctx, meta.VersionGA, act.id, &typeTrait{})
+	labels, _, err := resourceLabels(act.res)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) > 0 {
+		res, err := ops.GetFuncs(cl).Do(ctx, act.res.Version(), act.id, &typeTrait{})
+		if err != nil {
+			return nil, err
+		}
+		_, labelFingerprint, err := resourceLabels(res)
 		if err != nil {
 			return nil, err
-
 		}
-		ga, _ = res.ToGA()
-		if err := forwardingRuleSetLabels(ctx, cl, act.id.Key, ga.LabelFingerprint, labels); err != nil {
+		if err := forwardingRuleSetLabels(ctx, cl, act.res.Version(), act.id, labelFingerprint, labels); err != nil {
 			return nil, err
 		}
 	}
@@ -91,10 +147,17 @@ func (act *forwardingRuleCreateAction) DryRun() exec.EventList {
 	return exec.EventList{exec.NewExistsEvent(act.id)}
 }
 
+// Inverse implements exec.ReversibleAction.
+func (act *forwardingRuleCreateAction) Inverse() (exec.Action, error) {
+	return rnode.DeleteResourceAction[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule](&ops{}, act.id), nil
+}
+
 func (act *forwardingRuleCreateAction) String() string {
 	return fmt.Sprintf("ForwardingRuleCreateAction(%s)", act.id)
 }
 
+func (act *forwardingRuleCreateAction) ResourceID() *cloud.ResourceID { return act.id }
+
 func (act *forwardingRuleCreateAction) Metadata() *exec.ActionMetadata {
 	return &exec.ActionMetadata{
 		Name:    fmt.Sprintf("ForwardingRuleCreateAction(%s)", act.id),
@@ -107,6 +170,8 @@ type forwardingRuleUpdateAction struct {
 	exec.ActionBase
 
 	id *cloud.ResourceID
+	// version of the API used for the calls.
+	version meta.Version
 	// target if non-empty will call setTarget(),
 	target *cloud.ResourceID
 	// oldTarget is the previous target before the update.
@@ -116,51 +181,43 @@ type forwardingRuleUpdateAction struct {
 	labelFingerprint string
 	// labels if non-nil will call setLabels().
 	labels map[string]string
+
+	// patch if non-nil will call patch() with the fields to update.
+	patch *compute.ForwardingRule
+
+	// changes are human-readable descriptions of the changed fields.
+	changes []string
 }
 
 func (act *forwardingRuleUpdateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
-	// TODO: project routing.
-	if act.labels != nil {
-		switch act.id.Key.Type() {
-		case meta.Global:
-			err := cl.GlobalForwardingRules().SetLabels(ctx, act.id.Key, &compute.GlobalSetLabelsRequest{
-				LabelFingerprint: act.labelFingerprint,
-				Labels:           act.labels,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetLabels: %w", act.id, err)
-			}
-		case meta.Regional:
-			err := cl.ForwardingRules().SetLabels(ctx, act.id.Key, &compute.RegionSetLabelsRequest{
-				LabelFingerprint: act.labelFingerprint,
-				Labels:           act.labels,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetLabels: %w", act.id, err)
-			}
-		default:
-			return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): invalid key type", act.id)
+	switch act.id.Key.Type() {
+	case meta.Global, meta.Regional:
+	default:
+		return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): invalid key type", act.id)
+	}
+
+	// Patch() must be first as it carries the fingerprint from the plan, which
+	// is changed by SetTarget() and SetLabels(). SetLabels() checks the
+	// LabelFingerprint, which is only changed by SetLabels().
+	if act.patch != nil {
+		if act.id.Key.Type() != meta.Regional {
+			return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): Patch is only supported for regional forwarding rules", act.id)
+		}
+		if err := patchFuncs(cl).Do(ctx, act.version, act.id, act.patch); err != nil {
+			return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): Patch: %w", act.id, err)
 		}
 	}
 
 	if act.target != nil {
-		switch act.id.Key.Type() {
-		case meta.Global:
-			err := cl.GlobalForwardingRules().SetTarget(ctx, act.id.Key, &compute.TargetReference{
-				Target: act.target.SelfLink(meta.VersionGA),
-			})
-			if err != nil {
-				return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetTarget: %w", act.id, err)
-			}
-		case meta.Regional:
-			err := cl.GlobalForwardingRules().SetTarget(ctx, act.id.Key, &compute.TargetReference{
-				Target: act.target.SelfLink(meta.VersionGA),
-			})
-			if err != nil {
-				return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetTarget: %w", act.id, err)
-			}
-		default:
-			return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): invalid key type", act.id)
+		ref := &compute.TargetReference{Target: act.target.SelfLink(act.version)}
+		if err := setTargetFuncs(cl).Do(ctx, act.version, act.id, ref); err != nil {
+			return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetTarget: %w", act.id, err)
+		}
+	}
+
+	if act.labels != nil {
+		if err := forwardingRuleSetLabels(ctx, cl, act.version, act.id, act.labelFingerprint, act.labels); err != nil {
+			return nil, fmt.Errorf("forwardingRuleUpdateAction Run(%s): SetLabels: %w", act.id, err)
 		}
 	}
 
@@ -184,10 +241,57 @@ func (act *forwardingRuleUpdateAction) String() string {
 	return fmt.Sprintf("ForwardingRuleUpdateAction(%s)", act.id)
 }
 
+func (act *forwardingRuleUpdateAction) ResourceID() *cloud.ResourceID { return act.id }
+
 func (act *forwardingRuleUpdateAction) Metadata() *exec.ActionMetadata {
+	summary := fmt.Sprintf("Update %s", act.id)
+	if len(act.changes) > 0 {
+		summary += ": " + strings.Join(act.changes, ", ")
+	}
 	return &exec.ActionMetadata{
 		Name:    fmt.Sprintf("ForwardingRuleUpdateAction(%s)", act.id),
 		Type:    exec.ActionTypeUpdate,
-		Summary: fmt.Sprintf("Update %s", act.id),
+		Summary: summary,
+	}
+}
+
+func globalSetLabelsFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.GlobalSetLabelsRequest, alpha.GlobalSetLabelsRequest, beta.GlobalSetLabelsRequest] {
+	return &rnode.MethodFuncs[compute.GlobalSetLabelsRequest, alpha.GlobalSetLabelsRequest, beta.GlobalSetLabelsRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.GlobalSetLabelsRequest]{Global: gcp.GlobalForwardingRules().SetLabels},
+		Alpha: rnode.UpdateFuncsByScope[alpha.GlobalSetLabelsRequest]{Global: gcp.AlphaGlobalForwardingRules().SetLabels},
+		Beta:  rnode.UpdateFuncsByScope[beta.GlobalSetLabelsRequest]{Global: gcp.BetaGlobalForwardingRules().SetLabels},
+	}
+}
+
+func regionSetLabelsFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.RegionSetLabelsRequest, alpha.RegionSetLabelsRequest, beta.RegionSetLabelsRequest] {
+	return &rnode.MethodFuncs[compute.RegionSetLabelsRequest, alpha.RegionSetLabelsRequest, beta.RegionSetLabelsRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.RegionSetLabelsRequest]{Regional: gcp.ForwardingRules().SetLabels},
+		Alpha: rnode.UpdateFuncsByScope[alpha.RegionSetLabelsRequest]{Regional: gcp.AlphaForwardingRules().SetLabels},
+		Beta:  rnode.UpdateFuncsByScope[beta.RegionSetLabelsRequest]{Regional: gcp.BetaForwardingRules().SetLabels},
+	}
+}
+
+func patchFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] {
+	return &rnode.MethodFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]{
+		GA:    rnode.UpdateFuncsByScope[compute.ForwardingRule]{Regional: gcp.ForwardingRules().Patch},
+		Alpha: rnode.UpdateFuncsByScope[alpha.ForwardingRule]{Regional: gcp.AlphaForwardingRules().Patch},
+		Beta:  rnode.UpdateFuncsByScope[beta.ForwardingRule]{Regional: gcp.BetaForwardingRules().Patch},
+	}
+}
+
+func setTargetFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetReference, alpha.TargetReference, beta.TargetReference] {
+	return &rnode.MethodFuncs[compute.TargetReference, alpha.TargetReference, beta.TargetReference]{
+		GA: rnode.UpdateFuncsByScope[compute.TargetReference]{
+			Global:   gcp.GlobalForwardingRules().SetTarget,
+			Regional: gcp.ForwardingRules().SetTarget,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetReference]{
+			Global:   gcp.AlphaGlobalForwardingRules().SetTarget,
+			Regional: gcp.AlphaForwardingRules().SetTarget,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.TargetReference]{
+			Global:   gcp.BetaGlobalForwardingRules().SetTarget,
+			Regional: gcp.BetaForwardingRules().SetTarget,
+		},
 	}
 }
@@ -18,16 +18,63 @@ package forwardingrule
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	mockcloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
 )
 
 func TestCreateAction(t *testing.T) {
-	// TODO
+	ctx := context.Background()
+	id := ID("proj", meta.RegionalKey("fr", "us-central1"))
+
+	// .AllowPscPacketInjection is only in Alpha so the resource cannot be
+	// converted to GA.
+	mr := NewMutableForwardingRule("proj", id.Key)
+	if err := mr.AccessAlpha(func(x *alpha.ForwardingRule) {
+		x.Name = "fr"
+		x.AllowPscPacketInjection = true
+		x.Labels = map[string]string{"foo": "bar"}
+		// Send the remaining fields as zero values.
+		v := reflect.ValueOf(x).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			switch name := v.Type().Field(i).Name; name {
+			case "ServerResponse", "NullFields", "ForceSendFields":
+			default:
+				if v.Field(i).IsZero() {
+					x.ForceSendFields = append(x.ForceSendFields, name)
+				}
+			}
+		}
+	}); err != nil {
+		t.Fatalf("AccessAlpha() = %v, want nil", err)
+	}
+	r, err := mr.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v", err)
+	}
+	res := rnode.WithOwnerMarker(r, &rnode.OwnerMarker{Labels: map[string]string{"owner": "test"}})
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	act := newForwardingRuleCreateAction(id, res, nil)
+	if _, err := act.Run(ctx, mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	fr, err := mock.AlphaForwardingRules().Get(ctx, id.Key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if !fr.AllowPscPacketInjection || fr.Labels["foo"] != "bar" || fr.Labels["owner"] != "test" {
+		t.Errorf("Get() = %+v; want AllowPscPacketInjection and Labels {foo: bar, owner: test}", fr)
+	}
 }
 
 func TestUpdateAction(t *testing.T) {
@@ -44,6 +91,7 @@ func TestUpdateAction(t *testing.T) {
 			name: "update target",
 			action: &forwardingRuleUpdateAction{
 				id:        id,
+				version:   meta.VersionGA,
 				target:    targetID,
 				oldTarget: oldTargetID,
 			},
@@ -54,8 +102,9 @@ func TestUpdateAction(t *testing.T) {
 		{
 			name: "update label",
 			action: &forwardingRuleUpdateAction{
-				id:     id,
-				labels: map[string]string{"foo": "bar"},
+				id:      id,
+				version: meta.VersionGA,
+				labels:  map[string]string{"foo": "bar"},
 			},
 		},
 	} {
@@ -76,3 +125,113 @@ func TestUpdateAction(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateActionRegional(t *testing.T) {
+	id := ID("proj", meta.RegionalKey("fr", "us-central1"))
+	targetID := targethttpproxy.ID("proj", meta.RegionalKey("tp", "us-central1"))
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	if err := mock.ForwardingRules().Insert(context.Background(), id.Key, &compute.ForwardingRule{Name: "fr"}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	act := &forwardingRuleUpdateAction{
+		id:      id,
+		version: meta.VersionGA,
+		target:  targetID,
+		labels:  map[string]string{"foo": "bar"},
+		patch: &compute.ForwardingRule{
+			AllowGlobalAccess: true,
+			ForceSendFields:   []string{"AllowGlobalAccess"},
+		},
+	}
+	if _, err := act.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+
+	for _, op := range []string{"SetLabels", "SetTarget", "Patch"} {
+		calls := mock.Calls().Matching(cloud.MockCall{Service: "ForwardingRules", Operation: op, Key: id.Key})
+		if len(calls) != 1 {
+			t.Errorf("calls to ForwardingRules.%s = %v, want 1", op, calls)
+		}
+	}
+	if calls := mock.Calls().Matching(cloud.MockCall{Service: "GlobalForwardingRules"}); len(calls) != 0 {
+		t.Errorf("calls to GlobalForwardingRules = %v, want none", calls)
+	}
+	fr, err := mock.ForwardingRules().Get(context.Background(), id.Key)
+	if err != nil || !fr.AllowGlobalAccess {
+		t.Errorf("Get() = %+v, %v; want AllowGlobalAccess = true", fr, err)
+	}
+}
+
+func TestUpdateActionBeta(t *testing.T) {
+	ctx := context.Background()
+	id := ID("proj", meta.RegionalKey("fr", "us-central1"))
+	targetID := targethttpproxy.ID("proj", meta.RegionalKey("tp", "us-central1"))
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	if err := mock.BetaForwardingRules().Insert(ctx, id.Key, &beta.ForwardingRule{Name: "fr"}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	act := &forwardingRuleUpdateAction{
+		id:      id,
+		version: meta.VersionBeta,
+		target:  targetID,
+		labels:  map[string]string{"foo": "bar"},
+		patch: &compute.ForwardingRule{
+			AllowGlobalAccess: true,
+			ForceSendFields:   []string{"AllowGlobalAccess"},
+		},
+	}
+	if _, err := act.Run(ctx, mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	for _, op := range []string{"SetLabels", "SetTarget", "Patch"} {
+		calls := mock.Calls().Matching(cloud.MockCall{Service: "ForwardingRules", Version: meta.VersionBeta, Operation: op, Key: id.Key})
+		if len(calls) != 1 {
+			t.Errorf("calls to beta ForwardingRules.%s = %v, want 1", op, calls)
+		}
+	}
+	fr, err := mock.BetaForwardingRules().Get(ctx, id.Key)
+	if err != nil || !fr.AllowGlobalAccess {
+		t.Errorf("Get() = %+v, %v; want AllowGlobalAccess = true", fr, err)
+	}
+}
+
+func TestUpdateActionFingerprint(t *testing.T) {
+	ctx := context.Background()
+	id := ID("proj", meta.RegionalKey("fr", "us-central1"))
+	targetID := targethttpproxy.ID("proj", meta.RegionalKey("tp", "us-central1"))
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	mockcloud.EnableFingerprints(mock)
+	if err := mock.ForwardingRules().Insert(ctx, id.Key, &compute.ForwardingRule{Name: "fr"}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	got, err := mock.ForwardingRules().Get(ctx, id.Key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	// The fingerprints are from the plan, as set by updateActions().
+	act := &forwardingRuleUpdateAction{
+		id:               id,
+		version:          meta.VersionGA,
+		target:           targetID,
+		labelFingerprint: got.LabelFingerprint,
+		labels:           map[string]string{"foo": "bar"},
+		patch: &compute.ForwardingRule{
+			AllowGlobalAccess: true,
+			Fingerprint:       got.Fingerprint,
+			ForceSendFields:   []string{"AllowGlobalAccess"},
+		},
+	}
+	if _, err := act.Run(ctx, mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	fr, err := mock.ForwardingRules().Get(ctx, id.Key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if !fr.AllowGlobalAccess || fr.Target != targetID.SelfLink(meta.VersionGA) || fr.Labels["foo"] != "bar" {
+		t.Errorf("Get() = %+v; want AllowGlobalAccess, Target and Labels to be updated", fr)
+	}
+}
@@ -17,54 +17,14 @@ limitations under the License.
 package forwardingrule
 
 import (
-	"context"
 	"fmt"
 	"net"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
-	alpha "google.golang.org/api/compute/v0.alpha"
-	beta "google.golang.org/api/compute/v0.beta"
-	"google.golang.org/api/compute/v1"
 )
 
-func NewBuilder(id *cloud.ResourceID) rnode.Builder {
-	b := &builder{}
-	b.Defaults(id)
-	return b
-}
-
-func NewBuilderWithResource(r ForwardingRule) rnode.Builder {
-	b := &builder{resource: r}
-	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
-	return b
-}
-
-type builder struct {
-	rnode.BuilderBase
-	resource ForwardingRule
-}
-
-// builder implements node.Builder.
-var _ rnode.Builder = (*builder)(nil)
-
-func (b *builder) Resource() rnode.UntypedResource { return b.resource }
-
-func (b *builder) SetResource(u rnode.UntypedResource) error {
-	r, ok := u.(ForwardingRule)
-	if !ok {
-		return fmt.Errorf("SetResource: invalid type: %T, want ForwardingRule", u)
-	}
-	b.resource = r
-	return nil
-}
-
-func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
-	return rnode.GenericGet[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule](
-		ctx, gcp, "ForwardingRule", &ops{}, &typeTrait{}, b)
-}
-
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 	if b.resource == nil {
 		return nil, nil
@@ -92,12 +52,14 @@ func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 		}
 	}
 
-	// .BackendService, .Target
+	// .BackendService, .Network, .Subnetwork, .Target
 	for _, fieldSpec := range []struct {
 		name string
 		val  string
 	}{
 		{"BackendService", obj.BackendService},
+		{"Network", obj.Network},
+		{"Subnetwork", obj.Subnetwork},
 		{"Target", obj.Target},
 	} {
 		if fieldSpec.val == "" {
@@ -116,16 +78,3 @@ func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
 
 	return ret, nil
 }
-
-func (b *builder) Build() (rnode.Node, error) {
-	if b.State() == rnode.NodeExists && b.resource == nil {
-		return nil, fmt.Errorf("ForwardingRule %s resource is nil with state %s", b.ID(), b.State())
-	}
-
-	ret := &forwardingRuleNode{resource: b.resource}
-	if err := ret.InitFromBuilder(b); err != nil {
-		return nil, err
-	}
-
-	return ret, nil
-}
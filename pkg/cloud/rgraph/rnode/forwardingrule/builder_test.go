@@ -24,6 +24,8 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/fake"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/api/compute/v1"
@@ -67,6 +69,8 @@ func TestOutRefs(t *testing.T) {
 	id := ID("proj", meta.GlobalKey("fr"))
 	addrID := address.ID("proj", meta.GlobalKey("addr"))
 	targetID := targethttpproxy.ID("proj", meta.GlobalKey("tp"))
+	subnetID := subnetwork.ID("proj", meta.RegionalKey("sub", "us-central1"))
+	netID := network.ID("proj", meta.GlobalKey("vpc"))
 
 	for _, tc := range []struct {
 		name string
@@ -110,6 +114,17 @@ func TestOutRefs(t *testing.T) {
 				{From: id, To: targetID, Path: api.Path{}.Pointer().Field("Target")},
 			},
 		},
+		{
+			name: "network and subnetwork",
+			f: func(x *compute.ForwardingRule) {
+				x.Network = netID.SelfLink(meta.VersionGA)
+				x.Subnetwork = subnetID.SelfLink(meta.VersionGA)
+			},
+			want: []rnode.ResourceRef{
+				{From: id, To: netID, Path: api.Path{}.Pointer().Field("Network")},
+				{From: id, To: subnetID, Path: api.Path{}.Pointer().Field("Subnetwork")},
+			},
+		},
 		{
 			name: "garbage IP",
 			f: func(x *compute.ForwardingRule) {
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package forwardingrule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID returns the ResourceID of the ForwardingRule.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "forwardingRules",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableForwardingRule = api.MutableResource[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]
+
+func NewMutableForwardingRule(project string, key *meta.Key) MutableForwardingRule {
+	id := ID(project, key)
+	return api.NewResource[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule](id, &typeTrait{})
+}
+
+type ForwardingRule = api.Resource[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules
+type typeTrait struct {
+	api.BaseTypeTrait[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("BaseForwardingRule"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("LabelFingerprint"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("PscConnectionId"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("PscConnectionStatus"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("ServiceName"))
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] {
+	return &rnode.GetFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]{
+		GA: rnode.GetFuncsByScope[compute.ForwardingRule]{
+			Global:   gcp.GlobalForwardingRules().Get,
+			Regional: gcp.ForwardingRules().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.ForwardingRule]{
+			Global:   gcp.AlphaGlobalForwardingRules().Get,
+			Regional: gcp.AlphaForwardingRules().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.ForwardingRule]{
+			Global:   gcp.BetaGlobalForwardingRules().Get,
+			Regional: gcp.BetaForwardingRules().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] {
+	return &rnode.CreateFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]{
+		GA: rnode.CreateFuncsByScope[compute.ForwardingRule]{
+			Global:   gcp.GlobalForwardingRules().Insert,
+			Regional: gcp.ForwardingRules().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.ForwardingRule]{
+			Global:   gcp.AlphaGlobalForwardingRules().Insert,
+			Regional: gcp.AlphaForwardingRules().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.ForwardingRule]{
+			Global:   gcp.BetaGlobalForwardingRules().Insert,
+			Regional: gcp.BetaForwardingRules().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] {
+	return nil // Does not support generic Update.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule] {
+	return &rnode.DeleteFuncs[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]{
+		GA: rnode.DeleteFuncsByScope[compute.ForwardingRule]{
+			Global:   gcp.GlobalForwardingRules().Delete,
+			Regional: gcp.ForwardingRules().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.ForwardingRule]{
+			Global:   gcp.AlphaGlobalForwardingRules().Delete,
+			Regional: gcp.AlphaForwardingRules().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.ForwardingRule]{
+			Global:   gcp.BetaGlobalForwardingRules().Delete,
+			Regional: gcp.BetaForwardingRules().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r ForwardingRule) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource ForwardingRule
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(ForwardingRule)
+	if !ok {
+		return fmt.Errorf("cannot set ForwardingRule from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule](ctx, gcp, "ForwardingRule", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("ForwardingRule %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &forwardingRuleNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+type forwardingRuleNode struct {
+	rnode.NodeBase
+	resource ForwardingRule
+}
+
+var _ rnode.Node = (*forwardingRuleNode)(nil)
+
+func (n *forwardingRuleNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *forwardingRuleNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
@@ -18,9 +18,11 @@ package forwardingrule
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
@@ -30,45 +32,85 @@ import (
 
 func nodeErr(s string, args ...any) error { return fmt.Errorf("forwardingRule: "+s, args...) }
 
-type forwardingRuleNode struct {
-	rnode.NodeBase
-	resource ForwardingRule
+// diffPolicies are the default DiffPolicy for the forwarding rule by scope.
+var diffPolicies = map[meta.KeyType]*rnode.DiffPolicy{
+	meta.Global:   rnode.NewDiffPolicy(rnode.FieldRecreate).SetFields(rnode.FieldUpdate, "Target", "Labels"),
+	meta.Regional: rnode.NewDiffPolicy(rnode.FieldRecreate).SetFields(rnode.FieldUpdate, "Target", "Labels", "AllowGlobalAccess"),
 }
 
-var _ rnode.Node = (*forwardingRuleNode)(nil)
-
-func (n *forwardingRuleNode) Resource() rnode.UntypedResource { return n.resource }
-
-// changedFields is a helper that interprets the set of fields that have been changed in a Diff.
-type changedFields struct {
-	target bool
-	labels bool
-	other  bool
-
-	// messages are human-readable descriptions of the changed fields.
-	messages []string
-}
-
-// process an item from the diff. returns true if the item can be handled
-// without recreating the resource.
-func (c *changedFields) process(item api.DiffItem) bool {
-	var messages []string
-
-	switch {
-	case api.Path{}.Pointer().Field("Target").Equal(item.Path):
-		c.messages = append(messages, fmt.Sprintf("Target (%q -> %q)", item.A, item.B))
-		c.target = true
-		return true
-	case item.Path.HasPrefix(api.Path{}.Pointer().Field("Labels")):
-		c.messages = append(messages, fmt.Sprintf("Labels (%v -> %v)", item.A, item.B))
-		c.labels = true
-		return true
-	default:
-		c.messages = append(messages, fmt.Sprintf("%s (%v -> %v)", item.Path, item.A, item.B))
-		c.other = true
-	}
-
-	return false
+// setters for the fields that can be changed in place. Only regional
+// forwarding rules support patch(), which is limited to .AllowGlobalAccess.
+// All other fields (e.g. IPAddress, IPProtocol, Ports, PortRange,
+// LoadBalancingScheme, Network) require the resource to be recreated.
+//
+// The setters read from the nodes rather than the GA resource as the labels
+// are set from the resource with the owner marker.
+var setters = rnode.FieldSetters[*forwardingRuleUpdateAction, *forwardingRuleNode]{
+	{
+		// setTarget()
+		Path: api.Path{}.Pointer().Field("Target"),
+		Set: func(act *forwardingRuleUpdateAction, got, want *forwardingRuleNode) error {
+			oldTarget, err := parseTarget(got)
+			if err != nil {
+				return err
+			}
+			target, err := parseTarget(want)
+			if err != nil {
+				return err
+			}
+			act.Want = append(act.Want, exec.NewExistsEvent(target))
+			act.oldTarget = oldTarget
+			act.target = target
+			return nil
+		},
+	},
+	{
+		// setLabels()
+		Path: api.Path{}.Pointer().Field("Labels"),
+		Set: func(act *forwardingRuleUpdateAction, got, want *forwardingRuleNode) error {
+			_, labelFingerprint, err := resourceLabels(got.resource)
+			if err != nil {
+				return err
+			}
+			wantRes, err := rnode.WantResource(want, want.resource)
+			if err != nil {
+				return err
+			}
+			labels, _, err := resourceLabels(wantRes)
+			if err != nil {
+				return err
+			}
+			act.labelFingerprint = labelFingerprint
+			act.labels = labels
+			if act.labels == nil {
+				// Clear the labels.
+				act.labels = map[string]string{}
+			}
+			return nil
+		},
+	},
+	{
+		// patch()
+		Path:  api.Path{}.Pointer().Field("AllowGlobalAccess"),
+		Scope: meta.Regional,
+		Set: func(act *forwardingRuleUpdateAction, got, want *forwardingRuleNode) error {
+			_, fingerprint, err := resourceGlobalAccess(got.resource)
+			if err != nil {
+				return err
+			}
+			allowGlobalAccess, _, err := resourceGlobalAccess(want.resource)
+			if err != nil {
+				return err
+			}
+			// patchFuncs() converts the patch to act.version.
+			act.patch = &compute.ForwardingRule{
+				AllowGlobalAccess: allowGlobalAccess,
+				Fingerprint:       fingerprint,
+				ForceSendFields:   []string{"AllowGlobalAccess"},
+			}
+			return nil
+		},
+	},
 }
 
 func (n *forwardingRuleNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
@@ -82,31 +124,30 @@ func (n *forwardingRuleNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error
 		return nil, nodeErr("Diff: %w", err)
 	}
 
-	if diff.HasDiff() {
-		var changed changedFields
-		for _, item := range diff.Items {
-			changed.process(item)
-		}
-
-		if !changed.other {
-			return &rnode.PlanDetails{
-				Operation: rnode.OpUpdate,
-				Why:       fmt.Sprintf("update in place (changed=%+v)", changed),
-				Diff:      diff,
-			}, nil
+	// The desired .IPAddress may reference an Address resource while the
+	// cloud returns the numeric IP. These are not passed to the DiffPolicy.
+	ipAddress := api.Path{}.Pointer().Field("IPAddress")
+	var addressRef bool
+	filtered := &api.DiffResult{}
+	for _, item := range diff.Items {
+		if ipAddress.Equal(item.Path) && isAddressRef(item.A, item.B) {
+			addressRef = true
+			continue
 		}
+		filtered.Items = append(filtered.Items, item)
+	}
 
+	r, err := n.EffectiveDiffPolicy(diffPolicies[n.ID().Key.Type()]).Apply(filtered)
+	if err != nil {
+		return nil, nodeErr("%w", err)
+	}
+	if !r.Diff.HasDiff() && addressRef && len(r.Ignored) == 0 {
 		return &rnode.PlanDetails{
-			Operation: rnode.OpRecreate,
-			Why:       "needs to be recreated",
-			Diff:      diff,
+			Operation: rnode.OpNothing,
+			Why:       "No diff between got and want (.IPAddress references an Address)",
 		}, nil
 	}
-
-	return &rnode.PlanDetails{
-		Operation: rnode.OpNothing,
-		Why:       "No diff between got and want",
-	}, nil
+	return setters.PlanDetails("ForwardingRule", n.ID().Key.Type(), r), nil
 }
 
 func (n *forwardingRuleNode) Actions(got rnode.Node) ([]exec.Action, error) {
@@ -123,7 +164,7 @@ func (n *forwardingRuleNode) Actions(got rnode.Node) ([]exec.Action, error) {
 		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
 
 	case rnode.OpRecreate:
-		return rnode.RecreateActions[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule](&ops{}, got, n, n.resource)
+		return n.recreateActions(got)
 
 	case rnode.OpUpdate:
 		return n.updateActions(got)
@@ -131,19 +172,41 @@ func (n *forwardingRuleNode) Actions(got rnode.Node) ([]exec.Action, error) {
 	return nil, nodeErr("invalid plan op %s", op)
 }
 
-func (n *forwardingRuleNode) Builder() rnode.Builder {
-	b := &builder{}
-	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
-	return b
+func (n *forwardingRuleNode) createActions() ([]exec.Action, error) {
+	want, err := rnode.CreatePreconditions(n)
+	if err != nil {
+		return nil, err
+	}
+	res, err := rnode.WantResource(n, n.resource)
+	if err != nil {
+		return nil, nodeErr("createActions: %w", err)
+	}
+	return []exec.Action{
+		newForwardingRuleCreateAction(n.ID(), res, want),
+	}, nil
 }
 
-func (n *forwardingRuleNode) createActions() ([]exec.Action, error) {
+// recreateActions uses the ForwardingRule create Action so that the labels
+// are set on the new resource and, on rollback, on the restored one.
+func (n *forwardingRuleNode) recreateActions(got rnode.Node) ([]exec.Action, error) {
 	want, err := rnode.CreatePreconditions(n)
 	if err != nil {
 		return nil, err
 	}
+	// Condition: resource must have been deleted.
+	want = append(want, exec.NewNotExistsEvent(n.ID()))
+	res, err := rnode.WantResource(n, n.resource)
+	if err != nil {
+		return nil, nodeErr("recreateActions: %w", err)
+	}
+	var restore exec.Action
+	if gotNode, ok := got.(*forwardingRuleNode); ok {
+		restore = newForwardingRuleCreateAction(n.ID(), gotNode.resource, nil)
+	}
+	deleteAction := rnode.NewRecreateDeleteAction[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule](&ops{}, got, n, restore)
 	return []exec.Action{
-		newForwardingRuleCreateAction(n.ID(), n.resource, want),
+		deleteAction,
+		newForwardingRuleCreateAction(n.ID(), res, want),
 	}, nil
 }
 
@@ -157,34 +220,12 @@ func (n *forwardingRuleNode) updateActions(ngot rnode.Node) ([]exec.Action, erro
 		return nil, nodeErr("updateActions: node %s has invalid type %T", n.ID(), ngot)
 	}
 
-	act := &forwardingRuleUpdateAction{id: n.ID()}
-
-	var changed changedFields
-	for _, item := range details.Diff.Items {
-		if !changed.process(item) {
-			return nil, nodeErr("updateActions %s: field %s cannot be updated in place", n.ID(), item.Path)
-		}
-	}
-	if changed.target {
-		oldTarget, err := parseTarget(fmt.Sprintf("updateActions %s", n.ID()), got)
-		if err != nil {
-			return nil, err
-		}
-		target, err := parseTarget(fmt.Sprintf("updateActions %s", n.ID()), n)
-		if err != nil {
-			return nil, err
-		}
-		act.Want = append(act.Want, exec.NewExistsEvent(target))
-		act.oldTarget = oldTarget
-		act.target = target
-	}
-
-	if changed.labels {
-		gotRes, _ := got.resource.ToGA()
-		wantRes, _ := n.resource.ToGA()
-		act.labelFingerprint = gotRes.LabelFingerprint
-		act.labels = wantRes.Labels
+	act := &forwardingRuleUpdateAction{id: n.ID(), version: n.Version()}
+	changes, err := setters.Apply(n.ID().Key.Type(), details.Diff, act, got, n)
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
 	}
+	act.changes = changes
 
 	return []exec.Action{
 		// Action: Signal resource exists.
@@ -194,11 +235,26 @@ func (n *forwardingRuleNode) updateActions(ngot rnode.Node) ([]exec.Action, erro
 	}, nil
 }
 
-func parseTarget(errPrefix string, n *forwardingRuleNode) (*cloud.ResourceID, error) {
-	res, _ := n.resource.ToGA()
+// isAddressRef returns true if got is a numeric IP and want is a reference
+// to an Address resource.
+func isAddressRef(got, want any) bool {
+	gotStr, ok1 := got.(string)
+	wantStr, ok2 := want.(string)
+	if !ok1 || !ok2 || net.ParseIP(gotStr) == nil {
+		return false
+	}
+	id, err := cloud.ParseResourceURL(wantStr)
+	return err == nil && id.Resource == "addresses"
+}
+
+func parseTarget(n *forwardingRuleNode) (*cloud.ResourceID, error) {
+	res, err := n.resource.ToGA()
+	if err != nil {
+		return nil, err
+	}
 	ret, err := cloud.ParseResourceURL(res.Target)
 	if err != nil {
-		return nil, nodeErr("%s: invalid .Target %q: %w", errPrefix, res.Target, err)
+		return nil, fmt.Errorf("invalid .Target %q: %w", res.Target, err)
 	}
 	return ret, nil
 }
@@ -18,13 +18,17 @@ package forwardingrule
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/kr/pretty"
+	alpha "google.golang.org/api/compute/v0.alpha"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -60,14 +64,10 @@ func TestCreateActions(t *testing.T) {
 		x.IPAddress = addrID.SelfLink(meta.VersionGA)
 		x.Target = targetID.SelfLink(meta.VersionGA)
 	})
-	r, _ := mr.Freeze()
-	b := NewBuilderWithResource(r)
-	b.SetState(rnode.NodeExists)
-	b.SetOwnership(rnode.OwnershipManaged)
-	n, _ := b.Build()
+	n := nodetest.Build(t, mr, NewBuilderWithResource)
 	n.Plan().Set(rnode.PlanDetails{Operation: rnode.OpCreate})
 
-	b = NewBuilder(id)
+	b := NewBuilder(id)
 	b.SetState(rnode.NodeDoesNotExist)
 	b.SetOwnership(rnode.OwnershipManaged)
 	g, _ := b.Build()
@@ -217,7 +217,7 @@ func TestDiffAndActions(t *testing.T) {
 			wantOp:   rnode.OpRecreate,
 			wantActions: []string{
 				"GenericDeleteAction(compute/forwardingRules:proj/fr)",
-				"GenericCreateAction(compute/forwardingRules:proj/fr)",
+				"ForwardingRuleCreateAction(compute/forwardingRules:proj/fr)",
 			},
 		},
 	} {
@@ -264,3 +264,178 @@ func TestDiffAndActions(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffUpdateVerbs(t *testing.T) {
+	targetID := targethttpproxy.ID("proj", meta.GlobalKey("tp"))
+
+	makeFR := func(t *testing.T, key *meta.Key, isAlpha bool, f func(x *compute.ForwardingRule)) ForwardingRule {
+		t.Helper()
+
+		fr := NewMutableForwardingRule("proj", key)
+		var ga *compute.ForwardingRule
+		fr.Access(func(x *compute.ForwardingRule) {
+			x.Name = key.Name
+			x.IPAddress = "1.2.3.4"
+			x.IPProtocol = "TCP"
+			x.LoadBalancingScheme = "INTERNAL"
+			x.Ports = []string{"80"}
+			x.Target = targetID.SelfLink(meta.VersionGA)
+			x.NullFields = []string{"Labels"}
+			f(x)
+			ga = x
+		})
+		if isAlpha {
+			var convErr error
+			err := fr.AccessAlpha(func(x *alpha.ForwardingRule) {
+				convErr = api.Convert(x, ga)
+				// .AllowPscPacketInjection is only in Alpha so the resource
+				// cannot be converted to GA.
+				x.AllowPscPacketInjection = true
+				// Send the remaining fields as zero values.
+				sent := map[string]bool{}
+				for _, name := range append(x.NullFields, x.ForceSendFields...) {
+					sent[name] = true
+				}
+				v := reflect.ValueOf(x).Elem()
+				for i := 0; i < v.NumField(); i++ {
+					switch name := v.Type().Field(i).Name; name {
+					case "ServerResponse", "NullFields", "ForceSendFields":
+					default:
+						if v.Field(i).IsZero() && !sent[name] {
+							x.ForceSendFields = append(x.ForceSendFields, name)
+						}
+					}
+				}
+			})
+			if err != nil || convErr != nil {
+				t.Fatalf("AccessAlpha() = %v, %v", err, convErr)
+			}
+		}
+		r, err := fr.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v", err)
+		}
+		return r
+	}
+
+	for _, tc := range []struct {
+		name   string
+		key    *meta.Key
+		alpha  bool
+		change func(x *compute.ForwardingRule)
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "regional AllowGlobalAccess is patched",
+			key:    meta.RegionalKey("fr", "us-central1"),
+			change: func(x *compute.ForwardingRule) { x.AllowGlobalAccess = true },
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "alpha regional AllowGlobalAccess is patched",
+			key:    meta.RegionalKey("fr", "us-central1"),
+			alpha:  true,
+			change: func(x *compute.ForwardingRule) { x.AllowGlobalAccess = true },
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "global AllowGlobalAccess is recreated",
+			key:    meta.GlobalKey("fr"),
+			change: func(x *compute.ForwardingRule) { x.AllowGlobalAccess = true },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "IPAddress is recreated",
+			key:    meta.RegionalKey("fr", "us-central1"),
+			change: func(x *compute.ForwardingRule) { x.IPAddress = "1.2.3.5" },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "LoadBalancingScheme is recreated",
+			key:    meta.RegionalKey("fr", "us-central1"),
+			change: func(x *compute.ForwardingRule) { x.LoadBalancingScheme = "EXTERNAL" },
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeFR(t, tc.key, tc.alpha, func(x *compute.ForwardingRule) { x.ForceSendFields = []string{"AllowGlobalAccess"} })
+			want := makeFR(t, tc.key, tc.alpha, func(x *compute.ForwardingRule) {
+				x.ForceSendFields = []string{"AllowGlobalAccess"}
+				tc.change(x)
+			})
+			ng, _ := NewBuilderWithResource(got).Build()
+			nw, _ := NewBuilderWithResource(want).Build()
+
+			pd, err := ng.Diff(nw)
+			if err != nil {
+				t.Fatalf("Diff() = _, %v", err)
+			}
+			if pd.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s (why: %s)", pd.Operation, tc.wantOp, pd.Why)
+			}
+
+			nw.Plan().Set(*pd)
+			actions, err := nw.Actions(ng)
+			if err != nil {
+				t.Fatalf("Actions() = _, %v", err)
+			}
+			if tc.wantOp != rnode.OpUpdate {
+				return
+			}
+			var act *forwardingRuleUpdateAction
+			for _, a := range actions {
+				if x, ok := a.(*forwardingRuleUpdateAction); ok {
+					act = x
+				}
+			}
+			if act == nil {
+				t.Fatalf("Actions() = %v, want a forwardingRuleUpdateAction", actions)
+			}
+			if act.version != want.Version() {
+				t.Errorf("act.version = %s, want %s", act.version, want.Version())
+			}
+			if act.patch == nil || !act.patch.AllowGlobalAccess {
+				t.Errorf("act.patch = %+v, want AllowGlobalAccess = true", act.patch)
+			}
+		})
+	}
+}
+
+func TestDiffIPAddressReference(t *testing.T) {
+	key := meta.RegionalKey("fr", "us-central1")
+	addrURL := "https://www.googleapis.com/compute/v1/projects/proj/regions/us-central1/addresses/addr"
+
+	makeNode := func(ip string) rnode.Node {
+		t.Helper()
+		fr := NewMutableForwardingRule("proj", key)
+		fr.Access(func(x *compute.ForwardingRule) {
+			x.Name = key.Name
+			x.IPAddress = ip
+			x.IPProtocol = "TCP"
+			x.Ports = []string{"80"}
+			x.NullFields = []string{"Labels"}
+		})
+		return nodetest.Build(t, fr, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		got    string
+		want   string
+		wantOp rnode.Operation
+	}{
+		{name: "reference to numeric IP", got: "1.2.3.4", want: addrURL, wantOp: rnode.OpNothing},
+		{name: "numeric IP changed", got: "1.2.3.4", want: "1.2.3.5", wantOp: rnode.OpRecreate},
+		{name: "numeric IP to reference", got: addrURL, want: "1.2.3.4", wantOp: rnode.OpRecreate},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pd, err := makeNode(tc.want).Diff(makeNode(tc.got))
+			if err != nil {
+				t.Fatalf("Diff() = _, %v", err)
+			}
+			if pd.Operation != tc.wantOp {
+				t.Errorf("Diff().Operation = %s, want %s (why: %s)", pd.Operation, tc.wantOp, pd.Why)
+			}
+		})
+	}
+}
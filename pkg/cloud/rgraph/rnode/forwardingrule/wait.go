@@ -0,0 +1,56 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwardingrule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+// ConditionProgrammed is the condition signaled by
+// NewWaitProgrammedAction.
+const ConditionProgrammed = "Programmed"
+
+// NewWaitProgrammedAction returns an Action that waits for the
+// ForwardingRule to be programmed, i.e. to have an IP address assigned. It
+// signals exec.NewReadyEvent(id, ConditionProgrammed).
+func NewWaitProgrammedAction(id *cloud.ResourceID, opts exec.WaitOptions) exec.Action {
+	return exec.NewWaitAction(id, ConditionProgrammed, func(ctx context.Context, cl cloud.Cloud) (bool, error) {
+		var (
+			fr  *compute.ForwardingRule
+			err error
+		)
+		opt := cloud.ForceProjectID(id.ProjectID)
+		switch id.Key.Type() {
+		case meta.Global:
+			fr, err = cl.GlobalForwardingRules().Get(ctx, id.Key, opt)
+		case meta.Regional:
+			fr, err = cl.ForwardingRules().Get(ctx, id.Key, opt)
+		default:
+			return false, fmt.Errorf("invalid key type")
+		}
+		if err != nil {
+			return false, err
+		}
+		return fr.IPAddress != "", nil
+	}, opts)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// OutRefs returns the .Network of the Gateway. Addresses are literal IPs and
+// the certificates, policies and Subnetwork are passed through to the API
+// as-is. Routes reference the Gateway.
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	if b.resource == nil {
+		return nil, nil
+	}
+	obj, err := b.resource.ToGA()
+	if err != nil || obj.Network == "" {
+		return nil, nil
+	}
+	id, err := cloud.ParseResourceURL(obj.Network)
+	if err != nil {
+		return nil, fmt.Errorf("gatewayNode: Network: %w", err)
+	}
+	if id.APIGroup == "" {
+		// The Network is given as a relative name, e.g.
+		// "projects/p/global/networks/n".
+		id.APIGroup = meta.APIGroupCompute
+	}
+	return []rnode.ResourceRef{{
+		From: b.resource.ResourceID(),
+		Path: api.Path{}.Pointer().Field("Network"),
+		To:   id,
+	}}, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/networkservices/v1"
+)
+
+const projectID = "proj-1"
+
+func TestGatewaySchema(t *testing.T) {
+	x := NewMutableGateway(projectID, meta.GlobalKey("key-1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func makeNode(t *testing.T, id *cloud.ResourceID, f func(x *networkservices.Gateway)) rnode.Node {
+	t.Helper()
+
+	m := NewMutableGateway(id.ProjectID, id.Key)
+	err := m.Access(func(x *networkservices.Gateway) {
+		x.Name = id.Key.Name
+		x.Type = "SECURE_WEB_GATEWAY"
+		x.Ports = []int64{443}
+		x.Addresses = []string{"10.0.0.1"}
+		x.CertificateUrls = []string{"projects/proj-1/locations/us-central1/certificates/cert1"}
+		if f != nil {
+			f(x)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+	return nodetest.Build(t, m, NewBuilderWithResource)
+}
+
+func TestDiff(t *testing.T) {
+	id := ID(projectID, meta.LocationKey("gw", "us-central1"))
+
+	for _, tc := range []struct {
+		name   string
+		f      func(x *networkservices.Gateway)
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "no diff",
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "certificates",
+			f: func(x *networkservices.Gateway) {
+				x.CertificateUrls = append(x.CertificateUrls, "projects/proj-1/locations/us-central1/certificates/cert2")
+			},
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "labels",
+			f:      func(x *networkservices.Gateway) { x.Labels = map[string]string{"a": "b"} },
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "ports",
+			f:      func(x *networkservices.Gateway) { x.Ports = []int64{8443} },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "addresses",
+			f:      func(x *networkservices.Gateway) { x.Addresses = []string{"10.0.0.2"} },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "scope",
+			f:      func(x *networkservices.Gateway) { x.Scope = "scope" },
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := makeNode(t, id, nil)
+			want := makeNode(t, id, tc.f)
+			p, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Errorf("Diff() = %v (%s), want %v", p.Operation, p.Why, tc.wantOp)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	id := ID(projectID, meta.LocationKey("gw", "us-central1"))
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: projectID})
+	if err := mock.Gateways().Insert(ctx, id.Key, &networkservices.Gateway{Name: id.Key.Name}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+
+	got := makeNode(t, id, nil)
+	want := makeNode(t, id, func(x *networkservices.Gateway) { x.Labels = map[string]string{"a": "b"} })
+	p, err := want.Diff(got)
+	if err != nil {
+		t.Fatalf("Diff() = %v, want nil", err)
+	}
+	want.Plan().Set(*p)
+	actions, err := want.Actions(got)
+	if err != nil {
+		t.Fatalf("Actions() = %v, want nil", err)
+	}
+	for _, a := range actions {
+		if _, err := a.Run(ctx, mock); err != nil {
+			t.Fatalf("%v.Run() = %v, want nil", a, err)
+		}
+	}
+	if calls := mock.Calls().Matching(cloud.MockCall{Service: "Gateways", Operation: "Patch", Key: id.Key}); len(calls) != 1 {
+		t.Errorf("calls to Gateways.Patch = %v, want 1", calls)
+	}
+	gw, err := mock.Gateways().Get(ctx, id.Key)
+	if err != nil || gw.Labels["a"] != "b" {
+		t.Errorf("Get() = %+v, %v; want Labels[a] = b", gw, err)
+	}
+}
+
+func TestOutRefs(t *testing.T) {
+	id := ID(projectID, meta.LocationKey("gw", "us-central1"))
+	netID := network.ID(projectID, meta.GlobalKey("vpc"))
+
+	n := makeNode(t, id, func(x *networkservices.Gateway) {
+		x.Network = "projects/proj-1/global/networks/vpc"
+	})
+	got, err := n.Builder().OutRefs()
+	if err != nil {
+		t.Fatalf("OutRefs() = %v, want nil", err)
+	}
+	want := []rnode.ResourceRef{
+		{From: id, To: netID, Path: api.Path{}.Pointer().Field("Network")},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("OutRefs diff = -got,+want: %s", diff)
+	}
+}
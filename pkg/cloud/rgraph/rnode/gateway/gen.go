@@ -0,0 +1,236 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/networkservices/v1"
+	beta "google.golang.org/api/networkservices/v1beta1"
+)
+
+// ID returns the ResourceID of the Gateway.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "gateways",
+		APIGroup:  meta.APIGroupNetworkServices,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableGateway = api.MutableResource[networkservices.Gateway, api.PlaceholderType, beta.Gateway]
+
+func NewMutableGateway(project string, key *meta.Key) MutableGateway {
+	id := ID(project, key)
+	return api.NewResource[networkservices.Gateway, api.PlaceholderType, beta.Gateway](id, &typeTrait{})
+}
+
+type Gateway = api.Resource[networkservices.Gateway, api.PlaceholderType, beta.Gateway]
+
+// https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.gateways
+type typeTrait struct {
+	api.BaseTypeTrait[networkservices.Gateway, api.PlaceholderType, beta.Gateway]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreateTime"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("UpdateTime"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Addresses"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("CertificateUrls"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("GatewaySecurityPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Labels"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Network"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Scope"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("ServerTlsPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Subnetwork"))
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[networkservices.Gateway, api.PlaceholderType, beta.Gateway] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[networkservices.Gateway, api.PlaceholderType, beta.Gateway] {
+	return &rnode.GetFuncs[networkservices.Gateway, api.PlaceholderType, beta.Gateway]{
+		GA: rnode.GetFuncsByScope[networkservices.Gateway]{
+			Global:   gcp.Gateways().Get,
+			Regional: gcp.Gateways().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.Gateway]{
+			Global:   gcp.BetaGateways().Get,
+			Regional: gcp.BetaGateways().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[networkservices.Gateway, api.PlaceholderType, beta.Gateway] {
+	return &rnode.CreateFuncs[networkservices.Gateway, api.PlaceholderType, beta.Gateway]{
+		GA: rnode.CreateFuncsByScope[networkservices.Gateway]{
+			Global:   gcp.Gateways().Insert,
+			Regional: gcp.Gateways().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.Gateway]{
+			Global:   gcp.BetaGateways().Insert,
+			Regional: gcp.BetaGateways().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[networkservices.Gateway, api.PlaceholderType, beta.Gateway] {
+	return &rnode.UpdateFuncs[networkservices.Gateway, api.PlaceholderType, beta.Gateway]{
+		GA: rnode.UpdateFuncsByScope[networkservices.Gateway]{
+			Global:   gcp.Gateways().Patch,
+			Regional: gcp.Gateways().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.Gateway]{
+			Global:   gcp.BetaGateways().Patch,
+			Regional: gcp.BetaGateways().Patch,
+		},
+		Options: rnode.UpdateFuncsNoFingerprint,
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[networkservices.Gateway, api.PlaceholderType, beta.Gateway] {
+	return &rnode.DeleteFuncs[networkservices.Gateway, api.PlaceholderType, beta.Gateway]{
+		GA: rnode.DeleteFuncsByScope[networkservices.Gateway]{
+			Global:   gcp.Gateways().Delete,
+			Regional: gcp.Gateways().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.Gateway]{
+			Global:   gcp.BetaGateways().Delete,
+			Regional: gcp.BetaGateways().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r Gateway) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource Gateway
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(Gateway)
+	if !ok {
+		return fmt.Errorf("cannot set Gateway from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[networkservices.Gateway, api.PlaceholderType, beta.Gateway](ctx, gcp, "Gateway", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("Gateway %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &gatewayNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for Gateway.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate).
+	SetFields(rnode.FieldUpdate, "CertificateUrls", "Description", "GatewaySecurityPolicy", "Labels", "ServerTlsPolicy")
+
+type gatewayNode struct {
+	rnode.NodeBase
+	resource Gateway
+}
+
+var _ rnode.Node = (*gatewayNode)(nil)
+
+func (n *gatewayNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *gatewayNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*gatewayNode)
+	if !ok {
+		return nil, fmt.Errorf("GatewayNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("GatewayNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("Gateway", diff)
+}
+
+func (n *gatewayNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[networkservices.Gateway, api.PlaceholderType, beta.Gateway](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[networkservices.Gateway, api.PlaceholderType, beta.Gateway](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[networkservices.Gateway, api.PlaceholderType, beta.Gateway](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[networkservices.Gateway, api.PlaceholderType, beta.Gateway](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("GatewayNode: invalid plan op %s", op)
+}
+
+func (n *gatewayNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
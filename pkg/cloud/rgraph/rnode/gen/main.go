@@ -0,0 +1,382 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Generator for the rnode boilerplate (resource types, ops, builder and node)
+// from the meta service descriptors and the per-type specs in specs.go. Each
+// type is written to <out>/<package>/gen.go. You must regenerate the code
+// after modifying specs.go:
+//
+//	$ go run ./pkg/cloud/rgraph/rnode/gen
+//
+// New types must also be registered in rnode/all.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+var flags = struct {
+	out string
+}{}
+
+func init() {
+	flag.StringVar(&flags.out, "out", "pkg/cloud/rgraph/rnode", "directory containing the rnode packages")
+}
+
+// commonOutputOnly are the [Output Only] fields set on (most) resources. They
+// are added to the type traits if the object has the field.
+var commonOutputOnly = []string{
+	"Fingerprint",
+	"CreationTimestamp",
+	"CreateTime",
+	"Id",
+	"Kind",
+	"Region",
+	"SelfLink",
+	"UpdateTime",
+}
+
+// versionInfo is a version of the resource API.
+type versionInfo struct {
+	// Title is the field in the rnode funcs struct (GA, Alpha, Beta).
+	Title string
+	// Type is the Go type of the object, e.g. "compute.HealthCheck".
+	Type string
+	// Scopes maps the key type (Global, Regional, Zonal) to the wrapper
+	// service in cloud.Cloud, e.g. "BetaRegionHealthChecks".
+	Scopes []scopeInfo
+}
+
+// scopeOrder is the order of the scopes in the generated funcs.
+var scopeOrder = map[string]int{"Global": 0, "Regional": 1, "Zonal": 2}
+
+type scopeInfo struct {
+	Scope   string
+	Service string
+}
+
+// typeInfo is the template data for a spec.
+type typeInfo struct {
+	Package  string
+	Object   string
+	NodeType string
+	Resource string
+	APIGroup string
+	DocURL   string
+
+	Imports    []string
+	TypeParams string
+	Versions   []versionInfo
+
+	// UpdateMethod is the generic update method (Update or Patch). Empty if
+	// the resource cannot be updated.
+	UpdateMethod  string
+	NoFingerprint bool
+
+	OutputOnly []string
+	// AllowZeroValue are the api.Path expressions of the fields.
+	AllowZeroValue  []string
+	UpdateFields    []string
+	UpdateAllFields bool
+	// Refs are the reference fields.
+	Refs []refInfo
+
+	CustomUpdate  bool
+	CustomOutRefs bool
+}
+
+type refInfo struct {
+	// Path is the api.Path expression of the field.
+	Path     string
+	Resource string
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func newTypeInfo(s *spec) (*typeInfo, error) {
+	apiGroup := s.APIGroup
+	if apiGroup == "" {
+		apiGroup = meta.APIGroupCompute
+	}
+	objType := reflect.TypeOf(s.Object)
+	if objType == nil || objType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s: Object must be a struct, got %T", s.Package, s.Object)
+	}
+
+	ti := &typeInfo{
+		Package:         s.Package,
+		Resource:        s.Resource,
+		DocURL:          s.DocURL,
+		UpdateFields:    s.UpdateFields,
+		UpdateAllFields: s.UpdateAllFields,
+		CustomUpdate:    s.CustomUpdate,
+		CustomOutRefs:   s.CustomOutRefs,
+	}
+	switch apiGroup {
+	case meta.APIGroupCompute:
+		ti.APIGroup = "meta.APIGroupCompute"
+	case meta.APIGroupNetworkServices:
+		ti.APIGroup = "meta.APIGroupNetworkServices"
+	default:
+		return nil, fmt.Errorf("%s: unsupported APIGroup %q", s.Package, apiGroup)
+	}
+
+	byVersion := map[meta.Version][]*meta.ServiceInfo{}
+	for _, si := range meta.AllServices {
+		if si.Resource != s.Resource || si.APIGroup != apiGroup {
+			continue
+		}
+		if ti.Object == "" {
+			ti.Object = si.Object
+		} else if ti.Object != si.Object {
+			return nil, fmt.Errorf("%s: services for %q have different objects (%q, %q)", s.Package, s.Resource, ti.Object, si.Object)
+		}
+		byVersion[si.Version()] = append(byVersion[si.Version()], si)
+	}
+	if len(byVersion[meta.VersionGA]) == 0 {
+		return nil, fmt.Errorf("%s: no GA service for %s resource %q", s.Package, apiGroup, s.Resource)
+	}
+	ti.NodeType = lowerFirst(ti.Object) + "Node"
+
+	pkgs := map[meta.Version]struct{ path, alias string }{}
+	if apiGroup == meta.APIGroupCompute {
+		pkgs[meta.VersionGA] = struct{ path, alias string }{"google.golang.org/api/compute/v1", "compute"}
+		pkgs[meta.VersionAlpha] = struct{ path, alias string }{"google.golang.org/api/compute/v0.alpha", "alpha"}
+		pkgs[meta.VersionBeta] = struct{ path, alias string }{"google.golang.org/api/compute/v0.beta", "beta"}
+	} else {
+		pkgs[meta.VersionGA] = struct{ path, alias string }{"google.golang.org/api/networkservices/v1", "networkservices"}
+		pkgs[meta.VersionBeta] = struct{ path, alias string }{"google.golang.org/api/networkservices/v1beta1", "beta"}
+	}
+
+	var (
+		typeParams []string
+		updates    = map[string]int{}
+	)
+	for _, v := range []struct {
+		version meta.Version
+		title   string
+	}{
+		{meta.VersionGA, "GA"},
+		{meta.VersionAlpha, "Alpha"},
+		{meta.VersionBeta, "Beta"},
+	} {
+		services := byVersion[v.version]
+		if len(services) == 0 {
+			typeParams = append(typeParams, "api.PlaceholderType")
+			continue
+		}
+		pkg := pkgs[v.version]
+		if pkg.alias == "compute" || pkg.alias == "networkservices" {
+			ti.Imports = append(ti.Imports, fmt.Sprintf("%q", pkg.path))
+		} else {
+			ti.Imports = append(ti.Imports, fmt.Sprintf("%s %q", pkg.alias, pkg.path))
+		}
+		vi := versionInfo{Title: v.title, Type: pkg.alias + "." + ti.Object}
+		typeParams = append(typeParams, vi.Type)
+		for _, si := range services {
+			var scope string
+			switch {
+			case si.KeyIsGlobal():
+				scope = "Global"
+			case si.KeyIsRegional():
+				scope = "Regional"
+			case si.KeyIsZonal():
+				scope = "Zonal"
+			}
+			vi.Scopes = append(vi.Scopes, scopeInfo{Scope: scope, Service: si.WrapType()})
+			if s.LocationScoped {
+				if scope != "Global" {
+					return nil, fmt.Errorf("%s: LocationScoped service %s is %s", s.Package, si.WrapType(), scope)
+				}
+				vi.Scopes = append(vi.Scopes, scopeInfo{Scope: "Regional", Service: si.WrapType()})
+			}
+			for _, m := range si.Methods() {
+				if m.Name() == "Update" || m.Name() == "Patch" {
+					updates[m.Name()]++
+				}
+			}
+		}
+		sort.Slice(vi.Scopes, func(i, j int) bool { return scopeOrder[vi.Scopes[i].Scope] < scopeOrder[vi.Scopes[j].Scope] })
+		ti.Versions = append(ti.Versions, vi)
+	}
+	ti.TypeParams = strings.Join(typeParams, ", ")
+
+	// The generic update must be available for all of the services.
+	var nServices int
+	for _, services := range byVersion {
+		nServices += len(services)
+	}
+	for _, m := range []string{"Update", "Patch"} {
+		if updates[m] == nServices {
+			ti.UpdateMethod = m
+			break
+		}
+	}
+	switch {
+	case s.CustomUpdate && (len(s.UpdateFields) > 0 || s.UpdateAllFields):
+		return nil, fmt.Errorf("%s: UpdateFields cannot be used with CustomUpdate", s.Package)
+	case s.CustomUpdate:
+		ti.UpdateMethod = ""
+	case (len(s.UpdateFields) > 0 || s.UpdateAllFields) && ti.UpdateMethod == "":
+		return nil, fmt.Errorf("%s: UpdateFields is set but not all services have Update() or Patch()", s.Package)
+	}
+	_, hasFingerprint := objType.FieldByName("Fingerprint")
+	ti.NoFingerprint = !hasFingerprint
+
+	for _, f := range commonOutputOnly {
+		if _, ok := objType.FieldByName(f); ok {
+			ti.OutputOnly = append(ti.OutputOnly, f)
+		}
+	}
+	ti.OutputOnly = append(ti.OutputOnly, s.OutputOnly...)
+	for _, fields := range [][]string{ti.OutputOnly, s.UpdateFields} {
+		for _, f := range fields {
+			if _, ok := objType.FieldByName(f); !ok {
+				return nil, fmt.Errorf("%s: %s has no field %q", s.Package, objType, f)
+			}
+		}
+	}
+
+	for _, f := range s.AllowZeroValue {
+		path, err := genPath(objType, f, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Package, err)
+		}
+		ti.AllowZeroValue = append(ti.AllowZeroValue, path)
+	}
+	if s.CustomOutRefs && len(s.Refs) > 0 {
+		return nil, fmt.Errorf("%s: Refs cannot be used with CustomOutRefs", s.Package)
+	}
+	for _, ref := range s.Refs {
+		path, err := genPath(objType, ref.Path, true)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Package, err)
+		}
+		ti.Refs = append(ti.Refs, refInfo{Path: path, Resource: ref.Resource})
+	}
+
+	return ti, nil
+}
+
+// refSegment is an element of a field path, e.g. "Backends[]".
+type refSegment struct {
+	field string
+	slice bool
+}
+
+func parsePath(ref string) []refSegment {
+	var ret []refSegment
+	for _, s := range strings.Split(ref, ".") {
+		ret = append(ret, refSegment{
+			field: strings.TrimSuffix(s, "[]"),
+			slice: strings.HasSuffix(s, "[]"),
+		})
+	}
+	return ret
+}
+
+// genPath returns the api.Path expression for the field, with wildcards for
+// the slice elements, e.g. `api.Path{}.Pointer().Field("Backends").AnySliceIndex().Pointer().Field("Group")`.
+// If isRef, the field must be a string.
+func genPath(t reflect.Type, ref string, isRef bool) (string, error) {
+	path := "api.Path{}.Pointer()"
+	segs := parsePath(ref)
+	for i, seg := range segs {
+		last := i == len(segs)-1
+
+		f, ok := t.FieldByName(seg.field)
+		if !ok {
+			return "", fmt.Errorf("ref %q: %s has no field %q", ref, t, seg.field)
+		}
+		ft := f.Type
+		path = fmt.Sprintf("%s.Field(%q)", path, seg.field)
+
+		if seg.slice {
+			if ft.Kind() != reflect.Slice {
+				return "", fmt.Errorf("ref %q: %s.%s is not a slice", ref, t, seg.field)
+			}
+			ft = ft.Elem()
+			path += ".AnySliceIndex()"
+		}
+		if last {
+			if isRef && ft.Kind() != reflect.String {
+				return "", fmt.Errorf("ref %q: %s.%s is not a string", ref, t, seg.field)
+			}
+			break
+		}
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+			path += ".Pointer()"
+		}
+		if ft.Kind() != reflect.Struct {
+			return "", fmt.Errorf("ref %q: %s.%s is not a struct", ref, t, seg.field)
+		}
+		t = ft
+	}
+	return path, nil
+}
+
+// generate returns the formatted source for the spec.
+func generate(s *spec) ([]byte, error) {
+	ti, err := newTypeInfo(s)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, ti); err != nil {
+		return nil, err
+	}
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: gofmt: %w\n%s", s.Package, err, buf.String())
+	}
+	return out, nil
+}
+
+func main() {
+	flag.Parse()
+
+	for i := range specs {
+		s := &specs[i]
+		out, err := generate(s)
+		if err != nil {
+			log.Fatal(err)
+		}
+		file := filepath.Join(flags.out, s.Package, "gen.go")
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(file, out, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
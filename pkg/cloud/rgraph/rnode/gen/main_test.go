@@ -0,0 +1,79 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestGeneratedUpToDate checks that the checked in code matches specs.go.
+func TestGeneratedUpToDate(t *testing.T) {
+	for i := range specs {
+		s := &specs[i]
+		want, err := generate(s)
+		if err != nil {
+			t.Fatalf("generate(%q) = %v, want nil", s.Package, err)
+		}
+		file := filepath.Join("..", s.Package, "gen.go")
+		got, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("ReadFile(%q) = %v, want nil", file, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date, run \"go run ./pkg/cloud/rgraph/rnode/gen\"", file)
+		}
+	}
+}
+
+func TestGenPath(t *testing.T) {
+	type backend struct{ Group string }
+	type object struct {
+		Network      string
+		HealthChecks []string
+		Backends     []*backend
+		Port         int
+	}
+	for _, tc := range []struct {
+		ref      string
+		isRef    bool
+		wantPath string
+		wantErr  bool
+	}{
+		{ref: "Network", isRef: true, wantPath: `api.Path{}.Pointer().Field("Network")`},
+		{ref: "HealthChecks[]", isRef: true, wantPath: `api.Path{}.Pointer().Field("HealthChecks").AnySliceIndex()`},
+		{ref: "Backends[].Group", isRef: true, wantPath: `api.Path{}.Pointer().Field("Backends").AnySliceIndex().Pointer().Field("Group")`},
+		{ref: "Backends", wantPath: `api.Path{}.Pointer().Field("Backends")`},
+		{ref: "Port", wantPath: `api.Path{}.Pointer().Field("Port")`},
+		{ref: "Missing", isRef: true, wantErr: true},
+		{ref: "Port", isRef: true, wantErr: true},
+		{ref: "Network[]", isRef: true, wantErr: true},
+		{ref: "Backends[]", isRef: true, wantErr: true},
+	} {
+		path, err := genPath(reflect.TypeOf(object{}), tc.ref, tc.isRef)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("genPath(%q, %t) = %v; gotErr = %t, want %t", tc.ref, tc.isRef, err, gotErr, tc.wantErr)
+			continue
+		}
+		if err == nil && path != tc.wantPath {
+			t.Errorf("genPath(%q, %t) = %s, want %s", tc.ref, tc.isRef, path, tc.wantPath)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/networkservices/v1"
+)
+
+// spec describes an rnode type to generate. The API versions, scopes and
+// generic Update method come from the meta.ServiceInfo descriptors for the
+// Resource.
+type spec struct {
+	// Package is the name of the rnode package (and directory), e.g.
+	// "healthcheck".
+	Package string
+	// Resource is the plural resource name in the API URL (as in
+	// meta.ServiceInfo.Resource), e.g. "healthChecks".
+	Resource string
+	// APIGroup of the resource. Defaults to meta.APIGroupCompute.
+	APIGroup meta.APIGroup
+	// Object is a zero value of the GA API object. It is used to resolve
+	// the field paths below.
+	Object any
+	// DocURL is the link to the API reference for the resource.
+	DocURL string
+
+	// Refs are the fields that reference other resources by URL.
+	Refs []refSpec
+	// UpdateFields are the top-level fields that are changed in place with
+	// the generic update (Update() or Patch()). A diff in any other field
+	// recreates the resource (see rnode.DiffPolicy).
+	UpdateFields []string
+	// UpdateAllFields is set if all of the fields can be changed in place
+	// with the generic update.
+	UpdateAllFields bool
+	// OutputOnly fields in addition to the common ones (see
+	// commonOutputOnly).
+	OutputOnly []string
+	// AllowZeroValue fields. Nested fields use the same syntax as the
+	// refSpec.Path, e.g. "Rules[].Matches".
+	AllowZeroValue []string
+	// LocationScoped is set for the resources in location-scoped APIs. The
+	// same service handles the global and regional keys (see
+	// meta.LocationKey()).
+	LocationScoped bool
+
+	// The hooks below leave out parts of the generated code. These must be
+	// written by hand in the package.
+
+	// CustomUpdate is set for the resources that are changed in place with
+	// specific methods (e.g. setTarget()) rather than a generic update. The
+	// node's Diff() and Actions() and the default diffPolicy are not
+	// generated. UpdateFuncs() returns nil.
+	CustomUpdate bool
+	// CustomOutRefs is set if the references cannot be described by Refs.
+	// builder.OutRefs() is not generated.
+	CustomOutRefs bool
+}
+
+// refSpec is a field that references another resource.
+type refSpec struct {
+	// Path of the field, e.g. "Backends[].Group". A "[]" suffix iterates
+	// over the elements of a slice.
+	Path string
+	// Resource that is referenced, e.g. "backendServices". Empty for any
+	// resource.
+	Resource string
+}
+
+// specs are the generated rnode types. After changing this list, regenerate
+// the code:
+//
+//	$ go run ./pkg/cloud/rgraph/rnode/gen
+var specs = []spec{
+	{
+		Package:  "healthcheck",
+		Resource: "healthChecks",
+		Object:   compute.HealthCheck{},
+		DocURL:   "https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks",
+	},
+	{
+		Package:    "sslcertificate",
+		Resource:   "sslCertificates",
+		Object:     compute.SslCertificate{},
+		DocURL:     "https://cloud.google.com/compute/docs/reference/rest/v1/sslCertificates",
+		OutputOnly: []string{"ExpireTime", "SubjectAlternativeNames"},
+	},
+	{
+		Package:    "sslpolicy",
+		Resource:   "sslPolicies",
+		Object:     compute.SslPolicy{},
+		DocURL:     "https://cloud.google.com/compute/docs/reference/rest/v1/sslPolicies",
+		OutputOnly: []string{"EnabledFeatures", "Warnings"},
+	},
+	{
+		Package:  "network",
+		Resource: "networks",
+		Object:   compute.Network{},
+		DocURL:   "https://cloud.google.com/compute/docs/reference/rest/v1/networks",
+		// Changing any other field (e.g. .AutoCreateSubnetworks) requires a
+		// recreate. This will only be done for Networks that are
+		// OwnershipManaged, which should be limited to test environments.
+		UpdateFields: []string{
+			"EnableUlaInternalIpv6",
+			"InternalIpv6Range",
+			"Mtu",
+			"NetworkFirewallPolicyEnforcementOrder",
+			"RoutingConfig",
+		},
+		OutputOnly: []string{"FirewallPolicy", "GatewayIPv4", "Peerings", "SelfLinkWithId", "Subnetworks"},
+		AllowZeroValue: []string{
+			// AutoCreateSubnetworks = false is a custom mode network.
+			"AutoCreateSubnetworks",
+			"Description",
+			"EnableUlaInternalIpv6",
+			// Deprecated legacy network range.
+			"IPv4Range",
+			"InternalIpv6Range",
+			"Mtu",
+			"NetworkFirewallPolicyEnforcementOrder",
+			"RoutingConfig",
+		},
+	},
+	{
+		Package:       "forwardingrule",
+		Resource:      "forwardingRules",
+		Object:        compute.ForwardingRule{},
+		DocURL:        "https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules",
+		OutputOnly:    []string{"BaseForwardingRule", "LabelFingerprint", "PscConnectionId", "PscConnectionStatus", "ServiceName"},
+		CustomUpdate:  true,
+		CustomOutRefs: true, // .IPAddress can be a numeric IP.
+	},
+	{
+		Package:  "targethttpsproxy",
+		Resource: "targetHttpsProxies",
+		Object:   compute.TargetHttpsProxy{},
+		DocURL:   "https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpsProxies",
+		// CertificateMap is a Certificate Manager resource, which is not
+		// represented in the graph and is passed through to the API as-is.
+		Refs: []refSpec{
+			{"UrlMap", "urlMaps"},
+			{"SslCertificates[]", "sslCertificates"},
+			{"SslPolicy", "sslPolicies"},
+		},
+		CustomUpdate: true,
+	},
+	{
+		Package:  "targetsslproxy",
+		Resource: "targetSslProxies",
+		Object:   compute.TargetSslProxy{},
+		DocURL:   "https://cloud.google.com/compute/docs/reference/rest/v1/targetSslProxies",
+		// SslCertificates, CertificateMap and SslPolicy are not represented
+		// in the graph and are passed through to the API as-is.
+		Refs: []refSpec{{"Service", "backendServices"}},
+		AllowZeroValue: []string{
+			// The certificates are either in .SslCertificates or
+			// .CertificateMap.
+			"CertificateMap",
+			"Description",
+			// .ProxyHeader defaults to NONE.
+			"ProxyHeader",
+			"SslCertificates",
+			"SslPolicy",
+		},
+		CustomUpdate: true,
+	},
+	{
+		Package:  "targettcpproxy",
+		Resource: "targetTcpProxies",
+		Object:   compute.TargetTcpProxy{},
+		DocURL:   "https://cloud.google.com/compute/docs/reference/rest/v1/targetTcpProxies",
+		Refs:     []refSpec{{"Service", "backendServices"}},
+		AllowZeroValue: []string{
+			"Description",
+			"ProxyBind",
+			// .ProxyHeader defaults to NONE.
+			"ProxyHeader",
+		},
+		CustomUpdate: true,
+	},
+	{
+		Package:         "grpcroute",
+		Resource:        "grpcRoutes",
+		APIGroup:        meta.APIGroupNetworkServices,
+		Object:          networkservices.GrpcRoute{},
+		DocURL:          "https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.grpcRoutes",
+		UpdateAllFields: true,
+		Refs: []refSpec{
+			{"Meshes[]", "meshes"},
+			{"Gateways[]", "gateways"},
+			{"Rules[].Action.Destinations[].ServiceName", "backendServices"},
+		},
+		AllowZeroValue: []string{
+			"Description",
+			"Gateways",
+			"Labels",
+			"Meshes",
+			"Rules[].Matches",
+			"Rules[].Action.Destinations",
+			"Rules[].Action.Destinations[].Weight",
+			"Rules[].Action.FaultInjectionPolicy",
+			"Rules[].Action.RetryPolicy",
+			"Rules[].Action.StatefulSessionAffinity",
+			"Rules[].Action.Timeout",
+		},
+	},
+	{
+		Package:         "httproute",
+		Resource:        "httpRoutes",
+		APIGroup:        meta.APIGroupNetworkServices,
+		Object:          networkservices.HttpRoute{},
+		DocURL:          "https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.httpRoutes",
+		UpdateAllFields: true,
+		Refs: []refSpec{
+			{"Meshes[]", "meshes"},
+			{"Gateways[]", "gateways"},
+			{"Rules[].Action.Destinations[].ServiceName", "backendServices"},
+			{"Rules[].Action.RequestMirrorPolicy.Destination.ServiceName", "backendServices"},
+		},
+		AllowZeroValue: []string{
+			"Description",
+			"Gateways",
+			"Labels",
+			"Meshes",
+			"Rules[].Matches",
+			"Rules[].Action.CorsPolicy",
+			"Rules[].Action.Destinations",
+			"Rules[].Action.Destinations[].Weight",
+			"Rules[].Action.FaultInjectionPolicy",
+			"Rules[].Action.Redirect",
+			"Rules[].Action.RequestHeaderModifier",
+			"Rules[].Action.RequestMirrorPolicy",
+			"Rules[].Action.ResponseHeaderModifier",
+			"Rules[].Action.RetryPolicy",
+			"Rules[].Action.StatefulSessionAffinity",
+			"Rules[].Action.Timeout",
+			"Rules[].Action.UrlRewrite",
+		},
+	},
+	{
+		Package:         "mesh",
+		Resource:        "meshes",
+		APIGroup:        meta.APIGroupNetworkServices,
+		Object:          networkservices.Mesh{},
+		DocURL:          "https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.meshes",
+		UpdateAllFields: true,
+		AllowZeroValue:  []string{"Description", "InterceptionPort", "Labels"},
+	},
+	{
+		Package:  "tcproute",
+		Resource: "tcpRoutes",
+		APIGroup: meta.APIGroupNetworkServices,
+		Object:   networkservices.TcpRoute{},
+		DocURL:   "https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.tcpRoutes",
+		Refs:     []refSpec{{"Rules[].Action.Destinations[].ServiceName", "backendServices"}},
+		AllowZeroValue: []string{
+			"Gateways",
+			"Labels",
+			"Meshes",
+			"Rules[].Matches",
+			"Rules[].Action.Destinations",
+			"Rules[].Action.Destinations[].Weight",
+			"Rules[].Action.OriginalDestination",
+		},
+	},
+	{
+		Package:  "gateway",
+		Resource: "gateways",
+		APIGroup: meta.APIGroupNetworkServices,
+		Object:   networkservices.Gateway{},
+		DocURL:   "https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.gateways",
+		// All other fields (e.g. Type, Scope, Ports, Addresses, Network)
+		// require the Gateway to be recreated.
+		UpdateFields: []string{
+			"CertificateUrls",
+			"Description",
+			"GatewaySecurityPolicy",
+			"Labels",
+			"ServerTlsPolicy",
+		},
+		// Fields that only apply to some gateway Types.
+		AllowZeroValue: []string{
+			"Addresses",
+			"CertificateUrls",
+			"Description",
+			"GatewaySecurityPolicy",
+			"Labels",
+			"Network",
+			"Scope",
+			"ServerTlsPolicy",
+			"Subnetwork",
+		},
+		LocationScoped: true,
+		// .Network may be a relative name.
+		CustomOutRefs: true,
+	},
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "text/template"
+
+var codeTemplate = template.Must(template.New("gen").Parse(`/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package {{.Package}}
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+{{- if not .CustomUpdate}}
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+{{- end}}
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+// ID returns the ResourceID of the {{.Object}}.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "{{.Resource}}",
+		APIGroup:  {{.APIGroup}},
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type Mutable{{.Object}} = api.MutableResource[{{.TypeParams}}]
+
+func NewMutable{{.Object}}(project string, key *meta.Key) Mutable{{.Object}} {
+	id := ID(project, key)
+	return api.NewResource[{{.TypeParams}}](id, &typeTrait{})
+}
+
+type {{.Object}} = api.Resource[{{.TypeParams}}]
+
+// {{.DocURL}}
+type typeTrait struct {
+	api.BaseTypeTrait[{{.TypeParams}}]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+{{- range .OutputOnly}}
+	dt.OutputOnly(api.Path{}.Pointer().Field("{{.}}"))
+{{- end}}
+{{- range .AllowZeroValue}}
+	dt.AllowZeroValue({{.}})
+{{- end}}
+{{- range .Refs}}
+	dt.Reference({{.Path}}, "{{.Resource}}")
+{{- end}}
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[{{.TypeParams}}] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[{{.TypeParams}}] {
+	return &rnode.GetFuncs[{{.TypeParams}}]{
+{{- range .Versions}}
+		{{.Title}}: rnode.GetFuncsByScope[{{.Type}}]{
+{{- range .Scopes}}
+			{{.Scope}}: gcp.{{.Service}}().Get,
+{{- end}}
+		},
+{{- end}}
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[{{.TypeParams}}] {
+	return &rnode.CreateFuncs[{{.TypeParams}}]{
+{{- range .Versions}}
+		{{.Title}}: rnode.CreateFuncsByScope[{{.Type}}]{
+{{- range .Scopes}}
+			{{.Scope}}: gcp.{{.Service}}().Insert,
+{{- end}}
+		},
+{{- end}}
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[{{.TypeParams}}] {
+{{- if .UpdateMethod}}
+	return &rnode.UpdateFuncs[{{.TypeParams}}]{
+{{- range .Versions}}
+		{{.Title}}: rnode.UpdateFuncsByScope[{{.Type}}]{
+{{- range .Scopes}}
+			{{.Scope}}: gcp.{{.Service}}().{{$.UpdateMethod}},
+{{- end}}
+		},
+{{- end}}
+{{- if .NoFingerprint}}
+		Options: rnode.UpdateFuncsNoFingerprint,
+{{- end}}
+	}
+{{- else}}
+	return nil // Does not support generic Update.
+{{- end}}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[{{.TypeParams}}] {
+	return &rnode.DeleteFuncs[{{.TypeParams}}]{
+{{- range .Versions}}
+		{{.Title}}: rnode.DeleteFuncsByScope[{{.Type}}]{
+{{- range .Scopes}}
+			{{.Scope}}: gcp.{{.Service}}().Delete,
+{{- end}}
+		},
+{{- end}}
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r {{.Object}}) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource {{.Object}}
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.({{.Object}})
+	if !ok {
+		return fmt.Errorf("cannot set {{.Object}} from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[{{.TypeParams}}](ctx, gcp, "{{.Object}}", &ops{}, &typeTrait{}, b)
+}
+{{- if not .CustomOutRefs}}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+{{- if .Refs}}
+	return rnode.OutRefsFromTraits[{{.TypeParams}}](b.resource, &typeTrait{})
+{{- else}}
+	// {{.Object}} does not have any outgoing resource references.
+	return nil, nil
+{{- end}}
+}
+{{- end}}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("{{.Object}} %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &{{.NodeType}}{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+{{- if not .CustomUpdate}}
+
+// diffPolicy is the default DiffPolicy for {{.Object}}.
+{{- if .UpdateAllFields}}
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldUpdate)
+{{- else}}
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate)
+{{- if .UpdateFields}}.
+	SetFields(rnode.FieldUpdate{{range .UpdateFields}}, "{{.}}"{{end}})
+{{- end}}
+{{- end}}
+{{- end}}
+
+type {{.NodeType}} struct {
+	rnode.NodeBase
+	resource {{.Object}}
+}
+
+var _ rnode.Node = (*{{.NodeType}})(nil)
+
+func (n *{{.NodeType}}) Resource() rnode.UntypedResource { return n.resource }
+{{- if not .CustomUpdate}}
+
+func (n *{{.NodeType}}) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*{{.NodeType}})
+	if !ok {
+		return nil, fmt.Errorf("{{.Object}}Node: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("{{.Object}}Node: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("{{.Object}}", diff)
+}
+
+func (n *{{.NodeType}}) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[{{.TypeParams}}](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[{{.TypeParams}}](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[{{.TypeParams}}](&ops{}, got, n, n.resource)
+{{- if .UpdateMethod}}
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[{{.TypeParams}}](&ops{}, got, n, n.resource)
+{{- end}}
+	}
+
+	return nil, fmt.Errorf("{{.Object}}Node: invalid plan op %s", op)
+}
+{{- end}}
+
+func (n *{{.NodeType}}) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
+`))
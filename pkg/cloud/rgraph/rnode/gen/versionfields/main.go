@@ -0,0 +1,169 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Generator for the version specific fields of the rnode resource types
+// (see api.VersionFields). The output is written to the rnode/all package
+// and a report of the fields (including the fields that have drifted
+// between versions) is printed with -report:
+//
+//	$ go generate ./pkg/cloud/rgraph/rnode/all
+//	$ go run ./pkg/cloud/rgraph/rnode/gen/versionfields -report -out /dev/null
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/networkservices/v1"
+	nsbeta "google.golang.org/api/networkservices/v1beta1"
+)
+
+var flags = struct {
+	out    string
+	report bool
+}{}
+
+func init() {
+	flag.StringVar(&flags.out, "out", "pkg/cloud/rgraph/rnode/all/version_fields_gen.go", "output file")
+	flag.BoolVar(&flags.report, "report", false, "print a report of the version specific fields")
+}
+
+// resources are the rnode types by meta.ServiceInfo.Resource. This must
+// match the type traits of the rnode packages.
+var resources = []struct {
+	resource string
+	compare  func() *api.VersionFields
+}{
+	{"addresses", api.CompareVersions[compute.Address, alpha.Address, beta.Address]},
+	{"backendServices", api.CompareVersions[compute.BackendService, alpha.BackendService, beta.BackendService]},
+	{"firewalls", api.CompareVersions[compute.Firewall, alpha.Firewall, beta.Firewall]},
+	{"forwardingRules", api.CompareVersions[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]},
+	{"gateways", api.CompareVersions[networkservices.Gateway, api.PlaceholderType, nsbeta.Gateway]},
+	{"grpcRoutes", api.CompareVersions[networkservices.GrpcRoute, api.PlaceholderType, nsbeta.GrpcRoute]},
+	{"healthChecks", api.CompareVersions[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]},
+	{"httpRoutes", api.CompareVersions[networkservices.HttpRoute, api.PlaceholderType, nsbeta.HttpRoute]},
+	{"instanceGroups", api.CompareVersions[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup]},
+	{"meshes", api.CompareVersions[networkservices.Mesh, api.PlaceholderType, nsbeta.Mesh]},
+	{"networks", api.CompareVersions[compute.Network, alpha.Network, beta.Network]},
+	{"networkEndpointGroups", api.CompareVersions[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup]},
+	{"networkFirewallPolicies", api.CompareVersions[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy]},
+	{"securityPolicies", api.CompareVersions[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy]},
+	{"serviceAttachments", api.CompareVersions[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment]},
+	{"subnetworks", api.CompareVersions[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork]},
+	{"targetHttpProxies", api.CompareVersions[compute.TargetHttpProxy, alpha.TargetHttpProxy, beta.TargetHttpProxy]},
+	{"targetHttpsProxies", api.CompareVersions[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]},
+	{"targetSslProxies", api.CompareVersions[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy]},
+	{"targetTcpProxies", api.CompareVersions[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy]},
+	{"tcpRoute", api.CompareVersions[networkservices.TcpRoute, api.PlaceholderType, nsbeta.TcpRoute]},
+	{"urlMaps", api.CompareVersions[compute.UrlMap, alpha.UrlMap, beta.UrlMap]},
+}
+
+const header = `/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen/versionfields".
+// Do not edit directly.
+
+package all
+
+import "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+
+`
+
+func pathLiteral(p api.Path) string {
+	var elems []string
+	for _, e := range p {
+		elems = append(elems, strconv.Quote(e))
+	}
+	return "{" + strings.Join(elems, ", ") + "}"
+}
+
+func writePaths(buf *bytes.Buffer, name string, paths []api.Path) {
+	if len(paths) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "%s: []api.Path{\n", name)
+	for _, p := range paths {
+		fmt.Fprintf(buf, "%s,\n", pathLiteral(p))
+	}
+	fmt.Fprintf(buf, "},\n")
+}
+
+// generate returns the formatted source and the report.
+func generate() ([]byte, string, error) {
+	var (
+		buf    bytes.Buffer
+		report strings.Builder
+	)
+	buf.WriteString(header)
+	buf.WriteString("// versionFields by resource.\n")
+	buf.WriteString("var versionFields = map[string]*api.VersionFields{\n")
+	for _, r := range resources {
+		vf := r.compare()
+		fmt.Fprintf(&report, "== %s\n%s", r.resource, vf.Report())
+
+		fmt.Fprintf(&buf, "%q: {\n", r.resource)
+		writePaths(&buf, "Alpha", vf.Alpha)
+		writePaths(&buf, "Beta", vf.Beta)
+		writePaths(&buf, "Drift", vf.Drift)
+		fmt.Fprintf(&buf, "},\n")
+	}
+	buf.WriteString("}\n")
+
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, "", fmt.Errorf("gofmt: %w\n%s", err, buf.String())
+	}
+	return out, report.String(), nil
+}
+
+func main() {
+	flag.Parse()
+
+	out, report, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if flags.report {
+		fmt.Print(report)
+	}
+	if err := os.WriteFile(flags.out, out, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestGeneratedUpToDate checks that the checked in code matches the API
+// types.
+func TestGeneratedUpToDate(t *testing.T) {
+	want, _, err := generate()
+	if err != nil {
+		t.Fatalf("generate() = %v, want nil", err)
+	}
+	const file = "../../all/version_fields_gen.go"
+	got, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) = %v, want nil", file, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date, run \"go generate ./pkg/cloud/rgraph/rnode/all\"", file)
+	}
+}
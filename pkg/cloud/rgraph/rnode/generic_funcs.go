@@ -38,6 +38,12 @@ type GenericOps[GA any, Alpha any, Beta any] interface {
 	DeleteFuncs(gcp cloud.Cloud) *DeleteFuncs[GA, Alpha, Beta]
 }
 
+// PatchOps is implemented by the GenericOps of resources that support
+// patch(). The returned UpdateFuncs call patch() instead of update().
+type PatchOps[GA any, Alpha any, Beta any] interface {
+	PatchFuncs(gcp cloud.Cloud) *UpdateFuncs[GA, Alpha, Beta]
+}
+
 // GetFuncsByScope dispatches the operation by the appropriate scope. Set the
 // field to nil if the scope is not supported.
 type GetFuncsByScope[T any] struct {
@@ -104,6 +110,32 @@ func (f *GetFuncs[GA, Alpha, Beta]) Do(
 	return current.Freeze()
 }
 
+// fingerprint returns the current .Fingerprint of the resource.
+func (f *GetFuncs[GA, Alpha, Beta]) fingerprint(ctx context.Context, ver meta.Version, id *cloud.ResourceID) (string, error) {
+	var (
+		raw any
+		err error
+	)
+	switch ver {
+	case meta.VersionGA:
+		raw, err = f.GA.Do(ctx, id.Key, cloud.ForceProjectID(id.ProjectID))
+	case meta.VersionAlpha:
+		raw, err = f.Alpha.Do(ctx, id.Key, cloud.ForceProjectID(id.ProjectID))
+	case meta.VersionBeta:
+		raw, err = f.Beta.Do(ctx, id.Key, cloud.ForceProjectID(id.ProjectID))
+	default:
+		return "", fmt.Errorf("getFuncs.fingerprint unsupported version %q", ver)
+	}
+	if err != nil {
+		return "", err
+	}
+	fv, err := fingerprintField(reflect.ValueOf(raw))
+	if err != nil {
+		return "", err
+	}
+	return fv.String(), nil
+}
+
 type CreateFuncsByScope[T any] struct {
 	Global   func(context.Context, *meta.Key, *T, ...cloud.Option) error
 	Regional func(context.Context, *meta.Key, *T, ...cloud.Option) error
@@ -190,6 +222,37 @@ func (s *UpdateFuncsByScope[T]) Do(ctx context.Context, key *meta.Key, x *T, opt
 	return fmt.Errorf("unsupported scope (key = %s)", key)
 }
 
+// MethodFuncs are the versions of a method that changes a resource in place
+// with a request, e.g. setTarget(). The request is built with the GA type and
+// converted to the type of the version that is called.
+type MethodFuncs[GA any, Alpha any, Beta any] struct {
+	GA    UpdateFuncsByScope[GA]
+	Alpha UpdateFuncsByScope[Alpha]
+	Beta  UpdateFuncsByScope[Beta]
+}
+
+// Do calls the method of version ver for the resource id with req.
+func (f *MethodFuncs[GA, Alpha, Beta]) Do(ctx context.Context, ver meta.Version, id *cloud.ResourceID, req *GA) error {
+	opt := cloud.ForceProjectID(id.ProjectID)
+	switch ver {
+	case meta.VersionGA:
+		return f.GA.Do(ctx, id.Key, req, opt)
+	case meta.VersionAlpha:
+		var x Alpha
+		if err := api.Convert(&x, req); err != nil {
+			return err
+		}
+		return f.Alpha.Do(ctx, id.Key, &x, opt)
+	case meta.VersionBeta:
+		var x Beta
+		if err := api.Convert(&x, req); err != nil {
+			return err
+		}
+		return f.Beta.Do(ctx, id.Key, &x, opt)
+	}
+	return fmt.Errorf("methodFuncs.do unsupported version %q", ver)
+}
+
 const (
 	// Resource does not have a .Fingerprint field. Note: this
 	// means that the resource is technically not compliant with
@@ -204,6 +267,10 @@ type UpdateFuncs[GA any, Alpha any, Beta any] struct {
 	Beta  UpdateFuncsByScope[Beta]
 
 	Options int
+	// FieldMask, if non-empty, limits the fields sent to the named top-level
+	// fields (see FieldMask()). This should only be used with patch()
+	// methods as update() replaces the entire resource.
+	FieldMask []string
 }
 
 func fingerprintField(v reflect.Value) (reflect.Value, error) {
@@ -246,6 +313,11 @@ func (f *UpdateFuncs[GA, Alpha, Beta]) Do(
 				fv.Set(reflect.ValueOf(fingerprint))
 			}
 		}
+		if len(f.FieldMask) > 0 {
+			if raw, err = maskFields(raw, f.FieldMask); err != nil {
+				return err
+			}
+		}
 		err = f.GA.Do(ctx, id.Key, raw, cloud.ForceProjectID(id.ProjectID))
 		if err != nil {
 			return err
@@ -264,6 +336,11 @@ func (f *UpdateFuncs[GA, Alpha, Beta]) Do(
 				fv.Set(reflect.ValueOf(fingerprint))
 			}
 		}
+		if len(f.FieldMask) > 0 {
+			if raw, err = maskFields(raw, f.FieldMask); err != nil {
+				return err
+			}
+		}
 		err = f.Alpha.Do(ctx, id.Key, raw, cloud.ForceProjectID(id.ProjectID))
 		if err != nil {
 			return err
@@ -282,6 +359,11 @@ func (f *UpdateFuncs[GA, Alpha, Beta]) Do(
 				fv.Set(reflect.ValueOf(fingerprint))
 			}
 		}
+		if len(f.FieldMask) > 0 {
+			if raw, err = maskFields(raw, f.FieldMask); err != nil {
+				return err
+			}
+		}
 		err = f.Beta.Do(ctx, id.Key, raw, cloud.ForceProjectID(id.ProjectID))
 		if err != nil {
 			return err
@@ -292,6 +374,50 @@ func (f *UpdateFuncs[GA, Alpha, Beta]) Do(
 	return fmt.Errorf("updateFuncs.do unsupported version %q", desired.Version())
 }
 
+// DoWithRetry calls Do(). If the update fails because the fingerprint is
+// stale (e.g. the resource was changed after it was read), the current
+// fingerprint is read with get and the update is retried once.
+func (f *UpdateFuncs[GA, Alpha, Beta]) DoWithRetry(
+	ctx context.Context,
+	fingerprint string,
+	id *cloud.ResourceID,
+	desired api.Resource[GA, Alpha, Beta],
+	get *GetFuncs[GA, Alpha, Beta],
+) error {
+	err := f.Do(ctx, fingerprint, id, desired)
+	if f.Options&UpdateFuncsNoFingerprint != 0 || !IsErrorFingerprintMismatch(err) {
+		return err
+	}
+	klog.V(2).Infof("update %s: fingerprint mismatch, retrying with the current fingerprint: %v", id, err)
+	cur, getErr := get.fingerprint(ctx, desired.Version(), id)
+	if getErr != nil {
+		return fmt.Errorf("%w (get current fingerprint: %v)", err, getErr)
+	}
+	return f.Do(ctx, cur, id, desired)
+}
+
+// resourceFingerprint returns the .Fingerprint of r. Returns "" if the
+// resource does not have a fingerprint.
+func resourceFingerprint[GA any, Alpha any, Beta any](r api.Resource[GA, Alpha, Beta]) string {
+	var raw any
+	switch r.Version() {
+	case meta.VersionGA:
+		raw, _ = r.ToGA()
+	case meta.VersionAlpha:
+		raw, _ = r.ToAlpha()
+	case meta.VersionBeta:
+		raw, _ = r.ToBeta()
+	}
+	if raw == nil {
+		return ""
+	}
+	fv, err := fingerprintField(reflect.ValueOf(raw))
+	if err != nil {
+		return ""
+	}
+	return fv.String()
+}
+
 type DeleteFuncsByScope[T any] struct {
 	Global   func(context.Context, *meta.Key, ...cloud.Option) error
 	Regional func(context.Context, *meta.Key, ...cloud.Option) error
@@ -331,7 +457,12 @@ func isErrorCode(err error, code int) bool {
 	return gerr.Code == code
 }
 
-func isErrorNotFound(err error) bool { return isErrorCode(err, 404) }
+// IsErrorNotFound is true if the error is 404 notFound.
+func IsErrorNotFound(err error) bool { return isErrorCode(err, 404) }
+
+// IsErrorFingerprintMismatch is true if the error is 412 conditionNotMet,
+// which is returned when the fingerprint in the request is stale.
+func IsErrorFingerprintMismatch(err error) bool { return isErrorCode(err, 412) }
 
 func GenericGet[GA any, Alpha any, Beta any](
 	ctx context.Context,
@@ -344,13 +475,12 @@ func GenericGet[GA any, Alpha any, Beta any](
 	// TODO: this method needs some audits, it may be incomplete.
 
 	if b.Version() == "" {
-		// TODO: handle this by returning an error.
-		panic("XXX")
+		return fmt.Errorf("genericGet %s: %s has no version", resourceName, b.ID())
 	}
 	r, err := ops.GetFuncs(gcp).Do(ctx, b.Version(), b.ID(), typeTrait)
 
 	switch {
-	case isErrorNotFound(err):
+	case IsErrorNotFound(err):
 		b.SetState(NodeDoesNotExist)
 		return nil // Not found is not an error condition.
 
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/googleapi"
+)
+
+type fpResource struct {
+	Name            string
+	Value           string
+	Fingerprint     string
+	NullFields      []string
+	ForceSendFields []string
+}
+
+func TestUpdateFuncsDoWithRetry(t *testing.T) {
+	id := globalID("res")
+
+	for _, tc := range []struct {
+		name        string
+		fingerprint string
+		current     string
+		noFp        bool
+		wantUpdates []string
+		wantErr     bool
+	}{
+		{
+			name:        "fingerprint is current",
+			fingerprint: "fp1",
+			current:     "fp1",
+			wantUpdates: []string{"fp1"},
+		},
+		{
+			name:        "stale fingerprint is retried",
+			fingerprint: "fp1",
+			current:     "fp2",
+			wantUpdates: []string{"fp1", "fp2"},
+		},
+		{
+			name:        "retried once",
+			fingerprint: "fp1",
+			current:     "fp2",
+			wantUpdates: []string{"fp1", "fp2"},
+			wantErr:     true,
+		},
+		{
+			name:        "no fingerprint",
+			noFp:        true,
+			current:     "fp2",
+			wantUpdates: []string{"ignored"},
+			wantErr:     true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Do() sets the fingerprint in the desired resource.
+			m := api.NewResource[fpResource, fpResource, fpResource](id, nil)
+			m.Access(func(x *fpResource) {
+				x.Value = "v"
+				x.Fingerprint = "ignored"
+			})
+			desired, err := m.Freeze()
+			if err != nil {
+				t.Fatalf("Freeze() = %v", err)
+			}
+
+			var gotUpdates []string
+			update := &UpdateFuncs[fpResource, fpResource, fpResource]{}
+			update.GA.Global = func(_ context.Context, _ *meta.Key, x *fpResource, _ ...cloud.Option) error {
+				gotUpdates = append(gotUpdates, x.Fingerprint)
+				if x.Fingerprint != tc.current || (tc.wantErr && len(gotUpdates) > 1) {
+					return &googleapi.Error{Code: 412}
+				}
+				return nil
+			}
+			if tc.noFp {
+				update.Options = UpdateFuncsNoFingerprint
+			}
+			get := &GetFuncs[fpResource, fpResource, fpResource]{}
+			get.GA.Global = func(context.Context, *meta.Key, ...cloud.Option) (*fpResource, error) {
+				return &fpResource{Name: "res", Fingerprint: tc.current}, nil
+			}
+
+			err = update.DoWithRetry(context.Background(), tc.fingerprint, id, desired, get)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("DoWithRetry() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if len(gotUpdates) != len(tc.wantUpdates) {
+				t.Fatalf("updates = %v, want %v", gotUpdates, tc.wantUpdates)
+			}
+			for i := range gotUpdates {
+				if gotUpdates[i] != tc.wantUpdates[i] {
+					t.Errorf("updates = %v, want %v", gotUpdates, tc.wantUpdates)
+				}
+			}
+		})
+	}
+}
+
+func TestMethodFuncsDo(t *testing.T) {
+	type alphaReq struct {
+		Value, AlphaValue string
+		NullFields        []string
+		ForceSendFields   []string
+	}
+	id := globalID("res")
+
+	var calls []string
+	f := &MethodFuncs[fpResource, alphaReq, fpResource]{}
+	f.GA.Global = func(_ context.Context, _ *meta.Key, x *fpResource, _ ...cloud.Option) error {
+		calls = append(calls, "ga:"+x.Value)
+		return nil
+	}
+	f.Alpha.Global = func(_ context.Context, _ *meta.Key, x *alphaReq, _ ...cloud.Option) error {
+		calls = append(calls, "alpha:"+x.Value)
+		return nil
+	}
+
+	ctx := context.Background()
+	req := &fpResource{Value: "v"}
+	for _, ver := range []meta.Version{meta.VersionGA, meta.VersionAlpha} {
+		if err := f.Do(ctx, ver, id, req); err != nil {
+			t.Errorf("Do(%s) = %v, want nil", ver, err)
+		}
+	}
+	// No Beta method.
+	if err := f.Do(ctx, meta.VersionBeta, id, req); err == nil {
+		t.Errorf("Do(%s) = nil, want error", meta.VersionBeta)
+	}
+	if got, want := strings.Join(calls, ","), "ga:v,alpha:v"; got != want {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+}
+
+func TestResourceFingerprint(t *testing.T) {
+	m := api.NewResource[fpResource, fpResource, fpResource](globalID("res"), nil)
+	m.Access(func(x *fpResource) {
+		x.Value = "v"
+		x.Fingerprint = "fp"
+	})
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v", err)
+	}
+	if got := resourceFingerprint(r); got != "fp" {
+		t.Errorf("resourceFingerprint() = %q, want %q", got, "fp")
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package grpcroute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/networkservices/v1"
+	beta "google.golang.org/api/networkservices/v1beta1"
+)
+
+// ID returns the ResourceID of the GrpcRoute.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "grpcRoutes",
+		APIGroup:  meta.APIGroupNetworkServices,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableGrpcRoute = api.MutableResource[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute]
+
+func NewMutableGrpcRoute(project string, key *meta.Key) MutableGrpcRoute {
+	id := ID(project, key)
+	return api.NewResource[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute](id, &typeTrait{})
+}
+
+type GrpcRoute = api.Resource[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute]
+
+// https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.grpcRoutes
+type typeTrait struct {
+	api.BaseTypeTrait[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreateTime"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("UpdateTime"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Gateways"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Labels"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Meshes"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Matches"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations").AnySliceIndex().Pointer().Field("Weight"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("FaultInjectionPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("RetryPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("StatefulSessionAffinity"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Timeout"))
+	dt.Reference(api.Path{}.Pointer().Field("Meshes").AnySliceIndex(), "meshes")
+	dt.Reference(api.Path{}.Pointer().Field("Gateways").AnySliceIndex(), "gateways")
+	dt.Reference(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations").AnySliceIndex().Pointer().Field("ServiceName"), "backendServices")
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute] {
+	return &rnode.GetFuncs[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute]{
+		GA: rnode.GetFuncsByScope[networkservices.GrpcRoute]{
+			Global: gcp.GrpcRoutes().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.GrpcRoute]{
+			Global: gcp.BetaGrpcRoutes().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute] {
+	return &rnode.CreateFuncs[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute]{
+		GA: rnode.CreateFuncsByScope[networkservices.GrpcRoute]{
+			Global: gcp.GrpcRoutes().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.GrpcRoute]{
+			Global: gcp.BetaGrpcRoutes().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute] {
+	return &rnode.UpdateFuncs[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute]{
+		GA: rnode.UpdateFuncsByScope[networkservices.GrpcRoute]{
+			Global: gcp.GrpcRoutes().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.GrpcRoute]{
+			Global: gcp.BetaGrpcRoutes().Patch,
+		},
+		Options: rnode.UpdateFuncsNoFingerprint,
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute] {
+	return &rnode.DeleteFuncs[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute]{
+		GA: rnode.DeleteFuncsByScope[networkservices.GrpcRoute]{
+			Global: gcp.GrpcRoutes().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.GrpcRoute]{
+			Global: gcp.BetaGrpcRoutes().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r GrpcRoute) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource GrpcRoute
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(GrpcRoute)
+	if !ok {
+		return fmt.Errorf("cannot set GrpcRoute from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute](ctx, gcp, "GrpcRoute", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	return rnode.OutRefsFromTraits[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute](b.resource, &typeTrait{})
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("GrpcRoute %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &grpcRouteNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for GrpcRoute.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldUpdate)
+
+type grpcRouteNode struct {
+	rnode.NodeBase
+	resource GrpcRoute
+}
+
+var _ rnode.Node = (*grpcRouteNode)(nil)
+
+func (n *grpcRouteNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *grpcRouteNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*grpcRouteNode)
+	if !ok {
+		return nil, fmt.Errorf("GrpcRouteNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("GrpcRouteNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("GrpcRoute", diff)
+}
+
+func (n *grpcRouteNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[networkservices.GrpcRoute, api.PlaceholderType, beta.GrpcRoute](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("GrpcRouteNode: invalid plan op %s", op)
+}
+
+func (n *grpcRouteNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package grpcroute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/mesh"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/networkservices/v1"
+)
+
+const projectID = "proj-1"
+
+func TestGrpcRouteSchema(t *testing.T) {
+	key := meta.GlobalKey("key-1")
+	x := NewMutableGrpcRoute(projectID, key)
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func makeNode(t *testing.T, id *cloud.ResourceID, f func(x *networkservices.GrpcRoute)) rnode.Node {
+	t.Helper()
+
+	m := NewMutableGrpcRoute(id.ProjectID, id.Key)
+	err := m.Access(func(x *networkservices.GrpcRoute) {
+		x.Name = id.Key.Name
+		x.Hostnames = []string{"example.com"}
+		x.Meshes = []string{mesh.ID(projectID, meta.GlobalKey("mesh")).SelfLink(meta.VersionGA)}
+		x.Rules = []*networkservices.GrpcRouteRouteRule{{
+			Action: &networkservices.GrpcRouteRouteAction{
+				Destinations: []*networkservices.GrpcRouteDestination{{
+					ServiceName: backendservice.ID(projectID, meta.GlobalKey("bs1")).SelfLink(meta.VersionGA),
+				}},
+			},
+		}}
+		if f != nil {
+			f(x)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Access() = %v, want nil", err)
+	}
+	return nodetest.Build(t, m, NewBuilderWithResource)
+}
+
+func TestOutRefs(t *testing.T) {
+	id := ID(projectID, meta.GlobalKey("route"))
+	n := makeNode(t, id, nil)
+
+	refs, err := n.Builder().OutRefs()
+	if err != nil {
+		t.Fatalf("OutRefs() = %v, want nil", err)
+	}
+	var got []string
+	for _, ref := range refs {
+		got = append(got, ref.Path.String()+"="+ref.To.String())
+	}
+	want := []string{
+		"*.Meshes!0=" + mesh.ID(projectID, meta.GlobalKey("mesh")).String(),
+		"*.Rules!0*.Action*.Destinations!0*.ServiceName=" + backendservice.ID(projectID, meta.GlobalKey("bs1")).String(),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("OutRefs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("OutRefs()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDiffAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	id := ID(projectID, meta.GlobalKey("route"))
+
+	got := makeNode(t, id, nil)
+	want := makeNode(t, id, func(x *networkservices.GrpcRoute) {
+		x.Hostnames = []string{"example.com", "example.org"}
+	})
+
+	p, err := want.Diff(got)
+	if err != nil {
+		t.Fatalf("Diff() = %v, want nil", err)
+	}
+	if p.Operation != rnode.OpUpdate {
+		t.Fatalf("Diff() = %v, want %v", p.Operation, rnode.OpUpdate)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: projectID})
+	if err := mock.GrpcRoutes().Insert(ctx, id.Key, &networkservices.GrpcRoute{Name: id.Key.Name}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	want.Plan().Set(*p)
+	actions, err := want.Actions(got)
+	if err != nil {
+		t.Fatalf("Actions() = %v, want nil", err)
+	}
+	for _, a := range actions {
+		if _, err := a.Run(ctx, mock); err != nil {
+			t.Fatalf("%v.Run() = %v, want nil", a, err)
+		}
+	}
+	r, err := mock.GrpcRoutes().Get(ctx, id.Key)
+	if err != nil || len(r.Hostnames) != 2 {
+		t.Errorf("Get() = %+v, %v; want 2 Hostnames", r, err)
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID returns the ResourceID of the HealthCheck.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "healthChecks",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableHealthCheck = api.MutableResource[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]
+
+func NewMutableHealthCheck(project string, key *meta.Key) MutableHealthCheck {
+	id := ID(project, key)
+	return api.NewResource[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck](id, &typeTrait{})
+}
+
+type HealthCheck = api.Resource[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks
+type typeTrait struct {
+	api.BaseTypeTrait[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck] {
+	return &rnode.GetFuncs[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]{
+		GA: rnode.GetFuncsByScope[compute.HealthCheck]{
+			Global:   gcp.HealthChecks().Get,
+			Regional: gcp.RegionHealthChecks().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.HealthCheck]{
+			Global:   gcp.AlphaHealthChecks().Get,
+			Regional: gcp.AlphaRegionHealthChecks().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.HealthCheck]{
+			Global:   gcp.BetaHealthChecks().Get,
+			Regional: gcp.BetaRegionHealthChecks().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck] {
+	return &rnode.CreateFuncs[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]{
+		GA: rnode.CreateFuncsByScope[compute.HealthCheck]{
+			Global:   gcp.HealthChecks().Insert,
+			Regional: gcp.RegionHealthChecks().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.HealthCheck]{
+			Global:   gcp.AlphaHealthChecks().Insert,
+			Regional: gcp.AlphaRegionHealthChecks().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.HealthCheck]{
+			Global:   gcp.BetaHealthChecks().Insert,
+			Regional: gcp.BetaRegionHealthChecks().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck] {
+	return &rnode.UpdateFuncs[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]{
+		GA: rnode.UpdateFuncsByScope[compute.HealthCheck]{
+			Global:   gcp.HealthChecks().Update,
+			Regional: gcp.RegionHealthChecks().Update,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.HealthCheck]{
+			Global:   gcp.AlphaHealthChecks().Update,
+			Regional: gcp.AlphaRegionHealthChecks().Update,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.HealthCheck]{
+			Global:   gcp.BetaHealthChecks().Update,
+			Regional: gcp.BetaRegionHealthChecks().Update,
+		},
+		Options: rnode.UpdateFuncsNoFingerprint,
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck] {
+	return &rnode.DeleteFuncs[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]{
+		GA: rnode.DeleteFuncsByScope[compute.HealthCheck]{
+			Global:   gcp.HealthChecks().Delete,
+			Regional: gcp.RegionHealthChecks().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.HealthCheck]{
+			Global:   gcp.AlphaHealthChecks().Delete,
+			Regional: gcp.AlphaRegionHealthChecks().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.HealthCheck]{
+			Global:   gcp.BetaHealthChecks().Delete,
+			Regional: gcp.BetaRegionHealthChecks().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r HealthCheck) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource HealthCheck
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(HealthCheck)
+	if !ok {
+		return fmt.Errorf("cannot set HealthCheck from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck](ctx, gcp, "HealthCheck", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	// HealthCheck does not have any outgoing resource references.
+	return nil, nil
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("HealthCheck %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &healthCheckNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for HealthCheck.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate)
+
+type healthCheckNode struct {
+	rnode.NodeBase
+	resource HealthCheck
+}
+
+var _ rnode.Node = (*healthCheckNode)(nil)
+
+func (n *healthCheckNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *healthCheckNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*healthCheckNode)
+	if !ok {
+		return nil, fmt.Errorf("HealthCheckNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("HealthCheckNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("HealthCheck", diff)
+}
+
+func (n *healthCheckNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("HealthCheckNode: invalid plan op %s", op)
+}
+
+func (n *healthCheckNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
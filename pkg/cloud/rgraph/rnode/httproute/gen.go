@@ -0,0 +1,243 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package httproute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/networkservices/v1"
+	beta "google.golang.org/api/networkservices/v1beta1"
+)
+
+// ID returns the ResourceID of the HttpRoute.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "httpRoutes",
+		APIGroup:  meta.APIGroupNetworkServices,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableHttpRoute = api.MutableResource[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute]
+
+func NewMutableHttpRoute(project string, key *meta.Key) MutableHttpRoute {
+	id := ID(project, key)
+	return api.NewResource[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute](id, &typeTrait{})
+}
+
+type HttpRoute = api.Resource[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute]
+
+// https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.httpRoutes
+type typeTrait struct {
+	api.BaseTypeTrait[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreateTime"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("UpdateTime"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Gateways"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Labels"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Meshes"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Matches"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("CorsPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations").AnySliceIndex().Pointer().Field("Weight"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("FaultInjectionPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Redirect"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("RequestHeaderModifier"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("RequestMirrorPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("ResponseHeaderModifier"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("RetryPolicy"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("StatefulSessionAffinity"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Timeout"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("UrlRewrite"))
+	dt.Reference(api.Path{}.Pointer().Field("Meshes").AnySliceIndex(), "meshes")
+	dt.Reference(api.Path{}.Pointer().Field("Gateways").AnySliceIndex(), "gateways")
+	dt.Reference(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations").AnySliceIndex().Pointer().Field("ServiceName"), "backendServices")
+	dt.Reference(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("RequestMirrorPolicy").Pointer().Field("Destination").Pointer().Field("ServiceName"), "backendServices")
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute] {
+	return &rnode.GetFuncs[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute]{
+		GA: rnode.GetFuncsByScope[networkservices.HttpRoute]{
+			Global: gcp.HttpRoutes().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.HttpRoute]{
+			Global: gcp.BetaHttpRoutes().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute] {
+	return &rnode.CreateFuncs[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute]{
+		GA: rnode.CreateFuncsByScope[networkservices.HttpRoute]{
+			Global: gcp.HttpRoutes().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.HttpRoute]{
+			Global: gcp.BetaHttpRoutes().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute] {
+	return &rnode.UpdateFuncs[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute]{
+		GA: rnode.UpdateFuncsByScope[networkservices.HttpRoute]{
+			Global: gcp.HttpRoutes().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.HttpRoute]{
+			Global: gcp.BetaHttpRoutes().Patch,
+		},
+		Options: rnode.UpdateFuncsNoFingerprint,
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute] {
+	return &rnode.DeleteFuncs[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute]{
+		GA: rnode.DeleteFuncsByScope[networkservices.HttpRoute]{
+			Global: gcp.HttpRoutes().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.HttpRoute]{
+			Global: gcp.BetaHttpRoutes().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r HttpRoute) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource HttpRoute
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(HttpRoute)
+	if !ok {
+		return fmt.Errorf("cannot set HttpRoute from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute](ctx, gcp, "HttpRoute", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	return rnode.OutRefsFromTraits[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute](b.resource, &typeTrait{})
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("HttpRoute %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &httpRouteNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for HttpRoute.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldUpdate)
+
+type httpRouteNode struct {
+	rnode.NodeBase
+	resource HttpRoute
+}
+
+var _ rnode.Node = (*httpRouteNode)(nil)
+
+func (n *httpRouteNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *httpRouteNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*httpRouteNode)
+	if !ok {
+		return nil, fmt.Errorf("HttpRouteNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("HttpRouteNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("HttpRoute", diff)
+}
+
+func (n *httpRouteNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[networkservices.HttpRoute, api.PlaceholderType, beta.HttpRoute](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("HttpRouteNode: invalid plan op %s", op)
+}
+
+func (n *httpRouteNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
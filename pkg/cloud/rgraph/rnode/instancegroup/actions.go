@@ -0,0 +1,134 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+// updateAction sets the named ports and changes the membership of the
+// InstanceGroup.
+type updateAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// namedPorts if non-nil will call setNamedPorts().
+	namedPorts *compute.InstanceGroupsSetNamedPortsRequest
+	// add and remove are the URLs of the instances to add to and remove from
+	// the group.
+	add    []string
+	remove []string
+}
+
+func newUpdateAction(id *cloud.ResourceID, namedPorts *compute.InstanceGroupsSetNamedPortsRequest, add, remove []string) *updateAction {
+	act := &updateAction{id: id, namedPorts: namedPorts, add: add, remove: remove}
+	// Condition: the InstanceGroup must exist.
+	act.Want = exec.EventList{exec.NewExistsEvent(id)}
+	return act
+}
+
+func (act *updateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	key := act.id.Key
+	if key.Type() != meta.Zonal {
+		return nil, fmt.Errorf("InstanceGroupUpdateAction Run(%s): invalid key type", act.id)
+	}
+	projectOpt := cloud.ForceProjectID(act.id.ProjectID)
+	errf := func(verb string, err error) error {
+		return fmt.Errorf("InstanceGroupUpdateAction Run(%s): %s: %w", act.id, verb, err)
+	}
+	refs := func(urls []string) []*compute.InstanceReference {
+		var ret []*compute.InstanceReference
+		for _, url := range urls {
+			ret = append(ret, &compute.InstanceReference{Instance: url})
+		}
+		return ret
+	}
+
+	if act.namedPorts != nil {
+		if err := cl.InstanceGroups().SetNamedPorts(ctx, key, act.namedPorts, projectOpt); err != nil {
+			return nil, errf("SetNamedPorts", err)
+		}
+	}
+	if len(act.remove) > 0 {
+		req := &compute.InstanceGroupsRemoveInstancesRequest{Instances: refs(act.remove)}
+		if err := cl.InstanceGroups().RemoveInstances(ctx, key, req, projectOpt); err != nil {
+			return nil, errf("RemoveInstances", err)
+		}
+	}
+	if len(act.add) > 0 {
+		req := &compute.InstanceGroupsAddInstancesRequest{Instances: refs(act.add)}
+		if err := cl.InstanceGroups().AddInstances(ctx, key, req, projectOpt); err != nil {
+			return nil, errf("AddInstances", err)
+		}
+	}
+
+	return act.DryRun(), nil
+}
+
+func (act *updateAction) DryRun() exec.EventList { return nil }
+
+func (act *updateAction) String() string {
+	return fmt.Sprintf("InstanceGroupUpdateAction(%s)", act.id)
+}
+
+func (act *updateAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *updateAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("InstanceGroupUpdateAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: fmt.Sprintf("Update %s (named ports: %t, add %v, remove %v)", act.id, act.namedPorts != nil, act.add, act.remove),
+	}
+}
+
+// Coalesce merges a following update of the same InstanceGroup. The named
+// ports of other replace the ones of act and the membership changes are
+// applied in order.
+func (act *updateAction) Coalesce(other exec.Action) (exec.Action, bool) {
+	o, ok := other.(*updateAction)
+	if !ok || !o.id.Equal(act.id) {
+		return nil, false
+	}
+	without := func(l, remove []string) []string {
+		var ret []string
+		for _, x := range l {
+			found := false
+			for _, r := range remove {
+				found = found || r == x
+			}
+			if !found {
+				ret = append(ret, x)
+			}
+		}
+		return ret
+	}
+	namedPorts := act.namedPorts
+	if o.namedPorts != nil {
+		namedPorts = o.namedPorts
+	}
+	merged := newUpdateAction(act.id, namedPorts,
+		append(without(act.add, o.remove), without(o.add, act.add)...),
+		append(without(act.remove, o.add), without(o.remove, act.remove)...))
+	merged.Want = exec.MergeEvents(act.PendingEvents(), o.PendingEvents())
+	return merged, true
+}
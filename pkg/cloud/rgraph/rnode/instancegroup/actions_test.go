@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestUpdateAction(t *testing.T) {
+	key := meta.ZonalKey("ig", "us-central1-b")
+	id := ID("proj", key)
+	act := newUpdateAction(id,
+		&compute.InstanceGroupsSetNamedPortsRequest{NamedPorts: []*compute.NamedPort{{Name: "http", Port: 80}}},
+		[]string{"projects/proj/zones/us-central1-b/instances/vm-1"},
+		[]string{"projects/proj/zones/us-central1-b/instances/vm-2"},
+	)
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	if _, err := act.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	for _, op := range []string{"SetNamedPorts", "RemoveInstances", "AddInstances"} {
+		calls := mock.Calls().Matching(cloud.MockCall{Service: "InstanceGroups", Operation: op, Key: key})
+		if len(calls) != 1 {
+			t.Errorf("calls to InstanceGroups.%s = %v, want 1", op, calls)
+		}
+	}
+
+	act = newUpdateAction(ID("proj", meta.GlobalKey("ig")), nil, nil, nil)
+	if _, err := act.Run(context.Background(), mock); err == nil {
+		t.Errorf("Run() with a global key = nil, want error")
+	}
+}
+
+func TestUpdateActionCoalesce(t *testing.T) {
+	id := ID("proj", meta.ZonalKey("ig", "us-central1-b"))
+	np := &compute.InstanceGroupsSetNamedPortsRequest{NamedPorts: []*compute.NamedPort{{Name: "http", Port: 80}}}
+	a := newUpdateAction(id, nil, []string{"vm-1", "vm-2"}, []string{"vm-3"})
+	b := newUpdateAction(id, np, []string{"vm-3", "vm-4"}, []string{"vm-2"})
+
+	merged, ok := a.Coalesce(b)
+	if !ok {
+		t.Fatalf("Coalesce() = _, false, want true")
+	}
+	m := merged.(*updateAction)
+	if m.namedPorts != np {
+		t.Errorf("namedPorts = %v, want %v", m.namedPorts, np)
+	}
+	if diff := cmp.Diff(m.add, []string{"vm-1", "vm-3", "vm-4"}); diff != "" {
+		t.Errorf("add: -got,+want: %s", diff)
+	}
+	if diff := cmp.Diff(m.remove, []string{"vm-2"}); diff != "" {
+		t.Errorf("remove: -got,+want: %s", diff)
+	}
+	if len(m.PendingEvents()) != 1 {
+		t.Errorf("PendingEvents() = %v, want the exists event", m.PendingEvents())
+	}
+
+	other := newUpdateAction(ID("proj", meta.ZonalKey("ig-2", "us-central1-b")), nil, nil, nil)
+	if _, ok := a.Coalesce(other); ok {
+		t.Errorf("Coalesce(other group) = _, true, want false")
+	}
+}
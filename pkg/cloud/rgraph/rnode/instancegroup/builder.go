@@ -1,5 +1,5 @@
 /*
-Copyright 2023 Google LLC
+Copyright 2024 Google LLC
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,33 +14,26 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package tcproute
+package instancegroup
 
 import (
 	"context"
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
-	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
-	"google.golang.org/api/networkservices/v1"
-	beta "google.golang.org/api/networkservices/v1beta1"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
 )
 
-const (
-	resourceName = "TcpRoute"
-)
-
-// NewBuilder creates builder for tcp route.
 func NewBuilder(id *cloud.ResourceID) rnode.Builder {
 	b := &builder{}
 	b.Defaults(id)
 	return b
 }
 
-// NewBuilderWithResource creates builder for tcp route
-// with predefined resource.
-func NewBuilderWithResource(r TcpRoute) rnode.Builder {
+func NewBuilderWithResource(r InstanceGroup) rnode.Builder {
 	b := &builder{resource: r}
 	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
 	return b
@@ -48,7 +41,9 @@ func NewBuilderWithResource(r TcpRoute) rnode.Builder {
 
 type builder struct {
 	rnode.BuilderBase
-	resource TcpRoute
+	resource InstanceGroup
+	// instances are the members of the group. See SetInstances().
+	instances instanceSet
 }
 
 // builder implements node.Builder.
@@ -57,55 +52,39 @@ var _ rnode.Builder = (*builder)(nil)
 func (b *builder) Resource() rnode.UntypedResource { return b.resource }
 
 func (b *builder) SetResource(u rnode.UntypedResource) error {
-	r, ok := u.(TcpRoute)
+	r, ok := u.(InstanceGroup)
 	if !ok {
-		return fmt.Errorf("cannot set TcpRoute from untyped resource, %T", u)
+		return fmt.Errorf("cannot set InstanceGroup from untyped resource, %T", u)
 	}
 	b.resource = r
 	return nil
 }
 
 func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
-	return rnode.GenericGet[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](
-		ctx, gcp, resourceName, &tcpRouteOps{}, &tcpRouteTypeTrait{}, b)
+	err := rnode.GenericGet[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup](ctx, gcp, "InstanceGroup", &ops{}, &typeTrait{}, b)
+	if err != nil || b.State() != rnode.NodeExists {
+		return err
+	}
+	b.instances, err = listInstances(ctx, gcp, b.ID())
+	if err != nil {
+		b.SetState(rnode.NodeStateError)
+		return err
+	}
+	return nil
 }
 
+// OutRefs of the InstanceGroup. .Network and .Subnetwork are [Output Only]
+// and the instances are not nodes in the graph so there are no references.
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
-	if b.resource == nil {
-		return nil, nil
-	}
-	// TODO(kl52752) Add mesh dependency
-
-	var ret []rnode.ResourceRef
-	obj, _ := b.resource.ToGA()
-	for ruleIdx, rule := range obj.Rules {
-		if rule == nil || rule.Action == nil {
-			continue
-		}
-		for destIdx, dest := range rule.Action.Destinations {
-			if dest == nil {
-				continue
-			}
-			id, err := cloud.ParseResourceURL(dest.ServiceName)
-			if err != nil {
-				return nil, fmt.Errorf("tcpRouteNode: %w", err)
-			}
-			ret = append(ret, rnode.ResourceRef{
-				From: b.resource.ResourceID(),
-				Path: api.Path{}.Field("Rules").Index(ruleIdx).Field("Action").Field("Destinations").Index(destIdx).Field("ServiceName"),
-				To:   id,
-			})
-		}
-	}
-	return ret, nil
+	return nil, nil
 }
 
 func (b *builder) Build() (rnode.Node, error) {
 	if b.State() == rnode.NodeExists && b.resource == nil {
-		return nil, fmt.Errorf("TcpRoute %s resource is nil with state %s", b.ID(), b.State())
+		return nil, fmt.Errorf("InstanceGroup %s resource is nil with state %s", b.ID(), b.State())
 	}
 
-	ret := &tcpRouteNode{resource: b.resource}
+	ret := &instanceGroupNode{resource: b.resource, instances: b.instances}
 	if err := ret.InitFromBuilder(b); err != nil {
 		return nil, err
 	}
@@ -1,5 +1,5 @@
 /*
-Copyright 2023 Google LLC
+Copyright 2024 Google LLC
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,13 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package forwardingrule
+package instancegroup
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
-
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
@@ -28,22 +27,22 @@ import (
 
 func ID(project string, key *meta.Key) *cloud.ResourceID {
 	return &cloud.ResourceID{
-		Resource:  "forwardingRules",
+		Resource:  "instanceGroups",
 		APIGroup:  meta.APIGroupCompute,
 		ProjectID: project,
 		Key:       key,
 	}
 }
 
-type MutableForwardingRule = api.MutableResource[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]
+type MutableInstanceGroup = api.MutableResource[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup]
 
-func NewMutableForwardingRule(project string, key *meta.Key) MutableForwardingRule {
+func NewMutableInstanceGroup(project string, key *meta.Key) MutableInstanceGroup {
 	id := ID(project, key)
 	return api.NewResource[
-		compute.ForwardingRule,
-		alpha.ForwardingRule,
-		beta.ForwardingRule,
+		compute.InstanceGroup,
+		alpha.InstanceGroup,
+		beta.InstanceGroup,
 	](id, &typeTrait{})
 }
 
-type ForwardingRule = api.Resource[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]
+type InstanceGroup = api.Resource[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup]
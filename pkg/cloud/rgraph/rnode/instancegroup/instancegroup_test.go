@@ -0,0 +1,76 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroup
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+func TestInstanceGroupSchema(t *testing.T) {
+	x := NewMutableInstanceGroup("proj-1", meta.ZonalKey("key-1", "us-central1-b"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestSetInstances(t *testing.T) {
+	id := ID("proj", meta.ZonalKey("ig", "us-central1-b"))
+
+	for _, tc := range []struct {
+		name      string
+		instances []string
+		wantErr   bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "instances",
+			instances: []string{
+				"https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-b/instances/vm-1",
+				"projects/proj/zones/us-central1-b/instances/vm-2",
+			},
+		},
+		{
+			name: "duplicate",
+			instances: []string{
+				"https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-b/instances/vm-1",
+				"projects/proj/zones/us-central1-b/instances/vm-1",
+			},
+			wantErr: true,
+		},
+		{
+			name:      "other zone",
+			instances: []string{"projects/proj/zones/us-central1-c/instances/vm-1"},
+			wantErr:   true,
+		},
+		{
+			name:      "not an instance",
+			instances: []string{"projects/proj/global/networks/vpc"},
+			wantErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := SetInstances(NewBuilder(id), tc.instances)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("SetInstances() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
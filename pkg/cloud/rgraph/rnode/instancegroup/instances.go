@@ -0,0 +1,151 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+// instanceSet is the membership of the group: the instance URLs keyed by
+// instanceKey(). A nil instanceSet means that the membership is not managed.
+type instanceSet map[string]string
+
+// SetInstances sets the instances (by URL) that are members of the group. The
+// plan will add and remove instances so that the membership matches.
+// Membership is not managed unless SetInstances is called; an empty list will
+// remove all of the instances.
+func SetInstances(b rnode.Builder, instances []string) error {
+	igb, ok := b.(*builder)
+	if !ok {
+		return fmt.Errorf("SetInstances: invalid builder type %T", b)
+	}
+	set := instanceSet{}
+	for _, url := range instances {
+		k, err := instanceKey(igb.ID(), url)
+		if err != nil {
+			return fmt.Errorf("SetInstances: %w", err)
+		}
+		if _, ok := set[k]; ok {
+			return fmt.Errorf("SetInstances: duplicate instance %s in %s", k, igb.ID())
+		}
+		set[k] = url
+	}
+	igb.instances = set
+	return nil
+}
+
+// Instances returns the instance URLs of the InstanceGroup node in a stable
+// order. ok is false if the membership is not managed.
+func Instances(n rnode.Node) (instances []string, ok bool) {
+	ign, isIG := n.(*instanceGroupNode)
+	if !isIG || ign.instances == nil {
+		return nil, false
+	}
+	for _, k := range sortedKeys(ign.instances) {
+		instances = append(instances, ign.instances[k])
+	}
+	return instances, true
+}
+
+// instanceKey identifies the instance in the group. Instances must be in the
+// same project and zone as the group.
+func instanceKey(ig *cloud.ResourceID, url string) (string, error) {
+	id, err := cloud.ParseResourceURL(url)
+	if err != nil {
+		return "", err
+	}
+	if id.Resource != "instances" {
+		return "", fmt.Errorf("%q is not an instance", url)
+	}
+	if id.ProjectID != ig.ProjectID || id.Key.Zone != ig.Key.Zone {
+		return "", fmt.Errorf("instance %s is not in the same project and zone as %s", id, ig)
+	}
+	return meta.InstanceGroupInstanceSubKey(ig.Key, id.Key.Name).ID, nil
+}
+
+func sortedKeys(s instanceSet) []string {
+	var ret []string
+	for k := range s {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// diffInstances returns the instances to add and remove to go from got to
+// want.
+func diffInstances(got, want instanceSet) (add, remove []string) {
+	for _, k := range sortedKeys(want) {
+		if _, ok := got[k]; !ok {
+			add = append(add, want[k])
+		}
+	}
+	for _, k := range sortedKeys(got) {
+		if _, ok := want[k]; !ok {
+			remove = append(remove, got[k])
+		}
+	}
+	return add, remove
+}
+
+// listInstances fetches the instances in the group from the cloud.
+func listInstances(ctx context.Context, cl cloud.Cloud, id *cloud.ResourceID) (instanceSet, error) {
+	req := &compute.InstanceGroupsListInstancesRequest{InstanceState: "ALL"}
+	l, err := cl.InstanceGroups().ListInstances(ctx, id.Key, req, filter.None, cloud.ForceProjectID(id.ProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("listInstances(%s): %w", id, err)
+	}
+	ret := instanceSet{}
+	for _, inst := range l {
+		k, err := instanceKey(id, inst.Instance)
+		if err != nil {
+			return nil, fmt.Errorf("listInstances(%s): %w", id, err)
+		}
+		ret[k] = inst.Instance
+	}
+	return ret, nil
+}
+
+// namedPortsEqual compares the named ports ignoring the order.
+func namedPortsEqual(a, b []*compute.NamedPort) bool {
+	set := func(l []*compute.NamedPort) []string {
+		var ret []string
+		for _, np := range l {
+			ret = append(ret, fmt.Sprintf("%s:%d", np.Name, np.Port))
+		}
+		sort.Strings(ret)
+		return ret
+	}
+	sa, sb := set(a), set(b)
+	if len(sa) != len(sb) {
+		return false
+	}
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
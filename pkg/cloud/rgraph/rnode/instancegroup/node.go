@@ -0,0 +1,205 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+var namedPortsPath = api.Path{}.Pointer().Field("NamedPorts")
+
+// diffPolicy for InstanceGroup. .NamedPorts can be changed with
+// setNamedPorts(). All other fields require a recreate.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate).Set(namedPortsPath, rnode.FieldUpdate)
+
+type instanceGroupNode struct {
+	rnode.NodeBase
+	resource InstanceGroup
+	// instances are the members of the group. See SetInstances().
+	instances instanceSet
+}
+
+var _ rnode.Node = (*instanceGroupNode)(nil)
+
+func (n *instanceGroupNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *instanceGroupNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*instanceGroupNode)
+	if !ok {
+		return nil, fmt.Errorf("InstanceGroupNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("InstanceGroupNode: Diff %w", err)
+	}
+
+	r, err := n.EffectiveDiffPolicy(diffPolicy).Apply(diff)
+	if err != nil {
+		return nil, fmt.Errorf("InstanceGroupNode: %w", err)
+	}
+	var recreate []string
+	for _, item := range r.Recreate {
+		recreate = append(recreate, fmt.Sprintf("%s (%v -> %v)", item.Path, item.A, item.B))
+	}
+	// Only .NamedPorts can be changed in place. The policy may have been
+	// overridden to update other fields.
+	var namedPortsChanged bool
+	for _, item := range r.Update {
+		if item.Path.HasPrefix(namedPortsPath) {
+			namedPortsChanged = true
+			continue
+		}
+		recreate = append(recreate, fmt.Sprintf("%s (%v -> %v)", item.Path, item.A, item.B))
+	}
+	if len(recreate) > 0 {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpRecreate,
+			Why:       fmt.Sprintf("InstanceGroup needs to be recreated: %s", strings.Join(recreate, ", ")),
+			Diff:      r.Diff,
+		}, nil
+	}
+
+	var update []string
+	gotGA, err := got.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("InstanceGroupNode: Diff %w", err)
+	}
+	wantGA, err := n.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("InstanceGroupNode: Diff %w", err)
+	}
+	if namedPortsChanged && !namedPortsEqual(gotGA.NamedPorts, wantGA.NamedPorts) {
+		update = append(update, "named ports")
+	}
+	// Membership is only changed if it is managed in want.
+	if n.instances != nil {
+		if add, remove := diffInstances(got.instances, n.instances); len(add) > 0 || len(remove) > 0 {
+			update = append(update, fmt.Sprintf("instances (add %d, remove %d)", len(add), len(remove)))
+		}
+	}
+	if len(update) == 0 {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "No diff between got and want",
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpUpdate,
+		Why:       fmt.Sprintf("InstanceGroup needs to be updated: %s", strings.Join(update, ", ")),
+		Diff:      r.Diff,
+	}, nil
+}
+
+func (n *instanceGroupNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		actions, err := rnode.CreateActions[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup](&ops{}, n, n.resource)
+		if err != nil {
+			return nil, err
+		}
+		return n.withAddInstancesAction(actions), nil
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		actions, err := rnode.RecreateActions[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup](&ops{}, got, n, n.resource)
+		if err != nil {
+			return nil, err
+		}
+		return n.withAddInstancesAction(actions), nil
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+
+	return nil, fmt.Errorf("InstanceGroupNode: invalid plan op %s", op)
+}
+
+func (n *instanceGroupNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource, instances: n.instances}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
+
+// withAddInstancesAction appends the action to add the instances to a newly
+// created group. The named ports are set by insert().
+func (n *instanceGroupNode) withAddInstancesAction(actions []exec.Action) []exec.Action {
+	if len(n.instances) == 0 {
+		return actions
+	}
+	add, _ := diffInstances(nil, n.instances)
+	return append(actions, newUpdateAction(n.ID(), nil, add, nil))
+}
+
+func (n *instanceGroupNode) updateActions(gotNode rnode.Node) ([]exec.Action, error) {
+	got, ok := gotNode.(*instanceGroupNode)
+	if !ok {
+		return nil, fmt.Errorf("InstanceGroupNode: updateActions: invalid type %T", gotNode)
+	}
+	gotGA, err := got.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("InstanceGroupNode: updateActions: %w", err)
+	}
+	wantGA, err := n.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("InstanceGroupNode: updateActions: %w", err)
+	}
+
+	// The planned diff does not contain .NamedPorts if they are ignored by
+	// the policy.
+	var namedPortsChanged bool
+	for _, item := range n.Plan().Details().Diff.Items {
+		if item.Path.HasPrefix(namedPortsPath) {
+			namedPortsChanged = true
+		}
+	}
+	var namedPorts *compute.InstanceGroupsSetNamedPortsRequest
+	if namedPortsChanged && !namedPortsEqual(gotGA.NamedPorts, wantGA.NamedPorts) {
+		namedPorts = &compute.InstanceGroupsSetNamedPortsRequest{
+			// The fingerprint must be the one from the current resource.
+			Fingerprint: gotGA.Fingerprint,
+			NamedPorts:  wantGA.NamedPorts,
+			// Send the empty list to remove all of the named ports.
+			ForceSendFields: []string{"NamedPorts"},
+		}
+	}
+	var add, remove []string
+	if n.instances != nil {
+		add, remove = diffInstances(got.instances, n.instances)
+	}
+
+	return []exec.Action{
+		exec.NewExistsAction(n.ID()),
+		newUpdateAction(n.ID(), namedPorts, add, remove),
+	}, nil
+}
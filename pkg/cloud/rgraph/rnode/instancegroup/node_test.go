@@ -0,0 +1,143 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroup
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.ZonalKey("ig", "us-central1-b"))
+	vm := func(name string) string {
+		return "projects/proj-1/zones/us-central1-b/instances/" + name
+	}
+
+	makeNode := func(f func(*compute.InstanceGroup), instances []string) rnode.Node {
+		t.Helper()
+		m := NewMutableInstanceGroup(id.ProjectID, id.Key)
+		if err := m.Access(func(x *compute.InstanceGroup) {
+			x.Name = "ig"
+			x.NamedPorts = []*compute.NamedPort{{Name: "http", Port: 80}, {Name: "https", Port: 443}}
+			if f != nil {
+				f(x)
+			}
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		if instances == nil {
+			return nodetest.Build(t, m, NewBuilderWithResource)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource, func(b rnode.Builder) error {
+			return SetInstances(b, instances)
+		})
+	}
+
+	got := makeNode(nil, []string{vm("vm-1"), vm("vm-2")})
+
+	for _, tc := range []struct {
+		name           string
+		f              func(*compute.InstanceGroup)
+		instances      []string
+		wantOp         rnode.Operation
+		wantNamedPorts bool
+		wantAdd        []string
+		wantRemove     []string
+	}{
+		{
+			name:   "membership not managed",
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "named ports order",
+			f: func(x *compute.InstanceGroup) {
+				x.NamedPorts = []*compute.NamedPort{{Name: "https", Port: 443}, {Name: "http", Port: 80}}
+			},
+			instances: []string{vm("vm-2"), vm("vm-1")},
+			wantOp:    rnode.OpNothing,
+		},
+		{
+			name: "named ports",
+			f: func(x *compute.InstanceGroup) {
+				x.NamedPorts = []*compute.NamedPort{{Name: "http", Port: 8080}}
+			},
+			wantOp:         rnode.OpUpdate,
+			wantNamedPorts: true,
+		},
+		{
+			name:       "instances",
+			instances:  []string{vm("vm-1"), vm("vm-3")},
+			wantOp:     rnode.OpUpdate,
+			wantAdd:    []string{vm("vm-3")},
+			wantRemove: []string{vm("vm-2")},
+		},
+		{
+			name:       "remove all instances",
+			instances:  []string{},
+			wantOp:     rnode.OpUpdate,
+			wantRemove: []string{vm("vm-1"), vm("vm-2")},
+		},
+		{
+			name: "description",
+			f: func(x *compute.InstanceGroup) {
+				x.Description = "new"
+			},
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := makeNode(tc.f, tc.instances)
+			p, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*p)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*updateAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *updateAction", actions[1])
+			}
+			if gotNamedPorts := act.namedPorts != nil; gotNamedPorts != tc.wantNamedPorts {
+				t.Errorf("namedPorts = %v, want set = %t", act.namedPorts, tc.wantNamedPorts)
+			}
+			if diff := cmp.Diff(act.add, tc.wantAdd); diff != "" {
+				t.Errorf("add: diff -got,+want: %s", diff)
+			}
+			if diff := cmp.Diff(act.remove, tc.wantRemove); diff != "" {
+				t.Errorf("remove: diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
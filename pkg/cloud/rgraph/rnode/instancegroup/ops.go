@@ -0,0 +1,73 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroup
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup] {
+	return &rnode.GetFuncs[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup]{
+		GA: rnode.GetFuncsByScope[compute.InstanceGroup]{
+			Zonal: gcp.InstanceGroups().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.InstanceGroup]{
+			Zonal: gcp.AlphaInstanceGroups().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.InstanceGroup]{
+			Zonal: gcp.BetaInstanceGroups().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup] {
+	return &rnode.CreateFuncs[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup]{
+		GA: rnode.CreateFuncsByScope[compute.InstanceGroup]{
+			Zonal: gcp.InstanceGroups().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.InstanceGroup]{
+			Zonal: gcp.AlphaInstanceGroups().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.InstanceGroup]{
+			Zonal: gcp.BetaInstanceGroups().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup] {
+	return nil // Does not support generic Update.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup] {
+	return &rnode.DeleteFuncs[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup]{
+		GA: rnode.DeleteFuncsByScope[compute.InstanceGroup]{
+			Zonal: gcp.InstanceGroups().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.InstanceGroup]{
+			Zonal: gcp.AlphaInstanceGroups().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.InstanceGroup]{
+			Zonal: gcp.BetaInstanceGroups().Delete,
+		},
+	}
+}
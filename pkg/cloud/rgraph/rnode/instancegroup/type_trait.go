@@ -1,5 +1,5 @@
 /*
-Copyright 2023 Google LLC
+Copyright 2024 Google LLC
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package healthcheck
+package instancegroup
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
@@ -24,9 +24,9 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
-// https://cloud.google.com/compute/docs/reference/rest/v1/HealthChecks
+// https://cloud.google.com/compute/docs/reference/rest/v1/instanceGroups
 type typeTrait struct {
-	api.BaseTypeTrait[compute.HealthCheck, alpha.HealthCheck, beta.HealthCheck]
+	api.BaseTypeTrait[compute.InstanceGroup, alpha.InstanceGroup, beta.InstanceGroup]
 }
 
 func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
@@ -40,8 +40,14 @@ func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
-
-	// TODO: handle alpha/beta
+	dt.OutputOnly(api.Path{}.Pointer().Field("Size"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Zone"))
+	// .Network and .Subnetwork are taken from the instances in the group.
+	dt.OutputOnly(api.Path{}.Pointer().Field("Network"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Subnetwork"))
+
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("NamedPorts"))
 
 	return dt
 }
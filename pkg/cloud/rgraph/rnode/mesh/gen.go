@@ -0,0 +1,226 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package mesh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/networkservices/v1"
+	beta "google.golang.org/api/networkservices/v1beta1"
+)
+
+// ID returns the ResourceID of the Mesh.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "meshes",
+		APIGroup:  meta.APIGroupNetworkServices,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableMesh = api.MutableResource[networkservices.Mesh, api.PlaceholderType, beta.Mesh]
+
+func NewMutableMesh(project string, key *meta.Key) MutableMesh {
+	id := ID(project, key)
+	return api.NewResource[networkservices.Mesh, api.PlaceholderType, beta.Mesh](id, &typeTrait{})
+}
+
+type Mesh = api.Resource[networkservices.Mesh, api.PlaceholderType, beta.Mesh]
+
+// https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.meshes
+type typeTrait struct {
+	api.BaseTypeTrait[networkservices.Mesh, api.PlaceholderType, beta.Mesh]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreateTime"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("UpdateTime"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("InterceptionPort"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Labels"))
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[networkservices.Mesh, api.PlaceholderType, beta.Mesh] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[networkservices.Mesh, api.PlaceholderType, beta.Mesh] {
+	return &rnode.GetFuncs[networkservices.Mesh, api.PlaceholderType, beta.Mesh]{
+		GA: rnode.GetFuncsByScope[networkservices.Mesh]{
+			Global: gcp.Meshes().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.Mesh]{
+			Global: gcp.BetaMeshes().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[networkservices.Mesh, api.PlaceholderType, beta.Mesh] {
+	return &rnode.CreateFuncs[networkservices.Mesh, api.PlaceholderType, beta.Mesh]{
+		GA: rnode.CreateFuncsByScope[networkservices.Mesh]{
+			Global: gcp.Meshes().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.Mesh]{
+			Global: gcp.BetaMeshes().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[networkservices.Mesh, api.PlaceholderType, beta.Mesh] {
+	return &rnode.UpdateFuncs[networkservices.Mesh, api.PlaceholderType, beta.Mesh]{
+		GA: rnode.UpdateFuncsByScope[networkservices.Mesh]{
+			Global: gcp.Meshes().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.Mesh]{
+			Global: gcp.BetaMeshes().Patch,
+		},
+		Options: rnode.UpdateFuncsNoFingerprint,
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[networkservices.Mesh, api.PlaceholderType, beta.Mesh] {
+	return &rnode.DeleteFuncs[networkservices.Mesh, api.PlaceholderType, beta.Mesh]{
+		GA: rnode.DeleteFuncsByScope[networkservices.Mesh]{
+			Global: gcp.Meshes().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.Mesh]{
+			Global: gcp.BetaMeshes().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r Mesh) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource Mesh
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(Mesh)
+	if !ok {
+		return fmt.Errorf("cannot set Mesh from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[networkservices.Mesh, api.PlaceholderType, beta.Mesh](ctx, gcp, "Mesh", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	// Mesh does not have any outgoing resource references.
+	return nil, nil
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("Mesh %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &meshNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for Mesh.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldUpdate)
+
+type meshNode struct {
+	rnode.NodeBase
+	resource Mesh
+}
+
+var _ rnode.Node = (*meshNode)(nil)
+
+func (n *meshNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *meshNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*meshNode)
+	if !ok {
+		return nil, fmt.Errorf("MeshNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("MeshNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("Mesh", diff)
+}
+
+func (n *meshNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[networkservices.Mesh, api.PlaceholderType, beta.Mesh](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[networkservices.Mesh, api.PlaceholderType, beta.Mesh](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[networkservices.Mesh, api.PlaceholderType, beta.Mesh](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[networkservices.Mesh, api.PlaceholderType, beta.Mesh](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("MeshNode: invalid plan op %s", op)
+}
+
+func (n *meshNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
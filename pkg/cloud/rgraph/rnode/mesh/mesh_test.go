@@ -0,0 +1,67 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mesh
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/networkservices/v1"
+)
+
+func TestMeshSchema(t *testing.T) {
+	x := NewMutableMesh("proj-1", meta.GlobalKey("key-1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.GlobalKey("mesh"))
+	makeNode := func(port int64) rnode.Node {
+		t.Helper()
+		m := NewMutableMesh(id.ProjectID, id.Key)
+		if err := m.Access(func(x *networkservices.Mesh) {
+			x.Name = "mesh"
+			x.InterceptionPort = port
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		port   int64
+		wantOp rnode.Operation
+	}{
+		{name: "no diff", port: 15001, wantOp: rnode.OpNothing},
+		{name: "update", port: 15002, wantOp: rnode.OpUpdate},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := makeNode(tc.port).Diff(makeNode(15001))
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Errorf("Diff() = %v, want %v", p.Operation, tc.wantOp)
+			}
+		})
+	}
+}
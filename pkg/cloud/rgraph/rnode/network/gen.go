@@ -0,0 +1,251 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID returns the ResourceID of the Network.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "networks",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableNetwork = api.MutableResource[compute.Network, alpha.Network, beta.Network]
+
+func NewMutableNetwork(project string, key *meta.Key) MutableNetwork {
+	id := ID(project, key)
+	return api.NewResource[compute.Network, alpha.Network, beta.Network](id, &typeTrait{})
+}
+
+type Network = api.Resource[compute.Network, alpha.Network, beta.Network]
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/networks
+type typeTrait struct {
+	api.BaseTypeTrait[compute.Network, alpha.Network, beta.Network]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("FirewallPolicy"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("GatewayIPv4"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Peerings"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLinkWithId"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Subnetworks"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("AutoCreateSubnetworks"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("EnableUlaInternalIpv6"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("IPv4Range"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("InternalIpv6Range"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Mtu"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("NetworkFirewallPolicyEnforcementOrder"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("RoutingConfig"))
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.Network, alpha.Network, beta.Network] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.Network, alpha.Network, beta.Network] {
+	return &rnode.GetFuncs[compute.Network, alpha.Network, beta.Network]{
+		GA: rnode.GetFuncsByScope[compute.Network]{
+			Global: gcp.Networks().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.Network]{
+			Global: gcp.AlphaNetworks().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.Network]{
+			Global: gcp.BetaNetworks().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.Network, alpha.Network, beta.Network] {
+	return &rnode.CreateFuncs[compute.Network, alpha.Network, beta.Network]{
+		GA: rnode.CreateFuncsByScope[compute.Network]{
+			Global: gcp.Networks().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.Network]{
+			Global: gcp.AlphaNetworks().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.Network]{
+			Global: gcp.BetaNetworks().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.Network, alpha.Network, beta.Network] {
+	return &rnode.UpdateFuncs[compute.Network, alpha.Network, beta.Network]{
+		GA: rnode.UpdateFuncsByScope[compute.Network]{
+			Global: gcp.Networks().Patch,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.Network]{
+			Global: gcp.AlphaNetworks().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.Network]{
+			Global: gcp.BetaNetworks().Patch,
+		},
+		Options: rnode.UpdateFuncsNoFingerprint,
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.Network, alpha.Network, beta.Network] {
+	return &rnode.DeleteFuncs[compute.Network, alpha.Network, beta.Network]{
+		GA: rnode.DeleteFuncsByScope[compute.Network]{
+			Global: gcp.Networks().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.Network]{
+			Global: gcp.AlphaNetworks().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.Network]{
+			Global: gcp.BetaNetworks().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r Network) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource Network
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(Network)
+	if !ok {
+		return fmt.Errorf("cannot set Network from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.Network, alpha.Network, beta.Network](ctx, gcp, "Network", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	// Network does not have any outgoing resource references.
+	return nil, nil
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("Network %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &networkNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for Network.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate).
+	SetFields(rnode.FieldUpdate, "EnableUlaInternalIpv6", "InternalIpv6Range", "Mtu", "NetworkFirewallPolicyEnforcementOrder", "RoutingConfig")
+
+type networkNode struct {
+	rnode.NodeBase
+	resource Network
+}
+
+var _ rnode.Node = (*networkNode)(nil)
+
+func (n *networkNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *networkNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*networkNode)
+	if !ok {
+		return nil, fmt.Errorf("NetworkNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("NetworkNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("Network", diff)
+}
+
+func (n *networkNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.Network, alpha.Network, beta.Network](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.Network, alpha.Network, beta.Network](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.Network, alpha.Network, beta.Network](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[compute.Network, alpha.Network, beta.Network](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("NetworkNode: invalid plan op %s", op)
+}
+
+func (n *networkNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
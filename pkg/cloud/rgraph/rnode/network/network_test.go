@@ -0,0 +1,87 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestNetworkSchema(t *testing.T) {
+	x := NewMutableNetwork("proj-1", meta.GlobalKey("key-1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.GlobalKey("vpc"))
+	makeNode := func(f func(*compute.Network)) rnode.Node {
+		t.Helper()
+		m := NewMutableNetwork(id.ProjectID, id.Key)
+		if err := m.Access(func(x *compute.Network) {
+			x.Name = "vpc"
+			x.Mtu = 1460
+			x.RoutingConfig = &compute.NetworkRoutingConfig{RoutingMode: "REGIONAL"}
+			f(x)
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		f      func(*compute.Network)
+		wantOp rnode.Operation
+	}{
+		{
+			name:   "no diff",
+			f:      func(*compute.Network) {},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "mtu",
+			f:      func(x *compute.Network) { x.Mtu = 1500 },
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "routing mode",
+			f:      func(x *compute.Network) { x.RoutingConfig.RoutingMode = "GLOBAL" },
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "auto mode",
+			f:      func(x *compute.Network) { x.AutoCreateSubnetworks = true },
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := makeNode(tc.f).Diff(makeNode(func(*compute.Network) {}))
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Errorf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+		})
+	}
+}
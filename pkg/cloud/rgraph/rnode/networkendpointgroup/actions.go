@@ -0,0 +1,125 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkendpointgroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+// maxEndpointsPerCall is the maximum number of endpoints in a single
+// attachNetworkEndpoints() or detachNetworkEndpoints() call.
+const maxEndpointsPerCall = 500
+
+// endpointsAction attaches and detaches endpoints from the NEG.
+type endpointsAction struct {
+	exec.ActionBase
+
+	id     *cloud.ResourceID
+	attach []*compute.NetworkEndpoint
+	detach []*compute.NetworkEndpoint
+}
+
+func newEndpointsAction(id *cloud.ResourceID, attach, detach []*compute.NetworkEndpoint) *endpointsAction {
+	act := &endpointsAction{id: id, attach: attach, detach: detach}
+	// Condition: the NEG must exist.
+	act.Want = exec.EventList{exec.NewExistsEvent(id)}
+	return act
+}
+
+func (act *endpointsAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	// Detach first as a changed endpoint is detached and re-attached.
+	for _, batch := range batches(act.detach) {
+		if err := act.call(ctx, cl, "DetachNetworkEndpoints", batch); err != nil {
+			return nil, err
+		}
+	}
+	for _, batch := range batches(act.attach) {
+		if err := act.call(ctx, cl, "AttachNetworkEndpoints", batch); err != nil {
+			return nil, err
+		}
+	}
+	return act.DryRun(), nil
+}
+
+func (act *endpointsAction) call(ctx context.Context, cl cloud.Cloud, verb string, endpoints []*compute.NetworkEndpoint) error {
+	key := act.id.Key
+	projectOpt := cloud.ForceProjectID(act.id.ProjectID)
+	attach := verb == "AttachNetworkEndpoints"
+
+	var err error
+	switch key.Type() {
+	case meta.Global:
+		if attach {
+			err = cl.GlobalNetworkEndpointGroups().AttachNetworkEndpoints(ctx, key, &compute.GlobalNetworkEndpointGroupsAttachEndpointsRequest{NetworkEndpoints: endpoints}, projectOpt)
+		} else {
+			err = cl.GlobalNetworkEndpointGroups().DetachNetworkEndpoints(ctx, key, &compute.GlobalNetworkEndpointGroupsDetachEndpointsRequest{NetworkEndpoints: endpoints}, projectOpt)
+		}
+	case meta.Regional:
+		if attach {
+			err = cl.RegionNetworkEndpointGroups().AttachNetworkEndpoints(ctx, key, &compute.RegionNetworkEndpointGroupsAttachEndpointsRequest{NetworkEndpoints: endpoints}, projectOpt)
+		} else {
+			err = cl.RegionNetworkEndpointGroups().DetachNetworkEndpoints(ctx, key, &compute.RegionNetworkEndpointGroupsDetachEndpointsRequest{NetworkEndpoints: endpoints}, projectOpt)
+		}
+	case meta.Zonal:
+		if attach {
+			err = cl.NetworkEndpointGroups().AttachNetworkEndpoints(ctx, key, &compute.NetworkEndpointGroupsAttachEndpointsRequest{NetworkEndpoints: endpoints}, projectOpt)
+		} else {
+			err = cl.NetworkEndpointGroups().DetachNetworkEndpoints(ctx, key, &compute.NetworkEndpointGroupsDetachEndpointsRequest{NetworkEndpoints: endpoints}, projectOpt)
+		}
+	default:
+		err = fmt.Errorf("invalid key type")
+	}
+	if err != nil {
+		return fmt.Errorf("NetworkEndpointGroupEndpointsAction Run(%s): %s: %w", act.id, verb, err)
+	}
+	return nil
+}
+
+// batches splits the endpoints into batches of at most maxEndpointsPerCall.
+func batches(endpoints []*compute.NetworkEndpoint) [][]*compute.NetworkEndpoint {
+	var ret [][]*compute.NetworkEndpoint
+	for len(endpoints) > maxEndpointsPerCall {
+		ret = append(ret, endpoints[:maxEndpointsPerCall])
+		endpoints = endpoints[maxEndpointsPerCall:]
+	}
+	if len(endpoints) > 0 {
+		ret = append(ret, endpoints)
+	}
+	return ret
+}
+
+func (act *endpointsAction) DryRun() exec.EventList { return nil }
+
+func (act *endpointsAction) String() string {
+	return fmt.Sprintf("NetworkEndpointGroupEndpointsAction(%s)", act.id)
+}
+
+func (act *endpointsAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *endpointsAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("NetworkEndpointGroupEndpointsAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: fmt.Sprintf("Attach %d and detach %d endpoints in %s", len(act.attach), len(act.detach), act.id),
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkendpointgroup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestEndpointsAction(t *testing.T) {
+	t.Parallel()
+
+	var many []*compute.NetworkEndpoint
+	for i := 0; i < maxEndpointsPerCall+1; i++ {
+		many = append(many, &compute.NetworkEndpoint{IpAddress: fmt.Sprintf("10.0.%d.%d", i/256, i%256), Port: 80})
+	}
+	one := []*compute.NetworkEndpoint{{IpAddress: "10.1.0.1", Port: 80}}
+
+	for _, tc := range []struct {
+		name       string
+		key        *meta.Key
+		service    string
+		attach     []*compute.NetworkEndpoint
+		detach     []*compute.NetworkEndpoint
+		wantAttach int
+		wantDetach int
+	}{
+		{
+			name:       "zonal",
+			key:        meta.ZonalKey("neg", "us-central1-b"),
+			service:    "NetworkEndpointGroups",
+			attach:     one,
+			detach:     one,
+			wantAttach: 1,
+			wantDetach: 1,
+		},
+		{
+			name:       "regional",
+			key:        meta.RegionalKey("neg", "us-central1"),
+			service:    "RegionNetworkEndpointGroups",
+			attach:     one,
+			wantAttach: 1,
+		},
+		{
+			name:       "global",
+			key:        meta.GlobalKey("neg"),
+			service:    "GlobalNetworkEndpointGroups",
+			detach:     one,
+			wantDetach: 1,
+		},
+		{
+			name:       "batches",
+			key:        meta.ZonalKey("neg", "us-central1-b"),
+			service:    "NetworkEndpointGroups",
+			attach:     many,
+			wantAttach: 2,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := ID("proj", tc.key)
+			act := newEndpointsAction(id, tc.attach, tc.detach)
+			if want := (exec.EventList{exec.NewExistsEvent(id)}); !act.PendingEvents().Equal(want) {
+				t.Errorf("PendingEvents() = %v, want %v", act.PendingEvents(), want)
+			}
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+			if _, err := act.Run(context.Background(), mock); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			for op, want := range map[string]int{
+				"AttachNetworkEndpoints": tc.wantAttach,
+				"DetachNetworkEndpoints": tc.wantDetach,
+			} {
+				calls := mock.Calls().Matching(cloud.MockCall{Service: tc.service, Operation: op, Key: tc.key})
+				if len(calls) != want {
+					t.Errorf("calls to %s.%s = %v, want %d", tc.service, op, calls, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitHealthyAction(t *testing.T) {
+	t.Parallel()
+
+	healthy := []*compute.HealthStatusForNetworkEndpoint{{HealthState: "HEALTHY"}}
+	unhealthy := []*compute.HealthStatusForNetworkEndpoint{{HealthState: "UNHEALTHY"}}
+
+	for _, tc := range []struct {
+		name      string
+		endpoints []*compute.NetworkEndpointWithHealthStatus
+		want      bool
+	}{
+		{
+			name: "no endpoints",
+		},
+		{
+			name: "all healthy",
+			endpoints: []*compute.NetworkEndpointWithHealthStatus{
+				{NetworkEndpoint: &compute.NetworkEndpoint{IpAddress: "10.0.0.1"}, Healths: healthy},
+				{NetworkEndpoint: &compute.NetworkEndpoint{IpAddress: "10.0.0.2"}, Healths: healthy},
+			},
+			want: true,
+		},
+		{
+			name: "one unhealthy",
+			endpoints: []*compute.NetworkEndpointWithHealthStatus{
+				{NetworkEndpoint: &compute.NetworkEndpoint{IpAddress: "10.0.0.1"}, Healths: healthy},
+				{NetworkEndpoint: &compute.NetworkEndpoint{IpAddress: "10.0.0.2"}, Healths: unhealthy},
+			},
+		},
+		{
+			name: "health not reported yet",
+			endpoints: []*compute.NetworkEndpointWithHealthStatus{
+				{NetworkEndpoint: &compute.NetworkEndpoint{IpAddress: "10.0.0.1"}},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+			var gotReq *compute.NetworkEndpointGroupsListEndpointsRequest
+			mock.MockNetworkEndpointGroups.ListNetworkEndpointsHook = func(_ context.Context, _ *meta.Key, req *compute.NetworkEndpointGroupsListEndpointsRequest, _ *filter.F, _ *cloud.MockNetworkEndpointGroups, _ ...cloud.Option) ([]*compute.NetworkEndpointWithHealthStatus, error) {
+				gotReq = req
+				return tc.endpoints, nil
+			}
+			id := ID("proj", meta.ZonalKey("neg", "us-central1-b"))
+			act := NewWaitHealthyAction(id, exec.WaitOptions{Timeout: 10 * time.Millisecond, Interval: time.Millisecond})
+			act.Signal(exec.NewExistsEvent(id))
+
+			events, err := act.Run(context.Background(), mock)
+			if ready := err == nil; ready != tc.want {
+				t.Fatalf("Run() = %v, %v; want ready = %t", events, err, tc.want)
+			}
+			if gotReq == nil || gotReq.HealthStatus != "SHOW" {
+				t.Errorf("ListNetworkEndpoints() req = %+v, want HealthStatus = SHOW", gotReq)
+			}
+			if want := (exec.EventList{exec.NewReadyEvent(id, ConditionHealthy)}); tc.want && !events.Equal(want) {
+				t.Errorf("Run() = %v, want %v", events, want)
+			}
+		})
+	}
+}
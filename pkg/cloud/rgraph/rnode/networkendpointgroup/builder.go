@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
@@ -42,6 +43,8 @@ func NewBuilderWithResource(r NetworkEndpointGroup) rnode.Builder {
 type builder struct {
 	rnode.BuilderBase
 	resource NetworkEndpointGroup
+	// endpoints are the members of the NEG. See SetEndpoints().
+	endpoints endpointSet
 }
 
 // builder implements node.Builder.
@@ -52,20 +55,33 @@ func (b *builder) Resource() rnode.UntypedResource { return b.resource }
 func (b *builder) SetResource(u rnode.UntypedResource) error {
 	r, ok := u.(NetworkEndpointGroup)
 	if !ok {
-		return fmt.Errorf("XXX")
+		return fmt.Errorf("cannot set NetworkEndpointGroup from untyped resource, %T", u)
 	}
 	b.resource = r
 	return nil
 }
 
 func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
-	return rnode.GenericGet[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](
+	err := rnode.GenericGet[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](
 		ctx, gcp, "NetworkEndpointGroup", &ops{}, &typeTrait{}, b)
+	if err != nil || b.State() != rnode.NodeExists {
+		return err
+	}
+	// .NetworkEndpointType is in GA, so the conversion cannot lose it.
+	obj, _ := b.resource.ToGA()
+	if !hasEndpoints(obj.NetworkEndpointType) {
+		return nil
+	}
+	b.endpoints, err = listEndpoints(ctx, gcp, b.ID())
+	if err != nil {
+		b.SetState(rnode.NodeStateError)
+		return err
+	}
+	return nil
 }
 
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
-	// No references.
-	return nil, nil
+	return rnode.OutRefsFromTraits[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](b.resource, &typeTrait{})
 }
 
 func (b *builder) Build() (rnode.Node, error) {
@@ -73,10 +89,79 @@ func (b *builder) Build() (rnode.Node, error) {
 		return nil, fmt.Errorf("NetworkEndpointGroup %s resource is nil with state %s", b.ID(), b.State())
 	}
 
-	ret := &networkEndpointGroupNode{resource: b.resource}
+	if b.resource != nil {
+		// validate() only checks .NetworkEndpointType and the serverless
+		// fields (.AppEngine, .CloudFunction, .CloudRun), which are in GA.
+		obj, _ := b.resource.ToGA()
+		if err := validate(b.ID(), obj); err != nil {
+			return nil, err
+		}
+		if b.endpoints != nil && !hasEndpoints(obj.NetworkEndpointType) {
+			return nil, fmt.Errorf("NetworkEndpointGroup %s: %s does not have endpoints", b.ID(), obj.NetworkEndpointType)
+		}
+	}
+
+	ret := &networkEndpointGroupNode{resource: b.resource, endpoints: b.endpoints}
 	if err := ret.InitFromBuilder(b); err != nil {
 		return nil, err
 	}
 
 	return ret, nil
 }
+
+// endpointTypeScopes are the scopes of the NEG for each .NetworkEndpointType.
+// Types that are not listed are not checked.
+var endpointTypeScopes = map[string][]meta.KeyType{
+	// The default type is GCE_VM_IP_PORT.
+	"":                        {meta.Zonal},
+	"GCE_VM_IP":               {meta.Zonal},
+	"GCE_VM_IP_PORT":          {meta.Zonal},
+	"NON_GCP_PRIVATE_IP_PORT": {meta.Zonal},
+	"INTERNET_FQDN_PORT":      {meta.Global, meta.Regional},
+	"INTERNET_IP_PORT":        {meta.Global, meta.Regional},
+	"PRIVATE_SERVICE_CONNECT": {meta.Regional},
+	"SERVERLESS":              {meta.Regional},
+}
+
+// validate checks that the scope of the NEG and the fields that are set
+// match the .NetworkEndpointType.
+func validate(id *cloud.ResourceID, obj *compute.NetworkEndpointGroup) error {
+	errf := func(s string, args ...any) error {
+		return fmt.Errorf("NetworkEndpointGroup %s: %s", id, fmt.Sprintf(s, args...))
+	}
+
+	if scopes, ok := endpointTypeScopes[obj.NetworkEndpointType]; ok {
+		var found bool
+		for _, scope := range scopes {
+			found = found || scope == id.Key.Type()
+		}
+		if !found {
+			return errf("NetworkEndpointType %q must be one of %v, got %s", obj.NetworkEndpointType, scopes, id.Key.Type())
+		}
+	}
+
+	switch obj.NetworkEndpointType {
+	case "SERVERLESS":
+		var n int
+		for _, set := range []bool{obj.AppEngine != nil, obj.CloudFunction != nil, obj.CloudRun != nil} {
+			if set {
+				n++
+			}
+		}
+		if n != 1 {
+			return errf("SERVERLESS must set exactly one of AppEngine, CloudFunction and CloudRun (%d set)", n)
+		}
+		if obj.Network != "" || obj.Subnetwork != "" || obj.DefaultPort != 0 {
+			return errf("SERVERLESS cannot set Network, Subnetwork or DefaultPort")
+		}
+	case "INTERNET_FQDN_PORT", "INTERNET_IP_PORT":
+		if obj.Subnetwork != "" {
+			return errf("%s cannot set Subnetwork", obj.NetworkEndpointType)
+		}
+		if obj.AppEngine != nil || obj.CloudFunction != nil || obj.CloudRun != nil {
+			return errf("%s cannot set AppEngine, CloudFunction or CloudRun", obj.NetworkEndpointType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkendpointgroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestOutRefs(t *testing.T) {
+	id := ID("proj", meta.ZonalKey("neg", "us-central1-b"))
+	subnetID := subnetwork.ID("proj", meta.RegionalKey("sub", "us-central1"))
+	netID := network.ID("proj", meta.GlobalKey("vpc"))
+
+	for _, tc := range []struct {
+		name string
+		f    func(*compute.NetworkEndpointGroup)
+
+		wantErr bool
+		want    []rnode.ResourceRef
+	}{
+		{
+			name: "no refs",
+			f:    func(x *compute.NetworkEndpointGroup) {},
+		},
+		{
+			name: "network and subnetwork",
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.Network = netID.SelfLink(meta.VersionGA)
+				x.Subnetwork = subnetID.SelfLink(meta.VersionGA)
+			},
+			want: []rnode.ResourceRef{
+				{From: id, To: netID, Path: api.Path{}.Pointer().Field("Network")},
+				{From: id, To: subnetID, Path: api.Path{}.Pointer().Field("Subnetwork")},
+			},
+		},
+		{
+			name: "garbage subnetwork",
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.Subnetwork = "garbage"
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := NewMutableNetworkEndpointGroup(id.ProjectID, id.Key)
+			mr.Access(tc.f)
+			r, _ := mr.Freeze()
+			b := NewBuilderWithResource(r)
+
+			got, err := b.OutRefs()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("OutRefs() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			} else if gotErr {
+				return
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("OutRefs diff = -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		key     *meta.Key
+		f       func(*compute.NetworkEndpointGroup)
+		wantErr bool
+	}{
+		{
+			name: "zonal default type",
+			key:  meta.ZonalKey("neg", "us-central1-b"),
+			f:    func(x *compute.NetworkEndpointGroup) {},
+		},
+		{
+			name:    "regional default type",
+			key:     meta.RegionalKey("neg", "us-central1"),
+			f:       func(x *compute.NetworkEndpointGroup) {},
+			wantErr: true,
+		},
+		{
+			name: "serverless cloud run",
+			key:  meta.RegionalKey("neg", "us-central1"),
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+				x.CloudRun = &compute.NetworkEndpointGroupCloudRun{Service: "svc"}
+			},
+		},
+		{
+			name: "serverless zonal",
+			key:  meta.ZonalKey("neg", "us-central1-b"),
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+				x.CloudRun = &compute.NetworkEndpointGroupCloudRun{Service: "svc"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "serverless two targets",
+			key:  meta.RegionalKey("neg", "us-central1"),
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+				x.CloudRun = &compute.NetworkEndpointGroupCloudRun{Service: "svc"}
+				x.CloudFunction = &compute.NetworkEndpointGroupCloudFunction{Function: "fn"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "serverless no target",
+			key:  meta.RegionalKey("neg", "us-central1"),
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+			},
+			wantErr: true,
+		},
+		{
+			name: "serverless with network",
+			key:  meta.RegionalKey("neg", "us-central1"),
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "SERVERLESS"
+				x.AppEngine = &compute.NetworkEndpointGroupAppEngine{Service: "svc"}
+				x.Network = network.ID("proj", meta.GlobalKey("vpc")).SelfLink(meta.VersionGA)
+			},
+			wantErr: true,
+		},
+		{
+			name: "internet fqdn global",
+			key:  meta.GlobalKey("neg"),
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "INTERNET_FQDN_PORT"
+				x.DefaultPort = 443
+			},
+		},
+		{
+			name: "internet ip regional",
+			key:  meta.RegionalKey("neg", "us-central1"),
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "INTERNET_IP_PORT"
+				x.Network = network.ID("proj", meta.GlobalKey("vpc")).SelfLink(meta.VersionGA)
+			},
+		},
+		{
+			name: "internet with subnetwork",
+			key:  meta.GlobalKey("neg"),
+			f: func(x *compute.NetworkEndpointGroup) {
+				x.NetworkEndpointType = "INTERNET_FQDN_PORT"
+				x.Subnetwork = subnetwork.ID("proj", meta.RegionalKey("sub", "us-central1")).SelfLink(meta.VersionGA)
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := NewMutableNetworkEndpointGroup("proj", tc.key)
+			if err := mr.Access(tc.f); err != nil {
+				t.Fatalf("Access() = %v, want nil", err)
+			}
+			r, err := mr.Freeze()
+			if err != nil {
+				t.Fatalf("Freeze() = %v, want nil", err)
+			}
+			b := NewBuilderWithResource(r)
+			b.SetOwnership(rnode.OwnershipManaged)
+			b.SetState(rnode.NodeExists)
+
+			_, err = b.Build()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Build() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSyncFromCloudEndpoints(t *testing.T) {
+	key := meta.ZonalKey("neg", "us-central1-b")
+	ep := &compute.NetworkEndpoint{Instance: "vm-1", IpAddress: "10.0.0.1", Port: 80}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	if err := mock.NetworkEndpointGroups().Insert(context.Background(), key, &compute.NetworkEndpointGroup{Name: key.Name}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	mock.MockNetworkEndpointGroups.ListNetworkEndpointsHook = func(context.Context, *meta.Key, *compute.NetworkEndpointGroupsListEndpointsRequest, *filter.F, *cloud.MockNetworkEndpointGroups, ...cloud.Option) ([]*compute.NetworkEndpointWithHealthStatus, error) {
+		return []*compute.NetworkEndpointWithHealthStatus{{NetworkEndpoint: ep}}, nil
+	}
+
+	b := NewBuilder(ID("proj", key))
+	if err := b.SyncFromCloud(context.Background(), mock); err != nil {
+		t.Fatalf("SyncFromCloud() = %v, want nil", err)
+	}
+	b.SetOwnership(rnode.OwnershipManaged)
+	n, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	got, ok := Endpoints(n)
+	if !ok {
+		t.Fatalf("Endpoints() = _, false, want true")
+	}
+	if diff := cmp.Diff(got, []*compute.NetworkEndpoint{ep}); diff != "" {
+		t.Errorf("Endpoints() diff -got,+want: %s", diff)
+	}
+}
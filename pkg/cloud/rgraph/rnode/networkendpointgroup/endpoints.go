@@ -0,0 +1,156 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkendpointgroup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+// endpointSet is the membership of the NEG, keyed by endpointKey(). A nil
+// endpointSet means that the membership is not managed.
+type endpointSet map[string]*compute.NetworkEndpoint
+
+// SetEndpoints sets the endpoints that are members of the NEG. The plan will
+// attach and detach endpoints so that the membership matches. Membership is
+// not managed unless SetEndpoints is called; an empty list will detach all of
+// the endpoints.
+func SetEndpoints(b rnode.Builder, endpoints []*compute.NetworkEndpoint) error {
+	nb, ok := b.(*builder)
+	if !ok {
+		return fmt.Errorf("SetEndpoints: invalid builder type %T", b)
+	}
+	set := endpointSet{}
+	for _, ep := range endpoints {
+		k := endpointKey(nb.ID().Key, ep)
+		if _, ok := set[k]; ok {
+			return fmt.Errorf("SetEndpoints: duplicate endpoint %s in %s", k, nb.ID())
+		}
+		set[k] = ep
+	}
+	nb.endpoints = set
+	return nil
+}
+
+// Endpoints returns the endpoints of the NEG node in a stable order. ok is
+// false if the membership is not managed.
+func Endpoints(n rnode.Node) (endpoints []*compute.NetworkEndpoint, ok bool) {
+	nn, isNEG := n.(*networkEndpointGroupNode)
+	if !isNEG || nn.endpoints == nil {
+		return nil, false
+	}
+	return nn.endpoints.list(), true
+}
+
+// endpointKey identifies the endpoint in the NEG. Instances are referenced by
+// name or URL so only the name is used.
+func endpointKey(neg *meta.Key, ep *compute.NetworkEndpoint) string {
+	instance := ep.Instance
+	if i := strings.LastIndex(instance, "/"); i >= 0 {
+		instance = instance[i+1:]
+	}
+	addr := ep.IpAddress
+	if addr == "" {
+		addr = ep.Fqdn
+	}
+	return meta.NetworkEndpointSubKey(neg, instance, addr, ep.Port).ID
+}
+
+func (s endpointSet) list() []*compute.NetworkEndpoint {
+	var ret []*compute.NetworkEndpoint
+	for _, k := range sortedKeys(s) {
+		ret = append(ret, s[k])
+	}
+	return ret
+}
+
+// diffEndpoints returns the endpoints to attach and detach to go from got to
+// want. Endpoints that changed (e.g. .Annotations) are detached and
+// re-attached.
+func diffEndpoints(got, want endpointSet) (attach, detach []*compute.NetworkEndpoint) {
+	for _, k := range sortedKeys(want) {
+		gotEP, ok := got[k]
+		if !ok || !reflect.DeepEqual(gotEP.Annotations, want[k].Annotations) {
+			attach = append(attach, want[k])
+		}
+	}
+	for _, k := range sortedKeys(got) {
+		wantEP, ok := want[k]
+		if !ok || !reflect.DeepEqual(got[k].Annotations, wantEP.Annotations) {
+			detach = append(detach, got[k])
+		}
+	}
+	return attach, detach
+}
+
+func sortedKeys(s endpointSet) []string {
+	var ret []string
+	for k := range s {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// hasEndpoints is true if endpoints can be attached to NEGs of the given
+// .NetworkEndpointType.
+func hasEndpoints(networkEndpointType string) bool {
+	switch networkEndpointType {
+	case "SERVERLESS", "PRIVATE_SERVICE_CONNECT":
+		return false
+	}
+	return true
+}
+
+// listEndpoints fetches the endpoints of the NEG from the cloud.
+func listEndpoints(ctx context.Context, cl cloud.Cloud, id *cloud.ResourceID) (endpointSet, error) {
+	var (
+		l   []*compute.NetworkEndpointWithHealthStatus
+		err error
+	)
+	projectOpt := cloud.ForceProjectID(id.ProjectID)
+	switch id.Key.Type() {
+	case meta.Global:
+		l, err = cl.GlobalNetworkEndpointGroups().ListNetworkEndpoints(ctx, id.Key, filter.None, projectOpt)
+	case meta.Regional:
+		l, err = cl.RegionNetworkEndpointGroups().ListNetworkEndpoints(ctx, id.Key, filter.None, projectOpt)
+	case meta.Zonal:
+		req := &compute.NetworkEndpointGroupsListEndpointsRequest{}
+		l, err = cl.NetworkEndpointGroups().ListNetworkEndpoints(ctx, id.Key, req, filter.None, projectOpt)
+	default:
+		err = fmt.Errorf("invalid key type")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listEndpoints(%s): %w", id, err)
+	}
+	ret := endpointSet{}
+	for _, ep := range l {
+		if ep.NetworkEndpoint != nil {
+			ret[endpointKey(id.Key, ep.NetworkEndpoint)] = ep.NetworkEndpoint
+		}
+	}
+	return ret, nil
+}
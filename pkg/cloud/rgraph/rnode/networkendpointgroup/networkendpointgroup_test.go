@@ -19,6 +19,7 @@ package networkendpointgroup
 import (
 	"testing"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 )
 
@@ -30,3 +31,20 @@ func TestNetworkEndpointGroupSchema(t *testing.T) {
 		t.Fatalf("CheckSchema() = %v, want nil", err)
 	}
 }
+
+func TestParseResourceURL(t *testing.T) {
+	for _, key := range []*meta.Key{
+		meta.GlobalKey("neg"),
+		meta.RegionalKey("neg", "us-central1"),
+		meta.ZonalKey("neg", "us-central1-b"),
+	} {
+		id := ID("proj", key)
+		got, err := cloud.ParseResourceURL(id.SelfLink(meta.VersionGA))
+		if err != nil {
+			t.Fatalf("ParseResourceURL(%q) = %v, want nil", id.SelfLink(meta.VersionGA), err)
+		}
+		if !got.Equal(id) {
+			t.Errorf("ParseResourceURL(%q) = %v, want %v", id.SelfLink(meta.VersionGA), got, id)
+		}
+	}
+}
@@ -26,9 +26,16 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+// diffPolicy for NetworkEndpointGroup. All fields require a recreate.
+//
+// TODO: handle set labels with an update operation.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate)
+
 type networkEndpointGroupNode struct {
 	rnode.NodeBase
 	resource NetworkEndpointGroup
+	// endpoints are the members of the NEG. See SetEndpoints().
+	endpoints endpointSet
 }
 
 var _ rnode.Node = (*networkEndpointGroupNode)(nil)
@@ -46,14 +53,29 @@ func (n *networkEndpointGroupNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails,
 		return nil, fmt.Errorf("NetworkEndpointGroupNode: Diff %w", err)
 	}
 
-	if diff.HasDiff() {
-		// TODO: handle set labels with an update operation.
+	r, err := n.EffectiveDiffPolicy(diffPolicy).Apply(diff)
+	if err != nil {
+		return nil, fmt.Errorf("NetworkEndpointGroupNode: %w", err)
+	}
+	if r.Diff.HasDiff() {
+		// There is no update method for the NEG resource itself, only the
+		// endpoints can be changed.
 		return &rnode.PlanDetails{
 			Operation: rnode.OpRecreate,
 			Why:       "NetworkEndpointGroup needs to be recreated (no update method exists)",
-			Diff:      diff,
+			Diff:      r.Diff,
 		}, nil
 	}
+	// Membership is only changed if it is managed in want.
+	if n.endpoints != nil {
+		attach, detach := diffEndpoints(got.endpoints, n.endpoints)
+		if len(attach) > 0 || len(detach) > 0 {
+			return &rnode.PlanDetails{
+				Operation: rnode.OpUpdate,
+				Why:       fmt.Sprintf("NetworkEndpointGroup endpoints changed (attach %d, detach %d)", len(attach), len(detach)),
+			}, nil
+		}
+	}
 
 	return &rnode.PlanDetails{
 		Operation: rnode.OpNothing,
@@ -68,8 +90,12 @@ func (n *networkEndpointGroupNode) Actions(got rnode.Node) ([]exec.Action, error
 
 	switch op {
 	case rnode.OpCreate:
-		return rnode.CreateActions[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](
+		actions, err := rnode.CreateActions[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](
 			&ops{}, n, n.resource)
+		if err != nil {
+			return nil, err
+		}
+		return n.withAttachActions(actions), nil
 
 	case rnode.OpDelete:
 		return rnode.DeleteActions[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](
@@ -79,18 +105,43 @@ func (n *networkEndpointGroupNode) Actions(got rnode.Node) ([]exec.Action, error
 		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
 
 	case rnode.OpRecreate:
-		return rnode.RecreateActions[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](
+		actions, err := rnode.RecreateActions[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup](
 			&ops{}, got, n, n.resource)
+		if err != nil {
+			return nil, err
+		}
+		return n.withAttachActions(actions), nil
 
 	case rnode.OpUpdate:
-		// TODO
+		return n.updateActions(got)
 	}
 
 	return nil, fmt.Errorf("NetworkEndpointGroupNode: invalid plan op %s", op)
 }
 
 func (n *networkEndpointGroupNode) Builder() rnode.Builder {
-	b := &builder{}
+	b := &builder{resource: n.resource, endpoints: n.endpoints}
 	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
 	return b
 }
+
+// withAttachActions appends the action to attach the endpoints to a newly
+// created NEG.
+func (n *networkEndpointGroupNode) withAttachActions(actions []exec.Action) []exec.Action {
+	if len(n.endpoints) == 0 {
+		return actions
+	}
+	return append(actions, newEndpointsAction(n.ID(), n.endpoints.list(), nil))
+}
+
+func (n *networkEndpointGroupNode) updateActions(gotNode rnode.Node) ([]exec.Action, error) {
+	got, ok := gotNode.(*networkEndpointGroupNode)
+	if !ok {
+		return nil, fmt.Errorf("NetworkEndpointGroupNode: updateActions: invalid type %T", gotNode)
+	}
+	attach, detach := diffEndpoints(got.endpoints, n.endpoints)
+	return []exec.Action{
+		exec.NewExistsAction(n.ID()),
+		newEndpointsAction(n.ID(), attach, detach),
+	}, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkendpointgroup
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDiffEndpoints(t *testing.T) {
+	id := ID("proj-1", meta.ZonalKey("neg", "us-central1-b"))
+
+	ep := func(instance, ip string) *compute.NetworkEndpoint {
+		return &compute.NetworkEndpoint{Instance: instance, IpAddress: ip, Port: 80}
+	}
+	makeNode := func(endpoints []*compute.NetworkEndpoint) rnode.Node {
+		t.Helper()
+		m := NewMutableNetworkEndpointGroup(id.ProjectID, id.Key)
+		m.Access(func(x *compute.NetworkEndpointGroup) { x.Name = "neg" })
+		if endpoints == nil {
+			return nodetest.Build(t, m, NewBuilderWithResource)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource, func(b rnode.Builder) error {
+			return SetEndpoints(b, endpoints)
+		})
+	}
+
+	got := makeNode([]*compute.NetworkEndpoint{
+		ep("vm-1", "10.0.0.1"),
+		ep("vm-2", "10.0.0.2"),
+	})
+
+	for _, tc := range []struct {
+		name       string
+		endpoints  []*compute.NetworkEndpoint
+		wantOp     rnode.Operation
+		wantAttach []string
+		wantDetach []string
+	}{
+		{
+			name:   "membership not managed",
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "no diff",
+			endpoints: []*compute.NetworkEndpoint{
+				ep("vm-2", "10.0.0.2"),
+				ep("zones/us-central1-b/instances/vm-1", "10.0.0.1"),
+			},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "attach and detach",
+			endpoints: []*compute.NetworkEndpoint{
+				ep("vm-1", "10.0.0.1"),
+				ep("vm-3", "10.0.0.3"),
+			},
+			wantOp:     rnode.OpUpdate,
+			wantAttach: []string{"10.0.0.3"},
+			wantDetach: []string{"10.0.0.2"},
+		},
+		{
+			name: "annotations changed",
+			endpoints: []*compute.NetworkEndpoint{
+				{Instance: "vm-1", IpAddress: "10.0.0.1", Port: 80, Annotations: map[string]string{"a": "b"}},
+				ep("vm-2", "10.0.0.2"),
+			},
+			wantOp:     rnode.OpUpdate,
+			wantAttach: []string{"10.0.0.1"},
+			wantDetach: []string{"10.0.0.1"},
+		},
+		{
+			name:       "detach all",
+			endpoints:  []*compute.NetworkEndpoint{},
+			wantOp:     rnode.OpUpdate,
+			wantDetach: []string{"10.0.0.1", "10.0.0.2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := makeNode(tc.endpoints)
+			p, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*p)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*endpointsAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *endpointsAction", actions[1])
+			}
+			ips := func(l []*compute.NetworkEndpoint) []string {
+				var ret []string
+				for _, ep := range l {
+					ret = append(ret, ep.IpAddress)
+				}
+				return ret
+			}
+			if diff := cmp.Diff(ips(act.attach), tc.wantAttach); diff != "" {
+				t.Errorf("attach: diff -got,+want: %s", diff)
+			}
+			if diff := cmp.Diff(ips(act.detach), tc.wantDetach); diff != "" {
+				t.Errorf("detach: diff -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateActionsAttachEndpoints(t *testing.T) {
+	id := ID("proj-1", meta.ZonalKey("neg", "us-central1-b"))
+	m := NewMutableNetworkEndpointGroup(id.ProjectID, id.Key)
+	m.Access(func(x *compute.NetworkEndpointGroup) { x.Name = "neg" })
+	want := nodetest.Build(t, m, NewBuilderWithResource, func(b rnode.Builder) error {
+		return SetEndpoints(b, []*compute.NetworkEndpoint{{Instance: "vm-1", IpAddress: "10.0.0.1", Port: 80}})
+	})
+	gotb := NewBuilder(id)
+	gotb.SetState(rnode.NodeDoesNotExist)
+	gotb.SetOwnership(rnode.OwnershipManaged)
+	got, err := gotb.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	want.Plan().Set(rnode.PlanDetails{Operation: rnode.OpCreate})
+	actions, err := want.Actions(got)
+	if err != nil {
+		t.Fatalf("Actions() = %v, want nil", err)
+	}
+	act, ok := actions[len(actions)-1].(*endpointsAction)
+	if !ok {
+		t.Fatalf("last action is %T, want *endpointsAction", actions[len(actions)-1])
+	}
+	if len(act.attach) != 1 || len(act.detach) != 0 {
+		t.Errorf("attach = %v, detach = %v; want 1 endpoint attached", act.attach, act.detach)
+	}
+}
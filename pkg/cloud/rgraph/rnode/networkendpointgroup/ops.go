@@ -29,13 +29,19 @@ type ops struct{}
 func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup] {
 	return &rnode.GetFuncs[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup]{
 		GA: rnode.GetFuncsByScope[compute.NetworkEndpointGroup]{
-			Zonal: gcp.NetworkEndpointGroups().Get,
+			Global:   gcp.GlobalNetworkEndpointGroups().Get,
+			Regional: gcp.RegionNetworkEndpointGroups().Get,
+			Zonal:    gcp.NetworkEndpointGroups().Get,
 		},
 		Alpha: rnode.GetFuncsByScope[alpha.NetworkEndpointGroup]{
-			Zonal: gcp.AlphaNetworkEndpointGroups().Get,
+			Global:   gcp.AlphaGlobalNetworkEndpointGroups().Get,
+			Regional: gcp.AlphaRegionNetworkEndpointGroups().Get,
+			Zonal:    gcp.AlphaNetworkEndpointGroups().Get,
 		},
 		Beta: rnode.GetFuncsByScope[beta.NetworkEndpointGroup]{
-			Zonal: gcp.BetaNetworkEndpointGroups().Get,
+			Global:   gcp.BetaGlobalNetworkEndpointGroups().Get,
+			Regional: gcp.BetaRegionNetworkEndpointGroups().Get,
+			Zonal:    gcp.BetaNetworkEndpointGroups().Get,
 		},
 	}
 }
@@ -43,13 +49,19 @@ func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.NetworkEndpointGro
 func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup] {
 	return &rnode.CreateFuncs[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup]{
 		GA: rnode.CreateFuncsByScope[compute.NetworkEndpointGroup]{
-			Zonal: gcp.NetworkEndpointGroups().Insert,
+			Global:   gcp.GlobalNetworkEndpointGroups().Insert,
+			Regional: gcp.RegionNetworkEndpointGroups().Insert,
+			Zonal:    gcp.NetworkEndpointGroups().Insert,
 		},
 		Alpha: rnode.CreateFuncsByScope[alpha.NetworkEndpointGroup]{
-			Zonal: gcp.AlphaNetworkEndpointGroups().Insert,
+			Global:   gcp.AlphaGlobalNetworkEndpointGroups().Insert,
+			Regional: gcp.AlphaRegionNetworkEndpointGroups().Insert,
+			Zonal:    gcp.AlphaNetworkEndpointGroups().Insert,
 		},
 		Beta: rnode.CreateFuncsByScope[beta.NetworkEndpointGroup]{
-			Zonal: gcp.BetaNetworkEndpointGroups().Insert,
+			Global:   gcp.BetaGlobalNetworkEndpointGroups().Insert,
+			Regional: gcp.BetaRegionNetworkEndpointGroups().Insert,
+			Zonal:    gcp.BetaNetworkEndpointGroups().Insert,
 		},
 	}
 }
@@ -61,13 +73,19 @@ func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.NetworkEndpo
 func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup] {
 	return &rnode.DeleteFuncs[compute.NetworkEndpointGroup, alpha.NetworkEndpointGroup, beta.NetworkEndpointGroup]{
 		GA: rnode.DeleteFuncsByScope[compute.NetworkEndpointGroup]{
-			Zonal: gcp.NetworkEndpointGroups().Delete,
+			Global:   gcp.GlobalNetworkEndpointGroups().Delete,
+			Regional: gcp.RegionNetworkEndpointGroups().Delete,
+			Zonal:    gcp.NetworkEndpointGroups().Delete,
 		},
 		Alpha: rnode.DeleteFuncsByScope[alpha.NetworkEndpointGroup]{
-			Zonal: gcp.AlphaNetworkEndpointGroups().Delete,
+			Global:   gcp.AlphaGlobalNetworkEndpointGroups().Delete,
+			Regional: gcp.AlphaRegionNetworkEndpointGroups().Delete,
+			Zonal:    gcp.AlphaNetworkEndpointGroups().Delete,
 		},
 		Beta: rnode.DeleteFuncsByScope[beta.NetworkEndpointGroup]{
-			Zonal: gcp.BetaNetworkEndpointGroups().Delete,
+			Global:   gcp.BetaGlobalNetworkEndpointGroups().Delete,
+			Regional: gcp.BetaRegionNetworkEndpointGroups().Delete,
+			Zonal:    gcp.BetaNetworkEndpointGroups().Delete,
 		},
 	}
 }
@@ -43,6 +43,29 @@ func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 	dt.OutputOnly(api.Path{}.Pointer().Field("Size"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Zone"))
 
+	// The fields that are used depend on the .NetworkEndpointType, e.g.
+	// serverless NEGs set one of .AppEngine, .CloudFunction and .CloudRun
+	// and internet NEGs do not have a .Subnetwork.
+	for _, f := range []string{
+		"Annotations",
+		"AppEngine",
+		"CloudFunction",
+		"CloudRun",
+		"DefaultPort",
+		"Description",
+		"Network",
+		// NetworkEndpointType defaults to GCE_VM_IP_PORT.
+		"NetworkEndpointType",
+		"PscTargetService",
+		"Subnetwork",
+	} {
+		dt.AllowZeroValue(api.Path{}.Pointer().Field(f))
+	}
+
 	// TODO: handle alpha/beta
+	// References to other resources.
+	dt.Reference(api.Path{}.Pointer().Field("Network"), "networks")
+	dt.Reference(api.Path{}.Pointer().Field("Subnetwork"), "subnetworks")
+
 	return dt
 }
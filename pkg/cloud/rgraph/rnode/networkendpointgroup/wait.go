@@ -0,0 +1,81 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkendpointgroup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+// ConditionHealthy is the condition signaled by NewWaitHealthyAction.
+const ConditionHealthy = "Healthy"
+
+// NewWaitHealthyAction returns an Action that waits for the endpoints of
+// the NetworkEndpointGroup to be attached and healthy. It signals
+// exec.NewReadyEvent(id, ConditionHealthy).
+//
+// The NEG must have at least one endpoint. Health is only reported for
+// zonal NEGs; global and regional NEGs are ready once they have endpoints.
+func NewWaitHealthyAction(id *cloud.ResourceID, opts exec.WaitOptions) exec.Action {
+	return exec.NewWaitAction(id, ConditionHealthy, func(ctx context.Context, cl cloud.Cloud) (bool, error) {
+		var (
+			l   []*compute.NetworkEndpointWithHealthStatus
+			err error
+		)
+		projectOpt := cloud.ForceProjectID(id.ProjectID)
+		switch id.Key.Type() {
+		case meta.Global:
+			l, err = cl.GlobalNetworkEndpointGroups().ListNetworkEndpoints(ctx, id.Key, filter.None, projectOpt)
+		case meta.Regional:
+			l, err = cl.RegionNetworkEndpointGroups().ListNetworkEndpoints(ctx, id.Key, filter.None, projectOpt)
+		case meta.Zonal:
+			req := &compute.NetworkEndpointGroupsListEndpointsRequest{HealthStatus: "SHOW"}
+			l, err = cl.NetworkEndpointGroups().ListNetworkEndpoints(ctx, id.Key, req, filter.None, projectOpt)
+			if err == nil {
+				return len(l) > 0 && allHealthy(l), nil
+			}
+		default:
+			err = fmt.Errorf("invalid key type")
+		}
+		if err != nil {
+			return false, err
+		}
+		return len(l) > 0, nil
+	}, opts)
+}
+
+// allHealthy returns true if every endpoint is reported HEALTHY by all of
+// the backend services it is attached to.
+func allHealthy(l []*compute.NetworkEndpointWithHealthStatus) bool {
+	for _, ep := range l {
+		if len(ep.Healths) == 0 {
+			return false
+		}
+		for _, h := range ep.Healths {
+			if h.HealthState != "HEALTHY" {
+				return false
+			}
+		}
+	}
+	return true
+}
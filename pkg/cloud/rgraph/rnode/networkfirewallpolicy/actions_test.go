@@ -0,0 +1,55 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkfirewallpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestUpdateAction(t *testing.T) {
+	key := meta.GlobalKey("fwp")
+	act := &updateAction{
+		id:    ID("proj", key),
+		patch: &compute.FirewallPolicy{Name: "fwp", Description: "new"},
+		rules: &ruleChanges{
+			add:    []*compute.FirewallPolicyRule{makeRule(3000, "130.211.0.0/22")},
+			patch:  []*compute.FirewallPolicyRule{makeRule(1000, "10.1.0.0/16")},
+			remove: []int64{2000},
+		},
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	// The mock Patch() of the policy fields returns NotFound unless "fwp"
+	// was inserted; the rule methods do not check.
+	if err := mock.NetworkFirewallPolicies().Insert(context.Background(), key, &compute.FirewallPolicy{Name: "fwp"}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	if _, err := act.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	for _, op := range []string{"Patch", "RemoveRule", "PatchRule", "AddRule"} {
+		calls := mock.Calls().Matching(cloud.MockCall{Service: "NetworkFirewallPolicies", Operation: op, Key: key})
+		if len(calls) != 1 {
+			t.Errorf("calls to NetworkFirewallPolicies.%s = %v, want 1", op, calls)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkfirewallpolicy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "networkFirewallPolicies",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableNetworkFirewallPolicy = api.MutableResource[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy]
+
+func NewMutableNetworkFirewallPolicy(project string, key *meta.Key) MutableNetworkFirewallPolicy {
+	id := ID(project, key)
+	return api.NewResource[
+		compute.FirewallPolicy,
+		alpha.FirewallPolicy,
+		beta.FirewallPolicy,
+	](id, &typeTrait{})
+}
+
+type NetworkFirewallPolicy = api.Resource[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy]
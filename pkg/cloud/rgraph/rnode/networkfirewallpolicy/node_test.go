@@ -0,0 +1,128 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkfirewallpolicy
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func makeRule(priority int64, ipRange string) *compute.FirewallPolicyRule {
+	return &compute.FirewallPolicyRule{
+		Action:    "allow",
+		Direction: "INGRESS",
+		Priority:  priority,
+		Match: &compute.FirewallPolicyRuleMatcher{
+			SrcIpRanges:   []string{ipRange},
+			Layer4Configs: []*compute.FirewallPolicyRuleMatcherLayer4Config{{IpProtocol: "tcp"}},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.GlobalKey("fwp"))
+	makeNode := func(desc string, rules ...*compute.FirewallPolicyRule) rnode.Node {
+		t.Helper()
+		m := NewMutableNetworkFirewallPolicy(id.ProjectID, id.Key)
+		if err := m.Access(func(x *compute.FirewallPolicy) {
+			x.Name = "fwp"
+			x.Description = desc
+			x.Rules = rules
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	got := makeNode("",
+		makeRule(1000, "10.0.0.0/8"),
+		makeRule(2000, "35.191.0.0/16"),
+		makeRule(firstDefaultRulePriority, "0.0.0.0/0"),
+	)
+
+	for _, tc := range []struct {
+		name      string
+		want      rnode.Node
+		wantOp    rnode.Operation
+		wantRules string
+		wantPatch bool
+	}{
+		{
+			name:   "no diff",
+			want:   makeNode("", makeRule(1000, "10.0.0.0/8"), makeRule(2000, "35.191.0.0/16"), makeRule(firstDefaultRulePriority, "0.0.0.0/0")),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "default rules are ignored",
+			want:   makeNode("", makeRule(1000, "10.0.0.0/8"), makeRule(2000, "35.191.0.0/16")),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:      "description",
+			want:      makeNode("new", makeRule(1000, "10.0.0.0/8"), makeRule(2000, "35.191.0.0/16")),
+			wantOp:    rnode.OpUpdate,
+			wantRules: "add [], patch [], remove []",
+			wantPatch: true,
+		},
+		{
+			name:      "add, patch and remove rules",
+			want:      makeNode("", makeRule(1000, "10.1.0.0/16"), makeRule(3000, "130.211.0.0/22")),
+			wantOp:    rnode.OpUpdate,
+			wantRules: "add [3000], patch [1000], remove [2000]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := tc.want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			tc.want.Plan().Set(rnode.PlanDetails{
+				Operation: p.Operation,
+				Why:       p.Why,
+				Diff:      p.Diff,
+			})
+			actions, err := tc.want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*updateAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *updateAction", actions[1])
+			}
+			if gotRules := act.rules.String(); gotRules != tc.wantRules {
+				t.Errorf("rules = %q, want %q", gotRules, tc.wantRules)
+			}
+			if gotPatch := act.patch != nil; gotPatch != tc.wantPatch {
+				t.Errorf("patch = %t, want %t", gotPatch, tc.wantPatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkfirewallpolicy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy] {
+	return &rnode.GetFuncs[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy]{
+		GA: rnode.GetFuncsByScope[compute.FirewallPolicy]{
+			Global: gcp.NetworkFirewallPolicies().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.FirewallPolicy]{
+			Global: gcp.AlphaNetworkFirewallPolicies().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.FirewallPolicy]{
+			Global: gcp.BetaNetworkFirewallPolicies().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy] {
+	return &rnode.CreateFuncs[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy]{
+		GA: rnode.CreateFuncsByScope[compute.FirewallPolicy]{
+			Global: gcp.NetworkFirewallPolicies().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.FirewallPolicy]{
+			Global: gcp.AlphaNetworkFirewallPolicies().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.FirewallPolicy]{
+			Global: gcp.BetaNetworkFirewallPolicies().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy] {
+	return &rnode.UpdateFuncs[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy]{
+		GA: rnode.UpdateFuncsByScope[compute.FirewallPolicy]{
+			Global: gcp.NetworkFirewallPolicies().Patch,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.FirewallPolicy]{
+			Global: gcp.AlphaNetworkFirewallPolicies().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.FirewallPolicy]{
+			Global: gcp.BetaNetworkFirewallPolicies().Patch,
+		},
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy] {
+	return &rnode.DeleteFuncs[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy]{
+		GA: rnode.DeleteFuncsByScope[compute.FirewallPolicy]{
+			Global: gcp.NetworkFirewallPolicies().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.FirewallPolicy]{
+			Global: gcp.AlphaNetworkFirewallPolicies().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.FirewallPolicy]{
+			Global: gcp.BetaNetworkFirewallPolicies().Delete,
+		},
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkfirewallpolicy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/networkFirewallPolicies
+type typeTrait struct {
+	api.BaseTypeTrait[compute.FirewallPolicy, alpha.FirewallPolicy, beta.FirewallPolicy]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	// Built-ins
+	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
+
+	// [Output Only]
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Parent"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("RuleTupleCount"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLinkWithId"))
+
+	rule := func() api.Path { return api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer() }
+	dt.OutputOnly(rule().Field("Kind"))
+	dt.OutputOnly(rule().Field("RuleTupleCount"))
+	// FirewallPolicyRule is also returned by getRule().
+	dt.System(rule().Field("ServerResponse"))
+
+	// Associations are managed with addAssociation() and removeAssociation().
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Associations"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	// DisplayName and ShortName are only used by hierarchical policies.
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("DisplayName"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("ShortName"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules"))
+
+	for _, f := range []string{
+		"Description",
+		"Disabled",
+		"EnableLogging",
+		// Priority 0 is the highest priority.
+		"Priority",
+		"RuleName",
+		"TargetResources",
+		"TargetSecureTags",
+		"TargetServiceAccounts",
+	} {
+		dt.AllowZeroValue(rule().Field(f))
+	}
+	match := func() api.Path { return rule().Field("Match").Pointer() }
+	for _, f := range []string{
+		"DestAddressGroups",
+		"DestFqdns",
+		"DestIpRanges",
+		"DestRegionCodes",
+		"DestThreatIntelligences",
+		"SrcAddressGroups",
+		"SrcFqdns",
+		"SrcIpRanges",
+		"SrcRegionCodes",
+		"SrcSecureTags",
+		"SrcThreatIntelligences",
+	} {
+		dt.AllowZeroValue(match().Field(f))
+	}
+	dt.AllowZeroValue(match().Field("Layer4Configs").AnySliceIndex().Pointer().Field("Ports"))
+
+	return dt
+}
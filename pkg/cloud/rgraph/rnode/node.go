@@ -18,6 +18,7 @@ package rnode
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
 )
@@ -36,6 +37,8 @@ type Node interface {
 	State() NodeState
 	// Ownership of this resource.
 	Ownership() OwnershipStatus
+	// Version of the API used for the resource. See Builder.SetVersion().
+	Version() meta.Version
 	// OutRefs of this resource pointing to other resources.
 	OutRefs() []ResourceRef
 	// InRefs pointing to this resource.
@@ -56,6 +59,19 @@ type Node interface {
 	// have not been planned. "got" is the current state of the Node in the
 	// "got" graph.
 	Actions(got Node) ([]exec.Action, error)
+	// Preconditions that must be met before the planned operation is
+	// executed. These include the ones added with Builder.AddPrecondition().
+	Preconditions() []Precondition
+}
+
+// UsedByNode is implemented by Nodes with a resource that lists the resources
+// that reference it (e.g. Address.Users). This is used to find references from
+// resources that are not in the graph.
+type UsedByNode interface {
+	Node
+	// UsedBy returns the resources that reference this one. This is only
+	// valid for a Node that has been fetched from the Cloud.
+	UsedBy() ([]*cloud.ResourceID, error)
 }
 
 // NodeBase are common non-typed fields for implementing a Node in the graph.
@@ -63,30 +79,86 @@ type NodeBase struct {
 	id        *cloud.ResourceID
 	state     NodeState
 	ownership OwnershipStatus
+	version   meta.Version
 	outRefs   []ResourceRef
 	inRefs    []ResourceRef
 	plan      Plan
+
+	diffPolicy    *DiffPolicy
+	ignored       []api.Path
+	preconditions []Precondition
 }
 
 func (n *NodeBase) ID() *cloud.ResourceID      { return n.id }
 func (n *NodeBase) State() NodeState           { return n.state }
 func (n *NodeBase) Ownership() OwnershipStatus { return n.ownership }
+func (n *NodeBase) Version() meta.Version      { return n.version }
 func (n *NodeBase) OutRefs() []ResourceRef     { return n.outRefs }
 func (n *NodeBase) InRefs() []ResourceRef      { return n.inRefs }
 func (n *NodeBase) Plan() *Plan                { return &n.plan }
 
+// Preconditions added to the Builder. Node types with their own
+// preconditions should append to these.
+func (n *NodeBase) Preconditions() []Precondition { return n.preconditions }
+
+// EffectiveDiffPolicy returns the defaults for the node type with the
+// overrides from Builder.SetDiffPolicy() and Builder.IgnoreDiff() applied.
+// Changes that are only due to the OwnerMarker of the Plan are ignored.
+func (n *NodeBase) EffectiveDiffPolicy(defaults *DiffPolicy) *DiffPolicy {
+	ret := defaults.Override(n.diffPolicy)
+	if len(n.ignored) > 0 {
+		ignore := NewDiffPolicy("")
+		for _, p := range n.ignored {
+			ignore.Set(p, FieldIgnore)
+		}
+		ret = ret.Override(ignore)
+	}
+	if m := n.plan.OwnerMarker(); m != nil {
+		ret = ret.Override(NewDiffPolicy("").IgnoreItems(m.ignoreDiff))
+	}
+	return ret
+}
+
+// CopyBuilderSettings copies the settings of the Node that are not part of
+// the resource (API version, DiffPolicy, ignored diffs and preconditions) to
+// b. Node.Builder() only initializes b with the resource and its state.
+func CopyBuilderSettings(n Node, b Builder) {
+	b.SetVersion(n.Version())
+	nb, ok := n.(interface{ nodeBase() *NodeBase })
+	if !ok {
+		return
+	}
+	base := nb.nodeBase()
+	if base.diffPolicy != nil {
+		b.SetDiffPolicy(base.diffPolicy)
+	}
+	b.IgnoreDiff(base.ignored...)
+	for _, p := range base.preconditions {
+		b.AddPrecondition(p)
+	}
+}
+
+func (n *NodeBase) nodeBase() *NodeBase { return n }
+
 // InitFromBuilder is an rgraph library internal method for common
 // initialization from a Builder.
 func (n *NodeBase) InitFromBuilder(b Builder) error {
 	n.id = b.ID()
 	n.state = b.State()
 	n.ownership = b.Ownership()
+	n.version = b.Version()
+	if !b.versionSet() && b.Resource() != nil {
+		n.version = b.Resource().Version()
+	}
 	outRefs, err := b.OutRefs()
 	if err != nil {
 		return err
 	}
 	n.outRefs = outRefs
 	n.inRefs = b.inRefs()
+	n.diffPolicy = b.DiffPolicy()
+	n.ignored = b.IgnoredDiffs()
+	n.preconditions = b.Preconditions()
 
 	return nil
 }
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"reflect"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// OwnerMarker identifies the component (and graph) that manages a resource.
+// The marker is stamped on resources when they are created so that the
+// resources owned by the controller can be found later with Owns().
+type OwnerMarker struct {
+	// Labels are added to the .Labels of resources, e.g.
+	// {"managed-by": "my-controller", "graph": "lb-1"}.
+	Labels map[string]string
+	// Description is used for resource types that do not have .Labels. It
+	// is set as the .Description if the resource does not have one.
+	Description string
+}
+
+// Owns returns true if the resource carries the marker.
+func (m *OwnerMarker) Owns(r UntypedResource) bool {
+	if m == nil || r == nil {
+		return false
+	}
+	// Call ToGA(), ToAlpha() or ToBeta() depending on the version of the
+	// resource. .Labels and .Description are in all versions.
+	method := map[meta.Version]string{
+		meta.VersionGA:    "ToGA",
+		meta.VersionAlpha: "ToAlpha",
+		meta.VersionBeta:  "ToBeta",
+	}[r.Version()]
+	fn := reflect.ValueOf(r).MethodByName(method)
+	if !fn.IsValid() {
+		return false
+	}
+	out := fn.Call(nil)
+	if len(out) != 2 || !out[1].IsNil() || out[0].Kind() != reflect.Pointer || out[0].IsNil() {
+		return false
+	}
+	v := out[0].Elem()
+
+	if labels, ok := ownerLabels(v); ok {
+		if len(m.Labels) == 0 {
+			return false
+		}
+		for k, val := range m.Labels {
+			if lv, ok := labels[k]; !ok || lv != val {
+				return false
+			}
+		}
+		return true
+	}
+	desc := v.FieldByName("Description")
+	return m.Description != "" && desc.IsValid() && desc.Kind() == reflect.String && desc.String() == m.Description
+}
+
+// ignoreDiff returns true if the item is only due to the marker stamped on
+// the got resource (A) that is not in want (B). api.Diff reports the Labels
+// per map key (e.g. "*.Labels:owner"), except when the want has no Labels at
+// all, in which case the whole map is a single item.
+func (m *OwnerMarker) ignoreDiff(item api.DiffItem) bool {
+	labelsPath := api.Path{}.Pointer().Field("Labels")
+	switch {
+	case item.Path.Match(labelsPath.AnyMapIndex()):
+		if item.State != api.DiffItemOnlyInA {
+			return false
+		}
+		for k, v := range m.Labels {
+			if item.Path.Equal(labelsPath.MapIndex(k)) {
+				got, _ := item.A.(string)
+				return got == v
+			}
+		}
+		return false
+	case item.Path.Equal(labelsPath):
+		got, _ := item.A.(map[string]string)
+		want, _ := item.B.(map[string]string)
+		if len(m.Labels) == 0 || len(got) != len(want)+len(m.Labels) {
+			return false
+		}
+		for k, v := range got {
+			if wv, ok := want[k]; ok {
+				if wv != v {
+					return false
+				}
+			} else if mv, ok := m.Labels[k]; !ok || mv != v {
+				return false
+			}
+		}
+		return true
+	case item.Path.Equal(api.Path{}.Pointer().Field("Description")):
+		got, _ := item.A.(string)
+		want, _ := item.B.(string)
+		return m.Description != "" && got == m.Description && want == ""
+	}
+	return false
+}
+
+// ownerLabels returns the .Labels of the struct v. Returns false if the type
+// does not have .Labels.
+func ownerLabels(v reflect.Value) (map[string]string, bool) {
+	fv := v.FieldByName("Labels")
+	if !fv.IsValid() || fv.Type() != reflect.TypeOf(map[string]string{}) {
+		return nil, false
+	}
+	labels, _ := fv.Interface().(map[string]string)
+	return labels, true
+}
+
+// stampOwner returns a copy of x with the marker applied. x is not modified.
+func stampOwner[T any](x *T, m *OwnerMarker) *T {
+	ret := new(T)
+	*ret = *x
+	v := reflect.ValueOf(ret).Elem()
+	if v.Kind() != reflect.Struct {
+		return ret
+	}
+	if labels, ok := ownerLabels(v); ok {
+		if len(m.Labels) == 0 {
+			return ret
+		}
+		merged := map[string]string{}
+		for k, val := range labels {
+			merged[k] = val
+		}
+		for k, val := range m.Labels {
+			merged[k] = val
+		}
+		v.FieldByName("Labels").Set(reflect.ValueOf(merged))
+		return ret
+	}
+	if desc := v.FieldByName("Description"); m.Description != "" && desc.IsValid() && desc.Kind() == reflect.String && desc.String() == "" {
+		desc.SetString(m.Description)
+	}
+	return ret
+}
+
+// WantResource returns the resource to send to the API for node: resource
+// converted to the Version() of the node, with the OwnerMarker of the Plan
+// stamped. Every action that writes the resource (create, recreate, update,
+// setLabels) must send this so that a change does not drop the marker.
+func WantResource[GA any, Alpha any, Beta any](node Node, resource api.Resource[GA, Alpha, Beta]) (api.Resource[GA, Alpha, Beta], error) {
+	resource, err := resource.AsVersion(node.Version())
+	if err != nil {
+		return nil, err
+	}
+	return WithOwnerMarker(resource, node.Plan().OwnerMarker()), nil
+}
+
+// WithOwnerMarker returns r with the marker stamped on the raw objects. r is
+// returned as-is if m is nil. Use WantResource() to get the resource for a
+// Node.
+func WithOwnerMarker[GA any, Alpha any, Beta any](r api.Resource[GA, Alpha, Beta], m *OwnerMarker) api.Resource[GA, Alpha, Beta] {
+	if m == nil || r == nil {
+		return r
+	}
+	return &ownerResource[GA, Alpha, Beta]{Resource: r, marker: m}
+}
+
+// ownerResource stamps the marker on the objects returned by the wrapped
+// resource. Like api.Resource, the object is returned along with a conversion
+// error if there is one.
+type ownerResource[GA any, Alpha any, Beta any] struct {
+	api.Resource[GA, Alpha, Beta]
+	marker *OwnerMarker
+}
+
+func (r *ownerResource[GA, Alpha, Beta]) ToGA() (*GA, error) {
+	x, err := r.Resource.ToGA()
+	if x == nil {
+		return nil, err
+	}
+	return stampOwner(x, r.marker), err
+}
+
+func (r *ownerResource[GA, Alpha, Beta]) ToAlpha() (*Alpha, error) {
+	x, err := r.Resource.ToAlpha()
+	if x == nil {
+		return nil, err
+	}
+	return stampOwner(x, r.marker), err
+}
+
+func (r *ownerResource[GA, Alpha, Beta]) ToBeta() (*Beta, error) {
+	x, err := r.Resource.ToBeta()
+	if x == nil {
+		return nil, err
+	}
+	return stampOwner(x, r.marker), err
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+type labeledResource struct {
+	Name            string
+	Description     string
+	Labels          map[string]string
+	NullFields      []string
+	ForceSendFields []string
+}
+
+type labeledAlphaResource struct {
+	Name            string
+	Description     string
+	Labels          map[string]string
+	AlphaField      string
+	NullFields      []string
+	ForceSendFields []string
+}
+
+type describedResource struct {
+	Name            string
+	Description     string
+	NullFields      []string
+	ForceSendFields []string
+}
+
+func TestOwnerMarker(t *testing.T) {
+	marker := &OwnerMarker{Labels: map[string]string{"owner": "test"}, Description: "owner: test"}
+
+	t.Run("labels", func(t *testing.T) {
+		m := api.NewResource[labeledResource, labeledResource, labeledResource](globalID("res"), nil)
+		m.Access(func(x *labeledResource) {
+			x.Description = "desc"
+			x.Labels = map[string]string{"a": "b"}
+		})
+		r, err := m.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v", err)
+		}
+		if marker.Owns(r) {
+			t.Errorf("Owns() = true, want false")
+		}
+		stamped := WithOwnerMarker(r, marker)
+		if !marker.Owns(stamped) {
+			t.Errorf("Owns(stamped) = false, want true")
+		}
+		x, _ := stamped.ToGA()
+		want := &labeledResource{Name: "res", Description: "desc", Labels: map[string]string{"a": "b", "owner": "test"}}
+		if diff := cmp.Diff(x, want); diff != "" {
+			t.Errorf("ToGA() = %+v; -got,+want: %s", x, diff)
+		}
+		// The original resource is not modified.
+		if x, _ := r.ToGA(); len(x.Labels) != 1 {
+			t.Errorf("r.Labels = %v, want 1 label", x.Labels)
+		}
+	})
+
+	t.Run("description", func(t *testing.T) {
+		m := api.NewResource[describedResource, describedResource, describedResource](globalID("res"), nil)
+		r, err := m.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v", err)
+		}
+		if marker.Owns(r) {
+			t.Errorf("Owns() = true, want false")
+		}
+		stamped := WithOwnerMarker(r, marker)
+		if !marker.Owns(stamped) {
+			t.Errorf("Owns(stamped) = false, want true")
+		}
+		if x, _ := stamped.ToBeta(); x.Description != marker.Description {
+			t.Errorf("Description = %q, want %q", x.Description, marker.Description)
+		}
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+		m := api.NewResource[labeledResource, labeledAlphaResource, labeledResource](globalID("res"), nil)
+		m.AccessAlpha(func(x *labeledAlphaResource) {
+			x.Description = "desc"
+			x.Labels = map[string]string{"a": "b"}
+			x.AlphaField = "x"
+		})
+		r, err := m.Freeze()
+		if err != nil {
+			t.Fatalf("Freeze() = %v", err)
+		}
+		// .AlphaField cannot be converted to GA. The object is still
+		// returned (with the marker) along with the error.
+		x, err := WithOwnerMarker(r, marker).ToGA()
+		if err == nil {
+			t.Errorf("ToGA() = _, nil; want error")
+		}
+		if x == nil || x.Labels["owner"] != "test" {
+			t.Errorf("ToGA() = %+v, _; want Labels[owner] = test", x)
+		}
+	})
+
+	t.Run("nil marker", func(t *testing.T) {
+		var m *OwnerMarker
+		if m.Owns(nil) {
+			t.Errorf("Owns() = true, want false")
+		}
+	})
+}
+
+func TestOwnerMarkerIgnoreDiff(t *testing.T) {
+	marker := &OwnerMarker{Labels: map[string]string{"owner": "test"}, Description: "owner: test"}
+	labelsPath := api.Path{}.Pointer().Field("Labels")
+	descPath := api.Path{}.Pointer().Field("Description")
+
+	for _, tc := range []struct {
+		name string
+		item api.DiffItem
+		want bool
+	}{
+		{
+			name: "marker labels",
+			item: api.DiffItem{Path: labelsPath, A: map[string]string{"a": "b", "owner": "test"}, B: map[string]string{"a": "b"}},
+			want: true,
+		},
+		{
+			name: "marker labels only",
+			item: api.DiffItem{Path: labelsPath, A: map[string]string{"owner": "test"}, B: map[string]string(nil)},
+			want: true,
+		},
+		{
+			name: "other labels changed",
+			item: api.DiffItem{Path: labelsPath, A: map[string]string{"a": "c", "owner": "test"}, B: map[string]string{"a": "b"}},
+		},
+		{
+			name: "marker label value",
+			item: api.DiffItem{Path: labelsPath, A: map[string]string{"a": "b", "owner": "other"}, B: map[string]string{"a": "b"}},
+		},
+		{
+			name: "marker label key",
+			item: api.DiffItem{State: api.DiffItemOnlyInA, Path: labelsPath.MapIndex("owner"), A: "test"},
+			want: true,
+		},
+		{
+			name: "marker label key value",
+			item: api.DiffItem{State: api.DiffItemOnlyInA, Path: labelsPath.MapIndex("owner"), A: "other"},
+		},
+		{
+			name: "marker label key changed",
+			item: api.DiffItem{State: api.DiffItemDifferent, Path: labelsPath.MapIndex("owner"), A: "test", B: "other"},
+		},
+		{
+			name: "other label key",
+			item: api.DiffItem{State: api.DiffItemOnlyInA, Path: labelsPath.MapIndex("a"), A: "test"},
+		},
+		{
+			name: "marker description",
+			item: api.DiffItem{Path: descPath, A: "owner: test", B: ""},
+			want: true,
+		},
+		{
+			name: "description changed",
+			item: api.DiffItem{Path: descPath, A: "owner: test", B: "abc"},
+		},
+		{
+			name: "other field",
+			item: api.DiffItem{Path: api.Path{}.Pointer().Field("Name"), A: "owner: test", B: ""},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := marker.ignoreDiff(tc.item); got != tc.want {
+				t.Errorf("ignoreDiff() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOwnerMarkerIgnoreDiffWithDiff(t *testing.T) {
+	marker := &OwnerMarker{Labels: map[string]string{"owner": "test"}, Description: "owner: test"}
+	policy := NewDiffPolicy("").IgnoreItems(marker.ignoreDiff)
+
+	for _, tc := range []struct {
+		name     string
+		got      map[string]string
+		want     map[string]string
+		wantDiff bool
+	}{
+		{
+			name: "marker",
+			got:  map[string]string{"a": "b", "owner": "test"},
+			want: map[string]string{"a": "b"},
+		},
+		{
+			name: "marker only",
+			got:  map[string]string{"owner": "test"},
+		},
+		{
+			name:     "marker and other change",
+			got:      map[string]string{"a": "b", "owner": "test"},
+			want:     map[string]string{"a": "c"},
+			wantDiff: true,
+		},
+		{
+			name:     "no marker",
+			got:      map[string]string{"a": "b"},
+			want:     map[string]string{},
+			wantDiff: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			newRes := func(labels map[string]string) api.Resource[labeledResource, labeledResource, labeledResource] {
+				m := api.NewResource[labeledResource, labeledResource, labeledResource](globalID("res"), nil)
+				m.Access(func(x *labeledResource) { x.Labels = labels })
+				r, err := m.Freeze()
+				if err != nil {
+					t.Fatalf("Freeze() = %v", err)
+				}
+				return r
+			}
+			result, err := newRes(tc.got).Diff(newRes(tc.want))
+			if err != nil {
+				t.Fatalf("Diff() = %v", err)
+			}
+			if !result.HasDiff() {
+				t.Fatalf("Diff() has no diff, want the marker in the diff")
+			}
+			pr, err := policy.Apply(result)
+			if err != nil {
+				t.Fatalf("Apply() = %v", err)
+			}
+			if gotDiff := pr.Diff.HasDiff(); gotDiff != tc.wantDiff {
+				t.Errorf("Apply().Diff = %+v, want diff = %t", pr.Diff.Items, tc.wantDiff)
+			}
+		})
+	}
+}
@@ -30,6 +30,9 @@ type Plan struct {
 	// end of this list. We keep the previous values for debug
 	// output.
 	details []PlanDetails
+	// ownerMarker is stamped on the resource by the create, recreate and
+	// update Actions (e.g. setLabels()).
+	ownerMarker *OwnerMarker
 }
 
 // Operation to perform on the Node.
@@ -89,6 +92,17 @@ func (p *Plan) Set(a PlanDetails) {
 	p.details = append(p.details, a)
 }
 
+// OwnerMarker to stamp on the resource in the create, recreate and update
+// Actions of the Node (see WantResource()). nil if the resource is not
+// marked.
+func (p *Plan) OwnerMarker() *OwnerMarker { return p.ownerMarker }
+
+// SetOwnerMarker to stamp on the resource in the create, recreate and update
+// Actions, including the custom updates such as setLabels(). As every Action
+// that writes the resource keeps the marker, diffs that are only due to the
+// marker on the current resource are ignored.
+func (p *Plan) SetOwnerMarker(m *OwnerMarker) { p.ownerMarker = m }
+
 func (p *Plan) String() string {
 	if p == nil || len(p.details) == 0 {
 		return "no plan"
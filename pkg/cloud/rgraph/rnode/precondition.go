@@ -0,0 +1,70 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+)
+
+// Precondition is a read-only check against the Cloud that must pass before
+// the planned operation on a node is executed, e.g. "the subnetwork has
+// purpose PRIVATE_SERVICE_CONNECT". Preconditions are evaluated by the
+// planner so that the plan fails before any resource is changed.
+type Precondition struct {
+	// Name is a short description of the condition, e.g. "NAT subnets have
+	// purpose PRIVATE_SERVICE_CONNECT".
+	Name string
+	// Ops the check applies to. If empty, the check applies to all of the
+	// operations that change the resource (Create, Recreate, Update and
+	// Delete).
+	Ops []Operation
+	// Check returns an error if the precondition is not met. The error
+	// should tell the user what to fix. n is the node in the "want" graph.
+	Check func(ctx context.Context, cl cloud.Cloud, n Node) error
+}
+
+func (p *Precondition) appliesTo(op Operation) bool {
+	if len(p.Ops) == 0 {
+		return op == OpCreate || op == OpRecreate || op == OpUpdate || op == OpDelete
+	}
+	for _, o := range p.Ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckPreconditions evaluates the Preconditions of the node that apply to
+// the planned operation. All of the failed checks are returned.
+func CheckPreconditions(ctx context.Context, cl cloud.Cloud, n Node) error {
+	op := n.Plan().Op()
+	var errs []error
+	for _, p := range n.Preconditions() {
+		if !p.appliesTo(op) {
+			continue
+		}
+		if err := p.Check(ctx, cl, n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: precondition %q failed for %s: %w", n.ID(), p.Name, op, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rnode
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// OutRefsFromTraits returns the references from the resource using the
+// fields declared with FieldTraits.Reference() in the TypeTrait. The
+// resource is walked in its own version so references in Alpha and Beta only
+// fields are included.
+func OutRefsFromTraits[GA any, Alpha any, Beta any](
+	r api.Resource[GA, Alpha, Beta],
+	tt api.TypeTrait[GA, Alpha, Beta],
+) ([]ResourceRef, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	var (
+		obj any
+		err error
+	)
+	switch r.Version() {
+	case meta.VersionGA:
+		obj, err = r.ToGA()
+	case meta.VersionAlpha:
+		obj, err = r.ToAlpha()
+	case meta.VersionBeta:
+		obj, err = r.ToBeta()
+	default:
+		return nil, fmt.Errorf("OutRefsFromTraits %s: invalid version %q", r.ResourceID(), r.Version())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("OutRefsFromTraits %s: %w", r.ResourceID(), err)
+	}
+
+	values, err := tt.FieldTraits(r.Version()).FindReferences(obj)
+	if err != nil {
+		return nil, fmt.Errorf("OutRefsFromTraits %s: %w", r.ResourceID(), err)
+	}
+
+	var ret []ResourceRef
+	for _, v := range values {
+		id, err := cloud.ParseResourceURL(v.URL)
+		if err != nil {
+			return nil, fmt.Errorf("OutRefsFromTraits %s: %s: %w", r.ResourceID(), v.Path, err)
+		}
+		if v.Field.Resource != "" && id.Resource != v.Field.Resource {
+			return nil, fmt.Errorf("OutRefsFromTraits %s: %s: references %q, want a %s", r.ResourceID(), v.Path, v.URL, v.Field.Resource)
+		}
+		ret = append(ret, ResourceRef{From: r.ResourceID(), Path: v.Path, To: id})
+	}
+	return ret, nil
+}
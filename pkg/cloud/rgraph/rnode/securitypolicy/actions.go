@@ -0,0 +1,97 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+// updateAction patches the SecurityPolicy, sets the labels and updates the
+// rules that changed. Rules are removed first so that their priority can be
+// reused by the rules that are added.
+type updateAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// patch is nil if no fields other than the rules and labels changed.
+	patch *compute.SecurityPolicy
+	// labels is nil if the labels did not change.
+	labels *compute.GlobalSetLabelsRequest
+	rules  *ruleChanges
+}
+
+func (act *updateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	if act.id.Key.Type() != meta.Global {
+		return nil, fmt.Errorf("SecurityPolicyUpdateAction Run(%s): invalid key type", act.id)
+	}
+	svc := cl.SecurityPolicies()
+	projectOpt := cloud.ForceProjectID(act.id.ProjectID)
+	priorityOpt := func(p int64) cloud.Option {
+		return cloud.QueryParameter("priority", strconv.FormatInt(p, 10))
+	}
+
+	if act.patch != nil {
+		if err := svc.Patch(ctx, act.id.Key, act.patch, projectOpt); err != nil {
+			return nil, fmt.Errorf("SecurityPolicyUpdateAction Run(%s): Patch: %w", act.id, err)
+		}
+	}
+	if act.labels != nil {
+		if err := svc.SetLabels(ctx, act.id.Key, act.labels, projectOpt); err != nil {
+			return nil, fmt.Errorf("SecurityPolicyUpdateAction Run(%s): SetLabels: %w", act.id, err)
+		}
+	}
+	for _, p := range act.rules.remove {
+		if err := svc.RemoveRule(ctx, act.id.Key, projectOpt, priorityOpt(p)); err != nil {
+			return nil, fmt.Errorf("SecurityPolicyUpdateAction Run(%s): RemoveRule(%v): %w", act.id, meta.SecurityPolicyRuleSubKey(act.id.Key, p), err)
+		}
+	}
+	for _, r := range act.rules.patch {
+		if err := svc.PatchRule(ctx, act.id.Key, r, projectOpt, priorityOpt(r.Priority)); err != nil {
+			return nil, fmt.Errorf("SecurityPolicyUpdateAction Run(%s): PatchRule(%v): %w", act.id, meta.SecurityPolicyRuleSubKey(act.id.Key, r.Priority), err)
+		}
+	}
+	for _, r := range act.rules.add {
+		if err := svc.AddRule(ctx, act.id.Key, r, projectOpt); err != nil {
+			return nil, fmt.Errorf("SecurityPolicyUpdateAction Run(%s): AddRule(%v): %w", act.id, meta.SecurityPolicyRuleSubKey(act.id.Key, r.Priority), err)
+		}
+	}
+
+	return nil, nil
+}
+
+func (act *updateAction) DryRun() exec.EventList { return nil }
+
+func (act *updateAction) String() string {
+	return fmt.Sprintf("SecurityPolicyUpdateAction(%s)", act.id)
+}
+
+func (act *updateAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *updateAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("SecurityPolicyUpdateAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: fmt.Sprintf("Update %s (patch: %t, labels: %t, rules: %s)", act.id, act.patch != nil, act.labels != nil, act.rules),
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestUpdateAction(t *testing.T) {
+	key := meta.GlobalKey("sp")
+	act := &updateAction{
+		id:     ID("proj", key),
+		patch:  &compute.SecurityPolicy{Name: "sp", Description: "new"},
+		labels: &compute.GlobalSetLabelsRequest{Labels: map[string]string{"a": "b"}},
+		rules: &ruleChanges{
+			add:    []*compute.SecurityPolicyRule{makeRule(3000, "allow")},
+			patch:  []*compute.SecurityPolicyRule{makeRule(1000, "deny(404)")},
+			remove: []int64{2000},
+		},
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	// The mock Patch() of the policy fields returns NotFound unless "sp" was
+	// inserted; SetLabels() and the rule methods do not check.
+	if err := mock.SecurityPolicies().Insert(context.Background(), key, &compute.SecurityPolicy{Name: "sp"}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	if _, err := act.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	for _, op := range []string{"Patch", "SetLabels", "RemoveRule", "PatchRule", "AddRule"} {
+		calls := mock.Calls().Matching(cloud.MockCall{Service: "SecurityPolicies", Operation: op, Key: key})
+		if len(calls) != 1 {
+			t.Errorf("calls to SecurityPolicies.%s = %v, want 1", op, calls)
+		}
+	}
+}
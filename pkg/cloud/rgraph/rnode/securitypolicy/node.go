@@ -0,0 +1,266 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// defaultRulePriority is the priority of the default rule that GCE adds to
+// every security policy. The default rule can be changed but not removed.
+const defaultRulePriority = 2147483647
+
+// diffPolicy for SecurityPolicy. The .Type cannot be changed.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldUpdate).
+	Set(api.Path{}.Pointer().Field("Type"), rnode.FieldRecreate)
+
+type securityPolicyNode struct {
+	rnode.NodeBase
+	resource SecurityPolicy
+}
+
+var _ rnode.Node = (*securityPolicyNode)(nil)
+
+func (n *securityPolicyNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *securityPolicyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*securityPolicyNode)
+	if !ok {
+		return nil, fmt.Errorf("SecurityPolicyNode: invalid type to Diff: %T", gotNode)
+	}
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("SecurityPolicyNode: Diff %w", err)
+	}
+
+	r, err := n.EffectiveDiffPolicy(diffPolicy).Apply(diff)
+	if err != nil {
+		return nil, fmt.Errorf("SecurityPolicyNode: %w", err)
+	}
+	if len(r.Recreate) > 0 || !r.Diff.HasDiff() {
+		return r.PlanDetails("SecurityPolicy"), nil
+	}
+
+	// The .Rules are diffed by priority as they are changed individually
+	// with addRule(), patchRule() and removeRule(). The .Labels are changed
+	// with setLabels(). Everything else can be changed with patch().
+	var why []string
+	var rulesChanged bool
+	for _, item := range r.Update {
+		if item.Path.HasPrefix(api.Path{}.Pointer().Field("Rules")) {
+			rulesChanged = true
+			continue
+		}
+		why = append(why, fmt.Sprintf("%s (%v -> %v)", item.Path, item.A, item.B))
+	}
+	if rulesChanged {
+		rc, err := n.ruleChanges(got)
+		if err != nil {
+			return nil, err
+		}
+		if !rc.empty() {
+			why = append(why, fmt.Sprintf("rules (%s)", rc))
+		}
+	}
+	if len(why) == 0 {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "Only the default rule differs",
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpUpdate,
+		Why:       fmt.Sprintf("SecurityPolicy needs to be updated: %s", strings.Join(why, ", ")),
+		Diff:      r.Diff,
+	}, nil
+}
+
+func (n *securityPolicyNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+
+	return nil, fmt.Errorf("SecurityPolicyNode: invalid plan op %s", op)
+}
+
+func (n *securityPolicyNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
+
+func (n *securityPolicyNode) updateActions(gotNode rnode.Node) ([]exec.Action, error) {
+	got, ok := gotNode.(*securityPolicyNode)
+	if !ok {
+		return nil, fmt.Errorf("SecurityPolicyNode: updateActions: invalid type %T", gotNode)
+	}
+	gotGA, err := got.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("SecurityPolicyNode: updateActions: %w", err)
+	}
+	want, err := rnode.WantResource(n, n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("SecurityPolicyNode: updateActions: %w", err)
+	}
+	wantGA, err := want.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("SecurityPolicyNode: updateActions: %w", err)
+	}
+
+	act := &updateAction{
+		id:    n.ID(),
+		rules: diffRules(gotGA.Rules, wantGA.Rules),
+	}
+	for _, item := range n.Plan().Details().Diff.Items {
+		switch {
+		case item.Path.HasPrefix(api.Path{}.Pointer().Field("Rules")):
+		case item.Path.HasPrefix(api.Path{}.Pointer().Field("Labels")):
+			act.labels = &compute.GlobalSetLabelsRequest{
+				Labels:           wantGA.Labels,
+				LabelFingerprint: gotGA.LabelFingerprint,
+			}
+		case act.patch == nil:
+			// The rules are not changed by patch() if they are not sent.
+			patch := *wantGA
+			patch.Rules = nil
+			patch.Labels = nil
+			patch.Fingerprint = gotGA.Fingerprint
+			act.patch = &patch
+		}
+	}
+
+	return []exec.Action{exec.NewExistsAction(n.ID()), act}, nil
+}
+
+func (n *securityPolicyNode) ruleChanges(got *securityPolicyNode) (*ruleChanges, error) {
+	gotGA, err := got.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("SecurityPolicyNode: Diff %w", err)
+	}
+	wantGA, err := n.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("SecurityPolicyNode: Diff %w", err)
+	}
+	return diffRules(gotGA.Rules, wantGA.Rules), nil
+}
+
+// ruleChanges are the rule-level operations needed to go from one set of
+// rules to another.
+type ruleChanges struct {
+	add    []*compute.SecurityPolicyRule
+	patch  []*compute.SecurityPolicyRule
+	remove []int64
+}
+
+func (rc *ruleChanges) empty() bool {
+	return len(rc.add) == 0 && len(rc.patch) == 0 && len(rc.remove) == 0
+}
+
+func (rc *ruleChanges) String() string {
+	priorities := func(rules []*compute.SecurityPolicyRule) []int64 {
+		var ret []int64
+		for _, r := range rules {
+			ret = append(ret, r.Priority)
+		}
+		return ret
+	}
+	return fmt.Sprintf("add %v, patch %v, remove %v", priorities(rc.add), priorities(rc.patch), rc.remove)
+}
+
+// diffRules returns the changes to go from got to want. Rules are identified
+// by their priority. The default rule is patched if it is in want, and never
+// removed.
+func diffRules(got, want []*compute.SecurityPolicyRule) *ruleChanges {
+	byPriority := func(rules []*compute.SecurityPolicyRule) map[int64]*compute.SecurityPolicyRule {
+		ret := map[int64]*compute.SecurityPolicyRule{}
+		for _, r := range rules {
+			ret[r.Priority] = r
+		}
+		return ret
+	}
+	gotRules := byPriority(got)
+	wantRules := byPriority(want)
+
+	ret := &ruleChanges{}
+	for p, w := range wantRules {
+		g, ok := gotRules[p]
+		switch {
+		case !ok && p == defaultRulePriority:
+			// The default rule always exists.
+			ret.patch = append(ret.patch, w)
+		case !ok:
+			ret.add = append(ret.add, w)
+		case !ruleEqual(g, w):
+			ret.patch = append(ret.patch, w)
+		}
+	}
+	for p := range gotRules {
+		if _, ok := wantRules[p]; !ok && p != defaultRulePriority {
+			ret.remove = append(ret.remove, p)
+		}
+	}
+
+	sortRules := func(rules []*compute.SecurityPolicyRule) {
+		sort.Slice(rules, func(i, j int) bool { return rules[i].Priority < rules[j].Priority })
+	}
+	sortRules(ret.add)
+	sortRules(ret.patch)
+	sort.Slice(ret.remove, func(i, j int) bool { return ret.remove[i] < ret.remove[j] })
+
+	return ret
+}
+
+// ruleEqual compares the rules, ignoring the [Output Only] fields.
+func ruleEqual(a, b *compute.SecurityPolicyRule) bool {
+	normalize := func(r *compute.SecurityPolicyRule) string {
+		c := *r
+		c.Kind = ""
+		j, err := json.Marshal(&c)
+		if err != nil {
+			// Marshalling the API types does not fail.
+			return fmt.Sprintf("%+v", c)
+		}
+		return string(j)
+	}
+	return normalize(a) == normalize(b)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func makeRule(priority int64, action string) *compute.SecurityPolicyRule {
+	return &compute.SecurityPolicyRule{
+		Action:   action,
+		Priority: priority,
+		Match: &compute.SecurityPolicyRuleMatcher{
+			VersionedExpr: "SRC_IPS_V1",
+			Config:        &compute.SecurityPolicyRuleMatcherConfig{SrcIpRanges: []string{"*"}},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.GlobalKey("sp"))
+	makeNode := func(desc, typ string, labels map[string]string, rules ...*compute.SecurityPolicyRule) rnode.Node {
+		t.Helper()
+		m := NewMutableSecurityPolicy(id.ProjectID, id.Key)
+		if err := m.Access(func(x *compute.SecurityPolicy) {
+			x.Name = "sp"
+			x.Description = desc
+			x.Type = typ
+			x.Labels = labels
+			x.Rules = rules
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	got := makeNode("", "CLOUD_ARMOR", nil,
+		makeRule(1000, "deny(403)"),
+		makeRule(2000, "allow"),
+		makeRule(defaultRulePriority, "allow"),
+	)
+
+	for _, tc := range []struct {
+		name       string
+		want       rnode.Node
+		wantOp     rnode.Operation
+		wantRules  string
+		wantPatch  bool
+		wantLabels bool
+	}{
+		{
+			name:   "no diff",
+			want:   makeNode("", "CLOUD_ARMOR", nil, makeRule(1000, "deny(403)"), makeRule(2000, "allow"), makeRule(defaultRulePriority, "allow")),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:   "default rule is not removed",
+			want:   makeNode("", "CLOUD_ARMOR", nil, makeRule(1000, "deny(403)"), makeRule(2000, "allow")),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:      "default rule is patched",
+			want:      makeNode("", "CLOUD_ARMOR", nil, makeRule(1000, "deny(403)"), makeRule(2000, "allow"), makeRule(defaultRulePriority, "deny(404)")),
+			wantOp:    rnode.OpUpdate,
+			wantRules: "add [], patch [2147483647], remove []",
+		},
+		{
+			name:      "description",
+			want:      makeNode("new", "CLOUD_ARMOR", nil, makeRule(1000, "deny(403)"), makeRule(2000, "allow")),
+			wantOp:    rnode.OpUpdate,
+			wantRules: "add [], patch [], remove []",
+			wantPatch: true,
+		},
+		{
+			name:       "labels",
+			want:       makeNode("", "CLOUD_ARMOR", map[string]string{"a": "b"}, makeRule(1000, "deny(403)"), makeRule(2000, "allow")),
+			wantOp:     rnode.OpUpdate,
+			wantRules:  "add [], patch [], remove []",
+			wantLabels: true,
+		},
+		{
+			name:      "add, patch and remove rules",
+			want:      makeNode("", "CLOUD_ARMOR", nil, makeRule(1000, "deny(404)"), makeRule(3000, "allow")),
+			wantOp:    rnode.OpUpdate,
+			wantRules: "add [3000], patch [1000], remove [2000]",
+		},
+		{
+			name:   "type",
+			want:   makeNode("", "CLOUD_ARMOR_EDGE", nil, makeRule(1000, "deny(403)"), makeRule(2000, "allow")),
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := tc.want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			tc.want.Plan().Set(rnode.PlanDetails{
+				Operation: p.Operation,
+				Why:       p.Why,
+				Diff:      p.Diff,
+			})
+			actions, err := tc.want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*updateAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *updateAction", actions[1])
+			}
+			if gotRules := act.rules.String(); gotRules != tc.wantRules {
+				t.Errorf("rules = %q, want %q", gotRules, tc.wantRules)
+			}
+			if gotPatch := act.patch != nil; gotPatch != tc.wantPatch {
+				t.Errorf("patch = %t, want %t", gotPatch, tc.wantPatch)
+			}
+			if gotLabels := act.labels != nil; gotLabels != tc.wantLabels {
+				t.Errorf("labels = %t, want %t", gotLabels, tc.wantLabels)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy] {
+	return &rnode.GetFuncs[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy]{
+		GA: rnode.GetFuncsByScope[compute.SecurityPolicy]{
+			Global: gcp.SecurityPolicies().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.SecurityPolicy]{
+			Global: gcp.AlphaSecurityPolicies().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.SecurityPolicy]{
+			Global: gcp.BetaSecurityPolicies().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy] {
+	return &rnode.CreateFuncs[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy]{
+		GA: rnode.CreateFuncsByScope[compute.SecurityPolicy]{
+			Global: gcp.SecurityPolicies().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.SecurityPolicy]{
+			Global: gcp.AlphaSecurityPolicies().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.SecurityPolicy]{
+			Global: gcp.BetaSecurityPolicies().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy] {
+	return &rnode.UpdateFuncs[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy]{
+		GA: rnode.UpdateFuncsByScope[compute.SecurityPolicy]{
+			Global: gcp.SecurityPolicies().Patch,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.SecurityPolicy]{
+			Global: gcp.AlphaSecurityPolicies().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.SecurityPolicy]{
+			Global: gcp.BetaSecurityPolicies().Patch,
+		},
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy] {
+	return &rnode.DeleteFuncs[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy]{
+		GA: rnode.DeleteFuncsByScope[compute.SecurityPolicy]{
+			Global: gcp.SecurityPolicies().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.SecurityPolicy]{
+			Global: gcp.AlphaSecurityPolicies().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.SecurityPolicy]{
+			Global: gcp.BetaSecurityPolicies().Delete,
+		},
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitypolicy
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/securityPolicies
+type typeTrait struct {
+	api.BaseTypeTrait[compute.SecurityPolicy, alpha.SecurityPolicy, beta.SecurityPolicy]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	// Built-ins
+	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("LabelFingerprint"))
+
+	// [Output Only]
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+
+	rule := func() api.Path { return api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer() }
+	dt.OutputOnly(rule().Field("Kind"))
+	// SecurityPolicyRule is also returned by getRule().
+	dt.System(rule().Field("ServerResponse"))
+
+	for _, f := range []string{
+		"AdaptiveProtectionConfig",
+		"AdvancedOptionsConfig",
+		"DdosProtectionConfig",
+		"Description",
+		"Labels",
+		"RecaptchaOptionsConfig",
+		// Type defaults to CLOUD_ARMOR.
+		"Type",
+		"UserDefinedFields",
+	} {
+		dt.AllowZeroValue(api.Path{}.Pointer().Field(f))
+	}
+	for _, f := range []string{
+		"Description",
+		"HeaderAction",
+		"Match",
+		"NetworkMatch",
+		"PreconfiguredWafConfig",
+		"Preview",
+		// Priority 0 is the highest priority.
+		"Priority",
+		"RateLimitOptions",
+		"RedirectOptions",
+	} {
+		dt.AllowZeroValue(rule().Field(f))
+	}
+
+	return dt
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceattachment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+// updateAction patches the ServiceAttachment.
+type updateAction struct {
+	exec.ActionBase
+
+	id    *cloud.ResourceID
+	patch *compute.ServiceAttachment
+	// acceptList are the changes to the consumer accept list, for
+	// information only as patch() replaces the whole list.
+	acceptList *acceptListChanges
+	// dropRefs are emitted for the references removed by the update (e.g.
+	// .NatSubnets).
+	dropRefs exec.EventList
+}
+
+func (act *updateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	if act.id.Key.Type() != meta.Regional {
+		return nil, fmt.Errorf("ServiceAttachmentUpdateAction Run(%s): invalid key type", act.id)
+	}
+	if err := cl.ServiceAttachments().Patch(ctx, act.id.Key, act.patch, cloud.ForceProjectID(act.id.ProjectID)); err != nil {
+		return nil, fmt.Errorf("ServiceAttachmentUpdateAction Run(%s): Patch: %w", act.id, err)
+	}
+	return act.DryRun(), nil
+}
+
+func (act *updateAction) DryRun() exec.EventList { return act.dropRefs }
+
+func (act *updateAction) String() string {
+	return fmt.Sprintf("ServiceAttachmentUpdateAction(%s)", act.id)
+}
+
+func (act *updateAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *updateAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("ServiceAttachmentUpdateAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: fmt.Sprintf("Update %s (consumer accept list: %s)", act.id, act.acceptList),
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceattachment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestUpdateAction(t *testing.T) {
+	key := meta.RegionalKey("sa", "us-central1")
+	act := &updateAction{
+		id:         ID("proj", key),
+		patch:      &compute.ServiceAttachment{ConnectionPreference: "ACCEPT_AUTOMATIC"},
+		acceptList: &acceptListChanges{},
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	// The mock Patch() of .ConnectionPreference returns NotFound unless "sa"
+	// was inserted.
+	if err := mock.ServiceAttachments().Insert(context.Background(), key, &compute.ServiceAttachment{Name: "sa"}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	if _, err := act.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	if calls := mock.Calls().Matching(cloud.MockCall{Service: "ServiceAttachments", Operation: "Patch", Key: key}); len(calls) != 1 {
+		t.Errorf("calls to ServiceAttachments.Patch = %v, want 1", calls)
+	}
+}
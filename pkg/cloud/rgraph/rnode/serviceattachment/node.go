@@ -0,0 +1,297 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceattachment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type serviceAttachmentNode struct {
+	rnode.NodeBase
+	resource ServiceAttachment
+}
+
+var _ rnode.Node = (*serviceAttachmentNode)(nil)
+
+// pscPurpose is the .Purpose of the subnetworks used for .NatSubnets.
+const pscPurpose = "PRIVATE_SERVICE_CONNECT"
+
+func (n *serviceAttachmentNode) Resource() rnode.UntypedResource { return n.resource }
+
+// patchFields can be changed with patch(). All other fields (e.g.
+// .TargetService) require a recreate.
+var patchFields = []string{
+	"ConnectionPreference",
+	"ConsumerAcceptLists",
+	"ConsumerRejectLists",
+	"Description",
+	"EnableProxyProtocol",
+	"NatSubnets",
+	"ReconcileConnections",
+}
+
+// diffPolicy is the default DiffPolicy for ServiceAttachment.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate).
+	SetFields(rnode.FieldUpdate, patchFields...)
+
+func (n *serviceAttachmentNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*serviceAttachmentNode)
+	if !ok {
+		return nil, fmt.Errorf("ServiceAttachmentNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("ServiceAttachmentNode: Diff %w", err)
+	}
+
+	r, err := n.EffectiveDiffPolicy(diffPolicy).Apply(diff)
+	if err != nil {
+		return nil, fmt.Errorf("ServiceAttachmentNode: %w", err)
+	}
+	if len(r.Recreate) > 0 || !r.Diff.HasDiff() {
+		return r.PlanDetails("ServiceAttachment"), nil
+	}
+
+	// The .ConsumerAcceptLists are compared as a set.
+	var update []string
+	var acceptListChanged bool
+	for _, item := range r.Update {
+		if item.Path.HasPrefix(api.Path{}.Pointer().Field("ConsumerAcceptLists")) {
+			acceptListChanged = true
+			continue
+		}
+		update = append(update, fmt.Sprintf("%s (%v -> %v)", item.Path, item.A, item.B))
+	}
+	if acceptListChanged {
+		gotGA, err := got.resource.ToGA()
+		if err != nil {
+			return nil, fmt.Errorf("ServiceAttachmentNode: Diff %w", err)
+		}
+		wantGA, err := n.resource.ToGA()
+		if err != nil {
+			return nil, fmt.Errorf("ServiceAttachmentNode: Diff %w", err)
+		}
+		if c := diffAcceptLists(gotGA.ConsumerAcceptLists, wantGA.ConsumerAcceptLists); !c.empty() {
+			update = append(update, fmt.Sprintf("consumer accept list (%s)", c))
+		}
+	}
+	if len(update) == 0 {
+		return &rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       "Only the order of the consumer accept list differs",
+		}, nil
+	}
+
+	return &rnode.PlanDetails{
+		Operation: rnode.OpUpdate,
+		Why:       fmt.Sprintf("ServiceAttachment needs to be updated: %s", strings.Join(update, ", ")),
+		Diff:      r.Diff,
+	}, nil
+}
+
+func (n *serviceAttachmentNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+
+	return nil, fmt.Errorf("ServiceAttachmentNode: invalid plan op %s", op)
+}
+
+func (n *serviceAttachmentNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
+
+// natSubnetsPrecondition checks that the .NatSubnets have purpose
+// PRIVATE_SERVICE_CONNECT as the error returned by the API is not
+// descriptive.
+var natSubnetsPrecondition = rnode.Precondition{
+	Name:  "NAT subnets have purpose PRIVATE_SERVICE_CONNECT",
+	Ops:   []rnode.Operation{rnode.OpCreate, rnode.OpRecreate, rnode.OpUpdate},
+	Check: checkNatSubnets,
+}
+
+func (n *serviceAttachmentNode) Preconditions() []rnode.Precondition {
+	ret := append([]rnode.Precondition(nil), n.NodeBase.Preconditions()...)
+	return append(ret, natSubnetsPrecondition)
+}
+
+func checkNatSubnets(ctx context.Context, cl cloud.Cloud, n rnode.Node) error {
+	r, ok := n.Resource().(ServiceAttachment)
+	if !ok || r == nil {
+		return fmt.Errorf("invalid resource type %T", n.Resource())
+	}
+	// .NatSubnets is available in all versions.
+	obj, err := r.ToGA()
+	if err != nil {
+		return err
+	}
+	for _, u := range obj.NatSubnets {
+		id, err := cloud.ParseResourceURL(u)
+		if err != nil {
+			return fmt.Errorf("invalid .NatSubnets %q: %w", u, err)
+		}
+		sn, err := cl.Subnetworks().Get(ctx, id.Key, cloud.ForceProjectID(id.ProjectID))
+		if rnode.IsErrorNotFound(err) {
+			// The subnetwork is created by the plan.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("get subnetwork %s: %w", id, err)
+		}
+		if sn.Purpose != pscPurpose {
+			return fmt.Errorf("subnetwork %s has purpose %q; .NatSubnets must have purpose %s", id, sn.Purpose, pscPurpose)
+		}
+	}
+	return nil
+}
+
+func (n *serviceAttachmentNode) updateActions(gotNode rnode.Node) ([]exec.Action, error) {
+	got, ok := gotNode.(*serviceAttachmentNode)
+	if !ok {
+		return nil, fmt.Errorf("ServiceAttachmentNode: updateActions: invalid type %T", gotNode)
+	}
+	gotGA, err := got.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("ServiceAttachmentNode: updateActions: %w", err)
+	}
+	wantGA, err := n.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("ServiceAttachmentNode: updateActions: %w", err)
+	}
+
+	act := &updateAction{
+		id:         n.ID(),
+		patch:      patchObject(wantGA, gotGA.Fingerprint),
+		acceptList: diffAcceptLists(gotGA.ConsumerAcceptLists, wantGA.ConsumerAcceptLists),
+	}
+	// Condition: references (e.g. new .NatSubnets) must exist before the
+	// update.
+	wantRefs := map[string]bool{}
+	for _, ref := range n.OutRefs() {
+		act.Want = append(act.Want, exec.NewExistsEvent(ref.To))
+		wantRefs[ref.To.String()] = true
+	}
+	for _, ref := range got.OutRefs() {
+		if !wantRefs[ref.To.String()] {
+			act.dropRefs = append(act.dropRefs, exec.NewDropRefEvent(ref.From, ref.To))
+		}
+	}
+
+	return []exec.Action{exec.NewExistsAction(n.ID()), act}, nil
+}
+
+// patchObject returns the object to send to patch() with only the fields
+// that can be patched.
+func patchObject(want *compute.ServiceAttachment, fingerprint string) *compute.ServiceAttachment {
+	return &compute.ServiceAttachment{
+		ConnectionPreference: want.ConnectionPreference,
+		ConsumerAcceptLists:  want.ConsumerAcceptLists,
+		ConsumerRejectLists:  want.ConsumerRejectLists,
+		Description:          want.Description,
+		EnableProxyProtocol:  want.EnableProxyProtocol,
+		Fingerprint:          fingerprint,
+		NatSubnets:           want.NatSubnets,
+		ReconcileConnections: want.ReconcileConnections,
+		// Send the zero values so that the fields are cleared.
+		ForceSendFields: []string{"ConsumerAcceptLists", "ConsumerRejectLists", "Description", "EnableProxyProtocol", "ReconcileConnections"},
+	}
+}
+
+// acceptListChanges are the changes to the .ConsumerAcceptLists. Entries are
+// identified by the project or network that they accept.
+type acceptListChanges struct {
+	add    []string
+	update []string
+	remove []string
+}
+
+func (c *acceptListChanges) empty() bool {
+	return len(c.add) == 0 && len(c.update) == 0 && len(c.remove) == 0
+}
+
+func (c *acceptListChanges) String() string {
+	return fmt.Sprintf("add %v, update %v, remove %v", c.add, c.update, c.remove)
+}
+
+// diffAcceptLists returns the changes to go from got to want. The order of
+// the entries is ignored.
+func diffAcceptLists(got, want []*compute.ServiceAttachmentConsumerProjectLimit) *acceptListChanges {
+	key := func(l *compute.ServiceAttachmentConsumerProjectLimit) string {
+		if l.ProjectIdOrNum != "" {
+			return l.ProjectIdOrNum
+		}
+		return l.NetworkUrl
+	}
+	gotLimits := map[string]int64{}
+	for _, l := range got {
+		gotLimits[key(l)] = l.ConnectionLimit
+	}
+	wantKeys := map[string]bool{}
+
+	ret := &acceptListChanges{}
+	for _, l := range want {
+		k := key(l)
+		wantKeys[k] = true
+		limit, ok := gotLimits[k]
+		switch {
+		case !ok:
+			ret.add = append(ret.add, k)
+		case limit != l.ConnectionLimit:
+			ret.update = append(ret.update, k)
+		}
+	}
+	for k := range gotLimits {
+		if !wantKeys[k] {
+			ret.remove = append(ret.remove, k)
+		}
+	}
+
+	sort.Strings(ret.add)
+	sort.Strings(ret.update)
+	sort.Strings(ret.remove)
+
+	return ret
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceattachment
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.RegionalKey("sa", "us-central1"))
+	fr := forwardingrule.ID("proj-1", meta.RegionalKey("fr", "us-central1"))
+	fr2 := forwardingrule.ID("proj-1", meta.RegionalKey("fr-2", "us-central1"))
+	nat1 := subnetwork.ID("proj-1", meta.RegionalKey("nat-1", "us-central1"))
+	nat2 := subnetwork.ID("proj-1", meta.RegionalKey("nat-2", "us-central1"))
+
+	accept := func(project string, limit int64) *compute.ServiceAttachmentConsumerProjectLimit {
+		return &compute.ServiceAttachmentConsumerProjectLimit{ProjectIdOrNum: project, ConnectionLimit: limit}
+	}
+	makeNode := func(f func(*compute.ServiceAttachment)) rnode.Node {
+		t.Helper()
+		m := NewMutableServiceAttachment(id.ProjectID, id.Key)
+		if err := m.Access(func(x *compute.ServiceAttachment) {
+			x.Name = "sa"
+			x.ConnectionPreference = "ACCEPT_MANUAL"
+			x.TargetService = fr.SelfLink(meta.VersionGA)
+			x.NatSubnets = []string{nat1.SelfLink(meta.VersionGA)}
+			x.ConsumerAcceptLists = []*compute.ServiceAttachmentConsumerProjectLimit{accept("p1", 10), accept("p2", 10)}
+			if f != nil {
+				f(x)
+			}
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	got := makeNode(nil)
+
+	for _, tc := range []struct {
+		name           string
+		f              func(*compute.ServiceAttachment)
+		wantOp         rnode.Operation
+		wantAcceptList string
+		wantDropRefs   exec.EventList
+	}{
+		{
+			name:   "no diff",
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "accept list order",
+			f: func(x *compute.ServiceAttachment) {
+				x.ConsumerAcceptLists = []*compute.ServiceAttachmentConsumerProjectLimit{accept("p2", 10), accept("p1", 10)}
+			},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "accept list changes",
+			f: func(x *compute.ServiceAttachment) {
+				x.ConsumerAcceptLists = []*compute.ServiceAttachmentConsumerProjectLimit{accept("p3", 5), accept("p1", 20)}
+			},
+			wantOp:         rnode.OpUpdate,
+			wantAcceptList: "add [p3], update [p1], remove [p2]",
+		},
+		{
+			name: "clear accept list",
+			f: func(x *compute.ServiceAttachment) {
+				x.ConsumerAcceptLists = nil
+			},
+			wantOp:         rnode.OpUpdate,
+			wantAcceptList: "add [], update [], remove [p1 p2]",
+		},
+		{
+			name: "connection preference",
+			f: func(x *compute.ServiceAttachment) {
+				x.ConnectionPreference = "ACCEPT_AUTOMATIC"
+			},
+			wantOp:         rnode.OpUpdate,
+			wantAcceptList: "add [], update [], remove []",
+		},
+		{
+			name: "replace nat subnet",
+			f: func(x *compute.ServiceAttachment) {
+				x.NatSubnets = []string{nat2.SelfLink(meta.VersionGA)}
+			},
+			wantOp:         rnode.OpUpdate,
+			wantAcceptList: "add [], update [], remove []",
+			wantDropRefs:   exec.EventList{exec.NewDropRefEvent(id, nat1)},
+		},
+		{
+			name: "target service",
+			f: func(x *compute.ServiceAttachment) {
+				x.TargetService = fr2.SelfLink(meta.VersionGA)
+			},
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := makeNode(tc.f)
+			p, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*p)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*updateAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *updateAction", actions[1])
+			}
+			if s := act.acceptList.String(); s != tc.wantAcceptList {
+				t.Errorf("acceptList = %q, want %q", s, tc.wantAcceptList)
+			}
+			if events := act.DryRun(); !events.Equal(tc.wantDropRefs) {
+				t.Errorf("DryRun() = %v, want %v", events, tc.wantDropRefs)
+			}
+		})
+	}
+}
+
+func TestPreconditions(t *testing.T) {
+	id := ID("proj-1", meta.RegionalKey("sa", "us-central1"))
+	nat := subnetwork.ID("proj-1", meta.RegionalKey("nat", "us-central1"))
+
+	for _, tc := range []struct {
+		name string
+		// purpose of the subnet, nil if it does not exist.
+		purpose *string
+		op      rnode.Operation
+		// custom is the result of the precondition added to the builder.
+		custom  error
+		wantErr bool
+	}{
+		{name: "PSC subnet", purpose: strPtr(pscPurpose), op: rnode.OpCreate},
+		{name: "subnet does not exist", op: rnode.OpCreate},
+		{name: "wrong purpose", purpose: strPtr("PRIVATE"), op: rnode.OpCreate, wantErr: true},
+		{name: "wrong purpose on delete", purpose: strPtr("PRIVATE"), op: rnode.OpDelete},
+		{name: "custom precondition", purpose: strPtr(pscPurpose), op: rnode.OpUpdate, custom: errors.New("injected"), wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj-1"})
+			if tc.purpose != nil {
+				mock.Subnetworks().Insert(ctx, nat.Key, &compute.Subnetwork{Name: "nat", Purpose: *tc.purpose})
+			}
+
+			m := NewMutableServiceAttachment(id.ProjectID, id.Key)
+			m.Access(func(x *compute.ServiceAttachment) {
+				x.NatSubnets = []string{nat.SelfLink(meta.VersionGA)}
+			})
+			n := nodetest.Build(t, m, NewBuilderWithResource, func(b rnode.Builder) error {
+				b.AddPrecondition(rnode.Precondition{
+					Name:  "custom",
+					Check: func(context.Context, cloud.Cloud, rnode.Node) error { return tc.custom },
+				})
+				return nil
+			})
+			n.Plan().Set(rnode.PlanDetails{Operation: tc.op})
+
+			err := rnode.CheckPreconditions(ctx, mock, n)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("CheckPreconditions() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceattachment
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment] {
+	return &rnode.GetFuncs[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment]{
+		GA: rnode.GetFuncsByScope[compute.ServiceAttachment]{
+			Regional: gcp.ServiceAttachments().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.ServiceAttachment]{
+			Regional: gcp.AlphaServiceAttachments().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.ServiceAttachment]{
+			Regional: gcp.BetaServiceAttachments().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment] {
+	return &rnode.CreateFuncs[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment]{
+		GA: rnode.CreateFuncsByScope[compute.ServiceAttachment]{
+			Regional: gcp.ServiceAttachments().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.ServiceAttachment]{
+			Regional: gcp.AlphaServiceAttachments().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.ServiceAttachment]{
+			Regional: gcp.BetaServiceAttachments().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment] {
+	return &rnode.UpdateFuncs[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment]{
+		GA: rnode.UpdateFuncsByScope[compute.ServiceAttachment]{
+			Regional: gcp.ServiceAttachments().Patch,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.ServiceAttachment]{
+			Regional: gcp.AlphaServiceAttachments().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.ServiceAttachment]{
+			Regional: gcp.BetaServiceAttachments().Patch,
+		},
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment] {
+	return &rnode.DeleteFuncs[compute.ServiceAttachment, alpha.ServiceAttachment, beta.ServiceAttachment]{
+		GA: rnode.DeleteFuncsByScope[compute.ServiceAttachment]{
+			Regional: gcp.ServiceAttachments().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.ServiceAttachment]{
+			Regional: gcp.AlphaServiceAttachments().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.ServiceAttachment]{
+			Regional: gcp.BetaServiceAttachments().Delete,
+		},
+	}
+}
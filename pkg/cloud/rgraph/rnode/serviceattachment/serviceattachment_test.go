@@ -0,0 +1,92 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceattachment
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestServiceAttachmentSchema(t *testing.T) {
+	x := NewMutableServiceAttachment("proj-1", meta.RegionalKey("key-1", "us-central1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestOutRefs(t *testing.T) {
+	id := ID("proj", meta.RegionalKey("sa", "us-central1"))
+	frID := forwardingrule.ID("proj", meta.RegionalKey("fr", "us-central1"))
+	nat1 := subnetwork.ID("proj", meta.RegionalKey("nat-1", "us-central1"))
+	nat2 := subnetwork.ID("proj", meta.RegionalKey("nat-2", "us-central1"))
+
+	for _, tc := range []struct {
+		name string
+		f    func(*compute.ServiceAttachment)
+
+		wantErr bool
+		want    []rnode.ResourceRef
+	}{
+		{
+			name: "no refs",
+			f:    func(x *compute.ServiceAttachment) {},
+		},
+		{
+			name: "target service and nat subnets",
+			f: func(x *compute.ServiceAttachment) {
+				x.TargetService = frID.SelfLink(meta.VersionGA)
+				x.NatSubnets = []string{nat1.SelfLink(meta.VersionGA), nat2.SelfLink(meta.VersionGA)}
+			},
+			want: []rnode.ResourceRef{
+				{From: id, To: frID, Path: api.Path{}.Pointer().Field("TargetService")},
+				{From: id, To: nat1, Path: api.Path{}.Pointer().Field("NatSubnets").Index(0)},
+				{From: id, To: nat2, Path: api.Path{}.Pointer().Field("NatSubnets").Index(1)},
+			},
+		},
+		{
+			name: "garbage nat subnet",
+			f: func(x *compute.ServiceAttachment) {
+				x.NatSubnets = []string{"garbage"}
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := NewMutableServiceAttachment(id.ProjectID, id.Key)
+			mr.Access(tc.f)
+			r, _ := mr.Freeze()
+			b := NewBuilderWithResource(r)
+
+			got, err := b.OutRefs()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("OutRefs() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			} else if gotErr {
+				return
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("OutRefs diff = -got,+want: %s", diff)
+			}
+		})
+	}
+}
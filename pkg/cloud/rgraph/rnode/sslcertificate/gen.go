@@ -0,0 +1,235 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package sslcertificate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID returns the ResourceID of the SslCertificate.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "sslCertificates",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableSslCertificate = api.MutableResource[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate]
+
+func NewMutableSslCertificate(project string, key *meta.Key) MutableSslCertificate {
+	id := ID(project, key)
+	return api.NewResource[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate](id, &typeTrait{})
+}
+
+type SslCertificate = api.Resource[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate]
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/sslCertificates
+type typeTrait struct {
+	api.BaseTypeTrait[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("ExpireTime"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SubjectAlternativeNames"))
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate] {
+	return &rnode.GetFuncs[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate]{
+		GA: rnode.GetFuncsByScope[compute.SslCertificate]{
+			Global:   gcp.SslCertificates().Get,
+			Regional: gcp.RegionSslCertificates().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.SslCertificate]{
+			Global:   gcp.AlphaSslCertificates().Get,
+			Regional: gcp.AlphaRegionSslCertificates().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.SslCertificate]{
+			Global:   gcp.BetaSslCertificates().Get,
+			Regional: gcp.BetaRegionSslCertificates().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate] {
+	return &rnode.CreateFuncs[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate]{
+		GA: rnode.CreateFuncsByScope[compute.SslCertificate]{
+			Global:   gcp.SslCertificates().Insert,
+			Regional: gcp.RegionSslCertificates().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.SslCertificate]{
+			Global:   gcp.AlphaSslCertificates().Insert,
+			Regional: gcp.AlphaRegionSslCertificates().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.SslCertificate]{
+			Global:   gcp.BetaSslCertificates().Insert,
+			Regional: gcp.BetaRegionSslCertificates().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate] {
+	return nil // Does not support generic Update.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate] {
+	return &rnode.DeleteFuncs[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate]{
+		GA: rnode.DeleteFuncsByScope[compute.SslCertificate]{
+			Global:   gcp.SslCertificates().Delete,
+			Regional: gcp.RegionSslCertificates().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.SslCertificate]{
+			Global:   gcp.AlphaSslCertificates().Delete,
+			Regional: gcp.AlphaRegionSslCertificates().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.SslCertificate]{
+			Global:   gcp.BetaSslCertificates().Delete,
+			Regional: gcp.BetaRegionSslCertificates().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r SslCertificate) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource SslCertificate
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(SslCertificate)
+	if !ok {
+		return fmt.Errorf("cannot set SslCertificate from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate](ctx, gcp, "SslCertificate", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	// SslCertificate does not have any outgoing resource references.
+	return nil, nil
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("SslCertificate %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &sslCertificateNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for SslCertificate.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate)
+
+type sslCertificateNode struct {
+	rnode.NodeBase
+	resource SslCertificate
+}
+
+var _ rnode.Node = (*sslCertificateNode)(nil)
+
+func (n *sslCertificateNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *sslCertificateNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*sslCertificateNode)
+	if !ok {
+		return nil, fmt.Errorf("SslCertificateNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("SslCertificateNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("SslCertificate", diff)
+}
+
+func (n *sslCertificateNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.SslCertificate, alpha.SslCertificate, beta.SslCertificate](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("SslCertificateNode: invalid plan op %s", op)
+}
+
+func (n *sslCertificateNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
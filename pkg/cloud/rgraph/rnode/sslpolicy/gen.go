@@ -0,0 +1,210 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package sslpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID returns the ResourceID of the SslPolicy.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "sslPolicies",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableSslPolicy = api.MutableResource[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]
+
+func NewMutableSslPolicy(project string, key *meta.Key) MutableSslPolicy {
+	id := ID(project, key)
+	return api.NewResource[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](id, &typeTrait{})
+}
+
+type SslPolicy = api.Resource[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/sslPolicies
+type typeTrait struct {
+	api.BaseTypeTrait[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("EnabledFeatures"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Warnings"))
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.GetFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.GetFuncsByScope[compute.SslPolicy]{
+			Global:   gcp.SslPolicies().Get,
+			Regional: gcp.RegionSslPolicies().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.CreateFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.CreateFuncsByScope[compute.SslPolicy]{
+			Global:   gcp.SslPolicies().Insert,
+			Regional: gcp.RegionSslPolicies().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return nil // Does not support generic Update.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType] {
+	return &rnode.DeleteFuncs[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType]{
+		GA: rnode.DeleteFuncsByScope[compute.SslPolicy]{
+			Global:   gcp.SslPolicies().Delete,
+			Regional: gcp.RegionSslPolicies().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r SslPolicy) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource SslPolicy
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(SslPolicy)
+	if !ok {
+		return fmt.Errorf("cannot set SslPolicy from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](ctx, gcp, "SslPolicy", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	// SslPolicy does not have any outgoing resource references.
+	return nil, nil
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("SslPolicy %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &sslPolicyNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for SslPolicy.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate)
+
+type sslPolicyNode struct {
+	rnode.NodeBase
+	resource SslPolicy
+}
+
+var _ rnode.Node = (*sslPolicyNode)(nil)
+
+func (n *sslPolicyNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *sslPolicyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*sslPolicyNode)
+	if !ok {
+		return nil, fmt.Errorf("SslPolicyNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("SslPolicyNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("SslPolicy", diff)
+}
+
+func (n *sslPolicyNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.SslPolicy, api.PlaceholderType, api.PlaceholderType](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("SslPolicyNode: invalid plan op %s", op)
+}
+
+func (n *sslPolicyNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
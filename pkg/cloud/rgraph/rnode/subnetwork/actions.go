@@ -0,0 +1,114 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnetwork
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/klog/v2"
+)
+
+// updateAction expands the .IpCidrRange of the Subnetwork and then applies
+// the patches in order. The first call uses the fingerprint of the resource
+// at plan time; each call changes the fingerprint so the current one is read
+// before the next patch.
+type updateAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// fingerprint of the Subnetwork when the action was planned.
+	fingerprint string
+	// expandTo is the new .IpCidrRange. Empty if the range is unchanged.
+	expandTo string
+	patches  []*compute.Subnetwork
+}
+
+func (act *updateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	if act.id.Key.Type() != meta.Regional {
+		return nil, fmt.Errorf("SubnetworkUpdateAction Run(%s): invalid key type", act.id)
+	}
+	opt := cloud.ForceProjectID(act.id.ProjectID)
+
+	fingerprint := act.fingerprint
+	if act.expandTo != "" {
+		req := &compute.SubnetworksExpandIpCidrRangeRequest{IpCidrRange: act.expandTo}
+		if err := cl.Subnetworks().ExpandIpCidrRange(ctx, act.id.Key, req, opt); err != nil {
+			return nil, fmt.Errorf("SubnetworkUpdateAction Run(%s): ExpandIpCidrRange: %w", act.id, err)
+		}
+		fingerprint = ""
+	}
+	for i, p := range act.patches {
+		if err := act.patch(ctx, cl, fingerprint, p); err != nil {
+			return nil, fmt.Errorf("SubnetworkUpdateAction Run(%s): Patch %d/%d: %w", act.id, i+1, len(act.patches), err)
+		}
+		fingerprint = ""
+	}
+	return nil, nil
+}
+
+// patch the Subnetwork with fingerprint. The current fingerprint is read if
+// fingerprint is empty. If the fingerprint is stale (e.g. the Subnetwork was
+// changed concurrently), the patch is retried once with the current one.
+func (act *updateAction) patch(ctx context.Context, cl cloud.Cloud, fingerprint string, p *compute.Subnetwork) error {
+	opt := cloud.ForceProjectID(act.id.ProjectID)
+	current := func() (string, error) {
+		cur, err := cl.Subnetworks().Get(ctx, act.id.Key, opt)
+		if err != nil {
+			return "", fmt.Errorf("Get: %w", err)
+		}
+		return cur.Fingerprint, nil
+	}
+	if fingerprint == "" {
+		var err error
+		if fingerprint, err = current(); err != nil {
+			return err
+		}
+	}
+	patch := *p
+	patch.Fingerprint = fingerprint
+	err := cl.Subnetworks().Patch(ctx, act.id.Key, &patch, opt)
+	if !rnode.IsErrorFingerprintMismatch(err) {
+		return err
+	}
+	klog.V(2).Infof("patch %s: fingerprint mismatch, retrying with the current fingerprint: %v", act.id, err)
+	if patch.Fingerprint, err = current(); err != nil {
+		return err
+	}
+	return cl.Subnetworks().Patch(ctx, act.id.Key, &patch, opt)
+}
+
+func (act *updateAction) DryRun() exec.EventList { return nil }
+
+func (act *updateAction) String() string {
+	return fmt.Sprintf("SubnetworkUpdateAction(%s)", act.id)
+}
+
+func (act *updateAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *updateAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("SubnetworkUpdateAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: fmt.Sprintf("Update %s (expand to: %q, patches: %d)", act.id, act.expandTo, len(act.patches)),
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnetwork
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	mockcloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestUpdateAction(t *testing.T) {
+	key := meta.RegionalKey("sub", "us-central1")
+	act := &updateAction{
+		id:       ID("proj", key),
+		expandTo: "10.0.0.0/20",
+		patches:  []*compute.Subnetwork{{}, {Description: "x"}},
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	// The action reads .Fingerprint with Get() before it patches
+	// .Description, so "sub" must be in the mock.
+	if err := mock.Subnetworks().Insert(context.Background(), key, &compute.Subnetwork{Name: "sub"}); err != nil {
+		t.Fatalf("Insert() = %v, want nil", err)
+	}
+	if _, err := act.Run(context.Background(), mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	for _, tc := range []struct {
+		op   string
+		want int
+	}{
+		{"ExpandIpCidrRange", 1},
+		{"Get", 2},
+		{"Patch", 2},
+	} {
+		calls := mock.Calls().Matching(cloud.MockCall{Service: "Subnetworks", Operation: tc.op, Key: key})
+		if len(calls) != tc.want {
+			t.Errorf("calls to Subnetworks.%s = %v, want %d", tc.op, calls, tc.want)
+		}
+	}
+}
+
+func TestUpdateActionFingerprint(t *testing.T) {
+	ctx := context.Background()
+	key := meta.RegionalKey("sub", "us-central1")
+
+	for _, tc := range []struct {
+		name string
+		// stale uses a fingerprint that is not the current one.
+		stale     bool
+		wantGet   int
+		wantPatch int
+	}{
+		{name: "fingerprint from plan", wantGet: 0, wantPatch: 1},
+		{name: "stale fingerprint is retried", stale: true, wantGet: 1, wantPatch: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+			mockcloud.EnableFingerprints(mock)
+			if err := mock.Subnetworks().Insert(ctx, key, &compute.Subnetwork{Name: "sub"}); err != nil {
+				t.Fatalf("Insert() = %v, want nil", err)
+			}
+			sub, err := mock.Subnetworks().Get(ctx, key)
+			if err != nil {
+				t.Fatalf("Get() = %v, want nil", err)
+			}
+			act := &updateAction{
+				id:          ID("proj", key),
+				fingerprint: sub.Fingerprint,
+				patches:     []*compute.Subnetwork{{Description: "x"}},
+			}
+			if tc.stale {
+				act.fingerprint = "stale"
+			}
+			mock.ResetCalls()
+
+			if _, err := act.Run(ctx, mock); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			for op, want := range map[string]int{"Get": tc.wantGet, "Patch": tc.wantPatch} {
+				calls := mock.Calls().Matching(cloud.MockCall{Service: "Subnetworks", Operation: op, Key: key})
+				if len(calls) != want {
+					t.Errorf("calls to Subnetworks.%s = %v, want %d", op, calls, want)
+				}
+			}
+			if sub, _ := mock.Subnetworks().Get(ctx, key); sub.Description != "x" {
+				t.Errorf("Description = %q, want %q", sub.Description, "x")
+			}
+		})
+	}
+}
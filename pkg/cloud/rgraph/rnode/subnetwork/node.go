@@ -0,0 +1,229 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnetwork
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type subnetworkNode struct {
+	rnode.NodeBase
+	resource Subnetwork
+}
+
+var _ rnode.Node = (*subnetworkNode)(nil)
+
+func (n *subnetworkNode) Resource() rnode.UntypedResource { return n.resource }
+
+// patchFields can be changed with patch(). .IpCidrRange can only be expanded
+// with expandIpCidrRange(). All other fields require a recreate.
+var patchFields = []string{
+	"Description",
+	"EnableFlowLogs",
+	"Ipv6AccessType",
+	"LogConfig",
+	"PrivateIpGoogleAccess",
+	"PrivateIpv6GoogleAccess",
+	"Role",
+	"SecondaryIpRanges",
+	"StackType",
+}
+
+// ipCidrRangePath is changed with expandIpCidrRange().
+var ipCidrRangePath = api.Path{}.Pointer().Field("IpCidrRange")
+
+// diffPolicy is the default DiffPolicy for Subnetwork.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate).
+	Set(ipCidrRangePath, rnode.FieldUpdate).
+	SetFields(rnode.FieldUpdate, patchFields...)
+
+func (n *subnetworkNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*subnetworkNode)
+	if !ok {
+		return nil, fmt.Errorf("SubnetworkNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("SubnetworkNode: Diff %w", err)
+	}
+
+	r, err := n.EffectiveDiffPolicy(diffPolicy).Apply(diff)
+	if err != nil {
+		return nil, fmt.Errorf("SubnetworkNode: %w", err)
+	}
+
+	// .IpCidrRange can only be changed in place if the range is expanded.
+	gotGA, err := got.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("SubnetworkNode: Diff %w", err)
+	}
+	wantGA, err := n.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("SubnetworkNode: Diff %w", err)
+	}
+	if !isExpansion(gotGA.IpCidrRange, wantGA.IpCidrRange) {
+		var update []api.DiffItem
+		for _, item := range r.Update {
+			if item.Path.HasPrefix(ipCidrRangePath) {
+				r.Recreate = append(r.Recreate, item)
+			} else {
+				update = append(update, item)
+			}
+		}
+		r.Update = update
+	}
+
+	return r.PlanDetails("Subnetwork"), nil
+}
+
+func (n *subnetworkNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+
+	return nil, fmt.Errorf("SubnetworkNode: invalid plan op %s", op)
+}
+
+func (n *subnetworkNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
+
+func (n *subnetworkNode) updateActions(gotNode rnode.Node) ([]exec.Action, error) {
+	got, ok := gotNode.(*subnetworkNode)
+	if !ok {
+		return nil, fmt.Errorf("SubnetworkNode: updateActions: invalid type %T", gotNode)
+	}
+	gotGA, err := got.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("SubnetworkNode: updateActions: %w", err)
+	}
+	wantGA, err := n.resource.ToGA()
+	if err != nil {
+		return nil, fmt.Errorf("SubnetworkNode: updateActions: %w", err)
+	}
+
+	act := &updateAction{id: n.ID(), fingerprint: gotGA.Fingerprint}
+	if gotGA.IpCidrRange != wantGA.IpCidrRange {
+		act.expandTo = wantGA.IpCidrRange
+	}
+	var needsPatch bool
+	for _, item := range n.Plan().Details().Diff.Items {
+		if isPatchField(item.Path) {
+			needsPatch = true
+			break
+		}
+	}
+	if needsPatch {
+		act.patches = patches(gotGA.SecondaryIpRanges, wantGA)
+	}
+
+	return []exec.Action{exec.NewExistsAction(n.ID()), act}, nil
+}
+
+func isPatchField(p api.Path) bool {
+	for _, f := range patchFields {
+		if p.HasPrefix(api.Path{}.Pointer().Field(f)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpansion returns true if the CIDR range want contains got and is larger.
+func isExpansion(got, want string) bool {
+	_, gotNet, err := net.ParseCIDR(got)
+	if err != nil {
+		return false
+	}
+	_, wantNet, err := net.ParseCIDR(want)
+	if err != nil {
+		return false
+	}
+	gotOnes, gotBits := gotNet.Mask.Size()
+	wantOnes, wantBits := wantNet.Mask.Size()
+	return gotBits == wantBits && wantOnes < gotOnes && wantNet.Contains(gotNet.IP)
+}
+
+// patches returns the patch() calls to go from the got secondary ranges to
+// want. A secondary range cannot be changed in place, so ranges whose
+// .IpCidrRange changed are removed in a first patch and added back with the
+// new value in a second one.
+func patches(gotRanges []*compute.SubnetworkSecondaryRange, want *compute.Subnetwork) []*compute.Subnetwork {
+	gotCIDR := map[string]string{}
+	for _, r := range gotRanges {
+		gotCIDR[r.RangeName] = r.IpCidrRange
+	}
+	var changed bool
+	var kept []*compute.SubnetworkSecondaryRange
+	for _, r := range want.SecondaryIpRanges {
+		if cidr, ok := gotCIDR[r.RangeName]; ok && cidr != r.IpCidrRange {
+			changed = true
+			continue
+		}
+		kept = append(kept, r)
+	}
+
+	final := patchObject(want, want.SecondaryIpRanges)
+	if !changed {
+		return []*compute.Subnetwork{final}
+	}
+	return []*compute.Subnetwork{patchObject(want, kept), final}
+}
+
+// patchObject returns the object to send to patch() with only the fields
+// that can be patched.
+func patchObject(want *compute.Subnetwork, ranges []*compute.SubnetworkSecondaryRange) *compute.Subnetwork {
+	return &compute.Subnetwork{
+		Description:             want.Description,
+		EnableFlowLogs:          want.EnableFlowLogs,
+		Ipv6AccessType:          want.Ipv6AccessType,
+		LogConfig:               want.LogConfig,
+		PrivateIpGoogleAccess:   want.PrivateIpGoogleAccess,
+		PrivateIpv6GoogleAccess: want.PrivateIpv6GoogleAccess,
+		Role:                    want.Role,
+		SecondaryIpRanges:       ranges,
+		StackType:               want.StackType,
+		// Send the zero values so that the fields are cleared.
+		ForceSendFields: []string{"Description", "EnableFlowLogs", "PrivateIpGoogleAccess", "SecondaryIpRanges"},
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnetwork
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.RegionalKey("sub", "us-central1"))
+	base := func(x *compute.Subnetwork) {
+		x.Name = "sub"
+		x.Network = "projects/proj-1/global/networks/default"
+		x.IpCidrRange = "10.0.0.0/24"
+		x.SecondaryIpRanges = []*compute.SubnetworkSecondaryRange{
+			{RangeName: "pods", IpCidrRange: "10.4.0.0/16"},
+		}
+	}
+	makeNode := func(f func(*compute.Subnetwork)) rnode.Node {
+		t.Helper()
+		m := NewMutableSubnetwork(id.ProjectID, id.Key)
+		if err := m.Access(func(x *compute.Subnetwork) {
+			base(x)
+			f(x)
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	for _, tc := range []struct {
+		name        string
+		f           func(*compute.Subnetwork)
+		wantOp      rnode.Operation
+		wantExpand  string
+		wantPatches int
+	}{
+		{
+			name:   "no diff",
+			f:      func(*compute.Subnetwork) {},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:        "patch",
+			f:           func(x *compute.Subnetwork) { x.PrivateIpGoogleAccess = true },
+			wantOp:      rnode.OpUpdate,
+			wantPatches: 1,
+		},
+		{
+			name:       "expand",
+			f:          func(x *compute.Subnetwork) { x.IpCidrRange = "10.0.0.0/20" },
+			wantOp:     rnode.OpUpdate,
+			wantExpand: "10.0.0.0/20",
+		},
+		{
+			name:   "shrink",
+			f:      func(x *compute.Subnetwork) { x.IpCidrRange = "10.0.0.0/25" },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "move",
+			f:      func(x *compute.Subnetwork) { x.IpCidrRange = "10.1.0.0/20" },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "network",
+			f:      func(x *compute.Subnetwork) { x.Network = "projects/proj-1/global/networks/other" },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name: "add secondary range",
+			f: func(x *compute.Subnetwork) {
+				x.SecondaryIpRanges = append(x.SecondaryIpRanges, &compute.SubnetworkSecondaryRange{RangeName: "svc", IpCidrRange: "10.8.0.0/20"})
+			},
+			wantOp:      rnode.OpUpdate,
+			wantPatches: 1,
+		},
+		{
+			name:        "remove secondary range",
+			f:           func(x *compute.Subnetwork) { x.SecondaryIpRanges = nil; x.NullFields = []string{"SecondaryIpRanges"} },
+			wantOp:      rnode.OpUpdate,
+			wantPatches: 1,
+		},
+		{
+			name:        "change secondary range",
+			f:           func(x *compute.Subnetwork) { x.SecondaryIpRanges[0].IpCidrRange = "10.5.0.0/16" },
+			wantOp:      rnode.OpUpdate,
+			wantPatches: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := makeNode(tc.f)
+			got := makeNode(func(*compute.Subnetwork) {})
+			p, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*p)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*updateAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *updateAction", actions[1])
+			}
+			if act.expandTo != tc.wantExpand {
+				t.Errorf("expandTo = %q, want %q", act.expandTo, tc.wantExpand)
+			}
+			if len(act.patches) != tc.wantPatches {
+				t.Errorf("len(patches) = %d, want %d", len(act.patches), tc.wantPatches)
+			}
+		})
+	}
+}
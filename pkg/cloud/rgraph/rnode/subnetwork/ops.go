@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnetwork
+
+import (
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type ops struct{}
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork] {
+	return &rnode.GetFuncs[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork]{
+		GA: rnode.GetFuncsByScope[compute.Subnetwork]{
+			Regional: gcp.Subnetworks().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.Subnetwork]{
+			Regional: gcp.AlphaSubnetworks().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.Subnetwork]{
+			Regional: gcp.BetaSubnetworks().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork] {
+	return &rnode.CreateFuncs[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork]{
+		GA: rnode.CreateFuncsByScope[compute.Subnetwork]{
+			Regional: gcp.Subnetworks().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.Subnetwork]{
+			Regional: gcp.AlphaSubnetworks().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.Subnetwork]{
+			Regional: gcp.BetaSubnetworks().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork] {
+	return &rnode.UpdateFuncs[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork]{
+		GA: rnode.UpdateFuncsByScope[compute.Subnetwork]{
+			Regional: gcp.Subnetworks().Patch,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.Subnetwork]{
+			Regional: gcp.AlphaSubnetworks().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.Subnetwork]{
+			Regional: gcp.BetaSubnetworks().Patch,
+		},
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork] {
+	return &rnode.DeleteFuncs[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork]{
+		GA: rnode.DeleteFuncsByScope[compute.Subnetwork]{
+			Regional: gcp.Subnetworks().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.Subnetwork]{
+			Regional: gcp.AlphaSubnetworks().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.Subnetwork]{
+			Regional: gcp.BetaSubnetworks().Delete,
+		},
+	}
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2023 Google LLC
+Copyright 2024 Google LLC
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,35 +14,35 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package tcproute
+package subnetwork
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
-
-	"google.golang.org/api/networkservices/v1"
-	beta "google.golang.org/api/networkservices/v1beta1"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
 )
 
 func ID(project string, key *meta.Key) *cloud.ResourceID {
 	return &cloud.ResourceID{
-		Resource:  "tcpRoutes",
-		APIGroup:  meta.APIGroupNetworkServices,
+		Resource:  "subnetworks",
+		APIGroup:  meta.APIGroupCompute,
 		ProjectID: project,
 		Key:       key,
 	}
 }
 
-type MutableTcpRoute = api.MutableResource[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]
+type MutableSubnetwork = api.MutableResource[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork]
 
-func NewMutableTcpRoute(project string, key *meta.Key) MutableTcpRoute {
+func NewMutableSubnetwork(project string, key *meta.Key) MutableSubnetwork {
 	id := ID(project, key)
 	return api.NewResource[
-		networkservices.TcpRoute,
-		api.PlaceholderType,
-		beta.TcpRoute,
-	](id, &tcpRouteTypeTrait{})
+		compute.Subnetwork,
+		alpha.Subnetwork,
+		beta.Subnetwork,
+	](id, &typeTrait{})
 }
 
-type TcpRoute = api.Resource[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]
+type Subnetwork = api.Resource[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork]
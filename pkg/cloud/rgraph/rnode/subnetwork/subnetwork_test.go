@@ -0,0 +1,56 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnetwork
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestSubnetworkSchema(t *testing.T) {
+	x := NewMutableSubnetwork("proj-1", meta.RegionalKey("key-1", "us-central1"))
+	if err := x.CheckSchema(); err != nil {
+		t.Fatalf("CheckSchema() = %v, want nil", err)
+	}
+}
+
+func TestOutRefs(t *testing.T) {
+	id := ID("proj", meta.RegionalKey("sub", "us-central1"))
+	netID := network.ID("proj", meta.GlobalKey("vpc"))
+
+	mr := NewMutableSubnetwork(id.ProjectID, id.Key)
+	mr.Access(func(x *compute.Subnetwork) {
+		x.Network = netID.SelfLink(meta.VersionGA)
+	})
+	r, _ := mr.Freeze()
+	got, err := NewBuilderWithResource(r).OutRefs()
+	if err != nil {
+		t.Fatalf("OutRefs() = %v, want nil", err)
+	}
+	want := []rnode.ResourceRef{
+		{From: id, To: netID, Path: api.Path{}.Pointer().Field("Network")},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("OutRefs diff = -got,+want: %s", diff)
+	}
+}
@@ -1,5 +1,5 @@
 /*
-Copyright 2023 Google LLC
+Copyright 2024 Google LLC
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package forwardingrule
+package subnetwork
 
 import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
@@ -24,27 +24,46 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
-// https://cloud.google.com/compute/docs/reference/rest/beta/forwardingRules
+// https://cloud.google.com/compute/docs/reference/rest/v1/subnetworks
 type typeTrait struct {
-	api.BaseTypeTrait[compute.ForwardingRule, alpha.ForwardingRule, beta.ForwardingRule]
+	api.BaseTypeTrait[compute.Subnetwork, alpha.Subnetwork, beta.Subnetwork]
 }
 
 func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 	dt := api.NewFieldTraits()
+	// Built-ins
+	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
 
-	dt.OutputOnly(api.Path{}.Pointer().Field("BaseForwardingRule"))
+	// [Output Only]
 	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("GatewayAddress"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("InternalIpv6Prefix"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Ipv6CidrRange"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("LabelFingerprint"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("PscConnectionId"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("PscConnectionStatus")) // Not documented
 	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
 	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
-	dt.OutputOnly(api.Path{}.Pointer().Field("ServiceName"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("State"))
+
+	for _, f := range []string{
+		"Description",
+		"EnableFlowLogs",
+		"ExternalIpv6Prefix",
+		"Ipv6AccessType",
+		"LogConfig",
+		"PrivateIpGoogleAccess",
+		"PrivateIpv6GoogleAccess",
+		// Purpose and Role default to a regular subnetwork.
+		"Purpose",
+		"Role",
+		"SecondaryIpRanges",
+		"StackType",
+	} {
+		dt.AllowZeroValue(api.Path{}.Pointer().Field(f))
+	}
 
-	// TODO: handle alpha/beta
+	// References to other resources.
+	dt.Reference(api.Path{}.Pointer().Field("Network"), "networks")
 
 	return dt
 }
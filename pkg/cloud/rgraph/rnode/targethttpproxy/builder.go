@@ -21,7 +21,6 @@ import (
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
-	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
@@ -65,26 +64,7 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 }
 
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
-	if b.resource == nil {
-		return nil, nil
-	}
-
-	var ret []rnode.ResourceRef
-	obj, _ := b.resource.ToGA()
-
-	if obj.UrlMap != "" {
-		id, err := cloud.ParseResourceURL(obj.UrlMap)
-		if err != nil {
-			return nil, fmt.Errorf("targetHttpProxyNode: %w", err)
-		}
-		ret = append(ret, rnode.ResourceRef{
-			From: b.resource.ResourceID(),
-			Path: api.Path{}.Field("UrlMap"),
-			To:   id,
-		})
-	}
-
-	return ret, nil
+	return rnode.OutRefsFromTraits[compute.TargetHttpProxy, alpha.TargetHttpProxy, beta.TargetHttpProxy](b.resource, &targetHttpProxyTypeTrait{})
 }
 
 func (b *builder) Build() (rnode.Node, error) {
@@ -26,6 +26,11 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+// diffPolicy for TargetHttpProxy. There is no update method implemented.
+//
+// TODO: handle set labels with an update operation.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate)
+
 type targetHttpProxyNode struct {
 	rnode.NodeBase
 	resource TargetHttpProxy
@@ -46,19 +51,7 @@ func (n *targetHttpProxyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, erro
 		return nil, fmt.Errorf("TargetHttpProxyNode: Diff %w", err)
 	}
 
-	if diff.HasDiff() {
-		// TODO: handle set labels with an update operation.
-		return &rnode.PlanDetails{
-			Operation: rnode.OpRecreate,
-			Why:       "TargetHttpProxy needs to be recreated (no update method exists)",
-			Diff:      diff,
-		}, nil
-	}
-
-	return &rnode.PlanDetails{
-		Operation: rnode.OpNothing,
-		Why:       "No diff between got and want",
-	}, nil
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("TargetHttpProxy", diff)
 }
 
 func (n *targetHttpProxyNode) Actions(got rnode.Node) ([]exec.Action, error) {
@@ -40,5 +40,8 @@ func (*targetHttpProxyTypeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
 	// TODO: finish me
 	// TODO: handle alpha/beta
+	// References to other resources.
+	dt.Reference(api.Path{}.Pointer().Field("UrlMap"), "urlMaps")
+
 	return dt
 }
@@ -0,0 +1,218 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type targetHttpsProxyUpdateAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// version of the API used for the calls.
+	version meta.Version
+
+	// urlMap if non-nil will call setUrlMap().
+	urlMap *cloud.ResourceID
+	// oldUrlMap is the UrlMap before the update.
+	oldUrlMap *cloud.ResourceID
+
+	// sslCertificates if non-nil will call setSslCertificates().
+	sslCertificates []string
+	// certificateMap if non-nil will call setCertificateMap().
+	certificateMap *string
+	// sslPolicy if non-nil will call setSslPolicy() (global) or patch()
+	// (regional).
+	sslPolicy *string
+	// fingerprint for the patch() operation.
+	fingerprint string
+
+	// dropRefs are the events for the SslCertificates and SslPolicy that are
+	// no longer referenced after the update.
+	dropRefs exec.EventList
+
+	// changes are human-readable descriptions of the changed fields.
+	changes []string
+}
+
+func (act *targetHttpsProxyUpdateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	key := act.id.Key
+	switch key.Type() {
+	case meta.Global, meta.Regional:
+	default:
+		return nil, fmt.Errorf("targetHttpsProxyUpdateAction Run(%s): invalid key type", act.id)
+	}
+	errf := func(verb string, err error) error {
+		return fmt.Errorf("targetHttpsProxyUpdateAction Run(%s): %s: %w", act.id, verb, err)
+	}
+
+	// The SslPolicy is first as patch() carries the fingerprint from the plan,
+	// which is changed by the other calls.
+	if act.sslPolicy != nil {
+		var err error
+		switch key.Type() {
+		case meta.Global:
+			err = setSslPolicyFuncs(cl).Do(ctx, act.version, act.id, &compute.SslPolicyReference{
+				SslPolicy:       *act.sslPolicy,
+				ForceSendFields: []string{"SslPolicy"},
+			})
+		case meta.Regional:
+			err = patchFuncs(cl).Do(ctx, act.version, act.id, &compute.TargetHttpsProxy{
+				SslPolicy:       *act.sslPolicy,
+				Fingerprint:     act.fingerprint,
+				ForceSendFields: []string{"SslPolicy"},
+			})
+		}
+		if err != nil {
+			return nil, errf("SetSslPolicy", err)
+		}
+	}
+
+	if act.sslCertificates != nil {
+		var err error
+		switch key.Type() {
+		case meta.Global:
+			err = setSslCertificatesFuncs(cl).Do(ctx, act.version, act.id, &compute.TargetHttpsProxiesSetSslCertificatesRequest{
+				SslCertificates: act.sslCertificates,
+			})
+		case meta.Regional:
+			err = regionSetSslCertificatesFuncs(cl).Do(ctx, act.version, act.id, &compute.RegionTargetHttpsProxiesSetSslCertificatesRequest{
+				SslCertificates: act.sslCertificates,
+			})
+		}
+		if err != nil {
+			return nil, errf("SetSslCertificates", err)
+		}
+	}
+
+	if act.certificateMap != nil {
+		if key.Type() != meta.Global {
+			return nil, fmt.Errorf("targetHttpsProxyUpdateAction Run(%s): CertificateMap is only supported for global proxies", act.id)
+		}
+		err := setCertificateMapFuncs(cl).Do(ctx, act.version, act.id, &compute.TargetHttpsProxiesSetCertificateMapRequest{
+			CertificateMap:  *act.certificateMap,
+			ForceSendFields: []string{"CertificateMap"},
+		})
+		if err != nil {
+			return nil, errf("SetCertificateMap", err)
+		}
+	}
+
+	if act.urlMap != nil {
+		ref := &compute.UrlMapReference{UrlMap: act.urlMap.SelfLink(act.version)}
+		if err := setUrlMapFuncs(cl).Do(ctx, act.version, act.id, ref); err != nil {
+			return nil, errf("SetUrlMap", err)
+		}
+	}
+
+	return act.DryRun(), nil
+}
+
+func (act *targetHttpsProxyUpdateAction) DryRun() exec.EventList {
+	var events exec.EventList
+	if act.oldUrlMap != nil && !act.urlMap.Equal(act.oldUrlMap) {
+		events = append(events, exec.NewDropRefEvent(act.id, act.oldUrlMap))
+	}
+	events = append(events, act.dropRefs...)
+	return events
+}
+
+func (act *targetHttpsProxyUpdateAction) String() string {
+	return fmt.Sprintf("TargetHttpsProxyUpdateAction(%s)", act.id)
+}
+
+func (act *targetHttpsProxyUpdateAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *targetHttpsProxyUpdateAction) Metadata() *exec.ActionMetadata {
+	summary := fmt.Sprintf("Update %s", act.id)
+	if len(act.changes) > 0 {
+		summary += ": " + strings.Join(act.changes, ", ")
+	}
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("TargetHttpsProxyUpdateAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: summary,
+	}
+}
+
+func setSslPolicyFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.SslPolicyReference, alpha.SslPolicyReference, beta.SslPolicyReference] {
+	return &rnode.MethodFuncs[compute.SslPolicyReference, alpha.SslPolicyReference, beta.SslPolicyReference]{
+		GA:    rnode.UpdateFuncsByScope[compute.SslPolicyReference]{Global: gcp.TargetHttpsProxies().SetSslPolicy},
+		Alpha: rnode.UpdateFuncsByScope[alpha.SslPolicyReference]{Global: gcp.AlphaTargetHttpsProxies().SetSslPolicy},
+		Beta:  rnode.UpdateFuncsByScope[beta.SslPolicyReference]{Global: gcp.BetaTargetHttpsProxies().SetSslPolicy},
+	}
+}
+
+func patchFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy] {
+	return &rnode.MethodFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetHttpsProxy]{Regional: gcp.RegionTargetHttpsProxies().Patch},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetHttpsProxy]{Regional: gcp.AlphaRegionTargetHttpsProxies().Patch},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetHttpsProxy]{Regional: gcp.BetaRegionTargetHttpsProxies().Patch},
+	}
+}
+
+func setSslCertificatesFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetHttpsProxiesSetSslCertificatesRequest, alpha.TargetHttpsProxiesSetSslCertificatesRequest, beta.TargetHttpsProxiesSetSslCertificatesRequest] {
+	return &rnode.MethodFuncs[compute.TargetHttpsProxiesSetSslCertificatesRequest, alpha.TargetHttpsProxiesSetSslCertificatesRequest, beta.TargetHttpsProxiesSetSslCertificatesRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetHttpsProxiesSetSslCertificatesRequest]{Global: gcp.TargetHttpsProxies().SetSslCertificates},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetHttpsProxiesSetSslCertificatesRequest]{Global: gcp.AlphaTargetHttpsProxies().SetSslCertificates},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetHttpsProxiesSetSslCertificatesRequest]{Global: gcp.BetaTargetHttpsProxies().SetSslCertificates},
+	}
+}
+
+func regionSetSslCertificatesFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.RegionTargetHttpsProxiesSetSslCertificatesRequest, alpha.RegionTargetHttpsProxiesSetSslCertificatesRequest, beta.RegionTargetHttpsProxiesSetSslCertificatesRequest] {
+	return &rnode.MethodFuncs[compute.RegionTargetHttpsProxiesSetSslCertificatesRequest, alpha.RegionTargetHttpsProxiesSetSslCertificatesRequest, beta.RegionTargetHttpsProxiesSetSslCertificatesRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.RegionTargetHttpsProxiesSetSslCertificatesRequest]{Regional: gcp.RegionTargetHttpsProxies().SetSslCertificates},
+		Alpha: rnode.UpdateFuncsByScope[alpha.RegionTargetHttpsProxiesSetSslCertificatesRequest]{Regional: gcp.AlphaRegionTargetHttpsProxies().SetSslCertificates},
+		Beta:  rnode.UpdateFuncsByScope[beta.RegionTargetHttpsProxiesSetSslCertificatesRequest]{Regional: gcp.BetaRegionTargetHttpsProxies().SetSslCertificates},
+	}
+}
+
+func setCertificateMapFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetHttpsProxiesSetCertificateMapRequest, alpha.TargetHttpsProxiesSetCertificateMapRequest, beta.TargetHttpsProxiesSetCertificateMapRequest] {
+	return &rnode.MethodFuncs[compute.TargetHttpsProxiesSetCertificateMapRequest, alpha.TargetHttpsProxiesSetCertificateMapRequest, beta.TargetHttpsProxiesSetCertificateMapRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetHttpsProxiesSetCertificateMapRequest]{Global: gcp.TargetHttpsProxies().SetCertificateMap},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetHttpsProxiesSetCertificateMapRequest]{Global: gcp.AlphaTargetHttpsProxies().SetCertificateMap},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetHttpsProxiesSetCertificateMapRequest]{Global: gcp.BetaTargetHttpsProxies().SetCertificateMap},
+	}
+}
+
+func setUrlMapFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.UrlMapReference, alpha.UrlMapReference, beta.UrlMapReference] {
+	return &rnode.MethodFuncs[compute.UrlMapReference, alpha.UrlMapReference, beta.UrlMapReference]{
+		GA: rnode.UpdateFuncsByScope[compute.UrlMapReference]{
+			Global:   gcp.TargetHttpsProxies().SetUrlMap,
+			Regional: gcp.RegionTargetHttpsProxies().SetUrlMap,
+		},
+		Alpha: rnode.UpdateFuncsByScope[alpha.UrlMapReference]{
+			Global:   gcp.AlphaTargetHttpsProxies().SetUrlMap,
+			Regional: gcp.AlphaRegionTargetHttpsProxies().SetUrlMap,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.UrlMapReference]{
+			Global:   gcp.BetaTargetHttpsProxies().SetUrlMap,
+			Regional: gcp.BetaRegionTargetHttpsProxies().SetUrlMap,
+		},
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestUpdateAction(t *testing.T) {
+	t.Parallel()
+
+	policy := "policy"
+	certMap := "cm"
+
+	for _, tc := range []struct {
+		name      string
+		key       *meta.Key
+		version   meta.Version
+		service   string
+		wantCalls []string
+		certMap   *string
+		wantErr   bool
+	}{
+		{
+			name:      "global",
+			key:       meta.GlobalKey("thps"),
+			version:   meta.VersionGA,
+			service:   "TargetHttpsProxies",
+			certMap:   &certMap,
+			wantCalls: []string{"SetSslCertificates", "SetCertificateMap", "SetSslPolicy", "SetUrlMap"},
+		},
+		{
+			name:      "global alpha",
+			key:       meta.GlobalKey("thps"),
+			version:   meta.VersionAlpha,
+			service:   "TargetHttpsProxies",
+			certMap:   &certMap,
+			wantCalls: []string{"SetSslCertificates", "SetCertificateMap", "SetSslPolicy", "SetUrlMap"},
+		},
+		{
+			name:      "regional",
+			key:       meta.RegionalKey("thps", "us-central1"),
+			version:   meta.VersionGA,
+			service:   "RegionTargetHttpsProxies",
+			wantCalls: []string{"SetSslCertificates", "Patch", "SetUrlMap"},
+		},
+		{
+			name:      "regional beta",
+			key:       meta.RegionalKey("thps", "us-central1"),
+			version:   meta.VersionBeta,
+			service:   "RegionTargetHttpsProxies",
+			wantCalls: []string{"SetSslCertificates", "Patch", "SetUrlMap"},
+		},
+		{
+			name:    "regional certificate map",
+			key:     meta.RegionalKey("thps", "us-central1"),
+			version: meta.VersionGA,
+			certMap: &certMap,
+			wantErr: true,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := ID("proj", tc.key)
+			umID := urlmap.ID("proj", meta.GlobalKey("um"))
+			oldUmID := urlmap.ID("proj", meta.GlobalKey("um-old"))
+			act := &targetHttpsProxyUpdateAction{
+				id:              id,
+				version:         tc.version,
+				urlMap:          umID,
+				oldUrlMap:       oldUmID,
+				sslCertificates: []string{"cert"},
+				certificateMap:  tc.certMap,
+				sslPolicy:       &policy,
+			}
+			wantEvents := exec.EventList{exec.NewDropRefEvent(id, oldUmID)}
+
+			if events := act.DryRun(); !events.Equal(wantEvents) {
+				t.Errorf("DryRun() = %v, want %v", events, wantEvents)
+			}
+
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+			if tc.key.Type() == meta.Regional {
+				// Regional proxies change .SslPolicy with Patch(), which returns
+				// NotFound in the mock unless the proxy was inserted. The
+				// Set*() mocks of the global proxies do not check.
+				if err := mock.RegionTargetHttpsProxies().Insert(context.Background(), tc.key, &compute.TargetHttpsProxy{Name: tc.key.Name}); err != nil {
+					t.Fatalf("Insert() = %v, want nil", err)
+				}
+			}
+			events, err := act.Run(context.Background(), mock)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Run() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !events.Equal(wantEvents) {
+				t.Errorf("Run() = %v, want %v", events, wantEvents)
+			}
+			for _, op := range tc.wantCalls {
+				calls := mock.Calls().Matching(cloud.MockCall{Service: tc.service, Version: tc.version, Operation: op, Key: tc.key})
+				if len(calls) != 1 {
+					t.Errorf("calls to %s.%s(%s) = %v, want 1", tc.service, op, tc.version, mock.Calls())
+				}
+			}
+		})
+	}
+}
+
+func TestWaitCertificatesActiveAction(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name    string
+		managed []string
+		want    bool
+	}{
+		{name: "self-managed", managed: []string{""}, want: true},
+		{name: "active", managed: []string{"ACTIVE", ""}, want: true},
+		{name: "provisioning", managed: []string{"ACTIVE", "PROVISIONING"}},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+			var certs []string
+			for i, status := range tc.managed {
+				certID := cloud.NewSslCertificatesResourceID("proj", fmt.Sprintf("cert-%d", i))
+				cert := &compute.SslCertificate{Name: certID.Key.Name}
+				if status != "" {
+					cert.Managed = &compute.SslCertificateManagedSslCertificate{Status: status}
+				}
+				if err := mock.SslCertificates().Insert(ctx, certID.Key, cert); err != nil {
+					t.Fatalf("SslCertificates().Insert() = %v", err)
+				}
+				certs = append(certs, certID.SelfLink(meta.VersionGA))
+			}
+			id := ID("proj", meta.GlobalKey("thps"))
+			if err := mock.TargetHttpsProxies().Insert(ctx, id.Key, &compute.TargetHttpsProxy{Name: "thps", SslCertificates: certs}); err != nil {
+				t.Fatalf("TargetHttpsProxies().Insert() = %v", err)
+			}
+
+			act := NewWaitCertificatesActiveAction(id, exec.WaitOptions{Timeout: 10 * time.Millisecond, Interval: time.Millisecond})
+			act.Signal(exec.NewExistsEvent(id))
+			events, err := act.Run(ctx, mock)
+			if ready := err == nil; ready != tc.want {
+				t.Fatalf("Run() = %v, %v; want ready = %t", events, err, tc.want)
+			}
+			if want := (exec.EventList{exec.NewReadyEvent(id, ConditionCertificatesActive)}); tc.want && !events.Equal(want) {
+				t.Errorf("Run() = %v, want %v", events, want)
+			}
+		})
+	}
+}
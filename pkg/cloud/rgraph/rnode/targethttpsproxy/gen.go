@@ -0,0 +1,198 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package targethttpsproxy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID returns the ResourceID of the TargetHttpsProxy.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "targetHttpsProxies",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableTargetHttpsProxy = api.MutableResource[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]
+
+func NewMutableTargetHttpsProxy(project string, key *meta.Key) MutableTargetHttpsProxy {
+	id := ID(project, key)
+	return api.NewResource[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](id, &typeTrait{})
+}
+
+type TargetHttpsProxy = api.Resource[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpsProxies
+type typeTrait struct {
+	api.BaseTypeTrait[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("Fingerprint"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.Reference(api.Path{}.Pointer().Field("UrlMap"), "urlMaps")
+	dt.Reference(api.Path{}.Pointer().Field("SslCertificates").AnySliceIndex(), "sslCertificates")
+	dt.Reference(api.Path{}.Pointer().Field("SslPolicy"), "sslPolicies")
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy] {
+	return &rnode.GetFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]{
+		GA: rnode.GetFuncsByScope[compute.TargetHttpsProxy]{
+			Global:   gcp.TargetHttpsProxies().Get,
+			Regional: gcp.RegionTargetHttpsProxies().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.TargetHttpsProxy]{
+			Global:   gcp.AlphaTargetHttpsProxies().Get,
+			Regional: gcp.AlphaRegionTargetHttpsProxies().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.TargetHttpsProxy]{
+			Global:   gcp.BetaTargetHttpsProxies().Get,
+			Regional: gcp.BetaRegionTargetHttpsProxies().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy] {
+	return &rnode.CreateFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]{
+		GA: rnode.CreateFuncsByScope[compute.TargetHttpsProxy]{
+			Global:   gcp.TargetHttpsProxies().Insert,
+			Regional: gcp.RegionTargetHttpsProxies().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.TargetHttpsProxy]{
+			Global:   gcp.AlphaTargetHttpsProxies().Insert,
+			Regional: gcp.AlphaRegionTargetHttpsProxies().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.TargetHttpsProxy]{
+			Global:   gcp.BetaTargetHttpsProxies().Insert,
+			Regional: gcp.BetaRegionTargetHttpsProxies().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy] {
+	return nil // Does not support generic Update.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy] {
+	return &rnode.DeleteFuncs[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy]{
+		GA: rnode.DeleteFuncsByScope[compute.TargetHttpsProxy]{
+			Global:   gcp.TargetHttpsProxies().Delete,
+			Regional: gcp.RegionTargetHttpsProxies().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.TargetHttpsProxy]{
+			Global:   gcp.AlphaTargetHttpsProxies().Delete,
+			Regional: gcp.AlphaRegionTargetHttpsProxies().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.TargetHttpsProxy]{
+			Global:   gcp.BetaTargetHttpsProxies().Delete,
+			Regional: gcp.BetaRegionTargetHttpsProxies().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r TargetHttpsProxy) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource TargetHttpsProxy
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(TargetHttpsProxy)
+	if !ok {
+		return fmt.Errorf("cannot set TargetHttpsProxy from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](ctx, gcp, "TargetHttpsProxy", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	return rnode.OutRefsFromTraits[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](b.resource, &typeTrait{})
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("TargetHttpsProxy %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &targetHttpsProxyNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+type targetHttpsProxyNode struct {
+	rnode.NodeBase
+	resource TargetHttpsProxy
+}
+
+var _ rnode.Node = (*targetHttpsProxyNode)(nil)
+
+func (n *targetHttpsProxyNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *targetHttpsProxyNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
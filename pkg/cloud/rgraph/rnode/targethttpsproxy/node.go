@@ -0,0 +1,184 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+func nodeErr(s string, args ...any) error { return fmt.Errorf("targetHttpsProxy: "+s, args...) }
+
+// diffPolicies are the default DiffPolicy for the proxy by scope. Only global
+// proxies can change the .CertificateMap in place.
+var diffPolicies = map[meta.KeyType]*rnode.DiffPolicy{
+	meta.Global:   rnode.NewDiffPolicy(rnode.FieldRecreate).SetFields(rnode.FieldUpdate, "UrlMap", "SslCertificates", "CertificateMap", "SslPolicy"),
+	meta.Regional: rnode.NewDiffPolicy(rnode.FieldRecreate).SetFields(rnode.FieldUpdate, "UrlMap", "SslCertificates", "SslPolicy"),
+}
+
+// setters for the fields that can be changed in place. .SslPolicy is set
+// with setSslPolicy() for global proxies and with patch() for regional ones,
+// which is why the action also carries the fingerprint. All other fields
+// (e.g. QuicOverride, ServerTlsPolicy, Description) require the resource to
+// be recreated.
+var setters = rnode.FieldSetters[*targetHttpsProxyUpdateAction, *compute.TargetHttpsProxy]{
+	{
+		Path: api.Path{}.Pointer().Field("UrlMap"),
+		Set: func(act *targetHttpsProxyUpdateAction, got, want *compute.TargetHttpsProxy) error {
+			oldUrlMap, err := cloud.ParseResourceURL(got.UrlMap)
+			if err != nil {
+				return fmt.Errorf("invalid .UrlMap %q: %w", got.UrlMap, err)
+			}
+			urlMap, err := cloud.ParseResourceURL(want.UrlMap)
+			if err != nil {
+				return fmt.Errorf("invalid .UrlMap %q: %w", want.UrlMap, err)
+			}
+			act.Want = append(act.Want, exec.NewExistsEvent(urlMap))
+			act.oldUrlMap = oldUrlMap
+			act.urlMap = urlMap
+			return nil
+		},
+	},
+	{
+		Path: api.Path{}.Pointer().Field("SslCertificates"),
+		Set: func(act *targetHttpsProxyUpdateAction, _, want *compute.TargetHttpsProxy) error {
+			act.sslCertificates = want.SslCertificates
+			if act.sslCertificates == nil {
+				// Clear the certificates.
+				act.sslCertificates = []string{}
+			}
+			return nil
+		},
+	},
+	{
+		Path:  api.Path{}.Pointer().Field("CertificateMap"),
+		Scope: meta.Global,
+		Set: func(act *targetHttpsProxyUpdateAction, _, want *compute.TargetHttpsProxy) error {
+			act.certificateMap = &want.CertificateMap
+			return nil
+		},
+	},
+	{
+		Path: api.Path{}.Pointer().Field("SslPolicy"),
+		Set: func(act *targetHttpsProxyUpdateAction, got, want *compute.TargetHttpsProxy) error {
+			act.sslPolicy = &want.SslPolicy
+			act.fingerprint = got.Fingerprint
+			return nil
+		},
+	},
+}
+
+func (n *targetHttpsProxyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*targetHttpsProxyNode)
+	if !ok {
+		return nil, nodeErr("invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, nodeErr("Diff: %w", err)
+	}
+
+	r, err := n.EffectiveDiffPolicy(diffPolicies[n.ID().Key.Type()]).Apply(diff)
+	if err != nil {
+		return nil, nodeErr("%w", err)
+	}
+	return setters.PlanDetails("TargetHttpsProxy", n.ID().Key.Type(), r), nil
+}
+
+func (n *targetHttpsProxyNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.TargetHttpsProxy, alpha.TargetHttpsProxy, beta.TargetHttpsProxy](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+	return nil, nodeErr("invalid plan op %s", op)
+}
+
+func (n *targetHttpsProxyNode) updateActions(ngot rnode.Node) ([]exec.Action, error) {
+	details := n.Plan().Details()
+	if details == nil {
+		return nil, nodeErr("updateActions: node %s has not been planned", n.ID())
+	}
+	got, ok := ngot.(*targetHttpsProxyNode)
+	if !ok {
+		return nil, nodeErr("updateActions: node %s has invalid type %T", n.ID(), ngot)
+	}
+
+	gotRes, err := got.resource.ToGA()
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+	wantRes, err := n.resource.ToGA()
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+
+	act := &targetHttpsProxyUpdateAction{id: n.ID(), version: n.Version()}
+	act.changes, err = setters.Apply(n.ID().Key.Type(), details.Diff, act, gotRes, wantRes)
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+
+	// Condition: newly referenced SslCertificates and SslPolicy must exist
+	// before the update. References that are removed are dropped after the
+	// update. The UrlMap is handled above.
+	gotRefs := map[string]bool{}
+	for _, ref := range got.OutRefs() {
+		gotRefs[ref.To.String()] = true
+	}
+	wantRefs := map[string]bool{}
+	for _, ref := range n.OutRefs() {
+		wantRefs[ref.To.String()] = true
+		if ref.To.Resource != "urlMaps" && !gotRefs[ref.To.String()] {
+			act.Want = append(act.Want, exec.NewExistsEvent(ref.To))
+		}
+	}
+	for _, ref := range got.OutRefs() {
+		if ref.To.Resource != "urlMaps" && !wantRefs[ref.To.String()] {
+			act.dropRefs = append(act.dropRefs, exec.NewDropRefEvent(ref.From, ref.To))
+		}
+	}
+
+	return []exec.Action{
+		// Action: Signal resource exists.
+		exec.NewExistsAction(n.ID()),
+		// Action: Do the updates.
+		act,
+	}, nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/sslcertificate"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/sslpolicy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	const proj = "proj"
+	um1ID := urlmap.ID(proj, meta.GlobalKey("um1"))
+	um2ID := urlmap.ID(proj, meta.GlobalKey("um2"))
+	um1 := um1ID.SelfLink(meta.VersionGA)
+	um2 := um2ID.SelfLink(meta.VersionGA)
+	cert1ID := sslcertificate.ID(proj, meta.GlobalKey("cert1"))
+	cert2ID := sslcertificate.ID(proj, meta.GlobalKey("cert2"))
+	cert1 := cert1ID.SelfLink(meta.VersionGA)
+	cert2 := cert2ID.SelfLink(meta.VersionGA)
+	policyID := sslpolicy.ID(proj, meta.RegionalKey("policy", "us-central1"))
+
+	base := func(x *compute.TargetHttpsProxy) {
+		x.Name = "thps"
+		x.UrlMap = um1
+		x.SslCertificates = []string{cert1}
+	}
+	// Fields that are not set in base; the test cases may set these.
+	unset := []string{
+		"AuthorizationPolicy",
+		"CertificateMap",
+		"Description",
+		"HttpKeepAliveTimeoutSec",
+		"ProxyBind",
+		"QuicOverride",
+		"ServerTlsPolicy",
+		"SslPolicy",
+	}
+
+	for _, tc := range []struct {
+		name    string
+		key     *meta.Key
+		f       func(x *compute.TargetHttpsProxy)
+		wantOp  rnode.Operation
+		wantErr bool
+		// wantExists are the references that the update waits for.
+		wantExists []*cloud.ResourceID
+		// wantDropped are the references that are dropped by the update.
+		wantDropped []*cloud.ResourceID
+	}{
+		{
+			name:   "no diff",
+			key:    meta.GlobalKey("thps"),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:        "update .UrlMap",
+			key:         meta.GlobalKey("thps"),
+			f:           func(x *compute.TargetHttpsProxy) { x.UrlMap = um2 },
+			wantOp:      rnode.OpUpdate,
+			wantExists:  []*cloud.ResourceID{um2ID},
+			wantDropped: []*cloud.ResourceID{um1ID},
+		},
+		{
+			name:       "update .SslCertificates",
+			key:        meta.GlobalKey("thps"),
+			f:          func(x *compute.TargetHttpsProxy) { x.SslCertificates = []string{cert1, cert2} },
+			wantOp:     rnode.OpUpdate,
+			wantExists: []*cloud.ResourceID{cert2ID},
+		},
+		{
+			name:        "replace .SslCertificates",
+			key:         meta.GlobalKey("thps"),
+			f:           func(x *compute.TargetHttpsProxy) { x.SslCertificates = []string{cert2} },
+			wantOp:      rnode.OpUpdate,
+			wantExists:  []*cloud.ResourceID{cert2ID},
+			wantDropped: []*cloud.ResourceID{cert1ID},
+		},
+		{
+			name:   "update .CertificateMap",
+			key:    meta.GlobalKey("thps"),
+			f:      func(x *compute.TargetHttpsProxy) { x.CertificateMap = "cm" },
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name:   "regional .CertificateMap",
+			key:    meta.RegionalKey("thps", "us-central1"),
+			f:      func(x *compute.TargetHttpsProxy) { x.CertificateMap = "cm" },
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:       "regional update .SslPolicy",
+			key:        meta.RegionalKey("thps", "us-central1"),
+			f:          func(x *compute.TargetHttpsProxy) { x.SslPolicy = policyID.SelfLink(meta.VersionGA) },
+			wantOp:     rnode.OpUpdate,
+			wantExists: []*cloud.ResourceID{policyID},
+		},
+		{
+			name:   "recreate .QuicOverride",
+			key:    meta.GlobalKey("thps"),
+			f:      func(x *compute.TargetHttpsProxy) { x.QuicOverride = "ENABLE" },
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			makeNode := func(f func(x *compute.TargetHttpsProxy)) rnode.Node {
+				t.Helper()
+				m := NewMutableTargetHttpsProxy(proj, tc.key)
+				for _, f := range unset {
+					if err := m.ClearField(api.Path{}.Pointer().Field(f)); err != nil {
+						t.Fatalf("ClearField(%s) = %v, want nil", f, err)
+					}
+				}
+				if err := m.Access(func(x *compute.TargetHttpsProxy) {
+					base(x)
+					if f != nil {
+						f(x)
+					}
+				}); err != nil {
+					t.Fatalf("Access() = %v, want nil", err)
+				}
+				return nodetest.Build(t, m, NewBuilderWithResource)
+			}
+			got := makeNode(nil)
+			want := makeNode(tc.f)
+
+			plan, err := want.Diff(got)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Diff() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if plan.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v (%s), want %v", plan.Operation, plan.Why, tc.wantOp)
+			}
+			want.Plan().Set(*plan)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if tc.wantOp != rnode.OpUpdate {
+				return
+			}
+			act := actions[len(actions)-1].(*targetHttpsProxyUpdateAction)
+			var wantExists, wantDropped exec.EventList
+			for _, id := range tc.wantExists {
+				wantExists = append(wantExists, exec.NewExistsEvent(id))
+			}
+			for _, id := range tc.wantDropped {
+				wantDropped = append(wantDropped, exec.NewDropRefEvent(want.ID(), id))
+			}
+			if !act.Want.Equal(wantExists) {
+				t.Errorf("update action Want = %v, want %v", act.Want, wantExists)
+			}
+			if events := act.DryRun(); !events.Equal(wantDropped) {
+				t.Errorf("update action DryRun() = %v, want %v", events, wantDropped)
+			}
+		})
+	}
+}
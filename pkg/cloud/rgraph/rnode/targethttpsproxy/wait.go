@@ -0,0 +1,92 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targethttpsproxy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"google.golang.org/api/compute/v1"
+)
+
+// ConditionCertificatesActive is the condition signaled by
+// NewWaitCertificatesActiveAction.
+const ConditionCertificatesActive = "CertificatesActive"
+
+// NewWaitCertificatesActiveAction returns an Action that waits for the
+// Google-managed SslCertificates of the TargetHttpsProxy to be ACTIVE. It
+// signals exec.NewReadyEvent(id, ConditionCertificatesActive).
+//
+// Self-managed certificates are always ready. Certificates referenced
+// through a CertificateMap are not checked.
+func NewWaitCertificatesActiveAction(id *cloud.ResourceID, opts exec.WaitOptions) exec.Action {
+	return exec.NewWaitAction(id, ConditionCertificatesActive, func(ctx context.Context, cl cloud.Cloud) (bool, error) {
+		var (
+			proxy *compute.TargetHttpsProxy
+			err   error
+		)
+		opt := cloud.ForceProjectID(id.ProjectID)
+		switch id.Key.Type() {
+		case meta.Global:
+			proxy, err = cl.TargetHttpsProxies().Get(ctx, id.Key, opt)
+		case meta.Regional:
+			proxy, err = cl.RegionTargetHttpsProxies().Get(ctx, id.Key, opt)
+		default:
+			return false, fmt.Errorf("invalid key type")
+		}
+		if err != nil {
+			return false, err
+		}
+		for _, url := range proxy.SslCertificates {
+			certID, err := cloud.ParseResourceURL(url)
+			if err != nil {
+				return false, fmt.Errorf("SslCertificate %q: %w", url, err)
+			}
+			active, err := certificateActive(ctx, cl, certID)
+			if err != nil || !active {
+				return false, err
+			}
+		}
+		return true, nil
+	}, opts)
+}
+
+func certificateActive(ctx context.Context, cl cloud.Cloud, id *cloud.ResourceID) (bool, error) {
+	var (
+		cert *compute.SslCertificate
+		err  error
+	)
+	opt := cloud.ForceProjectID(id.ProjectID)
+	switch id.Key.Type() {
+	case meta.Global:
+		cert, err = cl.SslCertificates().Get(ctx, id.Key, opt)
+	case meta.Regional:
+		cert, err = cl.RegionSslCertificates().Get(ctx, id.Key, opt)
+	default:
+		return false, fmt.Errorf("SslCertificate %s: invalid key type", id)
+	}
+	if err != nil {
+		return false, fmt.Errorf("SslCertificate %s: %w", id, err)
+	}
+	if cert.Managed == nil {
+		return true, nil
+	}
+	return cert.Managed.Status == "ACTIVE", nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetsslproxy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type targetSslProxyUpdateAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// version of the API used for the calls.
+	version meta.Version
+
+	// service if non-nil will call setBackendService().
+	service *cloud.ResourceID
+	// oldService is the BackendService before the update.
+	oldService *cloud.ResourceID
+	// proxyHeader if non-nil will call setProxyHeader(). The empty string
+	// is the default (NONE).
+	proxyHeader *string
+	// sslCertificates if non-nil will call setSslCertificates().
+	sslCertificates []string
+	// certificateMap if non-nil will call setCertificateMap().
+	certificateMap *string
+	// sslPolicy if non-nil will call setSslPolicy().
+	sslPolicy *string
+
+	// changes are human-readable descriptions of the changed fields.
+	changes []string
+}
+
+func (act *targetSslProxyUpdateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	key := act.id.Key
+	if key.Type() != meta.Global {
+		return nil, fmt.Errorf("targetSslProxyUpdateAction Run(%s): invalid key type", act.id)
+	}
+	errf := func(verb string, err error) error {
+		return fmt.Errorf("targetSslProxyUpdateAction Run(%s): %s: %w", act.id, verb, err)
+	}
+
+	if act.sslCertificates != nil {
+		req := &compute.TargetSslProxiesSetSslCertificatesRequest{SslCertificates: act.sslCertificates}
+		if err := setSslCertificatesFuncs(cl).Do(ctx, act.version, act.id, req); err != nil {
+			return nil, errf("SetSslCertificates", err)
+		}
+	}
+
+	if act.certificateMap != nil {
+		req := &compute.TargetSslProxiesSetCertificateMapRequest{
+			CertificateMap:  *act.certificateMap,
+			ForceSendFields: []string{"CertificateMap"},
+		}
+		if err := setCertificateMapFuncs(cl).Do(ctx, act.version, act.id, req); err != nil {
+			return nil, errf("SetCertificateMap", err)
+		}
+	}
+
+	if act.sslPolicy != nil {
+		ref := &compute.SslPolicyReference{
+			SslPolicy:       *act.sslPolicy,
+			ForceSendFields: []string{"SslPolicy"},
+		}
+		if err := setSslPolicyFuncs(cl).Do(ctx, act.version, act.id, ref); err != nil {
+			return nil, errf("SetSslPolicy", err)
+		}
+	}
+
+	if act.proxyHeader != nil {
+		req := &compute.TargetSslProxiesSetProxyHeaderRequest{ProxyHeader: *act.proxyHeader}
+		if err := setProxyHeaderFuncs(cl).Do(ctx, act.version, act.id, req); err != nil {
+			return nil, errf("SetProxyHeader", err)
+		}
+	}
+
+	if act.service != nil {
+		req := &compute.TargetSslProxiesSetBackendServiceRequest{Service: act.service.SelfLink(act.version)}
+		if err := setBackendServiceFuncs(cl).Do(ctx, act.version, act.id, req); err != nil {
+			return nil, errf("SetBackendService", err)
+		}
+	}
+
+	return act.DryRun(), nil
+}
+
+func (act *targetSslProxyUpdateAction) DryRun() exec.EventList {
+	var events exec.EventList
+	if act.oldService != nil && !act.service.Equal(act.oldService) {
+		events = append(events, exec.NewDropRefEvent(act.id, act.oldService))
+	}
+	return events
+}
+
+func (act *targetSslProxyUpdateAction) String() string {
+	return fmt.Sprintf("TargetSslProxyUpdateAction(%s)", act.id)
+}
+
+func (act *targetSslProxyUpdateAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *targetSslProxyUpdateAction) Metadata() *exec.ActionMetadata {
+	summary := fmt.Sprintf("Update %s", act.id)
+	if len(act.changes) > 0 {
+		summary += ": " + strings.Join(act.changes, ", ")
+	}
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("TargetSslProxyUpdateAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: summary,
+	}
+}
+
+func setSslCertificatesFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetSslProxiesSetSslCertificatesRequest, alpha.TargetSslProxiesSetSslCertificatesRequest, beta.TargetSslProxiesSetSslCertificatesRequest] {
+	return &rnode.MethodFuncs[compute.TargetSslProxiesSetSslCertificatesRequest, alpha.TargetSslProxiesSetSslCertificatesRequest, beta.TargetSslProxiesSetSslCertificatesRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetSslProxiesSetSslCertificatesRequest]{Global: gcp.TargetSslProxies().SetSslCertificates},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetSslProxiesSetSslCertificatesRequest]{Global: gcp.AlphaTargetSslProxies().SetSslCertificates},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetSslProxiesSetSslCertificatesRequest]{Global: gcp.BetaTargetSslProxies().SetSslCertificates},
+	}
+}
+
+func setCertificateMapFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetSslProxiesSetCertificateMapRequest, alpha.TargetSslProxiesSetCertificateMapRequest, beta.TargetSslProxiesSetCertificateMapRequest] {
+	return &rnode.MethodFuncs[compute.TargetSslProxiesSetCertificateMapRequest, alpha.TargetSslProxiesSetCertificateMapRequest, beta.TargetSslProxiesSetCertificateMapRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetSslProxiesSetCertificateMapRequest]{Global: gcp.TargetSslProxies().SetCertificateMap},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetSslProxiesSetCertificateMapRequest]{Global: gcp.AlphaTargetSslProxies().SetCertificateMap},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetSslProxiesSetCertificateMapRequest]{Global: gcp.BetaTargetSslProxies().SetCertificateMap},
+	}
+}
+
+func setSslPolicyFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.SslPolicyReference, alpha.SslPolicyReference, beta.SslPolicyReference] {
+	return &rnode.MethodFuncs[compute.SslPolicyReference, alpha.SslPolicyReference, beta.SslPolicyReference]{
+		GA:    rnode.UpdateFuncsByScope[compute.SslPolicyReference]{Global: gcp.TargetSslProxies().SetSslPolicy},
+		Alpha: rnode.UpdateFuncsByScope[alpha.SslPolicyReference]{Global: gcp.AlphaTargetSslProxies().SetSslPolicy},
+		Beta:  rnode.UpdateFuncsByScope[beta.SslPolicyReference]{Global: gcp.BetaTargetSslProxies().SetSslPolicy},
+	}
+}
+
+func setProxyHeaderFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetSslProxiesSetProxyHeaderRequest, alpha.TargetSslProxiesSetProxyHeaderRequest, beta.TargetSslProxiesSetProxyHeaderRequest] {
+	return &rnode.MethodFuncs[compute.TargetSslProxiesSetProxyHeaderRequest, alpha.TargetSslProxiesSetProxyHeaderRequest, beta.TargetSslProxiesSetProxyHeaderRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetSslProxiesSetProxyHeaderRequest]{Global: gcp.TargetSslProxies().SetProxyHeader},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetSslProxiesSetProxyHeaderRequest]{Global: gcp.AlphaTargetSslProxies().SetProxyHeader},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetSslProxiesSetProxyHeaderRequest]{Global: gcp.BetaTargetSslProxies().SetProxyHeader},
+	}
+}
+
+func setBackendServiceFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetSslProxiesSetBackendServiceRequest, alpha.TargetSslProxiesSetBackendServiceRequest, beta.TargetSslProxiesSetBackendServiceRequest] {
+	return &rnode.MethodFuncs[compute.TargetSslProxiesSetBackendServiceRequest, alpha.TargetSslProxiesSetBackendServiceRequest, beta.TargetSslProxiesSetBackendServiceRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetSslProxiesSetBackendServiceRequest]{Global: gcp.TargetSslProxies().SetBackendService},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetSslProxiesSetBackendServiceRequest]{Global: gcp.AlphaTargetSslProxies().SetBackendService},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetSslProxiesSetBackendServiceRequest]{Global: gcp.BetaTargetSslProxies().SetBackendService},
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetsslproxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+)
+
+func TestUpdateAction(t *testing.T) {
+	key := meta.GlobalKey("sp")
+	id := ID("proj", key)
+	bsID := backendservice.ID("proj", meta.GlobalKey("bs"))
+	oldBsID := backendservice.ID("proj", meta.GlobalKey("bs-old"))
+	header := "PROXY_V1"
+	policy := "policy"
+	certMap := "cm"
+
+	for _, ver := range []meta.Version{meta.VersionGA, meta.VersionAlpha} {
+		act := &targetSslProxyUpdateAction{
+			id:              id,
+			version:         ver,
+			service:         bsID,
+			oldService:      oldBsID,
+			proxyHeader:     &header,
+			sslCertificates: []string{"cert"},
+			certificateMap:  &certMap,
+			sslPolicy:       &policy,
+		}
+		wantEvents := exec.EventList{exec.NewDropRefEvent(id, oldBsID)}
+
+		if events := act.DryRun(); !events.Equal(wantEvents) {
+			t.Errorf("DryRun() = %v, want %v", events, wantEvents)
+		}
+
+		mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+		events, err := act.Run(context.Background(), mock)
+		if err != nil {
+			t.Fatalf("Run(%s) = %v, want nil", ver, err)
+		}
+		if !events.Equal(wantEvents) {
+			t.Errorf("Run(%s) = %v, want %v", ver, events, wantEvents)
+		}
+		for _, op := range []string{"SetSslCertificates", "SetCertificateMap", "SetSslPolicy", "SetProxyHeader", "SetBackendService"} {
+			calls := mock.Calls().Matching(cloud.MockCall{Service: "TargetSslProxies", Version: ver, Operation: op, Key: key})
+			if len(calls) != 1 {
+				t.Errorf("calls to TargetSslProxies.%s(%s) = %v, want 1", op, ver, mock.Calls())
+			}
+		}
+	}
+}
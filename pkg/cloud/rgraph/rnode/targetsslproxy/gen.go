@@ -0,0 +1,190 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package targetsslproxy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID returns the ResourceID of the TargetSslProxy.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "targetSslProxies",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableTargetSslProxy = api.MutableResource[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy]
+
+func NewMutableTargetSslProxy(project string, key *meta.Key) MutableTargetSslProxy {
+	id := ID(project, key)
+	return api.NewResource[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy](id, &typeTrait{})
+}
+
+type TargetSslProxy = api.Resource[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy]
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetSslProxies
+type typeTrait struct {
+	api.BaseTypeTrait[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("CertificateMap"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("ProxyHeader"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("SslCertificates"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("SslPolicy"))
+	dt.Reference(api.Path{}.Pointer().Field("Service"), "backendServices")
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy] {
+	return &rnode.GetFuncs[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy]{
+		GA: rnode.GetFuncsByScope[compute.TargetSslProxy]{
+			Global: gcp.TargetSslProxies().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.TargetSslProxy]{
+			Global: gcp.AlphaTargetSslProxies().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.TargetSslProxy]{
+			Global: gcp.BetaTargetSslProxies().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy] {
+	return &rnode.CreateFuncs[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy]{
+		GA: rnode.CreateFuncsByScope[compute.TargetSslProxy]{
+			Global: gcp.TargetSslProxies().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.TargetSslProxy]{
+			Global: gcp.AlphaTargetSslProxies().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.TargetSslProxy]{
+			Global: gcp.BetaTargetSslProxies().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy] {
+	return nil // Does not support generic Update.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy] {
+	return &rnode.DeleteFuncs[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy]{
+		GA: rnode.DeleteFuncsByScope[compute.TargetSslProxy]{
+			Global: gcp.TargetSslProxies().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.TargetSslProxy]{
+			Global: gcp.AlphaTargetSslProxies().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.TargetSslProxy]{
+			Global: gcp.BetaTargetSslProxies().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r TargetSslProxy) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource TargetSslProxy
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(TargetSslProxy)
+	if !ok {
+		return fmt.Errorf("cannot set TargetSslProxy from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy](ctx, gcp, "TargetSslProxy", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	return rnode.OutRefsFromTraits[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy](b.resource, &typeTrait{})
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("TargetSslProxy %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &targetSslProxyNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+type targetSslProxyNode struct {
+	rnode.NodeBase
+	resource TargetSslProxy
+}
+
+var _ rnode.Node = (*targetSslProxyNode)(nil)
+
+func (n *targetSslProxyNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *targetSslProxyNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
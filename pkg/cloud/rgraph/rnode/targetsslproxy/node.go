@@ -0,0 +1,163 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetsslproxy
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+func nodeErr(s string, args ...any) error { return fmt.Errorf("targetSslProxy: "+s, args...) }
+
+// diffPolicy is the default DiffPolicy for the proxy.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate).
+	SetFields(rnode.FieldUpdate, "Service", "ProxyHeader", "SslCertificates", "CertificateMap", "SslPolicy")
+
+// setters for the fields that can be changed in place. TargetSslProxy has a
+// set method for each of these. All other fields (e.g. .Description) require
+// the resource to be recreated.
+var setters = rnode.FieldSetters[*targetSslProxyUpdateAction, *compute.TargetSslProxy]{
+	{
+		Path: api.Path{}.Pointer().Field("Service"),
+		Set: func(act *targetSslProxyUpdateAction, got, want *compute.TargetSslProxy) error {
+			oldService, err := cloud.ParseResourceURL(got.Service)
+			if err != nil {
+				return fmt.Errorf("invalid .Service %q: %w", got.Service, err)
+			}
+			service, err := cloud.ParseResourceURL(want.Service)
+			if err != nil {
+				return fmt.Errorf("invalid .Service %q: %w", want.Service, err)
+			}
+			act.Want = append(act.Want, exec.NewExistsEvent(service))
+			act.oldService = oldService
+			act.service = service
+			return nil
+		},
+	},
+	{
+		Path: api.Path{}.Pointer().Field("ProxyHeader"),
+		Set: func(act *targetSslProxyUpdateAction, _, want *compute.TargetSslProxy) error {
+			act.proxyHeader = &want.ProxyHeader
+			return nil
+		},
+	},
+	{
+		Path: api.Path{}.Pointer().Field("SslCertificates"),
+		Set: func(act *targetSslProxyUpdateAction, _, want *compute.TargetSslProxy) error {
+			act.sslCertificates = want.SslCertificates
+			if act.sslCertificates == nil {
+				// Clear the certificates.
+				act.sslCertificates = []string{}
+			}
+			return nil
+		},
+	},
+	{
+		Path: api.Path{}.Pointer().Field("CertificateMap"),
+		Set: func(act *targetSslProxyUpdateAction, _, want *compute.TargetSslProxy) error {
+			act.certificateMap = &want.CertificateMap
+			return nil
+		},
+	},
+	{
+		Path: api.Path{}.Pointer().Field("SslPolicy"),
+		Set: func(act *targetSslProxyUpdateAction, _, want *compute.TargetSslProxy) error {
+			act.sslPolicy = &want.SslPolicy
+			return nil
+		},
+	},
+}
+
+func (n *targetSslProxyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*targetSslProxyNode)
+	if !ok {
+		return nil, nodeErr("invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, nodeErr("Diff: %w", err)
+	}
+
+	r, err := n.EffectiveDiffPolicy(diffPolicy).Apply(diff)
+	if err != nil {
+		return nil, nodeErr("%w", err)
+	}
+	return setters.PlanDetails("TargetSslProxy", n.ID().Key.Type(), r), nil
+}
+
+func (n *targetSslProxyNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.TargetSslProxy, alpha.TargetSslProxy, beta.TargetSslProxy](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+	return nil, nodeErr("invalid plan op %s", op)
+}
+
+func (n *targetSslProxyNode) updateActions(ngot rnode.Node) ([]exec.Action, error) {
+	details := n.Plan().Details()
+	if details == nil {
+		return nil, nodeErr("updateActions: node %s has not been planned", n.ID())
+	}
+	got, ok := ngot.(*targetSslProxyNode)
+	if !ok {
+		return nil, nodeErr("updateActions: node %s has invalid type %T", n.ID(), ngot)
+	}
+
+	gotRes, err := got.resource.ToGA()
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+	wantRes, err := n.resource.ToGA()
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+
+	act := &targetSslProxyUpdateAction{id: n.ID(), version: n.Version()}
+	act.changes, err = setters.Apply(n.ID().Key.Type(), details.Diff, act, gotRes, wantRes)
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+
+	return []exec.Action{
+		// Action: Signal resource exists.
+		exec.NewExistsAction(n.ID()),
+		// Action: Do the updates.
+		act,
+	}, nil
+}
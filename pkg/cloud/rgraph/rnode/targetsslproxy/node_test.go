@@ -0,0 +1,136 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetsslproxy
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDiff(t *testing.T) {
+	id := ID("proj-1", meta.GlobalKey("sp"))
+
+	makeNode := func(f func(*compute.TargetSslProxy)) rnode.Node {
+		t.Helper()
+		m := NewMutableTargetSslProxy(id.ProjectID, id.Key)
+		if err := m.Access(func(x *compute.TargetSslProxy) {
+			x.Name = "sp"
+			x.Service = backendservice.ID("proj-1", meta.GlobalKey("bs")).SelfLink(meta.VersionGA)
+			x.SslCertificates = []string{"cert-1"}
+			if f != nil {
+				f(x)
+			}
+		}); err != nil {
+			t.Fatalf("Access() = %v, want nil", err)
+		}
+		return nodetest.Build(t, m, NewBuilderWithResource)
+	}
+
+	got := makeNode(nil)
+
+	for _, tc := range []struct {
+		name   string
+		f      func(*compute.TargetSslProxy)
+		wantOp rnode.Operation
+		check  func(*targetSslProxyUpdateAction) bool
+	}{
+		{
+			name:   "no diff",
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "service",
+			f: func(x *compute.TargetSslProxy) {
+				x.Service = backendservice.ID("proj-1", meta.GlobalKey("bs-2")).SelfLink(meta.VersionGA)
+			},
+			wantOp: rnode.OpUpdate,
+			check:  func(act *targetSslProxyUpdateAction) bool { return act.service != nil && act.oldService != nil },
+		},
+		{
+			name: "certificates",
+			f: func(x *compute.TargetSslProxy) {
+				x.SslCertificates = []string{"cert-1", "cert-2"}
+			},
+			wantOp: rnode.OpUpdate,
+			check:  func(act *targetSslProxyUpdateAction) bool { return len(act.sslCertificates) == 2 },
+		},
+		{
+			name: "clear certificates",
+			f: func(x *compute.TargetSslProxy) {
+				x.SslCertificates = nil
+				x.CertificateMap = "//certificatemanager.googleapis.com/projects/proj-1/locations/global/certificateMaps/cm"
+			},
+			wantOp: rnode.OpUpdate,
+			check: func(act *targetSslProxyUpdateAction) bool {
+				return act.sslCertificates != nil && len(act.sslCertificates) == 0 && act.certificateMap != nil
+			},
+		},
+		{
+			name: "ssl policy and proxy header",
+			f: func(x *compute.TargetSslProxy) {
+				x.SslPolicy = "policy"
+				x.ProxyHeader = "PROXY_V1"
+			},
+			wantOp: rnode.OpUpdate,
+			check: func(act *targetSslProxyUpdateAction) bool {
+				return act.sslPolicy != nil && act.proxyHeader != nil && act.service == nil
+			},
+		},
+		{
+			name: "description",
+			f: func(x *compute.TargetSslProxy) {
+				x.Description = "new"
+			},
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := makeNode(tc.f)
+			p, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*p)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			act, ok := actions[1].(*targetSslProxyUpdateAction)
+			if !ok {
+				t.Fatalf("Actions()[1] is %T, want *targetSslProxyUpdateAction", actions[1])
+			}
+			if !tc.check(act) {
+				t.Errorf("action = %+v, does not match the test case", act)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targettcpproxy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+type targetTcpProxyUpdateAction struct {
+	exec.ActionBase
+
+	id *cloud.ResourceID
+	// version of the API used for the calls.
+	version meta.Version
+
+	// service if non-nil will call setBackendService().
+	service *cloud.ResourceID
+	// oldService is the BackendService before the update.
+	oldService *cloud.ResourceID
+	// proxyHeader if non-nil will call setProxyHeader(). The empty string
+	// is the default (NONE).
+	proxyHeader *string
+
+	// changes are human-readable descriptions of the changed fields.
+	changes []string
+}
+
+func (act *targetTcpProxyUpdateAction) Run(ctx context.Context, cl cloud.Cloud) (exec.EventList, error) {
+	key := act.id.Key
+	if key.Type() != meta.Global {
+		return nil, fmt.Errorf("targetTcpProxyUpdateAction Run(%s): invalid key type", act.id)
+	}
+	errf := func(verb string, err error) error {
+		return fmt.Errorf("targetTcpProxyUpdateAction Run(%s): %s: %w", act.id, verb, err)
+	}
+
+	if act.proxyHeader != nil {
+		req := &compute.TargetTcpProxiesSetProxyHeaderRequest{ProxyHeader: *act.proxyHeader}
+		if err := setProxyHeaderFuncs(cl).Do(ctx, act.version, act.id, req); err != nil {
+			return nil, errf("SetProxyHeader", err)
+		}
+	}
+
+	if act.service != nil {
+		req := &compute.TargetTcpProxiesSetBackendServiceRequest{Service: act.service.SelfLink(act.version)}
+		if err := setBackendServiceFuncs(cl).Do(ctx, act.version, act.id, req); err != nil {
+			return nil, errf("SetBackendService", err)
+		}
+	}
+
+	return act.DryRun(), nil
+}
+
+func (act *targetTcpProxyUpdateAction) DryRun() exec.EventList {
+	var events exec.EventList
+	if act.oldService != nil && !act.service.Equal(act.oldService) {
+		events = append(events, exec.NewDropRefEvent(act.id, act.oldService))
+	}
+	return events
+}
+
+func (act *targetTcpProxyUpdateAction) String() string {
+	return fmt.Sprintf("TargetTcpProxyUpdateAction(%s)", act.id)
+}
+
+func (act *targetTcpProxyUpdateAction) ResourceID() *cloud.ResourceID { return act.id }
+
+func (act *targetTcpProxyUpdateAction) Metadata() *exec.ActionMetadata {
+	summary := fmt.Sprintf("Update %s", act.id)
+	if len(act.changes) > 0 {
+		summary += ": " + strings.Join(act.changes, ", ")
+	}
+	return &exec.ActionMetadata{
+		Name:    fmt.Sprintf("TargetTcpProxyUpdateAction(%s)", act.id),
+		Type:    exec.ActionTypeUpdate,
+		Summary: summary,
+	}
+}
+
+func setProxyHeaderFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetTcpProxiesSetProxyHeaderRequest, alpha.TargetTcpProxiesSetProxyHeaderRequest, beta.TargetTcpProxiesSetProxyHeaderRequest] {
+	return &rnode.MethodFuncs[compute.TargetTcpProxiesSetProxyHeaderRequest, alpha.TargetTcpProxiesSetProxyHeaderRequest, beta.TargetTcpProxiesSetProxyHeaderRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetTcpProxiesSetProxyHeaderRequest]{Global: gcp.TargetTcpProxies().SetProxyHeader},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetTcpProxiesSetProxyHeaderRequest]{Global: gcp.AlphaTargetTcpProxies().SetProxyHeader},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetTcpProxiesSetProxyHeaderRequest]{Global: gcp.BetaTargetTcpProxies().SetProxyHeader},
+	}
+}
+
+func setBackendServiceFuncs(gcp cloud.Cloud) *rnode.MethodFuncs[compute.TargetTcpProxiesSetBackendServiceRequest, alpha.TargetTcpProxiesSetBackendServiceRequest, beta.TargetTcpProxiesSetBackendServiceRequest] {
+	return &rnode.MethodFuncs[compute.TargetTcpProxiesSetBackendServiceRequest, alpha.TargetTcpProxiesSetBackendServiceRequest, beta.TargetTcpProxiesSetBackendServiceRequest]{
+		GA:    rnode.UpdateFuncsByScope[compute.TargetTcpProxiesSetBackendServiceRequest]{Global: gcp.TargetTcpProxies().SetBackendService},
+		Alpha: rnode.UpdateFuncsByScope[alpha.TargetTcpProxiesSetBackendServiceRequest]{Global: gcp.AlphaTargetTcpProxies().SetBackendService},
+		Beta:  rnode.UpdateFuncsByScope[beta.TargetTcpProxiesSetBackendServiceRequest]{Global: gcp.BetaTargetTcpProxies().SetBackendService},
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targettcpproxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+)
+
+func TestUpdateAction(t *testing.T) {
+	key := meta.GlobalKey("tp")
+	id := ID("proj", key)
+	bsID := backendservice.ID("proj", meta.GlobalKey("bs"))
+	oldBsID := backendservice.ID("proj", meta.GlobalKey("bs-old"))
+	header := "PROXY_V1"
+
+	for _, ver := range []meta.Version{meta.VersionGA, meta.VersionBeta} {
+		act := &targetTcpProxyUpdateAction{
+			id:          id,
+			version:     ver,
+			service:     bsID,
+			oldService:  oldBsID,
+			proxyHeader: &header,
+		}
+		wantEvents := exec.EventList{exec.NewDropRefEvent(id, oldBsID)}
+
+		mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+		events, err := act.Run(context.Background(), mock)
+		if err != nil {
+			t.Fatalf("Run(%s) = %v, want nil", ver, err)
+		}
+		if !events.Equal(wantEvents) {
+			t.Errorf("Run(%s) = %v, want %v", ver, events, wantEvents)
+		}
+		for _, op := range []string{"SetProxyHeader", "SetBackendService"} {
+			calls := mock.Calls().Matching(cloud.MockCall{Service: "TargetTcpProxies", Version: ver, Operation: op, Key: key})
+			if len(calls) != 1 {
+				t.Errorf("calls to TargetTcpProxies.%s(%s) = %v, want 1", op, ver, mock.Calls())
+			}
+		}
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+	act := &targetTcpProxyUpdateAction{id: ID("proj", meta.RegionalKey("tp", "us-central1")), version: meta.VersionGA, proxyHeader: &header}
+	if _, err := act.Run(context.Background(), mock); err == nil {
+		t.Errorf("Run() with a regional key = nil, want error")
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package targettcpproxy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// ID returns the ResourceID of the TargetTcpProxy.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "targetTcpProxies",
+		APIGroup:  meta.APIGroupCompute,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableTargetTcpProxy = api.MutableResource[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy]
+
+func NewMutableTargetTcpProxy(project string, key *meta.Key) MutableTargetTcpProxy {
+	id := ID(project, key)
+	return api.NewResource[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy](id, &typeTrait{})
+}
+
+type TargetTcpProxy = api.Resource[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy]
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetTcpProxies
+type typeTrait struct {
+	api.BaseTypeTrait[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreationTimestamp"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Id"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Kind"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("Region"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Description"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("ProxyBind"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("ProxyHeader"))
+	dt.Reference(api.Path{}.Pointer().Field("Service"), "backendServices")
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy] {
+	return &rnode.GetFuncs[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy]{
+		GA: rnode.GetFuncsByScope[compute.TargetTcpProxy]{
+			Global:   gcp.TargetTcpProxies().Get,
+			Regional: gcp.RegionTargetTcpProxies().Get,
+		},
+		Alpha: rnode.GetFuncsByScope[alpha.TargetTcpProxy]{
+			Global:   gcp.AlphaTargetTcpProxies().Get,
+			Regional: gcp.AlphaRegionTargetTcpProxies().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.TargetTcpProxy]{
+			Global:   gcp.BetaTargetTcpProxies().Get,
+			Regional: gcp.BetaRegionTargetTcpProxies().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy] {
+	return &rnode.CreateFuncs[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy]{
+		GA: rnode.CreateFuncsByScope[compute.TargetTcpProxy]{
+			Global:   gcp.TargetTcpProxies().Insert,
+			Regional: gcp.RegionTargetTcpProxies().Insert,
+		},
+		Alpha: rnode.CreateFuncsByScope[alpha.TargetTcpProxy]{
+			Global:   gcp.AlphaTargetTcpProxies().Insert,
+			Regional: gcp.AlphaRegionTargetTcpProxies().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.TargetTcpProxy]{
+			Global:   gcp.BetaTargetTcpProxies().Insert,
+			Regional: gcp.BetaRegionTargetTcpProxies().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy] {
+	return nil // Does not support generic Update.
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy] {
+	return &rnode.DeleteFuncs[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy]{
+		GA: rnode.DeleteFuncsByScope[compute.TargetTcpProxy]{
+			Global:   gcp.TargetTcpProxies().Delete,
+			Regional: gcp.RegionTargetTcpProxies().Delete,
+		},
+		Alpha: rnode.DeleteFuncsByScope[alpha.TargetTcpProxy]{
+			Global:   gcp.AlphaTargetTcpProxies().Delete,
+			Regional: gcp.AlphaRegionTargetTcpProxies().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.TargetTcpProxy]{
+			Global:   gcp.BetaTargetTcpProxies().Delete,
+			Regional: gcp.BetaRegionTargetTcpProxies().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r TargetTcpProxy) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource TargetTcpProxy
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(TargetTcpProxy)
+	if !ok {
+		return fmt.Errorf("cannot set TargetTcpProxy from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy](ctx, gcp, "TargetTcpProxy", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	return rnode.OutRefsFromTraits[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy](b.resource, &typeTrait{})
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("TargetTcpProxy %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &targetTcpProxyNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+type targetTcpProxyNode struct {
+	rnode.NodeBase
+	resource TargetTcpProxy
+}
+
+var _ rnode.Node = (*targetTcpProxyNode)(nil)
+
+func (n *targetTcpProxyNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *targetTcpProxyNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
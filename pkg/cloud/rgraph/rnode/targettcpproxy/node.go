@@ -0,0 +1,146 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targettcpproxy
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	beta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+func nodeErr(s string, args ...any) error { return fmt.Errorf("targetTcpProxy: "+s, args...) }
+
+// diffPolicies are the default DiffPolicy for the proxy by scope. Regional
+// proxies do not have any update methods.
+var diffPolicies = map[meta.KeyType]*rnode.DiffPolicy{
+	meta.Global:   rnode.NewDiffPolicy(rnode.FieldRecreate).SetFields(rnode.FieldUpdate, "Service", "ProxyHeader"),
+	meta.Regional: rnode.NewDiffPolicy(rnode.FieldRecreate),
+}
+
+// setters for the fields of global proxies that can be changed in place.
+// Regional proxies do not have any update methods; all other fields (e.g.
+// .ProxyBind, .Description) require the resource to be recreated.
+var setters = rnode.FieldSetters[*targetTcpProxyUpdateAction, *compute.TargetTcpProxy]{
+	{
+		// setBackendService()
+		Path:  api.Path{}.Pointer().Field("Service"),
+		Scope: meta.Global,
+		Set: func(act *targetTcpProxyUpdateAction, got, want *compute.TargetTcpProxy) error {
+			oldService, err := cloud.ParseResourceURL(got.Service)
+			if err != nil {
+				return fmt.Errorf("invalid .Service %q: %w", got.Service, err)
+			}
+			service, err := cloud.ParseResourceURL(want.Service)
+			if err != nil {
+				return fmt.Errorf("invalid .Service %q: %w", want.Service, err)
+			}
+			act.Want = append(act.Want, exec.NewExistsEvent(service))
+			act.oldService = oldService
+			act.service = service
+			return nil
+		},
+	},
+	{
+		// setProxyHeader()
+		Path:  api.Path{}.Pointer().Field("ProxyHeader"),
+		Scope: meta.Global,
+		Set: func(act *targetTcpProxyUpdateAction, _, want *compute.TargetTcpProxy) error {
+			act.proxyHeader = &want.ProxyHeader
+			return nil
+		},
+	},
+}
+
+func (n *targetTcpProxyNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*targetTcpProxyNode)
+	if !ok {
+		return nil, nodeErr("invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, nodeErr("Diff: %w", err)
+	}
+
+	r, err := n.EffectiveDiffPolicy(diffPolicies[n.ID().Key.Type()]).Apply(diff)
+	if err != nil {
+		return nil, nodeErr("%w", err)
+	}
+	return setters.PlanDetails("TargetTcpProxy", n.ID().Key.Type(), r), nil
+}
+
+func (n *targetTcpProxyNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[compute.TargetTcpProxy, alpha.TargetTcpProxy, beta.TargetTcpProxy](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return n.updateActions(got)
+	}
+	return nil, nodeErr("invalid plan op %s", op)
+}
+
+func (n *targetTcpProxyNode) updateActions(ngot rnode.Node) ([]exec.Action, error) {
+	details := n.Plan().Details()
+	if details == nil {
+		return nil, nodeErr("updateActions: node %s has not been planned", n.ID())
+	}
+	got, ok := ngot.(*targetTcpProxyNode)
+	if !ok {
+		return nil, nodeErr("updateActions: node %s has invalid type %T", n.ID(), ngot)
+	}
+
+	gotRes, err := got.resource.ToGA()
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+	wantRes, err := n.resource.ToGA()
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+
+	act := &targetTcpProxyUpdateAction{id: n.ID(), version: n.Version()}
+	act.changes, err = setters.Apply(n.ID().Key.Type(), details.Diff, act, gotRes, wantRes)
+	if err != nil {
+		return nil, nodeErr("updateActions %s: %w", n.ID(), err)
+	}
+
+	return []exec.Action{
+		// Action: Signal resource exists.
+		exec.NewExistsAction(n.ID()),
+		// Action: Do the updates.
+		act,
+	}, nil
+}
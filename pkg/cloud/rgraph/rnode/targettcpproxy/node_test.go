@@ -0,0 +1,128 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targettcpproxy
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDiff(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		key          *meta.Key
+		f            func(*compute.TargetTcpProxy)
+		wantOp       rnode.Operation
+		wantDropRefs bool
+	}{
+		{
+			name:   "no diff",
+			key:    meta.GlobalKey("tp"),
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name: "service",
+			key:  meta.GlobalKey("tp"),
+			f: func(x *compute.TargetTcpProxy) {
+				x.Service = backendservice.ID("proj-1", meta.GlobalKey("bs-2")).SelfLink(meta.VersionGA)
+			},
+			wantOp:       rnode.OpUpdate,
+			wantDropRefs: true,
+		},
+		{
+			name: "proxy header",
+			key:  meta.GlobalKey("tp"),
+			f: func(x *compute.TargetTcpProxy) {
+				x.ProxyHeader = "PROXY_V1"
+			},
+			wantOp: rnode.OpUpdate,
+		},
+		{
+			name: "description",
+			key:  meta.GlobalKey("tp"),
+			f: func(x *compute.TargetTcpProxy) {
+				x.Description = "new"
+			},
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name: "regional service",
+			key:  meta.RegionalKey("tp", "us-central1"),
+			f: func(x *compute.TargetTcpProxy) {
+				x.Service = backendservice.ID("proj-1", meta.RegionalKey("bs-2", "us-central1")).SelfLink(meta.VersionGA)
+			},
+			wantOp: rnode.OpRecreate,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			id := ID("proj-1", tc.key)
+			bsKey := meta.GlobalKey("bs")
+			if tc.key.Type() == meta.Regional {
+				bsKey = meta.RegionalKey("bs", tc.key.Region)
+			}
+			makeNode := func(f func(*compute.TargetTcpProxy)) rnode.Node {
+				t.Helper()
+				m := NewMutableTargetTcpProxy(id.ProjectID, id.Key)
+				if err := m.Access(func(x *compute.TargetTcpProxy) {
+					x.Name = "tp"
+					x.Service = backendservice.ID("proj-1", bsKey).SelfLink(meta.VersionGA)
+					if f != nil {
+						f(x)
+					}
+				}); err != nil {
+					t.Fatalf("Access() = %v, want nil", err)
+				}
+				return nodetest.Build(t, m, NewBuilderWithResource)
+			}
+
+			got := makeNode(nil)
+			want := makeNode(tc.f)
+			p, err := want.Diff(got)
+			if err != nil {
+				t.Fatalf("Diff() = %v, want nil", err)
+			}
+			if p.Operation != tc.wantOp {
+				t.Fatalf("Diff() = %v, want %v (%s)", p.Operation, tc.wantOp, p.Why)
+			}
+			if p.Operation != rnode.OpUpdate {
+				return
+			}
+
+			want.Plan().Set(*p)
+			actions, err := want.Actions(got)
+			if err != nil {
+				t.Fatalf("Actions() = %v, want nil", err)
+			}
+			if len(actions) != 2 {
+				t.Fatalf("len(Actions()) = %d, want 2", len(actions))
+			}
+			var wantEvents exec.EventList
+			if tc.wantDropRefs {
+				wantEvents = exec.EventList{exec.NewDropRefEvent(id, backendservice.ID("proj-1", bsKey))}
+			}
+			if events := actions[1].DryRun(); !events.Equal(wantEvents) {
+				t.Errorf("DryRun() = %v, want %v", events, wantEvents)
+			}
+		})
+	}
+}
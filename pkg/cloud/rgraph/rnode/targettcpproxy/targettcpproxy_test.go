@@ -0,0 +1,88 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targettcpproxy
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestTargetTcpProxySchema(t *testing.T) {
+	for _, key := range []*meta.Key{meta.GlobalKey("key-1"), meta.RegionalKey("key-1", "us-central1")} {
+		x := NewMutableTargetTcpProxy("proj-1", key)
+		if err := x.CheckSchema(); err != nil {
+			t.Fatalf("CheckSchema() = %v, want nil", err)
+		}
+	}
+}
+
+func TestOutRefs(t *testing.T) {
+	id := ID("proj", meta.GlobalKey("tp"))
+	bsID := backendservice.ID("proj", meta.GlobalKey("bs"))
+
+	for _, tc := range []struct {
+		name string
+		f    func(*compute.TargetTcpProxy)
+
+		wantErr bool
+		want    []rnode.ResourceRef
+	}{
+		{
+			name: "no refs",
+			f:    func(x *compute.TargetTcpProxy) {},
+		},
+		{
+			name: "service",
+			f: func(x *compute.TargetTcpProxy) {
+				x.Service = bsID.SelfLink(meta.VersionGA)
+			},
+			want: []rnode.ResourceRef{
+				{From: id, To: bsID, Path: api.Path{}.Pointer().Field("Service")},
+			},
+		},
+		{
+			name: "garbage service",
+			f: func(x *compute.TargetTcpProxy) {
+				x.Service = "garbage"
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := NewMutableTargetTcpProxy(id.ProjectID, id.Key)
+			mr.Access(tc.f)
+			r, _ := mr.Freeze()
+			b := NewBuilderWithResource(r)
+
+			got, err := b.OutRefs()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("OutRefs() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			} else if gotErr {
+				return
+			}
+			if diff := cmp.Diff(got, tc.want); diff != "" {
+				t.Errorf("OutRefs diff = -got,+want: %s", diff)
+			}
+		})
+	}
+}
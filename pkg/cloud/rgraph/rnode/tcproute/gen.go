@@ -0,0 +1,230 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was generated by "go run ./pkg/cloud/rgraph/rnode/gen". Do not
+// edit directly.
+
+package tcproute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/networkservices/v1"
+	beta "google.golang.org/api/networkservices/v1beta1"
+)
+
+// ID returns the ResourceID of the TcpRoute.
+func ID(project string, key *meta.Key) *cloud.ResourceID {
+	return &cloud.ResourceID{
+		Resource:  "tcpRoutes",
+		APIGroup:  meta.APIGroupNetworkServices,
+		ProjectID: project,
+		Key:       key,
+	}
+}
+
+type MutableTcpRoute = api.MutableResource[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]
+
+func NewMutableTcpRoute(project string, key *meta.Key) MutableTcpRoute {
+	id := ID(project, key)
+	return api.NewResource[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](id, &typeTrait{})
+}
+
+type TcpRoute = api.Resource[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]
+
+// https://cloud.google.com/traffic-director/docs/reference/network-services/rest/v1/projects.locations.tcpRoutes
+type typeTrait struct {
+	api.BaseTypeTrait[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]
+}
+
+func (*typeTrait) FieldTraits(meta.Version) *api.FieldTraits {
+	dt := api.NewFieldTraits()
+	dt.OutputOnly(api.Path{}.Pointer().Field("CreateTime"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
+	dt.OutputOnly(api.Path{}.Pointer().Field("UpdateTime"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Gateways"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Labels"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Meshes"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Matches"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations").AnySliceIndex().Pointer().Field("Weight"))
+	dt.AllowZeroValue(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("OriginalDestination"))
+	dt.Reference(api.Path{}.Pointer().Field("Rules").AnySliceIndex().Pointer().Field("Action").Pointer().Field("Destinations").AnySliceIndex().Pointer().Field("ServiceName"), "backendServices")
+	return dt
+}
+
+type ops struct{}
+
+// ops implements GenericOps.
+var _ rnode.GenericOps[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute] = (*ops)(nil)
+
+func (*ops) GetFuncs(gcp cloud.Cloud) *rnode.GetFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute] {
+	return &rnode.GetFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]{
+		GA: rnode.GetFuncsByScope[networkservices.TcpRoute]{
+			Global: gcp.TcpRoutes().Get,
+		},
+		Beta: rnode.GetFuncsByScope[beta.TcpRoute]{
+			Global: gcp.BetaTcpRoutes().Get,
+		},
+	}
+}
+
+func (*ops) CreateFuncs(gcp cloud.Cloud) *rnode.CreateFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute] {
+	return &rnode.CreateFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]{
+		GA: rnode.CreateFuncsByScope[networkservices.TcpRoute]{
+			Global: gcp.TcpRoutes().Insert,
+		},
+		Beta: rnode.CreateFuncsByScope[beta.TcpRoute]{
+			Global: gcp.BetaTcpRoutes().Insert,
+		},
+	}
+}
+
+func (*ops) UpdateFuncs(gcp cloud.Cloud) *rnode.UpdateFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute] {
+	return &rnode.UpdateFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]{
+		GA: rnode.UpdateFuncsByScope[networkservices.TcpRoute]{
+			Global: gcp.TcpRoutes().Patch,
+		},
+		Beta: rnode.UpdateFuncsByScope[beta.TcpRoute]{
+			Global: gcp.BetaTcpRoutes().Patch,
+		},
+		Options: rnode.UpdateFuncsNoFingerprint,
+	}
+}
+
+func (*ops) DeleteFuncs(gcp cloud.Cloud) *rnode.DeleteFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute] {
+	return &rnode.DeleteFuncs[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute]{
+		GA: rnode.DeleteFuncsByScope[networkservices.TcpRoute]{
+			Global: gcp.TcpRoutes().Delete,
+		},
+		Beta: rnode.DeleteFuncsByScope[beta.TcpRoute]{
+			Global: gcp.BetaTcpRoutes().Delete,
+		},
+	}
+}
+
+func NewBuilder(id *cloud.ResourceID) rnode.Builder {
+	b := &builder{}
+	b.Defaults(id)
+	return b
+}
+
+func NewBuilderWithResource(r TcpRoute) rnode.Builder {
+	b := &builder{resource: r}
+	b.Init(r.ResourceID(), rnode.NodeUnknown, rnode.OwnershipUnknown, r)
+	return b
+}
+
+type builder struct {
+	rnode.BuilderBase
+	resource TcpRoute
+}
+
+// builder implements node.Builder.
+var _ rnode.Builder = (*builder)(nil)
+
+func (b *builder) Resource() rnode.UntypedResource { return b.resource }
+
+func (b *builder) SetResource(u rnode.UntypedResource) error {
+	r, ok := u.(TcpRoute)
+	if !ok {
+		return fmt.Errorf("cannot set TcpRoute from untyped resource, %T", u)
+	}
+	b.resource = r
+	return nil
+}
+
+func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
+	return rnode.GenericGet[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](ctx, gcp, "TcpRoute", &ops{}, &typeTrait{}, b)
+}
+
+func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
+	return rnode.OutRefsFromTraits[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](b.resource, &typeTrait{})
+}
+
+func (b *builder) Build() (rnode.Node, error) {
+	if b.State() == rnode.NodeExists && b.resource == nil {
+		return nil, fmt.Errorf("TcpRoute %s resource is nil with state %s", b.ID(), b.State())
+	}
+
+	ret := &tcpRouteNode{resource: b.resource}
+	if err := ret.InitFromBuilder(b); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// diffPolicy is the default DiffPolicy for TcpRoute.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate)
+
+type tcpRouteNode struct {
+	rnode.NodeBase
+	resource TcpRoute
+}
+
+var _ rnode.Node = (*tcpRouteNode)(nil)
+
+func (n *tcpRouteNode) Resource() rnode.UntypedResource { return n.resource }
+
+func (n *tcpRouteNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
+	got, ok := gotNode.(*tcpRouteNode)
+	if !ok {
+		return nil, fmt.Errorf("TcpRouteNode: invalid type to Diff: %T", gotNode)
+	}
+
+	diff, err := got.resource.Diff(n.resource)
+	if err != nil {
+		return nil, fmt.Errorf("TcpRouteNode: Diff %w", err)
+	}
+
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("TcpRoute", diff)
+}
+
+func (n *tcpRouteNode) Actions(got rnode.Node) ([]exec.Action, error) {
+	op := n.Plan().Op()
+
+	switch op {
+	case rnode.OpCreate:
+		return rnode.CreateActions[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](&ops{}, n, n.resource)
+
+	case rnode.OpDelete:
+		return rnode.DeleteActions[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](&ops{}, got, n)
+
+	case rnode.OpNothing:
+		return []exec.Action{exec.NewExistsAction(n.ID())}, nil
+
+	case rnode.OpRecreate:
+		return rnode.RecreateActions[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](&ops{}, got, n, n.resource)
+
+	case rnode.OpUpdate:
+		return rnode.UpdateActions[networkservices.TcpRoute, api.PlaceholderType, beta.TcpRoute](&ops{}, got, n, n.resource)
+	}
+
+	return nil, fmt.Errorf("TcpRouteNode: invalid plan op %s", op)
+}
+
+func (n *tcpRouteNode) Builder() rnode.Builder {
+	b := &builder{resource: n.resource}
+	b.Init(n.ID(), n.State(), n.Ownership(), n.resource)
+	return b
+}
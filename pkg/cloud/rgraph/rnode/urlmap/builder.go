@@ -21,7 +21,6 @@ import (
 	"fmt"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
-	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	alpha "google.golang.org/api/compute/v0.alpha"
 	beta "google.golang.org/api/compute/v0.beta"
@@ -65,28 +64,7 @@ func (b *builder) SyncFromCloud(ctx context.Context, gcp cloud.Cloud) error {
 }
 
 func (b *builder) OutRefs() ([]rnode.ResourceRef, error) {
-	if b.resource == nil {
-		return nil, nil
-	}
-
-	var ret []rnode.ResourceRef
-	obj, _ := b.resource.ToGA()
-	// DefaultService
-	if obj.DefaultService != "" {
-		id, err := cloud.ParseResourceURL(obj.DefaultService)
-		if err != nil {
-			return nil, fmt.Errorf("UrlMapNode DefaultService: %w", err)
-		}
-		ret = append(ret, rnode.ResourceRef{
-			From: b.resource.ResourceID(),
-			Path: api.Path{}.Field("DefaultService"),
-			To:   id,
-		})
-	}
-
-	// TODO: rest of resource.
-
-	return ret, nil
+	return rnode.OutRefsFromTraits[compute.UrlMap, alpha.UrlMap, beta.UrlMap](b.resource, &urlMapTypeTrait{})
 }
 
 func (b *builder) Build() (rnode.Node, error) {
@@ -26,6 +26,11 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+// diffPolicy for UrlMap. There is no update method implemented.
+//
+// TODO: handle set labels with an update operation.
+var diffPolicy = rnode.NewDiffPolicy(rnode.FieldRecreate)
+
 type urlMapNode struct {
 	rnode.NodeBase
 	resource UrlMap
@@ -46,19 +51,7 @@ func (n *urlMapNode) Diff(gotNode rnode.Node) (*rnode.PlanDetails, error) {
 		return nil, fmt.Errorf("UrlMapNode: Diff %w", err)
 	}
 
-	if diff.HasDiff() {
-		// TODO: handle set labels with an update operation.
-		return &rnode.PlanDetails{
-			Operation: rnode.OpRecreate,
-			Why:       "UrlMap needs to be recreated (no update method exists)",
-			Diff:      diff,
-		}, nil
-	}
-
-	return &rnode.PlanDetails{
-		Operation: rnode.OpNothing,
-		Why:       "No diff between got and want",
-	}, nil
+	return n.EffectiveDiffPolicy(diffPolicy).PlanDiff("UrlMap", diff)
 }
 
 func (n *urlMapNode) Actions(got rnode.Node) ([]exec.Action, error) {
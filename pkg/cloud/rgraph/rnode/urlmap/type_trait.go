@@ -39,5 +39,8 @@ func (*urlMapTypeTrait) FieldTraits(meta.Version) *api.FieldTraits {
 	dt.OutputOnly(api.Path{}.Pointer().Field("SelfLink"))
 	dt.System(api.Path{}.Pointer().Field("Fingerprint"))
 
+	// References to other resources.
+	dt.Reference(api.Path{}.Pointer().Field("DefaultService"), "")
+
 	return dt
 }
@@ -50,6 +50,11 @@ type Graph struct {
 	Project string
 	// Options to use by default in the Graph and Nodes.
 	Options GraphOption
+	// NodeDefaults are the NodeOptions applied to Nodes that do not set the
+	// corresponding ownership or state option themselves. For example,
+	// NodeDefaults: External makes all Nodes external unless they are marked
+	// Managed.
+	NodeDefaults NodeOption
 
 	ids idMap
 }
@@ -83,8 +88,10 @@ func (m idMap) legacySelfLink(name string) string {
 // Clone a copy of the Graph.
 func (g *Graph) Clone() *Graph {
 	return &Graph{
-		Nodes:   append([]Node{}, g.Nodes...),
-		Project: g.Project,
+		Nodes:        append([]Node{}, g.Nodes...),
+		Project:      g.Project,
+		Options:      g.Options,
+		NodeDefaults: g.NodeDefaults,
 	}
 }
 
@@ -130,6 +137,9 @@ type Node struct {
 	Zone string
 	// Project will override the Project in Graph.
 	Project string
+
+	// fields is the JSON encoded resource from a Fixture.
+	fields []byte
 }
 
 // Ref to another Node.
@@ -138,7 +148,9 @@ type Ref struct {
 	// are available.
 	Field string
 	// To is the name of the node reference. For regional and zonal scopes, this
-	// should be <scope>/<name>.
+	// should be <scope>/<name>. Nodes in a Project other than the Graph
+	// Project are referenced by <project>:<name> (e.g.
+	// "host-project:us-central1/subnet").
 	To string
 }
 
@@ -155,11 +167,28 @@ const (
 	Exists
 	// DoesNotExist state.
 	DoesNotExist
+
+	ownershipOptions = External | Managed
+	stateOptions     = Exists | DoesNotExist
 )
 
+// withDefaults returns the options of the Node with the ownership and state
+// taken from defaults if the Node does not set them.
+func (n *Node) withDefaults(defaults NodeOption) NodeOption {
+	opts := n.Options
+	if opts&ownershipOptions == 0 {
+		opts |= defaults & ownershipOptions
+	}
+	if opts&stateOptions == 0 {
+		opts |= defaults & stateOptions
+	}
+	return opts
+}
+
 func (g *Graph) Builder() *rgraph.Builder {
 	g.ids = idMap{}
 
+	defaultProject := getProject(g, &Node{})
 	for _, n := range g.Nodes {
 		nf := getFactory(n.Name)
 		var name string
@@ -171,12 +200,16 @@ func (g *Graph) Builder() *rgraph.Builder {
 		default:
 			name = n.Name
 		}
-		g.ids[name] = nf.id(g, &n)
-
+		project := getProject(g, &n)
+		if project == defaultProject {
+			g.ids[name] = nf.id(g, &n)
+		}
+		g.ids[project+":"+name] = nf.id(g, &n)
 	}
 
 	b := rgraph.NewBuilder()
 	for _, n := range g.Nodes {
+		n.Options = n.withDefaults(g.NodeDefaults)
 		nf := getFactory(n.Name)
 		nb := nf.builder(g, &n)
 		b.Add(nb)
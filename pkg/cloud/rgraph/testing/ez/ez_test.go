@@ -18,7 +18,21 @@ limitations under the License.
 // concise description by use of naming conventions and default values.
 package ez
 
-import compute "google.golang.org/api/compute/v0.beta"
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instancegroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targettcpproxy"
+	compute "google.golang.org/api/compute/v0.beta"
+)
 
 func Example() {
 	ezg := Graph{
@@ -64,3 +78,90 @@ func Example() {
 	// Build the graph for use in a test.
 	ezg.Builder().MustBuild()
 }
+
+func TestGraphBuilder(t *testing.T) {
+	const (
+		proj   = "svc-project"
+		host   = "host-project"
+		region = "us-central1"
+		zone   = "us-central1-a"
+	)
+	ezg := Graph{
+		Project: proj,
+		// Everything is External unless marked otherwise.
+		NodeDefaults: External,
+		Nodes: []Node{
+			{Name: "net", Project: host},
+			{Name: "subnet", Project: host, Region: region, Refs: []Ref{{Field: "Network", To: host + ":net"}}},
+			{
+				Name:    "fr",
+				Region:  region,
+				Options: Managed,
+				Refs: []Ref{
+					{Field: "Target", To: region + "/ttp"},
+					{Field: "Network", To: host + ":net"},
+					{Field: "Subnetwork", To: host + ":" + region + "/subnet"},
+				},
+			},
+			{Name: "ttp", Region: region, Options: Managed, Refs: []Ref{{Field: "Service", To: region + "/bs"}}},
+			{
+				Name:    "bs",
+				Region:  region,
+				Options: Managed | DoesNotExist,
+			},
+			{Name: "neg", Region: region},
+			{Name: "ig", Zone: zone},
+		},
+	}
+	g := ezg.Builder().MustBuild()
+
+	netID := network.ID(host, meta.GlobalKey("net"))
+	subnetID := subnetwork.ID(host, meta.RegionalKey("subnet", region))
+	frID := forwardingrule.ID(proj, meta.RegionalKey("fr", region))
+	ttpID := targettcpproxy.ID(proj, meta.RegionalKey("ttp", region))
+	bsID := backendservice.ID(proj, meta.RegionalKey("bs", region))
+
+	for _, tc := range []struct {
+		id       *cloud.ResourceID
+		wantOwn  rnode.OwnershipStatus
+		wantSt   rnode.NodeState
+		wantRefs []*cloud.ResourceID
+	}{
+		{id: netID, wantOwn: rnode.OwnershipExternal, wantSt: rnode.NodeExists},
+		{id: subnetID, wantOwn: rnode.OwnershipExternal, wantSt: rnode.NodeExists, wantRefs: []*cloud.ResourceID{netID}},
+		{id: frID, wantOwn: rnode.OwnershipManaged, wantSt: rnode.NodeExists, wantRefs: []*cloud.ResourceID{ttpID, netID, subnetID}},
+		{id: ttpID, wantOwn: rnode.OwnershipManaged, wantSt: rnode.NodeExists, wantRefs: []*cloud.ResourceID{bsID}},
+		{id: bsID, wantOwn: rnode.OwnershipManaged, wantSt: rnode.NodeDoesNotExist},
+		{
+			id:      networkendpointgroup.ID(proj, meta.RegionalKey("neg", region)),
+			wantOwn: rnode.OwnershipExternal,
+			wantSt:  rnode.NodeExists,
+		},
+		{
+			id:      instancegroup.ID(proj, meta.ZonalKey("ig", zone)),
+			wantOwn: rnode.OwnershipExternal,
+			wantSt:  rnode.NodeExists,
+		},
+	} {
+		n := g.Get(tc.id)
+		if n == nil {
+			t.Errorf("g.Get(%v) = nil, want node", tc.id)
+			continue
+		}
+		if n.Ownership() != tc.wantOwn || n.State() != tc.wantSt {
+			t.Errorf("node %v: ownership, state = %v, %v; want %v, %v", tc.id, n.Ownership(), n.State(), tc.wantOwn, tc.wantSt)
+		}
+		refs := map[string]bool{}
+		for _, ref := range n.OutRefs() {
+			refs[ref.To.String()] = true
+		}
+		for _, id := range tc.wantRefs {
+			if !refs[id.String()] {
+				t.Errorf("node %v: OutRefs() = %v, want ref to %v", tc.id, n.OutRefs(), id)
+			}
+		}
+		if len(refs) != len(tc.wantRefs) {
+			t.Errorf("node %v: len(OutRefs()) = %d, want %d", tc.id, len(refs), len(tc.wantRefs))
+		}
+	}
+}
@@ -25,10 +25,17 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/fake"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/firewall"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/instancegroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpsproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targetsslproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targettcpproxy"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/tcproute"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
 	"google.golang.org/api/compute/v1"
@@ -40,15 +47,33 @@ var (
 		addressFactory{},
 		backendServiceFactory{},
 		fakeFactory{},
+		firewallFactory{},
 		forwardingRuleFactory{},
 		healthCheckFactory{},
+		instanceGroupFactory{},
 		negFactory{},
+		networkFactory{},
+		subnetworkFactory{},
+		targetSslProxyFactory{},
+		targetTcpProxyFactory{},
+		// targetHttpsProxyFactory must come before targetHttpProxyFactory
+		// as "thps" has "thp" as a prefix.
+		targetHttpsProxyFactory{},
 		targetHttpProxyFactory{},
 		urlMapFactory{},
 		tcpRouteFactory{},
 	}
 )
 
+func hasFactory(name string) bool {
+	for _, nf := range allNodeFactories {
+		if nf.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
 func getFactory(name string) nodeFactory {
 	for _, nf := range allNodeFactories {
 		if nf.match(name) {
@@ -116,6 +141,7 @@ func (f addressFactory) builder(g *Graph, n *Node) rnode.Builder {
 	if b.State() == rnode.NodeExists {
 		ma := address.NewMutableAddress(id.ProjectID, id.Key)
 		err := ma.Access(func(x *compute.Address) {
+			applyFields(n, x)
 			if n.SetupFunc != nil {
 				sf, ok := n.SetupFunc.(func(x *compute.Address))
 				if !ok {
@@ -176,6 +202,7 @@ func (f backendServiceFactory) builder(g *Graph, n *Node) rnode.Builder {
 					panicf("invalid Ref Field: %q (must be one of [Backends.Group, HealthChecks])", ref.Field)
 				}
 			}
+			applyFields(n, x)
 			if n.SetupFunc != nil {
 				sf, ok := n.SetupFunc.(func(x *compute.BackendService))
 				if !ok {
@@ -251,11 +278,18 @@ func (f forwardingRuleFactory) builder(g *Graph, n *Node) rnode.Builder {
 					x.IPAddress = g.ids.selfLink(ref.To)
 				case "Target":
 					x.Target = g.ids.selfLink(ref.To)
+				case "BackendService":
+					x.BackendService = g.ids.selfLink(ref.To)
+				case "Network":
+					x.Network = g.ids.selfLink(ref.To)
+				case "Subnetwork":
+					x.Subnetwork = g.ids.selfLink(ref.To)
 				default:
-					panicf("invalid Ref Field: %q (must be one of [IPAddress,Target])", ref.Field)
+					panicf("invalid Ref Field: %q (must be one of [IPAddress, Target, BackendService, Network, Subnetwork])", ref.Field)
 				}
 			}
 
+			applyFields(n, x)
 			if n.SetupFunc != nil {
 				sf, ok := n.SetupFunc.(func(x *compute.ForwardingRule))
 				if !ok {
@@ -303,6 +337,7 @@ func (f healthCheckFactory) builder(g *Graph, n *Node) rnode.Builder {
 	if b.State() == rnode.NodeExists {
 		ma := healthcheck.NewMutableHealthCheck(id.ProjectID, id.Key)
 		err := ma.Access(func(x *compute.HealthCheck) {
+			applyFields(n, x)
 			if n.SetupFunc != nil {
 				sf, ok := n.SetupFunc.(func(x *compute.HealthCheck))
 				if !ok {
@@ -332,6 +367,10 @@ func (negFactory) match(name string) bool { return strings.HasPrefix(name, "neg"
 
 func (negFactory) id(g *Graph, n *Node) *cloud.ResourceID {
 	switch {
+	case n.Region == "" && n.Zone == "":
+		return networkendpointgroup.ID(getProject(g, n), meta.GlobalKey(n.Name))
+	case n.Region != "":
+		return networkendpointgroup.ID(getProject(g, n), meta.RegionalKey(n.Name, n.Region))
 	case n.Zone != "":
 		return networkendpointgroup.ID(getProject(g, n), meta.ZonalKey(n.Name, n.Zone))
 	default:
@@ -348,6 +387,23 @@ func (f negFactory) builder(g *Graph, n *Node) rnode.Builder {
 	if b.State() == rnode.NodeExists {
 		ma := networkendpointgroup.NewMutableNetworkEndpointGroup(id.ProjectID, id.Key)
 		err := ma.Access(func(x *compute.NetworkEndpointGroup) {
+			// The default endpoint type is only valid for zonal NEGs. Use
+			// one that is valid for both of the other scopes.
+			if id.Key.Type() != meta.Zonal {
+				x.NetworkEndpointType = "INTERNET_FQDN_PORT"
+			}
+			for _, ref := range n.Refs {
+				switch ref.Field {
+				case "Network":
+					x.Network = g.ids.selfLink(ref.To)
+				case "Subnetwork":
+					x.Subnetwork = g.ids.selfLink(ref.To)
+				default:
+					panicf("invalid Ref Field: %q (must be one of [Network, Subnetwork])", ref.Field)
+				}
+			}
+
+			applyFields(n, x)
 			if n.SetupFunc != nil {
 				sf, ok := n.SetupFunc.(func(x *compute.NetworkEndpointGroup))
 				if !ok {
@@ -404,6 +460,7 @@ func (f targetHttpProxyFactory) builder(g *Graph, n *Node) rnode.Builder {
 				}
 			}
 
+			applyFields(n, x)
 			if n.SetupFunc != nil {
 				sf, ok := n.SetupFunc.(func(x *compute.TargetHttpProxy))
 				if !ok {
@@ -427,6 +484,63 @@ func (f targetHttpProxyFactory) builder(g *Graph, n *Node) rnode.Builder {
 	return b
 }
 
+type targetHttpsProxyFactory struct{}
+
+func (targetHttpsProxyFactory) match(name string) bool { return strings.HasPrefix(name, "thps") }
+
+func (targetHttpsProxyFactory) id(g *Graph, n *Node) *cloud.ResourceID {
+	switch {
+	case n.Region == "" && n.Zone == "":
+		return targethttpsproxy.ID(getProject(g, n), meta.GlobalKey(n.Name))
+	case n.Region != "":
+		return targethttpsproxy.ID(getProject(g, n), meta.RegionalKey(n.Name, n.Region))
+	default:
+		panicf("invalid id: %+v", n)
+	}
+	panic("not reached")
+}
+
+func (f targetHttpsProxyFactory) builder(g *Graph, n *Node) rnode.Builder {
+	id := f.id(g, n)
+	b := targethttpsproxy.NewBuilder(id)
+	setCommonOptions(n, b)
+
+	if b.State() == rnode.NodeExists {
+		ma := targethttpsproxy.NewMutableTargetHttpsProxy(id.ProjectID, id.Key)
+		err := ma.Access(func(x *compute.TargetHttpsProxy) {
+			for _, ref := range n.Refs {
+				switch ref.Field {
+				case "UrlMap":
+					x.UrlMap = g.ids.selfLink(ref.To)
+				default:
+					panicf("invalid Ref Field: %q (must be one of [UrlMap])", ref.Field)
+				}
+			}
+
+			applyFields(n, x)
+			if n.SetupFunc != nil {
+				sf, ok := n.SetupFunc.(func(x *compute.TargetHttpsProxy))
+				if !ok {
+					panicf("invalid type for SetupFunc: %T", n.SetupFunc)
+				}
+				sf(x)
+			}
+		})
+		if g.Options&PanicOnAccessErr != 0 && err != nil {
+			panicf("targetHttpsProxyFactory %s: Access: %v", id, err)
+		}
+		r, err := ma.Freeze()
+		if err != nil {
+			panic(err)
+		}
+		err = b.SetResource(r)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
 type urlMapFactory struct{}
 
 func (urlMapFactory) match(name string) bool { return strings.HasPrefix(name, "um") }
@@ -460,6 +574,7 @@ func (f urlMapFactory) builder(g *Graph, n *Node) rnode.Builder {
 				}
 			}
 
+			applyFields(n, x)
 			if n.SetupFunc != nil {
 				sf, ok := n.SetupFunc.(func(x *compute.UrlMap))
 				if !ok {
@@ -524,6 +639,7 @@ func (f tcpRouteFactory) builder(g *Graph, n *Node) rnode.Builder {
 				}
 			}
 
+			applyFields(n, x)
 			if n.SetupFunc != nil {
 				sf, ok := n.SetupFunc.(func(x *networkservices.TcpRoute))
 				if !ok {
@@ -546,3 +662,319 @@ func (f tcpRouteFactory) builder(g *Graph, n *Node) rnode.Builder {
 	}
 	return b
 }
+
+type firewallFactory struct{}
+
+func (firewallFactory) match(name string) bool { return strings.HasPrefix(name, "fw") }
+
+func (firewallFactory) id(g *Graph, n *Node) *cloud.ResourceID {
+	switch {
+	case n.Region == "" && n.Zone == "":
+		return firewall.ID(getProject(g, n), meta.GlobalKey(n.Name))
+	default:
+		panicf("invalid id: %+v", n)
+	}
+	panic("not reached")
+}
+
+func (f firewallFactory) builder(g *Graph, n *Node) rnode.Builder {
+	id := f.id(g, n)
+	b := firewall.NewBuilder(id)
+	setCommonOptions(n, b)
+
+	if b.State() == rnode.NodeExists {
+		ma := firewall.NewMutableFirewall(id.ProjectID, id.Key)
+		err := ma.Access(func(x *compute.Firewall) {
+			for _, ref := range n.Refs {
+				switch ref.Field {
+				case "Network":
+					x.Network = g.ids.selfLink(ref.To)
+				default:
+					panicf("invalid Ref Field: %q (must be one of [Network])", ref.Field)
+				}
+			}
+
+			applyFields(n, x)
+			if n.SetupFunc != nil {
+				sf, ok := n.SetupFunc.(func(x *compute.Firewall))
+				if !ok {
+					panicf("invalid type for SetupFunc: %T", n.SetupFunc)
+				}
+				sf(x)
+			}
+		})
+		if g.Options&PanicOnAccessErr != 0 && err != nil {
+			panicf("firewallFactory %s: Access: %v", id, err)
+		}
+		r, err := ma.Freeze()
+		if err != nil {
+			panic(err)
+		}
+		err = b.SetResource(r)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+type instanceGroupFactory struct{}
+
+func (instanceGroupFactory) match(name string) bool { return strings.HasPrefix(name, "ig") }
+
+func (instanceGroupFactory) id(g *Graph, n *Node) *cloud.ResourceID {
+	switch {
+	case n.Zone != "":
+		return instancegroup.ID(getProject(g, n), meta.ZonalKey(n.Name, n.Zone))
+	default:
+		panicf("invalid id: %+v", n)
+	}
+	panic("not reached")
+}
+
+func (f instanceGroupFactory) builder(g *Graph, n *Node) rnode.Builder {
+	id := f.id(g, n)
+	b := instancegroup.NewBuilder(id)
+	setCommonOptions(n, b)
+
+	if b.State() == rnode.NodeExists {
+		ma := instancegroup.NewMutableInstanceGroup(id.ProjectID, id.Key)
+		err := ma.Access(func(x *compute.InstanceGroup) {
+			// .Network and .Subnetwork are [Output Only] so there are no
+			// Refs for InstanceGroups.
+			applyFields(n, x)
+			if n.SetupFunc != nil {
+				sf, ok := n.SetupFunc.(func(x *compute.InstanceGroup))
+				if !ok {
+					panicf("invalid type for SetupFunc: %T", n.SetupFunc)
+				}
+				sf(x)
+			}
+		})
+		if g.Options&PanicOnAccessErr != 0 && err != nil {
+			panicf("instanceGroupFactory %s: Access: %v", id, err)
+		}
+		r, err := ma.Freeze()
+		if err != nil {
+			panic(err)
+		}
+		err = b.SetResource(r)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+type networkFactory struct{}
+
+func (networkFactory) match(name string) bool { return strings.HasPrefix(name, "net") }
+
+func (networkFactory) id(g *Graph, n *Node) *cloud.ResourceID {
+	switch {
+	case n.Region == "" && n.Zone == "":
+		return network.ID(getProject(g, n), meta.GlobalKey(n.Name))
+	default:
+		panicf("invalid id: %+v", n)
+	}
+	panic("not reached")
+}
+
+func (f networkFactory) builder(g *Graph, n *Node) rnode.Builder {
+	id := f.id(g, n)
+	b := network.NewBuilder(id)
+	setCommonOptions(n, b)
+
+	if b.State() == rnode.NodeExists {
+		ma := network.NewMutableNetwork(id.ProjectID, id.Key)
+		err := ma.Access(func(x *compute.Network) {
+			applyFields(n, x)
+			if n.SetupFunc != nil {
+				sf, ok := n.SetupFunc.(func(x *compute.Network))
+				if !ok {
+					panicf("invalid type for SetupFunc: %T", n.SetupFunc)
+				}
+				sf(x)
+			}
+		})
+		if g.Options&PanicOnAccessErr != 0 && err != nil {
+			panicf("networkFactory %s: Access: %v", id, err)
+		}
+		r, err := ma.Freeze()
+		if err != nil {
+			panic(err)
+		}
+		err = b.SetResource(r)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+type subnetworkFactory struct{}
+
+func (subnetworkFactory) match(name string) bool { return strings.HasPrefix(name, "subnet") }
+
+func (subnetworkFactory) id(g *Graph, n *Node) *cloud.ResourceID {
+	switch {
+	case n.Region != "":
+		return subnetwork.ID(getProject(g, n), meta.RegionalKey(n.Name, n.Region))
+	default:
+		panicf("invalid id: %+v", n)
+	}
+	panic("not reached")
+}
+
+func (f subnetworkFactory) builder(g *Graph, n *Node) rnode.Builder {
+	id := f.id(g, n)
+	b := subnetwork.NewBuilder(id)
+	setCommonOptions(n, b)
+
+	if b.State() == rnode.NodeExists {
+		ma := subnetwork.NewMutableSubnetwork(id.ProjectID, id.Key)
+		err := ma.Access(func(x *compute.Subnetwork) {
+			for _, ref := range n.Refs {
+				switch ref.Field {
+				case "Network":
+					x.Network = g.ids.selfLink(ref.To)
+				default:
+					panicf("invalid Ref Field: %q (must be one of [Network])", ref.Field)
+				}
+			}
+
+			applyFields(n, x)
+			if n.SetupFunc != nil {
+				sf, ok := n.SetupFunc.(func(x *compute.Subnetwork))
+				if !ok {
+					panicf("invalid type for SetupFunc: %T", n.SetupFunc)
+				}
+				sf(x)
+			}
+		})
+		if g.Options&PanicOnAccessErr != 0 && err != nil {
+			panicf("subnetworkFactory %s: Access: %v", id, err)
+		}
+		r, err := ma.Freeze()
+		if err != nil {
+			panic(err)
+		}
+		err = b.SetResource(r)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+type targetSslProxyFactory struct{}
+
+func (targetSslProxyFactory) match(name string) bool { return strings.HasPrefix(name, "tsp") }
+
+func (targetSslProxyFactory) id(g *Graph, n *Node) *cloud.ResourceID {
+	switch {
+	case n.Region == "" && n.Zone == "":
+		return targetsslproxy.ID(getProject(g, n), meta.GlobalKey(n.Name))
+	default:
+		panicf("invalid id: %+v", n)
+	}
+	panic("not reached")
+}
+
+func (f targetSslProxyFactory) builder(g *Graph, n *Node) rnode.Builder {
+	id := f.id(g, n)
+	b := targetsslproxy.NewBuilder(id)
+	setCommonOptions(n, b)
+
+	if b.State() == rnode.NodeExists {
+		ma := targetsslproxy.NewMutableTargetSslProxy(id.ProjectID, id.Key)
+		err := ma.Access(func(x *compute.TargetSslProxy) {
+			for _, ref := range n.Refs {
+				switch ref.Field {
+				case "Service":
+					x.Service = g.ids.selfLink(ref.To)
+				default:
+					panicf("invalid Ref Field: %q (must be one of [Service])", ref.Field)
+				}
+			}
+
+			applyFields(n, x)
+			if n.SetupFunc != nil {
+				sf, ok := n.SetupFunc.(func(x *compute.TargetSslProxy))
+				if !ok {
+					panicf("invalid type for SetupFunc: %T", n.SetupFunc)
+				}
+				sf(x)
+			}
+		})
+		if g.Options&PanicOnAccessErr != 0 && err != nil {
+			panicf("targetSslProxyFactory %s: Access: %v", id, err)
+		}
+		r, err := ma.Freeze()
+		if err != nil {
+			panic(err)
+		}
+		err = b.SetResource(r)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
+
+type targetTcpProxyFactory struct{}
+
+func (targetTcpProxyFactory) match(name string) bool { return strings.HasPrefix(name, "ttp") }
+
+func (targetTcpProxyFactory) id(g *Graph, n *Node) *cloud.ResourceID {
+	switch {
+	case n.Region == "" && n.Zone == "":
+		return targettcpproxy.ID(getProject(g, n), meta.GlobalKey(n.Name))
+	case n.Region != "":
+		return targettcpproxy.ID(getProject(g, n), meta.RegionalKey(n.Name, n.Region))
+	default:
+		panicf("invalid id: %+v", n)
+	}
+	panic("not reached")
+}
+
+func (f targetTcpProxyFactory) builder(g *Graph, n *Node) rnode.Builder {
+	id := f.id(g, n)
+	b := targettcpproxy.NewBuilder(id)
+	setCommonOptions(n, b)
+
+	if b.State() == rnode.NodeExists {
+		ma := targettcpproxy.NewMutableTargetTcpProxy(id.ProjectID, id.Key)
+		err := ma.Access(func(x *compute.TargetTcpProxy) {
+			for _, ref := range n.Refs {
+				switch ref.Field {
+				case "Service":
+					x.Service = g.ids.selfLink(ref.To)
+				default:
+					panicf("invalid Ref Field: %q (must be one of [Service])", ref.Field)
+				}
+			}
+
+			applyFields(n, x)
+			if n.SetupFunc != nil {
+				sf, ok := n.SetupFunc.(func(x *compute.TargetTcpProxy))
+				if !ok {
+					panicf("invalid type for SetupFunc: %T", n.SetupFunc)
+				}
+				sf(x)
+			}
+		})
+		if g.Options&PanicOnAccessErr != 0 && err != nil {
+			panicf("targetTcpProxyFactory %s: Access: %v", id, err)
+		}
+		r, err := ma.Freeze()
+		if err != nil {
+			panic(err)
+		}
+		err = b.SetResource(r)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return b
+}
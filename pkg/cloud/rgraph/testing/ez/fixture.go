@@ -0,0 +1,222 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ez
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"gopkg.in/yaml.v2"
+)
+
+// Fixture is a serializable description of a test topology: the Graph to
+// plan and the resources that exist in the Cloud before the test. Fixtures
+// are written in YAML or JSON. Example:
+//
+//	project: my-project
+//	nodes:
+//	- name: fr
+//	  refs: [{field: IPAddress, to: addr}, {field: Target, to: thp}]
+//	  resource: {loadBalancingScheme: EXTERNAL_MANAGED}
+//	- name: addr
+//	  options: [External]
+//	# ...
+//	cloud:
+//	- name: addr
+type Fixture struct {
+	// Project to use by default if not specified in the Node.
+	Project string `yaml:"project"`
+	// Nodes of the Graph.
+	Nodes []FixtureNode `yaml:"nodes"`
+	// Cloud are the resources that exist before the test. See Seed().
+	Cloud []FixtureNode `yaml:"cloud"`
+}
+
+// FixtureNode is the serialized form of a Node.
+type FixtureNode struct {
+	Name    string `yaml:"name"`
+	Region  string `yaml:"region"`
+	Zone    string `yaml:"zone"`
+	Project string `yaml:"project"`
+	Refs    []Ref  `yaml:"refs"`
+	// Options by name, e.g. [External, DoesNotExist].
+	Options []string `yaml:"options"`
+	// Resource fields to set, using the JSON names from the API (e.g.
+	// "description"). These are applied after the Refs and before the
+	// SetupFunc. Note: 64-bit integers are encoded as strings in the API.
+	Resource map[string]any `yaml:"resource"`
+}
+
+var nodeOptionNames = map[string]NodeOption{
+	"External":     External,
+	"Managed":      Managed,
+	"Exists":       Exists,
+	"DoesNotExist": DoesNotExist,
+}
+
+// LoadFixture from the YAML or JSON file at path.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("LoadFixture: %w", err)
+	}
+	f, err := ParseFixture(data)
+	if err != nil {
+		return nil, fmt.Errorf("LoadFixture %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// ParseFixture from YAML or JSON.
+func ParseFixture(data []byte) (*Fixture, error) {
+	var f Fixture
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("ParseFixture: %w", err)
+	}
+	for _, list := range [][]FixtureNode{f.Nodes, f.Cloud} {
+		for _, fn := range list {
+			if _, err := fn.node(); err != nil {
+				return nil, fmt.Errorf("ParseFixture: %w", err)
+			}
+		}
+	}
+	return &f, nil
+}
+
+// WantGraph returns the Graph for the Nodes of the Fixture.
+func (f *Fixture) WantGraph() *Graph { return f.graph(f.Nodes) }
+
+// CloudGraph returns the Graph for the Cloud resources of the Fixture.
+func (f *Fixture) CloudGraph() *Graph { return f.graph(f.Cloud) }
+
+func (f *Fixture) graph(nodes []FixtureNode) *Graph {
+	g := &Graph{Project: f.Project}
+	for _, fn := range nodes {
+		n, err := fn.node()
+		if err != nil {
+			panicf("Fixture: %v", err)
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+	return g
+}
+
+// Seed creates the Cloud resources of the Fixture using cl. This is used to
+// set up the state of a mock before the test. All references of the Cloud
+// resources must be to other Cloud resources.
+func (f *Fixture) Seed(ctx context.Context, cl cloud.Cloud) error {
+	g, err := f.CloudGraph().Builder().Build()
+	if err != nil {
+		return fmt.Errorf("Seed: %w", err)
+	}
+	var actions []exec.Action
+	for _, n := range g.All() {
+		if n.State() != rnode.NodeExists {
+			continue
+		}
+		n.Plan().Set(rnode.PlanDetails{
+			Operation: rnode.OpCreate,
+			Why:       "Fixture seed",
+		})
+		nodeActions, err := n.Actions(nil)
+		if err != nil {
+			return fmt.Errorf("Seed: %s: %w", n.ID(), err)
+		}
+		actions = append(actions, nodeActions...)
+	}
+	ex, err := exec.NewSerialExecutor(actions)
+	if err != nil {
+		return fmt.Errorf("Seed: %w", err)
+	}
+	result, err := ex.Run(ctx, cl)
+	if err != nil {
+		return fmt.Errorf("Seed: %w", err)
+	}
+	if len(result.Pending) > 0 {
+		return fmt.Errorf("Seed: %d actions could not be run (missing references?)", len(result.Pending))
+	}
+	return nil
+}
+
+func (fn *FixtureNode) node() (Node, error) {
+	n := Node{
+		Name:    fn.Name,
+		Refs:    fn.Refs,
+		Region:  fn.Region,
+		Zone:    fn.Zone,
+		Project: fn.Project,
+	}
+	if !hasFactory(fn.Name) {
+		return Node{}, fmt.Errorf("node %q: no resource type for the name", fn.Name)
+	}
+	for _, name := range fn.Options {
+		opt, ok := nodeOptionNames[name]
+		if !ok {
+			return Node{}, fmt.Errorf("node %q: invalid option %q", fn.Name, name)
+		}
+		n.Options |= opt
+	}
+	if fn.Resource != nil {
+		fields, err := json.Marshal(jsonValue(fn.Resource))
+		if err != nil {
+			return Node{}, fmt.Errorf("node %q: resource: %w", fn.Name, err)
+		}
+		n.fields = fields
+	}
+	return n, nil
+}
+
+// jsonValue converts the map[any]any from YAML to map[string]any so it can
+// be encoded as JSON.
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case map[any]any:
+		m := map[string]any{}
+		for k, val := range v {
+			m[fmt.Sprint(k)] = jsonValue(val)
+		}
+		return m
+	case map[string]any:
+		m := map[string]any{}
+		for k, val := range v {
+			m[k] = jsonValue(val)
+		}
+		return m
+	case []any:
+		var l []any
+		for _, val := range v {
+			l = append(l, jsonValue(val))
+		}
+		return l
+	}
+	return v
+}
+
+// applyFields sets the fields of the Node from the Fixture on x.
+func applyFields(n *Node, x any) {
+	if len(n.fields) == 0 {
+		return
+	}
+	if err := json.Unmarshal(n.fields, x); err != nil {
+		panicf("%s: invalid resource fields for %T: %v", n.Name, x, err)
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ez
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+	"google.golang.org/api/compute/v1"
+)
+
+const testFixtureYAML = `
+project: proj-1
+nodes:
+- name: addr
+  options: [External]
+- name: fr
+  refs: [{field: IPAddress, to: addr}, {field: Target, to: thp}]
+  resource:
+    loadBalancingScheme: EXTERNAL_MANAGED
+    portRange: "80"
+- name: thp
+  refs: [{field: UrlMap, to: um}]
+- name: um
+  refs: [{field: DefaultService, to: bs}]
+- name: bs
+  options: [DoesNotExist]
+cloud:
+- name: addr
+  resource: {description: "seeded"}
+- name: hc
+  region: us-central1
+`
+
+const testFixtureJSON = `{
+  "project": "proj-1",
+  "nodes": [{"name": "hc", "resource": {"checkIntervalSec": 10}}]
+}`
+
+func TestFixture(t *testing.T) {
+	ctx := context.Background()
+
+	f, err := ParseFixture([]byte(testFixtureYAML))
+	if err != nil {
+		t.Fatalf("ParseFixture() = %v, want nil", err)
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj-1"})
+	if err := f.Seed(ctx, mock); err != nil {
+		t.Fatalf("Seed() = %v, want nil", err)
+	}
+	addr, err := mock.GlobalAddresses().Get(ctx, meta.GlobalKey("addr"))
+	if err != nil {
+		t.Fatalf("GlobalAddresses().Get() = %v, want nil", err)
+	}
+	if addr.Description != "seeded" {
+		t.Errorf("addr.Description = %q, want %q", addr.Description, "seeded")
+	}
+	if _, err := mock.RegionHealthChecks().Get(ctx, meta.RegionalKey("hc", "us-central1")); err != nil {
+		t.Errorf("RegionHealthChecks().Get() = %v, want nil", err)
+	}
+
+	g := f.WantGraph().Builder().MustBuild()
+	addrNode := g.Get(address.ID("proj-1", meta.GlobalKey("addr")))
+	if addrNode == nil || addrNode.Ownership() != rnode.OwnershipExternal {
+		t.Errorf("addr node = %v, want External node", addrNode)
+	}
+	frNode := g.Get(forwardingrule.ID("proj-1", meta.GlobalKey("fr")))
+	if frNode == nil {
+		t.Fatalf("fr node is not in the graph")
+	}
+	fr, err := frNode.Resource().(forwardingrule.ForwardingRule).ToGA()
+	if err != nil {
+		t.Fatalf("ToGA() = %v, want nil", err)
+	}
+	if fr.LoadBalancingScheme != "EXTERNAL_MANAGED" || fr.PortRange != "80" || fr.IPAddress == "" {
+		t.Errorf("fr = %+v, want LoadBalancingScheme, PortRange and IPAddress set", fr)
+	}
+
+	// JSON fixtures are loaded from a file.
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(path, []byte(testFixtureJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err = LoadFixture(path)
+	if err != nil {
+		t.Fatalf("LoadFixture() = %v, want nil", err)
+	}
+	var hc compute.HealthCheck
+	n := f.WantGraph().Nodes[0]
+	applyFields(&n, &hc)
+	if hc.CheckIntervalSec != 10 {
+		t.Errorf("hc.CheckIntervalSec = %d, want 10", hc.CheckIntervalSec)
+	}
+}
+
+func TestFixtureErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data string
+	}{
+		{name: "invalid option", data: "nodes: [{name: addr, options: [Foo]}]"},
+		{name: "unknown resource type", data: "nodes: [{name: foo}]"},
+		{name: "unknown field", data: "nodes: [{name: addr, color: red}]"},
+		{name: "invalid resource", data: "nodes: [{name: addr, resource: [1, 2]}]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseFixture([]byte(tc.data)); err == nil {
+				t.Errorf("ParseFixture() = nil, want error")
+			}
+		})
+	}
+
+	// References to resources that are not in the cloud.
+	f, err := ParseFixture([]byte("cloud: [{name: thp, refs: [{field: UrlMap, to: um}]}, {name: um, options: [DoesNotExist]}]"))
+	if err != nil {
+		t.Fatalf("ParseFixture() = %v, want nil", err)
+	}
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "test-project"})
+	if err := f.Seed(context.Background(), mock); err == nil {
+		t.Errorf("Seed() = nil, want error")
+	}
+}
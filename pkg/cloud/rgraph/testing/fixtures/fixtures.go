@@ -0,0 +1,264 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fixtures has canonical load balancer topologies for unit tests,
+// benchmarks and examples. Each Topology is the Graph of the managed
+// resources and the state of the Cloud (e.g. the Network) that it depends
+// on:
+//
+//	topo := fixtures.InternalL7("proj")
+//	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+//	if err := topo.Seed(ctx, mock); err != nil { ... }
+//	result, err := plan.Do(ctx, mock, topo.MustGraph())
+package fixtures
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/network"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/subnetwork"
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	// Region of the regional resources in the topologies.
+	Region = "us-central1"
+	// Zone of the zonal resources in the topologies.
+	Zone = "us-central1-b"
+)
+
+// Topology is a complete load balancer configuration.
+type Topology struct {
+	// Name of the Topology, e.g. "internal-l7".
+	Name string
+	// Description of the Topology.
+	Description string
+	// Project of the resources.
+	Project string
+
+	// build adds the nodes to the Builder.
+	build func(b *rgraph.Builder) error
+	// seed creates the Cloud resources that are referenced by the
+	// Topology but not managed by it.
+	seed func(ctx context.Context, cl cloud.Cloud) error
+}
+
+// Builder returns a new Builder with the nodes of the Topology. The managed
+// resources have OwnershipManaged and the resources that they depend on
+// (e.g. the Network) have OwnershipExternal.
+func (t *Topology) Builder() (*rgraph.Builder, error) {
+	b := rgraph.NewBuilder()
+	if err := t.build(b); err != nil {
+		return nil, fmt.Errorf("Topology %s: %w", t.Name, err)
+	}
+	return b, nil
+}
+
+// Graph returns the Graph of the Topology.
+func (t *Topology) Graph() (*rgraph.Graph, error) {
+	b, err := t.Builder()
+	if err != nil {
+		return nil, err
+	}
+	return b.Build()
+}
+
+// MustGraph is like Graph but panics on error. This should ONLY be used in
+// tests.
+func (t *Topology) MustGraph() *rgraph.Graph {
+	g, err := t.Graph()
+	if err != nil {
+		panic(fmt.Sprintf("MustGraph: %v", err))
+	}
+	return g
+}
+
+// Seed creates the resources that the Topology depends on but does not
+// manage, e.g. the Network and the SSL certificates. Planning the Graph
+// after Seed will create all of the managed resources.
+func (t *Topology) Seed(ctx context.Context, cl cloud.Cloud) error {
+	if err := t.seed(ctx, cl); err != nil {
+		return fmt.Errorf("Topology %s: Seed: %w", t.Name, err)
+	}
+	return nil
+}
+
+// SeedAll creates all of the resources in the Topology, i.e. the state after
+// the Graph has been applied. Planning the Graph after SeedAll does nothing.
+func (t *Topology) SeedAll(ctx context.Context, cl cloud.Cloud) error {
+	if err := t.Seed(ctx, cl); err != nil {
+		return err
+	}
+	g, err := t.Graph()
+	if err != nil {
+		return err
+	}
+	var actions []exec.Action
+	for _, n := range g.All() {
+		op := rnode.OpCreate
+		if n.Ownership() != rnode.OwnershipManaged {
+			// Signals that the resource created by Seed() exists.
+			op = rnode.OpNothing
+		}
+		n.Plan().Set(rnode.PlanDetails{Operation: op, Why: "Topology seed"})
+		nodeActions, err := n.Actions(nil)
+		if err != nil {
+			return fmt.Errorf("Topology %s: SeedAll: %s: %w", t.Name, n.ID(), err)
+		}
+		actions = append(actions, nodeActions...)
+	}
+	ex, err := exec.NewSerialExecutor(actions)
+	if err != nil {
+		return fmt.Errorf("Topology %s: SeedAll: %w", t.Name, err)
+	}
+	result, err := ex.Run(ctx, cl)
+	if err != nil {
+		return fmt.Errorf("Topology %s: SeedAll: %w", t.Name, err)
+	}
+	if len(result.Pending) > 0 {
+		return fmt.Errorf("Topology %s: SeedAll: %d actions could not be run", t.Name, len(result.Pending))
+	}
+	return nil
+}
+
+// All returns all of the Topologies.
+func All(project string) []*Topology {
+	return []*Topology{
+		InternalL7(project),
+		ExternalHTTPS(project),
+		PSCProducer(project),
+		TDMesh(project),
+	}
+}
+
+// add a managed node for the resource to the Builder. f sets the fields of
+// the resource.
+func add[GA any, Alpha any, Beta any](
+	b *rgraph.Builder,
+	m api.MutableResource[GA, Alpha, Beta],
+	f func(x *GA),
+	newBuilder func(api.Resource[GA, Alpha, Beta]) rnode.Builder,
+) error {
+	return addNode(b, rnode.OwnershipManaged, m, f, newBuilder)
+}
+
+// addExternal adds an external node for a resource that the Topology
+// references but does not manage.
+func addExternal[GA any, Alpha any, Beta any](
+	b *rgraph.Builder,
+	m api.MutableResource[GA, Alpha, Beta],
+	f func(x *GA),
+	newBuilder func(api.Resource[GA, Alpha, Beta]) rnode.Builder,
+) error {
+	return addNode(b, rnode.OwnershipExternal, m, f, newBuilder)
+}
+
+func addNode[GA any, Alpha any, Beta any](
+	b *rgraph.Builder,
+	ownership rnode.OwnershipStatus,
+	m api.MutableResource[GA, Alpha, Beta],
+	f func(x *GA),
+	newBuilder func(api.Resource[GA, Alpha, Beta]) rnode.Builder,
+) error {
+	if f != nil {
+		// Access() reports the zero value fields that are not in
+		// NullFields/ForceSendFields. The fixtures only set the fields that
+		// matter, same as the ez package.
+		m.Access(f)
+	}
+	r, err := m.Freeze()
+	if err != nil {
+		return err
+	}
+	nb := newBuilder(r)
+	nb.SetOwnership(ownership)
+	nb.SetState(rnode.NodeExists)
+	b.Add(nb)
+	return nil
+}
+
+// vpc is the Network and Subnetworks shared by the Topologies.
+type vpc struct {
+	project string
+	// subnets by name (in Region).
+	subnets map[string]*compute.Subnetwork
+}
+
+func newVPC(project string, subnets ...*compute.Subnetwork) *vpc {
+	v := &vpc{project: project, subnets: map[string]*compute.Subnetwork{}}
+	for _, s := range subnets {
+		v.subnets[s.Name] = s
+	}
+	return v
+}
+
+func (v *vpc) networkID() *cloud.ResourceID {
+	return network.ID(v.project, meta.GlobalKey("net"))
+}
+
+func (v *vpc) subnetID(name string) *cloud.ResourceID {
+	return subnetwork.ID(v.project, meta.RegionalKey(name, Region))
+}
+
+func (v *vpc) network() *compute.Network {
+	return &compute.Network{Name: "net", AutoCreateSubnetworks: false}
+}
+
+func (v *vpc) subnet(name string) *compute.Subnetwork {
+	s := *v.subnets[name]
+	s.Network = v.networkID().SelfLink(meta.VersionGA)
+	s.Region = Region
+	return &s
+}
+
+func (v *vpc) addExternal(b *rgraph.Builder) error {
+	id := v.networkID()
+	err := addExternal(b, network.NewMutableNetwork(id.ProjectID, id.Key), func(x *compute.Network) {
+		*x = *v.network()
+	}, network.NewBuilderWithResource)
+	if err != nil {
+		return err
+	}
+	for name := range v.subnets {
+		id := v.subnetID(name)
+		err := addExternal(b, subnetwork.NewMutableSubnetwork(id.ProjectID, id.Key), func(x *compute.Subnetwork) {
+			*x = *v.subnet(name)
+		}, subnetwork.NewBuilderWithResource)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *vpc) seed(ctx context.Context, cl cloud.Cloud) error {
+	if err := cl.Networks().Insert(ctx, v.networkID().Key, v.network()); err != nil {
+		return err
+	}
+	for name := range v.subnets {
+		if err := cl.Subnetworks().Insert(ctx, v.subnetID(name).Key, v.subnet(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
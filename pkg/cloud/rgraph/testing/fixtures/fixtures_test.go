@@ -0,0 +1,133 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/fixtures"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/workflow/plan"
+)
+
+const project = "proj"
+
+func checkOps(t *testing.T, g *rgraph.Graph, want rnode.Operation) {
+	t.Helper()
+	for _, n := range g.All() {
+		if n.Ownership() != rnode.OwnershipManaged {
+			continue
+		}
+		if got := n.Plan().Op(); got != want {
+			t.Errorf("%s: Op() = %s, want %s (%s)", n.ID(), got, want, n.Plan().Details().Why)
+		}
+	}
+}
+
+func TestTopologies(t *testing.T) {
+	for _, topo := range fixtures.All(project) {
+		topo := topo
+		t.Run(topo.Name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("Seed", func(t *testing.T) {
+				mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+				if err := topo.Seed(ctx, mock); err != nil {
+					t.Fatalf("Seed() = %v, want nil", err)
+				}
+				res, err := plan.Do(ctx, mock, topo.MustGraph())
+				if err != nil {
+					t.Fatalf("plan.Do() = %v, want nil", err)
+				}
+				checkOps(t, res.Want, rnode.OpCreate)
+
+				ex, err := exec.NewSerialExecutor(res.Actions)
+				if err != nil {
+					t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+				}
+				if _, err := ex.Run(ctx, mock); err != nil {
+					t.Fatalf("Run() = %v, want nil", err)
+				}
+				res, err = plan.Do(ctx, mock, topo.MustGraph())
+				if err != nil {
+					t.Fatalf("plan.Do() after Run() = %v, want nil", err)
+				}
+				checkOps(t, res.Want, rnode.OpNothing)
+			})
+
+			t.Run("SeedAll", func(t *testing.T) {
+				mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+				if err := topo.SeedAll(ctx, mock); err != nil {
+					t.Fatalf("SeedAll() = %v, want nil", err)
+				}
+				res, err := plan.Do(ctx, mock, topo.MustGraph())
+				if err != nil {
+					t.Fatalf("plan.Do() = %v, want nil", err)
+				}
+				checkOps(t, res.Want, rnode.OpNothing)
+			})
+		})
+	}
+}
+
+func BenchmarkPlan(b *testing.B) {
+	ctx := context.Background()
+	for _, topo := range fixtures.All(project) {
+		topo := topo
+		b.Run(topo.Name, func(b *testing.B) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+			if err := topo.SeedAll(ctx, mock); err != nil {
+				b.Fatalf("SeedAll() = %v, want nil", err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := plan.Do(ctx, mock, topo.MustGraph()); err != nil {
+					b.Fatalf("plan.Do() = %v, want nil", err)
+				}
+			}
+		})
+	}
+}
+
+func Example() {
+	ctx := context.Background()
+	topo := fixtures.PSCProducer(project)
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: project})
+	if err := topo.Seed(ctx, mock); err != nil {
+		panic(err)
+	}
+	res, err := plan.Do(ctx, mock, topo.MustGraph())
+	if err != nil {
+		panic(err)
+	}
+	for _, n := range res.Want.All() {
+		if n.Ownership() == rnode.OwnershipManaged {
+			fmt.Printf("%s %s\n", n.Plan().Op(), n.ID())
+		}
+	}
+	// Unordered output:
+	// Create compute/healthChecks:proj/us-central1/psc-hc
+	// Create compute/networkEndpointGroups:proj/us-central1-b/psc-neg
+	// Create compute/backendServices:proj/us-central1/psc-bs
+	// Create compute/forwardingRules:proj/us-central1/psc-fr
+	// Create compute/serviceAttachments:proj/us-central1/psc-sa
+}
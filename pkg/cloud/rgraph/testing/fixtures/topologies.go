@@ -0,0 +1,388 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fixtures
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/mesh"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/serviceattachment"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/sslcertificate"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpsproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/tcproute"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/networkservices/v1"
+)
+
+func selfLink(id *cloud.ResourceID) string { return id.SelfLink(meta.VersionGA) }
+
+// InternalL7 is a regional internal Application Load Balancer: a
+// ForwardingRule on the VPC subnet, a TargetHttpProxy, UrlMap and
+// BackendService with a zonal NEG backend. The Network, the subnet and the
+// proxy-only subnet are external.
+func InternalL7(project string) *Topology {
+	v := newVPC(project,
+		&compute.Subnetwork{Name: "subnet", IpCidrRange: "10.0.0.0/24"},
+		&compute.Subnetwork{
+			Name:        "proxy-only",
+			IpCidrRange: "10.129.0.0/23",
+			Purpose:     "REGIONAL_MANAGED_PROXY",
+			Role:        "ACTIVE",
+		},
+	)
+	var (
+		hcID  = healthcheck.ID(project, meta.RegionalKey("il7-hc", Region))
+		negID = networkendpointgroup.ID(project, meta.ZonalKey("il7-neg", Zone))
+		bsID  = backendservice.ID(project, meta.RegionalKey("il7-bs", Region))
+		umID  = urlmap.ID(project, meta.RegionalKey("il7-um", Region))
+		thpID = targethttpproxy.ID(project, meta.RegionalKey("il7-thp", Region))
+		frID  = forwardingrule.ID(project, meta.RegionalKey("il7-fr", Region))
+	)
+
+	build := func(b *rgraph.Builder) error {
+		return all(
+			func() error { return v.addExternal(b) },
+			func() error {
+				return add(b, healthcheck.NewMutableHealthCheck(project, hcID.Key), func(x *compute.HealthCheck) {
+					x.Type = "HTTP"
+					x.HttpHealthCheck = &compute.HTTPHealthCheck{Port: 8080, RequestPath: "/healthz"}
+				}, healthcheck.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, networkendpointgroup.NewMutableNetworkEndpointGroup(project, negID.Key), func(x *compute.NetworkEndpointGroup) {
+					x.NetworkEndpointType = "GCE_VM_IP_PORT"
+					x.Network = selfLink(v.networkID())
+					x.Subnetwork = selfLink(v.subnetID("subnet"))
+					x.DefaultPort = 8080
+				}, networkendpointgroup.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, backendservice.NewMutableBackendService(project, bsID.Key), func(x *compute.BackendService) {
+					x.LoadBalancingScheme = "INTERNAL_MANAGED"
+					x.Protocol = "HTTP"
+					x.HealthChecks = []string{selfLink(hcID)}
+					x.Backends = []*compute.Backend{{
+						Group:              selfLink(negID),
+						BalancingMode:      "RATE",
+						MaxRatePerEndpoint: 100,
+					}}
+				}, backendservice.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, urlmap.NewMutableUrlMap(project, umID.Key), func(x *compute.UrlMap) {
+					x.DefaultService = selfLink(bsID)
+				}, urlmap.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, targethttpproxy.NewMutableTargetHttpProxy(project, thpID.Key), func(x *compute.TargetHttpProxy) {
+					x.UrlMap = selfLink(umID)
+				}, targethttpproxy.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, forwardingrule.NewMutableForwardingRule(project, frID.Key), func(x *compute.ForwardingRule) {
+					x.LoadBalancingScheme = "INTERNAL_MANAGED"
+					x.IPProtocol = "TCP"
+					x.PortRange = "80"
+					x.Network = selfLink(v.networkID())
+					x.Subnetwork = selfLink(v.subnetID("subnet"))
+					x.Target = selfLink(thpID)
+				}, forwardingrule.NewBuilderWithResource)
+			},
+		)
+	}
+
+	return &Topology{
+		Name:        "internal-l7",
+		Description: "Regional internal HTTP load balancer with a zonal NEG backend.",
+		Project:     project,
+		build:       build,
+		seed:        v.seed,
+	}
+}
+
+// ExternalHTTPS is a global external Application Load Balancer with TLS: a
+// reserved Address, a ForwardingRule on port 443, a TargetHttpsProxy with an
+// SslCertificate, UrlMap and BackendService with a zonal NEG backend. The
+// Network and the SslCertificate are not managed by the Topology.
+func ExternalHTTPS(project string) *Topology {
+	v := newVPC(project)
+	var (
+		certID = sslcertificate.ID(project, meta.GlobalKey("xhttps-cert"))
+		hcID   = healthcheck.ID(project, meta.GlobalKey("xhttps-hc"))
+		negID  = networkendpointgroup.ID(project, meta.ZonalKey("xhttps-neg", Zone))
+		bsID   = backendservice.ID(project, meta.GlobalKey("xhttps-bs"))
+		umID   = urlmap.ID(project, meta.GlobalKey("xhttps-um"))
+		thpsID = targethttpsproxy.ID(project, meta.GlobalKey("xhttps-thps"))
+		addrID = address.ID(project, meta.GlobalKey("xhttps-addr"))
+		frID   = forwardingrule.ID(project, meta.GlobalKey("xhttps-fr"))
+	)
+
+	cert := func() *compute.SslCertificate {
+		return &compute.SslCertificate{
+			Name: certID.Key.Name,
+			Type: "MANAGED",
+			Managed: &compute.SslCertificateManagedSslCertificate{
+				Domains: []string{"example.com"},
+			},
+		}
+	}
+
+	build := func(b *rgraph.Builder) error {
+		return all(
+			func() error { return v.addExternal(b) },
+			func() error {
+				return addExternal(b, sslcertificate.NewMutableSslCertificate(project, certID.Key), func(x *compute.SslCertificate) {
+					*x = *cert()
+				}, sslcertificate.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, healthcheck.NewMutableHealthCheck(project, hcID.Key), func(x *compute.HealthCheck) {
+					x.Type = "HTTP"
+					x.HttpHealthCheck = &compute.HTTPHealthCheck{Port: 8080, RequestPath: "/healthz"}
+				}, healthcheck.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, networkendpointgroup.NewMutableNetworkEndpointGroup(project, negID.Key), func(x *compute.NetworkEndpointGroup) {
+					x.NetworkEndpointType = "GCE_VM_IP_PORT"
+					x.Network = selfLink(v.networkID())
+					x.DefaultPort = 8080
+				}, networkendpointgroup.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, backendservice.NewMutableBackendService(project, bsID.Key), func(x *compute.BackendService) {
+					x.LoadBalancingScheme = "EXTERNAL_MANAGED"
+					x.Protocol = "HTTP"
+					x.HealthChecks = []string{selfLink(hcID)}
+					x.Backends = []*compute.Backend{{
+						Group:              selfLink(negID),
+						BalancingMode:      "RATE",
+						MaxRatePerEndpoint: 100,
+					}}
+				}, backendservice.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, urlmap.NewMutableUrlMap(project, umID.Key), func(x *compute.UrlMap) {
+					x.DefaultService = selfLink(bsID)
+				}, urlmap.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, targethttpsproxy.NewMutableTargetHttpsProxy(project, thpsID.Key), func(x *compute.TargetHttpsProxy) {
+					x.UrlMap = selfLink(umID)
+					x.SslCertificates = []string{selfLink(certID)}
+				}, targethttpsproxy.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, address.NewMutableAddress(project, addrID.Key), func(x *compute.Address) {
+					x.AddressType = "EXTERNAL"
+				}, address.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, forwardingrule.NewMutableForwardingRule(project, frID.Key), func(x *compute.ForwardingRule) {
+					x.LoadBalancingScheme = "EXTERNAL_MANAGED"
+					x.IPProtocol = "TCP"
+					x.PortRange = "443"
+					x.IPAddress = selfLink(addrID)
+					x.Target = selfLink(thpsID)
+				}, forwardingrule.NewBuilderWithResource)
+			},
+		)
+	}
+
+	seed := func(ctx context.Context, cl cloud.Cloud) error {
+		if err := v.seed(ctx, cl); err != nil {
+			return err
+		}
+		return cl.SslCertificates().Insert(ctx, certID.Key, cert())
+	}
+
+	return &Topology{
+		Name:        "external-https",
+		Description: "Global external HTTPS load balancer with a managed certificate and a zonal NEG backend.",
+		Project:     project,
+		build:       build,
+		seed:        seed,
+	}
+}
+
+// PSCProducer is the producer side of Private Service Connect: an internal
+// passthrough load balancer (ForwardingRule and BackendService with a zonal
+// NEG backend) published with a ServiceAttachment. The Network, the subnet
+// and the PSC NAT subnet are external.
+func PSCProducer(project string) *Topology {
+	v := newVPC(project,
+		&compute.Subnetwork{Name: "subnet", IpCidrRange: "10.0.0.0/24"},
+		&compute.Subnetwork{
+			Name:        "psc-nat",
+			IpCidrRange: "10.1.0.0/24",
+			Purpose:     "PRIVATE_SERVICE_CONNECT",
+		},
+	)
+	var (
+		hcID  = healthcheck.ID(project, meta.RegionalKey("psc-hc", Region))
+		negID = networkendpointgroup.ID(project, meta.ZonalKey("psc-neg", Zone))
+		bsID  = backendservice.ID(project, meta.RegionalKey("psc-bs", Region))
+		frID  = forwardingrule.ID(project, meta.RegionalKey("psc-fr", Region))
+		saID  = serviceattachment.ID(project, meta.RegionalKey("psc-sa", Region))
+	)
+
+	build := func(b *rgraph.Builder) error {
+		return all(
+			func() error { return v.addExternal(b) },
+			func() error {
+				return add(b, healthcheck.NewMutableHealthCheck(project, hcID.Key), func(x *compute.HealthCheck) {
+					x.Type = "TCP"
+					x.TcpHealthCheck = &compute.TCPHealthCheck{Port: 8080}
+				}, healthcheck.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, networkendpointgroup.NewMutableNetworkEndpointGroup(project, negID.Key), func(x *compute.NetworkEndpointGroup) {
+					x.NetworkEndpointType = "GCE_VM_IP"
+					x.Network = selfLink(v.networkID())
+					x.Subnetwork = selfLink(v.subnetID("subnet"))
+				}, networkendpointgroup.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, backendservice.NewMutableBackendService(project, bsID.Key), func(x *compute.BackendService) {
+					x.LoadBalancingScheme = "INTERNAL"
+					x.Protocol = "TCP"
+					x.HealthChecks = []string{selfLink(hcID)}
+					x.Backends = []*compute.Backend{{
+						Group:         selfLink(negID),
+						BalancingMode: "CONNECTION",
+					}}
+				}, backendservice.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, forwardingrule.NewMutableForwardingRule(project, frID.Key), func(x *compute.ForwardingRule) {
+					x.LoadBalancingScheme = "INTERNAL"
+					x.IPProtocol = "TCP"
+					x.AllPorts = true
+					x.Network = selfLink(v.networkID())
+					x.Subnetwork = selfLink(v.subnetID("subnet"))
+					x.BackendService = selfLink(bsID)
+				}, forwardingrule.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, serviceattachment.NewMutableServiceAttachment(project, saID.Key), func(x *compute.ServiceAttachment) {
+					x.ConnectionPreference = "ACCEPT_AUTOMATIC"
+					x.TargetService = selfLink(frID)
+					x.NatSubnets = []string{selfLink(v.subnetID("psc-nat"))}
+				}, serviceattachment.NewBuilderWithResource)
+			},
+		)
+	}
+
+	return &Topology{
+		Name:        "psc-producer",
+		Description: "Internal passthrough load balancer published with a Private Service Connect ServiceAttachment.",
+		Project:     project,
+		build:       build,
+		seed:        v.seed,
+	}
+}
+
+// TDMesh is a Traffic Director service mesh: a Mesh and a TcpRoute that
+// sends the traffic of the mesh to an INTERNAL_SELF_MANAGED BackendService
+// with a zonal NEG backend. The Network is external.
+func TDMesh(project string) *Topology {
+	v := newVPC(project)
+	var (
+		hcID    = healthcheck.ID(project, meta.GlobalKey("td-hc"))
+		negID   = networkendpointgroup.ID(project, meta.ZonalKey("td-neg", Zone))
+		bsID    = backendservice.ID(project, meta.GlobalKey("td-bs"))
+		meshID  = mesh.ID(project, meta.GlobalKey("td-mesh"))
+		routeID = tcproute.ID(project, meta.GlobalKey("td-route"))
+	)
+
+	build := func(b *rgraph.Builder) error {
+		return all(
+			func() error { return v.addExternal(b) },
+			func() error {
+				return add(b, healthcheck.NewMutableHealthCheck(project, hcID.Key), func(x *compute.HealthCheck) {
+					x.Type = "TCP"
+					x.TcpHealthCheck = &compute.TCPHealthCheck{Port: 8080}
+				}, healthcheck.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, networkendpointgroup.NewMutableNetworkEndpointGroup(project, negID.Key), func(x *compute.NetworkEndpointGroup) {
+					x.NetworkEndpointType = "GCE_VM_IP_PORT"
+					x.Network = selfLink(v.networkID())
+					x.DefaultPort = 8080
+				}, networkendpointgroup.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, backendservice.NewMutableBackendService(project, bsID.Key), func(x *compute.BackendService) {
+					x.LoadBalancingScheme = "INTERNAL_SELF_MANAGED"
+					x.Protocol = "TCP"
+					x.HealthChecks = []string{selfLink(hcID)}
+					x.Backends = []*compute.Backend{{
+						Group:          selfLink(negID),
+						BalancingMode:  "CONNECTION",
+						MaxConnections: 100,
+					}}
+				}, backendservice.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, mesh.NewMutableMesh(project, meshID.Key), func(x *networkservices.Mesh) {
+					x.Description = "Traffic Director mesh"
+				}, mesh.NewBuilderWithResource)
+			},
+			func() error {
+				return add(b, tcproute.NewMutableTcpRoute(project, routeID.Key), func(x *networkservices.TcpRoute) {
+					x.Meshes = []string{meshID.RelativeResourceName()}
+					x.Rules = []*networkservices.TcpRouteRouteRule{{
+						Action: &networkservices.TcpRouteRouteAction{
+							Destinations: []*networkservices.TcpRouteRouteDestination{{
+								// Routes refer to the BackendService with the
+								// v1 compute URL.
+								ServiceName: "https://compute.googleapis.com/v1/" + bsID.RelativeResourceName(),
+							}},
+						},
+					}}
+				}, tcproute.NewBuilderWithResource)
+			},
+		)
+	}
+
+	return &Topology{
+		Name:        "td-mesh",
+		Description: "Traffic Director Mesh with a TcpRoute to a self-managed BackendService.",
+		Project:     project,
+		build:       build,
+		seed:        v.seed,
+	}
+}
+
+// all runs the functions in order, stopping at the first error.
+func all(fns ...func() error) error {
+	for _, f := range fns {
+		if err := f(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package golden compares test output against golden files in testdata. Run
+// the tests with -update to write the golden files after a change in the
+// output, and review the diff of the files:
+//
+//	$ go test ./pkg/cloud/rgraph/workflow/plan -run TestGolden -update
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// Update returns true if the tests were run with -update.
+func Update() bool { return *update }
+
+// Check that got matches the contents of file. With -update, file is
+// (re)written with got instead.
+func Check(t testing.TB, file string, got []byte) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("MkdirAll(%q) = %v, want nil", filepath.Dir(file), err)
+		}
+		if err := os.WriteFile(file, got, 0644); err != nil {
+			t.Fatalf("WriteFile(%q) = %v, want nil", file, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) = %v, want nil (run with -update to create the golden file)", file, err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("%s does not match (run with -update and review the changes); -want,+got:\n%s", file, diff)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodetest builds the Nodes used in the tests of the rnode packages.
+// It only depends on the rnode package so that it can be used by the tests
+// inside of the packages for the resource types:
+//
+//	m := NewMutableAddress(proj, key)
+//	m.Access(func(x *compute.Address) { ... })
+//	n := nodetest.Build(t, m, NewBuilderWithResource)
+package nodetest
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// Build freezes m and returns the Node built from it with newBuilder. The
+// Node exists and is managed by the graph; setup can change the Builder
+// before the Node is built. The test fails if m cannot be frozen, e.g. if a
+// field is not set, or if the Node cannot be built.
+func Build[GA any, Alpha any, Beta any](
+	t testing.TB,
+	m api.MutableResource[GA, Alpha, Beta],
+	newBuilder func(api.Resource[GA, Alpha, Beta]) rnode.Builder,
+	setup ...func(rnode.Builder) error,
+) rnode.Node {
+	t.Helper()
+
+	r, err := m.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze() = %v, want nil", err)
+	}
+	b := newBuilder(r)
+	b.SetState(rnode.NodeExists)
+	b.SetOwnership(rnode.OwnershipManaged)
+	for _, f := range setup {
+		if err := f(b); err != nil {
+			t.Fatalf("setup() = %v, want nil", err)
+		}
+	}
+	n, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	return n
+}
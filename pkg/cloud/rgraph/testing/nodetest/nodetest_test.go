@@ -0,0 +1,42 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetest_test
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/nodetest"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestBuild(t *testing.T) {
+	m := address.NewMutableAddress("proj", meta.GlobalKey("addr"))
+	m.Access(func(x *compute.Address) { x.Name = "addr" })
+	n := nodetest.Build(t, m, address.NewBuilderWithResource, func(b rnode.Builder) error {
+		b.SetVersion(meta.VersionBeta)
+		return nil
+	})
+	if n.State() != rnode.NodeExists || n.Ownership() != rnode.OwnershipManaged {
+		t.Errorf("State(), Ownership() = %v, %v, want %v, %v", n.State(), n.Ownership(), rnode.NodeExists, rnode.OwnershipManaged)
+	}
+	if n.Version() != meta.VersionBeta {
+		t.Errorf("Version() = %v, want %v", n.Version(), meta.VersionBeta)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package converge applies a Graph by planning and executing it repeatedly
+// until there is nothing left to do.
+//
+// A single plan.Do() and execution may leave the resources partially updated,
+// e.g. when an Action fails due to a transient error or a concurrent change.
+// Do() re-plans from the current state of the Cloud after each execution so
+// the caller gets an eventually consistent apply.
+package converge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/workflow/plan"
+	"k8s.io/klog/v2"
+)
+
+const errPrefix = "Converge"
+
+// Result of Do().
+type Result struct {
+	// Iterations are the plans and executions, in order.
+	Iterations []Iteration
+	// Converged is true if the last plan had no changes.
+	Converged bool
+}
+
+// Iteration is a single plan and execution.
+type Iteration struct {
+	// Plan is the result of planning. This is nil if planning failed.
+	Plan *plan.Result
+	// Exec is the result of the execution. This is nil if the plan had no
+	// changes, planning failed or the plan is the final check after
+	// MaxIterations executions.
+	Exec *exec.Result
+	// Err is the error from planning or execution.
+	Err error
+}
+
+// Option for Do.
+type Option func(c *config)
+
+// MaxIterations is the number of executions before giving up. The Graph is
+// planned once more after the last execution to check if it has converged.
+// The default is 5.
+func MaxIterations(n int) Option {
+	return func(c *config) { c.maxIterations = n }
+}
+
+// Interval to wait between the iterations. The default is to not wait.
+func Interval(d time.Duration) Option {
+	return func(c *config) { c.interval = d }
+}
+
+// PlanOptions are passed to plan.Do().
+func PlanOptions(opts ...plan.Option) Option {
+	return func(c *config) { c.planOpts = append(c.planOpts, opts...) }
+}
+
+// ExecutorFunc returns the Executor for the Actions of an iteration.
+type ExecutorFunc func(actions []exec.Action) (exec.Executor, error)
+
+// Executor sets the Executor to use. The default is a serial executor with
+// exec.ContinueOnError so that as much of the plan as possible is done in
+// each iteration.
+func Executor(f ExecutorFunc) Option {
+	return func(c *config) { c.newExecutor = f }
+}
+
+type config struct {
+	maxIterations int
+	interval      time.Duration
+	planOpts      []plan.Option
+	newExecutor   ExecutorFunc
+}
+
+// Do plans and executes want until the plan has no changes. Returns an error
+// if the Graph has not converged after MaxIterations or if planning fails.
+// Execution errors are retried by planning again. want is not modified.
+func Do(ctx context.Context, c cloud.Cloud, want *rgraph.Graph, opts ...Option) (*Result, error) {
+	config := config{
+		maxIterations: 5,
+		newExecutor: func(actions []exec.Action) (exec.Executor, error) {
+			return exec.NewSerialExecutor(actions, exec.ErrorStrategyOption(exec.ContinueOnError))
+		},
+	}
+	for _, o := range opts {
+		o(&config)
+	}
+	if config.maxIterations < 1 {
+		return nil, fmt.Errorf("%s: invalid MaxIterations %d", errPrefix, config.maxIterations)
+	}
+
+	result := &Result{}
+	for i := 0; ; i++ {
+		if i > 0 && config.interval > 0 {
+			select {
+			case <-ctx.Done():
+				return result, fmt.Errorf("%s: %w", errPrefix, ctx.Err())
+			case <-time.After(config.interval):
+			}
+		}
+
+		// Planning modifies the "want" graph so each iteration starts from a
+		// copy.
+		g, err := want.Clone()
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", errPrefix, err)
+		}
+		pr, err := plan.Do(ctx, c, g, config.planOpts...)
+		if err != nil {
+			result.Iterations = append(result.Iterations, Iteration{Err: err})
+			return result, fmt.Errorf("%s: iteration %d: %w", errPrefix, i, err)
+		}
+		it := Iteration{Plan: pr}
+		if !pr.HasChanges() {
+			result.Iterations = append(result.Iterations, it)
+			result.Converged = true
+			return result, nil
+		}
+		if i == config.maxIterations {
+			// The last plan only checks if the previous execution converged.
+			result.Iterations = append(result.Iterations, it)
+			break
+		}
+
+		ex, err := config.newExecutor(pr.Actions)
+		if err != nil {
+			it.Err = err
+			result.Iterations = append(result.Iterations, it)
+			return result, fmt.Errorf("%s: iteration %d: %w", errPrefix, i, err)
+		}
+		it.Exec, it.Err = ex.Run(ctx, c)
+		result.Iterations = append(result.Iterations, it)
+		if it.Err != nil {
+			klog.V(2).Infof("%s: iteration %d: execution error (will re-plan): %v", errPrefix, i, it.Err)
+		}
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("%s: %w", errPrefix, err)
+		}
+	}
+	return result, fmt.Errorf("%s: not converged after %d iterations", errPrefix, config.maxIterations)
+}
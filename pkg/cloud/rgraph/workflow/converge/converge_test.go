@@ -0,0 +1,130 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package converge
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDo(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+
+	for _, tc := range []struct {
+		name string
+		// failures is the number of times the TargetHttpProxy insert fails.
+		failures       int
+		opts           []Option
+		wantIterations int
+		wantErr        bool
+	}{
+		{
+			name:           "no errors",
+			wantIterations: 2,
+		},
+		{
+			name:           "transient error",
+			failures:       1,
+			wantIterations: 3,
+		},
+		{
+			name:           "converged on the last iteration",
+			opts:           []Option{MaxIterations(1)},
+			wantIterations: 2,
+		},
+		{
+			name:           "error: not converged",
+			failures:       10,
+			opts:           []Option{MaxIterations(3)},
+			wantIterations: 4,
+			wantErr:        true,
+		},
+		{
+			name:    "error: invalid MaxIterations",
+			opts:    []Option{MaxIterations(0)},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			failures := tc.failures
+			mock.MockTargetHttpProxies.InsertHook = func(context.Context, *meta.Key, *compute.TargetHttpProxy, *cloud.MockTargetHttpProxies, ...cloud.Option) (bool, error) {
+				if failures > 0 {
+					failures--
+					return true, fmt.Errorf("injected error")
+				}
+				return false, nil
+			}
+
+			gr := rgraph.NewBuilder()
+			tpm := b.N("tp").TargetHttpProxy().Resource()
+			tpm.Access(func(x *compute.TargetHttpProxy) {
+				x.UrlMap = b.N("um").UrlMap().SelfLink()
+			})
+			tpr, _ := tpm.Freeze()
+			umr, _ := b.N("um").UrlMap().Resource().Freeze()
+			for _, nb := range []rnode.Builder{
+				targethttpproxy.NewBuilderWithResource(tpr),
+				urlmap.NewBuilderWithResource(umr),
+			} {
+				nb.SetOwnership(rnode.OwnershipManaged)
+				nb.SetState(rnode.NodeExists)
+				gr.Add(nb)
+			}
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			res, err := Do(context.Background(), mock, want, tc.opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if res == nil {
+				return
+			}
+			if len(res.Iterations) != tc.wantIterations {
+				t.Errorf("len(Iterations) = %d, want %d", len(res.Iterations), tc.wantIterations)
+			}
+			if res.Converged == tc.wantErr {
+				t.Errorf("Converged = %t, want %t", res.Converged, !tc.wantErr)
+			}
+			// The Graph passed in is not planned.
+			for _, n := range want.All() {
+				if op := n.Plan().Op(); op != rnode.OpUnknown {
+					t.Errorf("want node %s op = %s, want %s", n.ID(), op, rnode.OpUnknown)
+				}
+			}
+			if !tc.wantErr {
+				if _, err := mock.TargetHttpProxies().Get(context.Background(), meta.GlobalKey("tp")); err != nil {
+					t.Errorf("TargetHttpProxies().Get(tp) = %v, want nil", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// AnnotateFunc returns the annotations for a planned Action, e.g. the
+// estimated downtime, cost delta or risk level. node is the wanted node the
+// Action changes or nil if the Action is not an exec.ResourceAction.
+type AnnotateFunc func(a exec.Action, node rnode.Node) map[string]string
+
+// Annotate adds a hook that annotates each of the planned Actions. The
+// annotations are available with exec.Metadata() and are included in the
+// JSON() of the Result. This can be used with exec.ApprovalOption() to
+// block Actions, e.g. any Action with a downtime without an approval.
+//
+// The hooks are called in order; later hooks overwrite the annotations with
+// the same key.
+func Annotate(f AnnotateFunc) Option {
+	return func(c *config) { c.annotate = append(c.annotate, f) }
+}
+
+// annotate the Actions with the hooks in the config.
+func (pl *planner) annotate(acts []exec.Action) error {
+	for _, a := range acts {
+		var node rnode.Node
+		if ra, ok := a.(exec.ResourceAction); ok {
+			node = pl.want.Get(ra.ResourceID())
+		}
+		for _, f := range pl.config.annotate {
+			for k, v := range f(a, node) {
+				if err := exec.Annotate(a, k, v); err != nil {
+					return fmt.Errorf("%s: %w", errPrefix, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestAnnotate(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.UrlMaps().Insert(context.Background(), meta.GlobalKey("um"), &compute.UrlMap{Description: "old"})
+
+	gr := rgraph.NewBuilder()
+	for _, name := range []string{"um", "um2"} {
+		m := b.N(name).UrlMap().Resource()
+		m.Access(func(x *compute.UrlMap) { x.Description = "new" })
+		r, _ := m.Freeze()
+		nb := urlmap.NewBuilderWithResource(r)
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(rnode.NodeExists)
+		gr.Add(nb)
+	}
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	// downtime is set for the Actions of recreated resources.
+	downtime := func(a exec.Action, n rnode.Node) map[string]string {
+		if n != nil && n.Plan().Op() == rnode.OpRecreate {
+			return map[string]string{"downtime": "true"}
+		}
+		return map[string]string{"downtime": "false"}
+	}
+	risk := func(a exec.Action, n rnode.Node) map[string]string {
+		if a.Metadata().Type == exec.ActionTypeDelete {
+			return map[string]string{"risk": "high"}
+		}
+		return nil
+	}
+	res, err := Do(context.Background(), mock, want,
+		ImmutableFields(ImmutableFieldsRecreate), Annotate(downtime), Annotate(risk))
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+
+	umID := b.N("um").UrlMap().ID()
+	var sawDelete bool
+	for _, a := range res.Actions {
+		md := exec.Metadata(a)
+		if md.Type == exec.ActionTypeDelete {
+			sawDelete = true
+			if md.Annotations["risk"] != "high" {
+				t.Errorf("%s: risk = %q, want \"high\"", md.Name, md.Annotations["risk"])
+			}
+		}
+		ra, ok := a.(exec.ResourceAction)
+		if !ok {
+			continue
+		}
+		wantDowntime := "false"
+		if ra.ResourceID().Equal(umID) {
+			wantDowntime = "true"
+		}
+		if got := md.Annotations["downtime"]; got != wantDowntime {
+			t.Errorf("%s: downtime = %q, want %q", md.Name, got, wantDowntime)
+		}
+	}
+	if !sawDelete {
+		t.Errorf("no delete Action in the plan, want the recreate of %s", umID)
+	}
+
+	for _, ja := range res.JSON().Actions {
+		if ja.Annotations["downtime"] == "" {
+			t.Errorf("JSON action %s: Annotations = %v, want downtime", ja.Name, ja.Annotations)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/testing/golden"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/workflow/testlib"
+
+	_ "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/workflow/testlib/lb"
+)
+
+// TestGolden compares the plans for the steps of the testlib cases with
+// testdata/golden. After a change in the planner, update the files with
+//
+//	$ go test ./pkg/cloud/rgraph/workflow/plan -run TestGolden -update
+//
+// and review the diff.
+func TestGolden(t *testing.T) {
+	for _, tc := range testlib.Cases() {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "proj"})
+
+			for i, step := range tc.Steps {
+				if step.SetUp != nil {
+					step.SetUp(mock)
+				}
+				res, err := Do(ctx, mock, step.Graph, ImmutableFields(ImmutableFieldsRecreate))
+				if err != nil {
+					t.Fatalf("step %d: Do() = %v, want nil", i, err)
+				}
+				file := filepath.Join("testdata", "golden", strings.ReplaceAll(tc.Name, "/", "_"), fmt.Sprintf("step%d.txt", i))
+				golden.Check(t, file, []byte(res.Render()))
+
+				if i == len(tc.Steps)-1 {
+					break
+				}
+				// Execute the plan so that the next step starts from the
+				// result.
+				ex, err := exec.NewSerialExecutor(res.Actions)
+				if err != nil {
+					t.Fatalf("step %d: NewSerialExecutor() = %v, want nil", i, err)
+				}
+				if _, err := ex.Run(ctx, mock); err != nil {
+					t.Fatalf("step %d: Run() = %v, want nil", i, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// ImmutableFieldPolicy is what the planner does when a resource has changes
+// to fields that cannot be updated in place, i.e. the diff of the node
+// requires the resource to be recreated (see rnode.DiffPolicy).
+type ImmutableFieldPolicy string
+
+var (
+	// ImmutableFieldsFail fails the plan with an *ImmutableFieldError.
+	ImmutableFieldsFail ImmutableFieldPolicy = "Fail"
+	// ImmutableFieldsRecreate deletes and creates the resource again (and
+	// the resources that reference it).
+	ImmutableFieldsRecreate ImmutableFieldPolicy = "Recreate"
+	// ImmutableFieldsIgnore leaves the resource as it is. Note that the
+	// other changes to the same resource are not applied either.
+	ImmutableFieldsIgnore ImmutableFieldPolicy = "Ignore"
+)
+
+// ImmutableFields sets the ImmutableFieldPolicy. The default is
+// ImmutableFieldsFail so that resources are never recreated by surprise.
+func ImmutableFields(p ImmutableFieldPolicy) Option {
+	return func(c *config) { c.immutableFields = p }
+}
+
+// ImmutableFieldChange is a resource that would need to be recreated.
+type ImmutableFieldChange struct {
+	ID *cloud.ResourceID
+	// Why describes the fields that changed.
+	Why string
+}
+
+// ImmutableFieldError is returned when resources would need to be
+// recreated with ImmutableFieldsFail.
+type ImmutableFieldError struct {
+	// Changes sorted by ID.
+	Changes []ImmutableFieldChange
+}
+
+func (e *ImmutableFieldError) Error() string {
+	var l []string
+	for _, c := range e.Changes {
+		l = append(l, fmt.Sprintf("%v: %s", c.ID, c.Why))
+	}
+	return fmt.Sprintf("changes to immutable fields (use ImmutableFields(%s) to allow): %s", ImmutableFieldsRecreate, strings.Join(l, "; "))
+}
+
+// applyImmutableFieldPolicy to the nodes that have to be recreated due to
+// their own diff. This must be called before the recreates are propagated.
+func (pl *planner) applyImmutableFieldPolicy() error {
+	var changes []ImmutableFieldChange
+	for _, n := range pl.want.All() {
+		if n.Plan().Op() != rnode.OpRecreate {
+			continue
+		}
+		why := n.Plan().Details().Why
+		switch pl.config.immutableFields {
+		case ImmutableFieldsRecreate:
+		case ImmutableFieldsIgnore:
+			n.Plan().Set(rnode.PlanDetails{
+				Operation: rnode.OpNothing,
+				Why:       "Ignored changes to immutable fields: " + why,
+			})
+		case ImmutableFieldsFail, "":
+			changes = append(changes, ImmutableFieldChange{ID: n.ID(), Why: why})
+		default:
+			return fmt.Errorf("%s: invalid ImmutableFieldPolicy %q", errPrefix, pl.config.immutableFields)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID.String() < changes[j].ID.String() })
+	return fmt.Errorf("%s: %w", errPrefix, &ImmutableFieldError{Changes: changes})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestImmutableFields(t *testing.T) {
+	const proj = "proj"
+	key := meta.GlobalKey("addr")
+	id := address.ID(proj, key)
+
+	for _, tc := range []struct {
+		name    string
+		opts    []Option
+		wantOp  rnode.Operation
+		wantErr bool
+	}{
+		{
+			name:    "default fails",
+			wantErr: true,
+		},
+		{
+			name:    "fail",
+			opts:    []Option{ImmutableFields(ImmutableFieldsFail)},
+			wantErr: true,
+		},
+		{
+			name:   "recreate",
+			opts:   []Option{ImmutableFields(ImmutableFieldsRecreate)},
+			wantOp: rnode.OpRecreate,
+		},
+		{
+			name:   "ignore",
+			opts:   []Option{ImmutableFields(ImmutableFieldsIgnore)},
+			wantOp: rnode.OpNothing,
+		},
+		{
+			name:    "invalid policy",
+			opts:    []Option{ImmutableFields("bad")},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			mock.GlobalAddresses().Insert(context.Background(), key, &compute.Address{
+				Name:    "addr",
+				Address: "1.2.3.4",
+			})
+
+			// Changing .Address requires a recreate.
+			m := address.NewMutableAddress(proj, key)
+			m.Access(func(x *compute.Address) {
+				x.Name = "addr"
+				x.Address = "1.2.3.5"
+			})
+			r, err := m.Freeze()
+			if err != nil {
+				t.Fatalf("Freeze() = %v, want nil", err)
+			}
+			b := address.NewBuilderWithResource(r)
+			b.SetOwnership(rnode.OwnershipManaged)
+			b.SetState(rnode.NodeExists)
+			gr := rgraph.NewBuilder()
+			gr.Add(b)
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			res, err := Do(context.Background(), mock, want, tc.opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				var ife *ImmutableFieldError
+				if tc.opts == nil && (!errors.As(err, &ife) || len(ife.Changes) != 1 || !ife.Changes[0].ID.Equal(id)) {
+					t.Errorf("Do() = %v, want ImmutableFieldError for %v", err, id)
+				}
+				return
+			}
+			if op := res.Want.Get(id).Plan().Op(); op != tc.wantOp {
+				t.Errorf("op = %s, want %s", op, tc.wantOp)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// InjectFunc returns custom Actions to insert into the plan before and
+// after the Actions of the wanted node, e.g. to call a DNS API or to run a
+// smoke test between the creation of a load balancer and the switch of
+// traffic. The Actions must embed exec.ActionBase and have unique names.
+//
+// The Actions of the node wait for all of the Actions in before. The
+// Actions in after wait for all of the Actions of the node. Nodes that are
+// not changed (rnode.OpNothing) have no Actions to wait for.
+type InjectFunc func(n rnode.Node) (before, after []exec.Action, err error)
+
+// Inject adds a hook that is called for each node in the want graph to
+// insert custom Actions into the plan. The injected Actions are also passed
+// to the Annotate() hooks.
+func Inject(f InjectFunc) Option {
+	return func(c *config) { c.inject = append(c.inject, f) }
+}
+
+// doneEvent is signaled when the Action completes. See exec.SignalAfter().
+func doneEvent(a exec.Action) exec.Event {
+	return exec.StringEvent(fmt.Sprintf("Done(%s)", a.Metadata().Name))
+}
+
+// inject the Actions from the hooks in the config.
+func (pl *planner) inject(acts []exec.Action) ([]exec.Action, error) {
+	names := map[string]bool{}
+	for _, a := range acts {
+		names[a.Metadata().Name] = true
+	}
+	// signaling are the Actions that already signal their doneEvent().
+	signaling := map[exec.Action]bool{}
+	signalDone := func(a exec.Action) error {
+		if signaling[a] {
+			return nil
+		}
+		signaling[a] = true
+		return exec.SignalAfter(a, doneEvent(a))
+	}
+	add := func(a exec.Action) error {
+		name := a.Metadata().Name
+		if names[name] {
+			return fmt.Errorf("%s: injected Action %s: duplicate name", errPrefix, name)
+		}
+		names[name] = true
+		return nil
+	}
+
+	// Only the Actions planned for the nodes are gated, not the injected
+	// ones.
+	planned := acts
+	for _, n := range pl.want.All() {
+		var nodeActs []exec.Action
+		for _, a := range planned {
+			if ra, ok := a.(exec.ResourceAction); ok && ra.ResourceID() != nil && ra.ResourceID().Equal(n.ID()) {
+				nodeActs = append(nodeActs, a)
+			}
+		}
+		for _, f := range pl.config.inject {
+			before, after, err := f(n)
+			if err != nil {
+				return nil, fmt.Errorf("%s: inject %s: %w", errPrefix, n.ID(), err)
+			}
+			for _, a := range before {
+				if err := add(a); err != nil {
+					return nil, err
+				}
+				if err := signalDone(a); err != nil {
+					return nil, fmt.Errorf("%s: %w", errPrefix, err)
+				}
+				if err := exec.Gate(nodeActs, doneEvent(a), n.ID()); err != nil {
+					return nil, fmt.Errorf("%s: %w", errPrefix, err)
+				}
+			}
+			for _, a := range after {
+				if err := add(a); err != nil {
+					return nil, err
+				}
+				w, ok := a.(interface{ AddWant(...exec.Event) })
+				if !ok {
+					return nil, fmt.Errorf("%s: injected Action %v (%T) cannot wait for events", errPrefix, a, a)
+				}
+				for _, na := range nodeActs {
+					if err := signalDone(na); err != nil {
+						return nil, fmt.Errorf("%s: %w", errPrefix, err)
+					}
+					w.AddWant(doneEvent(na))
+				}
+			}
+			acts = append(acts, before...)
+			acts = append(acts, after...)
+		}
+	}
+	return acts, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+)
+
+// customAction is an Action injected by the test.
+type customAction struct {
+	exec.ActionBase
+	name string
+}
+
+func (a *customAction) Run(context.Context, cloud.Cloud) (exec.EventList, error) { return nil, nil }
+func (a *customAction) DryRun() exec.EventList                                   { return nil }
+func (a *customAction) String() string                                           { return a.name }
+
+func (a *customAction) Metadata() *exec.ActionMetadata {
+	return &exec.ActionMetadata{Name: a.name, Type: exec.ActionTypeCustom, Summary: a.name}
+}
+
+func TestInject(t *testing.T) {
+	const proj = "proj"
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: proj}
+	umID := b.N("um").UrlMap().ID()
+
+	newWant := func() *rgraph.Graph {
+		umr, _ := b.N("um").UrlMap().Resource().Freeze()
+		nb := urlmap.NewBuilderWithResource(umr)
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(rnode.NodeExists)
+		gr := rgraph.NewBuilder()
+		gr.Add(nb)
+		want, err := gr.Build()
+		if err != nil {
+			t.Fatalf("Build() = %v, want nil", err)
+		}
+		return want
+	}
+
+	for _, tc := range []struct {
+		name    string
+		f       InjectFunc
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "before and after",
+			f: func(n rnode.Node) ([]exec.Action, []exec.Action, error) {
+				return []exec.Action{&customAction{name: "dns"}}, []exec.Action{&customAction{name: "smoke"}}, nil
+			},
+			want: []string{"dns", "Create", "smoke"},
+		},
+		{
+			name: "nothing injected",
+			f: func(n rnode.Node) ([]exec.Action, []exec.Action, error) {
+				return nil, nil, nil
+			},
+			want: []string{"Create"},
+		},
+		{
+			name: "duplicate name",
+			f: func(n rnode.Node) ([]exec.Action, []exec.Action, error) {
+				return []exec.Action{&customAction{name: "x"}}, []exec.Action{&customAction{name: "x"}}, nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "error",
+			f: func(n rnode.Node) ([]exec.Action, []exec.Action, error) {
+				return nil, nil, fmt.Errorf("injected")
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			result, err := Do(ctx, mock, newWant(), Inject(tc.f))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			// The injected Actions are at the end of the list so the
+			// serial executor would run "dns" after the create without
+			// the dependencies. "smoke" must wait for the create.
+			for _, a := range result.Actions {
+				if a.String() == "smoke" && len(a.PendingEvents()) == 0 {
+					t.Errorf("smoke does not wait for any events, want Done(create)")
+				}
+			}
+			ex, err := exec.NewSerialExecutor(result.Actions)
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			res, err := ex.Run(ctx, mock)
+			if err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			var got []string
+			for _, a := range res.Completed {
+				switch {
+				case strings.Contains(a.String(), "Create") && strings.Contains(a.String(), umID.String()):
+					got = append(got, "Create")
+				case strings.Contains(a.String(), umID.String()):
+				default:
+					got = append(got, a.String())
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("Completed = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+)
+
+// JSONVersion is the version of the JSON encoding of a Result. This is
+// incremented when the encoding changes in an incompatible way.
+const JSONVersion = "v1"
+
+// JSONResult is the serialized form of a Result. The encoding is stable:
+// the same plan will always encode to the same bytes so that plans can be
+// stored, compared and approved before they are executed.
+type JSONResult struct {
+	Version string `json:"version"`
+	// Nodes are the planned operations for each node in the "want" graph,
+	// sorted by ID.
+	Nodes []JSONNode `json:"nodes"`
+	// Actions that will be executed, sorted by Name.
+	Actions []JSONAction `json:"actions"`
+}
+
+// JSONNode is the plan for a single node.
+type JSONNode struct {
+	ID        string         `json:"id"`
+	Ownership string         `json:"ownership"`
+	Operation string         `json:"operation"`
+	Why       string         `json:"why,omitempty"`
+	Diff      []JSONDiffItem `json:"diff,omitempty"`
+}
+
+// JSONDiffItem is an item in the diff between the current and wanted
+// resource.
+type JSONDiffItem struct {
+	State string `json:"state"`
+	Path  string `json:"path"`
+	A     any    `json:"a,omitempty"`
+	B     any    `json:"b,omitempty"`
+}
+
+// JSONAction is an Action in the plan.
+type JSONAction struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Summary string `json:"summary,omitempty"`
+	// Annotations added by the Annotate() hooks.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Want are the events the Action waits for before it can run.
+	Want []string `json:"want,omitempty"`
+}
+
+// JSON returns the serialized form of the Result.
+func (r *Result) JSON() *JSONResult {
+	ret := &JSONResult{
+		Version: JSONVersion,
+		Nodes:   []JSONNode{},
+		Actions: []JSONAction{},
+	}
+	if r.Want != nil {
+		for _, n := range r.Want.All() {
+			jn := JSONNode{
+				ID:        n.ID().String(),
+				Ownership: string(n.Ownership()),
+				Operation: string(n.Plan().Op()),
+			}
+			if details := n.Plan().Details(); details != nil {
+				jn.Why = details.Why
+				if details.Diff != nil {
+					for _, item := range details.Diff.Items {
+						jn.Diff = append(jn.Diff, JSONDiffItem{
+							State: string(item.State),
+							Path:  item.Path.String(),
+							A:     item.A,
+							B:     item.B,
+						})
+					}
+				}
+			}
+			ret.Nodes = append(ret.Nodes, jn)
+		}
+	}
+	sort.Slice(ret.Nodes, func(i, j int) bool { return ret.Nodes[i].ID < ret.Nodes[j].ID })
+
+	for _, a := range r.Actions {
+		md := exec.Metadata(a)
+		ja := JSONAction{
+			Name:        md.Name,
+			Type:        string(md.Type),
+			Summary:     md.Summary,
+			Annotations: md.Annotations,
+		}
+		for _, ev := range a.PendingEvents() {
+			ja.Want = append(ja.Want, ev.String())
+		}
+		sort.Strings(ja.Want)
+		ret.Actions = append(ret.Actions, ja)
+	}
+	sort.Slice(ret.Actions, func(i, j int) bool { return ret.Actions[i].Name < ret.Actions[j].Name })
+
+	return ret
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.JSON())
+}
+
+// ParseJSON parses a Result serialized with MarshalJSON. Returns an error if
+// the version is not supported.
+func ParseJSON(b []byte) (*JSONResult, error) {
+	var ret JSONResult
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil, fmt.Errorf("%s: ParseJSON: %w", errPrefix, err)
+	}
+	if ret.Version != JSONVersion {
+		return nil, fmt.Errorf("%s: ParseJSON: unsupported version %q (want %q)", errPrefix, ret.Version, JSONVersion)
+	}
+	return &ret, nil
+}
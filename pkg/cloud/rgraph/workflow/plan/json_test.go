@@ -0,0 +1,100 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestResultJSON(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.UrlMaps().Insert(context.Background(), meta.GlobalKey("um"), &compute.UrlMap{Description: "old"})
+
+	gr := rgraph.NewBuilder()
+	for _, name := range []string{"um", "um2"} {
+		m := b.N(name).UrlMap().Resource()
+		m.Access(func(x *compute.UrlMap) { x.Description = "new" })
+		r, _ := m.Freeze()
+		nb := urlmap.NewBuilderWithResource(r)
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(rnode.NodeExists)
+		gr.Add(nb)
+	}
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	res, err := Do(context.Background(), mock, want, ImmutableFields(ImmutableFieldsRecreate))
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+
+	j1, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want nil", err)
+	}
+	j2, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v, want nil", err)
+	}
+	if !bytes.Equal(j1, j2) {
+		t.Errorf("json.Marshal() is not stable:\n%s\n%s", j1, j2)
+	}
+	t.Logf("json = %s", j1)
+
+	parsed, err := ParseJSON(j1)
+	if err != nil {
+		t.Fatalf("ParseJSON() = %v, want nil", err)
+	}
+	ops := map[string]string{}
+	for _, n := range parsed.Nodes {
+		ops[n.ID] = n.Operation
+		if n.Operation == string(rnode.OpRecreate) && len(n.Diff) == 0 {
+			t.Errorf("node %s: Diff is empty, want .Description", n.ID)
+		}
+	}
+	wantOps := map[string]string{
+		b.N("um").UrlMap().ID().String():  string(rnode.OpRecreate),
+		b.N("um2").UrlMap().ID().String(): string(rnode.OpCreate),
+	}
+	for id, op := range wantOps {
+		if ops[id] != op {
+			t.Errorf("node %s operation = %q, want %q", id, ops[id], op)
+		}
+	}
+	if len(parsed.Actions) != len(res.Actions) {
+		t.Errorf("len(Actions) = %d, want %d", len(parsed.Actions), len(res.Actions))
+	}
+
+	if _, err := ParseJSON([]byte(`{"version":"v0"}`)); err == nil {
+		t.Errorf("ParseJSON(v0) = nil, want error")
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpsproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+)
+
+// OrphanListFunc returns the resources in the Cloud that may have been created
+// for the graph. The planner filters the resources with the OwnerMarker so the
+// list may include resources that are owned by someone else.
+type OrphanListFunc func(ctx context.Context, c cloud.Cloud) ([]*cloud.ResourceID, error)
+
+// ListLBResources lists the load balancer resources (ForwardingRules, target
+// proxies, UrlMaps, BackendServices and HealthChecks) in the project. Regional
+// resources are listed in the given regions.
+func ListLBResources(project string, regions []string) OrphanListFunc {
+	return func(ctx context.Context, c cloud.Cloud) ([]*cloud.ResourceID, error) {
+		var ret []*cloud.ResourceID
+		add := func(idf func(string, *meta.Key) *cloud.ResourceID, key *meta.Key) {
+			ret = append(ret, idf(project, key))
+		}
+		opt := cloud.ForceProjectID(project)
+
+		// Global resources.
+		frs, err := c.GlobalForwardingRules().List(ctx, filter.None, opt)
+		if err != nil {
+			return nil, fmt.Errorf("GlobalForwardingRules.List: %w", err)
+		}
+		for _, x := range frs {
+			add(forwardingrule.ID, meta.GlobalKey(x.Name))
+		}
+		thps, err := c.TargetHttpProxies().List(ctx, filter.None, opt)
+		if err != nil {
+			return nil, fmt.Errorf("TargetHttpProxies.List: %w", err)
+		}
+		for _, x := range thps {
+			add(targethttpproxy.ID, meta.GlobalKey(x.Name))
+		}
+		thsps, err := c.TargetHttpsProxies().List(ctx, filter.None, opt)
+		if err != nil {
+			return nil, fmt.Errorf("TargetHttpsProxies.List: %w", err)
+		}
+		for _, x := range thsps {
+			add(targethttpsproxy.ID, meta.GlobalKey(x.Name))
+		}
+		ums, err := c.UrlMaps().List(ctx, filter.None, opt)
+		if err != nil {
+			return nil, fmt.Errorf("UrlMaps.List: %w", err)
+		}
+		for _, x := range ums {
+			add(urlmap.ID, meta.GlobalKey(x.Name))
+		}
+		bss, err := c.BackendServices().List(ctx, filter.None, opt)
+		if err != nil {
+			return nil, fmt.Errorf("BackendServices.List: %w", err)
+		}
+		for _, x := range bss {
+			add(backendservice.ID, meta.GlobalKey(x.Name))
+		}
+		hcs, err := c.HealthChecks().List(ctx, filter.None, opt)
+		if err != nil {
+			return nil, fmt.Errorf("HealthChecks.List: %w", err)
+		}
+		for _, x := range hcs {
+			add(healthcheck.ID, meta.GlobalKey(x.Name))
+		}
+
+		// Regional resources.
+		for _, region := range regions {
+			frs, err := c.ForwardingRules().List(ctx, region, filter.None, opt)
+			if err != nil {
+				return nil, fmt.Errorf("ForwardingRules.List(%s): %w", region, err)
+			}
+			for _, x := range frs {
+				add(forwardingrule.ID, meta.RegionalKey(x.Name, region))
+			}
+			thps, err := c.RegionTargetHttpProxies().List(ctx, region, filter.None, opt)
+			if err != nil {
+				return nil, fmt.Errorf("RegionTargetHttpProxies.List(%s): %w", region, err)
+			}
+			for _, x := range thps {
+				add(targethttpproxy.ID, meta.RegionalKey(x.Name, region))
+			}
+			thsps, err := c.RegionTargetHttpsProxies().List(ctx, region, filter.None, opt)
+			if err != nil {
+				return nil, fmt.Errorf("RegionTargetHttpsProxies.List(%s): %w", region, err)
+			}
+			for _, x := range thsps {
+				add(targethttpsproxy.ID, meta.RegionalKey(x.Name, region))
+			}
+			ums, err := c.RegionUrlMaps().List(ctx, region, filter.None, opt)
+			if err != nil {
+				return nil, fmt.Errorf("RegionUrlMaps.List(%s): %w", region, err)
+			}
+			for _, x := range ums {
+				add(urlmap.ID, meta.RegionalKey(x.Name, region))
+			}
+			bss, err := c.RegionBackendServices().List(ctx, region, filter.None, opt)
+			if err != nil {
+				return nil, fmt.Errorf("RegionBackendServices.List(%s): %w", region, err)
+			}
+			for _, x := range bss {
+				add(backendservice.ID, meta.RegionalKey(x.Name, region))
+			}
+			hcs, err := c.RegionHealthChecks().List(ctx, region, filter.None, opt)
+			if err != nil {
+				return nil, fmt.Errorf("RegionHealthChecks.List(%s): %w", region, err)
+			}
+			for _, x := range hcs {
+				add(healthcheck.ID, meta.RegionalKey(x.Name, region))
+			}
+		}
+		sort.Slice(ret, func(i, j int) bool { return ret[i].String() < ret[j].String() })
+
+		return ret, nil
+	}
+}
+
+// addOrphans adds the resources from the CollectOrphans lister that carry the
+// OwnerMarker but are not in the "want" graph to gotBuilder. The orphans are
+// then planned for deletion like any other managed resource that is no longer
+// referenced.
+func (pl *planner) addOrphans(ctx context.Context, gotBuilder *rgraph.Builder) error {
+	if pl.config.ownerMarker == nil {
+		return fmt.Errorf("%s: CollectOrphans requires OwnerMarker", errPrefix)
+	}
+	ids, err := pl.config.listOrphans(ctx, pl.cloud)
+	if err != nil {
+		return fmt.Errorf("%s: CollectOrphans: %w", errPrefix, err)
+	}
+	for _, id := range ids {
+		if pl.want.Get(id) != nil || gotBuilder.Get(id) != nil {
+			continue
+		}
+		if err := canceled(ctx); err != nil {
+			return err
+		}
+		nb, err := all.NewBuilderByID(id)
+		if err != nil {
+			return fmt.Errorf("%s: CollectOrphans: %w", errPrefix, err)
+		}
+		if err := nb.SyncFromCloud(ctx, pl.cloudFor(id)); err != nil {
+			return fmt.Errorf("%s: CollectOrphans: %w", errPrefix, err)
+		}
+		// The resource may have been deleted since it was listed.
+		if nb.State() != rnode.NodeExists || !pl.config.ownerMarker.Owns(nb.Resource()) {
+			continue
+		}
+		nb.SetOwnership(rnode.OwnershipManaged)
+		gotBuilder.Add(nb)
+	}
+	return nil
+}
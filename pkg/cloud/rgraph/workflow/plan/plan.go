@@ -18,7 +18,10 @@ package plan
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
@@ -27,6 +30,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/algo/traversal"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/algo/trclosure"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/metrics"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 )
 
@@ -36,22 +40,180 @@ type Result struct {
 	Actions []exec.Action
 }
 
+// HasChanges returns true if any of the nodes will be changed by the plan.
+func (r *Result) HasChanges() bool {
+	for _, n := range r.Want.All() {
+		if n.Plan().Op() != rnode.OpNothing {
+			return true
+		}
+	}
+	return false
+}
+
+// Option for Do.
+type Option func(c *config)
+
+// InRefLookupFunc returns the resources that currently reference the resource
+// id in the Cloud.
+type InRefLookupFunc func(ctx context.Context, c cloud.Cloud, id *cloud.ResourceID) ([]*cloud.ResourceID, error)
+
+// InRefLookup is called for each resource that will be deleted or recreated to
+// find references from resources that are not in the graph (e.g. with a
+// List() or an inventory). The plan fails if a resource is still referenced.
+func InRefLookup(f InRefLookupFunc) Option {
+	return func(c *config) { c.inRefLookup = f }
+}
+
+// OwnerMarker is stamped on the resources that are created. Resources that
+// are no longer referenced by the graph are only deleted if they carry the
+// marker; the others are left as-is.
+func OwnerMarker(m *rnode.OwnerMarker) Option {
+	return func(c *config) { c.ownerMarker = m }
+}
+
+// CollectOrphans deletes the resources returned by list that carry the
+// OwnerMarker but are no longer in the graph, e.g. the resources of nodes that
+// were renamed or removed. Requires OwnerMarker().
+func CollectOrphans(list OrphanListFunc) Option {
+	return func(c *config) { c.listOrphans = list }
+}
+
+// Targets restricts the plan to the given nodes and their transitive
+// dependencies (similar to `terraform apply -target`). Nodes outside of the
+// targets are not changed. The plan fails if a change to a target requires a
+// change to a node that is not targeted, e.g. the recreate of a resource that
+// is referenced by a node outside of the targets.
+func Targets(ids ...*cloud.ResourceID) Option {
+	return func(c *config) { c.targets = append(c.targets, ids...) }
+}
+
+// CloudRouter fetches and checks each resource with the Cloud for its project,
+// e.g. for graphs that span the host and service projects of a shared VPC.
+// The Cloud passed to Do() is used for the projects that are not routed. Use
+// exec.CloudRouterOption() to execute the plan with the same routing.
+func CloudRouter(r exec.CloudRouter) Option {
+	return func(c *config) { c.router = r }
+}
+
+// DrainBeforeDelete delays the deletion of a resource by d after an updated
+// resource drops its reference to it, e.g. a NetworkEndpointGroup removed
+// from a BackendService or a BackendService replaced in a UrlMap. This
+// gives in-flight connections time to drain (see the BackendService
+// .ConnectionDraining) so that traffic is not dropped mid-apply. Set
+// exec.ActionTimeoutOption() to at least d.
+func DrainBeforeDelete(d time.Duration) Option {
+	return func(c *config) { c.drain = d }
+}
+
+// Metrics reports the duration of planning and the number of nodes by
+// planned operation to the Recorder. Use exec.MetricsOption() for the
+// metrics of the execution.
+func Metrics(r metrics.Recorder) Option {
+	return func(c *config) { c.metrics = r }
+}
+
+type config struct {
+	router      exec.CloudRouter
+	metrics     metrics.Recorder
+	inRefLookup InRefLookupFunc
+	ownerMarker *rnode.OwnerMarker
+	listOrphans OrphanListFunc
+	targets     []*cloud.ResourceID
+	quotaMetric QuotaMetricFunc
+	drain       time.Duration
+	annotate    []AnnotateFunc
+	inject      []InjectFunc
+
+	immutableFields ImmutableFieldPolicy
+}
+
 // Do will plan updates to cloud resources wanted in graph. Returns the set of
 // Actions needed to sync to "want".
-func Do(ctx context.Context, c cloud.Cloud, want *rgraph.Graph) (*Result, error) {
+//
+// If ctx is done before planning finishes, Do returns the error of ctx with
+// a Result that has the nodes planned so far and no Actions. Nodes that were
+// not planned have OpUnknown and Got is nil if the current state of the
+// resources was not fetched.
+func Do(ctx context.Context, c cloud.Cloud, want *rgraph.Graph, opts ...Option) (*Result, error) {
 	w := planner{
 		cloud: c,
 		want:  want,
 	}
-	return w.plan(ctx)
+	for _, o := range opts {
+		o(&w.config)
+	}
+
+	start := time.Now()
+	result, err := w.plan(ctx)
+	if err != nil && errors.Is(err, ctx.Err()) {
+		result = &Result{Got: w.got, Want: w.want}
+	}
+	if w.config.metrics == nil {
+		return result, err
+	}
+
+	m := metrics.Plan{Duration: time.Since(start), Err: err}
+	if err == nil {
+		m.Nodes = map[string]int{}
+		for _, n := range result.Want.All() {
+			m.Nodes[string(n.Plan().Op())]++
+		}
+	}
+	w.config.metrics.RecordPlan(m)
+	return result, err
+}
+
+// Destroy will plan the deletion of all of the managed resources in graph.
+// The resources are deleted in dependency order, i.e. a resource is deleted
+// only after the resources that reference it. External resources are left
+// as-is and will cause the plan to fail if they reference a resource that is
+// deleted. The Options are the same as for Do(), e.g. InRefLookup() will
+// check for references from resources outside of the graph.
+func Destroy(ctx context.Context, c cloud.Cloud, g *rgraph.Graph, opts ...Option) (*Result, error) {
+	b := rgraph.NewBuilder()
+	for _, n := range g.All() {
+		nb := n.Builder()
+		nb.SetVersion(n.Version())
+		switch n.Ownership() {
+		case rnode.OwnershipManaged:
+			nb.SetState(rnode.NodeDoesNotExist)
+		case rnode.OwnershipExternal:
+			if err := nb.SetResource(n.Resource()); err != nil {
+				return nil, fmt.Errorf("%s: Destroy: %w", errPrefix, err)
+			}
+		default:
+			return nil, fmt.Errorf("%s: Destroy: node %s has invalid ownership %s", errPrefix, n.ID(), n.Ownership())
+		}
+		b.Add(nb)
+	}
+	want, err := b.Build()
+	if err != nil {
+		return nil, fmt.Errorf("%s: Destroy: %w", errPrefix, err)
+	}
+	return Do(ctx, c, want, opts...)
 }
 
 const errPrefix = "Plan"
 
+// canceled returns an error if the context is done. Planning is stopped
+// between the calls to the Cloud so that callers can abort cleanly.
+func canceled(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	return nil
+}
+
 type planner struct {
-	cloud cloud.Cloud
-	got   *rgraph.Graph
-	want  *rgraph.Graph
+	config config
+	cloud  cloud.Cloud
+	got    *rgraph.Graph
+	want   *rgraph.Graph
+}
+
+// cloudFor returns the Cloud for the resource id.
+func (pl *planner) cloudFor(id *cloud.ResourceID) cloud.Cloud {
+	return pl.config.router.For(id, pl.cloud)
 }
 
 func (pl *planner) plan(ctx context.Context) (*Result, error) {
@@ -60,10 +222,24 @@ func (pl *planner) plan(ctx context.Context) (*Result, error) {
 	// are not in the "want" graph.
 	gotBuilder := pl.want.NewBuilderWithEmptyNodes()
 
+	if pl.config.listOrphans != nil {
+		if err := pl.addOrphans(ctx, gotBuilder); err != nil {
+			return nil, err
+		}
+	}
+
 	// Fetch the current resource graph from Cloud.
 	// TODO: resource_prefix, ownership due to prefix etc.
 	err := trclosure.Do(ctx, pl.cloud, gotBuilder,
+		trclosure.CloudRouter(pl.config.router),
 		trclosure.OnGetFunc(func(n rnode.Builder) error {
+			// Resources that are not in the graph are only managed if they
+			// carry the OwnerMarker.
+			m := pl.config.ownerMarker
+			if m != nil && pl.want.Get(n.ID()) == nil && !m.Owns(n.Resource()) {
+				n.SetOwnership(rnode.OwnershipExternal)
+				return nil
+			}
 			n.SetOwnership(rnode.OwnershipManaged)
 			return nil
 		}),
@@ -71,6 +247,9 @@ func (pl *planner) plan(ctx context.Context) (*Result, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := canceled(ctx); err != nil {
+		return nil, err
+	}
 
 	pl.got, err = gotBuilder.Build()
 	if err != nil {
@@ -84,7 +263,18 @@ func (pl *planner) plan(ctx context.Context) (*Result, error) {
 		case pl.want.Get(gotNode.ID()) != nil:
 			// Node exists in "want", don't need to do anything.
 		case gotNode.Ownership() == rnode.OwnershipExternal:
-			// TODO: clone the node from the "got" graph for "want" unchanged.
+			// Clone the node from the "got" graph for "want" unchanged.
+			wantNodeBuilder := gotNode.Builder()
+			if err := wantNodeBuilder.SetResource(gotNode.Resource()); err != nil {
+				return nil, err
+			}
+			wantNode, err := wantNodeBuilder.Build()
+			if err != nil {
+				return nil, err
+			}
+			if err := pl.want.AddExternal(wantNode); err != nil {
+				return nil, err
+			}
 		case gotNode.Ownership() == rnode.OwnershipManaged:
 			// Nodes that are no longer referenced should be deleted.
 			wantNodeBuilder := gotNode.Builder()
@@ -102,23 +292,71 @@ func (pl *planner) plan(ctx context.Context) (*Result, error) {
 		}
 	}
 
+	if pl.config.ownerMarker != nil {
+		for _, n := range pl.want.All() {
+			n.Plan().SetOwnerMarker(pl.config.ownerMarker)
+		}
+	}
+
 	// Compute the local plan for each resource.
 	if err := localplan.PlanWantGraph(pl.got, pl.want); err != nil {
 		return nil, err
 	}
 
+	if err := pl.applyImmutableFieldPolicy(); err != nil {
+		return nil, err
+	}
+
 	if err := pl.propagateRecreates(); err != nil {
 		return nil, err
 	}
 
+	if len(pl.config.targets) > 0 {
+		if err := pl.restrictToTargets(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pl.checkInRefs(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := pl.checkPreconditions(ctx); err != nil {
+		return nil, err
+	}
+
 	if err := pl.sanityCheck(); err != nil {
 		return nil, err
 	}
 
+	if pl.config.quotaMetric != nil {
+		if err := pl.checkQuota(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	acts, err := actions.Do(pl.got, pl.want)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errPrefix, err)
 	}
+	if pl.config.drain > 0 {
+		acts, err = pl.addDrains(acts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Actions are injected after coalescing as the merged Actions do not
+	// keep the events added by exec.SignalAfter().
+	acts = exec.Coalesce(acts)
+	if len(pl.config.inject) > 0 {
+		acts, err = pl.inject(acts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := pl.annotate(acts); err != nil {
+		return nil, err
+	}
 	return &Result{
 		Got:     pl.got,
 		Want:    pl.want,
@@ -126,6 +364,33 @@ func (pl *planner) plan(ctx context.Context) (*Result, error) {
 	}, nil
 }
 
+// addDrains makes the deletion of resources wait for the connections to
+// drain after the references from updated resources are dropped. See
+// DrainBeforeDelete().
+func (pl *planner) addDrains(acts []exec.Action) ([]exec.Action, error) {
+	for _, n := range pl.want.All() {
+		if n.Plan().Op() != rnode.OpDelete {
+			continue
+		}
+		gotNode := pl.got.Get(n.ID())
+		if gotNode == nil {
+			continue
+		}
+		for _, ref := range gotNode.InRefs() {
+			from := pl.want.Get(ref.From)
+			// Traffic only flows through the resources that remain.
+			if from == nil || from.Plan().Op() != rnode.OpUpdate {
+				continue
+			}
+			acts = append(acts, exec.NewDrainAction(ref.From, ref.To, pl.config.drain))
+			if err := exec.Gate(acts, exec.NewDrainedEvent(ref.From, ref.To), ref.To); err != nil {
+				return nil, fmt.Errorf("%s: %w", errPrefix, err)
+			}
+		}
+	}
+	return acts, nil
+}
+
 // propagateRecreates through inbound references. If a resource needs to be
 // recreated, this means any references will also be affected transitively.
 func (pl *planner) propagateRecreates() error {
@@ -171,6 +436,47 @@ func (pl *planner) propagateRecreates() error {
 	return nil
 }
 
+// restrictToTargets sets the plan of the nodes that are not reachable from the
+// targets to OpNothing. The out refs are followed in both the "want" and "got"
+// graphs so that resources that are no longer referenced by a target are
+// deleted as part of the change.
+func (pl *planner) restrictToTargets() error {
+	targeted := map[cloud.ResourceMapKey]bool{}
+	var queue []*cloud.ResourceID
+	for _, id := range pl.config.targets {
+		if pl.want.Get(id) == nil {
+			return fmt.Errorf("%s: target %v is not in the graph", errPrefix, id)
+		}
+		queue = append(queue, id)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if targeted[id.MapKey()] {
+			continue
+		}
+		targeted[id.MapKey()] = true
+		for _, g := range []*rgraph.Graph{pl.want, pl.got} {
+			if n := g.Get(id); n != nil {
+				for _, ref := range n.OutRefs() {
+					queue = append(queue, ref.To)
+				}
+			}
+		}
+	}
+
+	for _, n := range pl.want.All() {
+		if targeted[n.ID().MapKey()] || n.Plan().Op() == rnode.OpNothing {
+			continue
+		}
+		n.Plan().Set(rnode.PlanDetails{
+			Operation: rnode.OpNothing,
+			Why:       fmt.Sprintf("Not targeted (planned op was %s)", n.Plan().Op()),
+		})
+	}
+	return nil
+}
+
 func (pl *planner) sanityCheck() error {
 	for _, n := range pl.want.All() {
 		switch n.Plan().Op() {
@@ -179,7 +485,7 @@ func (pl *planner) sanityCheck() error {
 		case rnode.OpDelete:
 			// If A => B; if B is to be deleted, then A must be deleted.
 			for _, refs := range n.InRefs() {
-				if inNode := pl.want.Get(refs.To); inNode == nil {
+				if inNode := pl.want.Get(refs.From); inNode == nil {
 					return fmt.Errorf("%s: inRef from node %v that doesn't exist", errPrefix, refs.From)
 				} else if inNode.Plan().Op() != rnode.OpDelete {
 					return fmt.Errorf("%s: %v to be deleted, but inRef %v is not", errPrefix, n.ID(), inNode.ID())
@@ -190,3 +496,111 @@ func (pl *planner) sanityCheck() error {
 
 	return nil
 }
+
+// checkPreconditions evaluates the rnode.Preconditions of the nodes for the
+// planned operations. The checks are read-only so the plan fails before any
+// resource is changed.
+func (pl *planner) checkPreconditions(ctx context.Context) error {
+	nodes := pl.want.All()
+	// Sort the nodes so that the errors are in a stable order.
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID().String() < nodes[j].ID().String() })
+
+	var errs []error
+	for _, n := range nodes {
+		if err := canceled(ctx); err != nil {
+			return err
+		}
+		if err := rnode.CheckPreconditions(ctx, pl.cloudFor(n.ID()), n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s: %w", errPrefix, errors.Join(errs...))
+	}
+	return nil
+}
+
+// checkInRefs checks that the resources that will be deleted (including the
+// ones that are recreated) are not referenced by resources that will remain.
+// The references come from the "got" and "want" graphs, from nodes that list
+// their users (see rnode.UsedByNode) and from the InRefLookup option.
+//
+// References from nodes in the graph that are deleted, recreated or updated to
+// drop the reference are ordered by the DropRef events of the actions.
+func (pl *planner) checkInRefs(ctx context.Context) error {
+	for _, n := range pl.want.All() {
+		op := n.Plan().Op()
+		if op != rnode.OpDelete && op != rnode.OpRecreate {
+			continue
+		}
+		if err := canceled(ctx); err != nil {
+			return err
+		}
+		refs, err := pl.inRefs(ctx, n)
+		if err != nil {
+			return err
+		}
+		for _, from := range refs {
+			inNode := pl.want.Get(from)
+			if inNode == nil {
+				return fmt.Errorf("%s: %v planned for %s, but is still referenced by %v (not in the graph)", errPrefix, n.ID(), op, from)
+			}
+			switch inNode.Plan().Op() {
+			case rnode.OpDelete, rnode.OpRecreate:
+				// The reference is removed before the resource is deleted.
+				continue
+			case rnode.OpCreate:
+				// The reference is added after the resource is created.
+				continue
+			case rnode.OpNothing:
+				// The reference is not changed.
+				return fmt.Errorf("%s: %v planned for %s, but is still referenced by %v (op=%s)", errPrefix, n.ID(), op, from, inNode.Plan().Op())
+			}
+			// The update must remove the reference.
+			for _, ref := range inNode.OutRefs() {
+				if ref.To.Equal(n.ID()) {
+					return fmt.Errorf("%s: %v planned for %s, but is still referenced by %v (op=%s)", errPrefix, n.ID(), op, from, inNode.Plan().Op())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// inRefs returns the resources that reference n.
+func (pl *planner) inRefs(ctx context.Context, n rnode.Node) ([]*cloud.ResourceID, error) {
+	var ret []*cloud.ResourceID
+	seen := map[cloud.ResourceMapKey]bool{n.ID().MapKey(): true}
+	add := func(ids ...*cloud.ResourceID) {
+		for _, id := range ids {
+			if !seen[id.MapKey()] {
+				seen[id.MapKey()] = true
+				ret = append(ret, id)
+			}
+		}
+	}
+
+	for _, ref := range n.InRefs() {
+		add(ref.From)
+	}
+	if gotNode := pl.got.Get(n.ID()); gotNode != nil {
+		for _, ref := range gotNode.InRefs() {
+			add(ref.From)
+		}
+		if ub, ok := gotNode.(rnode.UsedByNode); ok && gotNode.State() == rnode.NodeExists {
+			ids, err := ub.UsedBy()
+			if err != nil {
+				return nil, fmt.Errorf("%s: UsedBy %v: %w", errPrefix, n.ID(), err)
+			}
+			add(ids...)
+		}
+	}
+	if pl.config.inRefLookup != nil {
+		ids, err := pl.config.inRefLookup(ctx, pl.cloudFor(n.ID()), n.ID())
+		if err != nil {
+			return nil, fmt.Errorf("%s: InRefLookup %v: %w", errPrefix, n.ID(), err)
+		}
+		add(ids...)
+	}
+	return ret, nil
+}
@@ -18,22 +18,32 @@ package plan
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	mockcloud "github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/mock"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/algo/graphviz"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/metrics"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/address"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/backendservice"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/firewall"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/healthcheck"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/networkendpointgroup"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"github.com/google/go-cmp/cmp"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -127,7 +137,7 @@ func TestLB(t *testing.T) {
 		t.Fatalf("Build() = %v, want nil", err)
 	}
 
-	res, err := Do(context.Background(), mock, want)
+	res, err := Do(context.Background(), mock, want, ImmutableFields(ImmutableFieldsRecreate))
 	if err != nil {
 		t.Fatalf("Do() = %v, want nil", err)
 	}
@@ -150,3 +160,1001 @@ func TestLB(t *testing.T) {
 	t.Logf("got: %s", graphviz.Do(res.Got))
 	t.Logf("want: %s", graphviz.Do(res.Want))
 }
+
+func TestInRefs(t *testing.T) {
+	const (
+		proj  = "proj"
+		frURL = "https://www.googleapis.com/compute/v1/projects/proj/global/forwardingRules/fr"
+	)
+	key := meta.GlobalKey("addr")
+	frID := forwardingrule.ID(proj, meta.GlobalKey("fr"))
+
+	for _, tc := range []struct {
+		name    string
+		users   []string
+		lookup  InRefLookupFunc
+		wantErr bool
+	}{
+		{name: "no references"},
+		{name: "UsedBy outside of the graph", users: []string{frURL}, wantErr: true},
+		{
+			name: "lookup outside of the graph",
+			lookup: func(context.Context, cloud.Cloud, *cloud.ResourceID) ([]*cloud.ResourceID, error) {
+				return []*cloud.ResourceID{frID}, nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "lookup with no references",
+			lookup: func(context.Context, cloud.Cloud, *cloud.ResourceID) ([]*cloud.ResourceID, error) {
+				return nil, nil
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			mock.GlobalAddresses().Insert(context.Background(), key, &compute.Address{
+				Name:    "addr",
+				Address: "1.2.3.4",
+				Users:   tc.users,
+			})
+
+			// Changing .Address recreates the resource.
+			m := address.NewMutableAddress(proj, key)
+			m.Access(func(x *compute.Address) {
+				x.Name = "addr"
+				x.Address = "1.2.3.5"
+			})
+			r, err := m.Freeze()
+			if err != nil {
+				t.Fatalf("Freeze() = %v, want nil", err)
+			}
+			b := address.NewBuilderWithResource(r)
+			b.SetOwnership(rnode.OwnershipManaged)
+			b.SetState(rnode.NodeExists)
+			gr := rgraph.NewBuilder()
+			gr.Add(b)
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			// The Address is recreated.
+			opts := []Option{ImmutableFields(ImmutableFieldsRecreate)}
+			if tc.lookup != nil {
+				opts = append(opts, InRefLookup(tc.lookup))
+			}
+			res, err := Do(context.Background(), mock, want, opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				t.Log(err)
+				return
+			}
+			if op := res.Want.Get(b.ID()).Plan().Op(); op != rnode.OpRecreate {
+				t.Errorf("op = %s, want %s", op, rnode.OpRecreate)
+			}
+		})
+	}
+}
+
+func TestOwnerMarker(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	marker := &rnode.OwnerMarker{Description: "managed-by: test"}
+
+	for _, tc := range []struct {
+		name string
+		// oldDesc is the .Description of the UrlMap that is no longer
+		// referenced.
+		oldDesc    string
+		wantOldOp  rnode.Operation
+		wantOldOwn rnode.OwnershipStatus
+	}{
+		{
+			name:       "owned resource is deleted",
+			oldDesc:    marker.Description,
+			wantOldOp:  rnode.OpDelete,
+			wantOldOwn: rnode.OwnershipManaged,
+		},
+		{
+			name:       "other resource is not changed",
+			oldDesc:    "other",
+			wantOldOp:  rnode.OpNothing,
+			wantOldOwn: rnode.OwnershipExternal,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			mock.TargetHttpProxies().Insert(context.Background(), meta.GlobalKey("tp"), &compute.TargetHttpProxy{
+				UrlMap: b.N("um-old").UrlMap().SelfLink(),
+			})
+			mock.UrlMaps().Insert(context.Background(), meta.GlobalKey("um-old"), &compute.UrlMap{Description: tc.oldDesc})
+
+			gr := rgraph.NewBuilder()
+			tpm := b.N("tp").TargetHttpProxy().Resource()
+			tpm.Access(func(x *compute.TargetHttpProxy) {
+				x.UrlMap = b.N("um").UrlMap().SelfLink()
+			})
+			tpr, _ := tpm.Freeze()
+			umr, _ := b.N("um").UrlMap().Resource().Freeze()
+			for _, nb := range []rnode.Builder{
+				targethttpproxy.NewBuilderWithResource(tpr),
+				urlmap.NewBuilderWithResource(umr),
+			} {
+				nb.SetOwnership(rnode.OwnershipManaged)
+				nb.SetState(rnode.NodeExists)
+				gr.Add(nb)
+			}
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			res, err := Do(context.Background(), mock, want, OwnerMarker(marker), ImmutableFields(ImmutableFieldsRecreate))
+			if err != nil {
+				t.Fatalf("Do() = %v, want nil", err)
+			}
+			old := res.Want.Get(b.N("um-old").UrlMap().ID())
+			if old == nil {
+				t.Fatalf("um-old is not in the want graph")
+			}
+			if op := old.Plan().Op(); op != tc.wantOldOp {
+				t.Errorf("um-old op = %s, want %s", op, tc.wantOldOp)
+			}
+			if own := old.Ownership(); own != tc.wantOldOwn {
+				t.Errorf("um-old ownership = %s, want %s", own, tc.wantOldOwn)
+			}
+
+			ex, err := exec.NewSerialExecutor(res.Actions)
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background(), mock); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			um, err := mock.UrlMaps().Get(context.Background(), meta.GlobalKey("um"))
+			if err != nil {
+				t.Fatalf("Get() = %v, want nil", err)
+			}
+			if um.Description != marker.Description {
+				t.Errorf("um.Description = %q, want %q", um.Description, marker.Description)
+			}
+		})
+	}
+}
+
+func TestCollectOrphans(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	marker := &rnode.OwnerMarker{Description: "managed-by: test"}
+
+	for _, tc := range []struct {
+		name    string
+		marker  *rnode.OwnerMarker
+		wantErr bool
+	}{
+		{
+			name:   "owned orphan is deleted",
+			marker: marker,
+		},
+		{
+			name:    "error: no OwnerMarker",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			// um-old was created for a node that has been renamed to um.
+			mock.UrlMaps().Insert(context.Background(), meta.GlobalKey("um-old"), &compute.UrlMap{Description: marker.Description})
+			mock.UrlMaps().Insert(context.Background(), meta.GlobalKey("um-other"), &compute.UrlMap{Description: "other"})
+
+			umr, _ := b.N("um").UrlMap().Resource().Freeze()
+			nb := urlmap.NewBuilderWithResource(umr)
+			nb.SetOwnership(rnode.OwnershipManaged)
+			nb.SetState(rnode.NodeExists)
+			gr := rgraph.NewBuilder()
+			gr.Add(nb)
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			opts := []Option{CollectOrphans(ListLBResources(proj, nil))}
+			if tc.marker != nil {
+				opts = append(opts, OwnerMarker(tc.marker))
+			}
+			res, err := Do(context.Background(), mock, want, opts...)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			old := res.Want.Get(b.N("um-old").UrlMap().ID())
+			if old == nil {
+				t.Fatalf("um-old is not in the want graph")
+			}
+			if op := old.Plan().Op(); op != rnode.OpDelete {
+				t.Errorf("um-old op = %s, want %s", op, rnode.OpDelete)
+			}
+			if n := res.Want.Get(b.N("um-other").UrlMap().ID()); n != nil {
+				t.Errorf("um-other is in the want graph, want not present")
+			}
+
+			ex, err := exec.NewSerialExecutor(res.Actions)
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background(), mock); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			ums, err := mock.UrlMaps().List(context.Background(), filter.None)
+			if err != nil {
+				t.Fatalf("List() = %v, want nil", err)
+			}
+			var names []string
+			for _, um := range ums {
+				names = append(names, um.Name)
+			}
+			sort.Strings(names)
+			if diff := cmp.Diff(names, []string{"um", "um-other"}); diff != "" {
+				t.Errorf("UrlMaps: -got,+want: %s", diff)
+			}
+		})
+	}
+}
+
+func TestOwnerMarkerLabelUpdate(t *testing.T) {
+	const proj = "proj"
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: proj}
+	marker := &rnode.OwnerMarker{Labels: map[string]string{"managed-by": "test"}}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.MockGlobalForwardingRules.SetLabelsHook = func(_ context.Context, key *meta.Key, req *compute.GlobalSetLabelsRequest, m *cloud.MockGlobalForwardingRules, _ ...cloud.Option) error {
+		m.Lock.Lock()
+		defer m.Lock.Unlock()
+		obj := m.Objects[*key].ToGA()
+		obj.Labels = req.Labels
+		m.Objects[*key] = m.Obj(obj)
+		return nil
+	}
+
+	// sync plans and runs the graph with fr (if frLabels is not nil) and
+	// um. Returns the op planned for fr.
+	sync := func(frLabels map[string]string, opts ...Option) rnode.Operation {
+		t.Helper()
+		gr := rgraph.NewBuilder()
+		umr, _ := b.N("um").UrlMap().Resource().Freeze()
+		builders := []rnode.Builder{urlmap.NewBuilderWithResource(umr)}
+		if frLabels != nil {
+			frm := b.N("fr").ForwardingRule().Resource()
+			frm.Access(func(x *compute.ForwardingRule) { x.Labels = frLabels })
+			frr, _ := frm.Freeze()
+			builders = append(builders, forwardingrule.NewBuilderWithResource(frr))
+		}
+		for _, nb := range builders {
+			nb.SetOwnership(rnode.OwnershipManaged)
+			nb.SetState(rnode.NodeExists)
+			gr.Add(nb)
+		}
+		want, err := gr.Build()
+		if err != nil {
+			t.Fatalf("Build() = %v, want nil", err)
+		}
+		res, err := Do(ctx, mock, want, append([]Option{OwnerMarker(marker)}, opts...)...)
+		if err != nil {
+			t.Fatalf("Do() = %v, want nil", err)
+		}
+		ex, err := exec.NewSerialExecutor(res.Actions)
+		if err != nil {
+			t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+		}
+		if _, err := ex.Run(ctx, mock); err != nil {
+			t.Fatalf("Run() = %v, want nil", err)
+		}
+		n := res.Want.Get(b.N("fr").ForwardingRule().ID())
+		if n == nil {
+			return rnode.OpNothing
+		}
+		return n.Plan().Op()
+	}
+	checkLabels := func(want map[string]string) {
+		t.Helper()
+		fr, err := mock.GlobalForwardingRules().Get(ctx, meta.GlobalKey("fr"))
+		if err != nil {
+			t.Fatalf("Get() = %v, want nil", err)
+		}
+		if diff := cmp.Diff(fr.Labels, want); diff != "" {
+			t.Errorf("fr.Labels: -got,+want: %s", diff)
+		}
+	}
+
+	if op := sync(map[string]string{"a": "b"}); op != rnode.OpCreate {
+		t.Fatalf("create: fr op = %s, want %s", op, rnode.OpCreate)
+	}
+	checkLabels(map[string]string{"a": "b", "managed-by": "test"})
+
+	// The marker is kept when the labels are updated.
+	if op := sync(map[string]string{"a": "c"}); op != rnode.OpUpdate {
+		t.Fatalf("update: fr op = %s, want %s", op, rnode.OpUpdate)
+	}
+	checkLabels(map[string]string{"a": "c", "managed-by": "test"})
+	if op := sync(map[string]string{"a": "c"}); op != rnode.OpNothing {
+		t.Fatalf("after update: fr op = %s, want %s", op, rnode.OpNothing)
+	}
+
+	// fr is removed from the graph and is collected as an orphan.
+	if op := sync(nil, CollectOrphans(ListLBResources(proj, nil))); op != rnode.OpDelete {
+		t.Fatalf("orphan: fr op = %s, want %s", op, rnode.OpDelete)
+	}
+	if _, err := mock.GlobalForwardingRules().Get(ctx, meta.GlobalKey("fr")); err == nil {
+		t.Errorf("fr exists after the orphan was collected, want deleted")
+	}
+}
+
+func TestTargets(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+
+	for _, tc := range []struct {
+		name    string
+		targets []*cloud.ResourceID
+		wantOps map[string]rnode.Operation
+		wantErr bool
+	}{
+		{
+			name:    "target with dependency",
+			targets: []*cloud.ResourceID{b.N("tp").TargetHttpProxy().ID()},
+			wantOps: map[string]rnode.Operation{
+				"tp":   rnode.OpCreate,
+				"um-a": rnode.OpCreate,
+				"um-b": rnode.OpNothing,
+			},
+		},
+		{
+			name:    "target leaf",
+			targets: []*cloud.ResourceID{b.N("um-b").UrlMap().ID()},
+			wantOps: map[string]rnode.Operation{
+				"tp":   rnode.OpNothing,
+				"um-a": rnode.OpNothing,
+				"um-b": rnode.OpCreate,
+			},
+		},
+		{
+			name:    "error: target not in graph",
+			targets: []*cloud.ResourceID{b.N("um-c").UrlMap().ID()},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+
+			gr := rgraph.NewBuilder()
+			tpm := b.N("tp").TargetHttpProxy().Resource()
+			tpm.Access(func(x *compute.TargetHttpProxy) {
+				x.UrlMap = b.N("um-a").UrlMap().SelfLink()
+			})
+			tpr, _ := tpm.Freeze()
+			umar, _ := b.N("um-a").UrlMap().Resource().Freeze()
+			umbr, _ := b.N("um-b").UrlMap().Resource().Freeze()
+			for _, nb := range []rnode.Builder{
+				targethttpproxy.NewBuilderWithResource(tpr),
+				urlmap.NewBuilderWithResource(umar),
+				urlmap.NewBuilderWithResource(umbr),
+			} {
+				nb.SetOwnership(rnode.OwnershipManaged)
+				nb.SetState(rnode.NodeExists)
+				gr.Add(nb)
+			}
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			res, err := Do(context.Background(), mock, want, Targets(tc.targets...))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			gotOps := map[string]rnode.Operation{}
+			for _, n := range res.Want.All() {
+				gotOps[n.ID().Key.Name] = n.Plan().Op()
+			}
+			if diff := cmp.Diff(gotOps, tc.wantOps); diff != "" {
+				t.Errorf("ops: -got,+want: %s", diff)
+			}
+
+			ex, err := exec.NewSerialExecutor(res.Actions)
+			if err != nil {
+				t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+			}
+			if _, err := ex.Run(context.Background(), mock); err != nil {
+				t.Fatalf("Run() = %v, want nil", err)
+			}
+			for name, op := range tc.wantOps {
+				_, err := mock.UrlMaps().Get(context.Background(), meta.GlobalKey(name))
+				if name == "tp" {
+					_, err = mock.TargetHttpProxies().Get(context.Background(), meta.GlobalKey(name))
+				}
+				if exists := err == nil; exists != (op == rnode.OpCreate) {
+					t.Errorf("%s exists = %t, want %t", name, exists, op == rnode.OpCreate)
+				}
+			}
+		})
+	}
+}
+
+func TestPreconditions(t *testing.T) {
+	const proj = "proj"
+	key := meta.GlobalKey("addr")
+
+	for _, tc := range []struct {
+		name string
+		// exists is true if the address is in the Cloud with the same
+		// value (op = Nothing).
+		exists  bool
+		wantErr bool
+	}{
+		{name: "create", wantErr: true},
+		{name: "no change", exists: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			if tc.exists {
+				mock.GlobalAddresses().Insert(context.Background(), key, &compute.Address{Name: "addr", Address: "1.2.3.4"})
+			}
+			m := address.NewMutableAddress(proj, key)
+			m.Access(func(x *compute.Address) {
+				x.Name = "addr"
+				x.Address = "1.2.3.4"
+			})
+			r, err := m.Freeze()
+			if err != nil {
+				t.Fatalf("Freeze() = %v, want nil", err)
+			}
+			b := address.NewBuilderWithResource(r)
+			b.SetOwnership(rnode.OwnershipManaged)
+			b.SetState(rnode.NodeExists)
+			b.AddPrecondition(rnode.Precondition{
+				Name: "always fails",
+				Check: func(context.Context, cloud.Cloud, rnode.Node) error {
+					return fmt.Errorf("injected")
+				},
+			})
+			gr := rgraph.NewBuilder()
+			gr.Add(b)
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			_, err = Do(context.Background(), mock, want)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRollback(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	ctx := context.Background()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.Firewalls().Insert(ctx, meta.GlobalKey("fw"), &compute.Firewall{Description: "old"})
+	mock.MockTargetHttpProxies.InsertHook = func(context.Context, *meta.Key, *compute.TargetHttpProxy, *cloud.MockTargetHttpProxies, ...cloud.Option) (bool, error) {
+		return true, fmt.Errorf("injected error")
+	}
+
+	gr := rgraph.NewBuilder()
+	fwm := b.N("fw").Firewall().Resource()
+	fwm.Access(func(x *compute.Firewall) { x.Description = "new" })
+	fwr, _ := fwm.Freeze()
+	tpm := b.N("tp").TargetHttpProxy().Resource()
+	tpm.Access(func(x *compute.TargetHttpProxy) {
+		x.UrlMap = b.N("um").UrlMap().SelfLink()
+	})
+	tpr, _ := tpm.Freeze()
+	umr, _ := b.N("um").UrlMap().Resource().Freeze()
+	for _, nb := range []rnode.Builder{
+		firewall.NewBuilderWithResource(fwr),
+		targethttpproxy.NewBuilderWithResource(tpr),
+		urlmap.NewBuilderWithResource(umr),
+	} {
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(rnode.NodeExists)
+		gr.Add(nb)
+	}
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+
+	ex, err := exec.NewSerialExecutor(res.Actions, exec.ErrorStrategyOption(exec.ContinueOnError), exec.RollbackOption(true))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	result, err := ex.Run(ctx, mock)
+	if err == nil {
+		t.Fatalf("Run() = nil, want error")
+	}
+	if len(result.RollbackErrors) > 0 {
+		t.Fatalf("RollbackErrors = %v, want none", result.RollbackErrors)
+	}
+
+	// The UrlMap that was created is deleted and the Firewall is restored.
+	if _, err := mock.UrlMaps().Get(ctx, meta.GlobalKey("um")); !rnode.IsErrorNotFound(err) {
+		t.Errorf("UrlMaps().Get(um) = %v, want NotFound", err)
+	}
+	fw, err := mock.Firewalls().Get(ctx, meta.GlobalKey("fw"))
+	if err != nil {
+		t.Fatalf("Firewalls().Get(fw) = %v, want nil", err)
+	}
+	if fw.Description != "old" {
+		t.Errorf("fw.Description = %q, want %q", fw.Description, "old")
+	}
+}
+
+func TestRollbackRecreate(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	ctx := context.Background()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.Firewalls().Insert(ctx, meta.GlobalKey("fw"), &compute.Firewall{Direction: "INGRESS", Description: "old"})
+	mock.MockTargetHttpProxies.InsertHook = func(context.Context, *meta.Key, *compute.TargetHttpProxy, *cloud.MockTargetHttpProxies, ...cloud.Option) (bool, error) {
+		return true, fmt.Errorf("injected error")
+	}
+
+	gr := rgraph.NewBuilder()
+	// Changing the direction recreates the Firewall.
+	fwm := b.N("fw").Firewall().Resource()
+	fwm.Access(func(x *compute.Firewall) {
+		x.Direction = "EGRESS"
+		x.Description = "new"
+	})
+	fwr, _ := fwm.Freeze()
+	tpm := b.N("tp").TargetHttpProxy().Resource()
+	tpm.Access(func(x *compute.TargetHttpProxy) {
+		x.UrlMap = b.N("um").UrlMap().SelfLink()
+	})
+	tpr, _ := tpm.Freeze()
+	umr, _ := b.N("um").UrlMap().Resource().Freeze()
+	for _, nb := range []rnode.Builder{
+		firewall.NewBuilderWithResource(fwr),
+		targethttpproxy.NewBuilderWithResource(tpr),
+		urlmap.NewBuilderWithResource(umr),
+	} {
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(rnode.NodeExists)
+		gr.Add(nb)
+	}
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	res, err := Do(ctx, mock, want, ImmutableFields(ImmutableFieldsRecreate))
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	fwID := b.N("fw").Firewall().ID()
+	if op := res.Want.Get(fwID).Plan().Op(); op != rnode.OpRecreate {
+		t.Fatalf("fw op = %s, want %s", op, rnode.OpRecreate)
+	}
+
+	ex, err := exec.NewSerialExecutor(res.Actions, exec.ErrorStrategyOption(exec.ContinueOnError), exec.RollbackOption(true))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	result, err := ex.Run(ctx, mock)
+	if err == nil {
+		t.Fatalf("Run() = nil, want error")
+	}
+	if len(result.RollbackErrors) > 0 {
+		t.Fatalf("RollbackErrors = %v, want none", result.RollbackErrors)
+	}
+
+	// The recreated Firewall is restored to its previous state.
+	fw, err := mock.Firewalls().Get(ctx, meta.GlobalKey("fw"))
+	if err != nil {
+		t.Fatalf("Firewalls().Get(fw) = %v, want nil", err)
+	}
+	if fw.Direction != "INGRESS" || fw.Description != "old" {
+		t.Errorf("fw = {Direction: %q, Description: %q}, want {%q, %q}", fw.Direction, fw.Description, "INGRESS", "old")
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	ctx := context.Background()
+
+	// fr -> tp -> um
+	newGraph := func() *rgraph.Graph {
+		gr := rgraph.NewBuilder()
+		frm := b.N("fr").ForwardingRule().Resource()
+		frm.Access(func(x *compute.ForwardingRule) {
+			x.Target = b.N("tp").TargetHttpProxy().SelfLink()
+		})
+		frr, _ := frm.Freeze()
+		tpm := b.N("tp").TargetHttpProxy().Resource()
+		tpm.Access(func(x *compute.TargetHttpProxy) {
+			x.UrlMap = b.N("um").UrlMap().SelfLink()
+		})
+		tpr, _ := tpm.Freeze()
+		umr, _ := b.N("um").UrlMap().Resource().Freeze()
+		for _, nb := range []rnode.Builder{
+			forwardingrule.NewBuilderWithResource(frr),
+			targethttpproxy.NewBuilderWithResource(tpr),
+			urlmap.NewBuilderWithResource(umr),
+		} {
+			nb.SetOwnership(rnode.OwnershipManaged)
+			nb.SetState(rnode.NodeExists)
+			gr.Add(nb)
+		}
+		return gr.MustBuild()
+	}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	res, err := Do(ctx, mock, newGraph())
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	ex, err := exec.NewSerialExecutor(res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	if _, err := ex.Run(ctx, mock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+
+	res, err = Destroy(ctx, mock, newGraph())
+	if err != nil {
+		t.Fatalf("Destroy() = %v, want nil", err)
+	}
+	for _, n := range res.Want.All() {
+		if op := n.Plan().Op(); op != rnode.OpDelete {
+			t.Errorf("node %s op = %s, want %s", n.ID(), op, rnode.OpDelete)
+		}
+	}
+	ex, err = exec.NewSerialExecutor(res.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	result, err := ex.Run(ctx, mock)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+
+	// Resources are deleted before the resources they reference.
+	var got []string
+	for _, a := range result.Completed {
+		if a.Metadata().Type == exec.ActionTypeDelete {
+			got = append(got, a.Metadata().Name)
+		}
+	}
+	want := []string{
+		"GenericDeleteAction(" + b.N("fr").ForwardingRule().ID().String() + ")",
+		"GenericDeleteAction(" + b.N("tp").TargetHttpProxy().ID().String() + ")",
+		"GenericDeleteAction(" + b.N("um").UrlMap().ID().String() + ")",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("deletes: diff -got,+want: %s", diff)
+	}
+	if _, err := mock.UrlMaps().Get(ctx, meta.GlobalKey("um")); !rnode.IsErrorNotFound(err) {
+		t.Errorf("UrlMaps().Get(um) = %v, want NotFound", err)
+	}
+}
+
+func TestMissingRefsAsExternal(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	ctx := context.Background()
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.UrlMaps().Insert(ctx, meta.GlobalKey("um"), &compute.UrlMap{Description: "external"})
+
+	// The UrlMap is not in the graph.
+	gr := rgraph.NewBuilder()
+	tpm := b.N("tp").TargetHttpProxy().Resource()
+	tpm.Access(func(x *compute.TargetHttpProxy) {
+		x.UrlMap = b.N("um").UrlMap().SelfLink()
+	})
+	tpr, _ := tpm.Freeze()
+	tpb := targethttpproxy.NewBuilderWithResource(tpr)
+	tpb.SetOwnership(rnode.OwnershipManaged)
+	tpb.SetState(rnode.NodeExists)
+	gr.Add(tpb)
+	gr.AddMissingRefsAsExternal(all.NewBuilderByID)
+
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	res, err := Do(ctx, mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	for _, x := range []struct {
+		id     *cloud.ResourceID
+		wantOp rnode.Operation
+	}{
+		{b.N("tp").TargetHttpProxy().ID(), rnode.OpCreate},
+		{b.N("um").UrlMap().ID(), rnode.OpNothing},
+	} {
+		n := res.Want.Get(x.id)
+		if n == nil {
+			t.Fatalf("node %s is not in the want graph", x.id)
+		}
+		if op := n.Plan().Op(); op != x.wantOp {
+			t.Errorf("node %s op = %s, want %s", x.id, op, x.wantOp)
+		}
+	}
+	// The state of the external UrlMap is fetched.
+	um := res.Got.Get(b.N("um").UrlMap().ID())
+	if um == nil || um.State() != rnode.NodeExists {
+		t.Errorf("got node for um = %v, want state %s", um, rnode.NodeExists)
+	}
+}
+
+func TestCloudRouter(t *testing.T) {
+	// The TargetHttpProxy in the service project references a UrlMap in the
+	// host project. Each project is accessed through a different Cloud.
+	svc := all.ResourceBuilder{Project: "svc"}
+	host := all.ResourceBuilder{Project: "host"}
+	svcMock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "svc"})
+	hostMock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "host"})
+	router := exec.ProjectClouds(map[string]cloud.Cloud{"host": hostMock})
+
+	makeWant := func() *rgraph.Graph {
+		gr := rgraph.NewBuilder()
+		tpm := svc.N("tp").TargetHttpProxy().Resource()
+		tpm.Access(func(x *compute.TargetHttpProxy) {
+			x.UrlMap = host.N("um").UrlMap().SelfLink()
+		})
+		tpr, _ := tpm.Freeze()
+		umr, _ := host.N("um").UrlMap().Resource().Freeze()
+		for _, nb := range []rnode.Builder{
+			targethttpproxy.NewBuilderWithResource(tpr),
+			urlmap.NewBuilderWithResource(umr),
+		} {
+			nb.SetOwnership(rnode.OwnershipManaged)
+			nb.SetState(rnode.NodeExists)
+			gr.Add(nb)
+		}
+		want, err := gr.Build()
+		if err != nil {
+			t.Fatalf("Build() = %v, want nil", err)
+		}
+		return want
+	}
+	ops := func(res *Result) map[string]rnode.Operation {
+		ret := map[string]rnode.Operation{}
+		for _, n := range res.Want.All() {
+			ret[n.ID().String()] = n.Plan().Op()
+		}
+		return ret
+	}
+	tpID := svc.N("tp").TargetHttpProxy().ID()
+	umID := host.N("um").UrlMap().ID()
+
+	ctx := context.Background()
+	res, err := Do(ctx, svcMock, makeWant(), CloudRouter(router))
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	wantOps := map[string]rnode.Operation{tpID.String(): rnode.OpCreate, umID.String(): rnode.OpCreate}
+	if diff := cmp.Diff(ops(res), wantOps); diff != "" {
+		t.Errorf("ops: -got,+want: %s", diff)
+	}
+	ex, err := exec.NewSerialExecutor(res.Actions, exec.CloudRouterOption(router))
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	if _, err := ex.Run(ctx, svcMock); err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+	if _, err := hostMock.UrlMaps().Get(ctx, umID.Key); err != nil {
+		t.Errorf("UrlMap %v not in the host project: %v", umID, err)
+	}
+	if _, err := svcMock.UrlMaps().Get(ctx, umID.Key); err == nil {
+		t.Errorf("UrlMap %v was created in the service project", umID)
+	}
+	if _, err := svcMock.TargetHttpProxies().Get(ctx, tpID.Key); err != nil {
+		t.Errorf("TargetHttpProxy %v not in the service project: %v", tpID, err)
+	}
+
+	// The resources are fetched from the Cloud of their project, so nothing
+	// changes.
+	res, err = Do(ctx, svcMock, makeWant(), CloudRouter(router))
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	wantOps = map[string]rnode.Operation{tpID.String(): rnode.OpNothing, umID.String(): rnode.OpNothing}
+	if diff := cmp.Diff(ops(res), wantOps); diff != "" {
+		t.Errorf("ops after apply: -got,+want: %s", diff)
+	}
+}
+
+func TestCanceled(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	umID := b.N("um").UrlMap().ID()
+
+	for _, tc := range []struct {
+		name string
+		// cancelInRefs cancels the context while checking for references
+		// to the deleted UrlMap, i.e. after the local plan.
+		cancelInRefs bool
+		wantGot      bool
+		wantOp       rnode.Operation
+	}{
+		{name: "before fetch", wantOp: rnode.OpUnknown},
+		{name: "after local plan", cancelInRefs: true, wantGot: true, wantOp: rnode.OpDelete},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			if err := mock.UrlMaps().Insert(context.Background(), umID.Key, &compute.UrlMap{Name: umID.Key.Name}); err != nil {
+				t.Fatalf("Insert() = %v", err)
+			}
+
+			gr := rgraph.NewBuilder()
+			nb := urlmap.NewBuilder(umID)
+			nb.SetOwnership(rnode.OwnershipManaged)
+			nb.SetState(rnode.NodeDoesNotExist)
+			gr.Add(nb)
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if !tc.cancelInRefs {
+				cancel()
+			}
+			calls := len(mock.Calls())
+			res, err := Do(ctx, mock, want, InRefLookup(func(context.Context, cloud.Cloud, *cloud.ResourceID) ([]*cloud.ResourceID, error) {
+				cancel()
+				return nil, nil
+			}))
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Do() = %v, want %v", err, context.Canceled)
+			}
+			if res == nil {
+				t.Fatalf("Do() = nil, _; want the partial result")
+			}
+			if gotGot := res.Got != nil; gotGot != tc.wantGot {
+				t.Errorf("res.Got = %v, want non-nil = %t", res.Got, tc.wantGot)
+			}
+			if op := res.Want.Get(umID).Plan().Op(); op != tc.wantOp {
+				t.Errorf("op = %s, want %s", op, tc.wantOp)
+			}
+			if len(res.Actions) != 0 {
+				t.Errorf("res.Actions = %v, want none", res.Actions)
+			}
+			if !tc.cancelInRefs && len(mock.Calls()) != calls {
+				t.Errorf("Calls() = %v, want none", mock.Calls()[calls:])
+			}
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+
+	gr := rgraph.NewBuilder()
+	umr, _ := b.N("um").UrlMap().Resource().Freeze()
+	nb := urlmap.NewBuilderWithResource(umr)
+	nb.SetOwnership(rnode.OwnershipManaged)
+	nb.SetState(rnode.NodeExists)
+	gr.Add(nb)
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	r := &metrics.MemoryRecorder{}
+	if _, err := Do(context.Background(), mock, want, Metrics(r)); err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	plans := r.Plans()
+	if len(plans) != 1 {
+		t.Fatalf("len(Plans()) = %d, want 1", len(plans))
+	}
+	if diff := cmp.Diff(plans[0].Nodes, map[string]int{"Create": 1}); diff != "" || plans[0].Err != nil {
+		t.Errorf("Plans()[0] = %+v; Nodes -got,+want: %s", plans[0], diff)
+	}
+}
+
+func TestDrainBeforeDelete(t *testing.T) {
+	const proj = "proj"
+	ctx := context.Background()
+	b := all.ResourceBuilder{Project: proj}
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+
+	fr := func(target string) forwardingrule.ForwardingRule {
+		m := b.N("fr").ForwardingRule().Resource()
+		m.Access(func(x *compute.ForwardingRule) { x.Target = b.N(target).TargetHttpProxy().SelfLink() })
+		r, _ := m.Freeze()
+		return r
+	}
+	tp := func(name string) targethttpproxy.TargetHttpProxy {
+		m := b.N(name).TargetHttpProxy().Resource()
+		m.Access(func(x *compute.TargetHttpProxy) { x.UrlMap = b.N("um").UrlMap().SelfLink() })
+		r, _ := m.Freeze()
+		return r
+	}
+	umr, _ := b.N("um").UrlMap().Resource().Freeze()
+
+	mock.MockGlobalForwardingRules.SetTargetHook = mockcloud.SetTargetGlobalForwardingRuleHook
+
+	// The ForwardingRule currently points to tp1 and will be changed to tp2.
+	umGA, _ := umr.ToGA()
+	mock.UrlMaps().Insert(ctx, meta.GlobalKey("um"), umGA)
+	tp1GA, _ := tp("tp1").ToGA()
+	mock.TargetHttpProxies().Insert(ctx, meta.GlobalKey("tp1"), tp1GA)
+	frGA, _ := fr("tp1").ToGA()
+	mock.GlobalForwardingRules().Insert(ctx, meta.GlobalKey("fr"), frGA)
+
+	gr := rgraph.NewBuilder()
+	for _, nb := range []rnode.Builder{
+		forwardingrule.NewBuilderWithResource(fr("tp2")),
+		targethttpproxy.NewBuilderWithResource(tp("tp2")),
+		urlmap.NewBuilderWithResource(umr),
+	} {
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(rnode.NodeExists)
+		gr.Add(nb)
+	}
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+
+	result, err := Do(ctx, mock, want, DrainBeforeDelete(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	ex, err := exec.NewSerialExecutor(result.Actions)
+	if err != nil {
+		t.Fatalf("NewSerialExecutor() = %v, want nil", err)
+	}
+	res, err := ex.Run(ctx, mock)
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil", err)
+	}
+
+	// The delete of tp1 must come after the drain, which must come after
+	// the update of the ForwardingRule.
+	frID := b.N("fr").ForwardingRule().ID()
+	tp1ID := b.N("tp1").TargetHttpProxy().ID()
+	drain := exec.NewDrainAction(frID, tp1ID, time.Millisecond).String()
+	update, di, del := -1, -1, -1
+	for i, a := range res.Completed {
+		s := a.String()
+		switch {
+		case s == drain:
+			di = i
+		case strings.Contains(s, "Update") && strings.Contains(s, frID.String()):
+			update = i
+		case strings.Contains(s, "Delete") && strings.Contains(s, tp1ID.String()):
+			del = i
+		}
+	}
+	if update < 0 || di < 0 || del < 0 || !(update < di && di < del) {
+		t.Errorf("Completed = %v, want update(fr) < %s < delete(tp1)", res.Completed, drain)
+	}
+}
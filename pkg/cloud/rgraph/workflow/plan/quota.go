@@ -0,0 +1,217 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"google.golang.org/api/compute/v1"
+)
+
+// QuotaMetricFunc returns the GCE quota metric that is consumed when the
+// resource is created and whether the quota is per region (otherwise it is
+// per project). Returns "" if the resource is not checked.
+type QuotaMetricFunc func(id *cloud.ResourceID) (metric string, regional bool)
+
+// DefaultQuotaMetric maps the Compute load balancing and networking
+// resources to their quota metrics. Resources without a well-known quota are
+// not checked.
+func DefaultQuotaMetric(id *cloud.ResourceID) (string, bool) {
+	if id.APIGroup != meta.APIGroupCompute || id.Key == nil {
+		return "", false
+	}
+	global := id.Key.Type() == meta.Global
+	switch id.Resource {
+	case "addresses":
+		if !global {
+			return "STATIC_ADDRESSES", true
+		}
+	case "backendServices", "forwardingRules", "healthChecks", "urlMaps",
+		"targetHttpProxies", "targetHttpsProxies", "sslCertificates", "securityPolicies":
+		if global {
+			return quotaName(id.Resource), false
+		}
+	case "targetSslProxies", "targetTcpProxies", "firewalls", "networks", "subnetworks", "networkEndpointGroups":
+		return quotaName(id.Resource), false
+	case "instanceGroups":
+		return "INSTANCE_GROUPS", true
+	}
+	return "", false
+}
+
+// quotaName converts the resource name to the quota metric, e.g.
+// "urlMaps" => "URL_MAPS".
+func quotaName(resource string) string {
+	var sb strings.Builder
+	for i, r := range resource {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			sb.WriteRune('_')
+		}
+		sb.WriteRune(r)
+	}
+	return strings.ToUpper(sb.String())
+}
+
+// CheckQuota checks that the project (and regional) quotas have room for the
+// resources that the plan creates. The plan fails with a *QuotaError if they
+// do not. Use DefaultQuotaMetric for the standard GCE quotas.
+//
+// Resources that are deleted by the plan are not counted as freeing quota,
+// as they may be deleted after the new resources are created.
+func CheckQuota(f QuotaMetricFunc) Option {
+	return func(c *config) { c.quotaMetric = f }
+}
+
+// QuotaShortfall is a quota that does not have room for the resources
+// created by a plan.
+type QuotaShortfall struct {
+	ProjectID string
+	// Region of the quota. Empty for project quotas.
+	Region string
+	Metric string
+	Limit  float64
+	Usage  float64
+	// Needed is the number of resources the plan creates.
+	Needed int
+	// Resources that are created by the plan.
+	Resources []*cloud.ResourceID
+}
+
+func (s *QuotaShortfall) String() string {
+	scope := s.ProjectID
+	if s.Region != "" {
+		scope += "/" + s.Region
+	}
+	return fmt.Sprintf("%s %s: need %d, available %g (limit %g, usage %g)", scope, s.Metric, s.Needed, s.Limit-s.Usage, s.Limit, s.Usage)
+}
+
+// QuotaError is returned by Do() when the plan exceeds the quota (see
+// CheckQuota()).
+type QuotaError struct {
+	Shortfalls []*QuotaShortfall
+}
+
+func (e *QuotaError) Error() string {
+	var l []string
+	for _, s := range e.Shortfalls {
+		l = append(l, s.String())
+	}
+	return "insufficient quota: " + strings.Join(l, "; ")
+}
+
+// quotaKey identifies a quota. region is empty for project quotas.
+type quotaKey struct {
+	project, region, metric string
+}
+
+// checkQuota counts the resources that are created per quota and compares
+// it with the quota available in the Cloud.
+func (pl *planner) checkQuota(ctx context.Context) error {
+	needed := map[quotaKey]*QuotaShortfall{}
+	for _, n := range pl.want.All() {
+		// Recreates delete the resource first and do not need more quota.
+		if n.Plan().Op() != rnode.OpCreate {
+			continue
+		}
+		id := n.ID()
+		metric, regional := pl.config.quotaMetric(id)
+		if metric == "" {
+			continue
+		}
+		k := quotaKey{project: id.ProjectID, metric: metric}
+		if regional {
+			k.region = regionOf(id.Key)
+		}
+		s, ok := needed[k]
+		if !ok {
+			s = &QuotaShortfall{ProjectID: k.project, Region: k.region, Metric: metric}
+			needed[k] = s
+		}
+		s.Needed++
+		s.Resources = append(s.Resources, id)
+	}
+
+	// Quotas are fetched once per project and region.
+	fetched := map[quotaKey][]*compute.Quota{}
+	var shortfalls []*QuotaShortfall
+	for k, s := range needed {
+		fk := quotaKey{project: k.project, region: k.region}
+		quotas, ok := fetched[fk]
+		if !ok {
+			if err := canceled(ctx); err != nil {
+				return err
+			}
+			var err error
+			quotas, err = pl.fetchQuotas(ctx, k.project, k.region)
+			if err != nil {
+				return fmt.Errorf("%s: CheckQuota: %w", errPrefix, err)
+			}
+			fetched[fk] = quotas
+		}
+		for _, q := range quotas {
+			if q.Metric != k.metric {
+				continue
+			}
+			s.Limit, s.Usage = q.Limit, q.Usage
+			if float64(s.Needed) > q.Limit-q.Usage {
+				shortfalls = append(shortfalls, s)
+			}
+		}
+	}
+	if len(shortfalls) == 0 {
+		return nil
+	}
+	sort.Slice(shortfalls, func(i, j int) bool {
+		return shortfalls[i].String() < shortfalls[j].String()
+	})
+	return fmt.Errorf("%s: %w", errPrefix, &QuotaError{Shortfalls: shortfalls})
+}
+
+// fetchQuotas returns the quotas of the project or of the region in the
+// project.
+func (pl *planner) fetchQuotas(ctx context.Context, project, region string) ([]*compute.Quota, error) {
+	c := pl.cloudFor(&cloud.ResourceID{ProjectID: project})
+	if region == "" {
+		p, err := c.Projects().Get(ctx, project)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", project, err)
+		}
+		return p.Quotas, nil
+	}
+	r, err := c.Regions().Get(ctx, meta.GlobalKey(region), cloud.ForceProjectID(project))
+	if err != nil {
+		return nil, fmt.Errorf("region %s/%s: %w", project, region, err)
+	}
+	return r.Quotas, nil
+}
+
+// regionOf the key. Zones are named <region>-<letter>.
+func regionOf(key *meta.Key) string {
+	if key.Zone != "" {
+		if i := strings.LastIndex(key.Zone, "-"); i > 0 {
+			return key.Zone[:i]
+		}
+		return key.Zone
+	}
+	return key.Region
+}
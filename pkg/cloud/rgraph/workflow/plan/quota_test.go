@@ -0,0 +1,146 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDefaultQuotaMetric(t *testing.T) {
+	b := all.ResourceBuilder{Project: "proj"}
+
+	for _, tc := range []struct {
+		id           *cloud.ResourceID
+		wantMetric   string
+		wantRegional bool
+	}{
+		{id: b.N("x").UrlMap().ID(), wantMetric: "URL_MAPS"},
+		{id: b.N("x").TargetHttpsProxy().ID(), wantMetric: "TARGET_HTTPS_PROXIES"},
+		{id: b.N("x").BackendService().ID(), wantMetric: "BACKEND_SERVICES"},
+		{id: b.N("x").DefaultRegion().BackendService().ID()},
+		{id: b.N("x").DefaultRegion().Address().ID(), wantMetric: "STATIC_ADDRESSES", wantRegional: true},
+		{id: b.N("x").DefaultZone().NetworkEndpointGroup().ID(), wantMetric: "NETWORK_ENDPOINT_GROUPS"},
+	} {
+		metric, regional := DefaultQuotaMetric(tc.id)
+		if metric != tc.wantMetric || regional != tc.wantRegional {
+			t.Errorf("DefaultQuotaMetric(%v) = %q, %t; want %q, %t", tc.id, metric, regional, tc.wantMetric, tc.wantRegional)
+		}
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+
+	for _, tc := range []struct {
+		name           string
+		quotas         []*compute.Quota
+		noProject      bool
+		wantShortfalls []string
+		wantErr        bool
+	}{
+		{
+			name: "enough quota",
+			quotas: []*compute.Quota{
+				{Metric: "URL_MAPS", Limit: 10, Usage: 8},
+				{Metric: "TARGET_HTTP_PROXIES", Limit: 10, Usage: 9},
+			},
+		},
+		{
+			name: "shortfall",
+			quotas: []*compute.Quota{
+				{Metric: "URL_MAPS", Limit: 10, Usage: 9},
+				{Metric: "TARGET_HTTP_PROXIES", Limit: 10, Usage: 10},
+			},
+			wantShortfalls: []string{
+				"proj TARGET_HTTP_PROXIES: need 1, available 0 (limit 10, usage 10)",
+				"proj URL_MAPS: need 2, available 1 (limit 10, usage 9)",
+			},
+			wantErr: true,
+		},
+		{
+			name: "metric not reported",
+		},
+		{
+			name:      "error: project not found",
+			noProject: true,
+			wantErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			if !tc.noProject {
+				mock.MockProjects.Objects[*meta.GlobalKey(proj)] = &cloud.MockProjectsObj{
+					Obj: &compute.Project{Name: proj, Quotas: tc.quotas},
+				}
+			}
+
+			gr := rgraph.NewBuilder()
+			tpm := b.N("tp").TargetHttpProxy().Resource()
+			tpm.Access(func(x *compute.TargetHttpProxy) {
+				x.UrlMap = b.N("um-a").UrlMap().SelfLink()
+			})
+			tpr, _ := tpm.Freeze()
+			umar, _ := b.N("um-a").UrlMap().Resource().Freeze()
+			umbr, _ := b.N("um-b").UrlMap().Resource().Freeze()
+			for _, nb := range []rnode.Builder{
+				targethttpproxy.NewBuilderWithResource(tpr),
+				urlmap.NewBuilderWithResource(umar),
+				urlmap.NewBuilderWithResource(umbr),
+			} {
+				nb.SetOwnership(rnode.OwnershipManaged)
+				nb.SetState(rnode.NodeExists)
+				gr.Add(nb)
+			}
+			want, err := gr.Build()
+			if err != nil {
+				t.Fatalf("Build() = %v, want nil", err)
+			}
+
+			_, err = Do(context.Background(), mock, want, CheckQuota(DefaultQuotaMetric))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Do() = %v; gotErr = %t, want %t", err, gotErr, tc.wantErr)
+			}
+			var qe *QuotaError
+			if !errors.As(err, &qe) {
+				if tc.wantShortfalls != nil {
+					t.Fatalf("Do() = %v, want QuotaError", err)
+				}
+				return
+			}
+			var got []string
+			for _, s := range qe.Shortfalls {
+				got = append(got, s.String())
+			}
+			if diff := cmp.Diff(got, tc.wantShortfalls); diff != "" {
+				t.Errorf("Shortfalls: -got,+want: %s", diff)
+			}
+		})
+	}
+}
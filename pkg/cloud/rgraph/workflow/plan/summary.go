@@ -0,0 +1,202 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/api"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+)
+
+// summaryOps is the order of the operations in the summary.
+var summaryOps = []rnode.Operation{
+	rnode.OpCreate,
+	rnode.OpUpdate,
+	rnode.OpRecreate,
+	rnode.OpDelete,
+}
+
+// summaryPrefix is the marker for each operation in the per-node list.
+var summaryPrefix = map[rnode.Operation]string{
+	rnode.OpCreate:   "+",
+	rnode.OpUpdate:   "~",
+	rnode.OpRecreate: "-/+",
+	rnode.OpDelete:   "-",
+}
+
+// Summary returns a one line summary of the planned operations, e.g.
+//
+//	3 to create, 1 to update (fields: Backends, PortName), 0 to recreate, 0 to delete
+func (r *Result) Summary() string {
+	count := map[rnode.Operation]int{}
+	fields := map[rnode.Operation]map[string]bool{}
+	for _, n := range r.changedNodes() {
+		op := n.Plan().Op()
+		count[op]++
+		if fields[op] == nil {
+			fields[op] = map[string]bool{}
+		}
+		for _, item := range diffItems(n) {
+			if f := topLevelField(item.Path); f != "" {
+				fields[op][f] = true
+			}
+		}
+	}
+
+	var parts []string
+	for _, op := range summaryOps {
+		s := fmt.Sprintf("%d to %s", count[op], strings.ToLower(string(op)))
+		// Only the changed fields of updates are interesting, the fields of
+		// the other operations are the whole resource.
+		if op == rnode.OpUpdate && len(fields[op]) > 0 {
+			var names []string
+			for f := range fields[op] {
+				names = append(names, f)
+			}
+			sort.Strings(names)
+			s += fmt.Sprintf(" (fields: %s)", strings.Join(names, ", "))
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Text returns a human-readable description of the plan with the summary
+// line followed by the diff for each node that will be changed. This is
+// suitable for logs.
+func (r *Result) Text() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "Plan: %s\n", r.Summary())
+	for _, n := range r.changedNodes() {
+		op := n.Plan().Op()
+		fmt.Fprintf(buf, "\n%s %s (%s)\n", summaryPrefix[op], n.ID(), op)
+		if why := n.Plan().Details().Why; why != "" {
+			fmt.Fprintf(buf, "    %s\n", why)
+		}
+		for _, item := range diffItems(n) {
+			fmt.Fprintf(buf, "    %s\n", formatDiffItem(item))
+		}
+	}
+	return buf.String()
+}
+
+// Markdown returns the same information as Text() formatted as Markdown, e.g.
+// for a PR comment.
+func (r *Result) Markdown() string {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "**Plan:** %s\n", r.Summary())
+	for _, n := range r.changedNodes() {
+		op := n.Plan().Op()
+		fmt.Fprintf(buf, "\n#### `%s` %s\n", summaryPrefix[op], n.ID())
+		if why := n.Plan().Details().Why; why != "" {
+			fmt.Fprintf(buf, "\n%s\n", why)
+		}
+		if items := diffItems(n); len(items) > 0 {
+			fmt.Fprintf(buf, "\n```diff\n")
+			for _, item := range items {
+				fmt.Fprintf(buf, "%s\n", formatDiffItem(item))
+			}
+			fmt.Fprintf(buf, "```\n")
+		}
+	}
+	return buf.String()
+}
+
+// changedNodes returns the nodes that will be changed, sorted by ID.
+func (r *Result) changedNodes() []rnode.Node {
+	if r.Want == nil {
+		return nil
+	}
+	var ret []rnode.Node
+	for _, n := range r.Want.All() {
+		if _, ok := summaryPrefix[n.Plan().Op()]; ok {
+			ret = append(ret, n)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID().String() < ret[j].ID().String() })
+	return ret
+}
+
+func diffItems(n rnode.Node) []api.DiffItem {
+	details := n.Plan().Details()
+	if details == nil || details.Diff == nil {
+		return nil
+	}
+	return details.Diff.Items
+}
+
+// topLevelField returns the name of the first field in the path, e.g.
+// "Backends" for "*.Backends!0.Group".
+func topLevelField(p api.Path) string {
+	for _, x := range p {
+		if strings.HasPrefix(x, ".") {
+			return x[1:]
+		}
+	}
+	return ""
+}
+
+// formatDiffItem formats the item in the style of a unified diff.
+func formatDiffItem(item api.DiffItem) string {
+	switch item.State {
+	case api.DiffItemOnlyInA:
+		return fmt.Sprintf("- %s: %s", item.Path, formatValue(item.A))
+	case api.DiffItemOnlyInB:
+		return fmt.Sprintf("+ %s: %s", item.Path, formatValue(item.B))
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", item.Path, formatValue(item.A), formatValue(item.B))
+}
+
+// formatValue uses the JSON encoding for the value so that pointers and
+// structs are printed with their contents.
+func formatValue(v any) string {
+	if v == nil {
+		return "<nil>"
+	}
+	if b, err := json.Marshal(v); err == nil {
+		return string(b)
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// Render returns a deterministic and complete description of the plan: the
+// Text() followed by the operation for every node and the Actions with the
+// events they wait for. This is used for golden file tests of the planner
+// (see rgraph/testing/golden).
+func (r *Result) Render() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(r.Text())
+
+	j := r.JSON()
+	fmt.Fprintf(buf, "\nNodes:\n")
+	for _, n := range j.Nodes {
+		fmt.Fprintf(buf, "  %s %s (%s)\n", n.Operation, n.ID, n.Ownership)
+	}
+	fmt.Fprintf(buf, "\nActions:\n")
+	for _, a := range j.Actions {
+		fmt.Fprintf(buf, "  %s\n", a.Name)
+		for _, ev := range a.Want {
+			fmt.Fprintf(buf, "    want: %s\n", ev)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/firewall"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestResultSummary(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.Firewalls().Insert(context.Background(), meta.GlobalKey("fw"), &compute.Firewall{
+		Description:  "old",
+		SourceRanges: []string{"10.0.0.0/8"},
+	})
+
+	fwm := b.N("fw").Firewall().Resource()
+	fwm.Access(func(x *compute.Firewall) {
+		x.Description = "new"
+		x.SourceRanges = []string{"10.0.0.0/16"}
+	})
+	fwr, _ := fwm.Freeze()
+	umr, _ := b.N("um").UrlMap().Resource().Freeze()
+
+	gr := rgraph.NewBuilder()
+	for _, nb := range []rnode.Builder{
+		firewall.NewBuilderWithResource(fwr),
+		urlmap.NewBuilderWithResource(umr),
+	} {
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(rnode.NodeExists)
+		gr.Add(nb)
+	}
+	want, err := gr.Build()
+	if err != nil {
+		t.Fatalf("Build() = %v, want nil", err)
+	}
+	res, err := Do(context.Background(), mock, want)
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+
+	const wantSummary = "1 to create, 1 to update (fields: Description, SourceRanges), 0 to recreate, 0 to delete"
+	if got := res.Summary(); got != wantSummary {
+		t.Errorf("Summary() = %q, want %q", got, wantSummary)
+	}
+
+	text := res.Text()
+	t.Logf("Text() =\n%s", text)
+	md := res.Markdown()
+	t.Logf("Markdown() =\n%s", md)
+
+	for _, tc := range []struct {
+		name string
+		got  string
+		want []string
+	}{
+		{
+			name: "Text",
+			got:  text,
+			want: []string{
+				"Plan: " + wantSummary,
+				"+ " + b.N("um").UrlMap().ID().String() + " (Create)",
+				"~ " + b.N("fw").Firewall().ID().String() + " (Update)",
+				`~ *.Description: "old" -> "new"`,
+			},
+		},
+		{
+			name: "Markdown",
+			got:  md,
+			want: []string{
+				"**Plan:** " + wantSummary,
+				"#### `+` " + b.N("um").UrlMap().ID().String(),
+				"```diff",
+			},
+		},
+	} {
+		for _, s := range tc.want {
+			if !strings.Contains(tc.got, s) {
+				t.Errorf("%s() does not contain %q", tc.name, s)
+			}
+		}
+	}
+}
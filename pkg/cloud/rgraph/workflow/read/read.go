@@ -0,0 +1,147 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package read imports existing resources from the Cloud into a Graph.
+//
+// This is used to adopt resources that were created outside of the graph
+// (e.g. an existing load balancer): the imported Graph can be modified and
+// used as the "want" graph for planning.
+package read
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/algo/trclosure"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/exec"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/forwardingrule"
+)
+
+const errPrefix = "Read"
+
+// Option for Do.
+type Option func(c *config)
+
+// Ownership sets the ownership of the imported nodes. The default is
+// OwnershipManaged, i.e. the resources are adopted.
+func Ownership(o rnode.OwnershipStatus) Option {
+	return func(c *config) { c.ownership = o }
+}
+
+// CloudRouter fetches the resources with the Cloud for their project, e.g.
+// for graphs that span the host and service projects of a shared VPC.
+func CloudRouter(r exec.CloudRouter) Option {
+	return func(c *config) { c.router = r }
+}
+
+type config struct {
+	ownership rnode.OwnershipStatus
+	router    exec.CloudRouter
+}
+
+// Do fetches the roots and all of the resources they reference
+// (transitively) from the Cloud. Returns the Graph of the current state of
+// the resources. All of the roots must exist.
+func Do(ctx context.Context, c cloud.Cloud, roots []*cloud.ResourceID, opts ...Option) (*rgraph.Graph, error) {
+	config := config{ownership: rnode.OwnershipManaged}
+	for _, o := range opts {
+		o(&config)
+	}
+
+	b := rgraph.NewBuilder()
+	for _, id := range roots {
+		nb, err := all.NewBuilderByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errPrefix, err)
+		}
+		b.Add(nb)
+	}
+	err := trclosure.Do(ctx, c, b,
+		trclosure.OnGetFunc(func(n rnode.Builder) error {
+			n.SetOwnership(config.ownership)
+			return nil
+		}),
+		trclosure.CloudRouter(config.router),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	for _, id := range roots {
+		if state := b.Get(id).State(); state != rnode.NodeExists {
+			return nil, fmt.Errorf("%s: root %s has state %s", errPrefix, id, state)
+		}
+	}
+
+	g, err := b.Build()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	return g, nil
+}
+
+// ForwardingRulesByLabels returns the ForwardingRules in the project that
+// have all of the labels. Global ForwardingRules are always searched;
+// regional ForwardingRules are searched in the given regions. This can be
+// used to find the roots of the load balancers to import with Do().
+func ForwardingRulesByLabels(
+	ctx context.Context,
+	c cloud.Cloud,
+	project string,
+	regions []string,
+	labels map[string]string,
+) ([]*cloud.ResourceID, error) {
+	hasLabels := func(l map[string]string) bool {
+		for k, v := range labels {
+			if lv, ok := l[k]; !ok || lv != v {
+				return false
+			}
+		}
+		return true
+	}
+	opt := cloud.ForceProjectID(project)
+
+	var ret []*cloud.ResourceID
+	frs, err := c.GlobalForwardingRules().List(ctx, filter.None, opt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: GlobalForwardingRules.List: %w", errPrefix, err)
+	}
+	for _, fr := range frs {
+		if hasLabels(fr.Labels) {
+			ret = append(ret, forwardingrule.ID(project, meta.GlobalKey(fr.Name)))
+		}
+	}
+	for _, region := range regions {
+		frs, err := c.ForwardingRules().List(ctx, region, filter.None, opt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: ForwardingRules.List(%s): %w", errPrefix, region, err)
+		}
+		for _, fr := range frs {
+			if hasLabels(fr.Labels) {
+				ret = append(ret, forwardingrule.ID(project, meta.RegionalKey(fr.Name, region)))
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].String() < ret[j].String() })
+
+	return ret, nil
+}
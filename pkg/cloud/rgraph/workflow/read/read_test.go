@@ -0,0 +1,105 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package read
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/workflow/plan"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestDo(t *testing.T) {
+	const proj = "proj"
+	b := all.ResourceBuilder{Project: proj}
+	ctx := context.Background()
+
+	// fr -> tp -> um
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	mock.GlobalForwardingRules().Insert(ctx, meta.GlobalKey("fr"), &compute.ForwardingRule{
+		Target: b.N("tp").TargetHttpProxy().SelfLink(),
+		Labels: map[string]string{"app": "web"},
+	})
+	mock.GlobalForwardingRules().Insert(ctx, meta.GlobalKey("fr-other"), &compute.ForwardingRule{
+		Labels: map[string]string{"app": "other"},
+	})
+	mock.ForwardingRules().Insert(ctx, meta.RegionalKey("fr-regional", "us-central1"), &compute.ForwardingRule{
+		Labels: map[string]string{"app": "web", "tier": "internal"},
+	})
+	mock.TargetHttpProxies().Insert(ctx, meta.GlobalKey("tp"), &compute.TargetHttpProxy{
+		UrlMap: b.N("um").UrlMap().SelfLink(),
+	})
+	mock.UrlMaps().Insert(ctx, meta.GlobalKey("um"), &compute.UrlMap{})
+
+	roots, err := ForwardingRulesByLabels(ctx, mock, proj, []string{"us-central1"}, map[string]string{"app": "web"})
+	if err != nil {
+		t.Fatalf("ForwardingRulesByLabels() = %v, want nil", err)
+	}
+	var gotRoots []string
+	for _, id := range roots {
+		gotRoots = append(gotRoots, id.String())
+	}
+	wantRoots := []string{
+		b.N("fr").ForwardingRule().ID().String(),
+		b.N("fr-regional").DefaultRegion().ForwardingRule().ID().String(),
+	}
+	if diff := cmp.Diff(gotRoots, wantRoots); diff != "" {
+		t.Errorf("ForwardingRulesByLabels(): diff -got,+want: %s", diff)
+	}
+
+	g, err := Do(ctx, mock, []*cloud.ResourceID{b.N("fr").ForwardingRule().ID()})
+	if err != nil {
+		t.Fatalf("Do() = %v, want nil", err)
+	}
+	gotNodes := map[string]rnode.NodeState{}
+	for _, n := range g.All() {
+		gotNodes[n.ID().String()] = n.State()
+		if n.Ownership() != rnode.OwnershipManaged {
+			t.Errorf("node %s ownership = %s, want %s", n.ID(), n.Ownership(), rnode.OwnershipManaged)
+		}
+	}
+	wantNodes := map[string]rnode.NodeState{
+		b.N("fr").ForwardingRule().ID().String():  rnode.NodeExists,
+		b.N("tp").TargetHttpProxy().ID().String(): rnode.NodeExists,
+		b.N("um").UrlMap().ID().String():          rnode.NodeExists,
+	}
+	if diff := cmp.Diff(gotNodes, wantNodes); diff != "" {
+		t.Errorf("Do() nodes: diff -got,+want: %s", diff)
+	}
+
+	// The imported graph can be used as the "want" graph; nothing changes.
+	res, err := plan.Do(ctx, mock, g)
+	if err != nil {
+		t.Fatalf("plan.Do() = %v, want nil", err)
+	}
+	for _, n := range res.Want.All() {
+		if op := n.Plan().Op(); op != rnode.OpNothing {
+			t.Errorf("node %s op = %s, want %s (%s)", n.ID(), op, rnode.OpNothing, n.Plan().Details().Why)
+		}
+	}
+
+	// Roots must exist.
+	if _, err := Do(ctx, mock, []*cloud.ResourceID{b.N("does-not-exist").ForwardingRule().ID()}); err == nil {
+		t.Errorf("Do(does-not-exist) = nil, want error")
+	}
+}
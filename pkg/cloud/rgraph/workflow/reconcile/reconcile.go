@@ -0,0 +1,250 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reconcile runs a Graph-producing function as a long-running
+// reconciler.
+//
+// Each sync gets the wanted Graph from the GraphFunc, checks it against the
+// Cloud and converges it if there are changes (see package converge). Syncs
+// happen periodically with a jittered resync period and on demand via
+// Trigger(). Differences between the Cloud and the Graph applied by the
+// last converged sync are reported as drift, e.g. when a resource was
+// modified outside of the reconciler. Changes to the wanted Graph are not
+// drift.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/workflow/converge"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/workflow/plan"
+	"k8s.io/klog/v2"
+)
+
+const errPrefix = "Reconcile"
+
+// GraphFunc returns the wanted Graph. It is called at the start of every sync.
+type GraphFunc func(ctx context.Context) (*rgraph.Graph, error)
+
+// Result of a sync.
+type Result struct {
+	// Check is the plan used to check for changes. This is nil if getting the
+	// Graph or planning failed.
+	Check *plan.Result
+	// Drift is true if the Cloud no longer matches the Graph applied by the
+	// previous sync.
+	Drift bool
+	// Converge is the result of converging the Graph. This is nil if there
+	// were no changes.
+	Converge *converge.Result
+}
+
+// Option for New.
+type Option func(c *config)
+
+// ResyncPeriod is the time between the periodic syncs. The default is 10
+// minutes.
+func ResyncPeriod(d time.Duration) Option {
+	return func(c *config) { c.resyncPeriod = d }
+}
+
+// Jitter adds a random delay of up to factor * ResyncPeriod to each period
+// so that many reconcilers do not sync at the same time. The default is 0.1.
+func Jitter(factor float64) Option {
+	return func(c *config) { c.jitter = factor }
+}
+
+// ConvergeOptions are passed to converge.Do(). The plan.Options are also
+// used for the check for changes.
+func ConvergeOptions(opts ...converge.Option) Option {
+	return func(c *config) { c.convergeOpts = append(c.convergeOpts, opts...) }
+}
+
+// PlanOptions are passed to plan.Do() for the check for changes and to
+// converge.Do().
+func PlanOptions(opts ...plan.Option) Option {
+	return func(c *config) { c.planOpts = append(c.planOpts, opts...) }
+}
+
+// BeforeSync is called at the start of every sync.
+func BeforeSync(f func(ctx context.Context)) Option {
+	return func(c *config) { c.beforeSync = f }
+}
+
+// AfterSync is called at the end of every sync with the result of Sync().
+func AfterSync(f func(ctx context.Context, r *Result, err error)) Option {
+	return func(c *config) { c.afterSync = f }
+}
+
+// OnDrift is called when a sync finds that the Cloud no longer matches the
+// Graph applied by the last sync that converged. check is the plan of the applied Graph
+// against the Cloud, i.e. the changes made outside of the Reconciler. This is
+// called before the changes are converged.
+func OnDrift(f func(ctx context.Context, check *plan.Result)) Option {
+	return func(c *config) { c.onDrift = f }
+}
+
+type config struct {
+	resyncPeriod time.Duration
+	jitter       float64
+	planOpts     []plan.Option
+	convergeOpts []converge.Option
+	beforeSync   func(context.Context)
+	afterSync    func(context.Context, *Result, error)
+	onDrift      func(context.Context, *plan.Result)
+}
+
+// Reconciler syncs the Graph from a GraphFunc to the Cloud.
+type Reconciler struct {
+	cl      cloud.Cloud
+	f       GraphFunc
+	config  config
+	trigger chan struct{}
+
+	// applied is the wanted Graph of the last sync that converged, nil if
+	// no sync has converged. Failed syncs do not change it. The Cloud is
+	// checked against it for drift.
+	applied *rgraph.Graph
+}
+
+// New returns a Reconciler for the Graphs returned by f.
+func New(cl cloud.Cloud, f GraphFunc, opts ...Option) *Reconciler {
+	r := &Reconciler{
+		cl: cl,
+		f:  f,
+		config: config{
+			resyncPeriod: 10 * time.Minute,
+			jitter:       0.1,
+		},
+		trigger: make(chan struct{}, 1),
+	}
+	for _, o := range opts {
+		o(&r.config)
+	}
+	return r
+}
+
+// Trigger a sync as soon as possible. This does not block; multiple
+// Triggers before the sync starts result in a single sync.
+func (r *Reconciler) Trigger() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run syncs immediately and then on every resync period or Trigger() until
+// ctx is done. Sync errors are reported to the AfterSync hook and the sync
+// is retried on the next period. Returns the error from ctx. Run must not be
+// called concurrently.
+func (r *Reconciler) Run(ctx context.Context) error {
+	if r.config.resyncPeriod <= 0 {
+		return fmt.Errorf("%s: invalid ResyncPeriod %v", errPrefix, r.config.resyncPeriod)
+	}
+	for {
+		if _, err := r.Sync(ctx); err != nil {
+			klog.Errorf("%s: %v", errPrefix, err)
+		}
+
+		timer := time.NewTimer(r.wait())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-r.trigger:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// wait returns the resync period with the jitter.
+func (r *Reconciler) wait() time.Duration {
+	d := r.config.resyncPeriod
+	if r.config.jitter > 0 {
+		d += time.Duration(rand.Float64() * r.config.jitter * float64(d))
+	}
+	return d
+}
+
+// Sync the Graph once: get the Graph, check for changes and converge if
+// there are any.
+func (r *Reconciler) Sync(ctx context.Context) (*Result, error) {
+	if r.config.beforeSync != nil {
+		r.config.beforeSync(ctx)
+	}
+	result, err := r.sync(ctx)
+	if r.config.afterSync != nil {
+		r.config.afterSync(ctx, result, err)
+	}
+	return result, err
+}
+
+func (r *Reconciler) sync(ctx context.Context) (*Result, error) {
+	result := &Result{}
+
+	want, err := r.f(ctx)
+	if err != nil {
+		return result, fmt.Errorf("%s: GraphFunc: %w", errPrefix, err)
+	}
+	result.Check, err = r.plan(ctx, want)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	if !result.Check.HasChanges() {
+		r.applied = want
+		return result, nil
+	}
+
+	if r.applied != nil {
+		drift, err := r.plan(ctx, r.applied)
+		if err != nil {
+			return result, fmt.Errorf("%s: drift check: %w", errPrefix, err)
+		}
+		if drift.HasChanges() {
+			result.Drift = true
+			klog.V(2).Infof("%s: drift detected", errPrefix)
+			if r.config.onDrift != nil {
+				r.config.onDrift(ctx, drift)
+			}
+		}
+	}
+
+	opts := append([]converge.Option{converge.PlanOptions(r.config.planOpts...)}, r.config.convergeOpts...)
+	result.Converge, err = converge.Do(ctx, r.cl, want, opts...)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	if result.Converge.Converged {
+		r.applied = want
+	}
+	return result, nil
+}
+
+// plan g against the Cloud. Planning modifies the Graph so this plans a
+// copy; g can be passed to converge.Do() or planned again.
+func (r *Reconciler) plan(ctx context.Context, g *rgraph.Graph) (*plan.Result, error) {
+	g, err := g.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return plan.Do(ctx, r.cl, g, r.config.planOpts...)
+}
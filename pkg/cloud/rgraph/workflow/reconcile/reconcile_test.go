@@ -0,0 +1,228 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/all"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/targethttpproxy"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/rnode/urlmap"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/rgraph/workflow/plan"
+	"google.golang.org/api/compute/v1"
+)
+
+const proj = "proj"
+
+func testGraph(ctx context.Context) (*rgraph.Graph, error) {
+	return testGraphWithDescription("")
+}
+
+// testGraphWithDescription returns the test Graph with the description of the
+// UrlMap set to desc.
+func testGraphWithDescription(desc string) (*rgraph.Graph, error) {
+	b := all.ResourceBuilder{Project: proj}
+	gr := rgraph.NewBuilder()
+	tpm := b.N("tp").TargetHttpProxy().Resource()
+	tpm.Access(func(x *compute.TargetHttpProxy) {
+		x.UrlMap = b.N("um").UrlMap().SelfLink()
+	})
+	tpr, _ := tpm.Freeze()
+	umm := b.N("um").UrlMap().Resource()
+	umm.Access(func(x *compute.UrlMap) { x.Description = desc })
+	umr, _ := umm.Freeze()
+	for _, nb := range []rnode.Builder{
+		targethttpproxy.NewBuilderWithResource(tpr),
+		urlmap.NewBuilderWithResource(umr),
+	} {
+		nb.SetOwnership(rnode.OwnershipManaged)
+		nb.SetState(rnode.NodeExists)
+		gr.Add(nb)
+	}
+	return gr.Build()
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+
+	var desc string
+	f := func(context.Context) (*rgraph.Graph, error) { return testGraphWithDescription(desc) }
+
+	var drifts, before, after int
+	r := New(mock, f,
+		// The UrlMap is recreated when its description changes.
+		PlanOptions(plan.ImmutableFields(plan.ImmutableFieldsRecreate)),
+		OnDrift(func(context.Context, *plan.Result) { drifts++ }),
+		BeforeSync(func(context.Context) { before++ }),
+		AfterSync(func(context.Context, *Result, error) { after++ }),
+	)
+
+	for _, step := range []struct {
+		name         string
+		setUp        func()
+		wantConverge bool
+		wantDrift    bool
+	}{
+		{name: "create", wantConverge: true},
+		{name: "no changes"},
+		{
+			name: "drift",
+			setUp: func() {
+				mock.TargetHttpProxies().Delete(ctx, meta.GlobalKey("tp"))
+			},
+			wantConverge: true,
+			wantDrift:    true,
+		},
+		{name: "no changes after drift"},
+		{
+			// Changes to the wanted Graph are not drift.
+			name:         "graph changed",
+			setUp:        func() { desc = "changed" },
+			wantConverge: true,
+		},
+		{name: "no changes after graph changed"},
+	} {
+		if step.setUp != nil {
+			step.setUp()
+		}
+		res, err := r.Sync(ctx)
+		if err != nil {
+			t.Fatalf("%s: Sync() = %v, want nil", step.name, err)
+		}
+		if gotConverge := res.Converge != nil; gotConverge != step.wantConverge {
+			t.Errorf("%s: res.Converge = %v, want non-nil = %t", step.name, res.Converge, step.wantConverge)
+		}
+		if res.Drift != step.wantDrift {
+			t.Errorf("%s: res.Drift = %t, want %t", step.name, res.Drift, step.wantDrift)
+		}
+		if _, err := mock.TargetHttpProxies().Get(ctx, meta.GlobalKey("tp")); err != nil {
+			t.Errorf("%s: TargetHttpProxies().Get(tp) = %v, want nil", step.name, err)
+		}
+	}
+	if drifts != 1 || before != 6 || after != 6 {
+		t.Errorf("hooks called (drift, before, after) = (%d, %d, %d), want (1, 6, 6)", drifts, before, after)
+	}
+}
+
+func TestSyncError(t *testing.T) {
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+	f := func(context.Context) (*rgraph.Graph, error) { return nil, errors.New("injected") }
+
+	var gotErr error
+	r := New(mock, f, AfterSync(func(_ context.Context, _ *Result, err error) { gotErr = err }))
+	if _, err := r.Sync(context.Background()); err == nil {
+		t.Errorf("Sync() = nil, want error")
+	}
+	if gotErr == nil {
+		t.Errorf("AfterSync() err = nil, want error")
+	}
+}
+
+func TestSyncDriftAfterError(t *testing.T) {
+	ctx := context.Background()
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+
+	var graphErr error
+	f := func(ctx context.Context) (*rgraph.Graph, error) {
+		if graphErr != nil {
+			return nil, graphErr
+		}
+		return testGraph(ctx)
+	}
+	var drifts int
+	r := New(mock, f, OnDrift(func(context.Context, *plan.Result) { drifts++ }))
+
+	if _, err := r.Sync(ctx); err != nil {
+		t.Fatalf("create: Sync() = %v, want nil", err)
+	}
+	graphErr = errors.New("injected")
+	if _, err := r.Sync(ctx); err == nil {
+		t.Fatalf("error: Sync() = nil, want error")
+	}
+	graphErr = nil
+	// The error does not discard the Graph applied by the first sync.
+	mock.TargetHttpProxies().Delete(ctx, meta.GlobalKey("tp"))
+	res, err := r.Sync(ctx)
+	if err != nil {
+		t.Fatalf("drift: Sync() = %v, want nil", err)
+	}
+	if !res.Drift || drifts != 1 {
+		t.Errorf("drift: res.Drift = %t, drifts = %d; want true, 1", res.Drift, drifts)
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		// trigger the next sync from the AfterSync hook.
+		trigger bool
+	}{
+		{
+			name: "resync",
+			opts: []Option{ResyncPeriod(time.Millisecond)},
+		},
+		{
+			name:    "trigger",
+			opts:    []Option{ResyncPeriod(time.Hour), Jitter(0)},
+			trigger: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: proj})
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			var r *Reconciler
+			var syncs int
+			afterSync := AfterSync(func(_ context.Context, _ *Result, err error) {
+				if err != nil {
+					t.Errorf("sync %d: %v", syncs, err)
+				}
+				syncs++
+				switch {
+				case syncs == 3:
+					cancel()
+				case tc.trigger:
+					r.Trigger()
+				}
+			})
+			r = New(mock, testGraph, append(tc.opts, afterSync)...)
+
+			err := r.Run(ctx)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Run() = %v, want %v", err, context.Canceled)
+			}
+			if syncs != 3 {
+				t.Errorf("syncs = %d, want 3", syncs)
+			}
+		})
+	}
+
+	r := New(nil, testGraph, ResyncPeriod(0))
+	if err := r.Run(context.Background()); err == nil {
+		t.Errorf("Run() with ResyncPeriod(0) = nil, want error")
+	}
+}
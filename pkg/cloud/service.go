@@ -158,27 +158,36 @@ func (s *Service) WaitForCompletion(ctx context.Context, genericOp interface{})
 func (s *Service) pollOperation(ctx context.Context, op operation) error {
 	start := time.Now()
 	var pollCount int
+	// logDone logs the result of the operation.
+	logDone := func(msg string, err error) {
+		kv := []any{"op", op, "polls", pollCount, "duration", time.Since(start)}
+		if err != nil {
+			logS(ctx, LogLevelCallFailed, msg, append(kv, "err", err)...)
+		} else {
+			logS(ctx, LogLevelCall, msg, kv...)
+		}
+	}
 	for {
 		// Check if context has been cancelled. Note that ctx.Done() must be checked before
 		// returning ctx.Err().
 		select {
 		case <-ctx.Done():
-			klog.V(5).Infof("op.pollOperation(%v, %v) not completed, poll count = %d, ctx.Err = %v (%v elapsed)", ctx, op, pollCount, ctx.Err(), time.Since(start))
+			logDone("Operation not completed", ctx.Err())
 			return ctx.Err()
 		default:
 			// ctx is not canceled, continue immediately
 		}
 
 		pollCount++
-		klog.V(5).Infof("op.isDone(%v) waiting; op = %v, poll count = %d (%v elapsed)", ctx, op, pollCount, time.Since(start))
+		logS(ctx, LogLevelCallDetail, "Polling operation", "op", op, "polls", pollCount, "duration", time.Since(start))
 		s.RateLimiter.Accept(ctx, op.rateLimitKey())
 		switch done, err := op.isDone(ctx); {
 		case err != nil:
-			klog.V(5).Infof("op.isDone(%v) error; op = %v, poll count = %d, err = %v, retrying (%v elapsed)", ctx, op, pollCount, err, time.Since(start))
+			logDone("Operation poll failed", err)
 			s.RateLimiter.Observe(ctx, err, op.rateLimitKey())
 			return err
 		case done:
-			klog.V(5).Infof("op.isDone(%v) complete; op = %v, poll count = %d, op.err = %v (%v elapsed)", ctx, op, pollCount, op.error(), time.Since(start))
+			logDone("Operation done", op.error())
 			s.RateLimiter.Observe(ctx, op.error(), op.rateLimitKey())
 			return op.error()
 		}
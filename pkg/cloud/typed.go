@@ -0,0 +1,64 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// TypedService is a service bound to the Go type T and the scope S of its
+// resource so that it only accepts meta.TypedKey[T, S]. TypedServices are
+// created with the generated Typed<Service>() functions:
+//
+//	key := meta.TypedGlobalKey[compute.BackendService]("bs")
+//	bs, err := cloud.TypedBackendServices(gce).Get(ctx, key)
+//
+//	// Does not compile: RegionBackendServices needs a regional key.
+//	bs, err := cloud.TypedRegionBackendServices(gce).Get(ctx, key)
+type TypedService[T any, S meta.Scope] struct {
+	name   string
+	get    func(context.Context, *meta.Key, ...Option) (*T, error)
+	insert func(context.Context, *meta.Key, *T, ...Option) error
+	delete func(context.Context, *meta.Key, ...Option) error
+}
+
+// Get the object named by key.
+func (s *TypedService[T, S]) Get(ctx context.Context, key meta.TypedKey[T, S], options ...Option) (*T, error) {
+	if s.get == nil {
+		return nil, fmt.Errorf("%s does not support Get", s.name)
+	}
+	return s.get(ctx, key.Key(), options...)
+}
+
+// Insert obj under key.
+func (s *TypedService[T, S]) Insert(ctx context.Context, key meta.TypedKey[T, S], obj *T, options ...Option) error {
+	if s.insert == nil {
+		return fmt.Errorf("%s does not support Insert", s.name)
+	}
+	return s.insert(ctx, key.Key(), obj, options...)
+}
+
+// Delete the object named by key.
+func (s *TypedService[T, S]) Delete(ctx context.Context, key meta.TypedKey[T, S], options ...Option) error {
+	if s.delete == nil {
+		return fmt.Errorf("%s does not support Delete", s.name)
+	}
+	return s.delete(ctx, key.Key(), options...)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	alpha "google.golang.org/api/compute/v0.alpha"
+	ga "google.golang.org/api/compute/v1"
+)
+
+func TestTypedService(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"proj"})
+
+	key := meta.TypedRegionalKey[ga.BackendService]("bs", "us-central1")
+	if got := key.Key(); *got != *meta.RegionalKey("bs", "us-central1") {
+		t.Errorf("key.Key() = %v, want %v", got, meta.RegionalKey("bs", "us-central1"))
+	}
+
+	svc := TypedRegionBackendServices(mock)
+	if err := svc.Insert(ctx, key, &ga.BackendService{Name: "bs", Description: "desc"}); err != nil {
+		t.Fatalf("Insert(%v) = %v", key, err)
+	}
+	bs, err := svc.Get(ctx, key)
+	if err != nil || bs.Description != "desc" {
+		t.Errorf("Get(%v) = %+v, %v; want Description = desc", key, bs, err)
+	}
+	// The same resource through the alpha service.
+	alphaKey := meta.TypedRegionalKey[alpha.BackendService]("bs", "us-central1")
+	if _, err := TypedAlphaRegionBackendServices(mock).Get(ctx, alphaKey); err != nil {
+		t.Errorf("Alpha Get(%v) = _, %v", alphaKey, err)
+	}
+	if err := svc.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete(%v) = %v", key, err)
+	}
+
+	// Read-only services do not support Insert.
+	zoneKey := meta.TypedGlobalKey[ga.Zone]("us-central1-b")
+	if err := TypedZones(mock).Insert(ctx, zoneKey, &ga.Zone{}); err == nil {
+		t.Errorf("Zones Insert() = nil, want error")
+	}
+}
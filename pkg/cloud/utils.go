@@ -48,6 +48,45 @@ type ResourceID struct {
 	Key      *meta.Key
 }
 
+// NewSubResourceID returns the ResourceID for the entity named by sub in
+// parent, e.g. an endpoint of a NetworkEndpointGroup. The ResourceID can be
+// used to identify the entity like any other resource (e.g. as an rgraph
+// node). The Resource is "<parent resource>/<kind>" and the key is
+// sub.Key().
+func NewSubResourceID(parent *ResourceID, sub *meta.SubKey) *ResourceID {
+	return &ResourceID{
+		ProjectID: parent.ProjectID,
+		APIGroup:  parent.APIGroup,
+		Resource:  parent.Resource + "/" + sub.Kind,
+		Key:       sub.Key(),
+	}
+}
+
+// IsSubResource is true if the ResourceID was created by NewSubResourceID.
+func (r *ResourceID) IsSubResource() bool {
+	return strings.Contains(r.Resource, "/")
+}
+
+// SubResource returns the parent and the SubKey of a ResourceID created by
+// NewSubResourceID.
+func (r *ResourceID) SubResource() (*ResourceID, *meta.SubKey, error) {
+	i := strings.Index(r.Resource, "/")
+	if i < 0 || r.Key == nil {
+		return nil, nil, fmt.Errorf("%v is not a sub-resource", r)
+	}
+	sub, err := meta.ParseSubKey(r.Resource[i+1:], r.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent := &ResourceID{
+		ProjectID: r.ProjectID,
+		APIGroup:  r.APIGroup,
+		Resource:  r.Resource[:i],
+		Key:       &sub.Parent,
+	}
+	return parent, sub, nil
+}
+
 // Equal returns true if two resource IDs are equal.
 func (r *ResourceID) Equal(other *ResourceID) bool {
 	switch {
@@ -110,6 +149,12 @@ func (r *ResourceID) ResourcePath() string {
 	return ResourcePath(r.Resource, r.Key)
 }
 
+// LocationResourceName returns the location-scoped name of the resource, as
+// used by the networkservices API.
+func (r *ResourceID) LocationResourceName() string {
+	return LocationResourceName(r.ProjectID, r.Resource, r.Key)
+}
+
 // SelfLink returns a URL representing the resource and defaults to Compute API
 // Group if no API Group is specified.
 func (r *ResourceID) SelfLink(ver meta.Version) string {
@@ -156,6 +201,10 @@ var apiGroupRegex = regexp.MustCompile(`([a-z]*)(\.googleapis\.com)?\/(alpha|bet
 //	[https://<apigroup>.googleapis.com/<ver>]/projects/<proj>/global/<res>/<name>
 //	[https://<apigroup>.googleapis.com/<ver>]/projects/<proj>/regions/<region>/<res>/<name>
 //	[https://<apigroup>.googleapis.com/<ver>]/projects/<proj>/zones/<zone>/<res>/<name>
+//	[https://<apigroup>.googleapis.com/<ver>]/projects/<proj>/locations/<location>/<res>/<name>
+//
+// Location-scoped URLs without an API Group are assumed to be
+// networkservices.
 //
 // Note that ParseResourceURL can't round trip partial paths that do not
 // include an API Group.
@@ -241,6 +290,16 @@ func parseURL(url string, apiGroup meta.APIGroup) (*ResourceID, error) {
 		default:
 			return nil, errNotValid
 		}
+	case "locations":
+		if len(scopedName) != 4 {
+			return nil, errNotValid
+		}
+		if ret.APIGroup == "" {
+			ret.APIGroup = meta.APIGroupNetworkServices
+		}
+		ret.Resource = scopedName[2]
+		ret.Key = meta.LocationKey(scopedName[3], scopedName[1])
+		return ret, nil
 	}
 	return nil, errNotValid
 }
@@ -287,6 +346,13 @@ func RelativeResourceName(project, resource string, key *meta.Key) string {
 	}
 }
 
+// LocationResourceName returns the name of a resource in a location-scoped
+// API such as networkservices.
+// Example: projects/my-project/locations/global/meshes/my-mesh
+func LocationResourceName(project, resource string, key *meta.Key) string {
+	return fmt.Sprintf("projects/%s/locations/%s/%s/%s", project, key.Location(), resource, key.Name)
+}
+
 // SelfLink returns a URL representing the resource and assumes Compute API Group.
 // Deprecated: Use SelfLinkWithGroup instead
 func SelfLink(ver meta.Version, project, resource string, key *meta.Key) string {
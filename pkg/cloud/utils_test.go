@@ -225,6 +225,18 @@ func TestParseResourceURL(t *testing.T) {
 			"https://compute.googleapis.com/compute/v1/projects/some-gce-project/regions/us-central1/backendServices/bs1",
 			&ResourceID{"some-gce-project", meta.APIGroupCompute, "backendServices", meta.RegionalKey("bs1", "us-central1")},
 		},
+		{
+			"https://networkservices.googleapis.com/v1beta1/projects/some-gce-project/locations/global/meshes/mesh1",
+			&ResourceID{"some-gce-project", meta.APIGroupNetworkServices, "meshes", meta.GlobalKey("mesh1")},
+		},
+		{
+			"projects/some-gce-project/locations/global/tcpRoutes/route1",
+			&ResourceID{"some-gce-project", meta.APIGroupNetworkServices, "tcpRoutes", meta.GlobalKey("route1")},
+		},
+		{
+			"projects/some-gce-project/locations/us-central1/gateways/gw1",
+			&ResourceID{"some-gce-project", meta.APIGroupNetworkServices, "gateways", meta.RegionalKey("gw1", "us-central1")},
+		},
 	} {
 		t.Run(tc.in, func(t *testing.T) {
 			r, err := ParseResourceURL(tc.in)
@@ -255,6 +267,8 @@ func TestParseResourceURL(t *testing.T) {
 		"projects/some-gce-project/regions/us-central1/res",
 		"projects/some-gce-project/zones/us-central1-c/res",
 		"projects/some-gce-project/zones/us-central1-c/res/name/extra",
+		"projects/some-gce-project/locations/global/meshes",
+		"projects/some-gce-project/locations/global/meshes/mesh1/extra",
 	} {
 		r, err := ParseResourceURL(tc)
 		if err == nil {
@@ -309,6 +323,48 @@ func TestCopyVisJSON(t *testing.T) {
 	}
 }
 
+func TestLocationResourceName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		key  *meta.Key
+		want string
+	}{
+		{meta.GlobalKey("m"), "projects/proj1/locations/global/meshes/m"},
+		{meta.RegionalKey("m", "us-central1"), "projects/proj1/locations/us-central1/meshes/m"},
+	} {
+		id := &ResourceID{"proj1", meta.APIGroupNetworkServices, "meshes", tc.key}
+		if got := id.LocationResourceName(); got != tc.want {
+			t.Errorf("ResourceID{%+v}.LocationResourceName() = %q, want %q", id, got, tc.want)
+		}
+		parsed, err := ParseResourceURL(tc.want)
+		if err != nil || !parsed.Equal(id) {
+			t.Errorf("ParseResourceURL(%q) = %+v, %v; want %+v, nil", tc.want, parsed, err, id)
+		}
+	}
+}
+
+func TestSubResourceID(t *testing.T) {
+	t.Parallel()
+
+	parent := NewNetworkEndpointGroupsResourceID("proj1", "us-central1-b", "neg")
+	sub := meta.NetworkEndpointSubKey(parent.Key, "vm", "10.0.0.1", 80)
+	id := NewSubResourceID(parent, sub)
+	if !id.IsSubResource() || parent.IsSubResource() {
+		t.Errorf("IsSubResource() = %t, parent.IsSubResource() = %t; want true, false", id.IsSubResource(), parent.IsSubResource())
+	}
+	if id.Equal(parent) || id.MapKey() == parent.MapKey() {
+		t.Errorf("sub-resource %v is equal to the parent %v", id, parent)
+	}
+	gotParent, gotSub, err := id.SubResource()
+	if err != nil || !gotParent.Equal(parent) || *gotSub != *sub {
+		t.Errorf("SubResource() = %v, %v, %v; want %v, %v, nil", gotParent, gotSub, err, parent, sub)
+	}
+	if _, _, err := parent.SubResource(); err == nil {
+		t.Errorf("parent.SubResource() = _, _, nil; want error")
+	}
+}
+
 func TestResourceIdSelfLink(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,317 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vcr implements an http.RoundTripper that records the HTTP
+// interactions with the GCE API to a fixture file (a "cassette") and replays
+// them later without network access or credentials.
+//
+// Recording:
+//
+//	rec, err := vcr.New(&vcr.Config{
+//		Path:      "testdata/cassette.json",
+//		Mode:      vcr.Record,
+//		Transport: authClient.Transport,
+//		Replacer:  strings.NewReplacer("my-real-project", "vcr-project"),
+//	})
+//	svc, err := cloud.NewService(ctx, rec.Client(), ...)
+//	// ... run the tests ...
+//	err = rec.Save()
+//
+// Replay is the same with Mode: vcr.Replay. The Replacer is applied to the
+// recorded URLs and bodies to remove identifying information such as the
+// project ID; the code under test must use the replaced values during replay.
+// Request headers are never recorded. Review the fixtures before checking them
+// in as the Replacer only removes the values it is given.
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Mode of the Recorder.
+type Mode int
+
+const (
+	// Replay serves requests from the cassette. No requests are sent to the
+	// network.
+	Replay Mode = iota
+	// Record sends requests to the network and saves the interactions in the
+	// cassette.
+	Record
+)
+
+// String implements Stringer.
+func (m Mode) String() string {
+	switch m {
+	case Replay:
+		return "replay"
+	case Record:
+		return "record"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ParseMode parses the value returned by Mode.String().
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "replay":
+		return Replay, nil
+	case "record":
+		return Record, nil
+	}
+	return Replay, fmt.Errorf("vcr: invalid mode %q", s)
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode  int    `json:"statusCode"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Interaction is a request and the response received for it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the contents of a fixture file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Config for the Recorder.
+type Config struct {
+	// Path of the cassette file.
+	Path string
+	// Mode of operation.
+	Mode Mode
+	// Transport used to send requests in Record mode. If nil,
+	// http.DefaultTransport is used.
+	Transport http.RoundTripper
+	// Replacer is applied to the URLs and bodies of the interactions before
+	// they are recorded. May be nil.
+	Replacer *strings.Replacer
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions. It
+// is safe for concurrent use.
+//
+// During replay, a request is matched to the first unused interaction with
+// the same method and URL, so concurrent requests for different resources may
+// be issued in a different order than they were recorded. Request bodies are
+// not compared.
+type Recorder struct {
+	cfg Config
+
+	lock     sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New returns a new Recorder. In Replay mode, the cassette is loaded from
+// cfg.Path.
+func New(cfg *Config) (*Recorder, error) {
+	r := &Recorder{cfg: *cfg}
+	if r.cfg.Transport == nil {
+		r.cfg.Transport = http.DefaultTransport
+	}
+	switch cfg.Mode {
+	case Replay:
+		b, err := os.ReadFile(cfg.Path)
+		if err != nil {
+			return nil, fmt.Errorf("vcr: %w", err)
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, fmt.Errorf("vcr: cannot parse cassette %q: %w", cfg.Path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	case Record:
+	default:
+		return nil, fmt.Errorf("vcr: invalid mode %v", cfg.Mode)
+	}
+	return r, nil
+}
+
+// Client returns an http.Client that uses the Recorder.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing in Replay mode.
+func (r *Recorder) Save() error {
+	if r.cfg.Mode != Record {
+		return nil
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(&r.cassette); err != nil {
+		return fmt.Errorf("vcr: %w", err)
+	}
+	if err := os.WriteFile(r.cfg.Path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("vcr: %w", err)
+	}
+	return nil
+}
+
+// Unused returns the interactions in the cassette that were not replayed.
+func (r *Recorder) Unused() []*Interaction {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var ret []*Interaction
+	for i, used := range r.used {
+		if !used {
+			ret = append(ret, r.cassette.Interactions[i])
+		}
+	}
+	return ret
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.cfg.Mode == Record {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request, send a clone with a copy of
+	// the body instead.
+	out := req.Clone(req.Context())
+	reqBody, err := readRequestBody(req, out)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.cfg.Transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.sanitize(req.URL.String()),
+			Body:   r.sanitize(reqBody),
+		},
+		Response: Response{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        r.sanitize(respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	url := req.URL.String()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.URL != url {
+			continue
+		}
+		r.used[i] = true
+		resp := &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}
+		if in.Response.ContentType != "" {
+			resp.Header.Set("Content-Type", in.Response.ContentType)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("vcr: no recorded interaction for %s %s", req.Method, url)
+}
+
+func (r *Recorder) sanitize(s string) string {
+	if r.cfg.Replacer == nil {
+		return s
+	}
+	return r.cfg.Replacer.Replace(s)
+}
+
+// readRequestBody returns the body of req and sets the body of out (a clone
+// of req) so that it can be sent. The body of req is read from GetBody if
+// possible, otherwise it is consumed.
+func readRequestBody(req, out *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+	if req.GetBody == nil {
+		return readBody(&out.Body)
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", fmt.Errorf("vcr: %w", err)
+	}
+	return readBody(&body)
+}
+
+// readBody reads *body and replaces it with a copy so that it can be read
+// again.
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	b, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return "", fmt.Errorf("vcr: %w", err)
+	}
+	*body = io.NopCloser(bytes.NewReader(b))
+	return string(b), nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcr
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/fakeserver"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	ga "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// exercise runs a sequence of calls using client against the given project.
+func exercise(t *testing.T, client *http.Client, project string) {
+	t.Helper()
+
+	ctx := context.Background()
+	svc, err := cloud.NewService(ctx, client, &cloud.SingleProjectRouter{ID: project}, &cloud.NopRateLimiter{})
+	if err != nil {
+		t.Fatalf("NewService() = %v", err)
+	}
+	theCloud := cloud.NewGCE(svc)
+
+	key := meta.GlobalKey("addr")
+	if err := theCloud.GlobalAddresses().Insert(ctx, key, &ga.Address{Name: key.Name}); err != nil {
+		t.Fatalf("Insert() = %v", err)
+	}
+	addr, err := theCloud.GlobalAddresses().Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() = _, %v", err)
+	}
+	if want := cloud.SelfLink(meta.VersionGA, project, "addresses", key); addr.SelfLink != want {
+		t.Errorf("SelfLink = %q, want %q", addr.SelfLink, want)
+	}
+	if l, err := theCloud.GlobalAddresses().List(ctx, filter.None); err != nil || len(l) != 1 {
+		t.Errorf("List() = %v, %v; want 1 item", l, err)
+	}
+	if err := theCloud.GlobalAddresses().Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() = %v", err)
+	}
+	_, err = theCloud.GlobalAddresses().Get(ctx, key)
+	if gerr, ok := err.(*googleapi.Error); !ok || gerr.Code != http.StatusNotFound {
+		t.Errorf("Get() after Delete() = %v, want %d", err, http.StatusNotFound)
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	mock := cloud.NewMockGCE(&cloud.SingleProjectRouter{ID: "real-project"})
+	srv := fakeserver.New(mock)
+	defer srv.Close()
+
+	rec, err := New(&Config{
+		Path:      path,
+		Mode:      Record,
+		Transport: srv.Client().Transport,
+		Replacer:  strings.NewReplacer("real-project", "vcr-project"),
+	})
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	exercise(t, rec.Client(), "real-project")
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save() = %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "real-project") {
+		t.Errorf("cassette contains the project ID:\n%s", b)
+	}
+
+	// Replay without the server.
+	srv.Close()
+	rec, err = New(&Config{Path: path, Mode: Replay})
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	exercise(t, rec.Client(), "vcr-project")
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Errorf("Unused() = %v, want none", unused)
+	}
+
+	// All interactions have been used.
+	resp, err := rec.Client().Get("https://compute.googleapis.com/compute/v1/projects/vcr-project/global/addresses/addr?alt=json&prettyPrint=false")
+	if err == nil {
+		resp.Body.Close()
+		t.Errorf("Get() of unrecorded request = nil, want error")
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRecordRequestUnmodified(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"name":"addr"}`
+	for _, tc := range []struct {
+		name    string
+		getBody bool
+	}{
+		{name: "GetBody", getBody: true},
+		{name: "no GetBody", getBody: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var sent string
+			rec, err := New(&Config{
+				Path: filepath.Join(t.TempDir(), "cassette.json"),
+				Mode: Record,
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					b, err := io.ReadAll(req.Body)
+					if err != nil {
+						return nil, err
+					}
+					sent = string(b)
+					return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+				}),
+			})
+			if err != nil {
+				t.Fatalf("New() = %v", err)
+			}
+
+			req, err := http.NewRequest("POST", "https://compute.googleapis.com/compute/v1/projects/p/global/addresses", strings.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tc.getBody {
+				req.GetBody = nil
+			}
+			reqBody := req.Body
+			resp, err := rec.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() = %v", err)
+			}
+			resp.Body.Close()
+
+			if req.Body != reqBody {
+				t.Errorf("RoundTrip() replaced the body of the request")
+			}
+			if sent != body {
+				t.Errorf("sent body = %q, want %q", sent, body)
+			}
+			if got := rec.cassette.Interactions[0].Request.Body; got != body {
+				t.Errorf("recorded body = %q, want %q", got, body)
+			}
+		})
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	t.Parallel()
+
+	for _, m := range []Mode{Replay, Record} {
+		got, err := ParseMode(m.String())
+		if err != nil || got != m {
+			t.Errorf("ParseMode(%q) = %v, %v; want %v, nil", m.String(), got, err, m)
+		}
+	}
+	if _, err := ParseMode("invalid"); err == nil {
+		t.Errorf("ParseMode(invalid) = _, nil; want error")
+	}
+}
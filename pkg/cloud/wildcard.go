@@ -0,0 +1,135 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+)
+
+// wildcardFilter returns the filter selecting the objects named by key.
+func wildcardFilter(key *meta.Key) (*filter.F, error) {
+	if !key.IsWildcard() {
+		return nil, fmt.Errorf("%v is not a wildcard key", key)
+	}
+	if err := key.ValidateName(); err != nil {
+		return nil, err
+	}
+	return filter.Regexp("name", "^"+key.Name+"$"), nil
+}
+
+// ListWildcard returns the objects named by the wildcard key (see
+// meta.AllRegionsKey and meta.AllZonesKey) in all regions or zones using a
+// single AggregatedList call. The result is keyed by the concrete key of each
+// object.
+//
+// Example:
+//
+//	frs, err := ListWildcard(ctx, meta.AllRegionsKey("my-addr"), gce.Addresses().AggregatedList)
+func ListWildcard[T any](
+	ctx context.Context,
+	key *meta.Key,
+	aggregatedList func(context.Context, *filter.F, ...Option) (map[string][]*T, error),
+	options ...Option,
+) (map[meta.Key]*T, error) {
+	fl, err := wildcardFilter(key)
+	if err != nil {
+		return nil, fmt.Errorf("ListWildcard: %w", err)
+	}
+	all, err := aggregatedList(ctx, fl, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[meta.Key]*T{}
+	for scope, objs := range all {
+		var k *meta.Key
+		switch {
+		case key.Type() == meta.Regional && strings.HasPrefix(scope, "regions/"):
+			k = meta.RegionalKey(key.Name, strings.TrimPrefix(scope, "regions/"))
+		case key.Type() == meta.Zonal && strings.HasPrefix(scope, "zones/"):
+			k = meta.ZonalKey(key.Name, strings.TrimPrefix(scope, "zones/"))
+		default:
+			continue
+		}
+		for _, obj := range objs {
+			ret[*k] = obj
+		}
+	}
+	return ret, nil
+}
+
+// ListWildcardFanOut is the same as ListWildcard for services without
+// AggregatedList. It calls list for each of the regions or zones in scopes
+// concurrently.
+//
+// Example:
+//
+//	bss, err := ListWildcardFanOut(ctx, meta.AllRegionsKey("my-bs"), regions, gce.RegionBackendServices().List)
+func ListWildcardFanOut[T any](
+	ctx context.Context,
+	key *meta.Key,
+	scopes []string,
+	list func(context.Context, string, *filter.F, ...Option) ([]*T, error),
+	options ...Option,
+) (map[meta.Key]*T, error) {
+	fl, err := wildcardFilter(key)
+	if err != nil {
+		return nil, fmt.Errorf("ListWildcardFanOut: %w", err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		ret  = map[meta.Key]*T{}
+		errs []error
+	)
+	for _, scope := range scopes {
+		scope := scope
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			objs, err := list(ctx, scope, fl, options...)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", scope, err))
+				return
+			}
+			k := meta.RegionalKey(key.Name, scope)
+			if key.Type() == meta.Zonal {
+				k = meta.ZonalKey(key.Name, scope)
+			}
+			for _, obj := range objs {
+				ret[*k] = obj
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("ListWildcardFanOut: %w", errors.Join(errs...))
+	}
+	return ret, nil
+}
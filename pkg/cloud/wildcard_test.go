@@ -0,0 +1,83 @@
+/*
+Copyright 2024 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	ga "google.golang.org/api/compute/v1"
+)
+
+func TestListWildcard(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	mock := NewMockGCE(&SingleProjectRouter{"proj"})
+
+	for _, key := range []*meta.Key{
+		meta.RegionalKey("addr", "us-central1"),
+		meta.RegionalKey("addr", "europe-west1"),
+		meta.RegionalKey("addr-other", "us-central1"),
+	} {
+		if err := mock.Addresses().Insert(ctx, key, &ga.Address{Name: key.Name}); err != nil {
+			t.Fatalf("Insert(%v) = %v", key, err)
+		}
+	}
+	want := []meta.Key{
+		*meta.RegionalKey("addr", "europe-west1"),
+		*meta.RegionalKey("addr", "us-central1"),
+	}
+
+	keysOf := func(m map[meta.Key]*ga.Address) []meta.Key {
+		var ret []meta.Key
+		for k := range m {
+			ret = append(ret, k)
+		}
+		sort.Slice(ret, func(i, j int) bool { return ret[i].String() < ret[j].String() })
+		return ret
+	}
+
+	got, err := ListWildcard(ctx, meta.AllRegionsKey("addr"), mock.Addresses().AggregatedList)
+	if err != nil {
+		t.Fatalf("ListWildcard() = _, %v", err)
+	}
+	if diff := cmp.Diff(keysOf(got), want); diff != "" {
+		t.Errorf("ListWildcard(): -got,+want: %s", diff)
+	}
+
+	got, err = ListWildcardFanOut(ctx, meta.AllRegionsKey("addr"), []string{"us-central1", "europe-west1", "asia-east1"}, mock.Addresses().List)
+	if err != nil {
+		t.Fatalf("ListWildcardFanOut() = _, %v", err)
+	}
+	if diff := cmp.Diff(keysOf(got), want); diff != "" {
+		t.Errorf("ListWildcardFanOut(): -got,+want: %s", diff)
+	}
+
+	// Zonal wildcard keys do not match regional resources.
+	got, err = ListWildcard(ctx, meta.AllZonesKey("addr"), mock.Addresses().AggregatedList)
+	if err != nil || len(got) != 0 {
+		t.Errorf("ListWildcard(AllZonesKey) = %v, %v; want empty, nil", got, err)
+	}
+
+	if _, err := ListWildcard(ctx, meta.RegionalKey("addr", "us-central1"), mock.Addresses().AggregatedList); err == nil {
+		t.Errorf("ListWildcard(non-wildcard key) = _, nil; want error")
+	}
+}